@@ -0,0 +1,27 @@
+/*
+Outil d'administration de la Dead Letter Queue (DLQ) du système PubSub.
+
+Ceci est le point d'entrée historique de la commande « pubsub dlq ».
+Construction: go build -tags kafka -o dlqctl ./cmd/dlqctl
+
+Usage:
+
+	dlqctl replay [options]
+	dlqctl browse [options]
+	dlqctl reprocess [options]
+
+Les options de configuration partagées (--config, --kafka.broker, --dlq.topic...)
+sont acceptées avant ou après la commande.
+*/
+package main
+
+import (
+	"os"
+
+	"github.com/agbruneau/PubSub/internal/cli"
+)
+
+// main exécute la commande dlq avec les arguments du binaire.
+func main() {
+	os.Exit(cli.Run("dlq", os.Args[1:]))
+}
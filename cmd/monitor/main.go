@@ -1,121 +1,48 @@
 /*
 Point d'entrée du moniteur pour le système PubSub de démonstration Kafka.
 
-Ceci est le point d'entrée principal pour le binaire du moniteur de logs TUI.
+Ceci est le point d'entrée historique du moniteur de logs TUI, équivalent à « pubsub monitor ».
 Construction: go build -o monitor.exe ./cmd/monitor
-*/
-package main
-
-import (
-	"fmt"
-	"os"
-	"time"
-
-	"github.com/agbruneau/PubSub/internal/config"
-	"github.com/agbruneau/PubSub/internal/monitor"
-	"github.com/agbruneau/PubSub/pkg/models"
-	ui "github.com/gizak/termui/v3"
-)
-
-// main est la fonction principale qui initialise et lance le moniteur TUI.
-// Elle configure l'interface utilisateur, lance la surveillance des fichiers de logs en arrière-plan,
-// et gère la boucle d'événements pour l'affichage et les interactions utilisateur.
-func main() {
-	if err := ui.Init(); err != nil {
-		fmt.Printf("Erreur lors de l'initialisation de l'UI: %v\n", err)
-		os.Exit(1)
-	}
-	defer ui.Close()
-
-	// Créer une instance du moniteur
-	mon := monitor.New()
 
-	// Canaux pour les logs et les événements
-	logChan := make(chan models.LogEntry, config.MonitorLogChannelBuffer)
-	eventChan := make(chan models.EventEntry, config.MonitorEventChannelBuffer)
+Options:
 
-	// Démarrer la surveillance des fichiers
-	go monitor.MonitorFile(config.TrackerLogFile, logChan, nil)
-	go monitor.MonitorFile(config.TrackerEventsFile, nil, eventChan)
+	--config              Fichier de configuration YAML, JSON (.json) ou TOML (.toml) (défaut: config.yaml).
+	--<chemin.yaml>       Surcharge d'un paramètre de configuration (ex.: --monitor.ui_update_ms 250).
+	                      Priorité: options > variables d'environnement > YAML > valeurs par défaut.
+	--log-file            Fichier de logs à surveiller (défaut: tracker.log_file ou TRACKER_LOG_FILE).
+	--events-file         Fichier d'événements à surveiller (défaut: tracker.events_file ou TRACKER_EVENTS_FILE).
+	--export-dir          Répertoire des graphiques exportés avec la touche x (défaut: exports).
+	--export-format       Format des graphiques exportés: svg ou png (défaut: svg).
+	--headless            N'initialise pas l'interface TUI et affiche un résumé périodique.
+	--summary-interval    Intervalle entre deux résumés en mode headless (défaut: 10s).
+	--output              Fichier de destination des résumés (défaut: sortie standard).
+	--record              Enregistre les entrées traitées (avec leur horodatage) dans un fichier de session.
+	--ingest-history      Pré-remplit les métriques avec le contenu existant et les segments rotatifs (.gz inclus)
+	                      avant le suivi en direct (défaut: monitor.ingest_history).
 
-	// Traiter les logs et les événements
-	go func() {
-		for {
-			select {
-			case log := <-logChan:
-				mon.ProcessLog(log)
-			case event := <-eventChan:
-				mon.ProcessEvent(event)
-			}
-		}
-	}()
+Analyse hors-ligne:
 
-	// Créer les widgets
-	metricsTable := monitor.CreateMetricsTable()
-	healthDashboard := monitor.CreateHealthDashboard()
-	logList := monitor.CreateLogList()
-	eventList := monitor.CreateEventList()
-	mpsChart := monitor.CreateMessagesPerSecondChart()
-	srChart := monitor.CreateSuccessRateChart()
+	monitor analyze [--speed N] [--ui] <tracker.events> [tracker.log]
 
-	// Gérer le redimensionnement et les événements UI
-	uiEvents := ui.PollEvents()
-	ticker := time.NewTicker(config.MonitorUIUpdateInterval)
-	defer ticker.Stop()
+Relit des fichiers historiques complets (segments rotatifs et .gz inclus) sans suivi
+en direct, instantanément (--speed 0) ou en respectant la chronologie accélérée N fois.
 
-	mon.Metrics.StartTime = time.Now()
+Lecture d'une session enregistrée:
 
-	// Configuration initiale de la mise en page (layout)
-	// Nous définissons des rectangles statiques pour commencer
-	termWidth, termHeight := ui.TerminalDimensions()
-	// La hauteur de la grille est divisée en 3 sections:
-	// 1. Haut: Métriques et Santé (hauteur 9)
-	// 2. Milieu: Logs et Événements (hauteur 10)
-	// 3. Bas: Graphiques (reste de la hauteur)
+	monitor play [--speed N] <session.jsonl>
 
-	// Largeur divisée par 2 pour la plupart des éléments
-	midWidth := termWidth / 2
-
-	// Section 1
-	metricsTable.SetRect(0, 0, 50, 9)
-	healthDashboard.SetRect(50, 0, termWidth, 9)
-
-	// Section 2
-	logList.SetRect(0, 9, midWidth, 19)
-	eventList.SetRect(midWidth, 9, termWidth, 19)
-
-	// Section 3
-	mpsChart.SetRect(0, 19, midWidth, termHeight)
-	srChart.SetRect(midWidth, 19, termWidth, termHeight)
-
-	ui.Render(metricsTable, healthDashboard, logList, eventList, mpsChart, srChart)
+Rejoue une session enregistrée avec --record dans l'interface TUI, à vitesse réglable
+(touches < et >), pour des démonstrations reproductibles.
+*/
+package main
 
-	for {
-		select {
-		case e := <-uiEvents:
-			switch e.ID {
-			case "q", "<C-c>":
-				return
-			case "<Resize>":
-				payload := e.Payload.(ui.Resize)
-				termWidth = payload.Width
-				termHeight = payload.Height
-				midWidth = termWidth / 2
+import (
+	"os"
 
-				metricsTable.SetRect(0, 0, 50, 9)
-				healthDashboard.SetRect(50, 0, termWidth, 9)
-				logList.SetRect(0, 9, midWidth, 19)
-				eventList.SetRect(midWidth, 9, termWidth, 19)
-				mpsChart.SetRect(0, 19, midWidth, termHeight)
-				srChart.SetRect(midWidth, 19, termWidth, termHeight)
+	"github.com/agbruneau/PubSub/internal/cli"
+)
 
-				ui.Clear()
-				ui.Render(metricsTable, healthDashboard, logList, eventList, mpsChart, srChart)
-			}
-		case <-ticker.C:
-			mon.Metrics.Uptime = time.Since(mon.Metrics.StartTime)
-			mon.UpdateUI(metricsTable, healthDashboard, logList, eventList, mpsChart, srChart)
-			ui.Render(metricsTable, healthDashboard, logList, eventList, mpsChart, srChart)
-		}
-	}
+// main exécute la commande monitor avec les arguments du binaire.
+func main() {
+	os.Exit(cli.Run("monitor", os.Args[1:]))
 }
@@ -0,0 +1,33 @@
+/*
+Point d'entrée unique du système PubSub de démonstration Kafka.
+
+Construction: go build -o pubsub ./cmd/pubsub (ajouter -tags kafka pour les commandes Kafka)
+
+Usage:
+
+	pubsub produce [options]
+	pubsub track [options]
+	pubsub monitor [options]
+	pubsub monitor analyze [--speed N] [--ui] <tracker.events> [tracker.log]
+	pubsub monitor play [--speed N] <session.jsonl>
+	pubsub config [--init-config | --encrypt VALEUR | --print-schema | --print-config | --check-config]
+	pubsub dlq replay|browse|reprocess [options]
+	pubsub up [--kafka compose|docker|none] [--keep-kafka] [--no-monitor] [options]
+
+Chaque commande accepte les options de configuration partagées, avant ou après
+son nom : --config et une option par paramètre, nommée d'après son chemin YAML
+(ex.: --kafka.broker). pubsub help <commande> affiche les options d'une commande.
+Priorité: options > variables d'environnement > config.yaml (--config) > valeurs par défaut.
+*/
+package main
+
+import (
+	"os"
+
+	"github.com/agbruneau/PubSub/internal/cli"
+)
+
+// main exécute la commande désignée par le premier argument.
+func main() {
+	os.Exit(cli.Main(os.Args[1:]))
+}
@@ -0,0 +1,18 @@
+/*
+Package e2e contient la suite de tests de bout en bout du système PubSub.
+
+Les tests démarrent un broker Kafka réel dans un conteneur Docker, y exécutent
+le producteur et le tracker, puis vérifient les nombres de messages produits
+et consommés, le contenu de la piste d'audit, l'envoi des messages en échec
+vers la DLQ et la reprise des partitions lors d'un rééquilibrage du groupe de
+consommateurs.
+
+Ils requièrent Docker et sont exclus des tests habituels par les tags de
+compilation e2e et kafka:
+
+	go test -tags "e2e kafka" -v ./e2e
+
+Les tests sont ignorés si la commande docker est introuvable. L'image du
+broker peut être remplacée par la variable E2E_KAFKA_IMAGE.
+*/
+package e2e
@@ -0,0 +1,380 @@
+//go:build e2e && kafka
+// +build e2e,kafka
+
+package e2e
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/producer"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/agbruneau/PubSub/internal/tracker"
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// consumeTimeout est l'attente maximale de la consommation des messages attendus.
+const consumeTimeout = 60 * time.Second
+
+// uniqueName retourne un nom de topic ou de groupe propre au test.
+//
+// Paramètres:
+//   - t: Le test.
+//   - suffix: Le suffixe du nom.
+//
+// Retourne:
+//   - string: Le nom.
+func uniqueName(t *testing.T, suffix string) string {
+	name := strings.ToLower(strings.ReplaceAll(t.Name(), "/", "-"))
+	return fmt.Sprintf("e2e-%s-%d-%s", name, time.Now().UnixNano(), suffix)
+}
+
+// trackerConfig crée la configuration d'un tracker lisant un topic depuis son début.
+//
+// Paramètres:
+//   - t: Le test.
+//   - k: Le broker.
+//   - topic: Le topic consommé.
+//   - group: Le groupe de consommateurs.
+//
+// Retourne:
+//   - *tracker.Config: La configuration, avec ses journaux dans un répertoire temporaire.
+func trackerConfig(t *testing.T, k *kafkaContainer, topic, group string) *tracker.Config {
+	dir := t.TempDir()
+	cfg := tracker.NewConfig()
+	cfg.KafkaBroker = k.broker
+	cfg.Topic = topic
+	cfg.ConsumerGroup = group
+	cfg.LogFile = filepath.Join(dir, "tracker.log")
+	cfg.EventsFile = filepath.Join(dir, "tracker.events")
+	cfg.MetricsInterval = time.Hour
+	cfg.ReadTimeout = 200 * time.Millisecond
+	cfg.Properties["auto.offset.reset"] = "earliest"
+	return cfg
+}
+
+// startTracker initialise et démarre un tracker.
+//
+// Paramètres:
+//   - t: Le test.
+//   - cfg: La configuration du tracker.
+//
+// Retourne:
+//   - func(): Arrête le tracker, attend la fin de sa boucle et libère ses ressources (idempotente).
+func startTracker(t *testing.T, cfg *tracker.Config) func() {
+	t.Helper()
+	trk := tracker.New(cfg)
+	if err := trk.Initialize(); err != nil {
+		t.Fatalf("Initialisation du tracker impossible: %v", err)
+	}
+	done := make(chan struct{})
+	go func() {
+		trk.Run(context.Background())
+		close(done)
+	}()
+
+	stopped := false
+	stop := func() {
+		if stopped {
+			return
+		}
+		stopped = true
+		trk.Stop()
+		<-done
+		trk.Close()
+	}
+	t.Cleanup(stop)
+	return stop
+}
+
+// produceOrders publie des commandes avec le producteur, réparties entre les
+// partitions par leur identifiant.
+//
+// Paramètres:
+//   - t: Le test.
+//   - k: Le broker.
+//   - topic: Le topic de publication.
+//   - count: Le nombre de commandes.
+func produceOrders(t *testing.T, k *kafkaContainer, topic string, count int) {
+	t.Helper()
+	cfg := producer.NewConfig()
+	cfg.KafkaBroker = k.broker
+	cfg.Topic = topic
+	cfg.PartitionKey = string(models.PartitionByOrder)
+	prod := producer.New(cfg)
+	if err := prod.Initialize(); err != nil {
+		t.Fatalf("Initialisation du producteur impossible: %v", err)
+	}
+	defer prod.Close()
+	for i := 0; i < count; i++ {
+		if err := prod.ProduceOrder(); err != nil {
+			t.Fatalf("Publication de la commande %d impossible: %v", i+1, err)
+		}
+	}
+}
+
+// produceRaw publie un message brut.
+//
+// Paramètres:
+//   - t: Le test.
+//   - k: Le broker.
+//   - topic: Le topic de publication.
+//   - value: Le contenu du message.
+func produceRaw(t *testing.T, k *kafkaContainer, topic string, value []byte) {
+	t.Helper()
+	p, err := kafka.NewProducer(&kafka.ConfigMap{"bootstrap.servers": k.broker})
+	if err != nil {
+		t.Fatalf("Création du producteur impossible: %v", err)
+	}
+	defer p.Close()
+	deliveries := make(chan kafka.Event, 1)
+	if err := p.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Value:          value,
+	}, deliveries); err != nil {
+		t.Fatalf("Publication impossible: %v", err)
+	}
+	if m := (<-deliveries).(*kafka.Message); m.TopicPartition.Error != nil {
+		t.Fatalf("Publication refusée: %v", m.TopicPartition.Error)
+	}
+}
+
+// readEvents lit les entrées de la piste d'audit d'un tracker.
+//
+// Paramètres:
+//   - t: Le test.
+//   - path: Le fichier d'événements.
+//
+// Retourne:
+//   - []models.EventEntry: Les entrées (aucune si le fichier n'existe pas encore).
+func readEvents(t *testing.T, path string) []models.EventEntry {
+	t.Helper()
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("Lecture de %s impossible: %v", path, err)
+	}
+	defer file.Close()
+
+	var entries []models.EventEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry models.EventEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue // ligne en cours d'écriture
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// orderIDs retourne les identifiants des commandes désérialisées de la piste d'audit.
+//
+// Paramètres:
+//   - t: Le test.
+//   - entries: Les entrées de la piste d'audit.
+//
+// Retourne:
+//   - map[string]int: Le nombre d'entrées par identifiant de commande.
+func orderIDs(t *testing.T, entries []models.EventEntry) map[string]int {
+	t.Helper()
+	ids := make(map[string]int)
+	for _, entry := range entries {
+		if !entry.Deserialized {
+			continue
+		}
+		var order models.Order
+		if err := json.Unmarshal(entry.OrderFull, &order); err != nil {
+			t.Fatalf("Commande illisible dans la piste d'audit: %v", err)
+		}
+		ids[order.OrderID]++
+	}
+	return ids
+}
+
+// waitFor attend qu'une condition soit vraie.
+//
+// Paramètres:
+//   - t: Le test.
+//   - what: La description de la condition, pour le message d'échec.
+//   - cond: La condition.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(consumeTimeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Délai dépassé en attendant %s", what)
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
+// TestProduceAndTrack vérifie que chaque commande publiée par le producteur
+// est consommée une fois par le tracker et consignée dans la piste d'audit.
+func TestProduceAndTrack(t *testing.T) {
+	k := requireKafka(t)
+	const count = 25
+	topic := uniqueName(t, "orders")
+	k.createTopic(t, topic, 1)
+	cfg := trackerConfig(t, k, topic, uniqueName(t, "tracker"))
+	startTracker(t, cfg)
+
+	produceOrders(t, k, topic, count)
+
+	waitFor(t, fmt.Sprintf("%d entrées d'audit", count), func() bool {
+		return len(readEvents(t, cfg.EventsFile)) >= count
+	})
+	entries := readEvents(t, cfg.EventsFile)
+	if len(entries) != count {
+		t.Fatalf("Attendu %d entrées d'audit, reçu %d", count, len(entries))
+	}
+	for i, entry := range entries {
+		if !entry.Deserialized || entry.Error != "" {
+			t.Errorf("Entrée %d: attendu une commande désérialisée, reçu l'erreur %q", i, entry.Error)
+		}
+		if entry.KafkaTopic != topic || entry.KafkaOffset != int64(i) {
+			t.Errorf("Entrée %d: attendu %s@%d, reçu %s@%d", i, topic, i, entry.KafkaTopic, entry.KafkaOffset)
+		}
+		if entry.TraceID == "" || entry.CorrelationID == "" {
+			t.Errorf("Entrée %d: attendu le contexte de trace du producteur, reçu %+v", i, entry)
+		}
+	}
+	if ids := orderIDs(t, entries); len(ids) != count {
+		t.Errorf("Attendu %d commandes distinctes, reçu %d", count, len(ids))
+	}
+}
+
+// TestInvalidOrderToDLQ vérifie qu'une commande invalide est consignée en
+// échec puis publiée dans la DLQ, avec son contenu et son contexte d'échec.
+func TestInvalidOrderToDLQ(t *testing.T) {
+	k := requireKafka(t)
+	topic := uniqueName(t, "orders")
+	dlqTopic := topic + "-dlq"
+	tiers := []time.Duration{time.Second}
+	k.createTopic(t, topic, 1)
+	k.createTopic(t, dlqTopic, 1)
+	for _, retryTopic := range (retry.RetryTiers{Topic: topic, Delays: tiers}).Topics() {
+		k.createTopic(t, retryTopic, 1)
+	}
+
+	cfg := trackerConfig(t, k, topic, uniqueName(t, "tracker"))
+	cfg.RetryTiers = tiers
+	cfg.DLQTopic = dlqTopic
+	cfg.DLQConfirmTimeout = 10 * time.Second
+	cfg.DLQRecovery = time.Hour
+	cfg.ProducerProperties = config.DefaultConfig().ProducerProperties()
+	startTracker(t, cfg)
+
+	payload := []byte(`{"order_id":"e2e-invalid","sequence":1}`)
+	produceRaw(t, k, topic, payload)
+
+	dlq := newDLQReader(t, k, dlqTopic)
+	var failed retry.FailedMessage
+	waitFor(t, "le message dans la DLQ", func() bool {
+		msg, err := dlq.ReadMessage(500 * time.Millisecond)
+		if err != nil {
+			return false
+		}
+		if failed, err = retry.DecodeFailedMessage(msg.Value); err != nil {
+			t.Fatalf("Enveloppe DLQ invalide: %v", err)
+		}
+		return true
+	})
+	if failed.OriginalTopic != topic || failed.OriginalOffset != 0 {
+		t.Errorf("Attendu l'origine %s@0, reçu %s@%d", topic, failed.OriginalTopic, failed.OriginalOffset)
+	}
+	if string(failed.Payload) != string(payload) {
+		t.Errorf("Attendu le contenu %s, reçu %s", payload, failed.Payload)
+	}
+	if failed.LastError == "" || failed.ErrorClass == "" || failed.ConsumerGroup != cfg.ConsumerGroup {
+		t.Errorf("Attendu le contexte d'échec, reçu %+v", failed)
+	}
+
+	entries := readEvents(t, cfg.EventsFile)
+	if len(entries) == 0 || entries[0].Deserialized || entries[0].ErrorCode == "" {
+		t.Errorf("Attendu une entrée d'audit en échec avec son code d'erreur, reçu %+v", entries)
+	}
+}
+
+// newDLQReader crée un consommateur lisant un topic depuis son début.
+//
+// Paramètres:
+//   - t: Le test.
+//   - k: Le broker.
+//   - topic: Le topic.
+//
+// Retourne:
+//   - *kafka.Consumer: Le consommateur abonné, fermé à la fin du test.
+func newDLQReader(t *testing.T, k *kafkaContainer, topic string) *kafka.Consumer {
+	t.Helper()
+	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers": k.broker,
+		"group.id":          uniqueName(t, "dlq-reader"),
+		"auto.offset.reset": "earliest",
+	})
+	if err != nil {
+		t.Fatalf("Création du consommateur impossible: %v", err)
+	}
+	t.Cleanup(func() { consumer.Close() })
+	if err := consumer.SubscribeTopics([]string{topic}, nil); err != nil {
+		t.Fatalf("Abonnement à %s impossible: %v", topic, err)
+	}
+	return consumer
+}
+
+// TestRebalance vérifie qu'aucune commande n'est perdue quand un second
+// tracker rejoint le groupe, puis quand le premier le quitte : le tracker
+// restant reprend toutes les partitions.
+func TestRebalance(t *testing.T) {
+	k := requireKafka(t)
+	const partitions, batch = 4, 40
+	topic := uniqueName(t, "orders")
+	group := uniqueName(t, "tracker")
+	k.createTopic(t, topic, partitions)
+
+	first := trackerConfig(t, k, topic, group)
+	stopFirst := startTracker(t, first)
+	produceOrders(t, k, topic, batch)
+	waitFor(t, "la consommation du premier lot", func() bool {
+		return len(orderIDs(t, readEvents(t, first.EventsFile))) >= batch
+	})
+
+	second := trackerConfig(t, k, topic, group)
+	startTracker(t, second)
+	produceOrders(t, k, topic, batch)
+	waitFor(t, "la consommation du deuxième lot par les deux trackers", func() bool {
+		return len(orderIDs(t, append(readEvents(t, first.EventsFile), readEvents(t, second.EventsFile)...))) >= 2*batch
+	})
+
+	// Le premier tracker quitte le groupe : le second reprend ses partitions
+	stopFirst()
+	before := len(readEvents(t, second.EventsFile))
+	produceOrders(t, k, topic, batch)
+	waitFor(t, "la consommation du troisième lot par le tracker restant", func() bool {
+		return len(orderIDs(t, readEvents(t, second.EventsFile)[before:])) >= batch
+	})
+
+	all := append(readEvents(t, first.EventsFile), readEvents(t, second.EventsFile)...)
+	if ids := orderIDs(t, all); len(ids) != 3*batch {
+		t.Errorf("Attendu %d commandes distinctes, reçu %d", 3*batch, len(ids))
+	}
+	seen := make(map[int32]bool)
+	for _, entry := range readEvents(t, second.EventsFile)[before:] {
+		seen[entry.KafkaPartition] = true
+	}
+	if len(seen) != partitions {
+		t.Errorf("Attendu que le tracker restant lise les %d partitions, reçu %v", partitions, seen)
+	}
+}
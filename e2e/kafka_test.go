@@ -0,0 +1,180 @@
+//go:build e2e && kafka
+// +build e2e,kafka
+
+package e2e
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// defaultKafkaImage est l'image du broker, celle de docker-compose.yaml.
+const defaultKafkaImage = "confluentinc/cp-kafka:7.8.3"
+
+// kafkaStartTimeout est l'attente maximale de la disponibilité du broker.
+const kafkaStartTimeout = 2 * time.Minute
+
+// kafkaContainer est un broker Kafka KRaft à nœud unique exécuté dans Docker.
+type kafkaContainer struct {
+	name   string // Nom du conteneur.
+	broker string // Adresse du broker depuis l'hôte (localhost:port).
+}
+
+// broker est le conteneur partagé par les tests (nil si Docker est indisponible).
+var broker *kafkaContainer
+
+// skipReason explique pourquoi le broker n'a pas pu être démarré.
+var skipReason string
+
+// TestMain démarre le broker avant les tests et le supprime après.
+func TestMain(m *testing.M) {
+	var err error
+	broker, err = startKafka()
+	if err != nil {
+		skipReason = err.Error()
+		broker = nil
+	}
+	code := m.Run()
+	if broker != nil {
+		broker.remove()
+	}
+	os.Exit(code)
+}
+
+// requireKafka ignore le test si le broker n'a pas pu être démarré.
+//
+// Paramètres:
+//   - t: Le test.
+//
+// Retourne:
+//   - *kafkaContainer: Le broker.
+func requireKafka(t *testing.T) *kafkaContainer {
+	t.Helper()
+	if broker == nil {
+		t.Skipf("Broker Kafka indisponible: %s", skipReason)
+	}
+	return broker
+}
+
+// startKafka démarre un conteneur Kafka sur un port libre de l'hôte et attend
+// qu'il réponde.
+//
+// Retourne:
+//   - *kafkaContainer: Le conteneur démarré.
+//   - error: Une erreur si Docker est indisponible ou si le broker ne démarre pas.
+func startKafka() (*kafkaContainer, error) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return nil, fmt.Errorf("docker introuvable: %w", err)
+	}
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	image := os.Getenv("E2E_KAFKA_IMAGE")
+	if image == "" {
+		image = defaultKafkaImage
+	}
+	c := &kafkaContainer{
+		name:   fmt.Sprintf("pubsub-e2e-%d", os.Getpid()),
+		broker: fmt.Sprintf("localhost:%d", port),
+	}
+	// Le listener écoute sur le même port dans le conteneur et sur l'hôte,
+	// afin que l'adresse annoncée aux clients soit joignable depuis l'hôte.
+	_, err = docker("run", "-d", "--rm", "--name", c.name, "-p", fmt.Sprintf("%d:%d", port, port),
+		"-e", "KAFKA_NODE_ID=1",
+		"-e", "CLUSTER_ID=1L6g7nGhU-eAKfL--X25wo",
+		"-e", "KAFKA_PROCESS_ROLES=broker,controller",
+		"-e", "KAFKA_CONTROLLER_QUORUM_VOTERS=1@localhost:9093",
+		"-e", fmt.Sprintf("KAFKA_LISTENERS=PLAINTEXT://0.0.0.0:%d,CONTROLLER://0.0.0.0:9093", port),
+		"-e", "KAFKA_ADVERTISED_LISTENERS=PLAINTEXT://"+c.broker,
+		"-e", "KAFKA_CONTROLLER_LISTENER_NAMES=CONTROLLER",
+		"-e", "KAFKA_OFFSETS_TOPIC_REPLICATION_FACTOR=1",
+		"-e", "KAFKA_GROUP_INITIAL_REBALANCE_DELAY_MS=0",
+		"-e", "KAFKA_AUTO_CREATE_TOPICS_ENABLE=false",
+		image)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(kafkaStartTimeout)
+	for {
+		if _, err = c.topicsCommand("--list"); err == nil {
+			return c, nil
+		}
+		if time.Now().After(deadline) {
+			c.remove()
+			return nil, fmt.Errorf("broker indisponible après %s: %w", kafkaStartTimeout, err)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// createTopic crée un topic.
+//
+// Paramètres:
+//   - t: Le test.
+//   - topic: Le nom du topic.
+//   - partitions: Le nombre de partitions.
+func (c *kafkaContainer) createTopic(t *testing.T, topic string, partitions int) {
+	t.Helper()
+	if _, err := c.topicsCommand("--create", "--if-not-exists", "--topic", topic,
+		"--partitions", strconv.Itoa(partitions), "--replication-factor", "1"); err != nil {
+		t.Fatalf("Création du topic %s impossible: %v", topic, err)
+	}
+}
+
+// topicsCommand exécute l'outil kafka-topics dans le conteneur.
+//
+// Paramètres:
+//   - args: Les arguments de kafka-topics.
+//
+// Retourne:
+//   - string: La sortie de la commande.
+//   - error: Une erreur si la commande échoue.
+func (c *kafkaContainer) topicsCommand(args ...string) (string, error) {
+	return docker(append([]string{"exec", c.name, "kafka-topics", "--bootstrap-server", c.broker}, args...)...)
+}
+
+// remove arrête et supprime le conteneur.
+func (c *kafkaContainer) remove() {
+	docker("rm", "-f", c.name)
+}
+
+// docker exécute une commande docker.
+//
+// Paramètres:
+//   - args: Les arguments de la commande.
+//
+// Retourne:
+//   - string: La sortie standard de la commande.
+//   - error: Une erreur contenant la sortie d'erreur si la commande échoue.
+func docker(args ...string) (string, error) {
+	var stderr strings.Builder
+	cmd := exec.Command("docker", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// freePort retourne un port TCP libre de l'hôte.
+//
+// Retourne:
+//   - int: Le port.
+//   - error: Une erreur si aucun port ne peut être réservé.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,411 @@
+/*
+Package admin administers the Kafka cluster of the PubSub system: it creates,
+describes and deletes topics, lists and describes the consumer groups, and
+shows and resets their offsets (PlanReset), so that the demonstrations do not
+need the Kafka command-line tools.
+
+The cluster is reached through a Client, implemented with the Kafka admin
+client when built with the "kafka" tag (NewClient).
+*/
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Default topic settings of CreateTopics, those of the single-broker cluster of
+// the demonstration.
+const (
+	// DefaultPartitions is the default number of partitions of a created topic.
+	DefaultPartitions = 1
+	// DefaultReplicationFactor is the default replication factor of a created topic.
+	DefaultReplicationFactor = 1
+	// DefaultTimeout is the default wait for the cluster to answer a request.
+	DefaultTimeout = 10 * time.Second
+)
+
+var (
+	// ErrKafkaUnsupported is returned by NewClient without the "kafka" build tag.
+	ErrKafkaUnsupported = errors.New("admin requires the kafka build tag (use -tags kafka)")
+	// ErrTopicExists reports a topic that CreateTopics found already created.
+	ErrTopicExists = errors.New("topic already exists")
+	// ErrUnknownTopic reports a topic that does not exist.
+	ErrUnknownTopic = errors.New("unknown topic")
+	// ErrUnknownGroup reports a consumer group that does not exist.
+	ErrUnknownGroup = errors.New("unknown consumer group")
+)
+
+// TopicSpec describes a topic to create.
+type TopicSpec struct {
+	Name              string
+	Partitions        int
+	ReplicationFactor int
+	Config            map[string]string // Topic configuration (e.g., retention.ms), may be nil.
+}
+
+// TopicResult is the outcome of the creation or deletion of a topic.
+type TopicResult struct {
+	Topic string
+	Err   error // nil on success; wraps ErrTopicExists or ErrUnknownTopic when nothing was done.
+}
+
+// PartitionInfo describes a partition of a topic.
+type PartitionInfo struct {
+	ID       int32
+	Leader   int32   // Broker leading the partition (-1: offline).
+	Replicas []int32 // Brokers holding a replica.
+	ISR      []int32 // In-sync replicas.
+}
+
+// TopicInfo describes a topic.
+type TopicInfo struct {
+	Name       string
+	Partitions []PartitionInfo // In ascending partition order.
+	Err        error           // Wraps ErrUnknownTopic for a missing topic.
+}
+
+// ReplicationFactor returns the number of replicas of the first partition.
+//
+// Returns:
+//   - int: The replication factor (0 without partition).
+func (t TopicInfo) ReplicationFactor() int {
+	if len(t.Partitions) == 0 {
+		return 0
+	}
+	return len(t.Partitions[0].Replicas)
+}
+
+// GroupListing is a consumer group of the cluster.
+type GroupListing struct {
+	ID     string
+	State  string // e.g., Stable, Empty, PreparingRebalance.
+	Simple bool   // True for a group without membership (manual assignment).
+}
+
+// TopicPartition is a partition of a topic.
+type TopicPartition struct {
+	Topic     string
+	Partition int32
+}
+
+// String formats the partition as topic[partition].
+//
+// Returns:
+//   - string: The partition (e.g., "orders[2]").
+func (tp TopicPartition) String() string {
+	return fmt.Sprintf("%s[%d]", tp.Topic, tp.Partition)
+}
+
+// GroupMember is a member of a consumer group and its assignment.
+type GroupMember struct {
+	ClientID   string
+	ConsumerID string
+	Host       string
+	Assignment []TopicPartition // Assigned partitions, in topic then partition order.
+}
+
+// GroupDescription describes a consumer group and its members.
+type GroupDescription struct {
+	ID       string
+	State    string
+	Assignor string // Partition assignment strategy (e.g., range, cooperative-sticky).
+	Members  []GroupMember
+	Err      error // Wraps ErrUnknownGroup for a missing group.
+}
+
+// Client administers a Kafka cluster.
+type Client interface {
+	// CreateTopics creates topics; already existing topics are left untouched.
+	//
+	// Parameters:
+	//   - ctx: The request deadline.
+	//   - specs: The topics to create.
+	//
+	// Returns:
+	//   - []TopicResult: The outcome of each topic, in the order of specs.
+	//   - error: An error if the request fails as a whole.
+	CreateTopics(ctx context.Context, specs []TopicSpec) ([]TopicResult, error)
+
+	// DeleteTopics deletes topics.
+	//
+	// Parameters:
+	//   - ctx: The request deadline.
+	//   - names: The topics to delete.
+	//
+	// Returns:
+	//   - []TopicResult: The outcome of each topic, in the order of names.
+	//   - error: An error if the request fails as a whole.
+	DeleteTopics(ctx context.Context, names []string) ([]TopicResult, error)
+
+	// DescribeTopics describes topics.
+	//
+	// Parameters:
+	//   - ctx: The request deadline.
+	//   - names: The topics to describe (empty: every topic but the internal ones).
+	//
+	// Returns:
+	//   - []TopicInfo: The topics, in the order of names or by name.
+	//   - error: An error if the cluster is unreachable.
+	DescribeTopics(ctx context.Context, names []string) ([]TopicInfo, error)
+
+	// ListGroups lists the consumer groups.
+	//
+	// Parameters:
+	//   - ctx: The request deadline.
+	//
+	// Returns:
+	//   - []GroupListing: The groups, by identifier.
+	//   - error: An error if the cluster is unreachable.
+	ListGroups(ctx context.Context) ([]GroupListing, error)
+
+	// DescribeGroups describes consumer groups and the assignment of their members.
+	//
+	// Parameters:
+	//   - ctx: The request deadline.
+	//   - ids: The groups to describe.
+	//
+	// Returns:
+	//   - []GroupDescription: The groups, in the order of ids.
+	//   - error: An error if the request fails as a whole.
+	DescribeGroups(ctx context.Context, ids []string) ([]GroupDescription, error)
+
+	// GroupOffsets returns the position of a consumer group on every partition of topics.
+	//
+	// Parameters:
+	//   - ctx: The request deadline.
+	//   - group: The consumer group.
+	//   - topics: The topics consumed by the group.
+	//
+	// Returns:
+	//   - []PartitionOffsets: The positions, in topic then partition order.
+	//   - error: An error if a topic is unknown or the cluster is unreachable.
+	GroupOffsets(ctx context.Context, group string, topics []string) ([]PartitionOffsets, error)
+
+	// OffsetsForTime returns the offset of the first message produced at or after t on each partition.
+	//
+	// Parameters:
+	//   - ctx: The request deadline.
+	//   - partitions: The partitions.
+	//   - t: The time.
+	//
+	// Returns:
+	//   - map[TopicPartition]int64: The offsets (negative for a partition without such message).
+	//   - error: An error if the cluster is unreachable.
+	OffsetsForTime(ctx context.Context, partitions []TopicPartition, t time.Time) (map[TopicPartition]int64, error)
+
+	// CommitOffsets commits the targets of resets for a consumer group, which
+	// must have no active member.
+	//
+	// Parameters:
+	//   - ctx: The request deadline.
+	//   - group: The consumer group.
+	//   - resets: The moves of the group.
+	//
+	// Returns:
+	//   - error: An error if an offset cannot be committed.
+	CommitOffsets(ctx context.Context, group string, resets []OffsetReset) error
+
+	// Close releases the connections to the cluster.
+	Close()
+}
+
+// WriteTopicResults writes the outcome of a creation or deletion, one line per topic.
+//
+// Parameters:
+//   - w: The destination.
+//   - action: The past participle of the action (e.g., "created").
+//   - results: The outcomes.
+//
+// Returns:
+//   - int: The number of failed topics (existing or unknown topics are not failures).
+//   - error: A write error.
+func WriteTopicResults(w io.Writer, action string, results []TopicResult) (int, error) {
+	failed := 0
+	for _, r := range results {
+		var err error
+		switch {
+		case r.Err == nil:
+			_, err = fmt.Fprintf(w, "✅ %s %s\n", r.Topic, action)
+		case errors.Is(r.Err, ErrTopicExists), errors.Is(r.Err, ErrUnknownTopic):
+			_, err = fmt.Fprintf(w, "ℹ️  %s: %v\n", r.Topic, r.Err)
+		default:
+			failed++
+			_, err = fmt.Fprintf(w, "❌ %s: %v\n", r.Topic, r.Err)
+		}
+		if err != nil {
+			return failed, err
+		}
+	}
+	return failed, nil
+}
+
+// WriteTopics writes one row per topic: partitions and replication factor.
+//
+// Parameters:
+//   - w: The destination.
+//   - topics: The topics.
+//
+// Returns:
+//   - error: A write error.
+func WriteTopics(w io.Writer, topics []TopicInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TOPIC\tPARTITIONS\tREPLICATION")
+	for _, t := range topics {
+		if t.Err != nil {
+			fmt.Fprintf(tw, "%s\t-\t-\t(%v)\n", t.Name, t.Err)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", t.Name, len(t.Partitions), t.ReplicationFactor())
+	}
+	return tw.Flush()
+}
+
+// WriteTopicDetails writes the partitions of each topic: leader, replicas and
+// in-sync replicas, flagging the offline and under-replicated partitions.
+//
+// Parameters:
+//   - w: The destination.
+//   - topics: The topics.
+//
+// Returns:
+//   - error: A write error.
+func WriteTopicDetails(w io.Writer, topics []TopicInfo) error {
+	for i, t := range topics {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if t.Err != nil {
+			if _, err := fmt.Fprintf(w, "Topic %s: %v\n", t.Name, t.Err); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(w, "Topic %s: %d partition(s), replication %d\n", t.Name, len(t.Partitions), t.ReplicationFactor())
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  PARTITION\tLEADER\tREPLICAS\tISR\t")
+		for _, p := range t.Partitions {
+			status := ""
+			switch {
+			case p.Leader < 0:
+				status = "offline"
+			case len(p.ISR) < len(p.Replicas):
+				status = "under-replicated"
+			}
+			fmt.Fprintf(tw, "  %d\t%d\t%s\t%s\t%s\n", p.ID, p.Leader, joinIDs(p.Replicas), joinIDs(p.ISR), status)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteGroups writes one row per consumer group.
+//
+// Parameters:
+//   - w: The destination.
+//   - groups: The groups.
+//
+// Returns:
+//   - error: A write error.
+func WriteGroups(w io.Writer, groups []GroupListing) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "GROUP\tSTATE\tTYPE")
+	for _, g := range groups {
+		kind := "consumer"
+		if g.Simple {
+			kind = "simple"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", g.ID, g.State, kind)
+	}
+	return tw.Flush()
+}
+
+// WriteGroupDetails writes the members of each consumer group and their
+// assigned partitions.
+//
+// Parameters:
+//   - w: The destination.
+//   - groups: The groups.
+//
+// Returns:
+//   - error: A write error.
+func WriteGroupDetails(w io.Writer, groups []GroupDescription) error {
+	for i, g := range groups {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if g.Err != nil {
+			if _, err := fmt.Fprintf(w, "Group %s: %v\n", g.ID, g.Err); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(w, "Group %s: %s, %d member(s), assignor %s\n", g.ID, g.State, len(g.Members), orDash(g.Assignor))
+		if len(g.Members) == 0 {
+			continue
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  MEMBER\tCLIENT\tHOST\tPARTITIONS")
+		for _, m := range g.Members {
+			partitions := make([]string, len(m.Assignment))
+			for j, tp := range m.Assignment {
+				partitions[j] = tp.String()
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", m.ConsumerID, m.ClientID, m.Host, orDash(strings.Join(partitions, ", ")))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SortAssignment sorts partitions by topic, then partition.
+//
+// Parameters:
+//   - tps: The partitions, sorted in place.
+func SortAssignment(tps []TopicPartition) {
+	sort.Slice(tps, func(i, j int) bool {
+		if tps[i].Topic != tps[j].Topic {
+			return tps[i].Topic < tps[j].Topic
+		}
+		return tps[i].Partition < tps[j].Partition
+	})
+}
+
+// joinIDs formats broker identifiers as a comma-separated list.
+//
+// Parameters:
+//   - ids: The identifiers.
+//
+// Returns:
+//   - string: The list (e.g., "1,2,3").
+func joinIDs(ids []int32) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = fmt.Sprint(id)
+	}
+	return strings.Join(s, ",")
+}
+
+// orDash returns s, or "-" if s is empty.
+//
+// Parameters:
+//   - s: The value.
+//
+// Returns:
+//   - string: The displayed value.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWriteTopicResults(t *testing.T) {
+	var buf bytes.Buffer
+	failed, err := WriteTopicResults(&buf, "created", []TopicResult{
+		{Topic: "orders"},
+		{Topic: "orders-dlq", Err: ErrTopicExists},
+		{Topic: "orders-retry-1m", Err: errors.New("invalid replication factor")},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if failed != 1 {
+		t.Errorf("Expected 1 failed topic, got %d", failed)
+	}
+	for _, want := range []string{"✅ orders created", "ℹ️  orders-dlq: topic already exists", "❌ orders-retry-1m: invalid replication factor"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %q in:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestWriteTopics(t *testing.T) {
+	topics := []TopicInfo{
+		{Name: "orders", Partitions: []PartitionInfo{
+			{ID: 0, Leader: 1, Replicas: []int32{1, 2}, ISR: []int32{1, 2}},
+			{ID: 1, Leader: 2, Replicas: []int32{2, 1}, ISR: []int32{2}},
+			{ID: 2, Leader: -1, Replicas: []int32{1, 2}},
+		}},
+		{Name: "absent", Err: ErrUnknownTopic},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTopics(&buf, topics); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || strings.Join(strings.Fields(lines[1]), " ") != "orders 3 2" {
+		t.Errorf("Unexpected topic list:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteTopicDetails(&buf, topics); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Topic orders: 3 partition(s), replication 2", "under-replicated", "offline", "Topic absent: unknown topic"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteGroups(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteGroups(&buf, []GroupListing{{ID: "order-tracker-group", State: "Stable"}, {ID: "dlqctl", State: "Empty", Simple: true}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "dlqctl") || !strings.Contains(buf.String(), "simple") {
+		t.Errorf("Unexpected group list:\n%s", buf.String())
+	}
+
+	assignment := []TopicPartition{{"orders", 2}, {"orders", 0}, {"orders-retry-1m", 0}}
+	SortAssignment(assignment)
+	buf.Reset()
+	err = WriteGroupDetails(&buf, []GroupDescription{
+		{ID: "order-tracker-group", State: "Stable", Assignor: "range", Members: []GroupMember{
+			{ConsumerID: "rdkafka-1", ClientID: "rdkafka", Host: "/10.0.0.1", Assignment: assignment},
+			{ConsumerID: "rdkafka-2", ClientID: "rdkafka", Host: "/10.0.0.2"},
+		}},
+		{ID: "absent", Err: ErrUnknownGroup},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Group order-tracker-group: Stable, 2 member(s), assignor range", "orders[0], orders[2], orders-retry-1m[0]", "Group absent: unknown consumer group"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,450 @@
+//go:build kafka
+// +build kafka
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// kafkaClient administers the cluster through a Kafka admin client.
+type kafkaClient struct {
+	admin *kafka.AdminClient // The admin client.
+}
+
+// NewClient creates a client connected to the brokers.
+//
+// Parameters:
+//   - brokers: The bootstrap brokers (comma-separated).
+//   - properties: Additional librdkafka properties (e.g., TLS/SASL security), may be nil.
+//
+// Returns:
+//   - Client: The client.
+//   - error: An error if the admin client cannot be created.
+func NewClient(brokers string, properties map[string]string) (Client, error) {
+	configMap := kafka.ConfigMap{"bootstrap.servers": brokers}
+	for name, value := range properties {
+		configMap[name] = value
+	}
+	admin, err := kafka.NewAdminClient(&configMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka admin client: %w", err)
+	}
+	return &kafkaClient{admin: admin}, nil
+}
+
+// CreateTopics creates topics; already existing topics are left untouched.
+//
+// Parameters:
+//   - ctx: The request deadline.
+//   - specs: The topics to create.
+//
+// Returns:
+//   - []TopicResult: The outcome of each topic, in the order of specs.
+//   - error: An error if the request fails as a whole.
+func (c *kafkaClient) CreateTopics(ctx context.Context, specs []TopicSpec) ([]TopicResult, error) {
+	topics := make([]kafka.TopicSpecification, len(specs))
+	for i, s := range specs {
+		topics[i] = kafka.TopicSpecification{
+			Topic:             s.Name,
+			NumPartitions:     s.Partitions,
+			ReplicationFactor: s.ReplicationFactor,
+			Config:            s.Config,
+		}
+	}
+	results, err := c.admin.CreateTopics(ctx, topics, kafka.SetAdminOperationTimeout(operationTimeout(ctx)))
+	if err != nil {
+		return nil, err
+	}
+	return topicResults(results, kafka.ErrTopicAlreadyExists, ErrTopicExists), nil
+}
+
+// DeleteTopics deletes topics.
+//
+// Parameters:
+//   - ctx: The request deadline.
+//   - names: The topics to delete.
+//
+// Returns:
+//   - []TopicResult: The outcome of each topic, in the order of names.
+//   - error: An error if the request fails as a whole.
+func (c *kafkaClient) DeleteTopics(ctx context.Context, names []string) ([]TopicResult, error) {
+	results, err := c.admin.DeleteTopics(ctx, names, kafka.SetAdminOperationTimeout(operationTimeout(ctx)))
+	if err != nil {
+		return nil, err
+	}
+	return topicResults(results, kafka.ErrUnknownTopicOrPart, ErrUnknownTopic), nil
+}
+
+// operationTimeout returns the wait of the brokers for a topic operation to
+// complete: the time left before the deadline of ctx.
+//
+// Parameters:
+//   - ctx: The request deadline.
+//
+// Returns:
+//   - time.Duration: The wait (DefaultTimeout without deadline).
+func operationTimeout(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	return DefaultTimeout
+}
+
+// topicResults converts the outcomes of a topic operation.
+//
+// Parameters:
+//   - results: The Kafka outcomes.
+//   - noop: The error code meaning that nothing had to be done.
+//   - noopErr: The error reported for noop.
+//
+// Returns:
+//   - []TopicResult: The outcomes.
+func topicResults(results []kafka.TopicResult, noop kafka.ErrorCode, noopErr error) []TopicResult {
+	out := make([]TopicResult, len(results))
+	for i, r := range results {
+		out[i].Topic = r.Topic
+		switch r.Error.Code() {
+		case kafka.ErrNoError:
+		case noop:
+			out[i].Err = noopErr
+		default:
+			out[i].Err = r.Error
+		}
+	}
+	return out
+}
+
+// DescribeTopics describes topics from the cluster metadata.
+//
+// Parameters:
+//   - ctx: The request deadline.
+//   - names: The topics to describe (empty: every topic but the internal ones).
+//
+// Returns:
+//   - []TopicInfo: The topics, in the order of names or by name.
+//   - error: An error if the cluster is unreachable.
+func (c *kafkaClient) DescribeTopics(ctx context.Context, names []string) ([]TopicInfo, error) {
+	md, err := c.admin.GetMetadata(nil, true, int(operationTimeout(ctx)/time.Millisecond))
+	if err != nil {
+		return nil, err
+	}
+	return topicInfos(md, names), nil
+}
+
+// topicInfos summarizes the topics of the cluster metadata.
+//
+// Parameters:
+//   - md: The cluster metadata.
+//   - names: The topics to describe (empty: every topic but the internal ones).
+//
+// Returns:
+//   - []TopicInfo: The topics, in the order of names or by name.
+func topicInfos(md *kafka.Metadata, names []string) []TopicInfo {
+	if len(names) == 0 {
+		for name := range md.Topics {
+			if !strings.HasPrefix(name, "__") {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+	}
+	infos := make([]TopicInfo, len(names))
+	for i, name := range names {
+		infos[i].Name = name
+		t, ok := md.Topics[name]
+		switch {
+		case !ok, t.Error.Code() == kafka.ErrUnknownTopicOrPart:
+			infos[i].Err = ErrUnknownTopic
+			continue
+		case t.Error.Code() != kafka.ErrNoError:
+			infos[i].Err = t.Error
+			continue
+		}
+		for _, p := range t.Partitions {
+			infos[i].Partitions = append(infos[i].Partitions, PartitionInfo{ID: p.ID, Leader: p.Leader, Replicas: p.Replicas, ISR: p.Isrs})
+		}
+		sort.Slice(infos[i].Partitions, func(a, b int) bool { return infos[i].Partitions[a].ID < infos[i].Partitions[b].ID })
+	}
+	return infos
+}
+
+// ListGroups lists the consumer groups.
+//
+// Parameters:
+//   - ctx: The request deadline.
+//
+// Returns:
+//   - []GroupListing: The groups, by identifier.
+//   - error: An error if the cluster is unreachable.
+func (c *kafkaClient) ListGroups(ctx context.Context) ([]GroupListing, error) {
+	result, err := c.admin.ListConsumerGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Valid) == 0 && len(result.Errors) > 0 {
+		return nil, result.Errors[0]
+	}
+	groups := make([]GroupListing, len(result.Valid))
+	for i, g := range result.Valid {
+		groups[i] = GroupListing{ID: g.GroupID, State: g.State.String(), Simple: g.IsSimpleConsumerGroup}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].ID < groups[j].ID })
+	return groups, nil
+}
+
+// DescribeGroups describes consumer groups and the assignment of their members.
+//
+// Parameters:
+//   - ctx: The request deadline.
+//   - ids: The groups to describe.
+//
+// Returns:
+//   - []GroupDescription: The groups, in the order of ids.
+//   - error: An error if the request fails as a whole.
+func (c *kafkaClient) DescribeGroups(ctx context.Context, ids []string) ([]GroupDescription, error) {
+	result, err := c.admin.DescribeConsumerGroups(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]GroupDescription, len(result.ConsumerGroupDescriptions))
+	for i, d := range result.ConsumerGroupDescriptions {
+		groups[i] = groupDescription(d)
+	}
+	return groups, nil
+}
+
+// groupDescription converts the description of a consumer group. The brokers
+// describe an unknown group as a dead group without member.
+//
+// Parameters:
+//   - d: The Kafka description.
+//
+// Returns:
+//   - GroupDescription: The description.
+func groupDescription(d kafka.ConsumerGroupDescription) GroupDescription {
+	g := GroupDescription{ID: d.GroupID, State: d.State.String(), Assignor: d.PartitionAssignor}
+	switch {
+	case d.Error.Code() == kafka.ErrGroupIDNotFound,
+		d.Error.Code() == kafka.ErrNoError && d.State == kafka.ConsumerGroupStateDead && len(d.Members) == 0:
+		g.Err = ErrUnknownGroup
+		return g
+	case d.Error.Code() != kafka.ErrNoError:
+		g.Err = d.Error
+		return g
+	}
+	for _, m := range d.Members {
+		member := GroupMember{ClientID: m.ClientID, ConsumerID: m.ConsumerID, Host: m.Host}
+		for _, tp := range m.Assignment.TopicPartitions {
+			if tp.Topic != nil {
+				member.Assignment = append(member.Assignment, TopicPartition{Topic: *tp.Topic, Partition: tp.Partition})
+			}
+		}
+		SortAssignment(member.Assignment)
+		g.Members = append(g.Members, member)
+	}
+	sort.Slice(g.Members, func(i, j int) bool { return g.Members[i].ConsumerID < g.Members[j].ConsumerID })
+	return g
+}
+
+// GroupOffsets returns the position of a consumer group on every partition of topics.
+//
+// Parameters:
+//   - ctx: The request deadline.
+//   - group: The consumer group.
+//   - topics: The topics consumed by the group.
+//
+// Returns:
+//   - []PartitionOffsets: The positions, in topic then partition order.
+//   - error: An error if a topic is unknown or the cluster is unreachable.
+func (c *kafkaClient) GroupOffsets(ctx context.Context, group string, topics []string) ([]PartitionOffsets, error) {
+	md, err := c.admin.GetMetadata(nil, true, int(operationTimeout(ctx)/time.Millisecond))
+	if err != nil {
+		return nil, err
+	}
+	var partitions []TopicPartition
+	for _, t := range topicInfos(md, topics) {
+		if t.Err != nil {
+			return nil, fmt.Errorf("%s: %w", t.Name, t.Err)
+		}
+		for _, p := range t.Partitions {
+			partitions = append(partitions, TopicPartition{Topic: t.Name, Partition: p.ID})
+		}
+	}
+	SortAssignment(partitions)
+
+	request := make([]kafka.TopicPartition, len(partitions))
+	for i, tp := range partitions {
+		request[i] = toKafka(tp, kafka.OffsetInvalid)
+	}
+	committed, err := c.admin.ListConsumerGroupOffsets(ctx, []kafka.ConsumerGroupTopicPartitions{{Group: group, Partitions: request}})
+	if err != nil {
+		return nil, err
+	}
+	earliest, err := c.listOffsets(ctx, partitions, kafka.EarliestOffsetSpec)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := c.listOffsets(ctx, partitions, kafka.LatestOffsetSpec)
+	if err != nil {
+		return nil, err
+	}
+	return partitionOffsets(partitions, committed.ConsumerGroupsTopicPartitions, earliest, latest)
+}
+
+// partitionOffsets merges the committed offsets of a group with the bounds of the partitions.
+//
+// Parameters:
+//   - partitions: The partitions, in the order of the result.
+//   - committed: The committed offsets of the group.
+//   - earliest: The first offset of each partition.
+//   - latest: The end offset of each partition.
+//
+// Returns:
+//   - []PartitionOffsets: The positions of the group.
+//   - error: An error if the committed offset of a partition cannot be read.
+func partitionOffsets(partitions []TopicPartition, committed []kafka.ConsumerGroupTopicPartitions, earliest, latest map[TopicPartition]int64) ([]PartitionOffsets, error) {
+	positions := make(map[TopicPartition]int64)
+	for _, g := range committed {
+		for _, tp := range g.Partitions {
+			if tp.Error != nil {
+				return nil, fmt.Errorf("%s: %w", fromKafka(tp), tp.Error)
+			}
+			if tp.Offset >= 0 {
+				positions[fromKafka(tp)] = int64(tp.Offset)
+			}
+		}
+	}
+	offsets := make([]PartitionOffsets, len(partitions))
+	for i, tp := range partitions {
+		position, ok := positions[tp]
+		if !ok {
+			position = OffsetNone
+		}
+		offsets[i] = PartitionOffsets{TopicPartition: tp, Committed: position, Earliest: earliest[tp], Latest: latest[tp]}
+	}
+	return offsets, nil
+}
+
+// OffsetsForTime returns the offset of the first message produced at or after t on each partition.
+//
+// Parameters:
+//   - ctx: The request deadline.
+//   - partitions: The partitions.
+//   - t: The time.
+//
+// Returns:
+//   - map[TopicPartition]int64: The offsets (negative for a partition without such message).
+//   - error: An error if the cluster is unreachable.
+func (c *kafkaClient) OffsetsForTime(ctx context.Context, partitions []TopicPartition, t time.Time) (map[TopicPartition]int64, error) {
+	return c.listOffsets(ctx, partitions, kafka.NewOffsetSpecForTimestamp(t.UnixMilli()))
+}
+
+// listOffsets looks up an offset of each partition.
+//
+// Parameters:
+//   - ctx: The request deadline.
+//   - partitions: The partitions.
+//   - spec: The offset to look up (earliest, latest or by timestamp).
+//
+// Returns:
+//   - map[TopicPartition]int64: The offsets.
+//   - error: An error if the offset of a partition cannot be looked up.
+func (c *kafkaClient) listOffsets(ctx context.Context, partitions []TopicPartition, spec kafka.OffsetSpec) (map[TopicPartition]int64, error) {
+	request := make(map[kafka.TopicPartition]kafka.OffsetSpec, len(partitions))
+	for _, tp := range partitions {
+		request[toKafka(tp, kafka.OffsetInvalid)] = spec
+	}
+	result, err := c.admin.ListOffsets(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return listedOffsets(result)
+}
+
+// listedOffsets converts the offsets looked up by ListOffsets.
+//
+// Parameters:
+//   - result: The Kafka result.
+//
+// Returns:
+//   - map[TopicPartition]int64: The offsets.
+//   - error: An error if the offset of a partition could not be looked up.
+func listedOffsets(result kafka.ListOffsetsResult) (map[TopicPartition]int64, error) {
+	offsets := make(map[TopicPartition]int64, len(result.ResultInfos))
+	for tp, info := range result.ResultInfos {
+		if info.Error.Code() != kafka.ErrNoError {
+			return nil, fmt.Errorf("%s: %w", fromKafka(tp), info.Error)
+		}
+		offsets[fromKafka(tp)] = int64(info.Offset)
+	}
+	return offsets, nil
+}
+
+// CommitOffsets commits the targets of resets for a consumer group, which
+// must have no active member.
+//
+// Parameters:
+//   - ctx: The request deadline.
+//   - group: The consumer group.
+//   - resets: The moves of the group.
+//
+// Returns:
+//   - error: An error if an offset cannot be committed.
+func (c *kafkaClient) CommitOffsets(ctx context.Context, group string, resets []OffsetReset) error {
+	partitions := make([]kafka.TopicPartition, len(resets))
+	for i, r := range resets {
+		partitions[i] = toKafka(r.TopicPartition, kafka.Offset(r.Target))
+	}
+	result, err := c.admin.AlterConsumerGroupOffsets(ctx, []kafka.ConsumerGroupTopicPartitions{{Group: group, Partitions: partitions}})
+	if err != nil {
+		return err
+	}
+	for _, g := range result.ConsumerGroupsTopicPartitions {
+		for _, tp := range g.Partitions {
+			if tp.Error != nil {
+				return fmt.Errorf("%s: %w", fromKafka(tp), tp.Error)
+			}
+		}
+	}
+	return nil
+}
+
+// toKafka converts a partition to a Kafka partition.
+//
+// Parameters:
+//   - tp: The partition.
+//   - offset: The offset of the Kafka partition.
+//
+// Returns:
+//   - kafka.TopicPartition: The Kafka partition.
+func toKafka(tp TopicPartition, offset kafka.Offset) kafka.TopicPartition {
+	topic := tp.Topic
+	return kafka.TopicPartition{Topic: &topic, Partition: tp.Partition, Offset: offset}
+}
+
+// fromKafka converts a Kafka partition.
+//
+// Parameters:
+//   - tp: The Kafka partition.
+//
+// Returns:
+//   - TopicPartition: The partition.
+func fromKafka(tp kafka.TopicPartition) TopicPartition {
+	var topic string
+	if tp.Topic != nil {
+		topic = *tp.Topic
+	}
+	return TopicPartition{Topic: topic, Partition: tp.Partition}
+}
+
+// Close releases the admin client.
+func (c *kafkaClient) Close() {
+	c.admin.Close()
+}
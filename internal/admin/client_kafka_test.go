@@ -0,0 +1,103 @@
+//go:build kafka
+// +build kafka
+
+package admin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func TestTopicInfos(t *testing.T) {
+	md := &kafka.Metadata{Topics: map[string]kafka.TopicMetadata{
+		"orders": {Topic: "orders", Partitions: []kafka.PartitionMetadata{
+			{ID: 1, Leader: 1, Replicas: []int32{1}, Isrs: []int32{1}},
+			{ID: 0, Leader: 1, Replicas: []int32{1}, Isrs: []int32{1}},
+		}},
+		"orders-dlq":         {Topic: "orders-dlq"},
+		"__consumer_offsets": {Topic: "__consumer_offsets"},
+	}}
+
+	all := topicInfos(md, nil)
+	if len(all) != 2 || all[0].Name != "orders" || all[1].Name != "orders-dlq" {
+		t.Fatalf("Expected the non-internal topics by name, got %+v", all)
+	}
+	if all[0].Partitions[0].ID != 0 || all[0].ReplicationFactor() != 1 {
+		t.Errorf("Expected the partitions in ID order, got %+v", all[0].Partitions)
+	}
+
+	named := topicInfos(md, []string{"absent", "__consumer_offsets"})
+	if !errors.Is(named[0].Err, ErrUnknownTopic) || named[1].Err != nil {
+		t.Errorf("Unexpected named topics %+v", named)
+	}
+}
+
+func TestTopicResults(t *testing.T) {
+	results := topicResults([]kafka.TopicResult{
+		{Topic: "orders"},
+		{Topic: "orders-dlq", Error: kafka.NewError(kafka.ErrTopicAlreadyExists, "exists", false)},
+		{Topic: "bad", Error: kafka.NewError(kafka.ErrInvalidArg, "invalid", false)},
+	}, kafka.ErrTopicAlreadyExists, ErrTopicExists)
+	if results[0].Err != nil || !errors.Is(results[1].Err, ErrTopicExists) || results[2].Err == nil {
+		t.Errorf("Unexpected results %+v", results)
+	}
+}
+
+func TestGroupDescription(t *testing.T) {
+	orders := "orders"
+	g := groupDescription(kafka.ConsumerGroupDescription{
+		GroupID:           "order-tracker-group",
+		State:             kafka.ConsumerGroupStateStable,
+		PartitionAssignor: "range",
+		Members: []kafka.MemberDescription{
+			{ConsumerID: "b", Assignment: kafka.MemberAssignment{TopicPartitions: []kafka.TopicPartition{{Topic: &orders, Partition: 1}, {Topic: &orders, Partition: 0}}}},
+			{ConsumerID: "a"},
+		},
+	})
+	if g.Err != nil || len(g.Members) != 2 || g.Members[0].ConsumerID != "a" {
+		t.Fatalf("Unexpected description %+v", g)
+	}
+	if a := g.Members[1].Assignment; len(a) != 2 || a[0].Partition != 0 {
+		t.Errorf("Expected a sorted assignment, got %+v", a)
+	}
+
+	dead := groupDescription(kafka.ConsumerGroupDescription{GroupID: "absent", State: kafka.ConsumerGroupStateDead})
+	if !errors.Is(dead.Err, ErrUnknownGroup) {
+		t.Errorf("Expected an unknown group, got %v", dead.Err)
+	}
+}
+
+func TestPartitionOffsets(t *testing.T) {
+	orders := "orders"
+	p0 := TopicPartition{Topic: "orders", Partition: 0}
+	p1 := TopicPartition{Topic: "orders", Partition: 1}
+	committed := []kafka.ConsumerGroupTopicPartitions{{Group: "order-tracker-group", Partitions: []kafka.TopicPartition{
+		{Topic: &orders, Partition: 0, Offset: 42},
+		{Topic: &orders, Partition: 1, Offset: kafka.OffsetInvalid},
+	}}}
+	offsets, err := partitionOffsets([]TopicPartition{p0, p1}, committed, map[TopicPartition]int64{p0: 5}, map[TopicPartition]int64{p0: 50, p1: 7})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if offsets[0] != (PartitionOffsets{TopicPartition: p0, Committed: 42, Earliest: 5, Latest: 50}) || offsets[1].Committed != OffsetNone || offsets[1].Latest != 7 {
+		t.Errorf("Unexpected offsets %+v", offsets)
+	}
+
+	committed[0].Partitions[1].Error = kafka.NewError(kafka.ErrInvalidArg, "invalid", false)
+	if _, err := partitionOffsets([]TopicPartition{p0, p1}, committed, nil, nil); err == nil {
+		t.Error("Expected the error of a partition")
+	}
+}
+
+func TestListedOffsets(t *testing.T) {
+	orders := "orders"
+	offsets, err := listedOffsets(kafka.ListOffsetsResult{ResultInfos: map[kafka.TopicPartition]kafka.ListOffsetsResultInfo{
+		{Topic: &orders, Partition: 0}: {Offset: 12},
+		{Topic: &orders, Partition: 1}: {Offset: kafka.OffsetEnd},
+	}})
+	if err != nil || offsets[TopicPartition{Topic: "orders", Partition: 0}] != 12 || offsets[TopicPartition{Topic: "orders", Partition: 1}] >= 0 {
+		t.Errorf("Unexpected offsets %v, %v", offsets, err)
+	}
+}
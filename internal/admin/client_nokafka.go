@@ -0,0 +1,18 @@
+//go:build !kafka
+// +build !kafka
+
+package admin
+
+// NewClient is unavailable without the "kafka" build tag, which keeps the
+// default binaries free of the CGO Kafka client.
+//
+// Parameters:
+//   - brokers: The bootstrap brokers (unused).
+//   - properties: Additional librdkafka properties (unused).
+//
+// Returns:
+//   - Client: Always nil.
+//   - error: ErrKafkaUnsupported.
+func NewClient(brokers string, properties map[string]string) (Client, error) {
+	return nil, ErrKafkaUnsupported
+}
@@ -0,0 +1,199 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// OffsetNone marks a partition on which a consumer group has no committed offset.
+const OffsetNone int64 = -1
+
+// Reset target kinds of ResetTarget.
+const (
+	// ResetEarliest moves a group to the first offset still in the log.
+	ResetEarliest = "earliest"
+	// ResetLatest moves a group to the end of the log, skipping every pending message.
+	ResetLatest = "latest"
+	// ResetOffset moves a group to a specific offset on every partition.
+	ResetOffset = "offset"
+	// ResetTimestamp moves a group to the first message produced at or after a time.
+	ResetTimestamp = "timestamp"
+)
+
+// ErrInvalidResetTarget is returned by ParseResetTarget for an unrecognized target.
+var ErrInvalidResetTarget = errors.New("invalid reset target (earliest, latest, an offset, an RFC 3339 time or a negative duration)")
+
+// PartitionOffsets is the position of a consumer group on a partition.
+type PartitionOffsets struct {
+	TopicPartition
+	Committed int64 // Next offset the group consumes (OffsetNone: no committed offset).
+	Earliest  int64 // First offset still in the log.
+	Latest    int64 // End of the log: the offset of the next produced message.
+}
+
+// Lag returns the number of messages the group has yet to consume.
+//
+// Returns:
+//   - int64: The lag (-1 without committed offset).
+func (p PartitionOffsets) Lag() int64 {
+	if p.Committed < 0 {
+		return -1
+	}
+	return p.Latest - p.Committed
+}
+
+// OffsetReset is the planned move of a consumer group on a partition.
+type OffsetReset struct {
+	TopicPartition
+	Current int64 // Committed offset before the reset (OffsetNone: none).
+	Target  int64 // Committed offset after the reset.
+}
+
+// ResetTarget selects the offsets a reset moves a consumer group to.
+type ResetTarget struct {
+	Kind   string    // ResetEarliest, ResetLatest, ResetOffset or ResetTimestamp.
+	Offset int64     // Offset of ResetOffset.
+	Time   time.Time // Time of ResetTimestamp.
+}
+
+// ParseResetTarget parses a reset target: "earliest", "latest", an offset, an
+// RFC 3339 time, or a negative duration relative to now (e.g., "-15m").
+//
+// Parameters:
+//   - s: The target.
+//   - now: The reference time of the durations.
+//
+// Returns:
+//   - ResetTarget: The target.
+//   - error: ErrInvalidResetTarget if s is not recognized.
+func ParseResetTarget(s string, now time.Time) (ResetTarget, error) {
+	switch s {
+	case ResetEarliest, ResetLatest:
+		return ResetTarget{Kind: s}, nil
+	}
+	if offset, err := strconv.ParseInt(s, 10, 64); err == nil && offset >= 0 {
+		return ResetTarget{Kind: ResetOffset, Offset: offset}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return ResetTarget{Kind: ResetTimestamp, Time: t}, nil
+	}
+	if strings.HasPrefix(s, "-") {
+		if d, err := time.ParseDuration(s); err == nil {
+			return ResetTarget{Kind: ResetTimestamp, Time: now.Add(d)}, nil
+		}
+	}
+	return ResetTarget{}, fmt.Errorf("%q: %w", s, ErrInvalidResetTarget)
+}
+
+// String describes the target.
+//
+// Returns:
+//   - string: The description (e.g., "offset 42").
+func (r ResetTarget) String() string {
+	switch r.Kind {
+	case ResetOffset:
+		return fmt.Sprintf("offset %d", r.Offset)
+	case ResetTimestamp:
+		return "timestamp " + r.Time.Format(time.RFC3339)
+	}
+	return r.Kind
+}
+
+// PlanReset computes the move of a consumer group on each partition. Targets
+// are clamped to the offsets still in the log.
+//
+// Parameters:
+//   - offsets: The current positions of the group.
+//   - target: The target of the reset.
+//   - byTime: The offsets of the first messages at or after target.Time, by
+//     partition (ResetTimestamp only); a negative or missing offset, for a
+//     partition without such message, moves the group to the end of the log.
+//
+// Returns:
+//   - []OffsetReset: The moves, in the order of offsets.
+func PlanReset(offsets []PartitionOffsets, target ResetTarget, byTime map[TopicPartition]int64) []OffsetReset {
+	resets := make([]OffsetReset, len(offsets))
+	for i, p := range offsets {
+		to := p.Latest
+		switch target.Kind {
+		case ResetEarliest:
+			to = p.Earliest
+		case ResetOffset:
+			to = target.Offset
+		case ResetTimestamp:
+			if offset, ok := byTime[p.TopicPartition]; ok && offset >= 0 {
+				to = offset
+			}
+		}
+		to = max(p.Earliest, min(to, p.Latest))
+		resets[i] = OffsetReset{TopicPartition: p.TopicPartition, Current: p.Committed, Target: to}
+	}
+	return resets
+}
+
+// WriteOffsets writes one row per partition: committed offset, log bounds and lag.
+//
+// Parameters:
+//   - w: The destination.
+//   - offsets: The positions of the group.
+//
+// Returns:
+//   - error: A write error.
+func WriteOffsets(w io.Writer, offsets []PartitionOffsets) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TOPIC\tPARTITION\tCOMMITTED\tEARLIEST\tLATEST\tLAG")
+	var total int64
+	for _, p := range offsets {
+		lag := p.Lag()
+		if lag > 0 {
+			total += lag
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%d\t%d\t%s\n", p.Topic, p.Partition, formatOffset(p.Committed), p.Earliest, p.Latest, formatOffset(lag))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "Total lag: %d\n", total)
+	return err
+}
+
+// WriteResets writes one row per partition: current and target offsets and
+// the number of messages replayed (positive) or skipped (negative).
+//
+// Parameters:
+//   - w: The destination.
+//   - resets: The moves of the group.
+//
+// Returns:
+//   - error: A write error.
+func WriteResets(w io.Writer, resets []OffsetReset) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TOPIC\tPARTITION\tCURRENT\tTARGET\tREPLAYED")
+	for _, r := range resets {
+		replayed := "-"
+		if r.Current >= 0 {
+			replayed = strconv.FormatInt(r.Current-r.Target, 10)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%d\t%s\n", r.Topic, r.Partition, formatOffset(r.Current), r.Target, replayed)
+	}
+	return tw.Flush()
+}
+
+// formatOffset formats an offset, or "-" if it is negative.
+//
+// Parameters:
+//   - offset: The offset.
+//
+// Returns:
+//   - string: The displayed offset.
+func formatOffset(offset int64) string {
+	if offset < 0 {
+		return "-"
+	}
+	return strconv.FormatInt(offset, 10)
+}
@@ -0,0 +1,94 @@
+package admin
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseResetTarget(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want ResetTarget
+	}{
+		{"earliest", ResetTarget{Kind: ResetEarliest}},
+		{"latest", ResetTarget{Kind: ResetLatest}},
+		{"42", ResetTarget{Kind: ResetOffset, Offset: 42}},
+		{"2026-03-01T10:00:00Z", ResetTarget{Kind: ResetTimestamp, Time: now.Add(-2 * time.Hour)}},
+		{"-15m", ResetTarget{Kind: ResetTimestamp, Time: now.Add(-15 * time.Minute)}},
+	}
+	for _, tt := range tests {
+		got, err := ParseResetTarget(tt.in, now)
+		if err != nil || got.Kind != tt.want.Kind || got.Offset != tt.want.Offset || !got.Time.Equal(tt.want.Time) {
+			t.Errorf("ParseResetTarget(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "-3", "15m", "yesterday"} {
+		if _, err := ParseResetTarget(in, now); !errors.Is(err, ErrInvalidResetTarget) {
+			t.Errorf("ParseResetTarget(%q): expected ErrInvalidResetTarget, got %v", in, err)
+		}
+	}
+}
+
+func TestPlanReset(t *testing.T) {
+	p0 := TopicPartition{Topic: "orders", Partition: 0}
+	p1 := TopicPartition{Topic: "orders", Partition: 1}
+	offsets := []PartitionOffsets{
+		{TopicPartition: p0, Committed: 80, Earliest: 10, Latest: 100},
+		{TopicPartition: p1, Committed: OffsetNone, Earliest: 0, Latest: 50},
+	}
+	tests := []struct {
+		name   string
+		target ResetTarget
+		byTime map[TopicPartition]int64
+		want   [2]int64
+	}{
+		{"earliest", ResetTarget{Kind: ResetEarliest}, nil, [2]int64{10, 0}},
+		{"latest", ResetTarget{Kind: ResetLatest}, nil, [2]int64{100, 50}},
+		{"clamped offset", ResetTarget{Kind: ResetOffset, Offset: 60}, nil, [2]int64{60, 50}},
+		{"below the log", ResetTarget{Kind: ResetOffset, Offset: 5}, nil, [2]int64{10, 5}},
+		{"timestamp", ResetTarget{Kind: ResetTimestamp}, map[TopicPartition]int64{p0: 90, p1: -1}, [2]int64{90, 50}},
+	}
+	for _, tt := range tests {
+		resets := PlanReset(offsets, tt.target, tt.byTime)
+		if len(resets) != 2 || resets[0].Target != tt.want[0] || resets[1].Target != tt.want[1] {
+			t.Errorf("%s: unexpected resets %+v", tt.name, resets)
+		}
+		if resets[0].Current != 80 || resets[1].Current != OffsetNone {
+			t.Errorf("%s: expected the committed offsets as current, got %+v", tt.name, resets)
+		}
+	}
+}
+
+func TestWriteOffsets(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteOffsets(&buf, []PartitionOffsets{
+		{TopicPartition: TopicPartition{Topic: "orders", Partition: 0}, Committed: 80, Earliest: 10, Latest: 100},
+		{TopicPartition: TopicPartition{Topic: "orders", Partition: 1}, Committed: OffsetNone, Latest: 50},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || strings.Join(strings.Fields(lines[1]), " ") != "orders 0 80 10 100 20" ||
+		strings.Join(strings.Fields(lines[2]), " ") != "orders 1 - 0 50 -" || lines[3] != "Total lag: 20" {
+		t.Errorf("Unexpected offsets:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	err = WriteResets(&buf, []OffsetReset{
+		{TopicPartition: TopicPartition{Topic: "orders", Partition: 0}, Current: 80, Target: 10},
+		{TopicPartition: TopicPartition{Topic: "orders", Partition: 1}, Current: OffsetNone, Target: 50},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || strings.Join(strings.Fields(lines[1]), " ") != "orders 0 80 10 70" ||
+		strings.Join(strings.Fields(lines[2]), " ") != "orders 1 - 50 -" {
+		t.Errorf("Unexpected resets:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,301 @@
+/*
+Package chaos injects failures into the PubSub system, so that the behaviour
+of the retry topics, the DLQ and the monitor under failure can be shown and
+scripted.
+
+The pubsub chaos command schedules faults (a Scenario, or a single fault) and
+shares them through a state file: with chaos.enabled, the producer and the
+tracker read it with an Injector and apply the faults active at each message.
+The producer corrupts payloads; the tracker delays or panics while processing
+a message and drops offset commits. The broker itself is paused by the command
+(docker pause), through a BrokerControl.
+*/
+package chaos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Fault kinds.
+const (
+	// FaultBrokerPause pauses the broker container.
+	FaultBrokerPause = "broker_pause"
+	// FaultConsumerDelay delays the processing of the messages by the tracker.
+	FaultConsumerDelay = "consumer_delay"
+	// FaultConsumerPanic makes the tracker panic while processing a message.
+	FaultConsumerPanic = "consumer_panic"
+	// FaultCommitDrop makes the tracker skip the offset commit of a message.
+	FaultCommitDrop = "commit_drop"
+	// FaultPayloadCorrupt makes the producer publish a corrupted payload.
+	FaultPayloadCorrupt = "payload_corrupt"
+)
+
+// Kinds lists the fault kinds.
+var Kinds = []string{FaultBrokerPause, FaultConsumerDelay, FaultConsumerPanic, FaultCommitDrop, FaultPayloadCorrupt}
+
+// refreshInterval is the minimum interval between two reads of the state file
+// by an Injector.
+const refreshInterval = time.Second
+
+// corruptionMarker is appended to the truncated payloads.
+const corruptionMarker = "\x00chaos"
+
+// Fault is a failure injected between From and Until.
+type Fault struct {
+	Kind    string        `json:"fault"`             // One of Kinds.
+	Delay   time.Duration `json:"delay,omitempty"`   // Processing delay of consumer_delay.
+	Percent float64       `json:"percent,omitempty"` // Share of the messages affected, in percent (0: all).
+	From    time.Time     `json:"from"`              // Start of the fault.
+	Until   time.Time     `json:"until"`             // End of the fault.
+}
+
+// Validate checks the kind and the parameters of the fault.
+//
+// Returns:
+//   - error: An error describing the first invalid parameter.
+func (f Fault) Validate() error {
+	known := false
+	for _, kind := range Kinds {
+		known = known || kind == f.Kind
+	}
+	switch {
+	case !known:
+		return fmt.Errorf("unknown fault %q (expected one of %v)", f.Kind, Kinds)
+	case f.Percent < 0 || f.Percent > 100:
+		return fmt.Errorf("%s: percent must be between 0 and 100 (got %g)", f.Kind, f.Percent)
+	case f.Kind == FaultConsumerDelay && f.Delay <= 0:
+		return fmt.Errorf("%s: delay must be positive (got %s)", f.Kind, f.Delay)
+	case !f.Until.After(f.From):
+		return fmt.Errorf("%s: duration must be positive", f.Kind)
+	}
+	return nil
+}
+
+// Active reports whether the fault is active at a time.
+//
+// Parameters:
+//   - now: The time.
+//
+// Returns:
+//   - bool: True between From (included) and Until (excluded).
+func (f Fault) Active(now time.Time) bool {
+	return !now.Before(f.From) && now.Before(f.Until)
+}
+
+// String describes the fault and its parameters.
+//
+// Returns:
+//   - string: The description (e.g., "consumer_delay 500ms on 20% of the messages").
+func (f Fault) String() string {
+	s := f.Kind
+	if f.Delay > 0 {
+		s += " " + f.Delay.String()
+	}
+	if f.Percent > 0 && f.Kind != FaultBrokerPause {
+		s += fmt.Sprintf(" on %g%% of the messages", f.Percent)
+	}
+	return s
+}
+
+// State is the content of the state file: the faults scheduled by pubsub chaos.
+type State struct {
+	Faults []Fault `json:"faults"`
+}
+
+// Active returns the faults active at a time.
+//
+// Parameters:
+//   - now: The time.
+//
+// Returns:
+//   - []Fault: The active faults.
+func (s State) Active(now time.Time) []Fault {
+	var active []Fault
+	for _, f := range s.Faults {
+		if f.Active(now) {
+			active = append(active, f)
+		}
+	}
+	return active
+}
+
+// ReadState reads the state file; a missing file is an empty state.
+//
+// Parameters:
+//   - path: The state file.
+//
+// Returns:
+//   - State: The scheduled faults.
+//   - error: An error if the file cannot be read or decoded.
+func ReadState(path string) (State, error) {
+	var s State
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("invalid chaos state %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// WriteState replaces the state file, atomically so that the injectors never
+// read a partial file, creating its directory if needed.
+//
+// Parameters:
+//   - path: The state file.
+//   - s: The scheduled faults.
+//
+// Returns:
+//   - error: An error if the file cannot be written.
+func WriteState(path string, s State) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Injector applies the faults of the state file in a component. It reads the
+// file again at most every refreshInterval, when it changed. A nil Injector
+// injects nothing. It is safe for concurrent use.
+type Injector struct {
+	path string
+
+	mu      sync.Mutex
+	state   State
+	modTime time.Time // Modification time of the state file read.
+	checked time.Time // Last check of the state file.
+	rand    *rand.Rand
+	now     func() time.Time // Current time (replaced by the tests).
+}
+
+// NewInjector creates an injector reading the state file.
+//
+// Parameters:
+//   - path: The state file written by pubsub chaos.
+//
+// Returns:
+//   - *Injector: The injector.
+func NewInjector(path string) *Injector {
+	return &Injector{path: path, rand: rand.New(rand.NewSource(time.Now().UnixNano())), now: time.Now}
+}
+
+// hit returns the active fault of a kind if it applies to the current message,
+// according to its percentage.
+//
+// Parameters:
+//   - kind: The fault kind.
+//
+// Returns:
+//   - Fault: The fault.
+//   - bool: True if the fault applies.
+func (in *Injector) hit(kind string) (Fault, bool) {
+	if in == nil {
+		return Fault{}, false
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	now := in.now()
+	in.refresh(now)
+	for _, f := range in.state.Active(now) {
+		if f.Kind != kind {
+			continue
+		}
+		if f.Percent == 0 || in.rand.Float64()*100 < f.Percent {
+			return f, true
+		}
+		return Fault{}, false
+	}
+	return Fault{}, false
+}
+
+// refresh reads the state file again if it changed since the last read; the
+// caller holds the lock. An unreadable file keeps the previous state.
+//
+// Parameters:
+//   - now: The current time.
+func (in *Injector) refresh(now time.Time) {
+	if now.Sub(in.checked) < refreshInterval && !in.checked.IsZero() {
+		return
+	}
+	in.checked = now
+	info, err := os.Stat(in.path)
+	if errors.Is(err, os.ErrNotExist) {
+		in.state, in.modTime = State{}, time.Time{}
+		return
+	}
+	if err != nil || info.ModTime().Equal(in.modTime) {
+		return
+	}
+	if s, err := ReadState(in.path); err == nil {
+		in.state, in.modTime = s, info.ModTime()
+	}
+}
+
+// Delay returns the processing delay to inject before the current message.
+//
+// Returns:
+//   - time.Duration: The delay (0: none).
+func (in *Injector) Delay() time.Duration {
+	f, ok := in.hit(FaultConsumerDelay)
+	if !ok {
+		return 0
+	}
+	return f.Delay
+}
+
+// Panic reports whether the processing of the current message must panic.
+//
+// Returns:
+//   - bool: True to panic.
+func (in *Injector) Panic() bool {
+	_, ok := in.hit(FaultConsumerPanic)
+	return ok
+}
+
+// DropCommit reports whether the offset commit of the current message must be
+// skipped.
+//
+// Returns:
+//   - bool: True to skip the commit.
+func (in *Injector) DropCommit() bool {
+	_, ok := in.hit(FaultCommitDrop)
+	return ok
+}
+
+// Corrupt returns the payload to publish: while payload_corrupt applies, the
+// payload truncated in its middle and followed by a marker, which no decoder
+// accepts.
+//
+// Parameters:
+//   - value: The payload.
+//
+// Returns:
+//   - []byte: The payload to publish (value itself if not corrupted).
+//   - bool: True if the payload was corrupted.
+func (in *Injector) Corrupt(value []byte) ([]byte, bool) {
+	if _, ok := in.hit(FaultPayloadCorrupt); !ok {
+		return value, false
+	}
+	corrupted := make([]byte, 0, len(value)/2+len(corruptionMarker))
+	corrupted = append(corrupted, value[:len(value)/2]...)
+	return append(corrupted, corruptionMarker...), true
+}
@@ -0,0 +1,263 @@
+package chaos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testInjector returns an injector of a state file holding the given faults.
+func testInjector(t *testing.T, faults ...Fault) (*Injector, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "chaos.json")
+	if err := WriteState(path, State{Faults: faults}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return NewInjector(path), path
+}
+
+func TestFaultValidate(t *testing.T) {
+	now := time.Now()
+	valid := []Fault{
+		{Kind: FaultBrokerPause, From: now, Until: now.Add(time.Second)},
+		{Kind: FaultConsumerDelay, Delay: time.Millisecond, Percent: 50, From: now, Until: now.Add(time.Second)},
+	}
+	for _, f := range valid {
+		if err := f.Validate(); err != nil {
+			t.Errorf("Expected %v to be valid, got %v", f, err)
+		}
+	}
+	invalid := []Fault{
+		{Kind: "broker_kill", From: now, Until: now.Add(time.Second)},
+		{Kind: FaultCommitDrop, Percent: 120, From: now, Until: now.Add(time.Second)},
+		{Kind: FaultConsumerDelay, From: now, Until: now.Add(time.Second)},
+		{Kind: FaultConsumerPanic, From: now, Until: now},
+	}
+	for _, f := range invalid {
+		if err := f.Validate(); err == nil {
+			t.Errorf("Expected %+v to be rejected", f)
+		}
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "chaos.json")
+	if s, err := ReadState(path); err != nil || len(s.Faults) != 0 {
+		t.Errorf("Expected an empty state without file, got %+v (%v)", s, err)
+	}
+
+	now := time.Now()
+	faults := []Fault{
+		{Kind: FaultConsumerPanic, From: now.Add(-time.Minute), Until: now.Add(-time.Second)},
+		{Kind: FaultCommitDrop, Percent: 20, From: now.Add(-time.Second), Until: now.Add(time.Minute)},
+	}
+	if err := WriteState(path, State{Faults: faults}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	s, err := ReadState(path)
+	if err != nil || len(s.Faults) != 2 {
+		t.Fatalf("Unexpected state %+v (%v)", s, err)
+	}
+	if active := s.Active(now); len(active) != 1 || active[0].Kind != FaultCommitDrop {
+		t.Errorf("Expected only commit_drop to be active, got %+v", active)
+	}
+	if _, err := os.Stat(path + ".tmp"); !errors.Is(err, os.ErrNotExist) {
+		t.Error("Expected no temporary file left")
+	}
+}
+
+func TestInjector(t *testing.T) {
+	now := time.Now()
+	in, _ := testInjector(t,
+		Fault{Kind: FaultConsumerDelay, Delay: 250 * time.Millisecond, From: now.Add(-time.Second), Until: now.Add(time.Minute)},
+		Fault{Kind: FaultConsumerPanic, From: now.Add(-time.Second), Until: now.Add(time.Minute)},
+		Fault{Kind: FaultPayloadCorrupt, From: now.Add(time.Hour), Until: now.Add(2 * time.Hour)},
+	)
+	if d := in.Delay(); d != 250*time.Millisecond {
+		t.Errorf("Expected a 250ms delay, got %s", d)
+	}
+	if !in.Panic() {
+		t.Error("Expected a panic")
+	}
+	if in.DropCommit() {
+		t.Error("Expected no commit drop")
+	}
+	if value, ok := in.Corrupt([]byte(`{"order_id":"1"}`)); ok || string(value) != `{"order_id":"1"}` {
+		t.Errorf("Expected a scheduled corruption not to apply yet, got %q", value)
+	}
+
+	// The faults apply once their time has come.
+	in.now = func() time.Time { return now.Add(90 * time.Minute) }
+	in.checked = time.Time{}
+	value, ok := in.Corrupt([]byte(`{"order_id":"1"}`))
+	if !ok || json.Valid(value) || !bytes.HasPrefix(value, []byte(`{"order_`)) {
+		t.Errorf("Expected a truncated payload, got %q", value)
+	}
+	if in.Delay() != 0 || in.Panic() {
+		t.Error("Expected the delay and the panic to be over")
+	}
+
+	var disabled *Injector
+	if disabled.Delay() != 0 || disabled.Panic() || disabled.DropCommit() {
+		t.Error("Expected a nil injector to inject nothing")
+	}
+	if _, ok := disabled.Corrupt([]byte("x")); ok {
+		t.Error("Expected a nil injector not to corrupt")
+	}
+}
+
+func TestInjectorPercent(t *testing.T) {
+	now := time.Now()
+	in, _ := testInjector(t, Fault{Kind: FaultCommitDrop, Percent: 25, From: now.Add(-time.Second), Until: now.Add(time.Minute)})
+	dropped := 0
+	for i := 0; i < 4000; i++ {
+		if in.DropCommit() {
+			dropped++
+		}
+	}
+	if dropped < 800 || dropped > 1200 {
+		t.Errorf("Expected about 1000 dropped commits, got %d", dropped)
+	}
+}
+
+func TestInjectorRefresh(t *testing.T) {
+	in, path := testInjector(t)
+	if in.Panic() {
+		t.Fatal("Expected no panic before the fault")
+	}
+	now := time.Now()
+	if err := WriteState(path, State{Faults: []Fault{{Kind: FaultConsumerPanic, From: now, Until: now.Add(time.Minute)}}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if in.Panic() {
+		t.Error("Expected the state file to be read again after refreshInterval only")
+	}
+	in.checked = in.checked.Add(-refreshInterval)
+	if !in.Panic() {
+		t.Error("Expected the new fault to apply")
+	}
+	os.Remove(path)
+	in.checked = in.checked.Add(-refreshInterval)
+	if in.Panic() {
+		t.Error("Expected no fault once the state file is removed")
+	}
+}
+
+func TestLoadScenario(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "scenario.yaml")
+	content := `name: outage
+steps:
+  - {at: 30s, for: 20s, fault: broker_pause}
+  - {at: 0s, for: 1m, fault: consumer_delay, delay: 200ms, percent: 50}
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := LoadScenario(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	start := time.Unix(1000, 0)
+	faults := s.Schedule(start)
+	if s.Name != "outage" || len(faults) != 2 || faults[0].Kind != FaultConsumerDelay || faults[0].Delay != 200*time.Millisecond {
+		t.Fatalf("Unexpected schedule %+v", faults)
+	}
+	if !faults[1].From.Equal(start.Add(30*time.Second)) || !faults[1].Until.Equal(start.Add(50*time.Second)) {
+		t.Errorf("Unexpected broker pause window %+v", faults[1])
+	}
+
+	bad := filepath.Join(dir, "bad.yaml")
+	os.WriteFile(bad, []byte("steps:\n  - {at: 0s, for: 1m, fault: consumer_delay}\n"), 0644)
+	if _, err := LoadScenario(bad); err == nil || !strings.Contains(err.Error(), "step 1") {
+		t.Errorf("Expected an invalid step error, got %v", err)
+	}
+}
+
+func TestExampleScenario(t *testing.T) {
+	s, err := LoadScenario(filepath.Join("..", "..", "chaos.example.yaml"))
+	if err != nil {
+		t.Fatalf("Expected a valid example scenario, got %v", err)
+	}
+	kinds := make(map[string]bool)
+	for _, step := range s.Steps {
+		kinds[step.Fault] = true
+	}
+	for _, kind := range Kinds {
+		if !kinds[kind] {
+			t.Errorf("Expected the example scenario to show %s", kind)
+		}
+	}
+}
+
+// fakeBroker records the pauses and resumes of the broker.
+type fakeBroker struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (b *fakeBroker) Pause() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calls = append(b.calls, "pause")
+	return nil
+}
+
+func (b *fakeBroker) Resume() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calls = append(b.calls, "resume")
+	return nil
+}
+
+func TestRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chaos.json")
+	s := &Scenario{Steps: []Step{
+		{At: 0, For: 60 * time.Millisecond, Fault: FaultBrokerPause},
+		{At: 30 * time.Millisecond, For: 60 * time.Millisecond, Fault: FaultBrokerPause},
+		{At: 0, For: 150 * time.Millisecond, Fault: FaultConsumerPanic},
+	}}
+	broker := &fakeBroker{}
+	done := make(chan error)
+	go func() { done <- Run(context.Background(), path, s.Schedule(time.Now()), broker) }()
+
+	time.Sleep(20 * time.Millisecond)
+	if state, _ := ReadState(path); len(state.Faults) != 3 {
+		t.Errorf("Expected the 3 faults in the state file, got %+v", state)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(broker.calls, ",") != "pause,resume" {
+		t.Errorf("Expected overlapping pauses to be merged, got %v", broker.calls)
+	}
+	if state, _ := ReadState(path); len(state.Faults) != 0 {
+		t.Errorf("Expected a cleared state file, got %+v", state)
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chaos.json")
+	now := time.Now()
+	broker := &fakeBroker{}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	err := Run(ctx, path, []Fault{{Kind: FaultBrokerPause, From: now, Until: now.Add(time.Hour)}}, broker)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(broker.calls, ",") != "pause,resume" {
+		t.Errorf("Expected the broker to be resumed, got %v", broker.calls)
+	}
+
+	if err := Run(context.Background(), path, []Fault{{Kind: FaultBrokerPause, From: now, Until: now.Add(time.Hour)}}, nil); err == nil {
+		t.Error("Expected an error without broker control")
+	}
+}
@@ -0,0 +1,208 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Step is a fault of a scenario, active from At to At+For after the start.
+type Step struct {
+	At      time.Duration `yaml:"at"`      // Start, relative to the start of the scenario.
+	For     time.Duration `yaml:"for"`     // Duration.
+	Fault   string        `yaml:"fault"`   // One of Kinds.
+	Delay   time.Duration `yaml:"delay"`   // Processing delay of consumer_delay.
+	Percent float64       `yaml:"percent"` // Share of the messages affected, in percent (0: all).
+}
+
+// Scenario is a schedule of faults, read from a YAML file:
+//
+//	name: broker-outage
+//	steps:
+//	  - {at: 0s, for: 1m, fault: consumer_delay, delay: 200ms, percent: 50}
+//	  - {at: 30s, for: 20s, fault: broker_pause}
+//	  - {at: 1m, for: 30s, fault: payload_corrupt, percent: 10}
+type Scenario struct {
+	Name  string `yaml:"name"`  // Displayed name.
+	Steps []Step `yaml:"steps"` // Faults, in any order.
+}
+
+// LoadScenario reads and validates a scenario file.
+//
+// Parameters:
+//   - path: The YAML file.
+//
+// Returns:
+//   - *Scenario: The scenario.
+//   - error: An error if the file cannot be read or the scenario is invalid.
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Scenario
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Validate checks every step of the scenario.
+//
+// Returns:
+//   - error: An error describing the first invalid step.
+func (s *Scenario) Validate() error {
+	if len(s.Steps) == 0 {
+		return fmt.Errorf("no step")
+	}
+	for i, step := range s.Steps {
+		if step.At < 0 {
+			return fmt.Errorf("step %d: at must be >= 0 (got %s)", i+1, step.At)
+		}
+		if err := step.fault(time.Time{}).Validate(); err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Schedule returns the faults of the scenario started at a time, in start order.
+//
+// Parameters:
+//   - start: The start of the scenario.
+//
+// Returns:
+//   - []Fault: The scheduled faults.
+func (s *Scenario) Schedule(start time.Time) []Fault {
+	faults := make([]Fault, len(s.Steps))
+	for i, step := range s.Steps {
+		faults[i] = step.fault(start)
+	}
+	sort.SliceStable(faults, func(i, j int) bool { return faults[i].From.Before(faults[j].From) })
+	return faults
+}
+
+// fault returns the fault of the step for a scenario started at a time.
+//
+// Parameters:
+//   - start: The start of the scenario.
+//
+// Returns:
+//   - Fault: The fault.
+func (st Step) fault(start time.Time) Fault {
+	from := start.Add(st.At)
+	return Fault{Kind: st.Fault, Delay: st.Delay, Percent: st.Percent, From: from, Until: from.Add(st.For)}
+}
+
+// BrokerControl pauses and resumes the broker, for the broker_pause faults.
+type BrokerControl interface {
+	// Pause freezes the broker.
+	//
+	// Returns:
+	//   - error: An error if the broker cannot be paused.
+	Pause() error
+
+	// Resume unfreezes the broker.
+	//
+	// Returns:
+	//   - error: An error if the broker cannot be resumed.
+	Resume() error
+}
+
+// Run executes a schedule: it writes the faults to the state file, read by the
+// injectors of the producer and the tracker, pauses the broker during the
+// broker_pause faults, then clears the state file once the last fault ended
+// or ctx is cancelled. A paused broker is always resumed.
+//
+// Parameters:
+//   - ctx: Cancelling it ends the faults early.
+//   - stateFile: The state file.
+//   - faults: The scheduled faults.
+//   - broker: The broker control (nil: broker_pause faults are rejected).
+//
+// Returns:
+//   - error: An error if a broker_pause fault has no broker control, or the
+//     state file or the broker control fails.
+func Run(ctx context.Context, stateFile string, faults []Fault, broker BrokerControl) (err error) {
+	// Pauses and resumes of the broker, in time order; overlapping pauses are merged
+	type event struct {
+		at    time.Time
+		pause bool
+	}
+	var events []event
+	var end time.Time
+	for _, f := range faults {
+		if f.Until.After(end) {
+			end = f.Until
+		}
+		if f.Kind != FaultBrokerPause {
+			continue
+		}
+		if broker == nil {
+			return fmt.Errorf("%s requires a broker container", FaultBrokerPause)
+		}
+		events = append(events, event{f.From, true}, event{f.Until, false})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].at.Before(events[j].at) })
+
+	if err := WriteState(stateFile, State{Faults: faults}); err != nil {
+		return err
+	}
+	paused := 0
+	defer func() {
+		if paused > 0 {
+			if resumeErr := broker.Resume(); err == nil {
+				err = resumeErr
+			}
+		}
+		if clearErr := WriteState(stateFile, State{}); err == nil {
+			err = clearErr
+		}
+	}()
+
+	for _, ev := range events {
+		if !wait(ctx, ev.at) {
+			return nil
+		}
+		if ev.pause {
+			if paused++; paused == 1 {
+				if err := broker.Pause(); err != nil {
+					paused = 0
+					return err
+				}
+			}
+		} else if paused--; paused == 0 {
+			if err := broker.Resume(); err != nil {
+				return err
+			}
+		}
+	}
+	wait(ctx, end)
+	return nil
+}
+
+// wait sleeps until a time.
+//
+// Parameters:
+//   - ctx: Cancelling it ends the wait.
+//   - at: The time.
+//
+// Returns:
+//   - bool: False if ctx was cancelled.
+func wait(ctx context.Context, at time.Time) bool {
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,354 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/admin"
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// newAdminClient crée le client d'administration du cluster. Remplacée par les tests.
+var newAdminClient = admin.NewClient
+
+// newAdminCommand crée la commande admin, qui administre le cluster Kafka sans les
+// outils en ligne de commande de Kafka :
+//
+//   - topics list et topics describe affichent les topics (partitions,
+//     réplication) et, pour describe, le leader et les répliques de chaque
+//     partition ; sans nom, tous les topics hors topics internes.
+//   - topics create crée les topics nommés avec --partitions et
+//     --replication-factor ; sans nom, les topics requis par la configuration
+//     (principal, paliers de relance et DLQ s'ils sont activés). Les topics
+//     existants sont laissés intacts.
+//   - topics delete supprime les topics nommés.
+//   - groups list affiche les groupes de consommateurs, et groups describe les
+//     membres d'un groupe et leurs partitions (défaut : kafka.consumer_group).
+//   - offsets show affiche les offsets validés d'un groupe (défaut :
+//     kafka.consumer_group) sur les topics (défaut : kafka.topic) et son retard.
+//   - offsets reset déplace le groupe vers le début ou la fin des partitions,
+//     un offset, ou le premier message produit depuis une date ou une durée
+//     (--to), pour rejouer ou sauter des messages. Le plan est toujours
+//     affiché ; --dry-run n'applique rien. Le groupe ne doit avoir aucun membre
+//     actif : le tracker doit être arrêté.
+//
+// La commande requiert des brokers Kafka et la compilation avec -tags kafka.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande et ses sous-commandes.
+func newAdminCommand(cfgFlags *config.Flags) *cobra.Command {
+	var timeout time.Duration
+	// leaf crée une sous-commande qui s'exécute connectée au cluster
+	leaf := func(use, short string, validate cobra.PositionalArgs, run func(s *adminSession) error) *cobra.Command {
+		return &cobra.Command{
+			Use:   use,
+			Short: short,
+			Args:  usageArgs(validate),
+			RunE: func(_ *cobra.Command, args []string) error {
+				s, err := openAdmin(cfgFlags, timeout, args)
+				if s == nil {
+					return err
+				}
+				defer s.Close()
+				return run(s)
+			},
+		}
+	}
+
+	var partitions, replication int
+	create := leaf("create [topic...]", "crée les topics nommés ou requis par la configuration", cobra.ArbitraryArgs,
+		func(s *adminSession) error { return runAdminTopicsCreate(s, partitions, replication) })
+	create.Flags().IntVar(&partitions, "partitions", admin.DefaultPartitions, "nombre de partitions de chaque topic")
+	create.Flags().IntVar(&replication, "replication-factor", admin.DefaultReplicationFactor, "nombre de répliques de chaque partition")
+	topics := groupCommand("topics", "liste, décrit, crée ou supprime des topics",
+		leaf("list", "liste les topics", cobra.NoArgs,
+			func(s *adminSession) error { return runAdminTopicsList(s, false) }),
+		leaf("describe [topic...]", "décrit les partitions des topics", cobra.ArbitraryArgs,
+			func(s *adminSession) error { return runAdminTopicsList(s, true) }),
+		create,
+		leaf("delete <topic...>", "supprime les topics nommés", cobra.MinimumNArgs(1), runAdminTopicsDelete),
+	)
+
+	groups := groupCommand("groups", "liste ou décrit les groupes de consommateurs",
+		leaf("list", "liste les groupes de consommateurs", cobra.NoArgs, runAdminGroupsList),
+		leaf("describe [group...]", "décrit les membres des groupes", cobra.ArbitraryArgs, runAdminGroupsDescribe),
+	)
+
+	var showGroup string
+	show := leaf("show [topic...]", "affiche les offsets validés d'un groupe et son retard", cobra.ArbitraryArgs,
+		func(s *adminSession) error { return runAdminOffsetsShow(s, showGroup) })
+	show.Flags().StringVar(&showGroup, "group", "", "groupe de consommateurs (défaut : kafka.consumer_group)")
+	var reset adminResetOptions
+	resetCmd := leaf("reset --to <cible> [topic...]", "déplace les offsets validés d'un groupe", cobra.ArbitraryArgs,
+		func(s *adminSession) error { return runAdminOffsetsReset(s, reset) })
+	resetCmd.Flags().StringVar(&reset.group, "group", "", "groupe de consommateurs (défaut : kafka.consumer_group)")
+	resetCmd.Flags().StringVar(&reset.to, "to", "", "cible : earliest, latest, un offset, une date RFC 3339 ou une durée négative (ex. -15m)")
+	resetCmd.Flags().BoolVar(&reset.dryRun, "dry-run", false, "affiche les offsets cibles sans les appliquer")
+	offsets := groupCommand("offsets", "affiche ou réinitialise les offsets d'un groupe", show, resetCmd)
+
+	cmd := groupCommand("admin", "administre les topics, les groupes de consommateurs et leurs offsets Kafka", topics, groups, offsets)
+	cmd.PersistentFlags().DurationVar(&timeout, "timeout", admin.DefaultTimeout, "attente maximale de la réponse du cluster")
+	return cmd
+}
+
+// adminSession est une connexion de la commande admin au cluster.
+type adminSession struct {
+	cfg    *config.AppConfig  // Configuration de l'application.
+	client admin.Client       // Client d'administration.
+	ctx    context.Context    // Échéance des requêtes (--timeout).
+	cancel context.CancelFunc // Libère l'échéance.
+	args   []string           // Arguments restants (noms des topics ou des groupes).
+}
+
+// openAdmin charge la configuration d'une sous-commande admin, puis connecte
+// le client au cluster.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//   - timeout: L'attente maximale de la réponse du cluster (--timeout).
+//   - args: Les arguments de la sous-commande (noms des topics ou des groupes).
+//
+// Retourne:
+//   - *adminSession: La connexion (nil si une option de configuration comme --print-config a été traitée).
+//   - error: Une erreur si la configuration est invalide, si les brokers ne sont pas Kafka ou si le client ne peut pas être créé.
+func openAdmin(cfgFlags *config.Flags, timeout time.Duration, args []string) (*adminSession, error) {
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return nil, err
+	}
+	if !appCfg.Kafka.Brokers.IsKafka() {
+		return nil, errors.New("la commande admin requiert des brokers Kafka")
+	}
+	client, err := newAdminClient(appCfg.Kafka.Brokers.String(), appCfg.Security.Properties())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	return &adminSession{cfg: appCfg, client: client, ctx: ctx, cancel: cancel, args: args}, nil
+}
+
+// Close ferme la connexion au cluster.
+func (s *adminSession) Close() {
+	s.cancel()
+	s.client.Close()
+}
+
+// runAdminTopicsList exécute les commandes topics list et topics describe.
+//
+// Paramètres:
+//   - s: La connexion au cluster, dont les topics (défaut: tous).
+//   - details: Vrai pour décrire chaque partition (topics describe).
+//
+// Retourne:
+//   - error: Une erreur si le cluster ne répond pas.
+func runAdminTopicsList(s *adminSession, details bool) error {
+	topics, err := s.client.DescribeTopics(s.ctx, s.args)
+	if err != nil {
+		return fmt.Errorf("impossible de décrire les topics: %w", err)
+	}
+	if details {
+		return admin.WriteTopicDetails(os.Stdout, topics)
+	}
+	return admin.WriteTopics(os.Stdout, topics)
+}
+
+// runAdminTopicsCreate exécute la commande topics create.
+//
+// Paramètres:
+//   - s: La connexion au cluster, dont les topics (défaut: ceux de la configuration).
+//   - partitions: Le nombre de partitions de chaque topic.
+//   - replication: Le nombre de répliques de chaque partition.
+//
+// Retourne:
+//   - error: Une erreur si la requête échoue ou si un topic n'a pas pu être créé.
+func runAdminTopicsCreate(s *adminSession, partitions, replication int) error {
+	names := s.args
+	if len(names) == 0 {
+		names = upTopics(s.cfg)
+	}
+	specs := make([]admin.TopicSpec, len(names))
+	for i, name := range names {
+		specs[i] = admin.TopicSpec{Name: name, Partitions: partitions, ReplicationFactor: replication}
+	}
+	results, err := s.client.CreateTopics(s.ctx, specs)
+	if err != nil {
+		return fmt.Errorf("impossible de créer les topics: %w", err)
+	}
+	return topicResultsError(admin.WriteTopicResults(os.Stdout, "created", results))
+}
+
+// runAdminTopicsDelete exécute la commande topics delete.
+//
+// Paramètres:
+//   - s: La connexion au cluster, dont les topics.
+//
+// Retourne:
+//   - error: Une erreur si la requête échoue ou si un topic n'a pas pu être supprimé.
+func runAdminTopicsDelete(s *adminSession) error {
+	results, err := s.client.DeleteTopics(s.ctx, s.args)
+	if err != nil {
+		return fmt.Errorf("impossible de supprimer les topics: %w", err)
+	}
+	return topicResultsError(admin.WriteTopicResults(os.Stdout, "deleted", results))
+}
+
+// topicResultsError retourne l'erreur de l'affichage des résultats d'une
+// création ou d'une suppression de topics.
+//
+// Paramètres:
+//   - failed: Le nombre de topics en échec.
+//   - err: L'erreur d'écriture.
+//
+// Retourne:
+//   - error: L'erreur d'écriture, ou une erreur si un topic est en échec.
+func topicResultsError(failed int, err error) error {
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d topic(s) en échec", failed)
+	}
+	return nil
+}
+
+// runAdminGroupsList exécute la commande groups list.
+//
+// Paramètres:
+//   - s: La connexion au cluster.
+//
+// Retourne:
+//   - error: Une erreur si le cluster ne répond pas.
+func runAdminGroupsList(s *adminSession) error {
+	groups, err := s.client.ListGroups(s.ctx)
+	if err != nil {
+		return fmt.Errorf("impossible de lister les groupes de consommateurs: %w", err)
+	}
+	return admin.WriteGroups(os.Stdout, groups)
+}
+
+// runAdminGroupsDescribe exécute la commande groups describe.
+//
+// Paramètres:
+//   - s: La connexion au cluster, dont les groupes (défaut: kafka.consumer_group).
+//
+// Retourne:
+//   - error: Une erreur si le cluster ne répond pas.
+func runAdminGroupsDescribe(s *adminSession) error {
+	ids := s.args
+	if len(ids) == 0 {
+		ids = []string{s.cfg.Kafka.ConsumerGroup}
+	}
+	groups, err := s.client.DescribeGroups(s.ctx, ids)
+	if err != nil {
+		return fmt.Errorf("impossible de décrire les groupes de consommateurs: %w", err)
+	}
+	return admin.WriteGroupDetails(os.Stdout, groups)
+}
+
+// runAdminOffsetsShow exécute la commande offsets show.
+//
+// Paramètres:
+//   - s: La connexion au cluster, dont les topics (défaut: kafka.topic).
+//   - group: Le groupe de consommateurs (vide: kafka.consumer_group).
+//
+// Retourne:
+//   - error: Une erreur si un topic est inconnu ou si le cluster ne répond pas.
+func runAdminOffsetsShow(s *adminSession, group string) error {
+	id, topics := s.offsetsScope(group)
+	offsets, err := s.client.GroupOffsets(s.ctx, id, topics)
+	if err != nil {
+		return fmt.Errorf("impossible de lire les offsets du groupe %s: %w", id, err)
+	}
+	fmt.Printf("Offsets du groupe %s\n", id)
+	return admin.WriteOffsets(os.Stdout, offsets)
+}
+
+// adminResetOptions regroupe les options de la commande offsets reset.
+type adminResetOptions struct {
+	group  string // Groupe de consommateurs (vide: kafka.consumer_group).
+	to     string // Cible (earliest, latest, offset, date RFC 3339 ou durée négative).
+	dryRun bool   // Affiche les offsets cibles sans les appliquer.
+}
+
+// runAdminOffsetsReset exécute la commande offsets reset.
+//
+// Paramètres:
+//   - s: La connexion au cluster, dont les topics (défaut: kafka.topic).
+//   - opts: Les options de la commande.
+//
+// Retourne:
+//   - error: Une erreur si la cible est invalide, si le groupe a des membres actifs ou si les offsets ne peuvent pas être validés.
+func runAdminOffsetsReset(s *adminSession, opts adminResetOptions) error {
+	if opts.to == "" {
+		return errors.New("une cible est requise (--to)")
+	}
+	target, err := admin.ParseResetTarget(opts.to, time.Now())
+	if err != nil {
+		return err
+	}
+	id, topics := s.offsetsScope(opts.group)
+	offsets, err := s.client.GroupOffsets(s.ctx, id, topics)
+	if err != nil {
+		return fmt.Errorf("impossible de lire les offsets du groupe %s: %w", id, err)
+	}
+	var byTime map[admin.TopicPartition]int64
+	if target.Kind == admin.ResetTimestamp {
+		partitions := make([]admin.TopicPartition, len(offsets))
+		for i, p := range offsets {
+			partitions[i] = p.TopicPartition
+		}
+		if byTime, err = s.client.OffsetsForTime(s.ctx, partitions, target.Time); err != nil {
+			return fmt.Errorf("impossible de rechercher les offsets au %s: %w", target.Time.Format(time.RFC3339), err)
+		}
+	}
+	resets := admin.PlanReset(offsets, target, byTime)
+	fmt.Printf("🔁 Réinitialisation du groupe %s vers %s\n", id, target)
+	if err := admin.WriteResets(os.Stdout, resets); err != nil {
+		return err
+	}
+	if opts.dryRun {
+		fmt.Println("ℹ️  --dry-run : aucun offset modifié")
+		return nil
+	}
+
+	groups, err := s.client.DescribeGroups(s.ctx, []string{id})
+	if err != nil {
+		return fmt.Errorf("impossible de décrire le groupe %s: %w", id, err)
+	}
+	for _, g := range groups {
+		if g.Err == nil && len(g.Members) > 0 {
+			return fmt.Errorf("le groupe %s a %d membre(s) actif(s) : arrêtez le tracker avant de réinitialiser ses offsets", id, len(g.Members))
+		}
+	}
+	if err := s.client.CommitOffsets(s.ctx, id, resets); err != nil {
+		return fmt.Errorf("impossible de réinitialiser les offsets du groupe %s: %w", id, err)
+	}
+	fmt.Printf("✅ Offsets du groupe %s réinitialisés\n", id)
+	return nil
+}
+
+// offsetsScope retourne le groupe et les topics des commandes offsets.
+//
+// Paramètres:
+//   - group: Le groupe de l'option --group (vide: kafka.consumer_group).
+//
+// Retourne:
+//   - string: Le groupe de consommateurs.
+//   - []string: Les topics nommés, ou kafka.topic.
+func (s *adminSession) offsetsScope(group string) (string, []string) {
+	if group == "" {
+		group = s.cfg.Kafka.ConsumerGroup
+	}
+	topics := s.args
+	if len(topics) == 0 {
+		topics = []string{s.cfg.Kafka.Topic}
+	}
+	return group, topics
+}
@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/admin"
+)
+
+// fakeAdminClient enregistre les requêtes de la commande admin.
+type fakeAdminClient struct {
+	created []admin.TopicSpec
+	deleted []string
+	groups  []string
+	members int
+	resets  []admin.OffsetReset
+	closed  bool
+}
+
+func (f *fakeAdminClient) CreateTopics(ctx context.Context, specs []admin.TopicSpec) ([]admin.TopicResult, error) {
+	f.created = append(f.created, specs...)
+	results := make([]admin.TopicResult, len(specs))
+	for i, s := range specs {
+		results[i].Topic = s.Name
+	}
+	return results, nil
+}
+
+func (f *fakeAdminClient) DeleteTopics(ctx context.Context, names []string) ([]admin.TopicResult, error) {
+	f.deleted = append(f.deleted, names...)
+	return []admin.TopicResult{{Topic: names[0], Err: errors.New("refusé")}}, nil
+}
+
+func (f *fakeAdminClient) DescribeTopics(ctx context.Context, names []string) ([]admin.TopicInfo, error) {
+	return []admin.TopicInfo{{Name: "orders"}}, nil
+}
+
+func (f *fakeAdminClient) ListGroups(ctx context.Context) ([]admin.GroupListing, error) {
+	return nil, nil
+}
+
+func (f *fakeAdminClient) DescribeGroups(ctx context.Context, ids []string) ([]admin.GroupDescription, error) {
+	f.groups = append(f.groups, ids...)
+	groups := make([]admin.GroupDescription, len(ids))
+	for i, id := range ids {
+		groups[i] = admin.GroupDescription{ID: id, Members: make([]admin.GroupMember, f.members)}
+	}
+	return groups, nil
+}
+
+func (f *fakeAdminClient) GroupOffsets(ctx context.Context, group string, topics []string) ([]admin.PartitionOffsets, error) {
+	offsets := make([]admin.PartitionOffsets, len(topics))
+	for i, topic := range topics {
+		offsets[i] = admin.PartitionOffsets{TopicPartition: admin.TopicPartition{Topic: topic}, Committed: 80, Earliest: 10, Latest: 100}
+	}
+	return offsets, nil
+}
+
+func (f *fakeAdminClient) OffsetsForTime(ctx context.Context, partitions []admin.TopicPartition, t time.Time) (map[admin.TopicPartition]int64, error) {
+	offsets := make(map[admin.TopicPartition]int64)
+	for _, tp := range partitions {
+		offsets[tp] = 50
+	}
+	return offsets, nil
+}
+
+func (f *fakeAdminClient) CommitOffsets(ctx context.Context, group string, resets []admin.OffsetReset) error {
+	f.resets = append(f.resets, resets...)
+	return nil
+}
+
+func (f *fakeAdminClient) Close() {
+	f.closed = true
+}
+
+// useFakeAdmin remplace le client d'administration pendant un test.
+func useFakeAdmin(t *testing.T) *fakeAdminClient {
+	fake := &fakeAdminClient{}
+	original := newAdminClient
+	t.Cleanup(func() { newAdminClient = original })
+	newAdminClient = func(brokers string, properties map[string]string) (admin.Client, error) {
+		return fake, nil
+	}
+	return fake
+}
+
+// TestAdminTopicsCreate vérifie la création des topics requis par la configuration.
+func TestAdminTopicsCreate(t *testing.T) {
+	fake := useFakeAdmin(t)
+	err := execute([]string{"admin", "topics", "create", "--partitions", "3", "--retry.topics.enabled", "--dlq.topic", "orders-dlq"})
+	if err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+	var names []string
+	for _, spec := range fake.created {
+		names = append(names, spec.Name)
+		if spec.Partitions != 3 || spec.ReplicationFactor != admin.DefaultReplicationFactor {
+			t.Errorf("Attendu 3 partitions et la réplication par défaut, reçu %+v", spec)
+		}
+	}
+	if got := strings.Join(names, ","); got != "orders,orders-retry-1m,orders-retry-5m,orders-dlq,orders-dlq-parked" {
+		t.Errorf("Topics inattendus: %s", got)
+	}
+	if !fake.closed {
+		t.Error("Attendu la fermeture du client")
+	}
+}
+
+// TestAdminTopicsDelete vérifie qu'un topic en échec fait échouer la suppression.
+func TestAdminTopicsDelete(t *testing.T) {
+	fake := useFakeAdmin(t)
+	if err := execute([]string{"admin", "topics", "delete"}); err == nil {
+		t.Error("Attendu une erreur sans topic")
+	}
+	if err := execute([]string{"admin", "topics", "delete", "orders-dlq"}); err == nil || !strings.Contains(err.Error(), "1 topic(s) en échec") {
+		t.Errorf("Attendu un topic en échec, reçu %v", err)
+	}
+	if len(fake.deleted) != 1 || fake.deleted[0] != "orders-dlq" {
+		t.Errorf("Suppressions inattendues: %v", fake.deleted)
+	}
+}
+
+// TestAdminGroupsDescribe vérifie que le groupe par défaut est celui du tracker.
+func TestAdminGroupsDescribe(t *testing.T) {
+	fake := useFakeAdmin(t)
+	if err := execute([]string{"admin", "groups", "describe", "--kafka.consumer_group", "tracker"}); err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+	if len(fake.groups) != 1 || fake.groups[0] != "tracker" {
+		t.Errorf("Groupes inattendus: %v", fake.groups)
+	}
+	if err := execute([]string{"admin", "groups", "describe", "--kafka.broker", "memory://"}); err == nil {
+		t.Error("Attendu une erreur avec le broker en mémoire")
+	}
+	if err := execute([]string{"admin", "groups"}); !errors.Is(err, errUsage) {
+		t.Errorf("Attendu errUsage, reçu %v", err)
+	}
+}
+
+// TestAdminOffsetsReset vérifie l'aperçu, l'application et le refus d'une réinitialisation.
+func TestAdminOffsetsReset(t *testing.T) {
+	fake := useFakeAdmin(t)
+	if err := execute([]string{"admin", "offsets", "reset", "--to", "earliest", "--dry-run"}); err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+	if len(fake.resets) != 0 || len(fake.groups) != 0 {
+		t.Errorf("Attendu aucune modification avec --dry-run, reçu %+v", fake.resets)
+	}
+
+	if err := execute([]string{"admin", "offsets", "reset", "--to", "-1h", "--group", "replay", "orders-dlq"}); err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+	if len(fake.resets) != 1 || fake.resets[0].Topic != "orders-dlq" || fake.resets[0].Target != 50 {
+		t.Errorf("Réinitialisations inattendues: %+v", fake.resets)
+	}
+	if len(fake.groups) != 1 || fake.groups[0] != "replay" {
+		t.Errorf("Attendu la vérification du groupe replay, reçu %v", fake.groups)
+	}
+
+	fake.members = 1
+	if err := execute([]string{"admin", "offsets", "reset", "--to", "latest"}); err == nil || !strings.Contains(err.Error(), "membre(s) actif(s)") {
+		t.Errorf("Attendu un refus avec un membre actif, reçu %v", err)
+	}
+	if err := execute([]string{"admin", "offsets", "reset"}); err == nil {
+		t.Error("Attendu une erreur sans cible")
+	}
+	if err := execute([]string{"admin", "offsets", "reset", "--to", "hier"}); err == nil {
+		t.Error("Attendu une erreur avec une cible invalide")
+	}
+}
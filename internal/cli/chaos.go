@@ -0,0 +1,247 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/chaos"
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+// newChaosCommand crée la commande chaos, qui injecte des pannes dans la
+// démonstration pour montrer le comportement des relances, de la DLQ et du
+// moniteur :
+//
+//   - run exécute un scénario (voir chaos.Scenario), une suite de pannes
+//     programmées relativement à son démarrage.
+//   - inject injecte une seule panne pendant --for.
+//   - status affiche les pannes programmées et celles qui sont actives.
+//   - clear retire les pannes programmées (après l'arrêt brutal de run).
+//
+// Les pannes sont écrites dans chaos.state_file, lu par le producteur
+// (payload_corrupt) et le tracker (consumer_delay, consumer_panic, commit_drop)
+// lancés avec chaos.enabled. Les pannes broker_pause suspendent le conteneur
+// chaos.broker_container (docker pause), qui est toujours relancé à la fin. run
+// et inject durent jusqu'à la fin de la dernière panne, ou jusqu'à SIGINT ou
+// SIGTERM, puis retirent les pannes.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande et ses sous-commandes.
+func newChaosCommand(cfgFlags *config.Flags) *cobra.Command {
+	runCmd := &cobra.Command{
+		Use:   "run <scenario.yaml>",
+		Short: "exécute un scénario de pannes programmées",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runChaosScenario(cfgFlags, args[0])
+		},
+	}
+
+	var opts chaosInjectOptions
+	injectCmd := &cobra.Command{
+		Use:   "inject <panne>",
+		Short: "injecte une seule panne pendant --for",
+		Long:  "Injecte une seule panne pendant --for.\n\nPannes: broker_pause, consumer_delay, consumer_panic, commit_drop, payload_corrupt.",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runChaosInject(cfgFlags, args[0], opts)
+		},
+	}
+	injectCmd.Flags().DurationVar(&opts.duration, "for", time.Minute, "durée de la panne")
+	injectCmd.Flags().DurationVar(&opts.delay, "delay", 500*time.Millisecond, "délai de traitement de consumer_delay")
+	injectCmd.Flags().Float64Var(&opts.percent, "percent", 0, "part des messages touchés, en pourcentage (0 : tous)")
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "affiche les pannes programmées et actives",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runChaosStatus(cfgFlags)
+		},
+	}
+	clearCmd := &cobra.Command{
+		Use:   "clear",
+		Short: "retire les pannes programmées",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runChaosClear(cfgFlags)
+		},
+	}
+	return groupCommand("chaos", "injecte des pannes programmées (run, inject, status, clear)", runCmd, injectCmd, statusCmd, clearCmd)
+}
+
+// chaosInjectOptions regroupe les options de la commande chaos inject.
+type chaosInjectOptions struct {
+	duration time.Duration // Durée de la panne.
+	delay    time.Duration // Délai de traitement de consumer_delay.
+	percent  float64       // Part des messages touchés, en pourcentage (0 : tous).
+}
+
+// runChaosScenario exécute la commande chaos run.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//   - path: Le fichier du scénario.
+//
+// Retourne:
+//   - error: Une erreur si la configuration ou le scénario est invalide, ou si l'injection échoue.
+func runChaosScenario(cfgFlags *config.Flags, path string) error {
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+	scenario, err := chaos.LoadScenario(path)
+	if err != nil {
+		return err
+	}
+	name := scenario.Name
+	if name == "" {
+		name = path
+	}
+	fmt.Printf("💥 Scénario de chaos %s (%d panne(s))\n", name, len(scenario.Steps))
+	return runChaos(appCfg, scenario.Schedule(time.Now()))
+}
+
+// runChaosInject exécute la commande chaos inject.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//   - kind: La panne (ex.: broker_pause).
+//   - opts: Les options de la commande.
+//
+// Retourne:
+//   - error: Une erreur si la configuration ou la panne est invalide, ou si l'injection échoue.
+func runChaosInject(cfgFlags *config.Flags, kind string, opts chaosInjectOptions) error {
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+	now := time.Now()
+	fault := chaos.Fault{Kind: kind, Percent: opts.percent, From: now, Until: now.Add(opts.duration)}
+	if fault.Kind == chaos.FaultConsumerDelay {
+		fault.Delay = opts.delay
+	}
+	if err := fault.Validate(); err != nil {
+		return err
+	}
+	return runChaos(appCfg, []chaos.Fault{fault})
+}
+
+// runChaos injecte des pannes programmées jusqu'à la fin de la dernière, ou
+// jusqu'à SIGINT ou SIGTERM.
+//
+// Paramètres:
+//   - cfg: La configuration de l'application.
+//   - faults: Les pannes programmées.
+//
+// Retourne:
+//   - error: Une erreur si le fichier d'état ou le conteneur du broker ne peut pas être piloté.
+func runChaos(cfg *config.AppConfig, faults []chaos.Fault) error {
+	if !cfg.Chaos.Enabled {
+		fmt.Fprintln(os.Stderr, "ℹ️  chaos.enabled est désactivé : seuls le producteur et le tracker lancés avec --chaos.enabled appliquent les pannes")
+	}
+	for _, f := range faults {
+		fmt.Printf("   %s → %s : %s\n", f.From.Format("15:04:05"), f.Until.Format("15:04:05"), f)
+	}
+	var broker chaos.BrokerControl
+	if cfg.Chaos.BrokerContainer != "" {
+		broker = dockerBroker{container: cfg.Chaos.BrokerContainer}
+	}
+
+	ctx, stop := lifecycle.SignalContext(context.Background())
+	defer stop()
+	if err := chaos.Run(ctx, cfg.Chaos.StateFile, faults, broker); err != nil {
+		return err
+	}
+	fmt.Println("✅ Pannes retirées de", cfg.Chaos.StateFile)
+	return nil
+}
+
+// runChaosStatus exécute la commande chaos status.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//
+// Retourne:
+//   - error: Une erreur si la configuration est invalide ou si le fichier d'état ne peut pas être lu.
+func runChaosStatus(cfgFlags *config.Flags) error {
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+	state, err := chaos.ReadState(appCfg.Chaos.StateFile)
+	if err != nil {
+		return err
+	}
+	if len(state.Faults) == 0 {
+		fmt.Println("Aucune panne programmée dans", appCfg.Chaos.StateFile)
+		return nil
+	}
+	now := time.Now()
+	for _, f := range state.Faults {
+		status := "programmée"
+		switch {
+		case f.Active(now):
+			status = fmt.Sprintf("active (encore %s)", f.Until.Sub(now).Round(time.Second))
+		case !now.Before(f.Until):
+			status = "terminée"
+		}
+		fmt.Printf("%s → %s  %-40s %s\n", f.From.Format("15:04:05"), f.Until.Format("15:04:05"), f, status)
+	}
+	return nil
+}
+
+// runChaosClear exécute la commande chaos clear.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//
+// Retourne:
+//   - error: Une erreur si la configuration est invalide ou si le fichier d'état ne peut pas être écrit.
+func runChaosClear(cfgFlags *config.Flags) error {
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+	if err := chaos.WriteState(appCfg.Chaos.StateFile, chaos.State{}); err != nil {
+		return err
+	}
+	fmt.Println("✅ Pannes retirées de", appCfg.Chaos.StateFile)
+	return nil
+}
+
+// dockerBroker suspend et relance le conteneur du broker (docker pause).
+type dockerBroker struct {
+	container string // Nom du conteneur.
+}
+
+// Pause suspend le conteneur.
+//
+// Retourne:
+//   - error: Une erreur si docker pause échoue.
+func (b dockerBroker) Pause() error {
+	fmt.Println("⏸️  Suspension du conteneur", b.container, "(docker pause)...")
+	if err := runCommand("docker", "pause", b.container); err != nil {
+		return fmt.Errorf("impossible de suspendre le conteneur %s: %w", b.container, err)
+	}
+	return nil
+}
+
+// Resume relance le conteneur.
+//
+// Retourne:
+//   - error: Une erreur si docker unpause échoue.
+func (b dockerBroker) Resume() error {
+	fmt.Println("▶️  Reprise du conteneur", b.container, "(docker unpause)...")
+	if err := runCommand("docker", "unpause", b.container); err != nil {
+		return fmt.Errorf("impossible de relancer le conteneur %s: %w", b.container, err)
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+/*
+Package cli regroupe les commandes du système PubSub de démonstration Kafka.
+
+Elles sont exposées par le binaire unique pubsub:
+
+	pubsub produce [options]     Producteur de commandes.
+	pubsub track [options]       Tracker (consommateur) des commandes.
+	pubsub monitor [options]     Moniteur TUI des logs (sous-commandes analyze et play).
+	pubsub config [options]      Opérations sur la configuration (--print-config par défaut).
+	pubsub dlq <commande>        Administration de la Dead Letter Queue (replay, browse, reprocess).
+	pubsub demo [options]        Tracker et producteur dans un même processus (broker memory:// possible).
+	pubsub up [options]          Démarrage de la démonstration complète (Kafka, topics et services).
+	pubsub loadtest [options]    Test de charge du broker (débit, latence, pertes et doublons).
+	pubsub latency [options]     Sonde de latence de livraison par partition (panneau du moniteur).
+	pubsub chaos <commande>      Injection de pannes (broker, consommateur, commits, messages).
+	pubsub admin <commande>      Administration du cluster Kafka (topics, groupes, offsets).
+	pubsub web [options]         Tableau de bord web (producteur, tracker, événements, DLQ).
+	pubsub gateway [options]     Passerelle HTTP d'ingestion de commandes (POST /v1/orders).
+	pubsub events [options]      Flux gRPC des événements du tracker (SubscribeEvents).
+
+Les binaires historiques (cmd/producer, cmd/tracker, cmd/monitor, cmd/dlqctl)
+restent disponibles : ils exécutent la commande correspondante avec leurs
+arguments.
+
+Les options de configuration partagées (--config, --<chemin.yaml>,
+--print-config, --check-config...) sont des options persistantes de la
+commande racine : acceptées avant ou après le nom de la commande, elles sont
+traitées de la même façon par toutes les commandes. pubsub help <commande>
+affiche l'usage et les options d'une commande.
+*/
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/spf13/cobra"
+)
+
+// errUsage signale des arguments invalides, déjà expliqués par l'usage affiché.
+var errUsage = errors.New("usage invalide")
+
+// Main exécute la commande désignée par le premier argument.
+//
+// Paramètres:
+//   - args: Les arguments du binaire, sans son nom.
+//
+// Retourne:
+//   - int: Le code de sortie (0 en cas de succès, 1 en cas d'erreur, 2 pour un usage invalide).
+func Main(args []string) int {
+	if err := execute(args); err != nil {
+		if errors.Is(err, errUsage) {
+			return 2
+		}
+		fmt.Println(err)
+		return 1
+	}
+	return 0
+}
+
+// Run exécute une commande par son nom (binaires historiques de cmd/).
+//
+// Paramètres:
+//   - name: Le nom de la commande (ex.: "produce").
+//   - args: Les arguments de la commande.
+//
+// Retourne:
+//   - int: Le code de sortie (0 en cas de succès, 1 en cas d'erreur, 2 pour un usage invalide).
+func Run(name string, args []string) int {
+	return Main(append([]string{name}, args...))
+}
+
+// execute construit l'arbre des commandes et exécute celle désignée par les arguments.
+//
+// Paramètres:
+//   - args: Les arguments du binaire, sans son nom.
+//
+// Retourne:
+//   - error: L'erreur de la commande, ou errUsage si les arguments sont invalides.
+func execute(args []string) error {
+	root := newRootCommand()
+	// cobra lit os.Args lorsque les arguments sont nil
+	root.SetArgs(append([]string{}, args...))
+	return root.Execute()
+}
+
+// newRootCommand crée la commande racine pubsub. Les options de configuration
+// partagées y sont enregistrées comme options persistantes, liées à un même
+// *config.Flags transmis à chaque commande.
+//
+// Retourne:
+//   - *cobra.Command: La commande racine et ses sous-commandes.
+func newRootCommand() *cobra.Command {
+	goFlags := flag.NewFlagSet("pubsub", flag.ContinueOnError)
+	cfgFlags := config.RegisterFlags(goFlags)
+
+	// L'usage liste les commandes dans l'ordre de leur ajout
+	cobra.EnableCommandSorting = false
+	root := groupCommand("pubsub", "Système PubSub de démonstration Kafka",
+		newProduceCommand(cfgFlags),
+		newTrackCommand(cfgFlags),
+		newMonitorCommand(cfgFlags),
+		newConfigCommand(cfgFlags),
+		newDLQCommand(cfgFlags),
+		newDemoCommand(cfgFlags),
+		newUpCommand(cfgFlags),
+		newLoadTestCommand(cfgFlags),
+		newLatencyCommand(cfgFlags),
+		newChaosCommand(cfgFlags),
+		newAdminCommand(cfgFlags),
+		newWebCommand(cfgFlags),
+		newGatewayCommand(cfgFlags),
+		newEventsCommand(cfgFlags),
+	)
+	root.PersistentFlags().AddGoFlagSet(goFlags)
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	root.CompletionOptions.DisableDefaultCmd = true
+	root.SetFlagErrorFunc(usageError)
+	return root
+}
+
+// groupCommand crée une commande qui ne fait que regrouper des sous-commandes :
+// sans sous-commande, ou avec une sous-commande inconnue, elle affiche son
+// usage et retourne errUsage.
+//
+// Paramètres:
+//   - use: Le nom de la commande.
+//   - short: Le résumé affiché par l'usage.
+//   - subs: Les sous-commandes.
+//
+// Retourne:
+//   - *cobra.Command: La commande.
+func groupCommand(use, short string, subs ...*cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				name := args[0]
+				if cmd.HasParent() {
+					name = cmd.Name() + " " + name
+				}
+				cmd.PrintErrf("commande inconnue: %s\n", name)
+			}
+			cmd.Usage()
+			return errUsage
+		},
+	}
+	cmd.AddCommand(subs...)
+	return cmd
+}
+
+// usageArgs convertit l'erreur d'une validation des arguments positionnels en
+// usage invalide.
+//
+// Paramètres:
+//   - validate: La validation (ex.: cobra.ExactArgs(1)).
+//
+// Retourne:
+//   - cobra.PositionalArgs: La validation, qui affiche l'usage en cas d'erreur.
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return usageError(cmd, err)
+		}
+		return nil
+	}
+}
+
+// usageError affiche une erreur d'arguments suivie de l'usage de la commande.
+//
+// Paramètres:
+//   - cmd: La commande.
+//   - err: L'erreur d'arguments ou d'options.
+//
+// Retourne:
+//   - error: errUsage.
+func usageError(cmd *cobra.Command, err error) error {
+	cmd.PrintErrln(err)
+	cmd.Usage()
+	return errUsage
+}
+
+// handleConfigActions exécute les opérations de configuration qui précèdent le
+// chargement : --init-config, --encrypt et --print-schema.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//
+// Retourne:
+//   - bool: Vrai si une opération a été exécutée et que la commande doit s'arrêter.
+//   - error: Une erreur si l'opération échoue.
+func handleConfigActions(cfgFlags *config.Flags) (bool, error) {
+	switch {
+	case cfgFlags.InitConfig:
+		// Écrire un fichier de configuration commenté avec les valeurs par défaut et quitter
+		if err := config.InitSample(cfgFlags.InitPath()); err != nil {
+			return true, fmt.Errorf("Erreur lors de la création de la configuration: %w", err)
+		}
+		fmt.Println("Configuration écrite dans", cfgFlags.InitPath())
+		return true, nil
+	case cfgFlags.Encrypt != "":
+		// Afficher la forme chiffrée (enc:) d'un secret et quitter
+		value, err := config.EncryptValue(cfgFlags.Encrypt)
+		if err != nil {
+			return true, fmt.Errorf("Erreur lors du chiffrement: %w", err)
+		}
+		fmt.Println(value)
+		return true, nil
+	case cfgFlags.PrintSchema:
+		// Afficher le schéma JSON du fichier de configuration et quitter
+		return true, config.WriteSchema(os.Stdout)
+	}
+	return false, nil
+}
+
+// handleConfigReports exécute les opérations de configuration qui suivent le
+// chargement : --print-config et --check-config.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//   - cfg: La configuration effective.
+//   - sources: L'origine de chaque paramètre.
+//
+// Retourne:
+//   - bool: Vrai si une opération a été exécutée et que la commande doit s'arrêter.
+//   - error: Une erreur si l'opération échoue ou si la configuration est invalide.
+func handleConfigReports(cfgFlags *config.Flags, cfg *config.AppConfig, sources config.Sources) (bool, error) {
+	switch {
+	case cfgFlags.PrintConfig:
+		// Afficher la configuration effective et quitter
+		return true, config.WriteEffective(os.Stdout, cfg, sources)
+	case cfgFlags.CheckConfig:
+		// Vérifier la configuration effective (brokers, topics, fichiers, registre) et quitter
+		results := cfg.Check()
+		config.WriteCheckReport(os.Stdout, results)
+		if !config.CheckPassed(results) {
+			return true, errors.New("Configuration invalide")
+		}
+		fmt.Println("Configuration valide")
+		return true, nil
+	}
+	return false, nil
+}
+
+// loadConfig exécute les opérations de configuration partagées et charge la
+// configuration effective.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//   - override: Applique les options propres à la commande avant --print-config et --check-config (nil si aucune).
+//
+// Retourne:
+//   - *config.AppConfig: La configuration effective (nil si la commande doit s'arrêter).
+//   - error: Une erreur si une opération échoue ou si la configuration ne peut pas être chargée.
+func loadConfig(cfgFlags *config.Flags, override func(*config.AppConfig, config.Sources)) (*config.AppConfig, error) {
+	if done, err := handleConfigActions(cfgFlags); done {
+		return nil, err
+	}
+	cfg, err := cfgFlags.Load()
+	if err != nil {
+		return nil, fmt.Errorf("Erreur lors du chargement de la configuration: %w", err)
+	}
+	sources := cfgFlags.Sources()
+	if override != nil {
+		override(cfg, sources)
+	}
+	if done, err := handleConfigReports(cfgFlags, cfg, sources); done {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// logConfigFile affiche le fichier de configuration chargé.
+//
+// Paramètres:
+//   - path: Le fichier chargé ("" si les valeurs par défaut sont utilisées).
+func logConfigFile(path string) {
+	if path == "" {
+		fmt.Println(i18n.T("common.config_defaults"))
+		return
+	}
+	fmt.Println(i18n.T("common.config_file", path))
+}
+
+// reportRemoteChange signale une mise à jour de la configuration distante,
+// appliquée au prochain redémarrage.
+//
+// Paramètres:
+//   - cfg: La nouvelle configuration (nil si elle est invalide).
+//   - err: L'erreur de chargement ou de validation.
+func reportRemoteChange(cfg *config.AppConfig, err error) {
+	if err != nil {
+		fmt.Println(i18n.T("common.remote_invalid", err))
+		return
+	}
+	fmt.Println(i18n.T("common.remote_changed"))
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRunUnknownCommand vérifie qu'une commande inconnue est un usage invalide.
+func TestRunUnknownCommand(t *testing.T) {
+	if code := Run("inconnue", nil); code != 2 {
+		t.Errorf("Attendu le code de sortie 2, reçu %d", code)
+	}
+	if code := Main(nil); code != 2 {
+		t.Errorf("Attendu le code de sortie 2 sans commande, reçu %d", code)
+	}
+}
+
+// TestUsageListsCommands vérifie que l'usage liste chaque commande et les
+// options de configuration persistantes.
+func TestUsageListsCommands(t *testing.T) {
+	root := newRootCommand()
+	var buf bytes.Buffer
+	root.SetOut(&buf)
+	root.SetArgs([]string{"--help"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+	for _, name := range []string{"produce", "track", "monitor", "config", "dlq", "demo", "up", "loadtest", "latency", "chaos", "admin", "web", "gateway", "events"} {
+		if cmd, _, err := root.Find([]string{name}); err != nil || cmd.Name() != name {
+			t.Errorf("Commande %s introuvable", name)
+		}
+		if !strings.Contains(buf.String(), "  "+name+" ") {
+			t.Errorf("Attendu la commande %s dans l'usage, reçu:\n%s", name, buf.String())
+		}
+	}
+	if !strings.Contains(buf.String(), "--kafka.broker") {
+		t.Errorf("Attendu les options de configuration dans l'usage, reçu:\n%s", buf.String())
+	}
+}
+
+// TestPersistentConfigFlags vérifie que les options de configuration sont
+// acceptées avant comme après le nom de la commande.
+func TestPersistentConfigFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"--kafka.broker", "memory://", "config", "--print-schema"},
+		{"config", "--kafka.broker=memory://", "--print-schema"},
+	} {
+		if err := execute(args); err != nil {
+			t.Errorf("%v: erreur inattendue: %v", args, err)
+		}
+	}
+	if err := execute([]string{"config", "--inconnue"}); !errors.Is(err, errUsage) {
+		t.Errorf("Attendu errUsage pour une option inconnue, reçu %v", err)
+	}
+}
+
+// TestDLQWithoutSubcommand vérifie que la commande dlq exige une sous-commande.
+func TestDLQWithoutSubcommand(t *testing.T) {
+	if code := Run("dlq", nil); code != 2 {
+		t.Errorf("Attendu le code de sortie 2, reçu %d", code)
+	}
+	if code := Run("dlq", []string{"purge"}); code != 2 {
+		t.Errorf("Attendu le code de sortie 2 pour une sous-commande inconnue, reçu %d", code)
+	}
+}
+
+// TestConfigPrintSchema vérifie que la commande config exécute les opérations
+// de configuration partagées.
+func TestConfigPrintSchema(t *testing.T) {
+	if err := execute([]string{"config", "--print-schema"}); err != nil {
+		t.Errorf("Erreur inattendue: %v", err)
+	}
+}
+
+// TestLoadTestMemoryBroker vérifie un test de charge court sur le broker en
+// mémoire et l'écriture de son rapport JSON.
+func TestLoadTestMemoryBroker(t *testing.T) {
+	report := filepath.Join(t.TempDir(), "report.json")
+	err := execute([]string{"loadtest", "--kafka.broker", "memory://", "--kafka.topic", "loadtest-cli",
+		"--rate", "500", "--duration", "100ms", "--drain", "5s", "--report", report})
+	if err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+	data, err := os.ReadFile(report)
+	if err != nil {
+		t.Fatalf("Rapport JSON absent: %v", err)
+	}
+	if !strings.Contains(string(data), `"sent": 50`) || !strings.Contains(string(data), `"lost": 0`) {
+		t.Errorf("Rapport inattendu:\n%s", data)
+	}
+}
+
+// TestLatencyMemoryBroker vérifie une mesure courte de la sonde de latence sur
+// le broker en mémoire, le flux des fenêtres et l'écriture de la synthèse.
+func TestLatencyMemoryBroker(t *testing.T) {
+	dir := t.TempDir()
+	stream := filepath.Join(dir, "logs", "latency.jsonl")
+	summary := filepath.Join(dir, "latency-summary.json")
+	err := execute([]string{"latency", "--kafka.broker", "memory://", "--kafka.topic", "latency-cli",
+		"--interval", "50ms", "--duration", "200ms", "--stream", stream, "--summary", summary})
+	if err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+	if data, err := os.ReadFile(stream); err != nil || !strings.Contains(string(data), `"topic":"latency-cli"`) {
+		t.Errorf("Flux inattendu (%v):\n%s", err, data)
+	}
+	if data, err := os.ReadFile(summary); err != nil || !strings.Contains(string(data), `"partitions": []`) {
+		t.Errorf("Synthèse inattendue (%v):\n%s", err, data)
+	}
+}
+
+// TestChaosInject vérifie l'injection d'une panne broker_pause : le conteneur
+// est suspendu puis relancé, et les pannes sont retirées à la fin.
+func TestChaosInject(t *testing.T) {
+	var calls []string
+	original := runCommand
+	t.Cleanup(func() { runCommand = original })
+	runCommand = func(name string, args ...string) error {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		return nil
+	}
+
+	stateFile := filepath.Join(t.TempDir(), "chaos.json")
+	err := execute([]string{"chaos", "inject", "--for", "50ms", "--chaos.state_file", stateFile, "--chaos.broker_container", "broker", "broker_pause"})
+	if err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+	if got := strings.Join(calls, ","); got != "docker pause broker,docker unpause broker" {
+		t.Errorf("Attendu la suspension puis la reprise du conteneur, reçu %s", got)
+	}
+	if data, err := os.ReadFile(stateFile); err != nil || !strings.Contains(string(data), `"faults": null`) {
+		t.Errorf("Attendu un fichier d'état vide (%v):\n%s", err, data)
+	}
+
+	if err := execute([]string{"chaos", "inject", "--chaos.state_file", stateFile, "broker_kill"}); err == nil {
+		t.Error("Attendu une erreur pour une panne inconnue")
+	}
+	if err := execute([]string{"chaos", "status", "--chaos.state_file", stateFile}); err != nil {
+		t.Errorf("Erreur inattendue: %v", err)
+	}
+	if err := execute([]string{"chaos", "inconnue"}); !errors.Is(err, errUsage) {
+		t.Errorf("Attendu errUsage, reçu %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// newConfigCommand crée la commande config.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande.
+func newConfigCommand(cfgFlags *config.Flags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "config",
+		Short: "initialise, affiche ou vérifie la configuration",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runConfig(cfgFlags)
+		},
+	}
+}
+
+// runConfig exécute la commande config, qui n'accepte que les options de
+// configuration partagées : --init-config, --encrypt, --print-schema,
+// --print-config ou --check-config. Sans opération, la configuration
+// effective est affichée.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//
+// Retourne:
+//   - error: Une erreur si l'opération échoue ou si la configuration est invalide.
+func runConfig(cfgFlags *config.Flags) error {
+	if !cfgFlags.InitConfig && cfgFlags.Encrypt == "" && !cfgFlags.PrintSchema && !cfgFlags.CheckConfig {
+		cfgFlags.PrintConfig = true
+	}
+	_, err := loadConfig(cfgFlags, nil)
+	return err
+}
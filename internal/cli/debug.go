@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/pkg/diag"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+)
+
+// appendDebug ajoute au gestionnaire d'une commande l'écoute de diagnostic
+// (pprof, expvar, instantanés) activée par debug.addr.
+//
+// Paramètres:
+//   - lc: Le gestionnaire de la commande.
+//   - cfg: La configuration du diagnostic.
+func appendDebug(lc *lifecycle.Manager, cfg config.DebugConfig) {
+	if cfg.Addr == "" {
+		return
+	}
+	server := newDebugServer(cfg)
+	var ln net.Listener
+	lc.Append(lifecycle.Hook{
+		Name: "debug",
+		Start: func(context.Context) error {
+			var err error
+			ln, err = listenDebug(cfg.Addr)
+			return err
+		},
+	})
+	lc.Go("debug", func(ctx context.Context) error { return serveHTTP(ctx, server, ln) })
+}
+
+// startDebug démarre en arrière-plan l'écoute de diagnostic d'une commande
+// qui n'utilise pas lifecycle.Manager (moniteur).
+//
+// Paramètres:
+//   - cfg: La configuration du diagnostic.
+//
+// Retourne:
+//   - func(): La fonction qui arrête l'écoute.
+//   - error: Une erreur si l'adresse ne peut pas être écoutée.
+func startDebug(cfg config.DebugConfig) (func(), error) {
+	if cfg.Addr == "" {
+		return func() {}, nil
+	}
+	ln, err := listenDebug(cfg.Addr)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		serveHTTP(ctx, newDebugServer(cfg), ln)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}, nil
+}
+
+// newDebugServer crée le serveur HTTP du diagnostic. Il n'a pas de délai
+// d'écriture : un profil CPU ou une trace dure ?seconds=N.
+//
+// Paramètres:
+//   - cfg: La configuration du diagnostic.
+//
+// Retourne:
+//   - *http.Server: Le serveur.
+func newDebugServer(cfg config.DebugConfig) *http.Server {
+	return &http.Server{Handler: diag.Handler(cfg.SnapshotDir), ReadHeaderTimeout: 10 * time.Second}
+}
+
+// listenDebug ouvre l'écoute de diagnostic et affiche son adresse.
+//
+// Paramètres:
+//   - addr: L'adresse d'écoute (host:port).
+//
+// Retourne:
+//   - net.Listener: L'écouteur.
+//   - error: Une erreur si l'adresse ne peut pas être écoutée.
+func listenDebug(addr string) (net.Listener, error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("impossible d'écouter sur %s: %w", addr, err)
+	}
+	fmt.Printf("🩺 Diagnostic sur http://%s/debug/pprof/ (instantané: POST /debug/snapshot)\n", ln.Addr())
+	return ln, nil
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/internal/producer"
+	"github.com/agbruneau/PubSub/internal/tracker"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+// newDemoCommand crée la commande demo.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande.
+func newDemoCommand(cfgFlags *config.Flags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "demo",
+		Short: "exécute le tracker et le producteur dans un même processus",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runDemo(cfgFlags)
+		},
+	}
+}
+
+// runDemo exécute la commande demo : elle démarre le tracker et le producteur dans
+// le même processus. Avec le broker en mémoire (--kafka.broker=memory://), la
+// démonstration ne requiert aucune dépendance externe ; le moniteur peut suivre
+// les fichiers du tracker depuis un autre processus (pubsub monitor).
+//
+// Le tracker démarre avant et s'arrête après le producteur (lifecycle.Manager) :
+// il consomme les messages vidés par le producteur à l'arrêt.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//
+// Retourne:
+//   - error: Une erreur si la configuration est invalide ou si un service ne peut pas être initialisé.
+func runDemo(cfgFlags *config.Flags) error {
+	// Charger la configuration (options > environnement > YAML > défauts)
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+
+	// Sélectionner la langue (app.locale, sinon LANG)
+	i18n.SetLocale(i18n.Detect(appCfg.App.Locale))
+	logConfigFile(cfgFlags.File())
+	if appCfg.Kafka.Brokers.IsMemory() {
+		fmt.Println("🧪 Broker en mémoire : les messages ne quittent pas le processus.")
+	}
+
+	trkCfg := tracker.ConfigFrom(appCfg)
+	trk := tracker.New(trkCfg)
+	prodCfg := producer.ConfigFrom(appCfg)
+	prod := producer.New(prodCfg)
+	lc := lifecycle.New(lifecycle.DefaultTimeout)
+
+	// Le tracker s'abonne avant la première publication ; sa boucle ne suit pas
+	// l'arrêt de la production mais son propre Stop, appelé en dernier
+	trkDone := make(chan error, 1)
+	lc.Append(lifecycle.Hook{
+		Name: "tracker",
+		Start: func(ctx context.Context) error {
+			if err := trk.Initialize(); err != nil {
+				return errors.New(i18n.T("common.init_error", err))
+			}
+			go func() { trkDone <- trk.Run(context.WithoutCancel(ctx)) }()
+			fmt.Println(i18n.T("tracker.running"))
+			fmt.Println(i18n.T("tracker.log_file", trkCfg.LogFile))
+			fmt.Println(i18n.T("tracker.events_file", trkCfg.EventsFile))
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			trk.Stop()
+			select {
+			case err := <-trkDone:
+				trk.Close()
+				fmt.Println(i18n.T("tracker.stopped"))
+				return err
+			case <-ctx.Done():
+				return lifecycle.ErrTimeout
+			}
+		},
+	})
+	lc.Append(lifecycle.Hook{
+		Name: "producer",
+		Start: func(ctx context.Context) error {
+			if err := prod.Initialize(); err != nil {
+				return errors.New(i18n.T("common.init_error", err))
+			}
+			// Signaler les mises à jour de la configuration distante (--remote-config)
+			go cfgFlags.Watch(ctx, reportRemoteChange)
+
+			fmt.Println(i18n.T("producer.started"))
+			fmt.Println(i18n.T("producer.publishing", prodCfg.Topic))
+			return nil
+		},
+		Stop: func(context.Context) error {
+			prod.Close()
+			return nil
+		},
+	})
+	lc.Go("production", prod.Run)
+	appendMetrics(lc, appCfg.Metrics, "pubsub-demo")
+	appendDebug(lc, appCfg.Debug)
+	return lc.Run(context.Background())
+}
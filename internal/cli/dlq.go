@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+// defaultIdleTimeout est l'attente sans nouveau message qui termine la lecture de la DLQ.
+const defaultIdleTimeout = 5 * time.Second
+
+// dlqClient lit et republie les messages de la DLQ (implémenté par *retry.Replayer).
+type dlqClient interface {
+	Replay(ctx context.Context, opts retry.ReplayOptions) (retry.ReplayReport, error)
+	Browse(ctx context.Context, idleTimeout time.Duration) ([]retry.DLQRecord, error)
+	Republish(rec retry.DLQRecord) error
+}
+
+// newDLQCommand crée la commande dlq, qui administre la Dead Letter Queue :
+//
+//   - replay consomme le topic DLQ (dlq.topic), désenveloppe chaque FailedMessage
+//     et republie son contenu sur son topic d'origine : tous les messages, une
+//     plage d'offsets (--from-offset, --to-offset) ou les messages approuvés un à
+//     un (--interactive). --dry-run rapporte les messages sans les republier. Le
+//     rapport de rejeu est affiché, et écrit dans --report si précisé.
+//   - browse ouvre un inspecteur TUI de la DLQ : la liste des messages (erreur,
+//     tentatives, position d'origine), le détail du contenu (Entrée), le rejeu (r)
+//     ou l'écartement (d) du message sélectionné. Les messages écartés sont
+//     consignés dans --discarded et ne sont plus listés.
+//   - reprocess consomme la DLQ en continu jusqu'à SIGINT ou SIGTERM : les
+//     messages dont l'erreur est retraitable (transient, throttled) sont
+//     republiés sur leur topic d'origine après dlq.reprocessor.cool_down, au plus
+//     dlq.reprocessor.max_reprocess fois (en-tête reprocess-count) ; les autres
+//     sont écartés vers dlq.reprocessor.park_topic (en-tête park-reason). Son
+//     activité est affichée toutes les --metrics-interval.
+//
+// La DLQ requiert la compilation avec -tags kafka.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande et ses sous-commandes.
+func newDLQCommand(cfgFlags *config.Flags) *cobra.Command {
+	return groupCommand("dlq", "administre la Dead Letter Queue (replay, browse, reprocess)",
+		newReplayCommand(cfgFlags),
+		newBrowseCommand(cfgFlags),
+		newReprocessCommand(cfgFlags),
+	)
+}
+
+// replayOptions regroupe les options propres à la commande replay.
+type replayOptions struct {
+	from        int64         // Premier offset rejoué (-1 : depuis le début).
+	to          int64         // Dernier offset rejoué (-1 : jusqu'à la fin).
+	dryRun      bool          // Rapporte les messages sans les republier.
+	interactive bool          // Demande l'approbation de chaque message.
+	reportPath  string        // Fichier où écrire aussi le rapport de rejeu.
+	idle        time.Duration // Attente sans nouveau message qui termine la lecture.
+}
+
+// newReplayCommand crée la commande replay.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande.
+func newReplayCommand(cfgFlags *config.Flags) *cobra.Command {
+	var opts replayOptions
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "republie les messages de la DLQ sur leur topic d'origine",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runReplay(cfgFlags, opts)
+		},
+	}
+	cmd.Flags().Int64Var(&opts.from, "from-offset", -1, "first DLQ offset replayed (-1: from the beginning)")
+	cmd.Flags().Int64Var(&opts.to, "to-offset", -1, "last DLQ offset replayed (-1: up to the end)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "report the messages that would be replayed without publishing them")
+	cmd.Flags().BoolVar(&opts.interactive, "interactive", false, "ask for the approval of each message (y: replay, n: skip, q: stop)")
+	cmd.Flags().StringVar(&opts.reportPath, "report", "", "also write the replay report to this file")
+	cmd.Flags().DurationVar(&opts.idle, "idle-timeout", defaultIdleTimeout, "stop reading the DLQ after this delay without a new message")
+	return cmd
+}
+
+// runReplay exécute la commande replay.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//   - opts: Les options propres à la commande.
+//
+// Retourne:
+//   - error: Une erreur si la configuration est invalide ou si le rejeu échoue.
+func runReplay(cfgFlags *config.Flags, opts replayOptions) error {
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+	if opts.from >= 0 && opts.to >= 0 && opts.from > opts.to {
+		return fmt.Errorf("--from-offset (%d) est après --to-offset (%d)", opts.from, opts.to)
+	}
+
+	replayOpts := retry.ReplayOptions{FromOffset: opts.from, ToOffset: opts.to, DryRun: opts.dryRun, IdleTimeout: opts.idle}
+	if opts.interactive {
+		replayOpts.Approve = promptApproval(os.Stdin, os.Stdout)
+	}
+
+	ctx, stop := lifecycle.SignalContext(context.Background())
+	defer stop()
+	client, closeClient, err := openDLQ(appCfg)
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+	report, replayErr := client.Replay(ctx, replayOpts)
+
+	if err := retry.WriteReplayReport(os.Stdout, report); err != nil {
+		return err
+	}
+	if opts.reportPath != "" {
+		if err := writeReportFile(opts.reportPath, report); err != nil {
+			return err
+		}
+	}
+	return replayErr
+}
+
+// promptApproval crée l'approbation interactive des messages.
+//
+// Paramètres:
+//   - in: L'entrée des réponses.
+//   - out: La sortie des questions.
+//
+// Retourne:
+//   - func(retry.FailedMessage) (bool, error): L'approbation (ErrStopReplay sur q ou fin de l'entrée).
+func promptApproval(in io.Reader, out io.Writer) func(retry.FailedMessage) (bool, error) {
+	scanner := bufio.NewScanner(in)
+	return func(msg retry.FailedMessage) (bool, error) {
+		fmt.Fprintf(out, "\n%s@%d/%d  attempts=%d  failed_at=%s\nerror: %s\npayload: %s\n",
+			msg.OriginalTopic, msg.OriginalPartition, msg.OriginalOffset, msg.Attempts,
+			msg.FailedAt.Format(time.RFC3339), msg.LastError, msg.Payload)
+		for {
+			fmt.Fprint(out, "replay? [y/n/q] ")
+			if !scanner.Scan() {
+				return false, retry.ErrStopReplay
+			}
+			switch strings.ToLower(strings.TrimSpace(scanner.Text())) {
+			case "y", "yes":
+				return true, nil
+			case "n", "no":
+				return false, nil
+			case "q", "quit":
+				return false, retry.ErrStopReplay
+			}
+		}
+	}
+}
+
+// writeReportFile écrit le rapport de rejeu dans un fichier.
+//
+// Paramètres:
+//   - path: Le chemin du fichier.
+//   - report: Le rapport.
+//
+// Retourne:
+//   - error: Une erreur si le fichier ne peut pas être écrit.
+func writeReportFile(path string, report retry.ReplayReport) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("impossible d'écrire le rapport: %w", err)
+	}
+	if err := retry.WriteReplayReport(file, report); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/internal/monitor"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+	ui "github.com/gizak/termui/v3"
+	"github.com/spf13/cobra"
+)
+
+// defaultDiscardedFile est le registre par défaut des messages DLQ écartés.
+const defaultDiscardedFile = "dlq-discarded.log"
+
+// browseOptions regroupe les options propres à la commande browse.
+type browseOptions struct {
+	discardedPath string        // Registre des messages écartés, qui ne sont plus listés.
+	idle          time.Duration // Attente sans nouveau message qui termine la lecture.
+}
+
+// newBrowseCommand crée la commande browse.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande.
+func newBrowseCommand(cfgFlags *config.Flags) *cobra.Command {
+	var opts browseOptions
+	cmd := &cobra.Command{
+		Use:   "browse",
+		Short: "inspecte la DLQ dans une interface TUI (rejeu, écartement)",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runBrowse(cfgFlags, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.discardedPath, "discarded", defaultDiscardedFile, "file recording the discarded DLQ messages, which are no longer listed")
+	cmd.Flags().DurationVar(&opts.idle, "idle-timeout", defaultIdleTimeout, "stop reading the DLQ after this delay without a new message")
+	return cmd
+}
+
+// runBrowse exécute la commande browse.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//   - opts: Les options propres à la commande.
+//
+// Retourne:
+//   - error: Une erreur si la configuration est invalide, si la lecture de la DLQ ou l'UI échoue.
+func runBrowse(cfgFlags *config.Flags, opts browseOptions) error {
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+	i18n.SetLocale(i18n.Detect(appCfg.App.Locale))
+
+	client, closeClient, err := openDLQ(appCfg)
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	ctx, stop := lifecycle.SignalContext(context.Background())
+	defer stop()
+	fmt.Printf("Lecture du topic DLQ %s...\n", appCfg.DLQ.Topic)
+	records, err := client.Browse(ctx, opts.idle)
+	if err != nil {
+		return err
+	}
+	discarded, err := loadDiscarded(opts.discardedPath)
+	if err != nil {
+		return err
+	}
+	records = withoutDiscarded(records, appCfg.DLQ.Topic, discarded)
+
+	actions := &browseActions{client: client, topic: appCfg.DLQ.Topic, discardedPath: opts.discardedPath}
+	return runBrowserUI(monitor.NewDLQBrowser(records, actions))
+}
+
+// runBrowserUI affiche l'inspecteur de la DLQ jusqu'à ce que l'utilisateur le quitte.
+//
+// Paramètres:
+//   - browser: L'inspecteur.
+//
+// Retourne:
+//   - error: Une erreur si l'UI ne peut pas être initialisée.
+func runBrowserUI(browser *monitor.DLQBrowser) error {
+	if err := ui.Init(); err != nil {
+		return fmt.Errorf("erreur lors de l'initialisation de l'UI: %w", err)
+	}
+	defer ui.Close()
+
+	termWidth, termHeight := ui.TerminalDimensions()
+	browser.Resize(termWidth, termHeight)
+	ui.Render(browser.Drawables()...)
+
+	for e := range ui.PollEvents() {
+		if e.ID == "<Resize>" {
+			payload := e.Payload.(ui.Resize)
+			browser.Resize(payload.Width, payload.Height)
+		} else if e.Type == ui.KeyboardEvent && !browser.HandleKey(e.ID) {
+			return nil
+		}
+		ui.Clear()
+		ui.Render(browser.Drawables()...)
+	}
+	return nil
+}
+
+// browseActions rejoue les messages sur leur topic d'origine et consigne les
+// messages écartés dans un registre.
+type browseActions struct {
+	client        dlqClient
+	topic         string
+	discardedPath string
+}
+
+// Replay republie le message sur son topic d'origine.
+//
+// Paramètres:
+//   - entry: Le message sélectionné.
+//
+// Retourne:
+//   - error: Une erreur si la republication échoue.
+func (a *browseActions) Replay(entry monitor.DLQEntry) error {
+	return a.client.Republish(entry.Record)
+}
+
+// Discard consigne la position DLQ du message dans le registre des messages écartés.
+//
+// Paramètres:
+//   - entry: Le message sélectionné.
+//
+// Retourne:
+//   - error: Une erreur si le registre ne peut pas être écrit.
+func (a *browseActions) Discard(entry monitor.DLQEntry) error {
+	file, err := os.OpenFile(a.discardedPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(file, dlqPosition(a.topic, entry.Record)); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// dlqPosition identifie un message DLQ, au format de l'en-tête replayed-from.
+//
+// Paramètres:
+//   - topic: Le topic DLQ.
+//   - rec: Le message DLQ.
+//
+// Retourne:
+//   - string: La position topic/partition/offset.
+func dlqPosition(topic string, rec retry.DLQRecord) string {
+	return fmt.Sprintf("%s/%d/%d", topic, rec.Partition, rec.Offset)
+}
+
+// loadDiscarded lit le registre des messages écartés.
+//
+// Paramètres:
+//   - path: Le chemin du registre.
+//
+// Retourne:
+//   - map[string]bool: Les positions écartées (vide si le registre n'existe pas).
+//   - error: Une erreur si le registre ne peut pas être lu.
+func loadDiscarded(path string) (map[string]bool, error) {
+	discarded := make(map[string]bool)
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return discarded, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("impossible de lire le registre des messages écartés: %w", err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			discarded[line] = true
+		}
+	}
+	return discarded, scanner.Err()
+}
+
+// withoutDiscarded retire les messages écartés de la liste lue.
+//
+// Paramètres:
+//   - records: Les messages lus.
+//   - topic: Le topic DLQ.
+//   - discarded: Les positions écartées.
+//
+// Retourne:
+//   - []retry.DLQRecord: Les messages restants, dans l'ordre de lecture.
+func withoutDiscarded(records []retry.DLQRecord, topic string, discarded map[string]bool) []retry.DLQRecord {
+	kept := records[:0]
+	for _, rec := range records {
+		if !discarded[dlqPosition(topic, rec)] {
+			kept = append(kept, rec)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,120 @@
+//go:build kafka
+// +build kafka
+
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// replayConsumerGroup est le groupe de consommateurs de la commande dlq, nommé
+// d'après le binaire dlqctl. Ses offsets ne sont jamais validés : chaque
+// commande relit la DLQ depuis le début.
+const replayConsumerGroup = "dlqctl"
+
+// reprocessorConsumerGroup est le groupe de consommateurs du retraiteur de la
+// DLQ, dont les offsets sont validés après chaque message traité.
+const reprocessorConsumerGroup = "dlq-reprocessor"
+
+// reprocessorPollTimeout est le délai de lecture du retraiteur.
+const reprocessorPollTimeout = time.Second
+
+// openDLQ crée les clients Kafka de lecture et de republication de la DLQ.
+//
+// Paramètres:
+//   - cfg: La configuration de l'application.
+//
+// Retourne:
+//   - dlqClient: Le client de la DLQ.
+//   - func(): Ferme les clients Kafka.
+//   - error: Une erreur si un client Kafka ne peut pas être créé.
+func openDLQ(cfg *config.AppConfig) (dlqClient, func(), error) {
+	consumerMap := kafka.ConfigMap{
+		"bootstrap.servers": cfg.Kafka.Brokers.String(),
+		"group.id":          replayConsumerGroup,
+	}
+	for name, value := range cfg.ConsumerProperties() {
+		consumerMap[name] = value
+	}
+	consumerMap["auto.offset.reset"] = "earliest"
+	consumerMap["enable.auto.commit"] = false
+	consumer, err := kafka.NewConsumer(&consumerMap)
+	if err != nil {
+		return nil, nil, fmt.Errorf("impossible de créer le consommateur DLQ: %w", err)
+	}
+
+	producerMap := kafka.ConfigMap{"bootstrap.servers": cfg.Kafka.Brokers.String()}
+	for name, value := range cfg.ProducerProperties() {
+		producerMap[name] = value
+	}
+	producer, err := kafka.NewProducer(&producerMap)
+	if err != nil {
+		consumer.Close()
+		return nil, nil, fmt.Errorf("impossible de créer le producteur: %w", err)
+	}
+
+	closeClients := func() {
+		producer.Close()
+		consumer.Close()
+	}
+	return retry.NewReplayer(consumer, producer, cfg.DLQ.Topic), closeClients, nil
+}
+
+// openReprocessor crée les clients Kafka du retraiteur de la DLQ.
+//
+// Paramètres:
+//   - cfg: La configuration de l'application.
+//
+// Retourne:
+//   - dlqReprocessor: Le retraiteur.
+//   - func(): Ferme les clients Kafka.
+//   - error: Une erreur si un client Kafka ne peut pas être créé.
+func openReprocessor(cfg *config.AppConfig) (dlqReprocessor, func(), error) {
+	consumerMap := kafka.ConfigMap{
+		"bootstrap.servers": cfg.Kafka.Brokers.String(),
+		"group.id":          reprocessorConsumerGroup,
+	}
+	for name, value := range cfg.ConsumerProperties() {
+		consumerMap[name] = value
+	}
+	consumerMap["auto.offset.reset"] = "earliest"
+	consumerMap["enable.auto.offset.store"] = false // offset enregistré après la livraison
+	consumer, err := kafka.NewConsumer(&consumerMap)
+	if err != nil {
+		return nil, nil, fmt.Errorf("impossible de créer le consommateur DLQ: %w", err)
+	}
+
+	producerMap := kafka.ConfigMap{"bootstrap.servers": cfg.Kafka.Brokers.String()}
+	for name, value := range cfg.ProducerProperties() {
+		producerMap[name] = value
+	}
+	producer, err := kafka.NewProducer(&producerMap)
+	if err != nil {
+		consumer.Close()
+		return nil, nil, fmt.Errorf("impossible de créer le producteur: %w", err)
+	}
+	go func() {
+		// Événements généraux : les rapports de livraison passent par les canaux du retraiteur
+		for range producer.Events() {
+		}
+	}()
+
+	reprocessor := retry.NewReprocessor(consumer, producer, retry.ReprocessorConfig{
+		Topic:        cfg.DLQ.Topic,
+		ParkTopic:    cfg.DLQ.Reprocessor.ParkTopic,
+		CoolDown:     cfg.DLQ.Reprocessor.CoolDown,
+		MaxReprocess: cfg.DLQ.Reprocessor.MaxReprocess,
+		PollTimeout:  reprocessorPollTimeout,
+	})
+	closeClients := func() {
+		consumer.Close()
+		producer.Flush(int(config.ProducerFlushTimeout.Milliseconds()))
+		producer.Close()
+	}
+	return reprocessor, closeClients, nil
+}
@@ -0,0 +1,36 @@
+//go:build !kafka
+// +build !kafka
+
+package cli
+
+import (
+	"errors"
+
+	"github.com/agbruneau/PubSub/internal/config"
+)
+
+// openDLQ est indisponible sans le tag de compilation "kafka".
+//
+// Paramètres:
+//   - cfg: La configuration de l'application (inutilisée).
+//
+// Retourne:
+//   - dlqClient: nil.
+//   - func(): nil.
+//   - error: Une erreur indiquant de compiler avec -tags kafka.
+func openDLQ(cfg *config.AppConfig) (dlqClient, func(), error) {
+	return nil, nil, errors.New("la commande dlq requiert la compilation avec -tags kafka")
+}
+
+// openReprocessor est indisponible sans le tag de compilation "kafka".
+//
+// Paramètres:
+//   - cfg: La configuration de l'application (inutilisée).
+//
+// Retourne:
+//   - dlqReprocessor: nil.
+//   - func(): nil.
+//   - error: Une erreur indiquant de compiler avec -tags kafka.
+func openReprocessor(cfg *config.AppConfig) (dlqReprocessor, func(), error) {
+	return nil, nil, errors.New("la commande dlq requiert la compilation avec -tags kafka")
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+// defaultMetricsInterval est l'intervalle par défaut d'affichage de l'activité du retraiteur.
+const defaultMetricsInterval = 30 * time.Second
+
+// dlqReprocessor retraite la DLQ en continu (implémenté par *retry.Reprocessor).
+type dlqReprocessor interface {
+	Run(ctx context.Context) error
+	Stats() retry.ReprocessorStats
+}
+
+// reprocessOptions regroupe les options propres à la commande reprocess.
+type reprocessOptions struct {
+	interval time.Duration // Intervalle d'affichage de l'activité du retraiteur.
+}
+
+// newReprocessCommand crée la commande reprocess.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande.
+func newReprocessCommand(cfgFlags *config.Flags) *cobra.Command {
+	var opts reprocessOptions
+	cmd := &cobra.Command{
+		Use:   "reprocess",
+		Short: "retraite la DLQ en continu (republication ou parking)",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runReprocess(cfgFlags, opts)
+		},
+	}
+	cmd.Flags().DurationVar(&opts.interval, "metrics-interval", defaultMetricsInterval, "interval between two reports of the reprocessor activity")
+	return cmd
+}
+
+// runReprocess exécute la commande reprocess.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//   - opts: Les options propres à la commande.
+//
+// Retourne:
+//   - error: Une erreur si la configuration est invalide ou si la lecture de la DLQ échoue.
+func runReprocess(cfgFlags *config.Flags, opts reprocessOptions) error {
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+	if opts.interval <= 0 {
+		return fmt.Errorf("--metrics-interval doit être positif (obtenu %s)", opts.interval)
+	}
+
+	reprocessor, closeClients, err := openReprocessor(appCfg)
+	if err != nil {
+		return err
+	}
+	defer closeClients()
+
+	ctx, stop := lifecycle.SignalContext(context.Background())
+	defer stop()
+	fmt.Printf("Retraitement du topic DLQ %s (parking: %s)...\n", appCfg.DLQ.Topic, appCfg.DLQ.Reprocessor.ParkTopic)
+
+	go reportActivity(ctx, reprocessor, opts.interval)
+	err = reprocessor.Run(ctx)
+	fmt.Println(reprocessor.Stats())
+	return err
+}
+
+// reportActivity affiche l'activité du retraiteur à chaque intervalle, jusqu'à
+// l'annulation du contexte.
+//
+// Paramètres:
+//   - ctx: Le contexte d'arrêt.
+//   - reprocessor: Le retraiteur.
+//   - interval: L'intervalle d'affichage.
+func reportActivity(ctx context.Context, reprocessor dlqReprocessor, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fmt.Println(reprocessor.Stats())
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/eventstream"
+	"github.com/agbruneau/PubSub/pkg/eventspb"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// eventsOptions regroupe les options propres à la commande events.
+type eventsOptions struct {
+	addr    string // Adresse d'écoute du serveur gRPC.
+	history int    // Nombre d'événements récents conservés pour le rejeu.
+}
+
+// newEventsCommand crée la commande events.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande.
+func newEventsCommand(cfgFlags *config.Flags) *cobra.Command {
+	var opts eventsOptions
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "diffuse les événements du tracker par un flux gRPC filtrable",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runEvents(cfgFlags, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.addr, "addr", eventstream.DefaultAddr, "adresse d'écoute du serveur gRPC")
+	cmd.Flags().IntVar(&opts.history, "history", eventstream.DefaultHistory, "nombre d'événements récents conservés pour le rejeu")
+	return cmd
+}
+
+// runEvents exécute la commande events : elle suit la piste d'audit du tracker
+// (tracker.events_file), depuis sa première ligne, et diffuse ses événements
+// aux abonnés de l'API gRPC (appel SubscribeEvents du service
+// pubsub.events.v1.EventStream, avec filtres et rejeu des derniers
+// événements). La réflexion gRPC est activée pour les clients génériques
+// (grpcurl). Le serveur s'arrête à la réception de SIGINT ou SIGTERM, après
+// avoir terminé les abonnements.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//   - opts: Les options propres à la commande.
+//
+// Retourne:
+//   - error: Une erreur si la configuration est invalide ou si le serveur échoue.
+func runEvents(cfgFlags *config.Flags, opts eventsOptions) error {
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+	logConfigFile(cfgFlags.File())
+
+	hub := eventstream.NewHub(opts.history, eventstream.DefaultBuffer)
+	go eventstream.Feed(hub, appCfg.Tracker.EventsFile)
+
+	server := grpc.NewServer()
+	eventspb.RegisterEventStreamServer(server, eventstream.NewServer(hub))
+	reflection.Register(server)
+
+	ln, err := net.Listen("tcp", opts.addr)
+	if err != nil {
+		return fmt.Errorf("impossible d'écouter sur %s: %w", opts.addr, err)
+	}
+	fmt.Printf("📡 Flux d'événements gRPC sur %s, piste d'audit %s (Ctrl+C pour arrêter)\n", ln.Addr(), appCfg.Tracker.EventsFile)
+
+	lc := lifecycle.New(lifecycle.DefaultTimeout)
+	lc.Go("events", func(ctx context.Context) error {
+		errc := make(chan error, 1)
+		go func() { errc <- server.Serve(ln) }()
+		select {
+		case err := <-errc:
+			return err
+		case <-ctx.Done():
+		}
+		// Terminer les abonnements, sans quoi GracefulStop attendrait leurs clients
+		hub.Close()
+		server.GracefulStop()
+		return nil
+	})
+	if err := lc.Run(context.Background()); err != nil {
+		return err
+	}
+	fmt.Println("✅ Flux d'événements arrêté")
+	return nil
+}
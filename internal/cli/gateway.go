@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/gateway"
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/internal/producer"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+// newGatewayCommand crée la commande gateway.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande.
+func newGatewayCommand(cfgFlags *config.Flags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "gateway",
+		Short: "publie les commandes reçues par HTTP (validation, jeton, limite de débit)",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runGateway(cfgFlags)
+		},
+	}
+}
+
+// runGateway exécute la commande gateway : elle sert la passerelle d'ingestion
+// HTTP, par laquelle des systèmes externes injectent de vraies commandes dans
+// le pipeline (POST /v1/orders). Les commandes acceptées sont publiées par un
+// producteur configuré comme celui de la commande produce, sans fichier de
+// statistiques pour ne pas écraser celui du producteur en cours. L'adresse, le
+// jeton et la limite de débit sont lus dans la section gateway de la
+// configuration (--gateway.addr, --gateway.token, ...). Le serveur s'arrête à
+// la réception de SIGINT ou SIGTERM, puis le producteur vide ses messages.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//
+// Retourne:
+//   - error: Une erreur si la configuration est invalide, si le producteur ne peut pas être initialisé ou si le serveur échoue.
+func runGateway(cfgFlags *config.Flags) error {
+
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+	i18n.SetLocale(i18n.Detect(appCfg.App.Locale))
+	logConfigFile(cfgFlags.File())
+
+	prodCfg := producer.ConfigFrom(appCfg)
+	prodCfg.StatsFile = ""
+	prod := producer.New(prodCfg)
+
+	gwCfg := appCfg.Gateway
+	if gwCfg.Token == "" {
+		fmt.Println("⚠️  gateway.token est vide : la passerelle accepte les commandes sans authentification")
+	}
+	server := &http.Server{
+		Handler: gateway.New(gateway.Config{
+			Publisher:    prod,
+			Token:        gwCfg.Token,
+			RateLimit:    gwCfg.RateLimit,
+			Burst:        gwCfg.Burst,
+			MaxBodyBytes: gwCfg.MaxBodyBytes,
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// Le producteur est fermé après l'arrêt du serveur, qui ne publie plus
+	var ln net.Listener
+	lc := lifecycle.New(lifecycle.DefaultTimeout)
+	lc.Append(lifecycle.Hook{
+		Name: "producer",
+		Start: func(context.Context) error {
+			if err := prod.Initialize(); err != nil {
+				return errors.New(i18n.T("common.init_error", err))
+			}
+			return nil
+		},
+		Stop: func(context.Context) error {
+			prod.Close()
+			return nil
+		},
+	})
+	lc.Append(lifecycle.Hook{
+		Name: "gateway",
+		Start: func(context.Context) error {
+			var err error
+			if ln, err = net.Listen("tcp", gwCfg.Addr); err != nil {
+				return fmt.Errorf("impossible d'écouter sur %s: %w", gwCfg.Addr, err)
+			}
+			fmt.Printf("📥 Passerelle sur http://%s/v1/orders, publication sur le topic %s (Ctrl+C pour arrêter)\n", ln.Addr(), prodCfg.Topic)
+			return nil
+		},
+	})
+	lc.Go("gateway", func(ctx context.Context) error { return serveHTTP(ctx, server, ln) })
+	appendMetrics(lc, appCfg.Metrics, "pubsub-gateway")
+	appendDebug(lc, appCfg.Debug)
+	if err := lc.Run(context.Background()); err != nil {
+		return err
+	}
+	fmt.Println("✅ Passerelle arrêtée")
+	return nil
+}
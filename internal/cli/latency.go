@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/drivers"
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/internal/latency"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+// latencyOptions regroupe les options propres à la commande latency.
+type latencyOptions struct {
+	interval    time.Duration // Durée de chaque fenêtre de mesure.
+	duration    time.Duration // Durée de la mesure (0 : jusqu'à l'interruption).
+	topic       string        // Topic mesuré (défaut: kafka.topic).
+	streamFile  string        // Fichier de flux des fenêtres (défaut: monitor.latency_file).
+	summaryFile string        // Fichier de synthèse JSON ("-" : sortie standard).
+}
+
+// newLatencyCommand crée la commande latency.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande.
+func newLatencyCommand(cfgFlags *config.Flags) *cobra.Command {
+	defaults := latency.DefaultConfig()
+	var opts latencyOptions
+	cmd := &cobra.Command{
+		Use:   "latency",
+		Short: "mesure la latence de livraison des messages par partition",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runLatency(cfgFlags, opts)
+		},
+	}
+	cmd.Flags().DurationVar(&opts.interval, "interval", defaults.Interval, "durée de chaque fenêtre de mesure")
+	cmd.Flags().DurationVar(&opts.duration, "duration", 0, "durée de la mesure (0 : jusqu'à l'interruption)")
+	cmd.Flags().StringVar(&opts.topic, "topic", "", "topic mesuré (défaut: kafka.topic)")
+	cmd.Flags().StringVar(&opts.streamFile, "stream", "", "fichier de flux des fenêtres (défaut: monitor.latency_file)")
+	cmd.Flags().StringVar(&opts.summaryFile, "summary", config.LatencySummaryFile, "fichier de synthèse JSON (\"-\" : sortie standard)")
+	return cmd
+}
+
+// runLatency exécute la commande latency : la sonde lit le topic avec un groupe de
+// consommateurs qui lui est propre et mesure, par partition, la latence de
+// livraison des messages horodatés par le producteur (producer.stamp_send_time).
+// La distribution de chaque fenêtre (--interval) est ajoutée au fichier de flux
+// (--stream, défaut: monitor.latency_file) affiché par le panneau de latence du
+// moniteur ; à l'arrêt (--duration écoulée, SIGINT ou SIGTERM), la distribution
+// de toute la mesure est affichée et écrite dans le fichier de synthèse
+// (--summary, "-" : JSON sur la sortie standard à la place du texte).
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//   - opts: Les options propres à la commande.
+//
+// Retourne:
+//   - error: Une erreur si la configuration est invalide, si un fichier ou le consommateur ne peut pas être créé, ou si la mesure échoue.
+func runLatency(cfgFlags *config.Flags, opts latencyOptions) error {
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+	i18n.SetLocale(i18n.Detect(appCfg.App.Locale))
+	logConfigFile(cfgFlags.File())
+
+	cfg := latency.DefaultConfig()
+	cfg.Topic = appCfg.Kafka.Topic
+	cfg.ReadTimeout = appCfg.GetReadTimeout()
+	cfg.Interval, cfg.Duration = opts.interval, opts.duration
+	if opts.topic != "" {
+		cfg.Topic = opts.topic
+	}
+	if opts.streamFile == "" {
+		opts.streamFile = appCfg.Monitor.LatencyFile
+	}
+
+	stream, closeStream, err := openLatencyStream(opts.streamFile)
+	if err != nil {
+		return err
+	}
+	defer closeStream()
+
+	// Groupe propre à la sonde, qui ne mesure que les messages publiés après son abonnement
+	props := appCfg.ConsumerProperties()
+	props["auto.offset.reset"] = "latest"
+	sub, err := drivers.NewSubscriber(appCfg.Kafka.Brokers.String(), appCfg.Kafka.ConsumerGroup+"-latency", props)
+	if err != nil {
+		return errors.New(i18n.T("common.init_error", err))
+	}
+	defer sub.Close()
+
+	if !appCfg.Producer.StampSendTime {
+		fmt.Fprintln(os.Stderr, "ℹ️  producer.stamp_send_time est désactivé : seuls les messages portant l'en-tête sent-at sont mesurés")
+	}
+	ctx, stop := lifecycle.SignalContext(context.Background())
+	defer stop()
+	fmt.Fprintf(os.Stderr, "⏱️  Sonde de latence sur le topic %s (fenêtres de %s, flux %s)\n", cfg.Topic, cfg.Interval, opts.streamFile)
+	summary, err := latency.Run(ctx, cfg, sub, stream)
+	if err != nil {
+		return err
+	}
+
+	if opts.summaryFile == "-" {
+		return summary.WriteJSON(os.Stdout)
+	}
+	if err := summary.WriteText(os.Stdout); err != nil {
+		return err
+	}
+	if opts.summaryFile != "" {
+		if err := os.MkdirAll(filepath.Dir(opts.summaryFile), 0755); err != nil {
+			return err
+		}
+		f, err := os.Create(opts.summaryFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := summary.WriteJSON(f); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "📄 Synthèse JSON écrite dans %s\n", opts.summaryFile)
+	}
+	return nil
+}
+
+// openLatencyStream ouvre en ajout le fichier de flux de la sonde de latence,
+// en créant son répertoire au besoin.
+//
+// Paramètres:
+//   - path: Le fichier de flux (vide pour ne rien écrire).
+//
+// Retourne:
+//   - io.Writer: La destination des fenêtres.
+//   - func() error: La fonction qui ferme le fichier.
+//   - error: Une erreur si le fichier ne peut pas être ouvert.
+func openLatencyStream(path string) (io.Writer, func() error, error) {
+	if path == "" {
+		return io.Discard, func() error { return nil }, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, nil, fmt.Errorf("impossible de créer le répertoire de %s: %w", path, err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("impossible d'ouvrir le fichier %s: %w", path, err)
+	}
+	return file, file.Close, nil
+}
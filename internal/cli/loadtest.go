@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/drivers"
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/internal/loadtest"
+	"github.com/agbruneau/PubSub/internal/producer"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+// loadTestOptions regroupe les options propres à la commande loadtest.
+type loadTestOptions struct {
+	rate       int           // Débit cible, en messages par seconde.
+	duration   time.Duration // Durée de la publication.
+	topic      string        // Topic du test (défaut: kafka.topic).
+	warmUp     time.Duration // Attente maximale du consommateur avant la publication.
+	drain      time.Duration // Attente maximale des derniers messages après la publication.
+	reportFile string        // Fichier du rapport JSON ("-" : sortie standard).
+}
+
+// newLoadTestCommand crée la commande loadtest.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande.
+func newLoadTestCommand(cfgFlags *config.Flags) *cobra.Command {
+	defaults := loadtest.DefaultConfig()
+	var opts loadTestOptions
+	cmd := &cobra.Command{
+		Use:   "loadtest",
+		Short: "mesure le débit et la latence de bout en bout du broker",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runLoadTest(cfgFlags, opts)
+		},
+	}
+	cmd.Flags().IntVar(&opts.rate, "rate", defaults.Rate, "débit cible, en messages par seconde")
+	cmd.Flags().DurationVar(&opts.duration, "duration", defaults.Duration, "durée de la publication")
+	cmd.Flags().StringVar(&opts.topic, "topic", "", "topic du test (défaut: kafka.topic)")
+	cmd.Flags().DurationVar(&opts.warmUp, "warmup", defaults.WarmUp, "attente maximale du consommateur avant la publication")
+	cmd.Flags().DurationVar(&opts.drain, "drain", defaults.Drain, "attente maximale des derniers messages après la publication")
+	cmd.Flags().StringVar(&opts.reportFile, "report", "", "fichier du rapport JSON (\"-\" : sortie standard)")
+	return cmd
+}
+
+// runLoadTest exécute la commande loadtest : elle publie des commandes générées au
+// débit demandé (--rate, messages par seconde) pendant --duration, les relit
+// avec un consommateur embarqué d'un groupe propre au test, puis affiche le
+// rapport (débit atteint, centiles de latence de bout en bout, messages perdus
+// et dupliqués). --report écrit aussi le rapport JSON dans un fichier ("-" :
+// JSON sur la sortie standard à la place du texte). SIGINT ou SIGTERM arrêtent
+// la publication ; le rapport couvre les messages déjà publiés.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//   - opts: Les options propres à la commande.
+//
+// Retourne:
+//   - error: Une erreur si la configuration est invalide, si un client ne peut pas être créé ou si le test échoue.
+func runLoadTest(cfgFlags *config.Flags, opts loadTestOptions) error {
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+	i18n.SetLocale(i18n.Detect(appCfg.App.Locale))
+	logConfigFile(cfgFlags.File())
+
+	cfg := loadtestConfig(appCfg, loadtest.DefaultConfig())
+	cfg.Rate, cfg.Duration, cfg.WarmUp, cfg.Drain = opts.rate, opts.duration, opts.warmUp, opts.drain
+	if opts.topic != "" {
+		cfg.Topic = opts.topic
+	}
+
+	brokers := appCfg.Kafka.Brokers.String()
+	pub, err := drivers.NewPublisher(brokers, appCfg.ProducerProperties())
+	if err != nil {
+		return errors.New(i18n.T("common.init_error", err))
+	}
+	defer pub.Close()
+	// Groupe propre au test, qui ne lit que les messages publiés après son abonnement
+	props := appCfg.ConsumerProperties()
+	props["auto.offset.reset"] = "latest"
+	sub, err := drivers.NewSubscriber(brokers, loadtestGroup(appCfg.Kafka.ConsumerGroup, cfg.RunID), props)
+	if err != nil {
+		return errors.New(i18n.T("common.init_error", err))
+	}
+	defer sub.Close()
+
+	ctx, stop := lifecycle.SignalContext(context.Background())
+	defer stop()
+	fmt.Fprintf(os.Stderr, "🚀 Test de charge %s : %d msg/s pendant %s sur le topic %s\n", cfg.RunID, cfg.Rate, cfg.Duration, cfg.Topic)
+	report, err := loadtest.Run(ctx, cfg, pub, sub)
+	if err != nil {
+		return err
+	}
+
+	if opts.reportFile == "-" {
+		return report.WriteJSON(os.Stdout)
+	}
+	if err := report.WriteText(os.Stdout); err != nil {
+		return err
+	}
+	if opts.reportFile != "" {
+		f, err := os.Create(opts.reportFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := report.WriteJSON(f); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "📄 Rapport JSON écrit dans %s\n", opts.reportFile)
+	}
+	return nil
+}
+
+// loadtestConfig retourne les paramètres d'un test de charge tirés de la
+// configuration partagée : topic, délais de lecture et de vidage, et génération
+// des commandes du producteur.
+//
+// Paramètres:
+//   - appCfg: La configuration de l'application.
+//   - defaults: Les paramètres par défaut du test.
+//
+// Retourne:
+//   - loadtest.Config: Les paramètres, avec un nouvel identifiant de test.
+func loadtestConfig(appCfg *config.AppConfig, defaults loadtest.Config) loadtest.Config {
+	cfg := defaults
+	cfg.RunID = loadtest.NewRunID()
+	cfg.Topic = appCfg.Kafka.Topic
+	cfg.ReadTimeout = appCfg.GetReadTimeout()
+	cfg.FlushTimeout = appCfg.GetFlushTimeout()
+
+	cfg.Generator = producer.GeneratorConfig(producer.ConfigFrom(appCfg))
+	return cfg
+}
+
+// loadtestGroup retourne le groupe de consommateurs d'un test de charge.
+//
+// Paramètres:
+//   - group: Le groupe de consommateurs configuré.
+//   - runID: L'identifiant du test.
+//
+// Retourne:
+//   - string: Le groupe, propre au test.
+func loadtestGroup(group, runID string) string {
+	return fmt.Sprintf("%s-loadtest-%s", group, runID)
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+	"github.com/agbruneau/PubSub/pkg/metrics"
+)
+
+// appendMetrics ajoute au gestionnaire d'une commande les exportateurs des
+// métriques du processus (metrics.Default) activés par la section metrics : le
+// point de collecte Prometheus /metrics sur metrics.addr et l'envoi OTLP vers
+// metrics.otlp_endpoint, avec un dernier envoi à l'arrêt.
+//
+// Paramètres:
+//   - lc: Le gestionnaire de la commande.
+//   - cfg: La configuration des métriques.
+//   - service: Le nom du service (attribut service.name des métriques OTLP).
+func appendMetrics(lc *lifecycle.Manager, cfg config.MetricsConfig, service string) {
+	if cfg.Addr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("GET /metrics", metrics.Handler(metrics.Default))
+		server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		var ln net.Listener
+		lc.Append(lifecycle.Hook{
+			Name: "metrics",
+			Start: func(context.Context) error {
+				var err error
+				if ln, err = net.Listen("tcp", cfg.Addr); err != nil {
+					return fmt.Errorf("impossible d'écouter sur %s: %w", cfg.Addr, err)
+				}
+				fmt.Printf("📈 Métriques Prometheus sur http://%s/metrics\n", ln.Addr())
+				return nil
+			},
+		})
+		lc.Go("metrics", func(ctx context.Context) error { return serveHTTP(ctx, server, ln) })
+	}
+
+	if cfg.OTLPEndpoint != "" {
+		pusher := &metrics.Pusher{
+			Endpoint: cfg.OTLPEndpoint,
+			Service:  service,
+			Interval: cfg.OTLPInterval,
+			OnError: func(err error) {
+				fmt.Printf("⚠️  Envoi des métriques impossible: %v\n", err)
+			},
+		}
+		lc.Go("otlp", pusher.Run)
+	}
+}
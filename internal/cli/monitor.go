@@ -0,0 +1,548 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/internal/monitor"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+	"github.com/agbruneau/PubSub/pkg/models"
+	ui "github.com/gizak/termui/v3"
+	"github.com/spf13/cobra"
+)
+
+// monitorOptions regroupe les options propres à la commande monitor.
+type monitorOptions struct {
+	logFile         string        // Fichier de logs à surveiller (remplace la configuration).
+	eventsFile      string        // Fichier d'événements à surveiller (remplace la configuration).
+	exportDir       string        // Répertoire des graphiques exportés avec la touche x.
+	exportFormat    string        // Format des graphiques exportés (svg ou png).
+	headless        bool          // Désactive l'interface TUI et affiche un résumé périodique.
+	summaryInterval time.Duration // Intervalle entre deux résumés en mode headless.
+	output          string        // Fichier de destination des résumés en mode headless (défaut: sortie standard).
+	record          string        // Fichier de session où enregistrer les entrées traitées.
+	ingestHistory   bool          // Pré-remplit les métriques avec l'historique existant (remplace la configuration).
+}
+
+// newMonitorCommand crée la commande monitor.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande.
+func newMonitorCommand(cfgFlags *config.Flags) *cobra.Command {
+	var opts monitorOptions
+	cmd := &cobra.Command{
+		Use:   "monitor",
+		Short: "surveille les logs du tracker (analyze, play)",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runMonitor(cfgFlags, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.logFile, "log-file", "", "fichier de logs à surveiller (remplace la configuration)")
+	cmd.Flags().StringVar(&opts.eventsFile, "events-file", "", "fichier d'événements à surveiller (remplace la configuration)")
+	cmd.Flags().StringVar(&opts.exportDir, "export-dir", "exports", "répertoire des graphiques exportés avec la touche x")
+	cmd.Flags().StringVar(&opts.exportFormat, "export-format", monitor.ExportFormatSVG, "format des graphiques exportés (svg ou png)")
+	cmd.Flags().BoolVar(&opts.headless, "headless", false, "désactive l'interface TUI et affiche un résumé périodique")
+	cmd.Flags().DurationVar(&opts.summaryInterval, "summary-interval", 10*time.Second, "intervalle entre deux résumés en mode headless")
+	cmd.Flags().StringVar(&opts.output, "output", "", "fichier de destination des résumés en mode headless (défaut: sortie standard)")
+	cmd.Flags().StringVar(&opts.record, "record", "", "fichier de session où enregistrer les entrées traitées")
+	cmd.Flags().BoolVar(&opts.ingestHistory, "ingest-history", false, "pré-remplit les métriques avec l'historique existant (remplace la configuration)")
+	cmd.AddCommand(newAnalyzeCommand(), newPlayCommand())
+	return cmd
+}
+
+// runMonitor exécute la commande monitor : elle lance la surveillance des fichiers
+// de logs en arrière-plan, puis démarre soit l'interface TUI, soit le mode
+// headless selon les options fournies. Les sous-commandes analyze et play
+// relisent des fichiers historiques ou une session enregistrée.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//   - opts: Les options propres à la commande.
+//
+// Retourne:
+//   - error: Une erreur si la configuration est invalide ou si la surveillance échoue.
+func runMonitor(cfgFlags *config.Flags, opts monitorOptions) error {
+	if opts.summaryInterval <= 0 {
+		return fmt.Errorf("--summary-interval doit être positif (obtenu %s)", opts.summaryInterval)
+	}
+
+	cfg, err := loadConfig(cfgFlags, func(cfg *config.AppConfig, sources config.Sources) {
+		i18n.SetLocale(i18n.Detect(cfg.App.Locale))
+		if opts.logFile != "" {
+			cfg.Tracker.LogFile = opts.logFile
+			sources["tracker.log_file"] = config.SourceFlag
+		}
+		if opts.eventsFile != "" {
+			cfg.Tracker.EventsFile = opts.eventsFile
+			sources["tracker.events_file"] = config.SourceFlag
+		}
+		if opts.ingestHistory {
+			cfg.Monitor.IngestHistory = true
+			sources["monitor.ingest_history"] = config.SourceFlag
+		}
+	})
+	if cfg == nil {
+		return err
+	}
+	logConfigFile(cfgFlags.File())
+
+	// Créer une instance du moniteur
+	mon := monitor.New()
+	mon.SetQualityConfig(cfg.Monitor.Quality)
+	monitor.SetThresholds(cfg.Monitor.Thresholds)
+	if err := mon.SetTimeConfig(cfg.Monitor.TimeSource, cfg.Monitor.MaxClockSkew); err != nil {
+		return fmt.Errorf("Erreur de configuration: %w", err)
+	}
+
+	// Appliquer à chaud la formule de qualité et les seuils de la configuration distante (--remote-config),
+	// sans répéter sur l'interface les avertissements de dépréciation et de migration affichés au démarrage
+	config.SetDeprecationHandler(nil)
+	config.SetMigrationHandler(nil)
+	go cfgFlags.Watch(context.Background(), func(next *config.AppConfig, err error) {
+		if err == nil {
+			mon.SetQualityConfig(next.Monitor.Quality)
+			monitor.SetThresholds(next.Monitor.Thresholds)
+		}
+	})
+
+	stopRecording, err := startRecording(mon, opts.record)
+	if err != nil {
+		return fmt.Errorf("Erreur lors de l'enregistrement de la session: %w", err)
+	}
+	if err := startWatching(mon, cfg.Tracker.LogFile, cfg.Tracker.EventsFile, cfg.Monitor.IngestHistory); err != nil {
+		return fmt.Errorf("Erreur lors de l'ingestion de l'historique: %w", err)
+	}
+
+	probeInterval := cfg.Monitor.ProbeInterval
+	if len(cfg.Monitor.Processes) > 0 {
+		go monitor.WatchProcesses(cfg.Monitor.Processes, probeInterval)
+	}
+	if cfg.Monitor.ClusterProbe && !cfg.Kafka.Brokers.IsKafka() {
+		fmt.Println("ℹ️  Sonde du cluster Kafka désactivée avec le broker", cfg.Kafka.Brokers.Redacted())
+	} else if cfg.Monitor.ClusterProbe {
+		src, err := monitor.NewKafkaMetadataSource(cfg.Kafka.Brokers.String(), cfg.Security.Properties())
+		if err != nil {
+			return fmt.Errorf("Erreur lors de la connexion au cluster Kafka: %w", err)
+		}
+		go monitor.WatchCluster(src, cfg.Kafka.Topic, probeInterval)
+	}
+	if cfg.Monitor.LatencyFile != "" {
+		go mon.WatchLatency(cfg.Monitor.LatencyFile, cfg.Monitor.UIUpdateInterval)
+	}
+
+	stopDebug, err := startDebug(cfg.Debug)
+	if err != nil {
+		return fmt.Errorf("Erreur du diagnostic: %w", err)
+	}
+	defer stopDebug()
+
+	if opts.headless {
+		err = runHeadless(mon, opts.summaryInterval, opts.output)
+	} else {
+		runUI(mon, uiOptions{ExportDir: opts.exportDir, ExportFormat: opts.exportFormat, RefreshInterval: cfg.Monitor.UIUpdateInterval})
+	}
+	if recErr := stopRecording(); err == nil {
+		err = recErr
+	}
+	if err != nil {
+		return fmt.Errorf("Erreur du moniteur: %w", err)
+	}
+	return nil
+}
+
+// uiOptions regroupe les options de l'interface TUI.
+type uiOptions struct {
+	ExportDir       string          // Répertoire des graphiques exportés.
+	ExportFormat    string          // Format des graphiques exportés (svg ou png).
+	Player          *monitor.Player // Lecteur de session dont la vitesse est réglable (nil hors lecture).
+	RefreshInterval time.Duration   // Intervalle de rafraîchissement initial (monitor.ui_update_interval ; 0: config.MonitorUIUpdateInterval).
+}
+
+// startRecording active l'enregistrement de la session si un fichier est fourni.
+//
+// Paramètres:
+//   - mon: Le moniteur à enregistrer.
+//   - path: Le fichier de session (vide pour ne pas enregistrer).
+//
+// Retourne:
+//   - func() error: La fonction qui termine l'enregistrement et ferme le fichier.
+//   - error: Une erreur si le fichier ne peut pas être créé.
+func startRecording(mon *monitor.Monitor, path string) (func() error, error) {
+	if path == "" {
+		return func() error { return nil }, nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("impossible de créer le fichier %s: %w", path, err)
+	}
+	recorder := monitor.NewRecorder(file)
+	mon.SetRecorder(recorder)
+
+	return func() error {
+		mon.SetRecorder(nil)
+		err := recorder.Flush()
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		return err
+	}, nil
+}
+
+// newPlayCommand crée la sous-commande monitor play.
+//
+// Retourne:
+//   - *cobra.Command: La sous-commande.
+func newPlayCommand() *cobra.Command {
+	var speed float64
+	cmd := &cobra.Command{
+		Use:   "play <session.jsonl>",
+		Short: "rejoue une session enregistrée (--record) dans l'interface TUI",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := runPlay(args[0], speed); err != nil {
+				return fmt.Errorf("Erreur lors de la lecture de la session: %w", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Float64Var(&speed, "speed", 1, "facteur de vitesse de lecture (1 = vitesse d'enregistrement, 0 = instantané)")
+	return cmd
+}
+
+// runPlay exécute la sous-commande de lecture d'une session enregistrée.
+//
+// Paramètres:
+//   - path: Le fichier de session.
+//   - speed: Le facteur de vitesse de lecture.
+//
+// Retourne:
+//   - error: Une erreur si la lecture échoue.
+func runPlay(path string, speed float64) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	mon := monitor.New()
+	player := monitor.NewPlayer(mon, speed)
+
+	done := make(chan struct{})
+	var playErr error
+	go func() {
+		playErr = player.Play(file)
+		close(done)
+	}()
+
+	runUI(mon, uiOptions{ExportDir: "exports", ExportFormat: monitor.ExportFormatSVG, Player: player})
+
+	select {
+	case <-done:
+		return playErr
+	default:
+		return nil
+	}
+}
+
+// analyzeOptions regroupe les options de la sous-commande monitor analyze.
+type analyzeOptions struct {
+	speed           float64       // Facteur de vitesse de relecture (0 = instantané).
+	withUI          bool          // Affiche le tableau de bord TUI pendant la relecture.
+	summaryInterval time.Duration // Intervalle entre deux résumés pendant une relecture cadencée.
+}
+
+// newAnalyzeCommand crée la sous-commande monitor analyze.
+//
+// Retourne:
+//   - *cobra.Command: La sous-commande.
+func newAnalyzeCommand() *cobra.Command {
+	var opts analyzeOptions
+	cmd := &cobra.Command{
+		Use:   "analyze <tracker.events> [tracker.log]",
+		Short: "relit hors-ligne des fichiers historiques du tracker",
+		Args:  usageArgs(cobra.MinimumNArgs(1)),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := runAnalyze(args, opts); err != nil {
+				return fmt.Errorf("Erreur lors de l'analyse: %w", err)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Float64Var(&opts.speed, "speed", 0, "facteur de vitesse de relecture (0 = instantané, 1 = temps réel)")
+	cmd.Flags().BoolVar(&opts.withUI, "ui", false, "affiche le tableau de bord TUI pendant la relecture")
+	cmd.Flags().DurationVar(&opts.summaryInterval, "summary-interval", 10*time.Second, "intervalle entre deux résumés pendant une relecture cadencée")
+	return cmd
+}
+
+// runAnalyze exécute la sous-commande d'analyse hors-ligne des fichiers historiques.
+//
+// Paramètres:
+//   - files: Les fichiers à relire.
+//   - opts: Les options de la sous-commande.
+//
+// Retourne:
+//   - error: Une erreur si les options sont invalides ou si la relecture échoue.
+func runAnalyze(files []string, opts analyzeOptions) error {
+	if opts.summaryInterval <= 0 {
+		return fmt.Errorf("--summary-interval doit être positif (obtenu %s)", opts.summaryInterval)
+	}
+
+	mon := monitor.New()
+	replayer := monitor.NewReplayer(mon, opts.speed)
+
+	// Le contexte est annulé à la fin de la relecture
+	replaying, replayed := context.WithCancel(context.Background())
+	var replayErr error
+	go func() {
+		replayErr = replayer.ReplayFiles(files)
+		replayed()
+	}()
+
+	switch {
+	case opts.withUI:
+		runUI(mon, uiOptions{ExportDir: "exports", ExportFormat: monitor.ExportFormatSVG})
+	case opts.speed > 0:
+		if err := mon.RunHeadless(replaying, os.Stdout, opts.summaryInterval); err != nil {
+			return err
+		}
+	default:
+		<-replaying.Done()
+		mon.UpdateUptime()
+		fmt.Print(monitor.FormatSummary(mon.Snapshot()))
+	}
+
+	select {
+	case <-replaying.Done():
+		return replayErr
+	default:
+		return nil
+	}
+}
+
+// startWatching lance la surveillance des fichiers de logs et le traitement des entrées.
+// Si ingest est vrai, le contenu déjà présent sur disque (segments rotatifs compris)
+// est traité avant le suivi, qui reprend ensuite là où l'ingestion s'est arrêtée.
+//
+// Paramètres:
+//   - mon: Le moniteur à alimenter.
+//   - logFile: Le chemin du fichier de logs structurés.
+//   - eventsFile: Le chemin du fichier de piste d'audit.
+//   - ingest: Indique s'il faut ingérer l'historique existant.
+//
+// Retourne:
+//   - error: Une erreur si l'historique ne peut pas être lu.
+func startWatching(mon *monitor.Monitor, logFile, eventsFile string, ingest bool) error {
+	var logOffset, eventsOffset int64
+	if ingest {
+		var err error
+		if logOffset, err = mon.IngestHistory(logFile, monitor.FileKindLogs); err != nil {
+			return err
+		}
+		if eventsOffset, err = mon.IngestHistory(eventsFile, monitor.FileKindEvents); err != nil {
+			return err
+		}
+	}
+
+	// Canaux pour les logs et les événements
+	logChan := make(chan models.LogEntry, config.MonitorLogChannelBuffer)
+	eventChan := make(chan models.EventEntry, config.MonitorEventChannelBuffer)
+
+	// Démarrer la surveillance des fichiers
+	go monitor.MonitorFileFrom(logFile, logOffset, logChan, nil)
+	go monitor.MonitorFileFrom(eventsFile, eventsOffset, nil, eventChan)
+
+	// Traiter les logs et les événements
+	go func() {
+		for {
+			select {
+			case log := <-logChan:
+				mon.ProcessLog(log)
+			case event := <-eventChan:
+				mon.ProcessEvent(event)
+			}
+		}
+	}()
+	return nil
+}
+
+// runHeadless affiche périodiquement un résumé des métriques jusqu'à la réception d'un signal d'arrêt.
+//
+// Paramètres:
+//   - mon: Le moniteur.
+//   - interval: L'intervalle entre deux résumés.
+//   - output: Le fichier de destination (vide pour la sortie standard).
+//
+// Retourne:
+//   - error: Une erreur si l'ouverture du fichier ou l'écriture échoue.
+func runHeadless(mon *monitor.Monitor, interval time.Duration, output string) error {
+	var w io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("impossible d'ouvrir le fichier %s: %w", output, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	// Gérer les signaux d'arrêt
+	ctx, stop := lifecycle.SignalContext(context.Background())
+	defer stop()
+
+	return mon.RunHeadless(ctx, w, interval)
+}
+
+// runUI initialise l'interface TUI et gère la boucle d'événements pour l'affichage
+// et les interactions utilisateur.
+//
+// Paramètres:
+//   - mon: Le moniteur à afficher.
+//   - opts: Les options de l'interface.
+func runUI(mon *monitor.Monitor, opts uiOptions) {
+	if err := ui.Init(); err != nil {
+		fmt.Printf("Erreur lors de l'initialisation de l'UI: %v\n", err)
+		os.Exit(1)
+	}
+	defer ui.Close()
+
+	// Créer les widgets
+	dashboard := monitor.NewDashboard()
+	details := monitor.CreateDetailsPopup()
+	help := monitor.CreateHelpOverlay()
+	showDetails, showHelp, paused := false, false, false
+
+	// Gérer le redimensionnement et les événements UI
+	uiEvents := ui.PollEvents()
+	initialInterval := opts.RefreshInterval
+	if initialInterval == 0 {
+		initialInterval = config.MonitorUIUpdateInterval
+	}
+	refreshRate := monitor.NewRefreshRate(initialInterval) // borné entre 100 ms et 5 s
+	ticker := time.NewTicker(refreshRate.Interval())
+	defer ticker.Stop()
+	status := monitor.StatusInfo{RefreshInterval: refreshRate.Interval(), Files: monitor.FileStates()}
+	monitor.UpdateStatusBar(dashboard.StatusBar, status)
+
+	// Configuration initiale de la mise en page (layout)
+	termWidth, termHeight := ui.TerminalDimensions()
+	dashboard.Resize(termWidth, termHeight)
+	monitor.CenterPopup(details, termWidth, termHeight)
+	monitor.CenterPopup(help, termWidth, termHeight)
+
+	// render affiche le tableau de bord et, le cas échéant, les fenêtres de détails et d'aide par-dessus
+	render := func() {
+		ui.Render(dashboard.Drawables()...)
+		if showDetails {
+			ui.Render(details)
+		}
+		if showHelp {
+			ui.Render(help)
+		}
+	}
+
+	// refreshStatus met à jour la barre d'état et l'affiche
+	refreshStatus := func() {
+		status.Paused = paused
+		status.Filters = dashboard.Filter.Active()
+		status.Files = monitor.FileStates()
+		monitor.UpdateStatusBar(dashboard.StatusBar, status)
+		ui.Render(dashboard.StatusBar)
+	}
+	render()
+
+	for {
+		select {
+		case e := <-uiEvents:
+			switch e.ID {
+			case "q", "<C-c>":
+				return
+			case "<Resize>":
+				payload := e.Payload.(ui.Resize)
+				dashboard.Resize(payload.Width, payload.Height)
+				monitor.CenterPopup(details, payload.Width, payload.Height)
+				monitor.CenterPopup(help, payload.Width, payload.Height)
+
+				ui.Clear()
+				render()
+			case "<MouseLeft>":
+				// Sélection d'une ligne ou déplacement d'un séparateur de panneaux
+				pane, row := dashboard.HandleMouseDown(e.Payload.(ui.Mouse))
+				if text, ok := mon.EntryDetails(pane, row, dashboard.Filter); ok {
+					details.Text = text
+					showDetails = true
+				}
+				ui.Clear()
+				render()
+			case "<MouseRelease>":
+				dashboard.HandleMouseRelease()
+			case "<Escape>":
+				showDetails, showHelp = false, false
+				ui.Clear()
+				render()
+			case "?":
+				showHelp = !showHelp
+				ui.Clear()
+				render()
+			case "p":
+				// Suspendre ou reprendre le rafraîchissement (les métriques continuent d'être collectées)
+				paused = !paused
+				refreshStatus()
+			case "x":
+				// Exporter les graphiques en images
+				paths, err := mon.ExportCharts(opts.ExportDir, opts.ExportFormat)
+				if err != nil {
+					status.Message = i18n.T("monitor.export.failed", err)
+				} else {
+					status.Message = i18n.T("monitor.export.done", len(paths), opts.ExportDir)
+				}
+				refreshStatus()
+			case "<", ">":
+				// Ajuster la vitesse de lecture d'une session
+				if opts.Player != nil {
+					speed := opts.Player.Slower()
+					if e.ID == ">" {
+						speed = opts.Player.Faster()
+					}
+					status.Message = i18n.T("monitor.playback.speed", speed)
+					refreshStatus()
+				}
+			case "e":
+				// Basculer le filtre des erreurs
+				dashboard.Filter.ErrorsOnly = !dashboard.Filter.ErrorsOnly
+				mon.Refresh(dashboard)
+				refreshStatus()
+				render()
+			case "+", "=", "-":
+				// Ajuster la fréquence de rafraîchissement
+				if e.ID == "-" {
+					refreshRate.Slower()
+				} else {
+					refreshRate.Faster()
+				}
+				ticker.Reset(refreshRate.Interval())
+				status.RefreshInterval = refreshRate.Interval()
+				refreshStatus()
+			}
+		case <-ticker.C:
+			if paused {
+				refreshStatus()
+				continue
+			}
+			mon.UpdateUptime()
+			mon.Refresh(dashboard)
+			status.LastRefresh = time.Now()
+			refreshStatus()
+			render()
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/internal/producer"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+// newProduceCommand crée la commande produce.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande.
+func newProduceCommand(cfgFlags *config.Flags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "produce",
+		Short: "publie des commandes sur le topic Kafka",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runProduce(cfgFlags)
+		},
+	}
+}
+
+// runProduce exécute la commande produce : elle charge la configuration, initialise
+// la connexion Kafka et démarre la boucle de production. Elle écoute également
+// les signaux système (SIGINT, SIGTERM) pour un arrêt gracieux (lifecycle.Manager) :
+// la boucle s'arrête, puis le producteur vide ses messages en attente.
+//
+// Chaque paramètre de configuration peut être surchargé par une option nommée
+// d'après son chemin YAML (ex.: --kafka.broker, --producer.interval).
+// Priorité: options > variables d'environnement > config.yaml (--config) > valeurs par défaut.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//
+// Retourne:
+//   - error: Une erreur si la configuration est invalide ou si le producteur ne peut pas être initialisé.
+func runProduce(cfgFlags *config.Flags) error {
+	// Charger la configuration (options > environnement > YAML > défauts)
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+
+	// Sélectionner la langue (app.locale, sinon LANG)
+	i18n.SetLocale(i18n.Detect(appCfg.App.Locale))
+	logConfigFile(cfgFlags.File())
+	cfg := producer.ConfigFrom(appCfg)
+
+	// Créer et initialiser le producteur, fermé après la fin de la boucle
+	prod := producer.New(cfg)
+	lc := lifecycle.New(lifecycle.DefaultTimeout)
+	lc.Append(lifecycle.Hook{
+		Name: "producer",
+		Start: func(ctx context.Context) error {
+			if err := prod.Initialize(); err != nil {
+				return errors.New(i18n.T("common.init_error", err))
+			}
+			// Signaler les mises à jour de la configuration distante (--remote-config)
+			go cfgFlags.Watch(ctx, reportRemoteChange)
+
+			fmt.Println(i18n.T("producer.started"))
+			fmt.Println(i18n.T("producer.publishing", cfg.Topic))
+			return nil
+		},
+		Stop: func(context.Context) error {
+			prod.Close()
+			return nil
+		},
+	})
+	lc.Go("production", prod.Run)
+	appendMetrics(lc, appCfg.Metrics, "pubsub-producer")
+	appendDebug(lc, appCfg.Debug)
+	return lc.Run(context.Background())
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// Couleurs ANSI des préfixes de sortie, attribuées aux processus dans l'ordre.
+var processColors = []string{"32", "36", "35", "33", "34"}
+
+// supervisedProcess est un processus lancé et relancé par un supervisor.
+type supervisedProcess struct {
+	Name string   // Nom affiché en préfixe de chaque ligne (ex.: "tracker").
+	Path string   // Exécutable.
+	Args []string // Arguments de l'exécutable.
+}
+
+// supervisor lance des processus, multiplexe leurs sorties ligne par ligne
+// avec un préfixe coloré et relance ceux qui s'arrêtent sans avoir été
+// demandés, jusqu'à MaxRestarts fois.
+type supervisor struct {
+	Out          io.Writer     // Sortie multiplexée.
+	Color        bool          // Colore les préfixes (codes ANSI).
+	MaxRestarts  int           // Relances maximales de chaque processus.
+	RestartDelay time.Duration // Attente avant chaque relance.
+	StopTimeout  time.Duration // Attente après l'interruption d'un processus avant de le tuer.
+
+	mu sync.Mutex // Sérialise les écritures sur Out.
+}
+
+// Run lance les processus et les supervise jusqu'à l'annulation du contexte,
+// qui les interrompt (SIGINT), ou jusqu'à l'abandon de chacun.
+//
+// Paramètres:
+//   - ctx: Le contexte d'arrêt.
+//   - procs: Les processus, dans leur ordre de lancement.
+//
+// Retourne:
+//   - error: Une erreur si un processus a été abandonné après MaxRestarts relances.
+func (s *supervisor) Run(ctx context.Context, procs []supervisedProcess) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(procs))
+	width := 0
+	for _, proc := range procs {
+		width = max(width, len(proc.Name))
+	}
+	for i, proc := range procs {
+		prefix := fmt.Sprintf("%-*s |", width, proc.Name)
+		if s.Color {
+			prefix = "\x1b[" + processColors[i%len(processColors)] + "m" + prefix + "\x1b[0m"
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.supervise(ctx, proc, &prefixWriter{s: s, prefix: prefix})
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// supervise exécute un processus et le relance tant que le contexte est actif.
+//
+// Paramètres:
+//   - ctx: Le contexte d'arrêt.
+//   - proc: Le processus.
+//   - out: La sortie préfixée du processus.
+//
+// Retourne:
+//   - error: Une erreur si le processus est abandonné.
+func (s *supervisor) supervise(ctx context.Context, proc supervisedProcess, out *prefixWriter) error {
+	defer out.Flush()
+	for restarts := 0; ; restarts++ {
+		cmd := exec.CommandContext(ctx, proc.Path, proc.Args...)
+		cmd.Stdout, cmd.Stderr = out, out
+		cmd.Cancel = func() error {
+			// Interruption gracieuse (indisponible sous Windows: le processus est tué)
+			if err := cmd.Process.Signal(os.Interrupt); err != nil {
+				return cmd.Process.Kill()
+			}
+			return nil
+		}
+		cmd.WaitDelay = s.StopTimeout
+		err := cmd.Run()
+		out.Flush()
+		if ctx.Err() != nil {
+			out.Printf("arrêté")
+			return nil
+		}
+		if restarts >= s.MaxRestarts {
+			out.Printf("abandonné après %d relances (%v)", restarts, err)
+			return fmt.Errorf("%s abandonné après %d relances: %v", proc.Name, restarts, err)
+		}
+		out.Printf("arrêté (%v), relance %d/%d dans %s", err, restarts+1, s.MaxRestarts, s.RestartDelay)
+		select {
+		case <-ctx.Done():
+			out.Printf("arrêté")
+			return nil
+		case <-time.After(s.RestartDelay):
+		}
+	}
+}
+
+// prefixWriter découpe la sortie d'un processus en lignes et écrit chacune,
+// précédée de son préfixe, sur la sortie du superviseur.
+type prefixWriter struct {
+	s      *supervisor
+	prefix string
+	buf    []byte // Ligne en cours, sans saut de ligne.
+}
+
+// Write écrit les lignes complètes et conserve la dernière ligne incomplète.
+//
+// Paramètres:
+//   - p: Les octets écrits par le processus.
+//
+// Retourne:
+//   - int: len(p).
+//   - error: Une erreur d'écriture sur la sortie du superviseur.
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := bytes.TrimSuffix(w.buf[:i], []byte("\r"))
+		if err := w.writeLine(string(line)); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+}
+
+// Flush écrit la ligne incomplète en attente.
+func (w *prefixWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.writeLine(string(w.buf))
+		w.buf = nil
+	}
+}
+
+// Printf écrit un message du superviseur avec le préfixe du processus.
+//
+// Paramètres:
+//   - format: Le format du message.
+//   - args: Les arguments du format.
+func (w *prefixWriter) Printf(format string, args ...any) {
+	w.writeLine("── " + fmt.Sprintf(format, args...))
+}
+
+// writeLine écrit une ligne préfixée.
+//
+// Paramètres:
+//   - line: La ligne, sans saut de ligne.
+//
+// Retourne:
+//   - error: Une erreur d'écriture.
+func (w *prefixWriter) writeLine(line string) error {
+	w.s.mu.Lock()
+	defer w.s.mu.Unlock()
+	_, err := fmt.Fprintf(w.s.Out, "%s %s\n", w.prefix, line)
+	return err
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/internal/tracker"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+// newTrackCommand crée la commande track.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande.
+func newTrackCommand(cfgFlags *config.Flags) *cobra.Command {
+	return &cobra.Command{
+		Use:   "track",
+		Short: "consomme et journalise les commandes",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runTrack(cfgFlags)
+		},
+	}
+}
+
+// runTrack exécute la commande track : elle charge la configuration, initialise la
+// connexion Kafka et les loggers, et démarre la consommation des messages. Elle
+// gère également l'arrêt gracieux via signaux (lifecycle.Manager) ; elle
+// s'arrête aussi lorsque le consommateur abandonne après des erreurs de lecture.
+//
+// Chaque paramètre de configuration peut être surchargé par une option nommée
+// d'après son chemin YAML (ex.: --kafka.broker, --tracker.log_file).
+// Priorité: options > variables d'environnement > config.yaml (--config) > valeurs par défaut.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//
+// Retourne:
+//   - error: Une erreur si la configuration est invalide ou si le tracker ne peut pas être initialisé.
+func runTrack(cfgFlags *config.Flags) error {
+	// Charger la configuration (options > environnement > YAML > défauts)
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+
+	// Sélectionner la langue (app.locale, sinon LANG)
+	i18n.SetLocale(i18n.Detect(appCfg.App.Locale))
+	logConfigFile(cfgFlags.File())
+	cfg := tracker.ConfigFrom(appCfg)
+
+	// Créer et initialiser le tracker, fermé après la fin de la consommation
+	trk := tracker.New(cfg)
+	lc := lifecycle.New(lifecycle.DefaultTimeout)
+	lc.Append(lifecycle.Hook{
+		Name: "tracker",
+		Start: func(ctx context.Context) error {
+			if err := trk.Initialize(); err != nil {
+				return errors.New(i18n.T("common.init_error", err))
+			}
+			// Signaler les mises à jour de la configuration distante (--remote-config)
+			go cfgFlags.Watch(ctx, reportRemoteChange)
+
+			fmt.Println(i18n.T("tracker.running"))
+			fmt.Println(i18n.T("tracker.log_file", cfg.LogFile))
+			fmt.Println(i18n.T("tracker.events_file", cfg.EventsFile))
+			return nil
+		},
+		Stop: func(context.Context) error {
+			trk.Close()
+			return nil
+		},
+	})
+	lc.Go("tracker", func(ctx context.Context) error {
+		err := trk.Run(ctx)
+		if ctx.Err() != nil {
+			fmt.Println(i18n.T("tracker.stop_signal"))
+		}
+		return err
+	})
+	appendMetrics(lc, appCfg.Metrics, "pubsub-tracker")
+	appendDebug(lc, appCfg.Debug)
+
+	err = lc.Run(context.Background())
+	fmt.Println(i18n.T("tracker.stopped"))
+	return err
+}
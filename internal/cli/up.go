@@ -0,0 +1,344 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+// Modes de démarrage du broker de la commande up (--kafka).
+const (
+	// upKafkaCompose démarre le service du broker dans docker-compose.yaml.
+	upKafkaCompose = "compose"
+	// upKafkaDocker démarre un conteneur autonome (docker run).
+	upKafkaDocker = "docker"
+	// upKafkaNone utilise un broker déjà démarré.
+	upKafkaNone = "none"
+)
+
+// upService est un broker que la commande up démarre dans un conteneur.
+type upService struct {
+	Name      string   // Nom affiché (ex.: "Kafka").
+	Container string   // Nom du conteneur, celui du service de docker-compose.yaml.
+	Profile   string   // Profil docker compose du service (vide : aucun).
+	RunArgs   []string // Options et image de docker run (--kafka docker).
+}
+
+// upKafka est le broker Kafka KRaft à nœud unique.
+var upKafka = upService{
+	Name:      "Kafka",
+	Container: "kafka",
+	RunArgs: []string{"-p", "9092:9092",
+		"-e", "KAFKA_NODE_ID=1",
+		"-e", "CLUSTER_ID=1L6g7nGhU-eAKfL--X25wo",
+		"-e", "KAFKA_PROCESS_ROLES=broker,controller",
+		"-e", "KAFKA_CONTROLLER_QUORUM_VOTERS=1@localhost:9093",
+		"-e", "KAFKA_LISTENERS=PLAINTEXT://0.0.0.0:9092,CONTROLLER://0.0.0.0:9093",
+		"-e", "KAFKA_ADVERTISED_LISTENERS=PLAINTEXT://localhost:9092",
+		"-e", "KAFKA_CONTROLLER_LISTENER_NAMES=CONTROLLER",
+		"-e", "KAFKA_OFFSETS_TOPIC_REPLICATION_FACTOR=1",
+		"confluentinc/cp-kafka:7.8.3"},
+}
+
+// upRabbitMQ est le broker RabbitMQ, démarré pour une URL amqp://.
+var upRabbitMQ = upService{
+	Name:      "RabbitMQ",
+	Container: "rabbitmq",
+	Profile:   "rabbitmq",
+	RunArgs:   []string{"-p", "5672:5672", "-p", "15672:15672", "rabbitmq:3.13-management"},
+}
+
+// Paramètres de supervision des processus de la commande up.
+const (
+	upMaxRestarts  = 3
+	upRestartDelay = 2 * time.Second
+	upStopTimeout  = 10 * time.Second
+	upPollInterval = 2 * time.Second
+)
+
+// runCommand exécute une commande externe (docker), sa sortie étant écrite sur
+// la sortie standard. Remplacée par les tests.
+var runCommand = func(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	return cmd.Run()
+}
+
+// upOptions regroupe les options propres à la commande up.
+type upOptions struct {
+	kafkaMode    string        // Démarrage du broker : compose, docker ou none.
+	readyTimeout time.Duration // Attente maximale de la disponibilité du broker.
+	keepKafka    bool          // Laisse le broker démarré à l'arrêt.
+	noMonitor    bool          // Ne lance pas le moniteur.
+}
+
+// newUpCommand crée la commande up.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande.
+func newUpCommand(cfgFlags *config.Flags) *cobra.Command {
+	var opts upOptions
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "démarre Kafka, les topics et les services de la démonstration",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runUp(cfgFlags, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.kafkaMode, "kafka", upKafkaCompose, "démarrage du broker (Kafka, ou RabbitMQ pour une URL amqp://): compose (docker compose), docker (docker run) ou none (broker existant)")
+	cmd.Flags().DurationVar(&opts.readyTimeout, "ready-timeout", time.Minute, "attente maximale de la disponibilité du broker")
+	cmd.Flags().BoolVar(&opts.keepKafka, "keep-kafka", false, "laisse le broker démarré à l'arrêt")
+	cmd.Flags().BoolVar(&opts.noMonitor, "no-monitor", false, "ne lance pas le moniteur")
+	return cmd
+}
+
+// runUp exécute la commande up, qui démarre la démonstration complète en local :
+// Kafka (service docker compose, conteneur autonome ou broker existant selon
+// --kafka), les topics requis, puis le tracker, le producteur et le moniteur
+// (en mode headless) comme processus supervisés dont les sorties sont
+// multiplexées. La touche q (suivie d'Entrée), SIGINT ou SIGTERM arrêtent les
+// processus puis, sauf --keep-kafka, Kafka. Avec le broker en mémoire
+// (--kafka.broker=memory://), Kafka n'est pas démarré et le tracker et le
+// producteur s'exécutent dans le processus de la commande demo. Avec une URL
+// amqp://, c'est RabbitMQ qui est démarré, sans création de topics.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées, transmises aux processus.
+//   - opts: Les options propres à la commande.
+//
+// Retourne:
+//   - error: Une erreur si Kafka ne démarre pas, si un topic ne peut pas être créé ou si un processus est abandonné.
+func runUp(cfgFlags *config.Flags, opts upOptions) error {
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+	if opts.kafkaMode != upKafkaCompose && opts.kafkaMode != upKafkaDocker && opts.kafkaMode != upKafkaNone {
+		return fmt.Errorf("--kafka doit valoir compose, docker ou none (obtenu %q)", opts.kafkaMode)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	brokers := appCfg.Kafka.Brokers
+	memoryBroker := brokers.IsMemory()
+	svc, addrs := upKafka, []string(brokers)
+	if brokers.IsAMQP() {
+		addr, err := config.AMQPAddress(brokers[0])
+		if err != nil {
+			return err
+		}
+		svc, addrs = upRabbitMQ, []string{addr}
+	}
+	if memoryBroker {
+		fmt.Println("🧪 Broker en mémoire : Kafka n'est pas démarré.")
+	} else {
+		if err := startService(opts.kafkaMode, svc); err != nil {
+			return fmt.Errorf("Erreur lors du démarrage de %s: %w", svc.Name, err)
+		}
+		if opts.kafkaMode != upKafkaNone && !opts.keepKafka {
+			defer stopService(opts.kafkaMode, svc)
+		}
+		fmt.Printf("⏳ Attente de la disponibilité du broker %s...\n", svc.Name)
+		if err := waitForBrokers(addrs, opts.readyTimeout); err != nil {
+			return err
+		}
+		// Avec RabbitMQ, les exchanges et les files sont déclarés par les clients
+		if brokers.IsKafka() {
+			if err := createTopics(opts.kafkaMode, upTopics(appCfg)); err != nil {
+				return fmt.Errorf("Erreur lors de la création des topics: %w", err)
+			}
+		}
+	}
+
+	procs := upProcesses(exe, cfgFlags.Args(), memoryBroker)
+	if !opts.noMonitor {
+		procs = append(procs, supervisedProcess{Name: "monitor", Path: exe, Args: append([]string{"monitor", "--headless"}, cfgFlags.Args()...)})
+	}
+
+	ctx, stop := lifecycle.SignalContext(context.Background())
+	defer stop()
+	go waitForQuit(os.Stdin, stop)
+
+	fmt.Println("🎉 Environnement démarré. Appuyez sur q puis Entrée (ou Ctrl+C) pour tout arrêter.")
+	sup := &supervisor{
+		Out:          os.Stdout,
+		Color:        os.Getenv("NO_COLOR") == "",
+		MaxRestarts:  upMaxRestarts,
+		RestartDelay: upRestartDelay,
+		StopTimeout:  upStopTimeout,
+	}
+	err = sup.Run(ctx, procs)
+	fmt.Println("🔄 Arrêt de l'environnement...")
+	return err
+}
+
+// upProcesses retourne les processus du tracker et du producteur. Le broker en
+// mémoire ne reliant que les clients d'un même processus, ils sont alors
+// exécutés ensemble par la commande demo.
+//
+// Paramètres:
+//   - exe: Le chemin du binaire pubsub.
+//   - forwarded: Les options de configuration transmises.
+//   - memoryBroker: Vrai avec le broker en mémoire (config.MemoryBroker).
+//
+// Retourne:
+//   - []supervisedProcess: Les processus à superviser.
+func upProcesses(exe string, forwarded []string, memoryBroker bool) []supervisedProcess {
+	if memoryBroker {
+		return []supervisedProcess{{Name: "demo", Path: exe, Args: append([]string{"demo"}, forwarded...)}}
+	}
+	return []supervisedProcess{
+		{Name: "tracker", Path: exe, Args: append([]string{"track"}, forwarded...)},
+		{Name: "producer", Path: exe, Args: append([]string{"produce"}, forwarded...)},
+	}
+}
+
+// startService démarre un broker selon le mode choisi.
+//
+// Paramètres:
+//   - mode: upKafkaCompose, upKafkaDocker ou upKafkaNone.
+//   - svc: Le broker.
+//
+// Retourne:
+//   - error: Une erreur si la commande docker échoue.
+func startService(mode string, svc upService) error {
+	switch mode {
+	case upKafkaCompose:
+		fmt.Println("🚀 Démarrage du service", svc.Container, "(docker compose)...")
+		args := []string{"compose"}
+		if svc.Profile != "" {
+			args = append(args, "--profile", svc.Profile)
+		}
+		return runCommand("docker", append(args, "up", "-d", svc.Container)...)
+	case upKafkaDocker:
+		fmt.Println("🚀 Démarrage du conteneur", svc.Container, "(docker run)...")
+		return runCommand("docker", append([]string{"run", "-d", "--rm", "--name", svc.Container}, svc.RunArgs...)...)
+	}
+	return nil
+}
+
+// stopService arrête le broker démarré par startService.
+//
+// Paramètres:
+//   - mode: upKafkaCompose ou upKafkaDocker.
+//   - svc: Le broker.
+func stopService(mode string, svc upService) {
+	fmt.Printf("🛑 Arrêt de %s...\n", svc.Name)
+	var err error
+	if mode == upKafkaCompose {
+		args := []string{"compose"}
+		if svc.Profile != "" {
+			args = append(args, "--profile", svc.Profile)
+		}
+		err = runCommand("docker", append(args, "stop", svc.Container)...)
+	} else {
+		err = runCommand("docker", "stop", svc.Container)
+	}
+	if err != nil {
+		fmt.Printf("Erreur lors de l'arrêt de %s: %v\n", svc.Name, err)
+	}
+}
+
+// waitForBrokers attend qu'un broker accepte les connexions TCP.
+//
+// Paramètres:
+//   - brokers: Les adresses des brokers (host:port).
+//   - timeout: L'attente maximale.
+//
+// Retourne:
+//   - error: Une erreur si aucun broker n'est disponible dans le délai.
+func waitForBrokers(brokers []string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		for _, broker := range brokers {
+			conn, err := net.DialTimeout("tcp", broker, config.ConfigCheckTimeout)
+			if err == nil {
+				conn.Close()
+				fmt.Println("✅ Broker", broker, "disponible")
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("aucun broker disponible après %s (%s)", timeout, strings.Join(brokers, ","))
+		}
+		time.Sleep(upPollInterval)
+	}
+}
+
+// upTopics liste les topics requis par la configuration : le topic principal,
+// les topics de relance par paliers et les topics de la DLQ.
+//
+// Paramètres:
+//   - cfg: La configuration de l'application.
+//
+// Retourne:
+//   - []string: Les noms des topics.
+func upTopics(cfg *config.AppConfig) []string {
+	topics := []string{cfg.Kafka.Topic}
+	if cfg.Retry.Topics.Enabled {
+		topics = append(topics, retry.RetryTiers{Topic: cfg.Kafka.Topic, Delays: cfg.Retry.Topics.Tiers}.Topics()...)
+	}
+	if cfg.DLQ.Enabled {
+		topics = append(topics, cfg.DLQ.Topic, cfg.DLQ.Reprocessor.ParkTopic)
+	}
+	return topics
+}
+
+// createTopics crée les topics manquants avec l'outil kafka-topics du
+// conteneur Kafka. Avec un broker existant, la création est laissée à
+// l'auto-création du broker.
+//
+// Paramètres:
+//   - mode: Le mode de démarrage de Kafka.
+//   - topics: Les noms des topics.
+//
+// Retourne:
+//   - error: Une erreur si un topic ne peut pas être créé.
+func createTopics(mode string, topics []string) error {
+	if mode == upKafkaNone {
+		fmt.Println("ℹ️  Création des topics laissée au broker (--kafka none):", strings.Join(topics, ", "))
+		return nil
+	}
+	for _, topic := range topics {
+		fmt.Println("📝 Création du topic", topic, "(s'il n'existe pas)...")
+		err := runCommand("docker", "exec", upKafka.Container, "kafka-topics",
+			"--bootstrap-server", "localhost:9092", "--create", "--if-not-exists",
+			"--topic", topic, "--partitions", "1", "--replication-factor", "1")
+		if err != nil {
+			return fmt.Errorf("topic %s: %w", topic, err)
+		}
+	}
+	return nil
+}
+
+// waitForQuit appelle stop quand la ligne « q » est lue sur l'entrée.
+//
+// Paramètres:
+//   - in: L'entrée (le terminal).
+//   - stop: La fonction d'arrêt de l'environnement.
+func waitForQuit(in io.Reader, stop func()) {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		if strings.EqualFold(strings.TrimSpace(scanner.Text()), "q") {
+			stop()
+			return
+		}
+	}
+}
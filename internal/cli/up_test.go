@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+)
+
+// TestHelperProcess n'est pas un test : c'est le processus supervisé lancé
+// par les tests du superviseur.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("PUBSUB_HELPER_PROCESS") != "1" {
+		return
+	}
+	fmt.Print("ligne 1\nligne")
+	fmt.Println(" 2")
+	os.Exit(1)
+}
+
+// helperProcess retourne un processus supervisé exécutant TestHelperProcess.
+//
+// Paramètres:
+//   - name: Le nom du processus.
+//
+// Retourne:
+//   - supervisedProcess: Le processus.
+func helperProcess(name string) supervisedProcess {
+	return supervisedProcess{Name: name, Path: os.Args[0], Args: []string{"-test.run=^TestHelperProcess$"}}
+}
+
+// TestSupervisorRestarts vérifie que le superviseur préfixe chaque ligne et
+// relance un processus arrêté jusqu'à l'abandon.
+func TestSupervisorRestarts(t *testing.T) {
+	t.Setenv("PUBSUB_HELPER_PROCESS", "1")
+	var out bytes.Buffer
+	sup := &supervisor{Out: &out, MaxRestarts: 1, RestartDelay: time.Millisecond, StopTimeout: time.Second}
+
+	err := sup.Run(context.Background(), []supervisedProcess{helperProcess("aide")})
+	if err == nil || !strings.Contains(err.Error(), "aide abandonné après 1 relances") {
+		t.Errorf("Attendu l'abandon du processus, reçu %v", err)
+	}
+	if got := strings.Count(out.String(), "aide | ligne 1\naide | ligne 2\n"); got != 2 {
+		t.Errorf("Attendu 2 exécutions aux lignes préfixées, reçu %d:\n%s", got, out.String())
+	}
+	if !strings.Contains(out.String(), "relance 1/1") {
+		t.Errorf("Attendu l'annonce de la relance, reçu:\n%s", out.String())
+	}
+}
+
+// TestSupervisorStop vérifie que l'annulation du contexte arrête la supervision sans erreur.
+func TestSupervisorStop(t *testing.T) {
+	t.Setenv("PUBSUB_HELPER_PROCESS", "1")
+	var out bytes.Buffer
+	sup := &supervisor{Out: &out, MaxRestarts: 10, RestartDelay: time.Hour, StopTimeout: time.Second}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	if err := sup.Run(ctx, []supervisedProcess{helperProcess("aide")}); err != nil {
+		t.Errorf("Erreur inattendue: %v", err)
+	}
+	if !strings.HasSuffix(out.String(), "aide | ── arrêté\n") {
+		t.Errorf("Attendu l'arrêt du processus, reçu:\n%s", out.String())
+	}
+}
+
+// TestCreateTopics vérifie les topics requis et leur création dans le conteneur Kafka.
+func TestCreateTopics(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Retry.Topics.Enabled = true
+	topics := upTopics(cfg)
+	want := []string{"orders", "orders-retry-1m", "orders-retry-5m", "orders-dlq", "orders-dlq-parked"}
+	if !reflect.DeepEqual(topics, want) {
+		t.Errorf("Attendu les topics %v, reçu %v", want, topics)
+	}
+
+	var calls []string
+	original := runCommand
+	t.Cleanup(func() { runCommand = original })
+	runCommand = func(name string, args ...string) error {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		return nil
+	}
+
+	if err := createTopics(upKafkaNone, topics); err != nil || len(calls) != 0 {
+		t.Errorf("Attendu aucune création avec --kafka none, reçu %v (%v)", calls, err)
+	}
+	if err := createTopics(upKafkaCompose, topics); err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+	if len(calls) != len(topics) || !strings.HasPrefix(calls[0], "docker exec kafka kafka-topics") || !strings.Contains(calls[0], "--topic orders ") {
+		t.Errorf("Attendu une création par topic, reçu %v", calls)
+	}
+}
+
+// TestUpProcesses vérifie qu'avec le broker en mémoire, le tracker et le
+// producteur s'exécutent dans le même processus.
+func TestUpProcesses(t *testing.T) {
+	forwarded := []string{"--kafka.broker=memory://"}
+	procs := upProcesses("pubsub", forwarded, true)
+	if len(procs) != 1 || !reflect.DeepEqual(procs[0].Args, []string{"demo", "--kafka.broker=memory://"}) {
+		t.Errorf("Attendu le seul processus demo, reçu %+v", procs)
+	}
+	procs = upProcesses("pubsub", nil, false)
+	if len(procs) != 2 || procs[0].Args[0] != "track" || procs[1].Args[0] != "produce" {
+		t.Errorf("Attendu les processus track et produce, reçu %+v", procs)
+	}
+}
+
+// TestStartService vérifie les commandes docker de démarrage et d'arrêt des brokers.
+func TestStartService(t *testing.T) {
+	var calls []string
+	original := runCommand
+	t.Cleanup(func() { runCommand = original })
+	runCommand = func(name string, args ...string) error {
+		calls = append(calls, name+" "+strings.Join(args, " "))
+		return nil
+	}
+
+	startService(upKafkaCompose, upKafka)
+	startService(upKafkaCompose, upRabbitMQ)
+	startService(upKafkaDocker, upRabbitMQ)
+	startService(upKafkaNone, upRabbitMQ)
+	stopService(upKafkaCompose, upRabbitMQ)
+	want := []string{
+		"docker compose up -d kafka",
+		"docker compose --profile rabbitmq up -d rabbitmq",
+		"docker run -d --rm --name rabbitmq -p 5672:5672 -p 15672:15672 rabbitmq:3.13-management",
+		"docker compose --profile rabbitmq stop rabbitmq",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Attendu les commandes %v, reçu %v", want, calls)
+	}
+}
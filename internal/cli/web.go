@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/monitor"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/agbruneau/PubSub/internal/web"
+	"github.com/agbruneau/PubSub/pkg/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+// webShutdownTimeout est l'attente maximale de la fin des requêtes à l'arrêt du serveur web.
+const webShutdownTimeout = 5 * time.Second
+
+// webOptions regroupe les options propres à la commande web.
+type webOptions struct {
+	addr     string        // Adresse d'écoute du serveur web.
+	interval time.Duration // Intervalle entre deux mises à jour du flux.
+}
+
+// newWebCommand crée la commande web.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration partagées.
+//
+// Retourne:
+//   - *cobra.Command: La commande.
+func newWebCommand(cfgFlags *config.Flags) *cobra.Command {
+	var opts webOptions
+	cmd := &cobra.Command{
+		Use:   "web",
+		Short: "sert le tableau de bord web du système (API REST et SSE)",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return runWeb(cfgFlags, opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.addr, "addr", web.DefaultAddr, "adresse d'écoute du serveur web")
+	cmd.Flags().DurationVar(&opts.interval, "interval", web.DefaultInterval, "intervalle entre deux mises à jour du flux")
+	return cmd
+}
+
+// runWeb exécute la commande web : elle sert un tableau de bord web, alternative
+// au moniteur TUI, qui affiche en direct les statistiques du producteur
+// (producer.stats_file), les métriques et les derniers événements du tracker
+// (tracker.log_file et tracker.events_file, surveillés comme par le moniteur)
+// et, à la demande, le contenu de la DLQ (brokers Kafka et -tags kafka). La
+// page est alimentée par l'API REST et le flux Server-Sent Events décrits par
+// le paquet web. Le serveur s'arrête à la réception de SIGINT ou SIGTERM.
+//
+// Paramètres:
+//   - cfgFlags: Les options de configuration analysées.
+//   - opts: Les options propres à la commande.
+//
+// Retourne:
+//   - error: Une erreur si la configuration est invalide, si l'historique ne peut pas être lu ou si le serveur échoue.
+func runWeb(cfgFlags *config.Flags, opts webOptions) error {
+	appCfg, err := loadConfig(cfgFlags, nil)
+	if appCfg == nil {
+		return err
+	}
+	logConfigFile(cfgFlags.File())
+
+	mon := monitor.New()
+	mon.SetQualityConfig(appCfg.Monitor.Quality)
+	monitor.SetThresholds(appCfg.Monitor.Thresholds)
+	if err := mon.SetTimeConfig(appCfg.Monitor.TimeSource, appCfg.Monitor.MaxClockSkew); err != nil {
+		return fmt.Errorf("Erreur de configuration: %w", err)
+	}
+	if err := startWatching(mon, appCfg.Tracker.LogFile, appCfg.Tracker.EventsFile, appCfg.Monitor.IngestHistory); err != nil {
+		return fmt.Errorf("Erreur lors de l'ingestion de l'historique: %w", err)
+	}
+
+	srvCfg := web.Config{Monitor: mon, ProducerStatsFile: appCfg.Producer.StatsFile, Interval: opts.interval}
+	if appCfg.Kafka.Brokers.IsKafka() {
+		srvCfg.DLQ = dlqReader(appCfg)
+	}
+
+	server := &http.Server{
+		Handler:           web.New(srvCfg),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	ln, err := net.Listen("tcp", opts.addr)
+	if err != nil {
+		return fmt.Errorf("impossible d'écouter sur %s: %w", opts.addr, err)
+	}
+	fmt.Printf("🌐 Tableau de bord sur http://%s (Ctrl+C pour arrêter)\n", ln.Addr())
+
+	lc := lifecycle.New(lifecycle.DefaultTimeout)
+	lc.Go("web", func(ctx context.Context) error {
+		server.BaseContext = func(net.Listener) context.Context { return ctx } // termine les flux à l'arrêt
+		return serveHTTP(ctx, server, ln)
+	})
+	if err := lc.Run(context.Background()); err != nil {
+		return err
+	}
+	fmt.Println("✅ Serveur web arrêté")
+	return nil
+}
+
+// serveHTTP sert les requêtes reçues sur ln jusqu'à l'annulation de ctx, puis
+// arrête le serveur en attendant la fin des requêtes en cours
+// (webShutdownTimeout). Elle est destinée à lifecycle.Manager.Go.
+//
+// Paramètres:
+//   - ctx: Le contexte du service.
+//   - server: Le serveur HTTP.
+//   - ln: L'écouteur.
+//
+// Retourne:
+//   - error: L'erreur du serveur ou de son arrêt.
+func serveHTTP(ctx context.Context, server *http.Server, ln net.Listener) error {
+	errc := make(chan error, 1)
+	go func() { errc <- server.Serve(ln) }()
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), webShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// dlqReader retourne la lecture de la DLQ du tableau de bord. Chaque lecture
+// ouvre ses propres clients et relit la DLQ depuis le début ; les lectures
+// simultanées sont sérialisées, car elles partagent le groupe de consommateurs
+// de la commande dlq.
+//
+// Paramètres:
+//   - cfg: La configuration de l'application.
+//
+// Retourne:
+//   - web.DLQReader: La lecture de la DLQ.
+func dlqReader(cfg *config.AppConfig) web.DLQReader {
+	var mu sync.Mutex
+	return func(ctx context.Context) ([]retry.DLQRecord, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		client, closeClient, err := openDLQ(cfg)
+		if err != nil {
+			return nil, err
+		}
+		defer closeClient()
+		return client.Browse(ctx, defaultIdleTimeout)
+	}
+}
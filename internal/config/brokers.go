@@ -0,0 +1,151 @@
+package config
+
+import (
+	"net/url"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BrokerList is the list of Kafka bootstrap brokers. It is written either as
+// a YAML list or as a comma-separated string (e.g., KAFKA_BROKER=a:9092,b:9092).
+type BrokerList []string
+
+// MemoryBroker is the broker address selecting the in-process broker of the
+// pkg/transport/memory driver instead of Kafka. It must be the only broker of
+// the list.
+const MemoryBroker = "memory://"
+
+// AMQPSchemes are the URL schemes of the brokers selecting the RabbitMQ
+// driver (pkg/transport/rabbitmq) instead of Kafka. Such a broker must be the
+// only one of the list.
+var AMQPSchemes = []string{"amqp://", "amqps://"}
+
+// IsAMQPBroker reports whether a comma-separated broker list selects the
+// RabbitMQ transport.
+//
+// Parameters:
+//   - brokers: The comma-separated addresses.
+//
+// Returns:
+//   - bool: True if the list is a single amqp:// or amqps:// URL.
+func IsAMQPBroker(brokers string) bool {
+	return ParseBrokerList(brokers).IsAMQP()
+}
+
+// IsMemoryBroker reports whether a comma-separated broker list selects the
+// in-process broker.
+//
+// Parameters:
+//   - brokers: The comma-separated addresses.
+//
+// Returns:
+//   - bool: True if the list is MemoryBroker.
+func IsMemoryBroker(brokers string) bool {
+	return ParseBrokerList(brokers).IsMemory()
+}
+
+// ParseBrokerList splits a comma-separated broker list, ignoring blanks.
+//
+// Parameters:
+//   - s: The comma-separated addresses.
+//
+// Returns:
+//   - BrokerList: The addresses.
+func ParseBrokerList(s string) BrokerList {
+	var brokers BrokerList
+	for _, b := range strings.Split(s, ",") {
+		if b = strings.TrimSpace(b); b != "" {
+			brokers = append(brokers, b)
+		}
+	}
+	return brokers
+}
+
+// String joins the brokers for the bootstrap.servers client property.
+//
+// Returns:
+//   - string: The comma-separated addresses.
+func (b BrokerList) String() string {
+	return strings.Join(b, ",")
+}
+
+// IsMemory reports whether the list selects the in-process broker.
+//
+// Returns:
+//   - bool: True if the list is MemoryBroker.
+func (b BrokerList) IsMemory() bool {
+	return len(b) == 1 && b[0] == MemoryBroker
+}
+
+// Redacted joins the brokers for display, masking the password of AMQP URLs.
+//
+// Returns:
+//   - string: The comma-separated addresses.
+func (b BrokerList) Redacted() string {
+	redacted := make([]string, len(b))
+	for i, broker := range b {
+		redacted[i] = broker
+		if u, err := url.Parse(broker); err == nil && isAMQPURL(broker) {
+			redacted[i] = u.Redacted()
+		}
+	}
+	return strings.Join(redacted, ",")
+}
+
+// IsAMQP reports whether the list selects the RabbitMQ transport.
+//
+// Returns:
+//   - bool: True if the list is a single amqp:// or amqps:// URL.
+func (b BrokerList) IsAMQP() bool {
+	return len(b) == 1 && isAMQPURL(b[0])
+}
+
+// IsKafka reports whether the list designates Kafka brokers, rather than the
+// in-process broker or RabbitMQ.
+//
+// Returns:
+//   - bool: True for host:port addresses.
+func (b BrokerList) IsKafka() bool {
+	return !b.IsMemory() && !b.IsAMQP()
+}
+
+// isAMQPURL reports whether a broker address is an AMQP URL.
+//
+// Parameters:
+//   - broker: The broker address.
+//
+// Returns:
+//   - bool: True if the address starts with an AMQP scheme.
+func isAMQPURL(broker string) bool {
+	for _, scheme := range AMQPSchemes {
+		if strings.HasPrefix(broker, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalYAML accepts a list of addresses or a comma-separated string.
+//
+// Parameters:
+//   - node: The YAML node.
+//
+// Returns:
+//   - error: An error if the node is neither a string nor a list of strings.
+func (b *BrokerList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var s string
+		if err := node.Decode(&s); err != nil {
+			return err
+		}
+		*b = ParseBrokerList(s)
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*b = ParseBrokerList(strings.Join(list, ","))
+	return nil
+}
@@ -0,0 +1,256 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// CheckStatus is the outcome of a live check of the configuration.
+type CheckStatus string
+
+const (
+	// CheckPass means the check succeeded.
+	CheckPass CheckStatus = "PASS"
+	// CheckFail means the check failed; the demo is likely to fail too.
+	CheckFail CheckStatus = "FAIL"
+	// CheckSkip means the check does not apply or cannot run in this build.
+	CheckSkip CheckStatus = "SKIP"
+)
+
+// CheckResult is a row of the --check-config report.
+type CheckResult struct {
+	Name   string      // Checked item (e.g., broker localhost:9092).
+	Status CheckStatus // Outcome.
+	Detail string      // Error, skip reason or observed state.
+}
+
+// ClusterInspector queries the Kafka cluster for the topic checks.
+type ClusterInspector interface {
+	// Topics returns the names of the existing topics.
+	Topics(timeout time.Duration) (map[string]bool, error)
+	// AutoCreateTopics reports whether the brokers create missing topics on first use.
+	AutoCreateTopics(timeout time.Duration) (bool, error)
+	// Close releases the connection.
+	Close()
+}
+
+// errKafkaUnsupported is returned by NewClusterInspector without the kafka build tag.
+var errKafkaUnsupported = errors.New("built without kafka support (use -tags kafka)")
+
+// Check runs the live checks of the configuration: every broker accepts TCP
+// connections, the main and DLQ topics exist or can be auto-created, the
+// tracker files are writable and the schema registry answers. The checks
+// needing a Kafka client are skipped without the kafka build tag, and the
+// broker and topic checks with the in-process broker (MemoryBroker). With an
+// AMQP broker, only its TCP connection is checked.
+//
+// Returns:
+//   - []CheckResult: The results, in report order.
+func (c *AppConfig) Check() []CheckResult {
+	var results []CheckResult
+	topics := []string{c.Kafka.Topic}
+	if c.DLQ.Enabled {
+		topics = append(topics, c.DLQ.Topic)
+	}
+	if c.Kafka.Brokers.IsMemory() {
+		results = append(results, CheckResult{Name: "broker " + MemoryBroker, Status: CheckSkip, Detail: "in-process broker"})
+		for _, topic := range topics {
+			results = append(results, CheckResult{Name: "topic " + topic, Status: CheckSkip, Detail: "created on first use by the in-process broker"})
+		}
+		return c.checkLocal(results)
+	}
+	if c.Kafka.Brokers.IsAMQP() {
+		results = append(results, checkAMQPBroker(c.Kafka.Brokers[0], ConfigCheckTimeout))
+		for _, topic := range topics {
+			results = append(results, CheckResult{Name: "topic " + topic, Status: CheckSkip, Detail: "exchange declared on first use by the RabbitMQ transport"})
+		}
+		return c.checkLocal(results)
+	}
+
+	reachable := false
+	for _, broker := range c.Kafka.Brokers {
+		r := checkBroker(broker, ConfigCheckTimeout)
+		reachable = reachable || r.Status == CheckPass
+		results = append(results, r)
+	}
+
+	switch inspector, err := NewClusterInspector(c.Kafka.Brokers.String(), c.Security.Properties()); {
+	case err != nil:
+		for _, topic := range topics {
+			results = append(results, CheckResult{Name: "topic " + topic, Status: CheckSkip, Detail: err.Error()})
+		}
+	case !reachable:
+		inspector.Close()
+		for _, topic := range topics {
+			results = append(results, CheckResult{Name: "topic " + topic, Status: CheckSkip, Detail: "no reachable broker"})
+		}
+	default:
+		results = append(results, checkTopics(inspector, topics, ConfigCheckTimeout)...)
+		inspector.Close()
+	}
+	return c.checkLocal(results)
+}
+
+// checkLocal appends the checks not involving the brokers to the results: the
+// DLQ setting, the tracker files and the schema registry.
+//
+// Parameters:
+//   - results: The broker and topic results.
+//
+// Returns:
+//   - []CheckResult: The complete results, in report order.
+func (c *AppConfig) checkLocal(results []CheckResult) []CheckResult {
+	if !c.DLQ.Enabled {
+		results = append(results, CheckResult{Name: "dlq", Status: CheckSkip, Detail: "dlq.enabled is false"})
+	}
+
+	results = append(results, checkWritable(c.Tracker.LogFile), checkWritable(c.Tracker.EventsFile))
+
+	registry := CheckResult{Name: "schema registry", Status: CheckSkip, Detail: "schema_registry.url is empty"}
+	if c.SchemaRegistry.URL != "" {
+		registry = CheckResult{Name: "schema registry " + c.SchemaRegistry.URL, Status: CheckPass, Detail: "reachable"}
+		if err := c.SchemaRegistry.Check(&http.Client{Timeout: SchemaRegistryCheckTimeout}); err != nil {
+			registry.Status, registry.Detail = CheckFail, err.Error()
+		}
+	}
+	return append(results, registry)
+}
+
+// checkBroker verifies that a broker accepts TCP connections.
+//
+// Parameters:
+//   - broker: The broker address (host:port).
+//   - timeout: The connection timeout.
+//
+// Returns:
+//   - CheckResult: The result.
+func checkBroker(broker string, timeout time.Duration) CheckResult {
+	r := CheckResult{Name: "broker " + broker, Status: CheckPass, Detail: "reachable"}
+	conn, err := net.DialTimeout("tcp", broker, timeout)
+	if err != nil {
+		r.Status, r.Detail = CheckFail, err.Error()
+		return r
+	}
+	conn.Close()
+	return r
+}
+
+// checkAMQPBroker verifies that the broker of an AMQP URL accepts TCP connections.
+//
+// Parameters:
+//   - broker: The AMQP URL.
+//   - timeout: The connection timeout.
+//
+// Returns:
+//   - CheckResult: The result, named after the URL without its password.
+func checkAMQPBroker(broker string, timeout time.Duration) CheckResult {
+	name := "broker " + BrokerList{broker}.Redacted()
+	addr, err := AMQPAddress(broker)
+	if err != nil {
+		return CheckResult{Name: name, Status: CheckFail, Detail: err.Error()}
+	}
+	r := checkBroker(addr, timeout)
+	r.Name = name
+	return r
+}
+
+// checkTopics verifies that the topics exist, or that the brokers create them
+// on first use.
+//
+// Parameters:
+//   - inspector: The cluster inspector.
+//   - topics: The topic names.
+//   - timeout: The maximum wait time of each request.
+//
+// Returns:
+//   - []CheckResult: One result per topic.
+func checkTopics(inspector ClusterInspector, topics []string, timeout time.Duration) []CheckResult {
+	results := make([]CheckResult, len(topics))
+	existing, err := inspector.Topics(timeout)
+	var autoCreate, asked bool
+	var autoErr error
+	for i, topic := range topics {
+		r := CheckResult{Name: "topic " + topic, Status: CheckPass, Detail: "exists"}
+		switch {
+		case err != nil:
+			r.Status, r.Detail = CheckFail, err.Error()
+		case !existing[topic]:
+			if !asked {
+				autoCreate, autoErr = inspector.AutoCreateTopics(timeout)
+				asked = true
+			}
+			switch {
+			case autoErr != nil:
+				r.Status, r.Detail = CheckFail, fmt.Sprintf("missing (auto-creation unknown: %v)", autoErr)
+			case autoCreate:
+				r.Detail = "missing, auto-created on first use"
+			default:
+				r.Status, r.Detail = CheckFail, "missing and auto.create.topics.enable is false"
+			}
+		}
+		results[i] = r
+	}
+	return results
+}
+
+// checkWritable verifies that a file can be opened for appending, creating it
+// if needed (a file created by the check is removed).
+//
+// Parameters:
+//   - path: The file path.
+//
+// Returns:
+//   - CheckResult: The result.
+func checkWritable(path string) CheckResult {
+	r := CheckResult{Name: "file " + path, Status: CheckPass, Detail: "writable"}
+	_, statErr := os.Stat(path)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		r.Status, r.Detail = CheckFail, err.Error()
+		return r
+	}
+	file.Close()
+	if os.IsNotExist(statErr) {
+		os.Remove(path)
+		r.Detail = "writable (not created yet)"
+	}
+	return r
+}
+
+// CheckPassed reports whether no check failed.
+//
+// Parameters:
+//   - results: The check results.
+//
+// Returns:
+//   - bool: False if a result is CheckFail.
+func CheckPassed(results []CheckResult) bool {
+	for _, r := range results {
+		if r.Status == CheckFail {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteCheckReport prints the check results as aligned "status  item  detail" columns.
+//
+// Parameters:
+//   - w: The destination.
+//   - results: The check results.
+//
+// Returns:
+//   - error: An error if writing fails.
+func WriteCheckReport(w io.Writer, results []CheckResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Status, r.Name, r.Detail)
+	}
+	return tw.Flush()
+}
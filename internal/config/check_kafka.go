@@ -0,0 +1,103 @@
+//go:build kafka
+// +build kafka
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// kafkaInspector queries the cluster through a Kafka admin client.
+type kafkaInspector struct {
+	admin *kafka.AdminClient // The admin client.
+}
+
+// NewClusterInspector creates a cluster inspector connected to the brokers.
+//
+// Parameters:
+//   - brokers: The bootstrap brokers (comma-separated).
+//   - properties: Additional librdkafka properties (e.g., TLS/SASL security), may be nil.
+//
+// Returns:
+//   - ClusterInspector: The inspector.
+//   - error: An error if the admin client cannot be created.
+func NewClusterInspector(brokers string, properties map[string]string) (ClusterInspector, error) {
+	configMap := kafka.ConfigMap{"bootstrap.servers": brokers}
+	for name, value := range properties {
+		configMap[name] = value
+	}
+	admin, err := kafka.NewAdminClient(&configMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka admin client: %w", err)
+	}
+	return &kafkaInspector{admin: admin}, nil
+}
+
+// Topics returns the names of the existing topics.
+//
+// Parameters:
+//   - timeout: The maximum wait time for the brokers to answer.
+//
+// Returns:
+//   - map[string]bool: The topic names.
+//   - error: An error if the cluster is unreachable.
+func (k *kafkaInspector) Topics(timeout time.Duration) (map[string]bool, error) {
+	md, err := k.admin.GetMetadata(nil, true, int(timeout/time.Millisecond))
+	if err != nil {
+		return nil, err
+	}
+	topics := make(map[string]bool, len(md.Topics))
+	for name, t := range md.Topics {
+		if t.Error.Code() == kafka.ErrNoError {
+			topics[name] = true
+		}
+	}
+	return topics, nil
+}
+
+// AutoCreateTopics reads auto.create.topics.enable from the first broker.
+//
+// Parameters:
+//   - timeout: The maximum wait time for the brokers to answer.
+//
+// Returns:
+//   - bool: The broker setting.
+//   - error: An error if the broker configuration cannot be read.
+func (k *kafkaInspector) AutoCreateTopics(timeout time.Duration) (bool, error) {
+	md, err := k.admin.GetMetadata(nil, false, int(timeout/time.Millisecond))
+	if err != nil {
+		return false, err
+	}
+	if len(md.Brokers) == 0 {
+		return false, fmt.Errorf("no broker in the cluster metadata")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	resource := kafka.ConfigResource{Type: kafka.ResourceBroker, Name: strconv.Itoa(int(md.Brokers[0].ID))}
+	results, err := k.admin.DescribeConfigs(ctx, []kafka.ConfigResource{resource})
+	if err != nil {
+		return false, err
+	}
+	if len(results) == 0 {
+		return false, fmt.Errorf("broker %s: no configuration returned", resource.Name)
+	}
+	if results[0].Error.Code() != kafka.ErrNoError {
+		return false, fmt.Errorf("broker %s: %v", resource.Name, results[0].Error)
+	}
+	entry, ok := results[0].Config["auto.create.topics.enable"]
+	if !ok {
+		return false, fmt.Errorf("broker %s: auto.create.topics.enable not returned", resource.Name)
+	}
+	return strconv.ParseBool(entry.Value)
+}
+
+// Close releases the admin client.
+func (k *kafkaInspector) Close() {
+	k.admin.Close()
+}
@@ -0,0 +1,19 @@
+//go:build !kafka
+// +build !kafka
+
+package config
+
+// NewClusterInspector is unavailable without the "kafka" build tag, which keeps
+// the default binaries free of the CGO Kafka client: the topic checks of
+// --check-config are then skipped.
+//
+// Parameters:
+//   - brokers: The bootstrap brokers (unused).
+//   - properties: Additional librdkafka properties (unused).
+//
+// Returns:
+//   - ClusterInspector: Always nil.
+//   - error: errKafkaUnsupported.
+func NewClusterInspector(brokers string, properties map[string]string) (ClusterInspector, error) {
+	return nil, errKafkaUnsupported
+}
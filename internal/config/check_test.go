@@ -0,0 +1,145 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeInspector is a ClusterInspector with fixed answers.
+type fakeInspector struct {
+	topics     map[string]bool
+	autoCreate bool
+	err        error
+	asked      int
+}
+
+func (f *fakeInspector) Topics(time.Duration) (map[string]bool, error) { return f.topics, f.err }
+
+func (f *fakeInspector) AutoCreateTopics(time.Duration) (bool, error) {
+	f.asked++
+	return f.autoCreate, nil
+}
+
+func (f *fakeInspector) Close() {}
+
+func TestCheckBroker(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	if r := checkBroker(addr, time.Second); r.Status != CheckPass {
+		t.Errorf("Expected PASS for a listening broker, got %s (%s)", r.Status, r.Detail)
+	}
+	ln.Close()
+	if r := checkBroker(addr, time.Second); r.Status != CheckFail {
+		t.Errorf("Expected FAIL for a closed broker, got %s", r.Status)
+	}
+}
+
+func TestCheckTopics(t *testing.T) {
+	inspector := &fakeInspector{topics: map[string]bool{"orders": true}}
+	results := checkTopics(inspector, []string{"orders", "orders-dlq", "other"}, time.Second)
+	want := []CheckStatus{CheckPass, CheckFail, CheckFail}
+	for i, r := range results {
+		if r.Status != want[i] {
+			t.Errorf("%s: expected %s, got %s (%s)", r.Name, want[i], r.Status, r.Detail)
+		}
+	}
+	if inspector.asked != 1 {
+		t.Errorf("Expected auto-creation to be queried once, got %d", inspector.asked)
+	}
+
+	inspector = &fakeInspector{topics: map[string]bool{}, autoCreate: true}
+	if r := checkTopics(inspector, []string{"orders"}, time.Second)[0]; r.Status != CheckPass || !strings.Contains(r.Detail, "auto-created") {
+		t.Errorf("Expected PASS with auto-creation, got %s (%s)", r.Status, r.Detail)
+	}
+
+	inspector = &fakeInspector{err: errors.New("timed out")}
+	if r := checkTopics(inspector, []string{"orders"}, time.Second)[0]; r.Status != CheckFail {
+		t.Errorf("Expected FAIL when metadata is unavailable, got %s", r.Status)
+	}
+}
+
+func TestCheckWritable(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tracker.log")
+	if r := checkWritable(path); r.Status != CheckPass {
+		t.Errorf("Expected PASS, got %s (%s)", r.Status, r.Detail)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("The file created by the check should be removed")
+	}
+	if r := checkWritable(filepath.Join(dir, "missing", "tracker.log")); r.Status != CheckFail {
+		t.Errorf("Expected FAIL in a missing directory, got %s", r.Status)
+	}
+}
+
+func TestCheckReport(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer ln.Close()
+
+	cfg := DefaultConfig()
+	cfg.Kafka.Brokers = BrokerList{ln.Addr().String()}
+	cfg.Tracker.LogFile = filepath.Join(t.TempDir(), "tracker.log")
+	cfg.Tracker.EventsFile = filepath.Join(t.TempDir(), "missing", "tracker.events")
+	results := cfg.Check()
+	if CheckPassed(results) {
+		t.Error("Expected the unwritable events file to fail the check")
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCheckReport(&buf, results); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"PASS  broker " + ln.Addr().String(), "FAIL  file ", "SKIP  schema registry"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Report should contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestCheckMemoryBroker(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Kafka.Brokers = BrokerList{MemoryBroker}
+	cfg.Tracker.LogFile = filepath.Join(t.TempDir(), "tracker.log")
+	cfg.Tracker.EventsFile = filepath.Join(t.TempDir(), "tracker.events")
+	results := cfg.Check()
+	if !CheckPassed(results) {
+		t.Errorf("Expected the memory broker configuration to pass: %+v", results)
+	}
+	if results[0].Name != "broker "+MemoryBroker || results[0].Status != CheckSkip {
+		t.Errorf("Expected the broker check to be skipped, got %+v", results[0])
+	}
+	for _, r := range results {
+		if strings.HasPrefix(r.Name, "topic ") && r.Status != CheckSkip {
+			t.Errorf("Expected the topic checks to be skipped, got %+v", r)
+		}
+	}
+}
+
+func TestCheckAMQPBroker(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer ln.Close()
+
+	r := checkAMQPBroker("amqp://guest:secret@"+ln.Addr().String()+"/", time.Second)
+	if r.Status != CheckPass || strings.Contains(r.Name, "secret") {
+		t.Errorf("Expected a passing check without password, got %+v", r)
+	}
+	if r := checkAMQPBroker("amqp:///vhost", time.Second); r.Status != CheckFail {
+		t.Errorf("Expected a failing check for a URL without host, got %+v", r)
+	}
+}
@@ -16,6 +16,34 @@ const (
 	DefaultConsumerGroup = "order-tracker-group"
 	// DefaultTopic is the default Kafka topic.
 	DefaultTopic = "orders"
+	// DefaultAutoOffsetReset is where a consumer group without committed offsets starts.
+	DefaultAutoOffsetReset = "earliest"
+)
+
+// Configuration Files
+const (
+	// ConfigFileName is the name of the configuration file looked up by SearchPaths.
+	ConfigFileName = "config.yaml"
+	// ConfigDirName is the directory of the configuration file under $XDG_CONFIG_HOME.
+	ConfigDirName = "pubsub"
+	// ConfigSystemDir is the system-wide directory of the configuration file.
+	ConfigSystemDir = "/etc/pubsub"
+	// RemoteFetchTimeout is the maximum wait time of a remote configuration request.
+	RemoteFetchTimeout = 5 * time.Second
+	// RemoteWatchInterval is the interval between two polls of the remote configuration.
+	RemoteWatchInterval = 10 * time.Second
+)
+
+// Schema Registry Configuration
+const (
+	// SchemaRegistrySubjectNameStrategy is the default subject naming strategy.
+	SchemaRegistrySubjectNameStrategy = "topic_name"
+	// SchemaRegistryCacheCapacity is the default number of schemas kept in the client cache.
+	SchemaRegistryCacheCapacity = 1000
+	// SchemaRegistryCheckTimeout is the maximum wait time of the connectivity check.
+	SchemaRegistryCheckTimeout = 5 * time.Second
+	// ConfigCheckTimeout is the maximum wait time of each broker and topic check of --check-config.
+	ConfigCheckTimeout = 5 * time.Second
 )
 
 // Log Files
@@ -24,6 +52,14 @@ const (
 	TrackerLogFile = "logs/tracker.log"
 	// TrackerEventsFile is the name of the event audit file.
 	TrackerEventsFile = "logs/tracker.events"
+	// LatencyStreamFile is the file of the latency snapshots streamed by the latency probe.
+	LatencyStreamFile = "logs/latency.jsonl"
+	// LatencySummaryFile is the file of the latency distribution written when the probe stops.
+	LatencySummaryFile = "logs/latency-summary.json"
+	// ChaosStateFile is the file of the faults scheduled by pubsub chaos.
+	ChaosStateFile = "logs/chaos.json"
+	// ProducerStatsFile is the file of the live producer statistics read by pubsub web.
+	ProducerStatsFile = "logs/producer-stats.json"
 )
 
 // Common timeouts and intervals
@@ -50,6 +86,10 @@ const (
 	ProducerDefaultPayment = "credit_card"
 	// ProducerDefaultWarehouse is the default warehouse.
 	ProducerDefaultWarehouse = "PARIS-01"
+	// ProducerPartitionKey is the order field keying the messages ("customer", "order" or "region").
+	ProducerPartitionKey = "customer"
+	// ProducerStatsInterval is the interval between two writes of the producer statistics file.
+	ProducerStatsInterval = 1 * time.Second
 )
 
 // Tracker (consumer) constants
@@ -64,14 +104,79 @@ const (
 	TrackerServiceName = "order-tracker"
 )
 
+// Retry constants
+const (
+	// BackoffConstant waits retry.initial_delay before every retry.
+	BackoffConstant = "constant"
+	// BackoffExponential multiplies the delay by retry.multiplier at every retry.
+	BackoffExponential = "exponential"
+	// BackoffDecorrelatedJitter draws every delay between retry.initial_delay and three times the previous one.
+	BackoffDecorrelatedJitter = "decorrelated_jitter"
+	// BackoffFibonacci grows the delay along the Fibonacci sequence.
+	BackoffFibonacci = "fibonacci"
+	// RetryJitter is the default random variation of the retry delays (±25%).
+	RetryJitter = 0.25
+	// RetryTopicFirstTier is the delay of the first retry topic (orders-retry-1m).
+	RetryTopicFirstTier = 1 * time.Minute
+	// RetryTopicSecondTier is the delay of the second retry topic (orders-retry-5m).
+	RetryTopicSecondTier = 5 * time.Minute
+	// RetryTopicConsumerGroupSuffix is appended to the consumer group of the retry topic consumer.
+	RetryTopicConsumerGroupSuffix = "-retry"
+)
+
+// Circuit breaker constants
+const (
+	// CircuitBreakerFailureThreshold is the number of consecutive Kafka errors opening the circuit
+	// (below TrackerMaxConsecutiveErrors, so that the tracker pauses before giving up).
+	CircuitBreakerFailureThreshold = 2
+	// CircuitBreakerResetTimeout is the open duration before a trial call.
+	CircuitBreakerResetTimeout = 5 * time.Second
+	// CircuitBreakerSuccessThreshold is the number of consecutive trial successes closing the circuit.
+	CircuitBreakerSuccessThreshold = 1
+)
+
+// Chaos constants
+const (
+	// ChaosBrokerContainer is the Docker container paused by the broker_pause faults (the Kafka service of pubsub up).
+	ChaosBrokerContainer = "kafka"
+)
+
+// Gateway constants
+const (
+	// GatewayAddr is the default listen address of the HTTP ingestion gateway.
+	GatewayAddr = "localhost:8082"
+	// GatewayRateLimit is the default number of orders per second accepted by the gateway.
+	GatewayRateLimit = 10.0
+	// GatewayBurst is the default number of orders accepted at once above the rate limit.
+	GatewayBurst = 20
+	// GatewayMaxBodyBytes is the default maximum size of an order posted to the gateway.
+	GatewayMaxBodyBytes = 64 << 10
+)
+
+// Metrics constants
+const (
+	// MetricsOTLPInterval is the default interval between two pushes of the metrics to the OTLP collector.
+	MetricsOTLPInterval = 15 * time.Second
+)
+
+// Debug constants
+const (
+	// DebugSnapshotDir is the default directory of the goroutine and heap snapshots.
+	DebugSnapshotDir = "logs/debug"
+)
+
 // Log Monitor constants
 const (
 	// MonitorMaxRecentLogs is the maximum number of recent logs to keep in memory.
 	MonitorMaxRecentLogs = 20
 	// MonitorMaxRecentEvents is the maximum number of recent events to keep in memory.
 	MonitorMaxRecentEvents = 20
-	// MonitorMaxHistorySize is the history size for charts.
+	// MonitorMaxHistorySize is the number of samples kept per history tier for charts.
 	MonitorMaxHistorySize = 50
+	// MonitorHistoryTiers is the number of resolution tiers kept for chart histories.
+	MonitorHistoryTiers = 3
+	// MonitorHistoryDownsampleFactor is the number of samples averaged into one sample of the next tier.
+	MonitorHistoryDownsampleFactor = 4
 	// MonitorLogChannelBuffer is the buffer size for the log channel.
 	MonitorLogChannelBuffer = 100
 	// MonitorEventChannelBuffer is the buffer size for the event channel.
@@ -124,6 +229,18 @@ const (
 	MonitorFilePollInterval = 200 * time.Millisecond
 	// MonitorUIUpdateInterval is the UI refresh interval.
 	MonitorUIUpdateInterval = 500 * time.Millisecond
+	// MonitorMinUIUpdateInterval is the shortest UI refresh interval selectable at runtime.
+	MonitorMinUIUpdateInterval = 100 * time.Millisecond
+	// MonitorMaxUIUpdateInterval is the longest UI refresh interval selectable at runtime.
+	MonitorMaxUIUpdateInterval = 5 * time.Second
+	// MonitorTimeSource selects the timeline of rate and error-age calculations ("event" or "local").
+	MonitorTimeSource = "event"
+	// MonitorMaxClockSkew is the tolerated advance of tracker timestamps over the local clock.
+	MonitorMaxClockSkew = 5 * time.Second
+	// MonitorProbeInterval is the interval between two producer/tracker liveness probes.
+	MonitorProbeInterval = 5 * time.Second
+	// MonitorProbeTimeout is the maximum wait time for a health endpoint response.
+	MonitorProbeTimeout = 1 * time.Second
 
 	// Display Limits
 
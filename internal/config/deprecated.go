@@ -0,0 +1,273 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// deprecatedSetting is a former configuration key or environment variable that
+// is still accepted in place of the setting replacing it.
+type deprecatedSetting struct {
+	Path    string                       // Path of the replacing setting.
+	Convert func(string) (string, error) // Converts a former value (nil keeps it unchanged).
+}
+
+// deprecatedKeys maps the deprecated configuration keys (dotted paths) to their
+// replacement (see deprecateKey).
+var deprecatedKeys = make(map[string]deprecatedSetting)
+
+// deprecatedEnvs maps the deprecated environment variables to their
+// replacement (see deprecateEnv).
+var deprecatedEnvs = make(map[string]deprecatedSetting)
+
+func init() {
+	for old, legacy := range legacyDurations {
+		deprecateKey(old, legacy.Path, legacyValue(legacy.Unit))
+		deprecateEnv(envName(old), legacy.Path, legacyValue(legacy.Unit))
+	}
+	deprecateEnv("LOG_LEVEL", "app.log_level", nil)
+	deprecateEnv("SASL_USERNAME", "security.sasl_username", nil)
+	deprecateEnv("SASL_PASSWORD", "security.sasl_password", nil)
+}
+
+// deprecateKey registers a configuration key replaced by another setting. The
+// key remains accepted in the configuration file, its profiles and the remote
+// document, and is reported by a Deprecation when used.
+//
+// Parameters:
+//   - old: The deprecated key path (e.g., producer.interval_ms).
+//   - path: The path of the replacing setting.
+//   - convert: Converts a former value to the new format (nil if unchanged).
+func deprecateKey(old, path string, convert func(string) (string, error)) {
+	deprecatedKeys[old] = deprecatedSetting{Path: path, Convert: convert}
+}
+
+// deprecateEnv registers an environment variable replaced by the variable of
+// another setting. It remains accepted with a lower precedence than the bound
+// variables (see envNames), and is reported by a Deprecation when used.
+//
+// Parameters:
+//   - name: The deprecated variable (e.g., LOG_LEVEL).
+//   - path: The path of the replacing setting.
+//   - convert: Converts a former value to the new format (nil if unchanged).
+func deprecateEnv(name, path string, convert func(string) (string, error)) {
+	deprecatedEnvs[name] = deprecatedSetting{Path: path, Convert: convert}
+}
+
+// deprecatedEnvNames returns the deprecated variables of a setting.
+//
+// Parameters:
+//   - path: The setting path.
+//
+// Returns:
+//   - []string: The variable names, sorted.
+func deprecatedEnvNames(path string) []string {
+	var names []string
+	for name, setting := range deprecatedEnvs {
+		if setting.Path == path {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Deprecation reports a deprecated key or environment variable used by the
+// loaded configuration. The value is still applied to its replacement.
+type Deprecation struct {
+	Setting     string // Deprecated key (e.g., producer.interval_ms) or variable (e.g., LOG_LEVEL).
+	Replacement string // Key or variable to use instead.
+	Source      Source // Where it was found: SourceRemote, SourceFile or SourceEnv.
+}
+
+// String formats the warning as logfmt key=value pairs.
+//
+// Returns:
+//   - string: The warning (e.g., level=warn msg=... setting=LOG_LEVEL replacement=APP_LOG_LEVEL source=env).
+func (d Deprecation) String() string {
+	return fmt.Sprintf("level=warn msg=%q setting=%s replacement=%s source=%s",
+		"deprecated configuration setting", d.Setting, d.Replacement, d.Source)
+}
+
+// deprecationHandler receives the deprecations found at load time.
+var deprecationHandler = func(d Deprecation) {
+	fmt.Fprintln(os.Stderr, d)
+}
+
+// SetDeprecationHandler replaces the handler receiving the deprecated keys and
+// environment variables found while loading the configuration. By default,
+// they are written to the standard error output (see Deprecation.String).
+//
+// Parameters:
+//   - handler: The handler (nil to ignore deprecations).
+func SetDeprecationHandler(handler func(Deprecation)) {
+	if handler == nil {
+		handler = func(Deprecation) {}
+	}
+	deprecationHandler = handler
+}
+
+// reportDeprecations passes each distinct deprecation to the handler.
+//
+// Parameters:
+//   - deprecations: The deprecations, in load order.
+func reportDeprecations(deprecations []Deprecation) {
+	seen := make(map[Deprecation]bool)
+	for _, d := range deprecations {
+		if !seen[d] {
+			seen[d] = true
+			deprecationHandler(d)
+		}
+	}
+}
+
+// migrateDeprecatedKeys moves the deprecated keys of a YAML document (and of its
+// profiles) to the settings replacing them. A replacing setting defined in the
+// same document (or profile) takes precedence.
+//
+// Parameters:
+//   - data: The YAML document.
+//
+// Returns:
+//   - []byte: The rewritten document (data itself if no deprecated key is used).
+//   - []string: The deprecated key paths found.
+//   - error: An error if the document cannot be parsed or a value cannot be converted.
+func migrateDeprecatedKeys(data []byte) ([]byte, []string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("error parsing YAML: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return data, nil, nil
+	}
+
+	var found []string
+	for _, mapping := range documentMappings(doc.Content[0]) {
+		if err := migrateMapping(mapping, &found); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(found) == 0 {
+		return data, nil, nil
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error converting configuration: %w", err)
+	}
+	return out, found, nil
+}
+
+// movedKey is a deprecated key removed from a document.
+type movedKey struct {
+	Path  string     // Deprecated key path.
+	Value *yaml.Node // Value to move to the replacing setting.
+}
+
+// migrateMapping moves the deprecated keys of a document or profile mapping.
+//
+// Parameters:
+//   - root: The mapping.
+//   - found: Receives the deprecated key paths.
+//
+// Returns:
+//   - error: An error if a value cannot be converted.
+func migrateMapping(root *yaml.Node, found *[]string) error {
+	if root.Kind != yaml.MappingNode {
+		return nil
+	}
+	var moved []movedKey
+	extractDeprecated(root, "", &moved)
+	for _, m := range moved {
+		*found = append(*found, m.Path)
+		setting := deprecatedKeys[m.Path]
+		value := m.Value
+		if setting.Convert != nil && value.Kind == yaml.ScalarNode {
+			converted, err := setting.Convert(value.Value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", m.Path, err)
+			}
+			value = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: converted}
+		}
+		insertNode(root, setting.Path, value)
+	}
+	return nil
+}
+
+// extractDeprecated removes the deprecated keys of a mapping and its sections.
+//
+// Parameters:
+//   - node: The mapping.
+//   - prefix: The path of the mapping ("" for the root).
+//   - out: Receives the removed keys.
+func extractDeprecated(node *yaml.Node, prefix string, out *[]movedKey) {
+	content := node.Content[:0]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		path := key.Value
+		if prefix != "" {
+			path = prefix + "." + key.Value
+		} else if path == "profiles" {
+			content = append(content, key, value)
+			continue
+		}
+		if _, ok := deprecatedKeys[path]; ok {
+			*out = append(*out, movedKey{Path: path, Value: value})
+			continue
+		}
+		if value.Kind == yaml.MappingNode {
+			extractDeprecated(value, path, out)
+		}
+		content = append(content, key, value)
+	}
+	node.Content = content
+}
+
+// insertNode sets a value at a path of a mapping, creating the missing
+// sections, unless the path is already defined.
+//
+// Parameters:
+//   - root: The mapping.
+//   - path: The dotted path.
+//   - value: The value.
+func insertNode(root *yaml.Node, path string, value *yaml.Node) {
+	parts := strings.Split(path, ".")
+	node := root
+	for _, name := range parts[:len(parts)-1] {
+		next := mappingValue(node, name)
+		if next == nil {
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, next)
+		}
+		if next.Kind != yaml.MappingNode {
+			return
+		}
+		node = next
+	}
+	name := parts[len(parts)-1]
+	if mappingValue(node, name) != nil {
+		return // the replacing setting wins
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, value)
+}
+
+// mappingValue returns the value of a key of a mapping.
+//
+// Parameters:
+//   - node: The mapping.
+//   - key: The key.
+//
+// Returns:
+//   - *yaml.Node: The value (nil if the key is absent).
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
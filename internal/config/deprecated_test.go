@@ -0,0 +1,104 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// captureDeprecations records the deprecations reported during a test.
+func captureDeprecations(t *testing.T) *[]Deprecation {
+	var got []Deprecation
+	previous := deprecationHandler
+	SetDeprecationHandler(func(d Deprecation) { got = append(got, d) })
+	t.Cleanup(func() { deprecationHandler = previous })
+	return &got
+}
+
+func TestDeprecatedKeys(t *testing.T) {
+	got := captureDeprecations(t)
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+version: 2
+producer:
+  interval_ms: 750
+profiles:
+  dev:
+    producer:
+      interval_ms: 500
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	t.Setenv("APP_ENV", "dev")
+
+	if _, err := Load(configPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []Deprecation{{Setting: "producer.interval_ms", Replacement: "producer.interval", Source: SourceFile}}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("Expected %v, got %v", want, *got)
+	}
+}
+
+func TestDeprecatedEnv(t *testing.T) {
+	got := captureDeprecations(t)
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("TRACKER_READ_TIMEOUT_MS", "250")
+
+	if _, err := Load(""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []Deprecation{
+		{Setting: "LOG_LEVEL", Replacement: "APP_LOG_LEVEL", Source: SourceEnv},
+		{Setting: "TRACKER_READ_TIMEOUT_MS", Replacement: "TRACKER_READ_TIMEOUT", Source: SourceEnv},
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("Expected %v, got %v", want, *got)
+	}
+}
+
+func TestDeprecatedUnprefixedEnv(t *testing.T) {
+	got := captureDeprecations(t)
+	SetEnvPrefix("PUBSUB")
+	t.Cleanup(func() { SetEnvPrefix("") })
+	t.Setenv("KAFKA_TOPIC", "legacy")
+	t.Setenv("PUBSUB_DLQ_TOPIC", "prefixed")
+
+	if _, err := Load(""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []Deprecation{{Setting: "KAFKA_TOPIC", Replacement: "PUBSUB_KAFKA_TOPIC", Source: SourceEnv}}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("Expected %v, got %v", want, *got)
+	}
+}
+
+func TestDeprecateKeyMovesSection(t *testing.T) {
+	deprecateKey("legacy.topic", "dlq.topic", nil)
+	t.Cleanup(func() { delete(deprecatedKeys, "legacy.topic") })
+
+	data, found, err := migrateDeprecatedKeys([]byte("legacy:\n  topic: old-dlq\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(found, []string{"legacy.topic"}) {
+		t.Errorf("Expected legacy.topic to be found, got %v", found)
+	}
+	cfg := DefaultConfig()
+	if err := loadFromYAML(data, cfg, "", nil, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.DLQ.Topic != "old-dlq" {
+		t.Errorf("Expected dlq.topic old-dlq, got %s", cfg.DLQ.Topic)
+	}
+}
+
+func TestDeprecationString(t *testing.T) {
+	d := Deprecation{Setting: "LOG_LEVEL", Replacement: "APP_LOG_LEVEL", Source: SourceEnv}
+	want := `level=warn msg="deprecated configuration setting" setting=LOG_LEVEL replacement=APP_LOG_LEVEL source=env`
+	if d.String() != want {
+		t.Errorf("Expected %s, got %s", want, d.String())
+	}
+}
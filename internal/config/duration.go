@@ -0,0 +1,76 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// durationType is the type of the delay settings, written as duration strings
+// parsed by time.ParseDuration (e.g., "2s", "500ms", "1m30s").
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// legacyDuration describes a former integer setting replaced by a duration.
+type legacyDuration struct {
+	Path string        // Path of the duration setting.
+	Unit time.Duration // Unit of the integer value.
+}
+
+// legacyDurations maps the former *_ms and *_seconds integer settings to the
+// duration settings that replace them. The integer forms remain accepted in
+// the configuration file and the environment during the deprecation window
+// (see deprecatedKeys and deprecatedEnvs).
+var legacyDurations = map[string]legacyDuration{
+	"kafka.client.linger_ms":               {"kafka.client.linger", time.Millisecond},
+	"kafka.client.session_timeout_ms":      {"kafka.client.session_timeout", time.Millisecond},
+	"kafka.client.heartbeat_interval_ms":   {"kafka.client.heartbeat_interval", time.Millisecond},
+	"kafka.client.max_poll_interval_ms":    {"kafka.client.max_poll_interval", time.Millisecond},
+	"producer.interval_ms":                 {"producer.interval", time.Millisecond},
+	"producer.flush_timeout_ms":            {"producer.flush_timeout", time.Millisecond},
+	"tracker.metrics_interval_seconds":     {"tracker.metrics_interval", time.Second},
+	"tracker.read_timeout_ms":              {"tracker.read_timeout", time.Millisecond},
+	"monitor.ui_update_ms":                 {"monitor.ui_update_interval", time.Millisecond},
+	"monitor.max_clock_skew_ms":            {"monitor.max_clock_skew", time.Millisecond},
+	"monitor.probe_interval_ms":            {"monitor.probe_interval", time.Millisecond},
+	"monitor.quality.latency_target_ms":    {"monitor.quality.latency_target", time.Millisecond},
+	"monitor.thresholds.error_critical_ms": {"monitor.thresholds.error_critical", time.Millisecond},
+	"monitor.thresholds.error_warning_ms":  {"monitor.thresholds.error_warning", time.Millisecond},
+	"retry.initial_delay_ms":               {"retry.initial_delay", time.Millisecond},
+	"retry.max_delay_ms":                   {"retry.max_delay", time.Millisecond},
+}
+
+// parseDuration parses a duration setting.
+//
+// Parameters:
+//   - raw: The duration string (e.g., "1m30s").
+//
+// Returns:
+//   - time.Duration: The duration.
+//   - error: An error if raw is not a valid duration.
+func parseDuration(raw string) (time.Duration, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q (e.g., 2s, 500ms, 1m30s)", raw)
+	}
+	return d, nil
+}
+
+// legacyValue returns the conversion of the integer values of a former setting
+// to duration strings (e.g., 1500 with a millisecond unit gives "1.5s").
+//
+// Parameters:
+//   - unit: The unit of the integer values.
+//
+// Returns:
+//   - func(string) (string, error): The conversion, failing on non-integer values.
+func legacyValue(unit time.Duration) func(string) (string, error) {
+	return func(raw string) (string, error) {
+		n, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid integer %q", raw)
+		}
+		return (time.Duration(n) * unit).String(), nil
+	}
+}
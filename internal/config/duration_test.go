@@ -0,0 +1,126 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDurationStrings(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+producer:
+  interval: 1m30s
+tracker:
+  read_timeout: 500ms
+kafka:
+  client:
+    session_timeout: 45s
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	t.Setenv("RETRY_MAX_DELAY", "2s")
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Producer.Interval != 90*time.Second {
+		t.Errorf("Expected interval 1m30s, got %s", cfg.Producer.Interval)
+	}
+	if cfg.Tracker.ReadTimeout != 500*time.Millisecond {
+		t.Errorf("Expected read timeout 500ms, got %s", cfg.Tracker.ReadTimeout)
+	}
+	if got := cfg.Kafka.Client.ConsumerProperties()["session.timeout.ms"]; got != "45000" {
+		t.Errorf("Expected session.timeout.ms 45000, got %s", got)
+	}
+	if cfg.Retry.MaxDelay != 2*time.Second {
+		t.Errorf("Expected max delay 2s from RETRY_MAX_DELAY, got %s", cfg.Retry.MaxDelay)
+	}
+}
+
+func TestInvalidDuration(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("producer:\n  interval: fast\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	if _, err := Load(configPath); err == nil {
+		t.Error("Expected error for an invalid duration")
+	}
+
+	fs, _ := newTestFlagSet()
+	if err := fs.Parse([]string{"--producer.interval", "2000"}); err == nil {
+		t.Error("Expected parse error for a duration without unit")
+	}
+}
+
+func TestLegacyDurations(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+producer:
+  interval_ms: 750
+  flush_timeout_ms: 3000
+  flush_timeout: 4s
+tracker:
+  metrics_interval_seconds: 15
+profiles:
+  dev:
+    retry:
+      max_delay_ms: 8000
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	t.Setenv("APP_ENV", "dev")
+	t.Setenv("MONITOR_UI_UPDATE_MS", "250")
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	tests := []struct {
+		name string
+		got  time.Duration
+		want time.Duration
+	}{
+		{"producer.interval_ms", cfg.Producer.Interval, 750 * time.Millisecond},
+		{"producer.flush_timeout (wins over flush_timeout_ms)", cfg.Producer.FlushTimeout, 4 * time.Second},
+		{"tracker.metrics_interval_seconds", cfg.Tracker.MetricsInterval, 15 * time.Second},
+		{"profile retry.max_delay_ms", cfg.Retry.MaxDelay, 8 * time.Second},
+		{"MONITOR_UI_UPDATE_MS", cfg.Monitor.UIUpdateInterval, 250 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, tt.got)
+		}
+	}
+}
+
+func TestLegacyDurationPrecedence(t *testing.T) {
+	t.Setenv("PRODUCER_INTERVAL_MS", "100")
+	t.Setenv("PRODUCER_INTERVAL", "3s")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Producer.Interval != 3*time.Second {
+		t.Errorf("Expected PRODUCER_INTERVAL to win, got %s", cfg.Producer.Interval)
+	}
+}
+
+func TestLegacyDurationsCoverDurations(t *testing.T) {
+	paths := make(map[string]bool)
+	for _, f := range fields(DefaultConfig()) {
+		if f.Value.Type() == durationType {
+			paths[f.Path] = true
+		}
+	}
+	for old, legacy := range legacyDurations {
+		if !paths[legacy.Path] {
+			t.Errorf("%s: replacement %s is not a duration setting", old, legacy.Path)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Source identifies where an effective setting comes from.
+type Source string
+
+const (
+	// SourceDefault means the built-in default value is used.
+	SourceDefault Source = "default"
+	// SourceRemote means the value comes from the remote configuration (etcd or Consul).
+	SourceRemote Source = "remote"
+	// SourceFile means the value comes from the configuration file or its profile.
+	SourceFile Source = "file"
+	// SourceEnv means the value comes from an environment variable.
+	SourceEnv Source = "env"
+	// SourceFlag means the value comes from a command-line flag.
+	SourceFlag Source = "flag"
+)
+
+// Sources maps setting paths (e.g., kafka.broker) to their origin.
+// Settings missing from the map use their default value.
+type Sources map[string]Source
+
+// Of returns the origin of a setting.
+//
+// Parameters:
+//   - path: The setting path.
+//
+// Returns:
+//   - Source: The origin (SourceDefault if unknown).
+func (s Sources) Of(path string) Source {
+	if src, ok := s[path]; ok {
+		return src
+	}
+	return SourceDefault
+}
+
+// secretMask replaces secret values in the effective configuration dump.
+const secretMask = "******"
+
+// isSecret reports whether a setting holds a secret that must not be printed.
+//
+// Parameters:
+//   - path: The setting path.
+//
+// Returns:
+//   - bool: True for passwords, secrets, tokens and inline private keys.
+func isSecret(path string) bool {
+	name := strings.ToLower(path[strings.LastIndex(path, ".")+1:])
+	for _, word := range []string{"password", "secret", "token", "key_pem"} {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteEffective prints every setting of the effective configuration, one per
+// line, as aligned "path  source  value" columns, where source is default, file,
+// env or flag. Secret values are masked.
+//
+// Parameters:
+//   - w: The destination.
+//   - cfg: The effective configuration.
+//   - sources: The origin of every setting.
+//
+// Returns:
+//   - error: An error if writing fails.
+func WriteEffective(w io.Writer, cfg *AppConfig, sources Sources) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, f := range fields(cfg) {
+		value := f.String()
+		if isSecret(f.Path) && value != "" {
+			value = secretMask
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", f.Path, sources.Of(f.Path), value)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFlagsSources(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+kafka:
+  broker: "yaml:9092"
+  topic: "yaml-topic"
+profiles:
+  staging:
+    producer:
+      interval_ms: 500
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	os.Setenv("KAFKA_TOPIC", "env-topic")
+	defer os.Unsetenv("KAFKA_TOPIC")
+
+	fs, f := newTestFlagSet()
+	if err := fs.Parse([]string{"--config", configPath, "--app.env", "staging", "--retry.max_attempts", "5"}); err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+	if _, err := f.Load(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := map[string]Source{
+		"kafka.broker":       SourceFile,
+		"kafka.topic":        SourceEnv,
+		"producer.interval":  SourceFile,
+		"app.env":            SourceFlag,
+		"retry.max_attempts": SourceFlag,
+		"dlq.topic":          SourceDefault,
+	}
+	for path, want := range tests {
+		if got := f.Sources().Of(path); got != want {
+			t.Errorf("%s: expected source %s, got %s", path, want, got)
+		}
+	}
+}
+
+func TestWriteEffective(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Kafka.Brokers = BrokerList{"flag:9092"}
+	cfg.Monitor.Processes = []ProcessProbe{{Name: "tracker", PIDFile: "tracker.pid"}}
+
+	var buf bytes.Buffer
+	if err := WriteEffective(&buf, cfg, Sources{"kafka.broker": SourceFlag}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(fields(cfg)) {
+		t.Errorf("Expected one line per setting (%d), got %d", len(fields(cfg)), len(lines))
+	}
+	want := map[string]string{
+		"kafka.broker":      "kafka.broker flag flag:9092",
+		"kafka.topic":       "kafka.topic default orders",
+		"monitor.processes": "monitor.processes default [{name: tracker, pid_file: tracker.pid}]",
+	}
+	for _, line := range lines {
+		got := strings.Join(strings.Fields(line), " ")
+		path := strings.Fields(line)[0]
+		if expected, ok := want[path]; ok && got != expected {
+			t.Errorf("Expected %q, got %q", expected, got)
+		}
+	}
+}
+
+func TestIsSecret(t *testing.T) {
+	tests := map[string]bool{
+		"kafka.sasl_password": true,
+		"kafka.client_secret": true,
+		"monitor.api_token":   true,
+		"security.key_pem":    true,
+		"kafka.broker":        false,
+	}
+	for path, want := range tests {
+		if got := isSecret(path); got != want {
+			t.Errorf("isSecret(%q) = %v, expected %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// brokerListType is the type of kafka.broker, a list set from a single
+// comma-separated value.
+var brokerListType = reflect.TypeOf(BrokerList(nil))
+
+// field is a scalar configuration setting addressed by its YAML path.
+type field struct {
+	Path  string        // Dotted YAML path (e.g., kafka.broker).
+	Value reflect.Value // Addressable value of the setting.
+}
+
+// fields lists the settings of a configuration in declaration order.
+// Nested structures are flattened; lists (e.g., monitor.processes) are kept as
+// a single setting, only configurable from the configuration file.
+//
+// Parameters:
+//   - cfg: The configuration.
+//
+// Returns:
+//   - []field: The settings.
+func fields(cfg *AppConfig) []field {
+	var out []field
+	collectFields(reflect.ValueOf(cfg).Elem(), "", &out)
+	return out
+}
+
+// collectFields appends the scalar settings of a structure.
+//
+// Parameters:
+//   - v: The structure value.
+//   - prefix: The YAML path of the structure ("" for the root).
+//   - out: The list to append to.
+func collectFields(v reflect.Value, prefix string, out *[]field) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		fv := v.Field(i)
+		switch fv.Kind() {
+		case reflect.Struct:
+			collectFields(fv, path, out)
+		case reflect.String, reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64, reflect.Slice:
+			*out = append(*out, field{Path: path, Value: fv})
+		}
+	}
+}
+
+// collectPaths records the setting paths defined by a decoded document.
+//
+// Parameters:
+//   - doc: The decoded document (nested maps).
+//   - prefix: The path of the document ("" for the root).
+//   - out: The set of paths to fill.
+func collectPaths(doc map[string]interface{}, prefix string, out map[string]bool) {
+	for key, value := range doc {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			collectPaths(nested, path, out)
+			continue
+		}
+		out[path] = true
+	}
+}
+
+// settingValues returns the formatted value of every setting.
+//
+// Parameters:
+//   - cfg: The configuration.
+//
+// Returns:
+//   - map[string]string: The values keyed by path.
+func settingValues(cfg *AppConfig) map[string]string {
+	values := make(map[string]string)
+	for _, f := range fields(cfg) {
+		values[f.Path] = f.String()
+	}
+	return values
+}
+
+// isList reports whether the setting is a list only configurable from the
+// configuration file (kafka.broker is set from a comma-separated value).
+//
+// Returns:
+//   - bool: True for lists other than BrokerList.
+func (f field) isList() bool {
+	return f.Value.Kind() == reflect.Slice && f.Value.Type() != brokerListType
+}
+
+// set parses a raw value and assigns it to the setting.
+//
+// Parameters:
+//   - raw: The value as text.
+//
+// Returns:
+//   - error: An error if the value does not match the setting type.
+func (f field) set(raw string) error {
+	switch f.Value.Kind() {
+	case reflect.String:
+		f.Value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%s: invalid boolean %q", f.Path, raw)
+		}
+		f.Value.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		if f.Value.Type() == durationType {
+			d, err := parseDuration(raw)
+			if err != nil {
+				return fmt.Errorf("%s: %w", f.Path, err)
+			}
+			f.Value.SetInt(int64(d))
+			return nil
+		}
+		i, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: invalid integer %q", f.Path, raw)
+		}
+		f.Value.SetInt(i)
+	case reflect.Float64:
+		x, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%s: invalid number %q", f.Path, raw)
+		}
+		f.Value.SetFloat(x)
+	case reflect.Slice:
+		if f.Value.Type() != brokerListType {
+			return fmt.Errorf("%s: list settings are only configurable from the file", f.Path)
+		}
+		f.Value.Set(reflect.ValueOf(ParseBrokerList(raw)))
+	}
+	return nil
+}
+
+// String returns the current value as text.
+//
+// Returns:
+//   - string: The formatted value (lists as [{key: value, ...}, ...]).
+func (f field) String() string {
+	return formatValue(f.Value)
+}
+
+// formatValue formats a setting value, naming structure fields after their YAML tags.
+//
+// Parameters:
+//   - v: The value.
+//
+// Returns:
+//   - string: The formatted value.
+func formatValue(v reflect.Value) string {
+	if v.Type() == brokerListType {
+		return v.Interface().(BrokerList).String()
+	}
+	switch v.Kind() {
+	case reflect.Slice:
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = formatValue(v.Index(i))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Struct:
+		var items []string
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).Kind() == reflect.String && v.Field(i).Len() == 0 {
+				continue // unset optional setting (e.g., health_url)
+			}
+			name := strings.Split(v.Type().Field(i).Tag.Get("yaml"), ",")[0]
+			items = append(items, name+": "+formatValue(v.Field(i)))
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
@@ -0,0 +1,277 @@
+package config
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+)
+
+// Flags binds a command-line flag to every scalar AppConfig setting, named
+// after its YAML path (e.g., --kafka.broker, --retry.max_attempts), plus the
+// --config flag selecting the YAML file (see SearchPaths when omitted). It is
+// shared by all binaries so that one-off overrides do not require exporting
+// environment variables.
+//
+// Precedence, from highest to lowest: flags > environment > YAML > remote
+// (--remote-config) > defaults.
+type Flags struct {
+	ConfigPath  string            // Path of the configuration file ("" to search SearchPaths).
+	PrintConfig bool              // Print the effective configuration and exit (--print-config).
+	CheckConfig bool              // Validate the configuration and run its live checks, then exit (--check-config).
+	PrintSchema bool              // Print the JSON Schema of the configuration file and exit (--print-schema).
+	InitConfig  bool              // Write a commented sample configuration file and exit (--init-config).
+	EnvPrefix   string            // Prefix of the environment variables (--env-prefix, see SetEnvPrefix).
+	RemoteURL   string            // Remote configuration source (--remote-config, else CONFIG_REMOTE).
+	Encrypt     string            // Value to encrypt with CONFIG_KEY for the configuration file (--encrypt).
+	overrides   map[string]string // Raw values of the flags set on the command line, keyed by path.
+	order       []string          // Paths of the set flags, in command-line order.
+	mu          sync.Mutex        // Guards sources and file, updated by Watch.
+	sources     Sources           // Origin of every setting, known after Load.
+	file        string            // Configuration file loaded, known after Load.
+	remote      RemoteSource      // Remote configuration source, known after Load.
+	version     string            // Version of the remote document loaded.
+}
+
+// flagValue is the flag.Value of a configuration setting.
+type flagValue struct {
+	flags *Flags // The owning flag set.
+	field field  // The setting of a default configuration (used for type checks and help).
+}
+
+// String returns the default value shown in the help.
+//
+// Returns:
+//   - string: The default value.
+func (v *flagValue) String() string {
+	if v == nil || v.flags == nil {
+		return ""
+	}
+	return v.field.String()
+}
+
+// Set checks and records a value given on the command line.
+//
+// Parameters:
+//   - raw: The value as text.
+//
+// Returns:
+//   - error: An error if the value does not match the setting type.
+func (v *flagValue) Set(raw string) error {
+	probe := field{Path: v.field.Path, Value: reflect.New(v.field.Value.Type()).Elem()}
+	if err := probe.set(raw); err != nil {
+		return err
+	}
+	if _, ok := v.flags.overrides[v.field.Path]; !ok {
+		v.flags.order = append(v.flags.order, v.field.Path)
+	}
+	v.flags.overrides[v.field.Path] = raw
+	return nil
+}
+
+// IsBoolFlag allows boolean settings to be enabled without a value (e.g., --dlq.enabled).
+//
+// Returns:
+//   - bool: True for boolean settings.
+func (v *flagValue) IsBoolFlag() bool {
+	return v.field.Value.Kind() == reflect.Bool
+}
+
+// RegisterFlags registers the configuration flags on a flag set.
+//
+// Parameters:
+//   - fs: The flag set (typically flag.CommandLine).
+//
+// Returns:
+//   - *Flags: The bound flags, to be loaded after fs.Parse.
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{overrides: make(map[string]string)}
+	fs.StringVar(&f.ConfigPath, "config", "", "configuration file, YAML, JSON (.json) or TOML (.toml) (flags > environment > file > defaults); default: first of ./config.yaml, $XDG_CONFIG_HOME/pubsub/config.yaml, /etc/pubsub/config.yaml")
+	fs.BoolVar(&f.PrintConfig, "print-config", false, "print the effective configuration with the source of each setting, then exit")
+	fs.BoolVar(&f.CheckConfig, "check-config", false, "validate the configuration and print a PASS/FAIL table of live checks (brokers, topics, DLQ topic, tracker files, schema registry), then exit")
+	fs.BoolVar(&f.InitConfig, "init-config", false, "write a commented configuration file holding the defaults to --config (default config.yaml), then exit")
+	fs.StringVar(&f.RemoteURL, "remote-config", "", "centralized configuration overridden by the file, environment and flags: consul://host:8500/key or etcd://host:2379/key (default $CONFIG_REMOTE)")
+	fs.StringVar(&f.EnvPrefix, "env-prefix", "", "prefix of the environment variables (e.g., PUBSUB: PUBSUB_KAFKA_BROKER), unprefixed names remain accepted as deprecated aliases")
+	fs.StringVar(&f.Encrypt, "encrypt", "", "print the enc: form of a secret value, encrypted with the $CONFIG_KEY AES key, then exit")
+	fs.BoolVar(&f.PrintSchema, "print-schema", false, "print the JSON Schema of the configuration file (editor completion and CI linting), then exit")
+	for _, fld := range fields(DefaultConfig()) {
+		if fld.isList() {
+			continue // lists are only configurable from the file
+		}
+		kind := fld.Value.Kind().String()
+		switch fld.Value.Type() {
+		case brokerListType:
+			kind = "host:port,..."
+		case durationType:
+			kind = "duration"
+		}
+		fs.Var(&flagValue{flags: f, field: fld}, fld.Path, "overrides "+fld.Path+" (`"+kind+"`)")
+	}
+	return f
+}
+
+// Load loads the configuration from the remote source (--remote-config), the
+// YAML file (with the profile selected by --app.env, else APP_ENV) and the
+// environment (namespaced by --env-prefix), then applies the flags set on the
+// command line and validates the result. Without --config, the first existing
+// file of SearchPaths is loaded (none: defaults only).
+//
+// Returns:
+//   - *AppConfig: The effective configuration.
+//   - error: An error if loading fails or a value is invalid.
+func (f *Flags) Load() (*AppConfig, error) {
+	if f.EnvPrefix != "" {
+		SetEnvPrefix(f.EnvPrefix)
+	}
+	remoteURL := f.RemoteURL
+	if remoteURL == "" {
+		remoteURL = os.Getenv("CONFIG_REMOTE")
+	}
+	var remote []byte
+	if remoteURL != "" {
+		src, err := ParseRemote(remoteURL)
+		if err != nil {
+			return nil, err
+		}
+		data, version, err := src.Fetch(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("error loading remote config: %w", err)
+		}
+		f.remote, f.version, remote = src, version, data
+	}
+	return f.load(remote)
+}
+
+// load loads the configuration on top of a remote document.
+//
+// Parameters:
+//   - remote: The remote document (nil if none).
+//
+// Returns:
+//   - *AppConfig: The effective configuration.
+//   - error: An error if loading fails or a value is invalid.
+func (f *Flags) load(remote []byte) (*AppConfig, error) {
+	profile, ok := f.overrides["app.env"]
+	if !ok {
+		profile, _, _ = lookupEnv("app.env")
+	}
+	path := f.ConfigPath
+	if path == "" {
+		path = FindConfigFile()
+	}
+	cfg, sources, err := load(path, profile, remote)
+	if err != nil {
+		return nil, err
+	}
+	file := ""
+	if _, err := os.Stat(path); err == nil {
+		file = path
+	}
+	if err := f.apply(cfg); err != nil {
+		return nil, err
+	}
+	if err := decryptSecrets(cfg); err != nil {
+		return nil, err
+	}
+	for _, path := range f.order {
+		sources[path] = SourceFlag
+	}
+	f.mu.Lock()
+	f.file, f.sources = file, sources
+	f.mu.Unlock()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Watch polls the remote source loaded by Load every RemoteWatchInterval and,
+// on every update, reloads the configuration (the file, environment and flags
+// still override it) and passes the result to onChange. It returns at once
+// without remote source, else when the context is cancelled.
+//
+// Parameters:
+//   - ctx: Stops the watch.
+//   - onChange: Receives the new configuration, or the error of an invalid update.
+func (f *Flags) Watch(ctx context.Context, onChange func(*AppConfig, error)) {
+	if f.remote == nil {
+		return
+	}
+	WatchRemote(ctx, f.remote, f.version, RemoteWatchInterval, func(data []byte) {
+		onChange(f.load(data))
+	})
+}
+
+// Sources returns the origin of every setting of the configuration returned by Load.
+//
+// Returns:
+//   - Sources: The origins keyed by setting path.
+func (f *Flags) Sources() Sources {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sources
+}
+
+// InitPath returns the file written by --init-config.
+//
+// Returns:
+//   - string: The --config path, else ConfigFileName.
+func (f *Flags) InitPath() string {
+	if f.ConfigPath != "" {
+		return f.ConfigPath
+	}
+	return ConfigFileName
+}
+
+// File returns the configuration file read by Load.
+//
+// Returns:
+//   - string: The file path ("" if no file was found and the defaults were used).
+func (f *Flags) File() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file
+}
+
+// Args returns the command-line arguments reproducing the configuration
+// flags that were set, so that a child process loads the same configuration.
+// The one-shot actions (--print-config, --init-config...) are not included.
+//
+// Returns:
+//   - []string: The arguments, as --name=value, setting overrides in command-line order.
+func (f *Flags) Args() []string {
+	var args []string
+	for _, opt := range []struct{ name, value string }{
+		{"config", f.ConfigPath}, {"env-prefix", f.EnvPrefix}, {"remote-config", f.RemoteURL},
+	} {
+		if opt.value != "" {
+			args = append(args, "--"+opt.name+"="+opt.value)
+		}
+	}
+	for _, path := range f.order {
+		args = append(args, "--"+path+"="+f.overrides[path])
+	}
+	return args
+}
+
+// apply assigns the flags set on the command line to a configuration.
+//
+// Parameters:
+//   - cfg: The configuration to update.
+//
+// Returns:
+//   - error: An error if a value does not match its setting type.
+func (f *Flags) apply(cfg *AppConfig) error {
+	byPath := make(map[string]field)
+	for _, fld := range fields(cfg) {
+		byPath[fld.Path] = fld
+	}
+	for _, path := range f.order {
+		if err := byPath[path].set(f.overrides[path]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
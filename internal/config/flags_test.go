@@ -0,0 +1,157 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// newTestFlagSet returns a silent flag set with the configuration flags registered.
+func newTestFlagSet() (*flag.FlagSet, *Flags) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs, RegisterFlags(fs)
+}
+
+func TestFlagsPrecedence(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+kafka:
+  broker: "yaml:9092"
+  topic: "yaml-topic"
+  consumer_group: "yaml-group"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	os.Setenv("KAFKA_BROKER", "env:9092")
+	os.Setenv("KAFKA_TOPIC", "env-topic")
+	defer func() {
+		os.Unsetenv("KAFKA_BROKER")
+		os.Unsetenv("KAFKA_TOPIC")
+	}()
+
+	fs, f := newTestFlagSet()
+	if err := fs.Parse([]string{"--config", configPath, "--kafka.broker", "flag:9092", "--retry.multiplier=1.5"}); err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+	cfg, err := f.Load()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Kafka.Brokers.String() != "flag:9092" {
+		t.Errorf("Flag should override env and YAML, got %s", cfg.Kafka.Brokers)
+	}
+	if cfg.Kafka.Topic != "env-topic" {
+		t.Errorf("Env should override YAML, got %s", cfg.Kafka.Topic)
+	}
+	if cfg.Kafka.ConsumerGroup != "yaml-group" {
+		t.Errorf("YAML should override defaults, got %s", cfg.Kafka.ConsumerGroup)
+	}
+	if cfg.Retry.MaxAttempts != 3 {
+		t.Errorf("Default should be kept, got %d", cfg.Retry.MaxAttempts)
+	}
+	if cfg.Retry.Multiplier != 1.5 {
+		t.Errorf("Expected multiplier 1.5, got %v", cfg.Retry.Multiplier)
+	}
+}
+
+func TestFlagsNestedAndBool(t *testing.T) {
+	fs, f := newTestFlagSet()
+	args := []string{"--config", "", "--dlq.enabled=false", "--monitor.ingest_history", "--monitor.quality.success_weight", "60"}
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+	cfg, err := f.Load()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.DLQ.Enabled {
+		t.Error("Expected DLQ to be disabled by flag")
+	}
+	if !cfg.Monitor.IngestHistory {
+		t.Error("Expected bare boolean flag to enable monitor.ingest_history")
+	}
+	if cfg.Monitor.Quality.SuccessWeight != 60 {
+		t.Errorf("Expected success weight 60, got %v", cfg.Monitor.Quality.SuccessWeight)
+	}
+}
+
+func TestFlagsInvalidValues(t *testing.T) {
+	fs, _ := newTestFlagSet()
+	if err := fs.Parse([]string{"--retry.max_attempts", "three"}); err == nil {
+		t.Error("Expected parse error for a non-integer value")
+	}
+
+	fs, f := newTestFlagSet()
+	if err := fs.Parse([]string{"--config", "", "--producer.interval", "-5s"}); err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+	if _, err := f.Load(); err == nil {
+		t.Error("Expected validation error for a negative interval given by flag")
+	}
+}
+
+func TestEnvPrefixFlag(t *testing.T) {
+	t.Cleanup(func() { SetEnvPrefix("") })
+	t.Setenv("PUBSUB_KAFKA_TOPIC", "prefixed-topic")
+
+	fs, f := newTestFlagSet()
+	if err := fs.Parse([]string{"--config", filepath.Join(t.TempDir(), "none.yaml"), "--env-prefix", "PUBSUB_"}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := f.Load()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Kafka.Topic != "prefixed-topic" {
+		t.Errorf("Expected topic from PUBSUB_KAFKA_TOPIC, got %s", cfg.Kafka.Topic)
+	}
+}
+
+func TestFlagsArgs(t *testing.T) {
+	fs, f := newTestFlagSet()
+	if err := fs.Parse([]string{"--kafka.topic", "demo", "--config", "demo.yaml", "--print-config", "--dlq.enabled"}); err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+
+	want := []string{"--config=demo.yaml", "--kafka.topic=demo", "--dlq.enabled=true"}
+	if got := f.Args(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+// TestFlagsReloadConcurrentReads tests that the reloads of Watch do not race
+// with Sources and File.
+func TestFlagsReloadConcurrentReads(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("kafka:\n  topic: \"yaml-topic\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	fs, f := newTestFlagSet()
+	if err := fs.Parse([]string{"--config", configPath, "--kafka.broker", "flag:9092"}); err != nil {
+		t.Fatalf("Unexpected parse error: %v", err)
+	}
+	if _, err := f.Load(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			f.load([]byte("retry:\n  max_attempts: 4\n"))
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		if f.Sources()["kafka.broker"] != SourceFlag || f.File() != configPath {
+			t.Errorf("Unexpected sources %v or file %q", f.Sources(), f.File())
+		}
+	}
+	<-done
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const yamlFormatConfig = `
+kafka:
+  broker: "fmt:9092"
+producer:
+  interval_ms: 750
+retry:
+  multiplier: 1.5
+dlq:
+  enabled: false
+monitor:
+  processes:
+    - name: tracker
+      pid_file: tracker.pid
+  quality:
+    throughput_buckets:
+      - { min_mps: 1, score: 30 }
+      - { min_mps: 0, score: 5 }
+profiles:
+  production:
+    kafka:
+      broker: "prod:9092"
+`
+
+const jsonFormatConfig = `{
+  "kafka": {"broker": "fmt:9092"},
+  "producer": {"interval_ms": 750},
+  "retry": {"multiplier": 1.5},
+  "dlq": {"enabled": false},
+  "monitor": {
+    "processes": [{"name": "tracker", "pid_file": "tracker.pid"}],
+    "quality": {"throughput_buckets": [{"min_mps": 1, "score": 30}, {"min_mps": 0, "score": 5}]}
+  },
+  "profiles": {"production": {"kafka": {"broker": "prod:9092"}}}
+}`
+
+const tomlFormatConfig = `
+# Same settings as the YAML version
+[kafka]
+broker = "fmt:9092" # inline comment
+
+[producer]
+interval_ms = 750
+
+[retry]
+multiplier = 1.5
+
+[dlq]
+enabled = false
+
+[[monitor.processes]]
+name = "tracker"
+pid_file = 'tracker.pid'
+
+[monitor.quality]
+throughput_buckets = [
+  { min_mps = 1, score = 30 },
+  { min_mps = 0, score = 5 },
+]
+
+[profiles.production.kafka]
+broker = "prod:9092"
+`
+
+func writeFormatConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadFileFormats(t *testing.T) {
+	want, err := Load(writeFormatConfig(t, "config.yaml", yamlFormatConfig))
+	if err != nil {
+		t.Fatalf("Unexpected YAML error: %v", err)
+	}
+	if want.Kafka.Brokers.String() != "fmt:9092" || want.Producer.Interval != 750*time.Millisecond || len(want.Monitor.Processes) != 1 {
+		t.Fatalf("Unexpected YAML configuration: %+v", want)
+	}
+
+	for name, content := range map[string]string{
+		"config.json": jsonFormatConfig,
+		"config.toml": tomlFormatConfig,
+	} {
+		got, err := Load(writeFormatConfig(t, name, content))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: expected %+v, got %+v", name, want, got)
+		}
+	}
+}
+
+func TestLoadFileFormatsProfile(t *testing.T) {
+	os.Setenv("APP_ENV", "production")
+	defer os.Unsetenv("APP_ENV")
+
+	for name, content := range map[string]string{
+		"config.json": jsonFormatConfig,
+		"config.toml": tomlFormatConfig,
+	} {
+		cfg, err := Load(writeFormatConfig(t, name, content))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if cfg.Kafka.Brokers.String() != "prod:9092" {
+			t.Errorf("%s: expected production broker, got %s", name, cfg.Kafka.Brokers)
+		}
+	}
+}
+
+func TestParseTOMLValues(t *testing.T) {
+	doc, err := parseTOML([]byte(`
+title = "a \"quoted\" # not a comment"
+big = 1_000
+ratio = -0.25
+list = [1, 2,
+  3]
+a.b.c = true
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if doc["title"] != `a "quoted" # not a comment` {
+		t.Errorf("Unexpected string: %v", doc["title"])
+	}
+	if doc["big"] != int64(1000) {
+		t.Errorf("Expected 1000, got %v", doc["big"])
+	}
+	if doc["ratio"] != -0.25 {
+		t.Errorf("Expected -0.25, got %v", doc["ratio"])
+	}
+	if !reflect.DeepEqual(doc["list"], []interface{}{int64(1), int64(2), int64(3)}) {
+		t.Errorf("Unexpected list: %v", doc["list"])
+	}
+	nested, _ := doc["a"].(map[string]interface{})["b"].(map[string]interface{})
+	if nested["c"] != true {
+		t.Errorf("Expected dotted key a.b.c = true, got %v", doc["a"])
+	}
+}
+
+func TestParseTOMLErrors(t *testing.T) {
+	tests := map[string]string{
+		"missing equal":     "[kafka]\nbroker \"x\"",
+		"unterminated":      "broker = \"x",
+		"unsupported value": "\n\nstarted = 2024-01-01T00:00:00Z",
+		"trailing garbage":  "port = 1 2",
+	}
+	for name, content := range tests {
+		if _, err := parseTOML([]byte(content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	_, err := parseTOML([]byte("\n\nstarted = 2024-01-01T00:00:00Z"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 3:") {
+		t.Errorf("Expected error on line 3, got %v", err)
+	}
+}
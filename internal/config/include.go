@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfDirName is the directory of configuration fragments next to the
+// configuration file (e.g., /etc/pubsub/conf.d).
+const ConfDirName = "conf.d"
+
+// includeDocument holds the optional "include:" directive of a configuration file.
+type includeDocument struct {
+	Include []string `yaml:"include"` // Fragment files or glob patterns, relative to the file directory.
+}
+
+// fragmentPaths returns the configuration fragments merged over a configuration
+// file, in order: the files of its include: directive (globs expanded in lexical
+// order), then the YAML, JSON and TOML files of the conf.d directory next to it,
+// in lexical order. Fragments are not searched for further includes.
+//
+// Parameters:
+//   - configPath: The configuration file.
+//
+// Returns:
+//   - []string: The fragment paths.
+//   - error: An error if the include: directive is invalid or names a missing file.
+func fragmentPaths(configPath string) ([]string, error) {
+	dir := filepath.Dir(configPath)
+	var paths []string
+	if data, err := readDocument(configPath); err == nil {
+		var doc includeDocument
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("error parsing include: %w", err)
+		}
+		for _, pattern := range doc.Include {
+			if !filepath.IsAbs(pattern) {
+				pattern = filepath.Join(dir, pattern)
+			}
+			matches, err := filepath.Glob(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("include %s: %w", pattern, err)
+			}
+			if len(matches) == 0 && !strings.ContainsAny(pattern, "*?[") {
+				return nil, fmt.Errorf("include %s: file not found", pattern)
+			}
+			paths = append(paths, matches...)
+		}
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, ConfDirName, "*"))
+	for _, path := range matches {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json", ".toml":
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestIncludeAndConfDir(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	writeFile(t, configPath, `
+include:
+  - overlays/*.yaml
+kafka:
+  topic: base
+  consumer_group: base-group
+producer:
+  interval: 1s
+`)
+	writeFile(t, filepath.Join(dir, "overlays", "a.yaml"), "kafka:\n  topic: from-include\n")
+	writeFile(t, filepath.Join(dir, ConfDirName, "20-last.yaml"), "producer:\n  interval: 3s\n")
+	writeFile(t, filepath.Join(dir, ConfDirName, "10-first.json"), `{"producer": {"interval": "2s"}, "dlq": {"topic": "from-conf-d"}}`)
+	writeFile(t, filepath.Join(dir, ConfDirName, "README.md"), "not a fragment")
+
+	cfg, sources, err := load(configPath, "", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Kafka.Topic != "from-include" {
+		t.Errorf("Expected topic from-include, got %s", cfg.Kafka.Topic)
+	}
+	if cfg.Kafka.ConsumerGroup != "base-group" {
+		t.Errorf("Expected base consumer_group kept, got %s", cfg.Kafka.ConsumerGroup)
+	}
+	if cfg.Producer.Interval != 3*time.Second {
+		t.Errorf("Expected conf.d fragments in lexical order (3s), got %s", cfg.Producer.Interval)
+	}
+	if cfg.DLQ.Topic != "from-conf-d" {
+		t.Errorf("Expected dlq topic from-conf-d, got %s", cfg.DLQ.Topic)
+	}
+	if sources["dlq.topic"] != SourceFile {
+		t.Errorf("Expected dlq.topic source file, got %s", sources["dlq.topic"])
+	}
+	if _, ok := sources["include"]; ok {
+		t.Error("include must not be reported as a setting")
+	}
+}
+
+func TestFragmentProfile(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	writeFile(t, configPath, "app:\n  env: staging\n")
+	writeFile(t, filepath.Join(dir, ConfDirName, "topic.yaml"), `
+kafka:
+  topic: fragment
+profiles:
+  staging:
+    kafka:
+      topic: fragment-staging
+  production:
+    kafka:
+      topic: fragment-production
+`)
+	writeFile(t, filepath.Join(dir, ConfDirName, "other.yaml"), "profiles:\n  production:\n    producer:\n      interval: 5s\n")
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Kafka.Topic != "fragment-staging" {
+		t.Errorf("Expected staging profile of the fragment, got %s", cfg.Kafka.Topic)
+	}
+}
+
+func TestIncludeMissingFile(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	writeFile(t, configPath, "include:\n  - missing.yaml\n  - optional/*.yaml\n")
+	if _, err := Load(configPath); err == nil {
+		t.Error("Expected error for a missing included file")
+	}
+
+	writeFile(t, configPath, "include:\n  - optional/*.yaml\n")
+	if _, err := Load(configPath); err != nil {
+		t.Errorf("Expected an empty glob to be ignored, got %v", err)
+	}
+}
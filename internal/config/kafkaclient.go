@@ -0,0 +1,59 @@
+package config
+
+import (
+	"strconv"
+	"time"
+)
+
+// ProducerProperties returns the librdkafka properties of the producer tuning
+// settings (e.g., linger.ms), to merge into the producer kafka.ConfigMap.
+// Unset settings are omitted so that librdkafka defaults apply.
+//
+// Returns:
+//   - map[string]string: The properties keyed by librdkafka name.
+func (k KafkaClientConfig) ProducerProperties() map[string]string {
+	props := make(map[string]string)
+	setString(props, "acks", k.Acks)
+	setMillis(props, "linger.ms", k.Linger)
+	setInt(props, "batch.size", k.BatchSize)
+	setString(props, "compression.type", k.Compression)
+	if k.EnableIdempotence {
+		props["enable.idempotence"] = "true"
+	}
+	return props
+}
+
+// ConsumerProperties returns the librdkafka properties of the consumer tuning
+// settings (e.g., session.timeout.ms), to merge into the consumer kafka.ConfigMap.
+// Unset settings are omitted so that librdkafka defaults apply.
+//
+// Returns:
+//   - map[string]string: The properties keyed by librdkafka name.
+func (k KafkaClientConfig) ConsumerProperties() map[string]string {
+	props := make(map[string]string)
+	setInt(props, "fetch.min.bytes", k.FetchMinBytes)
+	setMillis(props, "session.timeout.ms", k.SessionTimeout)
+	setMillis(props, "heartbeat.interval.ms", k.HeartbeatInterval)
+	setMillis(props, "max.poll.interval.ms", k.MaxPollInterval)
+	setString(props, "auto.offset.reset", k.AutoOffsetReset)
+	return props
+}
+
+// setString adds a property if its value is set.
+func setString(props map[string]string, name, value string) {
+	if value != "" {
+		props[name] = value
+	}
+}
+
+// setInt adds a property if its value is set (non-zero).
+func setInt(props map[string]string, name string, value int) {
+	if value != 0 {
+		props[name] = strconv.Itoa(value)
+	}
+}
+
+// setMillis adds a property in milliseconds if its value is set (non-zero).
+func setMillis(props map[string]string, name string, value time.Duration) {
+	setInt(props, name, int(value/time.Millisecond))
+}
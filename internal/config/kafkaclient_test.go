@@ -0,0 +1,72 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestKafkaClientProperties(t *testing.T) {
+	client := KafkaClientConfig{
+		Acks:              "all",
+		Linger:            5 * time.Millisecond,
+		BatchSize:         65536,
+		Compression:       "zstd",
+		EnableIdempotence: true,
+		FetchMinBytes:     1024,
+		SessionTimeout:    45 * time.Second,
+		HeartbeatInterval: 3 * time.Second,
+		MaxPollInterval:   5 * time.Minute,
+		AutoOffsetReset:   "latest",
+	}
+
+	wantProducer := map[string]string{
+		"acks":               "all",
+		"linger.ms":          "5",
+		"batch.size":         "65536",
+		"compression.type":   "zstd",
+		"enable.idempotence": "true",
+	}
+	if got := client.ProducerProperties(); !reflect.DeepEqual(got, wantProducer) {
+		t.Errorf("Producer properties: expected %v, got %v", wantProducer, got)
+	}
+
+	wantConsumer := map[string]string{
+		"fetch.min.bytes":       "1024",
+		"session.timeout.ms":    "45000",
+		"heartbeat.interval.ms": "3000",
+		"max.poll.interval.ms":  "300000",
+		"auto.offset.reset":     "latest",
+	}
+	if got := client.ConsumerProperties(); !reflect.DeepEqual(got, wantConsumer) {
+		t.Errorf("Consumer properties: expected %v, got %v", wantConsumer, got)
+	}
+}
+
+func TestKafkaClientDefaultsKeepLibrdkafkaDefaults(t *testing.T) {
+	client := DefaultConfig().Kafka.Client
+
+	if got := client.ProducerProperties(); len(got) != 0 {
+		t.Errorf("Expected no producer properties by default, got %v", got)
+	}
+	want := map[string]string{"auto.offset.reset": "earliest"}
+	if got := client.ConsumerProperties(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v by default, got %v", want, got)
+	}
+}
+
+func TestKafkaClientFromEnv(t *testing.T) {
+	t.Setenv("KAFKA_CLIENT_COMPRESSION", "lz4")
+	t.Setenv("KAFKA_CLIENT_LINGER_MS", "10")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Kafka.Client.Compression != "lz4" {
+		t.Errorf("Expected compression lz4, got %s", cfg.Kafka.Client.Compression)
+	}
+	if cfg.Kafka.Client.Linger != 10*time.Millisecond {
+		t.Errorf("Expected linger 10ms, got %s", cfg.Kafka.Client.Linger)
+	}
+}
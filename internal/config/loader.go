@@ -1,9 +1,13 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
-	"strconv"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -12,62 +16,251 @@ import (
 // AppConfig is the main configuration structure for the application.
 // It aggregates configurations for all subsystems.
 type AppConfig struct {
-	App      AppSettings    `yaml:"app"`      // General application configuration.
-	Kafka    KafkaConfig    `yaml:"kafka"`    // Kafka configuration.
-	Producer ProducerConfig `yaml:"producer"` // Producer configuration.
-	Tracker  TrackerConfig  `yaml:"tracker"`  // Tracker configuration.
-	Monitor  MonitorConfig  `yaml:"monitor"`  // Monitor configuration.
-	Retry    RetryConfig    `yaml:"retry"`    // Retry configuration.
-	DLQ      DLQConfig      `yaml:"dlq"`      // Dead Letter Queue configuration.
+	App            AppSettings          `yaml:"app"`             // General application configuration.
+	Kafka          KafkaConfig          `yaml:"kafka"`           // Kafka configuration.
+	Security       SecurityConfig       `yaml:"security"`        // Kafka TLS/SASL security configuration.
+	SchemaRegistry SchemaRegistryConfig `yaml:"schema_registry"` // Schema registry configuration.
+	Producer       ProducerConfig       `yaml:"producer"`        // Producer configuration.
+	Tracker        TrackerConfig        `yaml:"tracker"`         // Tracker configuration.
+	Monitor        MonitorConfig        `yaml:"monitor"`         // Monitor configuration.
+	Retry          RetryConfig          `yaml:"retry"`           // Retry configuration.
+	DLQ            DLQConfig            `yaml:"dlq"`             // Dead Letter Queue configuration.
+	Chaos          ChaosConfig          `yaml:"chaos"`           // Failure injection (pubsub chaos).
+	Gateway        GatewayConfig        `yaml:"gateway"`         // HTTP ingestion gateway (pubsub gateway).
+	Metrics        MetricsConfig        `yaml:"metrics"`         // Metrics exporters (Prometheus, OTLP).
+	Debug          DebugConfig          `yaml:"debug"`           // Runtime diagnostics listener (pprof, expvar).
 }
 
 // AppSettings contains general application settings.
 type AppSettings struct {
 	Env      string `yaml:"env"`       // Execution environment (e.g., development, production).
 	LogLevel string `yaml:"log_level"` // Logging level.
+	Locale   string `yaml:"locale"`    // UI language ("fr" or "en"; empty to use LANG).
 }
 
 // KafkaConfig contains Kafka connection settings.
 type KafkaConfig struct {
-	Broker        string `yaml:"broker"`         // Kafka broker address.
-	Topic         string `yaml:"topic"`          // Main Kafka topic.
-	ConsumerGroup string `yaml:"consumer_group"` // Consumer group identifier.
+	Brokers       BrokerList        `yaml:"broker"`         // Bootstrap broker addresses (host:port), memory:// for the in-process broker, or an amqp:// URL for RabbitMQ.
+	Topic         string            `yaml:"topic"`          // Main Kafka topic.
+	ConsumerGroup string            `yaml:"consumer_group"` // Consumer group identifier.
+	Client        KafkaClientConfig `yaml:"client"`         // Client tuning shared by the producer and the tracker.
+}
+
+// KafkaClientConfig contains the Kafka client tuning settings.
+// Zero values keep the librdkafka defaults.
+type KafkaClientConfig struct {
+	Acks              string        `yaml:"acks"`               // Producer acknowledgements: 0, 1 or all.
+	Linger            time.Duration `yaml:"linger"`             // Producer batching delay.
+	BatchSize         int           `yaml:"batch_size"`         // Producer maximum batch size in bytes.
+	Compression       string        `yaml:"compression"`        // Producer compression: none, gzip, snappy, lz4 or zstd.
+	EnableIdempotence bool          `yaml:"enable_idempotence"` // Producer exactly-once delivery per partition.
+	FetchMinBytes     int           `yaml:"fetch_min_bytes"`    // Consumer minimum bytes per fetch response.
+	SessionTimeout    time.Duration `yaml:"session_timeout"`    // Consumer group session timeout.
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval"` // Consumer group heartbeat interval.
+	MaxPollInterval   time.Duration `yaml:"max_poll_interval"`  // Consumer maximum delay between two polls.
+	AutoOffsetReset   string        `yaml:"auto_offset_reset"`  // Consumer start without committed offsets: earliest or latest.
+}
+
+// SecurityConfig contains the TLS and SASL settings of every Kafka client.
+// Certificates are given either as file paths or as inline PEM.
+type SecurityConfig struct {
+	Protocol      string `yaml:"protocol"`       // plaintext, ssl, sasl_plaintext or sasl_ssl ("" = plaintext).
+	CAFile        string `yaml:"ca_file"`        // CA certificate file verifying the brokers.
+	CertFile      string `yaml:"cert_file"`      // Client certificate file (mutual TLS).
+	KeyFile       string `yaml:"key_file"`       // Client private key file (mutual TLS).
+	CAPEM         string `yaml:"ca_pem"`         // Inline CA certificate (instead of ca_file).
+	CertPEM       string `yaml:"cert_pem"`       // Inline client certificate (instead of cert_file).
+	KeyPEM        string `yaml:"key_pem"`        // Inline client private key (instead of key_file).
+	KeyPassword   string `yaml:"key_password"`   // Passphrase of the client private key.
+	SASLMechanism string `yaml:"sasl_mechanism"` // PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512.
+	SASLUsername  string `yaml:"sasl_username"`  // SASL user name.
+	SASLPassword  string `yaml:"sasl_password"`  // SASL password.
+}
+
+// SchemaRegistryConfig contains the schema registry settings used by the
+// Avro/Protobuf serializers. An empty URL disables the registry.
+type SchemaRegistryConfig struct {
+	URL                 string `yaml:"url"`                   // Registry base URL (e.g., http://localhost:8081).
+	Username            string `yaml:"username"`              // Basic authentication user (or API key).
+	Password            string `yaml:"password"`              // Basic authentication password (or API secret).
+	SubjectNameStrategy string `yaml:"subject_name_strategy"` // topic_name, record_name or topic_record_name.
+	CacheCapacity       int    `yaml:"cache_capacity"`        // Maximum number of schemas cached by the client.
 }
 
 // ProducerConfig contains producer-specific settings.
 type ProducerConfig struct {
-	IntervalMs     int `yaml:"interval_ms"`      // Interval between messages in milliseconds.
-	FlushTimeoutMs int `yaml:"flush_timeout_ms"` // Wait timeout for sending messages in milliseconds.
+	Interval      time.Duration `yaml:"interval"`        // Interval between messages.
+	FlushTimeout  time.Duration `yaml:"flush_timeout"`   // Wait timeout for sending messages.
+	PartitionKey  string        `yaml:"partition_key"`   // Order field keying the messages: "customer", "order" or "region".
+	StampSendTime bool          `yaml:"stamp_send_time"` // Stamp every message with its send time (sent-at header) for pubsub latency.
+	StatsFile     string        `yaml:"stats_file"`      // Live statistics of the producer, shown by pubsub web ("" disables them).
 }
 
 // TrackerConfig contains tracker-specific settings.
 type TrackerConfig struct {
-	LogFile                string `yaml:"log_file"`                 // Path to the structured log file.
-	EventsFile             string `yaml:"events_file"`              // Path to the event file.
-	MetricsIntervalSeconds int    `yaml:"metrics_interval_seconds"` // Metrics calculation interval in seconds.
-	ReadTimeoutMs          int    `yaml:"read_timeout_ms"`          // Kafka read timeout in milliseconds.
-	MaxConsecutiveErrors   int    `yaml:"max_consecutive_errors"`   // Max consecutive errors.
+	LogFile              string        `yaml:"log_file"`               // Path to the structured log file.
+	EventsFile           string        `yaml:"events_file"`            // Path to the event file.
+	MetricsInterval      time.Duration `yaml:"metrics_interval"`       // Metrics calculation interval.
+	ReadTimeout          time.Duration `yaml:"read_timeout"`           // Kafka read timeout.
+	MaxConsecutiveErrors int           `yaml:"max_consecutive_errors"` // Max consecutive errors.
+	StrictDecoding       bool          `yaml:"strict_decoding"`        // Reject orders with fields unknown to the schema (schema_mismatch events).
+	NormalizeOrders      bool          `yaml:"normalize_orders"`       // Normalize the currency and the amounts of the received orders.
+	RedactPII            bool          `yaml:"redact_pii"`             // Mask the personal data of the orders written to the audit trail.
 }
 
 // MonitorConfig contains monitor-specific settings.
 type MonitorConfig struct {
-	MaxRecentLogs   int `yaml:"max_recent_logs"`   // Max recent logs to display.
-	MaxRecentEvents int `yaml:"max_recent_events"` // Max recent events to display.
-	UIUpdateMs      int `yaml:"ui_update_ms"`      // UI update frequency in milliseconds.
+	MaxRecentLogs    int              `yaml:"max_recent_logs"`    // Max recent logs to display.
+	MaxRecentEvents  int              `yaml:"max_recent_events"`  // Max recent events to display.
+	UIUpdateInterval time.Duration    `yaml:"ui_update_interval"` // UI update frequency.
+	Quality          QualityConfig    `yaml:"quality"`            // Quality score formula.
+	TimeSource       string           `yaml:"time_source"`        // Timeline of rates and error age: "event" (tracker timestamps) or "local".
+	MaxClockSkew     time.Duration    `yaml:"max_clock_skew"`     // Tolerated advance of tracker timestamps over the local clock.
+	IngestHistory    bool             `yaml:"ingest_history"`     // Pre-populate metrics from existing and rotated files on startup.
+	Processes        []ProcessProbe   `yaml:"processes"`          // Processes whose liveness is shown in the health dashboard.
+	ProbeInterval    time.Duration    `yaml:"probe_interval"`     // Interval between two process and cluster probes.
+	ClusterProbe     bool             `yaml:"cluster_probe"`      // Query Kafka broker and topic metadata (requires the kafka build tag).
+	Thresholds       ThresholdsConfig `yaml:"thresholds"`         // Health status thresholds and display limits.
+	LatencyFile      string           `yaml:"latency_file"`       // Latency snapshots streamed by pubsub latency, shown in the latency panel ("" hides it).
+}
+
+// ThresholdsConfig contains the thresholds of the monitor health statuses and
+// the truncation of the displayed log and event rows.
+type ThresholdsConfig struct {
+	SuccessRateExcellent float64       `yaml:"success_rate_excellent"` // Minimum success rate (%) shown as excellent.
+	SuccessRateGood      float64       `yaml:"success_rate_good"`      // Minimum success rate (%) shown as good.
+	ThroughputNormal     float64       `yaml:"throughput_normal"`      // Minimum throughput (msg/s) shown as normal.
+	ThroughputLow        float64       `yaml:"throughput_low"`         // Minimum throughput (msg/s) shown as low rather than stopped.
+	ErrorCritical        time.Duration `yaml:"error_critical"`         // Age of the last error below which errors are active (critical).
+	ErrorWarning         time.Duration `yaml:"error_warning"`          // Age of the last error below which errors are recent (warning).
+	QualityExcellent     float64       `yaml:"quality_excellent"`      // Minimum quality score shown as excellent.
+	QualityGood          float64       `yaml:"quality_good"`           // Minimum quality score shown as good.
+	QualityMedium        float64       `yaml:"quality_medium"`         // Minimum quality score shown as medium.
+	MaxLogRowLength      int           `yaml:"max_log_row_length"`     // Maximum length of a displayed log row.
+	MaxEventRowLength    int           `yaml:"max_event_row_length"`   // Maximum length of a displayed event row.
+	TruncateSuffix       string        `yaml:"truncate_suffix"`        // Suffix of truncated rows.
+}
+
+// ProcessProbe describes how to check that a component process is alive.
+// The health URL takes precedence over the PID file when both are set.
+type ProcessProbe struct {
+	Name      string `yaml:"name"`       // Displayed process name (e.g., producer).
+	PIDFile   string `yaml:"pid_file"`   // File containing the process ID (e.g., producer.pid).
+	HealthURL string `yaml:"health_url"` // HTTP endpoint answering 2xx when healthy.
+}
+
+// QualityConfig contains the weights and thresholds of the monitor quality score.
+// The score is the sum of the weighted components, normalized to 0-100 over the
+// components in use. Latency and lag are only included when their weight is
+// positive and the corresponding metric is available.
+type QualityConfig struct {
+	SuccessWeight     float64            `yaml:"success_weight"`      // Points awarded for a 100% success rate.
+	ThroughputBuckets []ThroughputBucket `yaml:"throughput_buckets"`  // Throughput score buckets.
+	ErrorWeight       float64            `yaml:"error_weight"`        // Points awarded when no error occurred.
+	ErrorPenalty      float64            `yaml:"error_penalty"`       // Points removed per error.
+	LatencyWeight     float64            `yaml:"latency_weight"`      // Points awarded when latency meets the target (0 disables).
+	LatencyTarget     time.Duration      `yaml:"latency_target"`      // Target p99 delivery latency, measured by the latency probe (monitor.latency_file).
+	LagWeight         float64            `yaml:"lag_weight"`          // Points awarded when consumer lag meets the target (0 disables).
+	LagTargetMessages int64              `yaml:"lag_target_messages"` // Target consumer lag in messages.
+}
+
+// ThroughputBucket awards a throughput score above a minimum rate.
+type ThroughputBucket struct {
+	MinMPS float64 `yaml:"min_mps"` // Minimum throughput in messages per second.
+	Score  float64 `yaml:"score"`   // Points awarded.
 }
 
 // RetryConfig contains retry model settings.
 type RetryConfig struct {
-	MaxAttempts    int     `yaml:"max_attempts"`     // Maximum number of attempts.
-	InitialDelayMs int     `yaml:"initial_delay_ms"` // Initial delay in milliseconds.
-	MaxDelayMs     int     `yaml:"max_delay_ms"`     // Maximum delay in milliseconds.
-	Multiplier     float64 `yaml:"multiplier"`       // Backoff multiplier.
+	MaxAttempts  int           `yaml:"max_attempts"`  // Maximum number of attempts.
+	InitialDelay time.Duration `yaml:"initial_delay"` // Initial delay.
+	MaxDelay     time.Duration `yaml:"max_delay"`     // Maximum delay.
+	Multiplier   float64       `yaml:"multiplier"`    // Backoff multiplier.
+	Backoff      string        `yaml:"backoff"`       // Delay strategy: constant, exponential, decorrelated_jitter or fibonacci.
+	Jitter       float64       `yaml:"jitter"`        // Random delay variation (e.g., 0.25 for ±25%).
+
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuit_breaker"` // Circuit breaker of the producer and tracker Kafka clients.
+	Topics         RetryTopicsConfig    `yaml:"topics"`          // Non-blocking retry topics of the tracker.
+}
+
+// CircuitBreakerConfig contains the circuit breaker settings. After
+// failure_threshold consecutive Kafka errors, the producer stops publishing and
+// the tracker stops reading for reset_timeout, then a trial call closes or
+// reopens the circuit.
+type CircuitBreakerConfig struct {
+	Enabled          bool          `yaml:"enabled"`           // Enables the circuit breaker.
+	FailureThreshold int           `yaml:"failure_threshold"` // Consecutive failures opening the circuit.
+	ResetTimeout     time.Duration `yaml:"reset_timeout"`     // Open duration before a trial call.
+	SuccessThreshold int           `yaml:"success_threshold"` // Consecutive trial successes closing the circuit.
+}
+
+// RetryTopicsConfig contains the non-blocking retry topic settings. A message
+// the tracker fails to process is published to the retry topic of the next
+// tier (e.g., orders-retry-1m), re-injected into the main topic once the tier
+// delay has elapsed, and sent to the DLQ after the last tier.
+type RetryTopicsConfig struct {
+	Enabled bool            `yaml:"enabled"` // Enables the retry topics.
+	Tiers   []time.Duration `yaml:"tiers"`   // Delay of each tier, in retry order.
 }
 
 // DLQConfig contains Dead Letter Queue (DLQ) settings.
 type DLQConfig struct {
-	Enabled bool   `yaml:"enabled"` // Enables or disables DLQ.
-	Topic   string `yaml:"topic"`   // Kafka topic for DLQ.
+	Enabled        bool          `yaml:"enabled"`         // Enables or disables DLQ.
+	Topic          string        `yaml:"topic"`           // Kafka topic for DLQ.
+	ConfirmTimeout time.Duration `yaml:"confirm_timeout"` // Delivery wait of each DLQ send (0: asynchronous sends).
+
+	FallbackFile     string        `yaml:"fallback_file"`     // File receiving the messages the DLQ fails to publish ("": disabled).
+	RecoveryInterval time.Duration `yaml:"recovery_interval"` // Interval between attempts to drain the fallback file back to the DLQ topic.
+
+	Reprocessor DLQReprocessorConfig `yaml:"reprocessor"` // Automated DLQ reprocessor (dlqctl reprocess).
+}
+
+// DLQReprocessorConfig contains the settings of the DLQ reprocessor. It
+// re-publishes the DLQ messages whose error is retryable (transient or
+// throttled) once cool_down has elapsed since their failure, and parks the
+// others, and those already reprocessed max_reprocess times, in park_topic.
+type DLQReprocessorConfig struct {
+	ParkTopic    string        `yaml:"park_topic"`    // Kafka topic receiving the messages that cannot be reprocessed.
+	CoolDown     time.Duration `yaml:"cool_down"`     // Delay after the failure before a message is re-published.
+	MaxReprocess int           `yaml:"max_reprocess"` // Reprocessing rounds of a message before it is parked.
+}
+
+// ChaosConfig contains the failure injection settings. pubsub chaos writes the
+// scheduled faults to state_file; with enabled, the producer and the tracker
+// read it and inject the active faults, and the broker_pause faults pause
+// broker_container.
+type ChaosConfig struct {
+	Enabled         bool   `yaml:"enabled"`          // Makes the producer and the tracker apply the faults of state_file.
+	StateFile       string `yaml:"state_file"`       // Faults scheduled by pubsub chaos.
+	BrokerContainer string `yaml:"broker_container"` // Docker container paused by the broker_pause faults.
+}
+
+// GatewayConfig contains the settings of the HTTP ingestion gateway, which
+// publishes the orders posted by external systems to the orders topic.
+type GatewayConfig struct {
+	Addr         string  `yaml:"addr"`           // Listen address (host:port).
+	Token        string  `yaml:"token"`          // Bearer token required to post orders ("" disables authentication).
+	RateLimit    float64 `yaml:"rate_limit"`     // Accepted orders per second (0 disables rate limiting).
+	Burst        int     `yaml:"burst"`          // Orders accepted at once before rate_limit applies.
+	MaxBodyBytes int64   `yaml:"max_body_bytes"` // Maximum size of a posted order.
+}
+
+// MetricsConfig contains the exporters of the metrics of the producer, the
+// tracker and their DLQ (pkg/metrics). Both are disabled by default; as each
+// process exposes its own metrics, addr is usually set per command
+// (--metrics.addr).
+type MetricsConfig struct {
+	Addr         string        `yaml:"addr"`          // Listen address (host:port) of the Prometheus scrape endpoint /metrics ("" disables it).
+	OTLPEndpoint string        `yaml:"otlp_endpoint"` // OTLP/HTTP metrics URL of the collector, e.g. http://localhost:4318/v1/metrics ("" disables the push).
+	OTLPInterval time.Duration `yaml:"otlp_interval"` // Interval between two OTLP pushes.
+}
+
+// DebugConfig contains the runtime diagnostics listener of the producer, the
+// tracker and the monitor (pkg/diag). Disabled by default; as it exposes the
+// internals of the process, addr should be a loopback address.
+type DebugConfig struct {
+	Addr        string `yaml:"addr"`         // Listen address (host:port) of /debug/pprof/, /debug/vars and /debug/snapshot ("" disables it).
+	SnapshotDir string `yaml:"snapshot_dir"` // Directory of the goroutine and heap snapshots written by POST /debug/snapshot.
 }
 
 // DefaultConfig returns a configuration with default values.
@@ -82,141 +275,575 @@ func DefaultConfig() *AppConfig {
 			LogLevel: "info",
 		},
 		Kafka: KafkaConfig{
-			Broker:        DefaultKafkaBroker,
+			Brokers:       BrokerList{DefaultKafkaBroker},
 			Topic:         DefaultTopic,
 			ConsumerGroup: DefaultConsumerGroup,
+			Client: KafkaClientConfig{
+				AutoOffsetReset: DefaultAutoOffsetReset,
+			},
+		},
+		SchemaRegistry: SchemaRegistryConfig{
+			SubjectNameStrategy: SchemaRegistrySubjectNameStrategy,
+			CacheCapacity:       SchemaRegistryCacheCapacity,
 		},
 		Producer: ProducerConfig{
-			IntervalMs:     int(ProducerMessageInterval / time.Millisecond),
-			FlushTimeoutMs: int(ProducerFlushTimeout / time.Millisecond),
+			Interval:     ProducerMessageInterval,
+			FlushTimeout: ProducerFlushTimeout,
+			PartitionKey: ProducerPartitionKey,
+			StatsFile:    ProducerStatsFile,
 		},
 		Tracker: TrackerConfig{
-			LogFile:                TrackerLogFile,
-			EventsFile:             TrackerEventsFile,
-			MetricsIntervalSeconds: int(TrackerMetricsInterval / time.Second),
-			ReadTimeoutMs:          int(TrackerConsumerReadTimeout / time.Millisecond),
-			MaxConsecutiveErrors:   TrackerMaxConsecutiveErrors,
+			LogFile:              TrackerLogFile,
+			EventsFile:           TrackerEventsFile,
+			MetricsInterval:      TrackerMetricsInterval,
+			ReadTimeout:          TrackerConsumerReadTimeout,
+			MaxConsecutiveErrors: TrackerMaxConsecutiveErrors,
 		},
 		Monitor: MonitorConfig{
-			MaxRecentLogs:   MonitorMaxRecentLogs,
-			MaxRecentEvents: MonitorMaxRecentEvents,
-			UIUpdateMs:      int(MonitorUIUpdateInterval / time.Millisecond),
+			MaxRecentLogs:    MonitorMaxRecentLogs,
+			MaxRecentEvents:  MonitorMaxRecentEvents,
+			UIUpdateInterval: MonitorUIUpdateInterval,
+			Quality:          DefaultQualityConfig(),
+			TimeSource:       MonitorTimeSource,
+			MaxClockSkew:     MonitorMaxClockSkew,
+			ProbeInterval:    MonitorProbeInterval,
+			Thresholds:       DefaultThresholdsConfig(),
+			LatencyFile:      LatencyStreamFile,
 		},
 		Retry: RetryConfig{
-			MaxAttempts:    3,
-			InitialDelayMs: 100,
-			MaxDelayMs:     5000,
-			Multiplier:     2.0,
+			MaxAttempts:  3,
+			InitialDelay: 100 * time.Millisecond,
+			MaxDelay:     5 * time.Second,
+			Multiplier:   2.0,
+			Backoff:      BackoffExponential,
+			Jitter:       RetryJitter,
+			CircuitBreaker: CircuitBreakerConfig{
+				Enabled:          true,
+				FailureThreshold: CircuitBreakerFailureThreshold,
+				ResetTimeout:     CircuitBreakerResetTimeout,
+				SuccessThreshold: CircuitBreakerSuccessThreshold,
+			},
+			Topics: RetryTopicsConfig{
+				Tiers: []time.Duration{RetryTopicFirstTier, RetryTopicSecondTier},
+			},
 		},
 		DLQ: DLQConfig{
-			Enabled: true,
-			Topic:   "orders-dlq",
+			Enabled:          true,
+			Topic:            "orders-dlq",
+			FallbackFile:     "dlq-fallback.events",
+			RecoveryInterval: 30 * time.Second,
+			Reprocessor: DLQReprocessorConfig{
+				ParkTopic:    "orders-dlq-parked",
+				CoolDown:     5 * time.Minute,
+				MaxReprocess: 3,
+			},
+		},
+		Chaos: ChaosConfig{
+			StateFile:       ChaosStateFile,
+			BrokerContainer: ChaosBrokerContainer,
+		},
+		Gateway: GatewayConfig{
+			Addr:         GatewayAddr,
+			RateLimit:    GatewayRateLimit,
+			Burst:        GatewayBurst,
+			MaxBodyBytes: GatewayMaxBodyBytes,
+		},
+		Metrics: MetricsConfig{
+			OTLPInterval: MetricsOTLPInterval,
+		},
+		Debug: DebugConfig{
+			SnapshotDir: DebugSnapshotDir,
 		},
 	}
 }
 
-// Load loads the configuration from a YAML file, utilizing default values if necessary.
-// Environment variables override values from the YAML file.
+// DefaultQualityConfig returns the default quality score formula:
+// 50 points for the success rate, up to 30 points for throughput and
+// 20 points minus 2 per error. Latency and lag are disabled.
+//
+// Returns:
+//   - QualityConfig: The default quality configuration.
+func DefaultQualityConfig() QualityConfig {
+	return QualityConfig{
+		SuccessWeight: 50,
+		ThroughputBuckets: []ThroughputBucket{
+			{MinMPS: MonitorQualityThroughputHigh, Score: 30},
+			{MinMPS: MonitorQualityThroughputMedium, Score: 25},
+			{MinMPS: MonitorQualityThroughputLow, Score: 15},
+			{MinMPS: 0, Score: 10},
+		},
+		ErrorWeight:       20,
+		ErrorPenalty:      2,
+		LatencyTarget:     100 * time.Millisecond,
+		LagTargetMessages: 100,
+	}
+}
+
+// DefaultThresholdsConfig returns the default monitor thresholds.
+//
+// Returns:
+//   - ThresholdsConfig: The default thresholds.
+func DefaultThresholdsConfig() ThresholdsConfig {
+	return ThresholdsConfig{
+		SuccessRateExcellent: MonitorSuccessRateExcellent,
+		SuccessRateGood:      MonitorSuccessRateGood,
+		ThroughputNormal:     MonitorThroughputNormal,
+		ThroughputLow:        MonitorThroughputLow,
+		ErrorCritical:        MonitorErrorTimeoutCritical,
+		ErrorWarning:         MonitorErrorTimeoutWarning,
+		QualityExcellent:     MonitorQualityScoreExcellent,
+		QualityGood:          MonitorQualityScoreGood,
+		QualityMedium:        MonitorQualityScoreMedium,
+		MaxLogRowLength:      MonitorMaxLogRowLength,
+		MaxEventRowLength:    MonitorMaxEventRowLength,
+		TruncateSuffix:       MonitorTruncateSuffix,
+	}
+}
+
+// Load loads the configuration from a YAML, JSON or TOML file (detected from its
+// extension, see loadFromFile), utilizing default values if necessary.
+// The profile selected by APP_ENV (see loadFromYAML) overlays the base file, and
+// environment variables override the result. The resulting configuration is
+// validated (see Validate).
 //
 // Parameters:
-//   - configPath: Path to the YAML configuration file (optional).
+//   - configPath: Path to the configuration file (optional).
 //
 // Returns:
 //   - *AppConfig: The loaded configuration.
-//   - error: An error if loading fails.
+//   - error: An error if loading fails or a value is invalid (*ValidationError).
 func Load(configPath string) (*AppConfig, error) {
+	profile, _, _ := lookupEnv("app.env")
+	cfg, _, err := load(configPath, profile, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// load applies the remote document, the configuration file, their selected
+// profile, the file fragments (see fragmentPaths) and the environment to the
+// default configuration, without validating the result. The local file
+// overrides the remote document. Documents of an older layout are migrated
+// (see SetMigrationHandler); the deprecated keys and environment variables
+// used are passed to the deprecation handler (see SetDeprecationHandler).
+//
+// Parameters:
+//   - configPath: Path to the configuration file (optional).
+//   - profile: The requested profile ("" to use app.env from the file).
+//   - remote: The YAML document of the remote source (nil if none).
+//
+// Returns:
+//   - *AppConfig: The loaded configuration.
+//   - Sources: The origin of every setting.
+//   - error: An error if a document cannot be read or parsed, the requested profile
+//     is unknown, a *_FILE variable names an unreadable file, or an encrypted value
+//     cannot be decrypted.
+func load(configPath, profile string, remote []byte) (*AppConfig, Sources, error) {
 	cfg := DefaultConfig()
+	sources := make(Sources)
+	var deprecations []Deprecation
+	var applied []Migration
+
+	// Start from the centralized configuration
+	if remote != nil {
+		present := make(map[string]bool)
+		var notes documentNotes
+		if err := loadFromYAML(remote, cfg, profile, present, &notes); err != nil {
+			return nil, nil, fmt.Errorf("error loading remote config: %w", err)
+		}
+		for path := range present {
+			sources[path] = SourceRemote
+		}
+		deprecations = appendKeyDeprecations(deprecations, notes.Deprecated, SourceRemote)
+		applied = appendMigrations(applied, notes.Migrations, SourceRemote)
+	}
 
-	// Try to load from YAML file
+	// Try to load from the configuration file
 	if configPath != "" {
-		if err := loadFromYAML(configPath, cfg); err != nil {
+		present := make(map[string]bool)
+		var notes documentNotes
+		if err := loadFromFile(configPath, cfg, profile, present, &notes); err != nil {
 			// Not found file is acceptable, use defaults
 			if !os.IsNotExist(err) {
-				return nil, fmt.Errorf("error loading config file: %w", err)
+				return nil, nil, fmt.Errorf("error loading config file: %w", err)
+			}
+		}
+
+		// Merge the fragments (include: directive, then conf.d) in order
+		fragments, err := fragmentPaths(configPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error loading config file: %w", err)
+		}
+		for _, fragment := range fragments {
+			if err := loadFromFile(fragment, cfg, "", present, &notes); err != nil {
+				return nil, nil, fmt.Errorf("error loading config fragment %s: %w", fragment, err)
 			}
 		}
+		for path := range present {
+			sources[path] = SourceFile
+		}
+		deprecations = appendKeyDeprecations(deprecations, notes.Deprecated, SourceFile)
+		applied = appendMigrations(applied, notes.Migrations, SourceFile)
 	}
 
 	// Override with environment variables
-	loadFromEnv(cfg)
+	before := settingValues(cfg)
+	if err := loadFromEnv(cfg, &deprecations); err != nil {
+		return nil, nil, fmt.Errorf("error loading environment: %w", err)
+	}
+	for path, value := range settingValues(cfg) {
+		if value != before[path] {
+			sources[path] = SourceEnv
+		}
+	}
 
-	return cfg, nil
+	// Decrypt the enc: values (see EncryptedPrefix)
+	if err := decryptSecrets(cfg); err != nil {
+		return nil, nil, err
+	}
+
+	reportMigrations(applied)
+	reportDeprecations(deprecations)
+	return cfg, sources, nil
+}
+
+// profilesDocument holds the optional "profiles:" section of a configuration file.
+// Each profile (e.g., dev, staging, prod) has the same structure as the base file.
+type profilesDocument struct {
+	Profiles map[string]yaml.Node `yaml:"profiles"` // Profile overlays keyed by environment name.
 }
 
-// loadFromYAML loads configuration from a YAML file.
+// loadFromFile loads configuration from a file whose format is detected from its
+// extension: ".json" for JSON, ".toml" for TOML, YAML otherwise. JSON and TOML
+// documents are converted to YAML so that the same struct tags and profiles apply.
 //
 // Parameters:
 //   - path: The file path.
 //   - cfg: The configuration structure to fill.
+//   - profile: The requested profile ("" to use app.env from the file).
+//   - present: Receives the paths of the settings defined by the file (may be nil).
+//   - notes: Receives the deprecated keys and migrations of the file (may be nil).
 //
 // Returns:
 //   - error: An error if reading or parsing fails.
-func loadFromYAML(path string, cfg *AppConfig) error {
-	data, err := os.ReadFile(path)
+func loadFromFile(path string, cfg *AppConfig, profile string, present map[string]bool, notes *documentNotes) error {
+	data, err := readDocument(path)
 	if err != nil {
 		return err
 	}
+	return loadFromYAML(data, cfg, profile, present, notes)
+}
 
-	if err := yaml.Unmarshal(data, cfg); err != nil {
-		return fmt.Errorf("error parsing YAML: %w", err)
+// readDocument reads a configuration file as a YAML document, converting JSON
+// (".json") and TOML (".toml") files.
+//
+// Parameters:
+//   - path: The file path.
+//
+// Returns:
+//   - []byte: The YAML document.
+//   - error: An error if reading (unwrapped, see os.IsNotExist) or parsing fails.
+func readDocument(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	var doc map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("error parsing JSON: %w", err)
+		}
+	case ".toml":
+		if doc, err = parseTOML(data); err != nil {
+			return nil, fmt.Errorf("error parsing TOML: %w", err)
+		}
+	default:
+		return data, nil
+	}
+
+	if data, err = yaml.Marshal(doc); err != nil {
+		return nil, fmt.Errorf("error converting configuration: %w", err)
+	}
+	return data, nil
 }
 
-// loadFromEnv overrides the configuration with environment variables.
+// loadFromYAML loads configuration from a YAML document, then overlays the profile
+// matching the environment: the requested profile if any, else app.env from the
+// base document. Settings absent from the profile keep their base value.
+// Deprecated keys are first moved to their replacement (see migrateDeprecatedKeys).
 //
 // Parameters:
-//   - cfg: The configuration structure to update.
-func loadFromEnv(cfg *AppConfig) {
-	// App Parameters
-	if v := os.Getenv("APP_ENV"); v != "" {
-		cfg.App.Env = v
+//   - data: The YAML document.
+//   - cfg: The configuration structure to fill.
+//   - profile: The requested profile ("" to use app.env from the document).
+//   - present: Receives the paths of the settings defined by the document (may be nil).
+//   - notes: Receives the deprecated keys and migrations of the document (may be nil).
+//
+// Returns:
+//   - error: An error if parsing fails, or if the requested profile
+//     is missing from a document that defines profiles.
+func loadFromYAML(data []byte, cfg *AppConfig, profile string, present map[string]bool, notes *documentNotes) error {
+	data, applied, err := migrateVersion(data)
+	if err != nil {
+		return err
+	}
+	data, found, err := migrateDeprecatedKeys(data)
+	if err != nil {
+		return err
+	}
+	if notes != nil {
+		notes.Deprecated = append(notes.Deprecated, found...)
+		notes.Migrations = append(notes.Migrations, applied...)
 	}
-	if v := os.Getenv("LOG_LEVEL"); v != "" {
-		cfg.App.LogLevel = v
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("error parsing YAML: %w", err)
 	}
 
-	// Kafka Parameters
-	if v := os.Getenv("KAFKA_BROKER"); v != "" {
-		cfg.Kafka.Broker = v
+	var doc profilesDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("error parsing YAML: %w", err)
 	}
-	if v := os.Getenv("KAFKA_TOPIC"); v != "" {
-		cfg.Kafka.Topic = v
+	if present != nil {
+		var raw map[string]interface{}
+		if err := yaml.Unmarshal(data, &raw); err == nil {
+			delete(raw, "profiles")
+			delete(raw, "include")
+			delete(raw, "version")
+			collectPaths(raw, "", present)
+		}
 	}
-	if v := os.Getenv("KAFKA_CONSUMER_GROUP"); v != "" {
-		cfg.Kafka.ConsumerGroup = v
+	if len(doc.Profiles) == 0 {
+		return nil
 	}
 
-	// Producer Parameters
-	if v := os.Getenv("PRODUCER_INTERVAL_MS"); v != "" {
-		if i, err := strconv.Atoi(v); err == nil {
-			cfg.Producer.IntervalMs = i
+	name := profile
+	if name == "" {
+		name = cfg.App.Env
+	}
+	node, ok := doc.Profiles[name]
+	if !ok {
+		if profile != "" {
+			return fmt.Errorf("unknown profile %q (defined: %s)", profile, strings.Join(profileNames(doc.Profiles), ", "))
+		}
+		return nil
+	}
+	if err := node.Decode(cfg); err != nil {
+		return fmt.Errorf("error parsing profile %s: %w", name, err)
+	}
+	cfg.App.Env = name
+	if present != nil {
+		var raw map[string]interface{}
+		if err := node.Decode(&raw); err == nil {
+			collectPaths(raw, "", present)
 		}
+		present["app.env"] = true
 	}
 
-	// Tracker Parameters
-	if v := os.Getenv("TRACKER_LOG_FILE"); v != "" {
-		cfg.Tracker.LogFile = v
+	return nil
+}
+
+// profileNames returns the sorted names of the defined profiles.
+//
+// Parameters:
+//   - profiles: The profiles section.
+//
+// Returns:
+//   - []string: The profile names.
+func profileNames(profiles map[string]yaml.Node) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
 	}
-	if v := os.Getenv("TRACKER_EVENTS_FILE"); v != "" {
-		cfg.Tracker.EventsFile = v
+	sort.Strings(names)
+	return names
+}
+
+// documentNotes receives what loading a document reports besides the settings.
+type documentNotes struct {
+	Deprecated []string    // Deprecated keys used (see deprecateKey).
+	Migrations []Migration // Layout migrations applied (see ConfigVersion), without Source.
+}
+
+// appendMigrations appends the migrations applied to a document.
+//
+// Parameters:
+//   - applied: The migrations found so far.
+//   - found: The migrations of the document.
+//   - source: The document origin.
+//
+// Returns:
+//   - []Migration: The extended migrations.
+func appendMigrations(applied, found []Migration, source Source) []Migration {
+	for _, m := range found {
+		m.Source = source
+		applied = append(applied, m)
 	}
+	return applied
+}
 
-	// Retry Parameters
-	if v := os.Getenv("RETRY_MAX_ATTEMPTS"); v != "" {
-		if i, err := strconv.Atoi(v); err == nil {
-			cfg.Retry.MaxAttempts = i
-		}
+// appendKeyDeprecations appends the deprecations of the keys used by a document.
+//
+// Parameters:
+//   - deprecations: The deprecations found so far.
+//   - keys: The deprecated keys used.
+//   - source: The document origin.
+//
+// Returns:
+//   - []Deprecation: The extended deprecations.
+func appendKeyDeprecations(deprecations []Deprecation, keys []string, source Source) []Deprecation {
+	for _, key := range keys {
+		deprecations = append(deprecations, Deprecation{Setting: key, Replacement: deprecatedKeys[key].Path, Source: source})
+	}
+	return deprecations
+}
+
+// envPrefix namespaces the environment variables (see SetEnvPrefix).
+var envPrefix string
+
+// SetEnvPrefix namespaces the environment variables bound to the settings,
+// e.g., "PUBSUB" binds kafka.broker to PUBSUB_KAFKA_BROKER. The unprefixed
+// names remain accepted as deprecated aliases, with a lower precedence.
+//
+// Parameters:
+//   - prefix: The prefix, with or without the trailing underscore ("" to disable).
+func SetEnvPrefix(prefix string) {
+	prefix = strings.ToUpper(prefix)
+	if prefix != "" && !strings.HasSuffix(prefix, "_") {
+		prefix += "_"
+	}
+	envPrefix = prefix
+}
+
+// envName returns the environment variable bound to a setting: the path in
+// upper case with dots replaced by underscores (kafka.broker -> KAFKA_BROKER).
+//
+// Parameters:
+//   - path: The setting path.
+//
+// Returns:
+//   - string: The variable name.
+func envName(path string) string {
+	return strings.ToUpper(strings.ReplaceAll(path, ".", "_"))
+}
+
+// envNames returns the environment variables bound to a setting, by decreasing
+// precedence: the prefixed name (if a prefix is set), the generated name and
+// its deprecated variables (see deprecateEnv).
+//
+// Parameters:
+//   - path: The setting path.
+//
+// Returns:
+//   - []string: The variable names.
+func envNames(path string) []string {
+	var names []string
+	if envPrefix != "" {
+		names = append(names, envPrefix+envName(path))
 	}
+	names = append(names, envName(path))
+	return append(names, deprecatedEnvNames(path)...)
+}
+
+// lookupEnv returns the value of the first environment variable of envNames
+// that is set (see lookupEnvVar).
+//
+// Parameters:
+//   - path: The setting path.
+//
+// Returns:
+//   - string: The value.
+//   - bool: False if no variable is set to a non-empty value.
+//   - error: An error if the file named by a *_FILE variable cannot be read.
+func lookupEnv(path string) (string, bool, error) {
+	_, v, ok, err := lookupEnvVar(path)
+	return v, ok, err
+}
 
-	// DLQ Parameters
-	if v := os.Getenv("DLQ_ENABLED"); v != "" {
-		cfg.DLQ.Enabled = v == "true" || v == "1"
+// lookupEnvVar returns the first environment variable of envNames that is set,
+// and its value. Following the Docker secrets convention, NAME_FILE may instead
+// hold the path of a file whose contents (without the trailing newline) are the
+// value; NAME takes precedence when both are set. The value of a deprecated
+// variable is converted to the format of its replacement, and skipped if the
+// conversion fails.
+//
+// Parameters:
+//   - path: The setting path.
+//
+// Returns:
+//   - string: The variable name.
+//   - string: The value.
+//   - bool: False if no variable is set to a non-empty value.
+//   - error: An error if the file named by a *_FILE variable cannot be read.
+func lookupEnvVar(path string) (string, string, bool, error) {
+	for _, name := range envNames(path) {
+		v := os.Getenv(name)
+		if v == "" {
+			file := os.Getenv(name + "_FILE")
+			if file == "" {
+				continue
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return "", "", false, fmt.Errorf("%s_FILE: %w", name, err)
+			}
+			v = strings.TrimRight(string(data), "\r\n")
+		}
+		if convert := deprecatedEnvs[name].Convert; convert != nil {
+			converted, err := convert(v)
+			if err != nil {
+				continue
+			}
+			v = converted
+		}
+		return name, v, true, nil
 	}
-	if v := os.Getenv("DLQ_TOPIC"); v != "" {
-		cfg.DLQ.Topic = v
+	return "", "", false, nil
+}
+
+// loadFromEnv overrides the configuration with environment variables.
+// Every scalar setting is bound to the variable named by envName (or read from
+// the file named by its *_FILE variant); kafka.broker takes a comma-separated
+// list, other lists are only configurable from the file. Delays take duration
+// strings. Booleans are true for "true" or "1", and invalid numbers are ignored.
+// A variable other than the preferred one of envNames (a deprecated variable,
+// or an unprefixed name when a prefix is set) is reported as a deprecation.
+//
+// Parameters:
+//   - cfg: The configuration structure to update.
+//   - deprecations: Receives the deprecated variables used (may be nil).
+//
+// Returns:
+//   - error: An error if a *_FILE variable names an unreadable file.
+func loadFromEnv(cfg *AppConfig, deprecations *[]Deprecation) error {
+	for _, f := range fields(cfg) {
+		if f.isList() {
+			continue
+		}
+		name, v, ok, err := lookupEnvVar(f.Path)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if preferred := envNames(f.Path)[0]; name != preferred && deprecations != nil {
+			*deprecations = append(*deprecations, Deprecation{Setting: name, Replacement: preferred, Source: SourceEnv})
+		}
+		if f.Value.Kind() == reflect.Bool {
+			f.Value.SetBool(v == "true" || v == "1")
+			continue
+		}
+		_ = f.set(v) // an invalid value keeps the previous one
 	}
+	return nil
 }
 
 // GetProducerInterval returns the producer interval as a duration.
@@ -224,7 +851,7 @@ func loadFromEnv(cfg *AppConfig) {
 // Returns:
 //   - time.Duration: The interval.
 func (c *AppConfig) GetProducerInterval() time.Duration {
-	return time.Duration(c.Producer.IntervalMs) * time.Millisecond
+	return c.Producer.Interval
 }
 
 // GetFlushTimeout returns the flush timeout as a duration.
@@ -232,7 +859,7 @@ func (c *AppConfig) GetProducerInterval() time.Duration {
 // Returns:
 //   - time.Duration: The timeout.
 func (c *AppConfig) GetFlushTimeout() time.Duration {
-	return time.Duration(c.Producer.FlushTimeoutMs) * time.Millisecond
+	return c.Producer.FlushTimeout
 }
 
 // GetMetricsInterval returns the metrics interval as a duration.
@@ -240,7 +867,7 @@ func (c *AppConfig) GetFlushTimeout() time.Duration {
 // Returns:
 //   - time.Duration: The interval.
 func (c *AppConfig) GetMetricsInterval() time.Duration {
-	return time.Duration(c.Tracker.MetricsIntervalSeconds) * time.Second
+	return c.Tracker.MetricsInterval
 }
 
 // GetReadTimeout returns the read timeout as a duration.
@@ -248,7 +875,7 @@ func (c *AppConfig) GetMetricsInterval() time.Duration {
 // Returns:
 //   - time.Duration: The timeout.
 func (c *AppConfig) GetReadTimeout() time.Duration {
-	return time.Duration(c.Tracker.ReadTimeoutMs) * time.Millisecond
+	return c.Tracker.ReadTimeout
 }
 
 // GetInitialRetryDelay returns the initial retry delay as a duration.
@@ -256,7 +883,7 @@ func (c *AppConfig) GetReadTimeout() time.Duration {
 // Returns:
 //   - time.Duration: The initial delay.
 func (c *AppConfig) GetInitialRetryDelay() time.Duration {
-	return time.Duration(c.Retry.InitialDelayMs) * time.Millisecond
+	return c.Retry.InitialDelay
 }
 
 // GetMaxRetryDelay returns the maximum retry delay as a duration.
@@ -264,5 +891,16 @@ func (c *AppConfig) GetInitialRetryDelay() time.Duration {
 // Returns:
 //   - time.Duration: The maximum delay.
 func (c *AppConfig) GetMaxRetryDelay() time.Duration {
-	return time.Duration(c.Retry.MaxDelayMs) * time.Millisecond
+	return c.Retry.MaxDelay
+}
+
+// GetChaosFile returns the chaos state file read by the producer and the tracker.
+//
+// Returns:
+//   - string: chaos.state_file, or "" when chaos.enabled is false.
+func (c *AppConfig) GetChaosFile() string {
+	if !c.Chaos.Enabled {
+		return ""
+	}
+	return c.Chaos.StateFile
 }
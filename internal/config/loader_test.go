@@ -1,8 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -14,8 +17,8 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.App.Env != "development" {
 		t.Errorf("Expected env 'development', got %s", cfg.App.Env)
 	}
-	if cfg.Kafka.Broker != DefaultKafkaBroker {
-		t.Errorf("Expected broker '%s', got %s", DefaultKafkaBroker, cfg.Kafka.Broker)
+	if cfg.Kafka.Brokers.String() != DefaultKafkaBroker {
+		t.Errorf("Expected broker '%s', got %s", DefaultKafkaBroker, cfg.Kafka.Brokers)
 	}
 	if cfg.Kafka.Topic != DefaultTopic {
 		t.Errorf("Expected topic '%s', got %s", DefaultTopic, cfg.Kafka.Topic)
@@ -43,9 +46,9 @@ func TestInvalidEnvVars(t *testing.T) {
 	}
 
 	// Should fall back to defaults (or previously set values)
-	// Default Producer.IntervalMs is 2000 (from DefaultConfig)
-	if cfg.Producer.IntervalMs != 2000 {
-		t.Errorf("Expected default interval 2000, got %d", cfg.Producer.IntervalMs)
+	// Default Producer.Interval is 2s (from DefaultConfig)
+	if cfg.Producer.Interval != 2000*time.Millisecond {
+		t.Errorf("Expected default interval 2000, got %s", cfg.Producer.Interval)
 	}
 	// Default Retry.MaxAttempts is 3
 	if cfg.Retry.MaxAttempts != 3 {
@@ -60,8 +63,8 @@ func TestLoadWithDefaults(t *testing.T) {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
-	if cfg.Kafka.Broker != DefaultKafkaBroker {
-		t.Errorf("Expected default broker, got %s", cfg.Kafka.Broker)
+	if cfg.Kafka.Brokers.String() != DefaultKafkaBroker {
+		t.Errorf("Expected default broker, got %s", cfg.Kafka.Brokers)
 	}
 }
 
@@ -100,14 +103,14 @@ dlq:
 	if cfg.App.Env != "production" {
 		t.Errorf("Expected env 'production', got %s", cfg.App.Env)
 	}
-	if cfg.Kafka.Broker != "kafka.example.com:9092" {
-		t.Errorf("Expected broker 'kafka.example.com:9092', got %s", cfg.Kafka.Broker)
+	if cfg.Kafka.Brokers.String() != "kafka.example.com:9092" {
+		t.Errorf("Expected broker 'kafka.example.com:9092', got %s", cfg.Kafka.Brokers)
 	}
 	if cfg.Kafka.Topic != "custom-topic" {
 		t.Errorf("Expected topic 'custom-topic', got %s", cfg.Kafka.Topic)
 	}
-	if cfg.Producer.IntervalMs != 5000 {
-		t.Errorf("Expected interval 5000ms, got %d", cfg.Producer.IntervalMs)
+	if cfg.Producer.Interval != 5000*time.Millisecond {
+		t.Errorf("Expected interval 5000ms, got %s", cfg.Producer.Interval)
 	}
 	if cfg.Retry.MaxAttempts != 5 {
 		t.Errorf("Expected 5 max attempts, got %d", cfg.Retry.MaxAttempts)
@@ -135,8 +138,8 @@ func TestLoadFromEnv(t *testing.T) {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
-	if cfg.Kafka.Broker != "env-broker:9092" {
-		t.Errorf("Expected broker 'env-broker:9092', got %s", cfg.Kafka.Broker)
+	if cfg.Kafka.Brokers.String() != "env-broker:9092" {
+		t.Errorf("Expected broker 'env-broker:9092', got %s", cfg.Kafka.Brokers)
 	}
 	if cfg.Kafka.Topic != "env-topic" {
 		t.Errorf("Expected topic 'env-topic', got %s", cfg.Kafka.Topic)
@@ -173,8 +176,8 @@ kafka:
 	}
 
 	// Env should override YAML
-	if cfg.Kafka.Broker != "env-broker:9092" {
-		t.Errorf("Expected env broker to override YAML, got %s", cfg.Kafka.Broker)
+	if cfg.Kafka.Brokers.String() != "env-broker:9092" {
+		t.Errorf("Expected env broker to override YAML, got %s", cfg.Kafka.Brokers)
 	}
 	// YAML value should be preserved when no env override
 	if cfg.Kafka.Topic != "yaml-topic" {
@@ -202,42 +205,51 @@ func TestDurationHelpers(t *testing.T) {
 
 	// Test GetProducerInterval
 	interval := cfg.GetProducerInterval()
-	expected := time.Duration(cfg.Producer.IntervalMs) * time.Millisecond
+	expected := cfg.Producer.Interval
 	if interval != expected {
 		t.Errorf("GetProducerInterval: expected %v, got %v", expected, interval)
 	}
 
 	// Test GetFlushTimeout
 	flush := cfg.GetFlushTimeout()
-	expectedFlush := time.Duration(cfg.Producer.FlushTimeoutMs) * time.Millisecond
+	expectedFlush := cfg.Producer.FlushTimeout
 	if flush != expectedFlush {
 		t.Errorf("GetFlushTimeout: expected %v, got %v", expectedFlush, flush)
 	}
 
 	// Test GetMetricsInterval
 	metrics := cfg.GetMetricsInterval()
-	expectedMetrics := time.Duration(cfg.Tracker.MetricsIntervalSeconds) * time.Second
+	expectedMetrics := cfg.Tracker.MetricsInterval
 	if metrics != expectedMetrics {
 		t.Errorf("GetMetricsInterval: expected %v, got %v", expectedMetrics, metrics)
 	}
 
 	// Test GetReadTimeout
 	read := cfg.GetReadTimeout()
-	expectedRead := time.Duration(cfg.Tracker.ReadTimeoutMs) * time.Millisecond
+	expectedRead := cfg.Tracker.ReadTimeout
 	if read != expectedRead {
 		t.Errorf("GetReadTimeout: expected %v, got %v", expectedRead, read)
 	}
 
+	// Test GetChaosFile
+	if file := cfg.GetChaosFile(); file != "" {
+		t.Errorf("GetChaosFile: expected no file while chaos is disabled, got %q", file)
+	}
+	cfg.Chaos.Enabled = true
+	if file := cfg.GetChaosFile(); file != cfg.Chaos.StateFile {
+		t.Errorf("GetChaosFile: expected %q, got %q", cfg.Chaos.StateFile, file)
+	}
+
 	// Test GetInitialRetryDelay
 	retryDelay := cfg.GetInitialRetryDelay()
-	expectedRetryDelay := time.Duration(cfg.Retry.InitialDelayMs) * time.Millisecond
+	expectedRetryDelay := cfg.Retry.InitialDelay
 	if retryDelay != expectedRetryDelay {
 		t.Errorf("GetInitialRetryDelay: expected %v, got %v", expectedRetryDelay, retryDelay)
 	}
 
 	// Test GetMaxRetryDelay
 	maxRetry := cfg.GetMaxRetryDelay()
-	expectedMaxRetry := time.Duration(cfg.Retry.MaxDelayMs) * time.Millisecond
+	expectedMaxRetry := cfg.Retry.MaxDelay
 	if maxRetry != expectedMaxRetry {
 		t.Errorf("GetMaxRetryDelay: expected %v, got %v", expectedMaxRetry, maxRetry)
 	}
@@ -280,8 +292,8 @@ func TestAllEnvVariables(t *testing.T) {
 	if cfg.Kafka.ConsumerGroup != "test-group" {
 		t.Errorf("KAFKA_CONSUMER_GROUP: expected 'test-group', got %s", cfg.Kafka.ConsumerGroup)
 	}
-	if cfg.Producer.IntervalMs != 1000 {
-		t.Errorf("PRODUCER_INTERVAL_MS: expected 1000, got %d", cfg.Producer.IntervalMs)
+	if cfg.Producer.Interval != 1000*time.Millisecond {
+		t.Errorf("PRODUCER_INTERVAL_MS: expected 1000, got %s", cfg.Producer.Interval)
 	}
 	if cfg.Tracker.LogFile != "custom.log" {
 		t.Errorf("TRACKER_LOG_FILE: expected 'custom.log', got %s", cfg.Tracker.LogFile)
@@ -325,3 +337,259 @@ func TestDLQEnabledVariations(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadQualityConfigFromYAML(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+
+	yamlContent := `
+monitor:
+  quality:
+    success_weight: 70
+    throughput_buckets:
+      - { min_mps: 1.0, score: 10 }
+    latency_weight: 20
+`
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	q := cfg.Monitor.Quality
+	if q.SuccessWeight != 70 {
+		t.Errorf("Expected success weight 70, got %f", q.SuccessWeight)
+	}
+	if len(q.ThroughputBuckets) != 1 || q.ThroughputBuckets[0].MinMPS != 1.0 {
+		t.Errorf("Expected a single 1.0 msg/s bucket, got %+v", q.ThroughputBuckets)
+	}
+	if q.LatencyWeight != 20 {
+		t.Errorf("Expected latency weight 20, got %f", q.LatencyWeight)
+	}
+	// Unspecified values keep their defaults
+	if q.ErrorWeight != 20 || q.LatencyTarget != 100*time.Millisecond {
+		t.Errorf("Expected default error weight and latency target, got %+v", q)
+	}
+}
+
+func writeProfilesConfig(t *testing.T) string {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+app:
+  env: dev
+kafka:
+  broker: "localhost:9092"
+  topic: "orders"
+producer:
+  interval_ms: 2000
+profiles:
+  dev:
+    producer:
+      interval_ms: 500
+  prod:
+    kafka:
+      broker: "kafka-prod:9092"
+    dlq:
+      topic: "orders-dlq-prod"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return configPath
+}
+
+func TestLoadProfileFromFileEnv(t *testing.T) {
+	cfg, err := Load(writeProfilesConfig(t))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.App.Env != "dev" {
+		t.Errorf("Expected env 'dev', got %s", cfg.App.Env)
+	}
+	if cfg.Producer.Interval != 500*time.Millisecond {
+		t.Errorf("Expected dev profile interval 500, got %s", cfg.Producer.Interval)
+	}
+	if cfg.Kafka.Brokers.String() != "localhost:9092" {
+		t.Errorf("Expected base broker, got %s", cfg.Kafka.Brokers)
+	}
+}
+
+func TestLoadProfileSelectedByAppEnv(t *testing.T) {
+	os.Setenv("APP_ENV", "prod")
+	defer os.Unsetenv("APP_ENV")
+
+	cfg, err := Load(writeProfilesConfig(t))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Kafka.Brokers.String() != "kafka-prod:9092" {
+		t.Errorf("Expected prod broker, got %s", cfg.Kafka.Brokers)
+	}
+	if cfg.DLQ.Topic != "orders-dlq-prod" {
+		t.Errorf("Expected prod DLQ topic, got %s", cfg.DLQ.Topic)
+	}
+	// Settings absent from the profile keep their base value
+	if cfg.Producer.Interval != 2000*time.Millisecond {
+		t.Errorf("Expected base interval 2000, got %s", cfg.Producer.Interval)
+	}
+	if cfg.Kafka.Topic != "orders" {
+		t.Errorf("Expected base topic, got %s", cfg.Kafka.Topic)
+	}
+}
+
+func TestLoadUnknownProfile(t *testing.T) {
+	os.Setenv("APP_ENV", "staging")
+	defer os.Unsetenv("APP_ENV")
+
+	_, err := Load(writeProfilesConfig(t))
+	if err == nil || !strings.Contains(err.Error(), `unknown profile "staging"`) {
+		t.Errorf("Expected unknown profile error, got %v", err)
+	}
+}
+
+func TestEnvNames(t *testing.T) {
+	tests := []struct {
+		path string
+		env  string
+	}{
+		{"app.env", "APP_ENV"},
+		{"kafka.consumer_group", "KAFKA_CONSUMER_GROUP"},
+		{"producer.flush_timeout", "PRODUCER_FLUSH_TIMEOUT"},
+		{"retry.initial_delay", "RETRY_INITIAL_DELAY"},
+		{"monitor.quality.success_weight", "MONITOR_QUALITY_SUCCESS_WEIGHT"},
+	}
+	for _, tt := range tests {
+		if got := envName(tt.path); got != tt.env {
+			t.Errorf("envName(%q) = %s, expected %s", tt.path, got, tt.env)
+		}
+	}
+}
+
+func TestEnvCoversEveryField(t *testing.T) {
+	for _, f := range fields(DefaultConfig()) {
+		var raw, want string
+		if f.isList() {
+			continue
+		}
+		switch f.Value.Kind() {
+		case reflect.String, reflect.Slice:
+			raw, want = "env-value", "env-value"
+		case reflect.Bool:
+			raw, want = "1", fmt.Sprint(!f.Value.Bool())
+			if f.Value.Bool() {
+				raw = "false"
+			}
+		case reflect.Float64:
+			raw, want = "4.5", "4.5"
+		default:
+			raw, want = "4242", "4242"
+		}
+		if f.Value.Type() == durationType {
+			raw, want = "42s", "42s"
+		}
+
+		t.Run(f.Path, func(t *testing.T) {
+			t.Setenv(envName(f.Path), raw)
+			cfg, _, err := load("", "", nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := settingValues(cfg)[f.Path]; got != want {
+				t.Errorf("%s=%s: expected %s, got %s", envName(f.Path), raw, want, got)
+			}
+		})
+	}
+}
+
+func TestEnvAliases(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "warn")
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.App.LogLevel != "warn" {
+		t.Errorf("LOG_LEVEL: expected 'warn', got %s", cfg.App.LogLevel)
+	}
+
+	t.Setenv("APP_LOG_LEVEL", "debug")
+	cfg, err = Load("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.App.LogLevel != "debug" {
+		t.Errorf("APP_LOG_LEVEL should take precedence over LOG_LEVEL, got %s", cfg.App.LogLevel)
+	}
+}
+
+func TestEnvFileValues(t *testing.T) {
+	secret := filepath.Join(t.TempDir(), "topic")
+	if err := os.WriteFile(secret, []byte("mounted-topic\n"), 0600); err != nil {
+		t.Fatalf("Failed to write secret file: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"file", map[string]string{"KAFKA_TOPIC_FILE": secret}, "mounted-topic"},
+		{"value wins", map[string]string{"KAFKA_TOPIC": "plain", "KAFKA_TOPIC_FILE": secret}, "plain"},
+		{"empty file variable", map[string]string{"KAFKA_TOPIC_FILE": ""}, "orders"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			cfg, err := Load("")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if cfg.Kafka.Topic != tt.want {
+				t.Errorf("Expected topic %s, got %s", tt.want, cfg.Kafka.Topic)
+			}
+		})
+	}
+}
+
+func TestEnvFileMissing(t *testing.T) {
+	t.Setenv("KAFKA_TOPIC_FILE", filepath.Join(t.TempDir(), "missing"))
+	_, err := Load("")
+	if err == nil {
+		t.Fatal("Expected error for unreadable KAFKA_TOPIC_FILE")
+	}
+	if !strings.Contains(err.Error(), "KAFKA_TOPIC_FILE") {
+		t.Errorf("Error should name the variable, got %v", err)
+	}
+}
+
+func TestEnvPrefix(t *testing.T) {
+	SetEnvPrefix("pubsub")
+	t.Cleanup(func() { SetEnvPrefix("") })
+
+	t.Setenv("KAFKA_TOPIC", "legacy-topic")
+	t.Setenv("KAFKA_CONSUMER_GROUP", "legacy-group")
+	t.Setenv("PUBSUB_KAFKA_TOPIC", "prefixed-topic")
+	t.Setenv("PUBSUB_APP_LOG_LEVEL", "debug")
+	t.Setenv("LOG_LEVEL", "warn")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Kafka.Topic != "prefixed-topic" {
+		t.Errorf("Prefixed variable should take precedence, got %s", cfg.Kafka.Topic)
+	}
+	if cfg.Kafka.ConsumerGroup != "legacy-group" {
+		t.Errorf("Unprefixed variable should remain accepted, got %s", cfg.Kafka.ConsumerGroup)
+	}
+	if cfg.App.LogLevel != "debug" {
+		t.Errorf("Prefixed variable should take precedence over the alias, got %s", cfg.App.LogLevel)
+	}
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RemoteSource fetches a configuration document (YAML, with optional profiles)
+// from a key-value store shared by several hosts.
+type RemoteSource interface {
+	// Fetch returns the document and its version (changed on every update).
+	Fetch(ctx context.Context) ([]byte, string, error)
+}
+
+// consulSource reads a key of the Consul KV HTTP API.
+type consulSource struct {
+	client *http.Client // HTTP client.
+	url    string       // URL of the key (http://host:8500/v1/kv/<key>).
+}
+
+// Fetch returns the raw value of the key and its modify index.
+//
+// Parameters:
+//   - ctx: Cancels the request.
+//
+// Returns:
+//   - []byte: The document.
+//   - string: The X-Consul-Index of the key.
+//   - error: An error if Consul is unreachable or the key is missing.
+func (s *consulSource) Fetch(ctx context.Context) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url+"?raw", nil)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("consul unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("consul answered %s for %s", resp.Status, s.url)
+	}
+	data, err := io.ReadAll(resp.Body)
+	return data, resp.Header.Get("X-Consul-Index"), err
+}
+
+// etcdSource reads a key through the etcd v3 JSON gateway.
+type etcdSource struct {
+	client *http.Client // HTTP client.
+	url    string       // URL of the range endpoint (http://host:2379/v3/kv/range).
+	key    string       // Key holding the document.
+}
+
+// etcdRangeResponse is the part of the /v3/kv/range answer used by etcdSource.
+type etcdRangeResponse struct {
+	Kvs []struct {
+		Value       string `json:"value"`        // Base64-encoded value.
+		ModRevision string `json:"mod_revision"` // Revision of the last modification.
+	} `json:"kvs"`
+}
+
+// Fetch returns the value of the key and its modification revision.
+//
+// Parameters:
+//   - ctx: Cancels the request.
+//
+// Returns:
+//   - []byte: The document.
+//   - string: The mod_revision of the key.
+//   - error: An error if etcd is unreachable or the key is missing.
+func (s *etcdSource) Fetch(ctx context.Context) ([]byte, string, error) {
+	body, _ := json.Marshal(map[string]string{"key": base64.StdEncoding.EncodeToString([]byte(s.key))})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("etcd unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("etcd answered %s", resp.Status)
+	}
+
+	var rr etcdRangeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rr); err != nil {
+		return nil, "", fmt.Errorf("invalid etcd response: %w", err)
+	}
+	if len(rr.Kvs) == 0 {
+		return nil, "", fmt.Errorf("etcd key %s not found", s.key)
+	}
+	data, err := base64.StdEncoding.DecodeString(rr.Kvs[0].Value)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid etcd value: %w", err)
+	}
+	return data, rr.Kvs[0].ModRevision, nil
+}
+
+// ParseRemote returns the remote source designated by a URL:
+// consul://host:8500/<key> or etcd://host:2379/<key> (consul+https and
+// etcd+https for TLS endpoints).
+//
+// Parameters:
+//   - raw: The source URL.
+//
+// Returns:
+//   - RemoteSource: The source.
+//   - error: An error if the URL is malformed or the backend unknown.
+func ParseRemote(raw string) (RemoteSource, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("remote config: %w", err)
+	}
+	key := strings.TrimPrefix(u.Path, "/")
+	if u.Host == "" || key == "" {
+		return nil, fmt.Errorf("remote config: %q must be backend://host:port/key", raw)
+	}
+	backend, scheme, _ := strings.Cut(u.Scheme, "+")
+	if scheme == "" {
+		scheme = "http"
+	}
+	client := &http.Client{Timeout: RemoteFetchTimeout}
+	switch backend {
+	case "consul":
+		return &consulSource{client: client, url: scheme + "://" + u.Host + "/v1/kv/" + key}, nil
+	case "etcd":
+		return &etcdSource{client: client, url: scheme + "://" + u.Host + "/v3/kv/range", key: key}, nil
+	}
+	return nil, fmt.Errorf("remote config: unknown backend %q (consul or etcd)", u.Scheme)
+}
+
+// WatchRemote polls a remote source and calls onChange with every new version
+// of the document, until the context is cancelled. Fetch errors are retried at
+// the next poll.
+//
+// Parameters:
+//   - ctx: Stops the watch.
+//   - src: The remote source.
+//   - version: The version already loaded ("" if none).
+//   - interval: The delay between two polls.
+//   - onChange: Receives the new document.
+func WatchRemote(ctx context.Context, src RemoteSource, version string, interval time.Duration, onChange func([]byte)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		data, v, err := src.Fetch(ctx)
+		if err != nil || v == version {
+			continue
+		}
+		version = v
+		onChange(data)
+	}
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeKV serves a document through the Consul KV and etcd v3 gateway APIs.
+type fakeKV struct {
+	mu      sync.Mutex
+	doc     string
+	version int
+}
+
+func (kv *fakeKV) set(doc string) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	kv.doc = doc
+	kv.version++
+}
+
+func (kv *fakeKV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	switch {
+	case r.URL.Path == "/v1/kv/pubsub/config":
+		w.Header().Set("X-Consul-Index", strconv.Itoa(kv.version))
+		w.Write([]byte(kv.doc))
+	case r.URL.Path == "/v3/kv/range":
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		if key, _ := base64.StdEncoding.DecodeString(req["key"]); string(key) != "pubsub/config" {
+			w.Write([]byte(`{}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"kvs": []map[string]string{{
+				"value":        base64.StdEncoding.EncodeToString([]byte(kv.doc)),
+				"mod_revision": strconv.Itoa(kv.version),
+			}},
+		})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestRemoteSources(t *testing.T) {
+	kv := &fakeKV{}
+	kv.set("kafka:\n  topic: remote-topic\n")
+	server := httptest.NewServer(kv)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	for _, backend := range []string{"consul", "etcd"} {
+		t.Run(backend, func(t *testing.T) {
+			src, err := ParseRemote(backend + "://" + host + "/pubsub/config")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			data, version, err := src.Fetch(context.Background())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(data) != kv.doc || version == "" {
+				t.Errorf("Expected the document with a version, got %q (version %q)", data, version)
+			}
+
+			missing, _ := ParseRemote(backend + "://" + host + "/pubsub/missing")
+			if _, _, err := missing.Fetch(context.Background()); err == nil {
+				t.Error("Expected an error for a missing key")
+			}
+		})
+	}
+}
+
+func TestParseRemoteRejectsInvalidURL(t *testing.T) {
+	for _, raw := range []string{"zookeeper://host:2181/key", "consul://host:8500", "etcd:///key"} {
+		if _, err := ParseRemote(raw); err == nil {
+			t.Errorf("%s: expected an error", raw)
+		}
+	}
+}
+
+func TestFlagsLoadRemoteOverriddenLocally(t *testing.T) {
+	kv := &fakeKV{}
+	kv.set("kafka:\n  topic: remote-topic\n  consumer_group: remote-group\nretry:\n  max_attempts: 7\n")
+	server := httptest.NewServer(kv)
+	defer server.Close()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("kafka:\n  topic: file-topic\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KAFKA_CONSUMER_GROUP", "env-group")
+
+	fs, f := newTestFlagSet()
+	remote := "consul://" + strings.TrimPrefix(server.URL, "http://") + "/pubsub/config"
+	if err := fs.Parse([]string{"--config", configPath, "--remote-config", remote}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := f.Load()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Kafka.Topic != "file-topic" || cfg.Kafka.ConsumerGroup != "env-group" || cfg.Retry.MaxAttempts != 7 {
+		t.Errorf("Expected file and env over remote, got topic=%s group=%s attempts=%d",
+			cfg.Kafka.Topic, cfg.Kafka.ConsumerGroup, cfg.Retry.MaxAttempts)
+	}
+	if src := f.Sources().Of("retry.max_attempts"); src != SourceRemote {
+		t.Errorf("Expected source %s, got %s", SourceRemote, src)
+	}
+}
+
+func TestWatchRemote(t *testing.T) {
+	kv := &fakeKV{}
+	kv.set("retry:\n  max_attempts: 4\n")
+	server := httptest.NewServer(kv)
+	defer server.Close()
+	src, _ := ParseRemote("etcd://" + strings.TrimPrefix(server.URL, "http://") + "/pubsub/config")
+	_, version, _ := src.Fetch(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	changes := make(chan string, 1)
+	go WatchRemote(ctx, src, version, 10*time.Millisecond, func(data []byte) {
+		changes <- string(data)
+		cancel()
+	})
+	kv.set("retry:\n  max_attempts: 5\n")
+
+	select {
+	case doc := <-changes:
+		if !strings.Contains(doc, "max_attempts: 5") {
+			t.Errorf("Expected the updated document, got %q", doc)
+		}
+	case <-ctx.Done():
+		t.Fatal("Expected a change notification")
+	}
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// structSource is the declaration of the configuration structures, whose
+// comments document the settings of the generated sample.
+//
+//go:embed loader.go
+var structSource string
+
+// structDocs returns the documentation of the configuration structures, parsed
+// from their Go declaration so that the sample follows the code.
+//
+// Returns:
+//   - map[string]string: The comments keyed by type name (section comments) and
+//     by "Type.Field" (setting comments).
+func structDocs() map[string]string {
+	docs := make(map[string]string)
+	file, err := parser.ParseFile(token.NewFileSet(), "loader.go", structSource, parser.ParseComments)
+	if err != nil {
+		return docs
+	}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			docs[ts.Name.Name] = strings.TrimSpace(gen.Doc.Text())
+			for _, f := range st.Fields.List {
+				for _, name := range f.Names {
+					docs[ts.Name.Name+"."+name.Name] = strings.TrimSpace(f.Comment.Text())
+				}
+			}
+		}
+	}
+	return docs
+}
+
+// WriteSample writes a configuration file holding every setting with its
+// default value, commented with the documentation of its field and the
+// environment variable bound to it.
+//
+// Parameters:
+//   - w: The destination.
+//
+// Returns:
+//   - error: An error if encoding or writing fails.
+func WriteSample(w io.Writer) error {
+	var root yaml.Node
+	if err := root.Encode(DefaultConfig()); err != nil {
+		return err
+	}
+	annotate(&root, reflect.TypeOf(AppConfig{}), "", structDocs())
+	root.Content = append([]*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"},
+		{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(ConfigVersion), LineComment: "Configuration layout version (older layouts are migrated at load time)."},
+	}, root.Content...)
+	root.HeadComment = "PubSub configuration, generated from the defaults (--init-config).\n" +
+		"Precedence: flags > environment variables > this file > defaults.\n" +
+		"yaml-language-server: $schema=./config.schema.json"
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&root); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// InitSample writes the sample configuration to a new file.
+//
+// Parameters:
+//   - path: The file to create.
+//
+// Returns:
+//   - error: An error if the file already exists or cannot be written.
+func InitSample(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("%s already exists, remove it first", path)
+		}
+		return err
+	}
+	if err := WriteSample(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// annotate attaches the documentation of a structure to its YAML mapping:
+// sections get a head comment, settings a line comment with their variable.
+//
+// Parameters:
+//   - node: The mapping node of the structure.
+//   - t: The structure type.
+//   - prefix: The YAML path of the structure ("" for the root).
+//   - docs: The documentation (see structDocs).
+func annotate(node *yaml.Node, t reflect.Type, prefix string, docs map[string]string) {
+	byName := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		byName[strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]] = t.Field(i)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		sf, ok := byName[key.Value]
+		if !ok {
+			continue
+		}
+		path := key.Value
+		if prefix != "" {
+			path = prefix + "." + key.Value
+		}
+		doc := docs[t.Name()+"."+sf.Name]
+		if sf.Type.Kind() == reflect.Struct {
+			key.HeadComment = doc
+			if prefix == "" {
+				key.HeadComment = "\n" + doc // blank line between top-level sections
+			}
+			annotate(value, sf.Type, path, docs)
+			continue
+		}
+		comment := envName(path)
+		if sf.Type == brokerListType {
+			// written as the comma-separated form accepted by KAFKA_BROKER
+			var brokers BrokerList
+			if err := value.Decode(&brokers); err == nil {
+				*value = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: brokers.String()}
+			}
+		} else if sf.Type.Kind() == reflect.Slice {
+			comment = "file only"
+		}
+		if doc != "" {
+			comment = doc + " (" + comment + ")"
+		}
+		if value.Kind == yaml.SequenceNode && len(value.Content) > 0 {
+			key.HeadComment = comment // a line comment would follow the last item
+			continue
+		}
+		value.LineComment = comment
+	}
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSampleLoadsAsDefaults(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSample(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Sample should load: %v", err)
+	}
+	if !reflect.DeepEqual(settingValues(cfg), settingValues(DefaultConfig())) {
+		t.Error("Sample should hold the default values")
+	}
+}
+
+func TestSampleDocumentsEverySetting(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSample(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sample := buf.String()
+	for _, f := range fields(DefaultConfig()) {
+		if f.isList() {
+			continue
+		}
+		if !strings.Contains(sample, "("+envName(f.Path)+")") {
+			t.Errorf("%s: missing from the sample comments", f.Path)
+		}
+	}
+	if !strings.Contains(sample, "# Main Kafka topic. (KAFKA_TOPIC)") {
+		t.Error("Settings should be commented with their field documentation")
+	}
+}
+
+func TestInitSampleKeepsExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := InitSample(path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := InitSample(path); err == nil {
+		t.Error("Expected an error when the file already exists")
+	}
+}
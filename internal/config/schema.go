@@ -0,0 +1,181 @@
+package config
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// schemaDraft is the JSON Schema dialect of the generated schema.
+const schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// durationPattern matches the duration strings accepted by time.ParseDuration.
+const durationPattern = `^-?([0-9]+(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+$|^0$`
+
+// schemaEnums lists the allowed values of the enumerated settings (see Validate).
+var schemaEnums = map[string][]string{
+	"app.locale":                            {"", "fr", "en"},
+	"kafka.client.acks":                     {"", "0", "1", "-1", "all"},
+	"kafka.client.compression":              {"", "none", "gzip", "snappy", "lz4", "zstd"},
+	"kafka.client.auto_offset_reset":        {"", "earliest", "latest"},
+	"security.protocol":                     {"", "plaintext", "ssl", "sasl_plaintext", "sasl_ssl"},
+	"security.sasl_mechanism":               {"", "PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512"},
+	"schema_registry.subject_name_strategy": {"topic_name", "record_name", "topic_record_name"},
+	"producer.partition_key":                {"", "customer", "order", "region"},
+	"monitor.time_source":                   {"", "event", "local"},
+	"retry.backoff":                         {"", BackoffConstant, BackoffExponential, BackoffDecorrelatedJitter, BackoffFibonacci},
+}
+
+// Schema returns the JSON Schema of the configuration file, derived from the
+// YAML tags of AppConfig. Every setting carries its type and default value,
+// enumerated settings their allowed values, and unknown keys are rejected so
+// that editors flag typos. Deprecated keys (see deprecateKey) are marked as
+// such. Profiles reuse the schema of the whole file.
+//
+// Returns:
+//   - map[string]interface{}: The schema document.
+func Schema() map[string]interface{} {
+	root := valueSchema(reflect.ValueOf(DefaultConfig()).Elem(), "", true)
+	root["$schema"] = schemaDraft
+	root["title"] = "PubSub configuration"
+	props := root["properties"].(map[string]interface{})
+	for old, setting := range deprecatedKeys {
+		parts := strings.Split(old, ".")
+		section := props
+		for _, name := range parts[:len(parts)-1] {
+			parent, _ := section[name].(map[string]interface{})
+			section, _ = parent["properties"].(map[string]interface{})
+		}
+		if section != nil {
+			section[parts[len(parts)-1]] = map[string]interface{}{
+				"deprecated":  true,
+				"description": "Deprecated: use " + setting.Path + ".",
+			}
+		}
+	}
+	props["$schema"] = map[string]interface{}{"type": "string"}
+	props["version"] = map[string]interface{}{
+		"type":        "integer",
+		"minimum":     1,
+		"maximum":     ConfigVersion,
+		"description": "Layout version of this file (absent = 1); older layouts are migrated at load time.",
+	}
+	props["include"] = map[string]interface{}{
+		"type":        "array",
+		"description": "Fragment files or glob patterns, relative to this file, merged over it in order (before " + ConfDirName + "/).",
+		"items":       map[string]interface{}{"type": "string"},
+	}
+	props["profiles"] = map[string]interface{}{
+		"type":                 "object",
+		"description":          "Overlays keyed by environment name, selected by APP_ENV, --app.env or app.env.",
+		"additionalProperties": map[string]interface{}{"$ref": "#"},
+	}
+	return root
+}
+
+// WriteSchema writes the indented JSON Schema of the configuration file.
+//
+// Parameters:
+//   - w: The destination.
+//
+// Returns:
+//   - error: An error if encoding or writing fails.
+func WriteSchema(w io.Writer) error {
+	data, err := json.MarshalIndent(Schema(), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// valueSchema returns the schema of a setting or of a section.
+//
+// Parameters:
+//   - v: The default value.
+//   - path: The setting path ("" for the root).
+//   - defaults: Whether v holds default values (false for list items).
+//
+// Returns:
+//   - map[string]interface{}: The schema.
+func valueSchema(v reflect.Value, path string, defaults bool) map[string]interface{} {
+	if v.Type() == brokerListType {
+		return map[string]interface{}{
+			"description": "Bootstrap brokers (host:port), as a list or a comma-separated string.",
+			"oneOf": []interface{}{
+				map[string]interface{}{"type": "string"},
+				map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			},
+			"default": v.Interface().(BrokerList).String(),
+		}
+	}
+
+	s := make(map[string]interface{})
+	switch v.Kind() {
+	case reflect.Struct:
+		props := make(map[string]interface{})
+		for i := 0; i < v.NumField(); i++ {
+			name := strings.Split(v.Type().Field(i).Tag.Get("yaml"), ",")[0]
+			if name == "" || name == "-" {
+				continue
+			}
+			sub := name
+			if path != "" {
+				sub = path + "." + name
+			}
+			props[name] = valueSchema(v.Field(i), sub, defaults)
+		}
+		s["type"] = "object"
+		s["properties"] = props
+		s["additionalProperties"] = false
+		return s
+	case reflect.Slice:
+		s["type"] = "array"
+		s["items"] = valueSchema(reflect.New(v.Type().Elem()).Elem(), path, false)
+		if v.Len() == 0 {
+			return s
+		}
+	case reflect.String:
+		s["type"] = "string"
+	case reflect.Bool:
+		s["type"] = "boolean"
+	case reflect.Int, reflect.Int64:
+		s["type"] = "integer"
+		if v.Type() == durationType {
+			s["type"] = "string"
+			s["pattern"] = durationPattern
+		}
+	case reflect.Float64:
+		s["type"] = "number"
+	}
+	if defaults {
+		s["default"] = plainValue(v)
+	}
+	if allowed, ok := schemaEnums[path]; ok {
+		s["enum"] = allowed
+	}
+	return s
+}
+
+// plainValue converts a default value to maps, lists and scalars keyed by the
+// YAML names, as written in the configuration file.
+//
+// Parameters:
+//   - v: The value.
+//
+// Returns:
+//   - interface{}: The converted value.
+func plainValue(v reflect.Value) interface{} {
+	data, err := yaml.Marshal(v.Interface())
+	if err != nil {
+		return nil
+	}
+	var out interface{}
+	if err := yaml.Unmarshal(data, &out); err != nil {
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSchemaDescribesEverySetting(t *testing.T) {
+	schema := Schema()
+	for _, f := range fields(DefaultConfig()) {
+		node := schema
+		for _, name := range strings.Split(f.Path, ".") {
+			props, ok := node["properties"].(map[string]interface{})
+			if !ok {
+				t.Fatalf("%s: missing properties", f.Path)
+			}
+			if node, ok = props[name].(map[string]interface{}); !ok {
+				t.Fatalf("%s: missing from the schema", f.Path)
+			}
+		}
+		empty := f.Value.Kind() == reflect.Slice && f.Value.Len() == 0
+		if _, ok := node["default"]; !ok && !empty {
+			t.Errorf("%s: missing default", f.Path)
+		}
+	}
+}
+
+func TestSchemaEnums(t *testing.T) {
+	props := Schema()["properties"].(map[string]interface{})
+	client := props["kafka"].(map[string]interface{})["properties"].(map[string]interface{})["client"].(map[string]interface{})
+	acks := client["properties"].(map[string]interface{})["acks"].(map[string]interface{})
+	if enum, ok := acks["enum"].([]string); !ok || len(enum) == 0 {
+		t.Errorf("Expected kafka.client.acks to be enumerated, got %v", acks)
+	}
+}
+
+// TestSchemaFileUpToDate guards the committed schema used by editors (make schema).
+func TestSchemaFileUpToDate(t *testing.T) {
+	committed, err := os.ReadFile("../../config.schema.json")
+	if err != nil {
+		t.Fatalf("Cannot read config.schema.json: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteSchema(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !bytes.Equal(committed, buf.Bytes()) {
+		t.Error("config.schema.json is stale, run make schema")
+	}
+	if !json.Valid(committed) {
+		t.Error("config.schema.json is not valid JSON")
+	}
+}
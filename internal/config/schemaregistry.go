@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Check verifies that the schema registry is reachable and accepts the
+// configured credentials, by listing its subjects.
+//
+// Parameters:
+//   - client: The HTTP client (its timeout bounds the check).
+//
+// Returns:
+//   - error: An error if the registry is unreachable or rejects the request,
+//     nil if it answers 2xx or no URL is configured.
+func (r SchemaRegistryConfig) Check(client *http.Client) error {
+	if r.URL == "" {
+		return nil
+	}
+	req, err := http.NewRequest(http.MethodGet, strings.TrimRight(r.URL, "/")+"/subjects", nil)
+	if err != nil {
+		return fmt.Errorf("schema_registry.url: %w", err)
+	}
+	if r.Username != "" {
+		req.SetBasicAuth(r.Username, r.Password)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("schema registry unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("schema registry rejected the credentials: %s", resp.Status)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return fmt.Errorf("schema registry answered %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSchemaRegistryCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/subjects" {
+			http.NotFound(w, r)
+			return
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "key" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`["orders-value"]`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		cfg     SchemaRegistryConfig
+		wantErr string
+	}{
+		{"disabled", SchemaRegistryConfig{}, ""},
+		{"reachable", SchemaRegistryConfig{URL: server.URL + "/", Username: "key", Password: "secret"}, ""},
+		{"bad credentials", SchemaRegistryConfig{URL: server.URL, Username: "key", Password: "wrong"}, "rejected the credentials"},
+		{"unreachable", SchemaRegistryConfig{URL: "http://127.0.0.1:1"}, "unreachable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Check(server.Client())
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSchemaRegistryDefaults(t *testing.T) {
+	r := DefaultConfig().SchemaRegistry
+	if r.URL != "" {
+		t.Errorf("Registry should be disabled by default, got %s", r.URL)
+	}
+	if r.SubjectNameStrategy != "topic_name" {
+		t.Errorf("Expected topic_name strategy, got %s", r.SubjectNameStrategy)
+	}
+	if r.CacheCapacity != 1000 {
+		t.Errorf("Expected cache capacity 1000, got %d", r.CacheCapacity)
+	}
+}
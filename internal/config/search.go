@@ -0,0 +1,39 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// SearchPaths returns the configuration files looked up when no --config flag
+// is given, in order: ./config.yaml, $XDG_CONFIG_HOME/pubsub/config.yaml
+// ($HOME/.config when XDG_CONFIG_HOME is unset) and /etc/pubsub/config.yaml.
+//
+// Returns:
+//   - []string: The candidate paths.
+func SearchPaths() []string {
+	paths := []string{ConfigFileName}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, ".config")
+		}
+	}
+	if dir != "" {
+		paths = append(paths, filepath.Join(dir, ConfigDirName, ConfigFileName))
+	}
+	return append(paths, filepath.Join(ConfigSystemDir, ConfigFileName))
+}
+
+// FindConfigFile returns the first existing file of the search path.
+//
+// Returns:
+//   - string: The file path ("" if none exists, to use the defaults).
+func FindConfigFile() string {
+	for _, path := range SearchPaths() {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSearchPaths(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+
+	want := []string{"config.yaml", filepath.Join("/xdg", "pubsub", "config.yaml"), filepath.Join("/etc/pubsub", "config.yaml")}
+	got := SearchPaths()
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Path %d: expected %s, got %s", i, want[i], got[i])
+		}
+	}
+}
+
+func TestFlagsLoadSearchesXDGConfig(t *testing.T) {
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", xdg)
+	t.Chdir(t.TempDir())
+	path := filepath.Join(xdg, "pubsub", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("kafka:\n  topic: xdg-topic\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	f := RegisterFlags(fs)
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := f.Load()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Kafka.Topic != "xdg-topic" {
+		t.Errorf("Expected topic from the XDG file, got %s", cfg.Kafka.Topic)
+	}
+	if f.File() != path {
+		t.Errorf("Expected loaded file %s, got %q", path, f.File())
+	}
+}
+
+func TestFlagsLoadWithoutConfigFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	f := RegisterFlags(fs)
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Load(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if f.File() != "" && f.File() != filepath.Join("/etc/pubsub", "config.yaml") {
+		t.Errorf("Expected no file loaded, got %s", f.File())
+	}
+}
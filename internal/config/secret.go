@@ -0,0 +1,167 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// EncryptedPrefix marks an encrypted string value in the configuration, e.g.,
+// sasl_password: "enc:3q2+7w...". The rest of the value is the base64 encoding
+// of the AES-GCM nonce followed by the ciphertext.
+const EncryptedPrefix = "enc:"
+
+// KeyEnv names the environment variable holding the base64 AES key (16, 24 or
+// 32 bytes) decrypting the enc: values. KeyEnv+"_FILE" may instead name a
+// file holding it (e.g., a mounted secret or a key fetched from a KMS).
+const KeyEnv = "CONFIG_KEY"
+
+// errNoKey is returned when an encrypted value is found without key.
+var errNoKey = errors.New("encrypted value requires the " + KeyEnv + " (or " + KeyEnv + "_FILE) key")
+
+// encryptionKey reads the key of the encrypted values.
+//
+// Returns:
+//   - []byte: The AES key.
+//   - error: errNoKey if no key is set, or an error if it is unreadable or invalid.
+func encryptionKey() ([]byte, error) {
+	encoded := os.Getenv(KeyEnv)
+	if encoded == "" {
+		file := os.Getenv(KeyEnv + "_FILE")
+		if file == "" {
+			return nil, errNoKey
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s_FILE: %w", KeyEnv, err)
+		}
+		encoded = string(data)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid base64 key: %w", KeyEnv, err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+		return key, nil
+	}
+	return nil, fmt.Errorf("%s: key must be 16, 24 or 32 bytes (got %d)", KeyEnv, len(key))
+}
+
+// newGCM creates the AES-GCM cipher of a key.
+//
+// Parameters:
+//   - key: The AES key.
+//
+// Returns:
+//   - cipher.AEAD: The cipher.
+//   - error: An error if the key size is invalid.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptValue encrypts a value with the CONFIG_KEY key, for use in the
+// configuration file (see --encrypt).
+//
+// Parameters:
+//   - plaintext: The value to encrypt.
+//
+// Returns:
+//   - string: The encrypted value, with the enc: prefix.
+//   - error: An error if the key is missing or invalid.
+func EncryptValue(plaintext string) (string, error) {
+	key, err := encryptionKey()
+	if err != nil {
+		return "", err
+	}
+	return encryptValue(key, plaintext)
+}
+
+// encryptValue encrypts a value with a key.
+//
+// Parameters:
+//   - key: The AES key.
+//   - plaintext: The value to encrypt.
+//
+// Returns:
+//   - string: The encrypted value, with the enc: prefix.
+//   - error: An error if the key is invalid.
+func encryptValue(key []byte, plaintext string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return EncryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptValue decrypts an enc: value.
+//
+// Parameters:
+//   - key: The AES key.
+//   - value: The encrypted value, with the enc: prefix.
+//
+// Returns:
+//   - string: The plaintext.
+//   - error: An error if the value is malformed or was encrypted with another key.
+func decryptValue(key []byte, value string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, EncryptedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted value: %w", err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("invalid encrypted value: too short")
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", errors.New("cannot decrypt value (wrong key or altered value)")
+	}
+	return string(plaintext), nil
+}
+
+// decryptSecrets replaces the enc: string settings of a configuration by their
+// plaintext. The key is only required when an encrypted value is present.
+//
+// Parameters:
+//   - cfg: The configuration to update.
+//
+// Returns:
+//   - error: An error naming the setting that cannot be decrypted.
+func decryptSecrets(cfg *AppConfig) error {
+	var key []byte
+	for _, f := range fields(cfg) {
+		if f.Value.Kind() != reflect.String || !strings.HasPrefix(f.Value.String(), EncryptedPrefix) {
+			continue
+		}
+		if key == nil {
+			var err error
+			if key, err = encryptionKey(); err != nil {
+				return fmt.Errorf("%s: %w", f.Path, err)
+			}
+		}
+		plaintext, err := decryptValue(key, f.Value.String())
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Path, err)
+		}
+		f.Value.SetString(plaintext)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testKey is a base64 AES-256 key.
+var testKey = base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+
+func TestEncryptedValues(t *testing.T) {
+	t.Setenv(KeyEnv, testKey)
+	secret, err := EncryptValue("s3cr3t")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(secret, EncryptedPrefix) || strings.Contains(secret, "s3cr3t") {
+		t.Fatalf("Unexpected encrypted value %s", secret)
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := "security:\n  protocol: sasl_plaintext\n  sasl_mechanism: PLAIN\n  sasl_username: tracker\n  sasl_password: \"" + secret + "\"\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Security.SASLPassword != "s3cr3t" {
+		t.Errorf("Expected decrypted password, got %q", cfg.Security.SASLPassword)
+	}
+	if cfg.Security.SASLUsername != "tracker" {
+		t.Errorf("Plain values should be kept, got %q", cfg.Security.SASLUsername)
+	}
+}
+
+func TestEncryptedValueFromEnvAndKeyFile(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "config.key")
+	if err := os.WriteFile(keyFile, []byte(testKey+"\n"), 0600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+	t.Setenv(KeyEnv+"_FILE", keyFile)
+	secret, err := EncryptValue("registry-pass")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Setenv("SCHEMA_REGISTRY_USERNAME", "registry")
+	t.Setenv("SCHEMA_REGISTRY_PASSWORD", secret)
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.SchemaRegistry.Password != "registry-pass" {
+		t.Errorf("Expected decrypted password, got %q", cfg.SchemaRegistry.Password)
+	}
+}
+
+func TestEncryptedValueErrors(t *testing.T) {
+	t.Setenv(KeyEnv, testKey)
+	secret, err := EncryptValue("s3cr3t")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Setenv("SECURITY_SASL_PASSWORD", secret)
+
+	t.Setenv(KeyEnv, "")
+	if _, err := Load(""); err == nil || !strings.Contains(err.Error(), "security.sasl_password") {
+		t.Errorf("Expected missing key error naming the setting, got %v", err)
+	}
+
+	t.Setenv(KeyEnv, base64.StdEncoding.EncodeToString([]byte("another-key-0123another-key-0123")))
+	if _, err := Load(""); err == nil || !strings.Contains(err.Error(), "wrong key") {
+		t.Errorf("Expected wrong key error, got %v", err)
+	}
+
+	t.Setenv(KeyEnv, base64.StdEncoding.EncodeToString([]byte("short")))
+	if _, err := Load(""); err == nil || !strings.Contains(err.Error(), "16, 24 or 32 bytes") {
+		t.Errorf("Expected key size error, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+// Properties returns the librdkafka properties of the security settings
+// (e.g., security.protocol, sasl.username), to merge into the kafka.ConfigMap
+// of every Kafka client. Unset settings are omitted.
+//
+// Returns:
+//   - map[string]string: The properties keyed by librdkafka name.
+func (s SecurityConfig) Properties() map[string]string {
+	props := make(map[string]string)
+	setString(props, "security.protocol", s.Protocol)
+	setString(props, "ssl.ca.location", s.CAFile)
+	setString(props, "ssl.certificate.location", s.CertFile)
+	setString(props, "ssl.key.location", s.KeyFile)
+	setString(props, "ssl.ca.pem", s.CAPEM)
+	setString(props, "ssl.certificate.pem", s.CertPEM)
+	setString(props, "ssl.key.pem", s.KeyPEM)
+	setString(props, "ssl.key.password", s.KeyPassword)
+	setString(props, "sasl.mechanisms", s.SASLMechanism)
+	setString(props, "sasl.username", s.SASLUsername)
+	setString(props, "sasl.password", s.SASLPassword)
+	return props
+}
+
+// ProducerProperties returns the librdkafka properties of a producer: the
+// producer tuning settings and the security settings.
+//
+// Returns:
+//   - map[string]string: The properties keyed by librdkafka name.
+func (c *AppConfig) ProducerProperties() map[string]string {
+	return mergeProperties(c.Kafka.Client.ProducerProperties(), c.Security.Properties())
+}
+
+// ConsumerProperties returns the librdkafka properties of a consumer: the
+// consumer tuning settings and the security settings.
+//
+// Returns:
+//   - map[string]string: The properties keyed by librdkafka name.
+func (c *AppConfig) ConsumerProperties() map[string]string {
+	return mergeProperties(c.Kafka.Client.ConsumerProperties(), c.Security.Properties())
+}
+
+// mergeProperties copies the properties of src into dst.
+//
+// Parameters:
+//   - dst: The properties to complete.
+//   - src: The properties to add.
+//
+// Returns:
+//   - map[string]string: dst.
+func mergeProperties(dst, src map[string]string) map[string]string {
+	for name, value := range src {
+		dst[name] = value
+	}
+	return dst
+}
@@ -0,0 +1,485 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseTOML parses a TOML document into nested maps, so that it can be decoded
+// with the same struct tags as YAML. The supported subset covers configuration
+// files: tables ([a.b]), arrays of tables ([[a.b]]), dotted keys, basic and
+// literal strings, integers, floats, booleans, arrays (possibly multi-line)
+// and inline tables. Dates and multi-line strings are not supported.
+//
+// Parameters:
+//   - data: The TOML document.
+//
+// Returns:
+//   - map[string]interface{}: The document as nested maps.
+//   - error: An error naming the offending line if the document is invalid.
+func parseTOML(data []byte) (map[string]interface{}, error) {
+	root := make(map[string]interface{})
+	current := root
+
+	lines := strings.Split(string(data), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(stripTOMLComment(lines[i]))
+		if line == "" {
+			continue
+		}
+		// Multi-line arrays and inline tables continue until brackets balance
+		for !tomlBalanced(line) && i+1 < len(lines) {
+			i++
+			line += " " + strings.TrimSpace(stripTOMLComment(lines[i]))
+		}
+
+		var err error
+		switch {
+		case strings.HasPrefix(line, "[["):
+			if !strings.HasSuffix(line, "]]") {
+				return nil, fmt.Errorf("line %d: unterminated array of tables header", lineNo)
+			}
+			current, err = appendTOMLTable(root, strings.TrimSpace(line[2:len(line)-2]))
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated table header", lineNo)
+			}
+			current, err = tomlTable(root, strings.TrimSpace(line[1:len(line)-1]))
+		default:
+			err = setTOMLKeyValue(current, line)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+	}
+	return root, nil
+}
+
+// stripTOMLComment removes a trailing comment outside strings.
+//
+// Parameters:
+//   - line: The raw line.
+//
+// Returns:
+//   - string: The line without comment.
+func stripTOMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// tomlBalanced reports whether the brackets and braces of a line are closed.
+//
+// Parameters:
+//   - line: The line without comment.
+//
+// Returns:
+//   - bool: True if every bracket outside strings is closed.
+func tomlBalanced(line string) bool {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+// splitTOMLKey splits a dotted key into its parts, unquoting quoted parts.
+//
+// Parameters:
+//   - key: The dotted key (e.g., monitor.quality or "a.b".c).
+//
+// Returns:
+//   - []string: The key parts.
+//   - error: An error if a part is empty.
+func splitTOMLKey(key string) ([]string, error) {
+	var parts []string
+	var b strings.Builder
+	var quote byte
+	flush := func() error {
+		part := strings.TrimSpace(b.String())
+		b.Reset()
+		if len(part) >= 2 && (part[0] == '"' || part[0] == '\'') && part[len(part)-1] == part[0] {
+			part = part[1 : len(part)-1]
+		} else if part == "" {
+			return fmt.Errorf("invalid key %q", key)
+		}
+		parts = append(parts, part)
+		return nil
+	}
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '.':
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		b.WriteByte(c)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+// descendTOML returns the table at the given path below a table, creating
+// missing tables. For an array of tables, its last element is used.
+//
+// Parameters:
+//   - table: The starting table.
+//   - parts: The key parts.
+//
+// Returns:
+//   - map[string]interface{}: The table.
+//   - error: An error if a key is already a value.
+func descendTOML(table map[string]interface{}, parts []string) (map[string]interface{}, error) {
+	for _, part := range parts {
+		switch v := table[part].(type) {
+		case nil:
+			next := make(map[string]interface{})
+			table[part] = next
+			table = next
+		case map[string]interface{}:
+			table = v
+		case []interface{}:
+			last, ok := lastTOMLTable(v)
+			if !ok {
+				return nil, fmt.Errorf("key %s is not a table", part)
+			}
+			table = last
+		default:
+			return nil, fmt.Errorf("key %s is not a table", part)
+		}
+	}
+	return table, nil
+}
+
+// lastTOMLTable returns the last element of an array of tables.
+//
+// Parameters:
+//   - arr: The array.
+//
+// Returns:
+//   - map[string]interface{}: The last table.
+//   - bool: False if the array is empty or not an array of tables.
+func lastTOMLTable(arr []interface{}) (map[string]interface{}, bool) {
+	if len(arr) == 0 {
+		return nil, false
+	}
+	last, ok := arr[len(arr)-1].(map[string]interface{})
+	return last, ok
+}
+
+// tomlTable handles a [table] header.
+//
+// Parameters:
+//   - root: The document root.
+//   - header: The header key.
+//
+// Returns:
+//   - map[string]interface{}: The table receiving the following keys.
+//   - error: An error if the header is invalid.
+func tomlTable(root map[string]interface{}, header string) (map[string]interface{}, error) {
+	parts, err := splitTOMLKey(header)
+	if err != nil {
+		return nil, err
+	}
+	return descendTOML(root, parts)
+}
+
+// appendTOMLTable handles an [[array.of.tables]] header.
+//
+// Parameters:
+//   - root: The document root.
+//   - header: The header key.
+//
+// Returns:
+//   - map[string]interface{}: The new table receiving the following keys.
+//   - error: An error if the header is invalid.
+func appendTOMLTable(root map[string]interface{}, header string) (map[string]interface{}, error) {
+	parts, err := splitTOMLKey(header)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := descendTOML(root, parts[:len(parts)-1])
+	if err != nil {
+		return nil, err
+	}
+	name := parts[len(parts)-1]
+	table := make(map[string]interface{})
+	switch v := parent[name].(type) {
+	case nil:
+		parent[name] = []interface{}{table}
+	case []interface{}:
+		parent[name] = append(v, table)
+	default:
+		return nil, fmt.Errorf("key %s is not an array of tables", name)
+	}
+	return table, nil
+}
+
+// setTOMLKeyValue handles a key = value line.
+//
+// Parameters:
+//   - table: The current table.
+//   - line: The line.
+//
+// Returns:
+//   - error: An error if the line is invalid.
+func setTOMLKeyValue(table map[string]interface{}, line string) error {
+	eq := strings.Index(line, "=")
+	if eq < 0 {
+		return fmt.Errorf("expected key = value, got %q", line)
+	}
+	parts, err := splitTOMLKey(line[:eq])
+	if err != nil {
+		return err
+	}
+	target, err := descendTOML(table, parts[:len(parts)-1])
+	if err != nil {
+		return err
+	}
+
+	raw := strings.TrimSpace(line[eq+1:])
+	value, n, err := parseTOMLValue(raw)
+	if err != nil {
+		return err
+	}
+	if rest := strings.TrimSpace(raw[n:]); rest != "" {
+		return fmt.Errorf("unexpected %q after value", rest)
+	}
+	target[parts[len(parts)-1]] = value
+	return nil
+}
+
+// parseTOMLValue parses the value at the beginning of s.
+//
+// Parameters:
+//   - s: The text starting with a value.
+//
+// Returns:
+//   - interface{}: The value (string, int64, float64, bool, []interface{} or map[string]interface{}).
+//   - int: The number of bytes consumed.
+//   - error: An error if the value is invalid or unsupported.
+func parseTOMLValue(s string) (interface{}, int, error) {
+	if s == "" {
+		return nil, 0, fmt.Errorf("missing value")
+	}
+	switch s[0] {
+	case '"':
+		return parseTOMLBasicString(s)
+	case '\'':
+		end := strings.IndexByte(s[1:], '\'')
+		if end < 0 {
+			return nil, 0, fmt.Errorf("unterminated string")
+		}
+		return s[1 : end+1], end + 2, nil
+	case '[':
+		return parseTOMLArray(s)
+	case '{':
+		return parseTOMLInlineTable(s)
+	}
+
+	end := strings.IndexAny(s, ",]} \t")
+	if end < 0 {
+		end = len(s)
+	}
+	token := s[:end]
+	switch token {
+	case "true":
+		return true, end, nil
+	case "false":
+		return false, end, nil
+	}
+	clean := strings.ReplaceAll(token, "_", "")
+	if i, err := strconv.ParseInt(clean, 10, 64); err == nil {
+		return i, end, nil
+	}
+	if f, err := strconv.ParseFloat(clean, 64); err == nil {
+		return f, end, nil
+	}
+	return nil, 0, fmt.Errorf("unsupported value %q", token)
+}
+
+// parseTOMLBasicString parses a double-quoted string with escapes.
+//
+// Parameters:
+//   - s: The text starting with '"'.
+//
+// Returns:
+//   - interface{}: The unescaped string.
+//   - int: The number of bytes consumed.
+//   - error: An error if the string is unterminated or an escape is invalid.
+func parseTOMLBasicString(s string) (interface{}, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+1 >= len(s) {
+				return nil, 0, fmt.Errorf("unterminated string")
+			}
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case '"', '\\':
+				b.WriteByte(s[i])
+			case 'u':
+				if i+4 >= len(s) {
+					return nil, 0, fmt.Errorf("invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(s[i+1:i+5], 16, 32)
+				if err != nil {
+					return nil, 0, fmt.Errorf("invalid unicode escape")
+				}
+				b.WriteRune(rune(r))
+				i += 4
+			default:
+				return nil, 0, fmt.Errorf("invalid escape \\%c", s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return nil, 0, fmt.Errorf("unterminated string")
+}
+
+// parseTOMLArray parses an array, allowing a trailing comma.
+//
+// Parameters:
+//   - s: The text starting with '['.
+//
+// Returns:
+//   - interface{}: The array elements.
+//   - int: The number of bytes consumed.
+//   - error: An error if the array is invalid.
+func parseTOMLArray(s string) (interface{}, int, error) {
+	arr := []interface{}{}
+	i := 1
+	for {
+		i += leadingSpace(s[i:])
+		if i >= len(s) {
+			return nil, 0, fmt.Errorf("unterminated array")
+		}
+		if s[i] == ']' {
+			return arr, i + 1, nil
+		}
+		value, n, err := parseTOMLValue(s[i:])
+		if err != nil {
+			return nil, 0, err
+		}
+		arr = append(arr, value)
+		i += n
+		i += leadingSpace(s[i:])
+		if i < len(s) && s[i] == ',' {
+			i++
+		} else if i >= len(s) || s[i] != ']' {
+			return nil, 0, fmt.Errorf("expected , or ] in array")
+		}
+	}
+}
+
+// parseTOMLInlineTable parses an inline table ({ k = v, ... }).
+//
+// Parameters:
+//   - s: The text starting with '{'.
+//
+// Returns:
+//   - interface{}: The table.
+//   - int: The number of bytes consumed.
+//   - error: An error if the table is invalid.
+func parseTOMLInlineTable(s string) (interface{}, int, error) {
+	table := make(map[string]interface{})
+	i := 1
+	for {
+		i += leadingSpace(s[i:])
+		if i >= len(s) {
+			return nil, 0, fmt.Errorf("unterminated inline table")
+		}
+		if s[i] == '}' {
+			return table, i + 1, nil
+		}
+		eq := strings.IndexByte(s[i:], '=')
+		if eq < 0 {
+			return nil, 0, fmt.Errorf("expected key = value in inline table")
+		}
+		parts, err := splitTOMLKey(s[i : i+eq])
+		if err != nil {
+			return nil, 0, err
+		}
+		target, err := descendTOML(table, parts[:len(parts)-1])
+		if err != nil {
+			return nil, 0, err
+		}
+		i += eq + 1
+		i += leadingSpace(s[i:])
+		value, n, err := parseTOMLValue(s[i:])
+		if err != nil {
+			return nil, 0, err
+		}
+		target[parts[len(parts)-1]] = value
+		i += n
+		i += leadingSpace(s[i:])
+		if i < len(s) && s[i] == ',' {
+			i++
+		} else if i >= len(s) || s[i] != '}' {
+			return nil, 0, fmt.Errorf("expected , or } in inline table")
+		}
+	}
+}
+
+// leadingSpace returns the number of leading spaces and tabs.
+//
+// Parameters:
+//   - s: The text.
+//
+// Returns:
+//   - int: The number of blank bytes.
+func leadingSpace(s string) int {
+	return len(s) - len(strings.TrimLeft(s, " \t"))
+}
@@ -0,0 +1,322 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// FieldError describes an invalid configuration value.
+type FieldError struct {
+	Field   string // Qualified YAML path of the field (e.g., retry.multiplier).
+	Message string // Description of the constraint that is not met.
+}
+
+// Error returns the field-qualified message.
+//
+// Returns:
+//   - string: The message (e.g., "retry.multiplier: must be >= 1 (got 0.5)").
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError aggregates every invalid value found in a configuration,
+// so that all problems can be fixed at once.
+type ValidationError struct {
+	Errors []FieldError // The invalid fields, in declaration order.
+}
+
+// Error returns one line per invalid field.
+//
+// Returns:
+//   - string: The aggregated message.
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		lines[i] = "  - " + fe.Error()
+	}
+	return fmt.Sprintf("invalid configuration (%d error(s)):\n%s", len(e.Errors), strings.Join(lines, "\n"))
+}
+
+// validator collects field errors.
+type validator struct {
+	errors []FieldError
+}
+
+// check records an error for the field if the condition does not hold.
+//
+// Parameters:
+//   - ok: The condition that must hold.
+//   - field: The qualified field path.
+//   - format: The message format.
+//   - args: The message arguments.
+func (v *validator) check(ok bool, field, format string, args ...interface{}) {
+	if !ok {
+		v.errors = append(v.errors, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+}
+
+// Validate rejects impossible configuration values: empty mandatory settings,
+// negative or zero intervals, a retry multiplier below 1, or a DLQ enabled
+// without topic.
+//
+// Returns:
+//   - error: A *ValidationError listing every invalid field, or nil.
+func (c *AppConfig) Validate() error {
+	v := &validator{}
+
+	v.check(len(c.Kafka.Brokers) > 0, "kafka.broker", "must not be empty")
+	for i, broker := range c.Kafka.Brokers {
+		field := fmt.Sprintf("kafka.broker[%d]", i)
+		switch {
+		case broker == MemoryBroker:
+			v.check(len(c.Kafka.Brokers) == 1, field, "%s must be the only broker", MemoryBroker)
+		case isAMQPURL(broker):
+			v.check(len(c.Kafka.Brokers) == 1, field, "an AMQP URL must be the only broker")
+			_, err := AMQPAddress(broker)
+			v.check(err == nil, field, "invalid AMQP URL: %v", err)
+		default:
+			v.check(validHostPort(broker), field, "must be host:port (got %q)", broker)
+		}
+	}
+	v.check(c.Kafka.Brokers.IsKafka() || !c.Retry.Topics.Enabled, "retry.topics.enabled", "requires Kafka brokers (not supported with %s)", c.Kafka.Brokers.String())
+	v.check(c.Kafka.Topic != "", "kafka.topic", "must not be empty")
+	v.check(c.Kafka.ConsumerGroup != "", "kafka.consumer_group", "must not be empty")
+	c.Kafka.Client.validate(v)
+	c.Security.validate(v)
+	c.SchemaRegistry.validate(v)
+
+	v.check(c.Producer.Interval > 0, "producer.interval", "must be > 0 (got %s)", c.Producer.Interval)
+	v.check(c.Producer.FlushTimeout >= 0, "producer.flush_timeout", "must be >= 0 (got %s)", c.Producer.FlushTimeout)
+	v.check(c.Producer.PartitionKey == "" || c.Producer.PartitionKey == "customer" || c.Producer.PartitionKey == "order" || c.Producer.PartitionKey == "region",
+		"producer.partition_key", `must be "customer", "order" or "region" (got %q)`, c.Producer.PartitionKey)
+
+	v.check(c.Tracker.LogFile != "", "tracker.log_file", "must not be empty")
+	v.check(c.Tracker.EventsFile != "", "tracker.events_file", "must not be empty")
+	v.check(c.Tracker.MetricsInterval > 0, "tracker.metrics_interval", "must be > 0 (got %s)", c.Tracker.MetricsInterval)
+	v.check(c.Tracker.ReadTimeout > 0, "tracker.read_timeout", "must be > 0 (got %s)", c.Tracker.ReadTimeout)
+	v.check(c.Tracker.MaxConsecutiveErrors > 0, "tracker.max_consecutive_errors", "must be > 0 (got %d)", c.Tracker.MaxConsecutiveErrors)
+
+	c.Monitor.validate(v)
+
+	v.check(c.Retry.MaxAttempts >= 1, "retry.max_attempts", "must be >= 1 (got %d)", c.Retry.MaxAttempts)
+	v.check(c.Retry.InitialDelay >= 0, "retry.initial_delay", "must be >= 0 (got %s)", c.Retry.InitialDelay)
+	v.check(c.Retry.MaxDelay >= c.Retry.InitialDelay, "retry.max_delay", "must be >= retry.initial_delay (got %s < %s)", c.Retry.MaxDelay, c.Retry.InitialDelay)
+	v.check(c.Retry.Multiplier >= 1, "retry.multiplier", "must be >= 1 (got %g)", c.Retry.Multiplier)
+	v.check(oneOf(c.Retry.Backoff, "", BackoffConstant, BackoffExponential, BackoffDecorrelatedJitter, BackoffFibonacci),
+		"retry.backoff", `must be "constant", "exponential", "decorrelated_jitter" or "fibonacci" (got %q)`, c.Retry.Backoff)
+	v.check(c.Retry.Jitter >= 0 && c.Retry.Jitter <= 1, "retry.jitter", "must be between 0 and 1 (got %g)", c.Retry.Jitter)
+	if cb := c.Retry.CircuitBreaker; cb.Enabled {
+		v.check(cb.FailureThreshold >= 1, "retry.circuit_breaker.failure_threshold", "must be >= 1 (got %d)", cb.FailureThreshold)
+		v.check(cb.ResetTimeout > 0, "retry.circuit_breaker.reset_timeout", "must be > 0 (got %s)", cb.ResetTimeout)
+		v.check(cb.SuccessThreshold >= 1, "retry.circuit_breaker.success_threshold", "must be >= 1 (got %d)", cb.SuccessThreshold)
+	}
+	if rt := c.Retry.Topics; rt.Enabled {
+		v.check(len(rt.Tiers) > 0, "retry.topics.tiers", "must not be empty when retry.topics.enabled is true")
+		for i, tier := range rt.Tiers {
+			v.check(tier > 0, "retry.topics.tiers", "must be > 0 (got %s at index %d)", tier, i)
+		}
+	}
+
+	v.check(!c.DLQ.Enabled || c.DLQ.Topic != "", "dlq.topic", "must not be empty when dlq.enabled is true")
+	v.check(c.DLQ.ConfirmTimeout >= 0, "dlq.confirm_timeout", "must be >= 0 (got %s)", c.DLQ.ConfirmTimeout)
+	v.check(c.DLQ.Reprocessor.ParkTopic != "" && c.DLQ.Reprocessor.ParkTopic != c.DLQ.Topic, "dlq.reprocessor.park_topic", "must not be empty nor equal to dlq.topic (got %q)", c.DLQ.Reprocessor.ParkTopic)
+	v.check(c.DLQ.Reprocessor.CoolDown >= 0, "dlq.reprocessor.cool_down", "must be >= 0 (got %s)", c.DLQ.Reprocessor.CoolDown)
+	v.check(c.DLQ.Reprocessor.MaxReprocess >= 0, "dlq.reprocessor.max_reprocess", "must be >= 0 (got %d)", c.DLQ.Reprocessor.MaxReprocess)
+	v.check(c.DLQ.FallbackFile == "" || c.DLQ.RecoveryInterval > 0, "dlq.recovery_interval", "must be > 0 when dlq.fallback_file is set (got %s)", c.DLQ.RecoveryInterval)
+
+	v.check(c.Chaos.StateFile != "", "chaos.state_file", "must not be empty")
+
+	v.check(validHostPort(c.Gateway.Addr), "gateway.addr", "must be host:port (got %q)", c.Gateway.Addr)
+	v.check(c.Gateway.RateLimit >= 0, "gateway.rate_limit", "must be >= 0 (got %g)", c.Gateway.RateLimit)
+	v.check(c.Gateway.RateLimit == 0 || c.Gateway.Burst >= 1, "gateway.burst", "must be >= 1 when gateway.rate_limit is set (got %d)", c.Gateway.Burst)
+	v.check(c.Gateway.MaxBodyBytes > 0, "gateway.max_body_bytes", "must be > 0 (got %d)", c.Gateway.MaxBodyBytes)
+
+	v.check(c.Metrics.Addr == "" || validHostPort(c.Metrics.Addr), "metrics.addr", "must be host:port (got %q)", c.Metrics.Addr)
+	if c.Metrics.OTLPEndpoint != "" {
+		u, err := url.Parse(c.Metrics.OTLPEndpoint)
+		v.check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+			"metrics.otlp_endpoint", "must be an http(s) URL (got %q)", c.Metrics.OTLPEndpoint)
+	}
+	v.check(c.Metrics.OTLPInterval > 0, "metrics.otlp_interval", "must be > 0 (got %s)", c.Metrics.OTLPInterval)
+
+	v.check(c.Debug.Addr == "" || validHostPort(c.Debug.Addr), "debug.addr", "must be host:port (got %q)", c.Debug.Addr)
+	v.check(c.Debug.SnapshotDir != "", "debug.snapshot_dir", "must not be empty")
+
+	if len(v.errors) > 0 {
+		return &ValidationError{Errors: v.errors}
+	}
+	return nil
+}
+
+// validate checks the Kafka client tuning settings.
+//
+// Parameters:
+//   - v: The validator collecting errors.
+func (k *KafkaClientConfig) validate(v *validator) {
+	v.check(oneOf(k.Acks, "", "0", "1", "-1", "all"), "kafka.client.acks", `must be "0", "1" or "all" (got %q)`, k.Acks)
+	v.check(k.Linger >= 0, "kafka.client.linger", "must be >= 0 (got %s)", k.Linger)
+	v.check(k.BatchSize >= 0, "kafka.client.batch_size", "must be >= 0 (got %d)", k.BatchSize)
+	v.check(oneOf(k.Compression, "", "none", "gzip", "snappy", "lz4", "zstd"),
+		"kafka.client.compression", `must be none, gzip, snappy, lz4 or zstd (got %q)`, k.Compression)
+	v.check(!k.EnableIdempotence || oneOf(k.Acks, "", "-1", "all"),
+		"kafka.client.enable_idempotence", `requires acks "all" (got %q)`, k.Acks)
+	v.check(k.FetchMinBytes >= 0, "kafka.client.fetch_min_bytes", "must be >= 0 (got %d)", k.FetchMinBytes)
+	v.check(k.SessionTimeout >= 0, "kafka.client.session_timeout", "must be >= 0 (got %s)", k.SessionTimeout)
+	v.check(k.HeartbeatInterval >= 0, "kafka.client.heartbeat_interval", "must be >= 0 (got %s)", k.HeartbeatInterval)
+	v.check(k.SessionTimeout == 0 || k.HeartbeatInterval < k.SessionTimeout,
+		"kafka.client.heartbeat_interval", "must be < kafka.client.session_timeout (got %s >= %s)", k.HeartbeatInterval, k.SessionTimeout)
+	v.check(k.MaxPollInterval >= 0, "kafka.client.max_poll_interval", "must be >= 0 (got %s)", k.MaxPollInterval)
+	v.check(oneOf(k.AutoOffsetReset, "", "earliest", "latest"),
+		"kafka.client.auto_offset_reset", `must be "earliest" or "latest" (got %q)`, k.AutoOffsetReset)
+}
+
+// validate checks the TLS/SASL security settings.
+//
+// Parameters:
+//   - v: The validator collecting errors.
+func (s *SecurityConfig) validate(v *validator) {
+	v.check(oneOf(s.Protocol, "", "plaintext", "ssl", "sasl_plaintext", "sasl_ssl"),
+		"security.protocol", "must be plaintext, ssl, sasl_plaintext or sasl_ssl (got %q)", s.Protocol)
+
+	tls := s.Protocol == "ssl" || s.Protocol == "sasl_ssl"
+	hasTLS := s.CAFile != "" || s.CertFile != "" || s.KeyFile != "" || s.CAPEM != "" || s.CertPEM != "" || s.KeyPEM != ""
+	v.check(tls || !hasTLS, "security.protocol", "must be ssl or sasl_ssl when certificates are configured (got %q)", s.Protocol)
+	v.check(s.CAFile == "" || s.CAPEM == "", "security.ca_pem", "must not be set together with security.ca_file")
+	v.check(s.CertFile == "" || s.CertPEM == "", "security.cert_pem", "must not be set together with security.cert_file")
+	v.check(s.KeyFile == "" || s.KeyPEM == "", "security.key_pem", "must not be set together with security.key_file")
+	hasCert := s.CertFile != "" || s.CertPEM != ""
+	hasKey := s.KeyFile != "" || s.KeyPEM != ""
+	v.check(hasCert == hasKey, "security.cert_file", "client certificate and private key must be set together")
+	v.check(s.KeyPassword == "" || hasKey, "security.key_password", "requires a private key")
+
+	sasl := s.Protocol == "sasl_plaintext" || s.Protocol == "sasl_ssl"
+	if sasl {
+		v.check(oneOf(s.SASLMechanism, "PLAIN", "SCRAM-SHA-256", "SCRAM-SHA-512"),
+			"security.sasl_mechanism", "must be PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512 (got %q)", s.SASLMechanism)
+		v.check(s.SASLUsername != "", "security.sasl_username", "must not be empty when security.protocol is %s", s.Protocol)
+		v.check(s.SASLPassword != "", "security.sasl_password", "must not be empty when security.protocol is %s", s.Protocol)
+	} else {
+		v.check(s.SASLMechanism == "" && s.SASLUsername == "" && s.SASLPassword == "",
+			"security.protocol", "must be sasl_plaintext or sasl_ssl when SASL credentials are configured (got %q)", s.Protocol)
+	}
+}
+
+// validate checks the schema registry settings.
+//
+// Parameters:
+//   - v: The validator collecting errors.
+func (r *SchemaRegistryConfig) validate(v *validator) {
+	if r.URL != "" {
+		u, err := url.Parse(r.URL)
+		v.check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+			"schema_registry.url", "must be an http(s) URL (got %q)", r.URL)
+	}
+	v.check(r.Password == "" || r.Username != "", "schema_registry.username", "must not be empty when schema_registry.password is set")
+	v.check(oneOf(r.SubjectNameStrategy, "topic_name", "record_name", "topic_record_name"),
+		"schema_registry.subject_name_strategy", "must be topic_name, record_name or topic_record_name (got %q)", r.SubjectNameStrategy)
+	v.check(r.CacheCapacity > 0, "schema_registry.cache_capacity", "must be > 0 (got %d)", r.CacheCapacity)
+}
+
+// validHostPort reports whether an address is a host:port pair with a valid port.
+//
+// Parameters:
+//   - addr: The address to check.
+//
+// Returns:
+//   - bool: True if addr is host:port with a port in 1-65535.
+func validHostPort(addr string) bool {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || host == "" {
+		return false
+	}
+	p, err := strconv.Atoi(port)
+	return err == nil && p > 0 && p <= 65535
+}
+
+// AMQPAddress returns the host:port address of an AMQP URL, with the default
+// port of its scheme.
+//
+// Parameters:
+//   - broker: The AMQP URL.
+//
+// Returns:
+//   - string: The host:port address.
+//   - error: An error if the URL cannot be parsed or has no host.
+func AMQPAddress(broker string) (string, error) {
+	u, err := url.Parse(broker)
+	if err != nil {
+		return "", err
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("missing host in %q", u.Redacted())
+	}
+	if u.Port() != "" {
+		return u.Host, nil
+	}
+	port := "5672"
+	if u.Scheme == "amqps" {
+		port = "5671"
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
+
+// oneOf reports whether a value belongs to a set of allowed values.
+//
+// Parameters:
+//   - value: The value to check.
+//   - allowed: The allowed values.
+//
+// Returns:
+//   - bool: True if value is allowed.
+func oneOf(value string, allowed ...string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the monitor settings.
+//
+// Parameters:
+//   - v: The validator collecting errors.
+func (m *MonitorConfig) validate(v *validator) {
+	v.check(m.MaxRecentLogs > 0, "monitor.max_recent_logs", "must be > 0 (got %d)", m.MaxRecentLogs)
+	v.check(m.MaxRecentEvents > 0, "monitor.max_recent_events", "must be > 0 (got %d)", m.MaxRecentEvents)
+	v.check(m.UIUpdateInterval > 0, "monitor.ui_update_interval", "must be > 0 (got %s)", m.UIUpdateInterval)
+	v.check(m.TimeSource == "" || m.TimeSource == "event" || m.TimeSource == "local",
+		"monitor.time_source", `must be "event" or "local" (got %q)`, m.TimeSource)
+	v.check(m.MaxClockSkew >= 0, "monitor.max_clock_skew", "must be >= 0 (got %s)", m.MaxClockSkew)
+	v.check(m.ProbeInterval > 0, "monitor.probe_interval", "must be > 0 (got %s)", m.ProbeInterval)
+
+	for i, p := range m.Processes {
+		field := fmt.Sprintf("monitor.processes[%d]", i)
+		v.check(p.Name != "", field+".name", "must not be empty")
+		v.check(p.PIDFile != "" || p.HealthURL != "", field, "pid_file or health_url is required")
+	}
+
+	t := m.Thresholds
+	v.check(t.SuccessRateGood <= t.SuccessRateExcellent, "monitor.thresholds.success_rate_good",
+		"must be <= success_rate_excellent (got %g > %g)", t.SuccessRateGood, t.SuccessRateExcellent)
+	v.check(t.ThroughputLow >= 0 && t.ThroughputLow <= t.ThroughputNormal, "monitor.thresholds.throughput_low",
+		"must be between 0 and throughput_normal (got %g)", t.ThroughputLow)
+	v.check(t.ErrorCritical >= 0 && t.ErrorCritical <= t.ErrorWarning, "monitor.thresholds.error_critical",
+		"must be between 0 and error_warning (got %s)", t.ErrorCritical)
+	v.check(t.QualityMedium <= t.QualityGood && t.QualityGood <= t.QualityExcellent, "monitor.thresholds.quality_good",
+		"quality thresholds must satisfy medium <= good <= excellent (got %g, %g, %g)", t.QualityMedium, t.QualityGood, t.QualityExcellent)
+	v.check(t.MaxLogRowLength > len(t.TruncateSuffix), "monitor.thresholds.max_log_row_length",
+		"must be > the truncate_suffix length (got %d)", t.MaxLogRowLength)
+	v.check(t.MaxEventRowLength > len(t.TruncateSuffix), "monitor.thresholds.max_event_row_length",
+		"must be > the truncate_suffix length (got %d)", t.MaxEventRowLength)
+
+	q := m.Quality
+	v.check(q.SuccessWeight >= 0, "monitor.quality.success_weight", "must be >= 0 (got %g)", q.SuccessWeight)
+	v.check(q.ErrorWeight >= 0, "monitor.quality.error_weight", "must be >= 0 (got %g)", q.ErrorWeight)
+	v.check(q.ErrorPenalty >= 0, "monitor.quality.error_penalty", "must be >= 0 (got %g)", q.ErrorPenalty)
+	v.check(q.LatencyWeight >= 0, "monitor.quality.latency_weight", "must be >= 0 (got %g)", q.LatencyWeight)
+	v.check(q.LagWeight >= 0, "monitor.quality.lag_weight", "must be >= 0 (got %g)", q.LagWeight)
+}
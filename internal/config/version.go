@@ -0,0 +1,247 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigVersion is the version of the current configuration layout, written
+// in the version: key of the configuration documents. A document without
+// version: uses layout 1. Older layouts are migrated at load time:
+//   - 1: delays as *_ms and *_seconds integers (e.g., producer.interval_ms: 2000).
+//   - 2: delays as duration strings (e.g., producer.interval: 2s).
+const ConfigVersion = 2
+
+// migration upgrades the layout of a document from a version to the next.
+type migration struct {
+	From  int                                     // Version upgraded to From+1.
+	Apply func(root *yaml.Node) ([]string, error) // Rewrites a document or profile mapping, returning the changes.
+}
+
+// migrations lists the layout upgrades, in version order (one per version
+// below ConfigVersion).
+var migrations = []migration{
+	{From: 1, Apply: migrateDurations},
+}
+
+// Migration reports a change applied to a configuration document written for
+// an older layout. The document itself is left unchanged.
+type Migration struct {
+	From   int    // Layout version of the document.
+	To     int    // Layout version after the change.
+	Change string // Description (e.g., producer.interval_ms: 750 -> producer.interval: 750ms).
+	Source Source // Where it was found: SourceRemote or SourceFile.
+}
+
+// String formats the report as logfmt key=value pairs.
+//
+// Returns:
+//   - string: The report (e.g., level=info msg=... from=1 to=2 change=... source=file).
+func (m Migration) String() string {
+	return fmt.Sprintf("level=info msg=%q from=%d to=%d change=%q source=%s",
+		"migrated configuration setting", m.From, m.To, m.Change, m.Source)
+}
+
+// migrationHandler receives the migrations applied at load time.
+var migrationHandler = func(m Migration) {
+	fmt.Fprintln(os.Stderr, m)
+}
+
+// SetMigrationHandler replaces the handler receiving the changes applied to
+// the configuration documents of an older layout. By default, they are
+// written to the standard error output (see Migration.String).
+//
+// Parameters:
+//   - handler: The handler (nil to ignore migrations).
+func SetMigrationHandler(handler func(Migration)) {
+	if handler == nil {
+		handler = func(Migration) {}
+	}
+	migrationHandler = handler
+}
+
+// reportMigrations passes each distinct migration to the handler.
+//
+// Parameters:
+//   - applied: The migrations, in load order.
+func reportMigrations(applied []Migration) {
+	seen := make(map[Migration]bool)
+	for _, m := range applied {
+		if !seen[m] {
+			seen[m] = true
+			migrationHandler(m)
+		}
+	}
+}
+
+// migrateVersion upgrades a YAML document (and its profiles) from its layout
+// version to ConfigVersion.
+//
+// Parameters:
+//   - data: The YAML document.
+//
+// Returns:
+//   - []byte: The upgraded document (data itself if nothing changed).
+//   - []Migration: The changes applied (Source unset).
+//   - error: An error if the document cannot be parsed, its version is invalid
+//     or newer than ConfigVersion, or a value cannot be converted.
+func migrateVersion(data []byte) ([]byte, []Migration, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("error parsing YAML: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return data, nil, nil
+	}
+
+	root := doc.Content[0]
+	version := 1
+	if node := mappingValue(root, "version"); node != nil {
+		n, err := strconv.Atoi(node.Value)
+		if err != nil || n < 1 {
+			return nil, nil, fmt.Errorf("version: invalid configuration version %q", node.Value)
+		}
+		version = n
+	}
+	if version > ConfigVersion {
+		return nil, nil, fmt.Errorf("version: configuration version %d is newer than the supported version %d", version, ConfigVersion)
+	}
+
+	var applied []Migration
+	for _, m := range migrations[version-1:] {
+		for _, mapping := range documentMappings(root) {
+			changes, err := m.Apply(mapping)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, change := range changes {
+				applied = append(applied, Migration{From: m.From, To: m.From + 1, Change: change})
+			}
+		}
+	}
+	if len(applied) == 0 {
+		return data, nil, nil
+	}
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error converting configuration: %w", err)
+	}
+	return out, applied, nil
+}
+
+// documentMappings returns the root mapping of a document followed by the
+// mappings of its profiles.
+//
+// Parameters:
+//   - root: The root mapping.
+//
+// Returns:
+//   - []*yaml.Node: The mappings.
+func documentMappings(root *yaml.Node) []*yaml.Node {
+	mappings := []*yaml.Node{root}
+	if profiles := mappingValue(root, "profiles"); profiles != nil && profiles.Kind == yaml.MappingNode {
+		for i := 1; i < len(profiles.Content); i += 2 {
+			if profiles.Content[i].Kind == yaml.MappingNode {
+				mappings = append(mappings, profiles.Content[i])
+			}
+		}
+	}
+	return mappings
+}
+
+// migrateDurations upgrades layout 1 to 2: the *_ms and *_seconds integer
+// delays become duration settings (see legacyDurations).
+//
+// Parameters:
+//   - root: The document or profile mapping.
+//
+// Returns:
+//   - []string: The changes.
+//   - error: An error if a delay is not an integer.
+func migrateDurations(root *yaml.Node) ([]string, error) {
+	olds := make([]string, 0, len(legacyDurations))
+	for old := range legacyDurations {
+		olds = append(olds, old)
+	}
+	sort.Strings(olds)
+
+	var changes []string
+	for _, old := range olds {
+		legacy := legacyDurations[old]
+		change, err := moveSetting(root, old, legacy.Path, legacyValue(legacy.Unit))
+		if err != nil {
+			return nil, err
+		}
+		if change != "" {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// moveSetting moves a key (or section) of a mapping to another path. A value
+// already defined at the destination takes precedence.
+//
+// Parameters:
+//   - root: The mapping.
+//   - from: The dotted path of the former key.
+//   - to: The dotted path of the new key.
+//   - convert: Converts a scalar value (nil keeps it unchanged).
+//
+// Returns:
+//   - string: The change ("" if the former key is absent).
+//   - error: An error if the value cannot be converted.
+func moveSetting(root *yaml.Node, from, to string, convert func(string) (string, error)) (string, error) {
+	value := removeNode(root, from)
+	if value == nil {
+		return "", nil
+	}
+	if value.Kind != yaml.ScalarNode {
+		insertNode(root, to, value)
+		return from + " -> " + to, nil
+	}
+
+	before := value.Value
+	if convert != nil {
+		converted, err := convert(value.Value)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", from, err)
+		}
+		value = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: converted}
+	}
+	insertNode(root, to, value)
+	return fmt.Sprintf("%s: %s -> %s: %s", from, before, to, value.Value), nil
+}
+
+// removeNode removes the key at a path of a mapping.
+//
+// Parameters:
+//   - root: The mapping.
+//   - path: The dotted path.
+//
+// Returns:
+//   - *yaml.Node: The removed value (nil if the path is absent).
+func removeNode(root *yaml.Node, path string) *yaml.Node {
+	parts := strings.Split(path, ".")
+	node := root
+	for _, name := range parts[:len(parts)-1] {
+		if node = mappingValue(node, name); node == nil || node.Kind != yaml.MappingNode {
+			return nil
+		}
+	}
+	name := parts[len(parts)-1]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == name {
+			value := node.Content[i+1]
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return value
+		}
+	}
+	return nil
+}
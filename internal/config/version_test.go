@@ -0,0 +1,120 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func captureMigrations(t *testing.T) *[]Migration {
+	var got []Migration
+	previous := migrationHandler
+	SetMigrationHandler(func(m Migration) { got = append(got, m) })
+	t.Cleanup(func() { migrationHandler = previous })
+	return &got
+}
+
+func TestMigrationsCoverVersions(t *testing.T) {
+	if len(migrations) != ConfigVersion-1 {
+		t.Fatalf("Expected %d migrations, got %d", ConfigVersion-1, len(migrations))
+	}
+	for i, m := range migrations {
+		if m.From != i+1 {
+			t.Errorf("Migration %d: expected From %d, got %d", i, i+1, m.From)
+		}
+	}
+}
+
+func TestMigrateVersion1(t *testing.T) {
+	got := captureMigrations(t)
+	captureDeprecations(t)
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+producer:
+  interval_ms: 750
+tracker:
+  metrics_interval_seconds: 15
+  read_timeout: 2s
+  read_timeout_ms: 100
+profiles:
+  dev:
+    retry:
+      max_delay_ms: 8000
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	cfg, sources, err := load(configPath, "dev", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.Producer.Interval != 750*time.Millisecond {
+		t.Errorf("Expected interval 750ms, got %s", cfg.Producer.Interval)
+	}
+	if cfg.Tracker.MetricsInterval != 15*time.Second {
+		t.Errorf("Expected metrics interval 15s, got %s", cfg.Tracker.MetricsInterval)
+	}
+	if cfg.Tracker.ReadTimeout != 2*time.Second {
+		t.Errorf("Expected read_timeout to win over read_timeout_ms, got %s", cfg.Tracker.ReadTimeout)
+	}
+	if cfg.Retry.MaxDelay != 8*time.Second {
+		t.Errorf("Expected profile max delay 8s, got %s", cfg.Retry.MaxDelay)
+	}
+	if sources["producer.interval"] != SourceFile {
+		t.Errorf("Expected producer.interval source file, got %s", sources["producer.interval"])
+	}
+
+	want := Migration{From: 1, To: 2, Change: "producer.interval_ms: 750 -> producer.interval: 750ms", Source: SourceFile}
+	found := false
+	for _, m := range *got {
+		found = found || m == want
+	}
+	if !found || len(*got) != 4 {
+		t.Errorf("Expected 4 migrations including %v, got %v", want, *got)
+	}
+}
+
+func TestCurrentVersionNotMigrated(t *testing.T) {
+	got := captureMigrations(t)
+	deprecations := captureDeprecations(t)
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := "version: 2\nproducer:\n  interval: 1s\n  flush_timeout_ms: 3000\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	cfg, sources, err := load(configPath, "", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(*got) != 0 {
+		t.Errorf("Expected no migration, got %v", *got)
+	}
+	if cfg.Producer.FlushTimeout != 3*time.Second || len(*deprecations) != 1 {
+		t.Errorf("Expected flush_timeout_ms applied as deprecated key, got %s and %v", cfg.Producer.FlushTimeout, *deprecations)
+	}
+	if _, ok := sources["version"]; ok {
+		t.Error("version must not be reported as a setting")
+	}
+}
+
+func TestInvalidVersion(t *testing.T) {
+	for _, version := range []string{"0", "two", "3"} {
+		_, _, err := migrateVersion([]byte("version: " + version + "\n"))
+		if err == nil || !strings.Contains(err.Error(), "version") {
+			t.Errorf("version %s: expected a version error, got %v", version, err)
+		}
+	}
+}
+
+func TestSampleHasVersion(t *testing.T) {
+	var b strings.Builder
+	if err := WriteSample(&b); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(b.String(), "version: 2") {
+		t.Error("Expected the sample to declare the current version")
+	}
+}
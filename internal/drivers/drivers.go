@@ -0,0 +1,75 @@
+/*
+Package drivers selects the transport driver of a broker list: the
+in-process broker for config.MemoryBroker, RabbitMQ for an AMQP URL, and
+Kafka otherwise. The producer and the tracker only see the transport.Publisher
+and transport.Subscriber it returns.
+*/
+package drivers
+
+import (
+	"fmt"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/pkg/transport"
+	"github.com/agbruneau/PubSub/pkg/transport/kafkadriver"
+	"github.com/agbruneau/PubSub/pkg/transport/memory"
+	"github.com/agbruneau/PubSub/pkg/transport/rabbitmq"
+)
+
+// NewPublisher creates the publisher of a broker list.
+//
+// Parameters:
+//   - brokers: The comma-separated broker addresses (kafka.broker).
+//   - properties: The librdkafka properties, used by the Kafka driver only.
+//
+// Returns:
+//   - transport.Publisher: The publisher.
+//   - error: An error if the driver cannot connect or is misconfigured.
+func NewPublisher(brokers string, properties map[string]string) (transport.Publisher, error) {
+	switch {
+	case config.IsMemoryBroker(brokers):
+		return memory.Default().NewProducer(), nil
+	case config.IsAMQPBroker(brokers):
+		p, err := rabbitmq.NewProducer(brokers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create RabbitMQ producer: %w", err)
+		}
+		return p, nil
+	default:
+		p, err := kafkadriver.NewProducer(brokers, properties)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Kafka producer: %w", err)
+		}
+		return p, nil
+	}
+}
+
+// NewSubscriber creates the subscriber of a broker list, member of a
+// consumer group.
+//
+// Parameters:
+//   - brokers: The comma-separated broker addresses (kafka.broker).
+//   - group: The consumer group ID.
+//   - properties: The librdkafka properties, used by the Kafka driver only.
+//
+// Returns:
+//   - transport.Subscriber: The subscriber.
+//   - error: An error if the driver cannot connect or is misconfigured.
+func NewSubscriber(brokers, group string, properties map[string]string) (transport.Subscriber, error) {
+	switch {
+	case config.IsMemoryBroker(brokers):
+		return memory.Default().NewConsumer(group), nil
+	case config.IsAMQPBroker(brokers):
+		c, err := rabbitmq.NewConsumer(brokers, group)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create RabbitMQ consumer: %w", err)
+		}
+		return c, nil
+	default:
+		c, err := kafkadriver.NewConsumer(brokers, group, properties)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Kafka consumer: %w", err)
+		}
+		return c, nil
+	}
+}
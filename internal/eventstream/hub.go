@@ -0,0 +1,220 @@
+package eventstream
+
+import (
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+)
+
+// ErrSlowSubscriber ends a subscription whose buffer is full: the events it
+// did not read in time are lost, so it must subscribe again.
+var ErrSlowSubscriber = errors.New("subscriber too slow, events dropped")
+
+// ErrHubClosed ends the subscriptions when the hub is closed.
+var ErrHubClosed = errors.New("event stream closed")
+
+// Filter selects the events of a subscription. Empty fields match every
+// event; set fields must all match.
+type Filter struct {
+	EventTypes    []string // Event types (e.g., "message.received").
+	Topic         string   // Source Kafka topic.
+	Partitions    []int32  // Source Kafka partitions.
+	CorrelationID string   // Correlation ID of the message.
+	TraceID       string   // Trace of the message.
+	FailedOnly    bool     // Only the messages the tracker failed to deserialize.
+}
+
+// Match reports whether an event passes the filter.
+//
+// Parameters:
+//   - e: The event.
+//
+// Returns:
+//   - bool: True if every set field matches.
+func (f Filter) Match(e models.EventEntry) bool {
+	switch {
+	case len(f.EventTypes) > 0 && !slices.Contains(f.EventTypes, e.EventType):
+		return false
+	case f.Topic != "" && e.KafkaTopic != f.Topic:
+		return false
+	case len(f.Partitions) > 0 && !slices.Contains(f.Partitions, e.KafkaPartition):
+		return false
+	case f.CorrelationID != "" && e.CorrelationID != f.CorrelationID:
+		return false
+	case f.TraceID != "" && e.TraceID != f.TraceID:
+		return false
+	case f.FailedOnly && e.Deserialized:
+		return false
+	}
+	return true
+}
+
+// Hub fans the events of the audit trail out to the subscriptions, and keeps
+// the most recent ones for the replay of new subscriptions.
+type Hub struct {
+	mu      sync.Mutex
+	subs    map[*Subscription]struct{}
+	history []models.EventEntry // Most recent events, oldest first.
+	size    int                 // Capacity of history.
+	buffer  int                 // Buffer of each subscription.
+	closed  bool
+}
+
+// NewHub creates a Hub.
+//
+// Parameters:
+//   - history: The number of recent events kept for replay (0: DefaultHistory).
+//   - buffer: The events buffered per subscription before it is ended (0: DefaultBuffer).
+//
+// Returns:
+//   - *Hub: The hub.
+func NewHub(history, buffer int) *Hub {
+	if history <= 0 {
+		history = DefaultHistory
+	}
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+	return &Hub{subs: make(map[*Subscription]struct{}), size: history, buffer: buffer}
+}
+
+// Publish sends an event to the matching subscriptions. A subscription whose
+// buffer is full is ended with ErrSlowSubscriber rather than blocking the others.
+//
+// Parameters:
+//   - e: The event.
+func (h *Hub) Publish(e models.EventEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.history = append(h.history, e)
+	if len(h.history) > h.size {
+		h.history = h.history[len(h.history)-h.size:]
+	}
+	for sub := range h.subs {
+		if !sub.filter.Match(e) {
+			continue
+		}
+		select {
+		case sub.events <- e:
+		default:
+			h.end(sub, ErrSlowSubscriber)
+		}
+	}
+}
+
+// Subscribe starts a subscription. Its first events are the last replay
+// events of the history matching the filter.
+//
+// Parameters:
+//   - filter: The selected events.
+//   - replay: The number of recent events to send first.
+//
+// Returns:
+//   - *Subscription: The subscription, to close once done.
+func (h *Hub) Subscribe(filter Filter, replay int) *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var recent []models.EventEntry
+	for i := len(h.history) - 1; i >= 0 && len(recent) < replay; i-- {
+		if filter.Match(h.history[i]) {
+			recent = append(recent, h.history[i])
+		}
+	}
+	slices.Reverse(recent)
+
+	sub := &Subscription{hub: h, filter: filter, events: make(chan models.EventEntry, h.buffer+len(recent)), done: make(chan struct{})}
+	for _, e := range recent {
+		sub.events <- e
+	}
+	if h.closed {
+		sub.err = ErrHubClosed
+		close(sub.done)
+		return sub
+	}
+	h.subs[sub] = struct{}{}
+	return sub
+}
+
+// Subscribers returns the number of active subscriptions.
+//
+// Returns:
+//   - int: The subscriptions.
+func (h *Hub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs)
+}
+
+// Close ends every subscription with ErrHubClosed. Later events are ignored.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for sub := range h.subs {
+		h.end(sub, ErrHubClosed)
+	}
+}
+
+// end removes a subscription with its error; h.mu must be held.
+//
+// Parameters:
+//   - sub: The subscription.
+//   - err: The reason (nil when closed by the subscriber).
+func (h *Hub) end(sub *Subscription, err error) {
+	if _, ok := h.subs[sub]; !ok {
+		return
+	}
+	delete(h.subs, sub)
+	sub.err = err
+	close(sub.done)
+}
+
+// Subscription receives the events matching its filter.
+type Subscription struct {
+	hub    *Hub
+	filter Filter
+	events chan models.EventEntry
+	done   chan struct{} // Closed when the subscription ends.
+	err    error         // Reason of the end, set before done is closed.
+}
+
+// Events returns the events of the subscription, to read until Done is closed.
+//
+// Returns:
+//   - <-chan models.EventEntry: The events.
+func (s *Subscription) Events() <-chan models.EventEntry {
+	return s.events
+}
+
+// Done returns a channel closed when the hub ends the subscription.
+//
+// Returns:
+//   - <-chan struct{}: The channel.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the reason why the hub ended the subscription.
+//
+// Returns:
+//   - error: ErrSlowSubscriber or ErrHubClosed once Done is closed, nil otherwise.
+func (s *Subscription) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// Close ends the subscription.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.end(s, nil)
+}
@@ -0,0 +1,78 @@
+package eventstream
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+)
+
+func TestFilterMatch(t *testing.T) {
+	event := models.EventEntry{EventType: "message.received", KafkaTopic: "orders", KafkaPartition: 2, CorrelationID: "c-1", Deserialized: true}
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"empty", Filter{}, true},
+		{"event type", Filter{EventTypes: []string{"other", "message.received"}}, true},
+		{"other event type", Filter{EventTypes: []string{"other"}}, false},
+		{"topic", Filter{Topic: "orders"}, true},
+		{"other topic", Filter{Topic: "orders-dlq"}, false},
+		{"partition", Filter{Partitions: []int32{1, 2}}, true},
+		{"other partition", Filter{Partitions: []int32{0}}, false},
+		{"correlation", Filter{Topic: "orders", CorrelationID: "c-1"}, true},
+		{"other correlation", Filter{Topic: "orders", CorrelationID: "c-2"}, false},
+		{"failed only", Filter{FailedOnly: true}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.filter.Match(event); got != tt.want {
+			t.Errorf("%s: Match() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHubReplayAndLive(t *testing.T) {
+	hub := NewHub(3, 0)
+	for offset := int64(1); offset <= 5; offset++ {
+		hub.Publish(models.EventEntry{KafkaOffset: offset, Deserialized: offset != 4})
+	}
+
+	sub := hub.Subscribe(Filter{}, 10)
+	defer sub.Close()
+	hub.Publish(models.EventEntry{KafkaOffset: 6, Deserialized: true})
+	for _, want := range []int64{3, 4, 5, 6} {
+		if e := <-sub.Events(); e.KafkaOffset != want {
+			t.Fatalf("Expected offset %d (history of 3, then live), got %d", want, e.KafkaOffset)
+		}
+	}
+
+	failed := hub.Subscribe(Filter{FailedOnly: true}, 10)
+	if e := <-failed.Events(); e.KafkaOffset != 4 || len(failed.Events()) != 0 {
+		t.Errorf("Expected only the failed event in the replay, got %d (+%d)", e.KafkaOffset, len(failed.Events()))
+	}
+	failed.Close()
+	if hub.Subscribers() != 1 {
+		t.Errorf("Expected 1 subscriber after Close, got %d", hub.Subscribers())
+	}
+}
+
+func TestHubEndsSubscriptions(t *testing.T) {
+	hub := NewHub(0, 1)
+	slow := hub.Subscribe(Filter{}, 0)
+	hub.Publish(models.EventEntry{KafkaOffset: 1})
+	hub.Publish(models.EventEntry{KafkaOffset: 2})
+	if !errors.Is(slow.Err(), ErrSlowSubscriber) {
+		t.Errorf("Expected ErrSlowSubscriber, got %v", slow.Err())
+	}
+
+	live := hub.Subscribe(Filter{}, 0)
+	hub.Close()
+	<-live.Done()
+	if !errors.Is(live.Err(), ErrHubClosed) || hub.Subscribers() != 0 {
+		t.Errorf("Expected ErrHubClosed, got %v (%d subscribers)", live.Err(), hub.Subscribers())
+	}
+	if late := hub.Subscribe(Filter{}, 0); !errors.Is(late.Err(), ErrHubClosed) {
+		t.Errorf("A subscription to a closed hub should end at once, got %v", late.Err())
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Package eventstream implements the gRPC event stream of the PubSub system
+(pubsub events): it tails the tracker audit trail (tracker.events_file) and
+streams its events to programmatic subscribers, such as test harnesses,
+through the server-streaming SubscribeEvents call of the eventspb.EventStream
+service.
+
+A Hub fans the events out to the subscriptions, each with its Filter, and
+keeps the most recent ones so that a subscriber can replay them before the
+live events.
+*/
+package eventstream
+
+import (
+	"errors"
+
+	"github.com/agbruneau/PubSub/internal/monitor"
+	"github.com/agbruneau/PubSub/pkg/eventspb"
+	"github.com/agbruneau/PubSub/pkg/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server defaults.
+const (
+	// DefaultAddr is the default listen address of pubsub events.
+	DefaultAddr = "localhost:9090"
+	// DefaultHistory is the default number of recent events kept for replay.
+	DefaultHistory = 100
+	// DefaultBuffer is the default number of events buffered per subscription.
+	DefaultBuffer = 256
+)
+
+// Server implements the eventspb.EventStream service over a Hub.
+type Server struct {
+	eventspb.UnimplementedEventStreamServer
+	hub *Hub
+}
+
+// NewServer creates a Server.
+//
+// Parameters:
+//   - hub: The source of the events.
+//
+// Returns:
+//   - *Server: The server, to register with eventspb.RegisterEventStreamServer.
+func NewServer(hub *Hub) *Server {
+	return &Server{hub: hub}
+}
+
+// SubscribeEvents streams the events matching the request until the client
+// cancels the call or the hub ends the subscription.
+//
+// Parameters:
+//   - req: The filters and the replay.
+//   - stream: The server stream.
+//
+// Returns:
+//   - error: A ResourceExhausted status for a slow subscriber, Unavailable
+//     once the hub is closed, or the send error.
+func (s *Server) SubscribeEvents(req *eventspb.SubscribeEventsRequest, stream grpc.ServerStreamingServer[eventspb.Event]) error {
+	sub := s.hub.Subscribe(FilterFrom(req), int(req.GetReplay()))
+	defer sub.Close()
+
+	for {
+		select {
+		case e := <-sub.Events():
+			if err := stream.Send(ToProto(e)); err != nil {
+				return err
+			}
+		case <-sub.Done():
+			if errors.Is(sub.Err(), ErrSlowSubscriber) {
+				return status.Error(codes.ResourceExhausted, sub.Err().Error())
+			}
+			return status.Error(codes.Unavailable, ErrHubClosed.Error())
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// Feed publishes to the hub the events of the audit trail, from its first
+// line, as the tracker appends them. It never returns and is meant to run in
+// its own goroutine.
+//
+// Parameters:
+//   - hub: The hub.
+//   - eventsFile: The audit trail (tracker.events_file).
+func Feed(hub *Hub, eventsFile string) {
+	events := make(chan models.EventEntry, DefaultBuffer)
+	go monitor.MonitorFileFrom(eventsFile, 0, nil, events)
+	for e := range events {
+		hub.Publish(e)
+	}
+}
+
+// FilterFrom converts the filters of a request.
+//
+// Parameters:
+//   - req: The request.
+//
+// Returns:
+//   - Filter: The filter.
+func FilterFrom(req *eventspb.SubscribeEventsRequest) Filter {
+	return Filter{
+		EventTypes:    req.GetEventTypes(),
+		Topic:         req.GetTopic(),
+		Partitions:    req.GetPartitions(),
+		CorrelationID: req.GetCorrelationId(),
+		TraceID:       req.GetTraceId(),
+		FailedOnly:    req.GetFailedOnly(),
+	}
+}
+
+// ToProto converts an event of the audit trail.
+//
+// Parameters:
+//   - e: The event.
+//
+// Returns:
+//   - *eventspb.Event: The streamed event.
+func ToProto(e models.EventEntry) *eventspb.Event {
+	return &eventspb.Event{
+		Timestamp:      timestamppb.New(e.Timestamp.Time),
+		EventType:      e.EventType,
+		TraceId:        e.TraceID,
+		SpanId:         e.SpanID,
+		CorrelationId:  e.CorrelationID,
+		KafkaTopic:     e.KafkaTopic,
+		KafkaPartition: e.KafkaPartition,
+		KafkaOffset:    e.KafkaOffset,
+		RawMessage:     e.RawMessage,
+		MessageSize:    int32(e.MessageSize),
+		Deserialized:   e.Deserialized,
+		Error:          e.Error,
+		ErrorCode:      string(e.ErrorCode),
+		OrderJson:      string(e.OrderFull),
+	}
+}
@@ -0,0 +1,119 @@
+package eventstream
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/eventspb"
+	"github.com/agbruneau/PubSub/pkg/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newClient serves the hub over an in-memory connection.
+func newClient(t *testing.T, hub *Hub) eventspb.EventStreamClient {
+	t.Helper()
+	ln := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	eventspb.RegisterEventStreamServer(srv, NewServer(hub))
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return eventspb.NewEventStreamClient(conn)
+}
+
+// waitSubscribers waits until the hub has n subscriptions.
+func waitSubscribers(t *testing.T, hub *Hub, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for hub.Subscribers() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d subscribers, got %d", n, hub.Subscribers())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	hub := NewHub(0, 0)
+	hub.Publish(models.EventEntry{KafkaOffset: 1, Error: "invalid order", ErrorCode: "ERR_SCHEMA_MISMATCH"})
+	client := newClient(t, hub)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.SubscribeEvents(ctx, &eventspb.SubscribeEventsRequest{FailedOnly: true, Replay: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitSubscribers(t, hub, 1)
+	hub.Publish(models.EventEntry{KafkaOffset: 2, Deserialized: true})
+	hub.Publish(models.EventEntry{Timestamp: models.Now(), KafkaTopic: "orders", KafkaOffset: 3, OrderFull: []byte(`{"order_id":"o-1"}`)})
+
+	for _, want := range []int64{1, 3} {
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		if event.GetKafkaOffset() != want {
+			t.Fatalf("Expected offset %d, got %d", want, event.GetKafkaOffset())
+		}
+		if want == 1 && event.GetErrorCode() != "ERR_SCHEMA_MISMATCH" {
+			t.Errorf("Expected the error code, got %+v", event)
+		}
+		if want == 3 && (event.GetKafkaTopic() != "orders" || event.GetOrderJson() != `{"order_id":"o-1"}` || event.GetTimestamp().AsTime().IsZero()) {
+			t.Errorf("Unexpected event %+v", event)
+		}
+	}
+
+	cancel()
+	waitSubscribers(t, hub, 0)
+}
+
+func TestSubscribeEventsHubClosed(t *testing.T) {
+	hub := NewHub(0, 0)
+	client := newClient(t, hub)
+	stream, err := client.SubscribeEvents(context.Background(), &eventspb.SubscribeEventsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitSubscribers(t, hub, 1)
+	hub.Close()
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+}
+
+func TestFeed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tracker.events")
+	line := `{"timestamp":"2026-01-02T03:04:05Z","event_type":"message.received","kafka_topic":"orders","kafka_offset":7,"deserialized":true}`
+	if err := os.WriteFile(path, []byte(line+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	hub := NewHub(0, 0)
+	go Feed(hub, path)
+
+	sub := hub.Subscribe(Filter{}, 1)
+	defer sub.Close()
+	select {
+	case e := <-sub.Events():
+		if e.KafkaOffset != 7 || e.KafkaTopic != "orders" {
+			t.Errorf("Unexpected event %+v", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("The event of the audit trail was not published")
+	}
+}
@@ -0,0 +1,290 @@
+/*
+Package gateway implements the HTTP ingestion gateway of the PubSub system
+(pubsub gateway), through which external systems inject real orders into the
+pipeline:
+
+	POST /v1/orders  publishes a JSON order (models.Order), 202 once handed to the publisher
+	GET  /healthz    answers 200 while the gateway runs (monitor.processes health_url)
+
+A posted order goes through the same checks as a generated one: it is
+decoded strictly (models.DecodeOrderStrict), its missing metadata is set,
+then it is normalized and every validation rule is applied before it is
+handed to the Publisher. Posting requires the bearer token of the Config,
+and is rate limited by a token bucket.
+
+Errors are returned as an ErrorResponse, with the codes of the models errors.
+*/
+package gateway
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/google/uuid"
+)
+
+// DefaultSource is the metadata source of the posted orders that have none.
+const DefaultSource = "gateway-service"
+
+// StatusAccepted is the status of an order handed to the publisher.
+const StatusAccepted = "accepted"
+
+// Gateway errors, returned in the ErrorResponse.
+var (
+	ErrUnauthorized   = errors.New("missing or invalid bearer token")
+	ErrRateLimited    = errors.New("too many orders, retry later")
+	ErrBodyTooLarge   = errors.New("order too large")
+	ErrContentType    = errors.New("content type must be application/json")
+	ErrInvalidOrder   = errors.New("invalid order")
+	ErrPublishFailed  = errors.New("order could not be published")
+	ErrPublishPending = errors.New("publishing suspended by the circuit breaker, retry later")
+)
+
+// Publisher publishes the accepted orders. It is implemented by
+// *producer.OrderProducer.
+type Publisher interface {
+	// PublishOrder normalizes and sends an order to the orders topic. It must
+	// be safe for concurrent use.
+	PublishOrder(order models.Order) error
+}
+
+// Config contains the settings of the Gateway.
+type Config struct {
+	Publisher    Publisher        // Destination of the accepted orders.
+	Token        string           // Bearer token required to post orders ("": no authentication).
+	RateLimit    float64          // Accepted orders per second (0: unlimited).
+	Burst        int              // Orders accepted at once before RateLimit applies.
+	MaxBodyBytes int64            // Maximum size of an order (0: config.GatewayMaxBodyBytes).
+	Source       string           // Metadata source of the orders that have none ("": DefaultSource).
+	Now          func() time.Time // Clock of the rate limiter and of the order timestamps (nil: time.Now).
+}
+
+// Accepted is the response to an accepted order.
+type Accepted struct {
+	OrderID       string `json:"order_id"`       // Identifier of the order.
+	CorrelationID string `json:"correlation_id"` // Correlation identifier, to follow the order in the tracker.
+	Status        string `json:"status"`         // StatusAccepted.
+}
+
+// Problem is a failed validation rule of a rejected order.
+type Problem struct {
+	Field string           `json:"field,omitempty"` // Field path (e.g., "items[0].quantity").
+	Code  models.ErrorCode `json:"code,omitempty"`  // Stable error code (e.g., "ERR_ORDER_ID_REQUIRED").
+	Error string           `json:"error"`           // English message.
+}
+
+// ErrorResponse is the response to a rejected request.
+type ErrorResponse struct {
+	Error    string    `json:"error"`              // Reason of the rejection.
+	Problems []Problem `json:"problems,omitempty"` // Failed validation rules of an invalid order.
+}
+
+// Gateway serves the ingestion API. It implements http.Handler.
+type Gateway struct {
+	cfg     Config
+	limiter *limiter
+	mux     *http.ServeMux
+}
+
+// New creates a Gateway.
+//
+// Parameters:
+//   - cfg: The settings.
+//
+// Returns:
+//   - *Gateway: The gateway.
+func New(cfg Config) *Gateway {
+	if cfg.MaxBodyBytes <= 0 {
+		cfg.MaxBodyBytes = config.GatewayMaxBodyBytes
+	}
+	if cfg.Source == "" {
+		cfg.Source = DefaultSource
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	g := &Gateway{cfg: cfg, limiter: newLimiter(cfg.RateLimit, cfg.Burst, cfg.Now()), mux: http.NewServeMux()}
+	g.mux.HandleFunc("POST /v1/orders", g.handleOrder)
+	g.mux.HandleFunc("GET /healthz", g.handleHealth)
+	return g
+}
+
+// ServeHTTP dispatches a request to the API.
+//
+// Parameters:
+//   - w: The response writer.
+//   - r: The request.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// handleHealth answers the liveness probes.
+func (g *Gateway) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleOrder authenticates, rate limits, validates and publishes a posted order.
+func (g *Gateway) handleOrder(w http.ResponseWriter, r *http.Request) {
+	if !g.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="pubsub"`)
+		writeError(w, http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+	if wait, ok := g.limiter.allow(g.cfg.Now()); !ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		writeError(w, http.StatusTooManyRequests, ErrRateLimited)
+		return
+	}
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		if mediaType, _, err := mime.ParseMediaType(ct); err != nil || mediaType != "application/json" {
+			writeError(w, http.StatusUnsupportedMediaType, ErrContentType)
+			return
+		}
+	}
+
+	raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, g.cfg.MaxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("%w: more than %d bytes", ErrBodyTooLarge, tooLarge.Limit))
+			return
+		}
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	order, err := g.readOrder(raw)
+	if err != nil {
+		if _, coded := models.CodeOf(err); !coded {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("%w: %v", ErrInvalidOrder, err))
+			return
+		}
+		writeJSON(w, http.StatusUnprocessableEntity, ErrorResponse{Error: ErrInvalidOrder.Error(), Problems: problems(err)})
+		return
+	}
+
+	if err := g.cfg.Publisher.PublishOrder(order); err != nil {
+		if errors.Is(err, retry.ErrCircuitOpen) {
+			writeError(w, http.StatusServiceUnavailable, ErrPublishPending)
+			return
+		}
+		writeError(w, http.StatusBadGateway, fmt.Errorf("%w: %v", ErrPublishFailed, err))
+		return
+	}
+	writeJSON(w, http.StatusAccepted, Accepted{
+		OrderID:       order.OrderID,
+		CorrelationID: order.Metadata.CorrelationID,
+		Status:        StatusAccepted,
+	})
+}
+
+// authorized reports whether a request carries the bearer token of the Config.
+//
+// Parameters:
+//   - r: The request.
+//
+// Returns:
+//   - bool: True if the token matches, or if no token is configured.
+func (g *Gateway) authorized(r *http.Request) bool {
+	if g.cfg.Token == "" {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(strings.TrimSpace(token)), []byte(g.cfg.Token)) == 1
+}
+
+// readOrder decodes a posted order, sets its missing metadata and checks it.
+//
+// Parameters:
+//   - raw: The request body.
+//
+// Returns:
+//   - models.Order: The normalized, valid order.
+//   - error: A JSON decoding error, or a coded error (see models.CodeOf) for
+//     an order that does not match the schema or breaks validation rules.
+func (g *Gateway) readOrder(raw []byte) (models.Order, error) {
+	order, err := models.DecodeOrderStrict(raw)
+	if err != nil {
+		return models.Order{}, err
+	}
+	meta := &order.Metadata
+	if meta.Timestamp.IsZero() {
+		meta.Timestamp = models.NewTimestamp(g.cfg.Now())
+	}
+	if meta.EventType == "" {
+		meta.EventType = models.DefaultEventType
+	}
+	if meta.Source == "" {
+		meta.Source = g.cfg.Source
+	}
+	if meta.CorrelationID == "" {
+		meta.CorrelationID = uuid.New().String()
+	}
+	if err := order.Normalize(); err != nil {
+		return models.Order{}, err
+	}
+	if err := order.ValidateAll(); err != nil {
+		return models.Order{}, err
+	}
+	return order, nil
+}
+
+// problems lists the failed rules of a validation error.
+//
+// Parameters:
+//   - err: A coded error, or several joined by errors.Join.
+//
+// Returns:
+//   - []Problem: The failed rules.
+func problems(err error) []Problem {
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	list := make([]Problem, 0, len(errs))
+	for _, e := range errs {
+		p := Problem{Error: e.Error()}
+		var field *models.FieldError
+		if errors.As(e, &field) {
+			p.Field = field.Field
+			p.Error = field.Err.Error()
+		}
+		p.Code, _ = models.CodeOf(e)
+		list = append(list, p)
+	}
+	return list
+}
+
+// writeJSON writes a JSON response.
+//
+// Parameters:
+//   - w: The response writer.
+//   - status: The HTTP status.
+//   - v: The response body.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an ErrorResponse.
+//
+// Parameters:
+//   - w: The response writer.
+//   - status: The HTTP status.
+//   - err: The error.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
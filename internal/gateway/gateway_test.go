@@ -0,0 +1,184 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/agbruneau/PubSub/pkg/models"
+)
+
+// validOrder is an order posted by an external system, without metadata nor computed totals.
+const validOrder = `{
+	"order_id": "ext-1",
+	"sequence": 1,
+	"status": "pending",
+	"customer_info": {"customer_id": "c-1", "name": "Alice"},
+	"items": [{"item_id": "i-1", "item_name": "Book", "quantity": 2, "unit_price": 9.99}],
+	"tax": 2,
+	"shipping_fee": 2.5,
+	"currency": "eur"
+}`
+
+// fakePublisher records the published orders.
+type fakePublisher struct {
+	mu     sync.Mutex
+	orders []models.Order
+	err    error
+}
+
+func (p *fakePublisher) PublishOrder(order models.Order) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	p.orders = append(p.orders, order)
+	return nil
+}
+
+// post sends an order to the gateway and decodes the response.
+func post(t *testing.T, g *Gateway, token, body string, v any) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/v1/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+	if v != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+			t.Fatalf("Invalid JSON response %q: %v", rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+func TestPostOrder(t *testing.T) {
+	pub := &fakePublisher{}
+	g := New(Config{Publisher: pub})
+
+	var accepted Accepted
+	if code := post(t, g, "", validOrder, &accepted); code != http.StatusAccepted {
+		t.Fatalf("Expected 202, got %d", code)
+	}
+	if accepted.OrderID != "ext-1" || accepted.Status != StatusAccepted || accepted.CorrelationID == "" {
+		t.Errorf("Unexpected response %+v", accepted)
+	}
+	if len(pub.orders) != 1 {
+		t.Fatalf("Expected 1 published order, got %d", len(pub.orders))
+	}
+	order := pub.orders[0]
+	if order.Currency != "EUR" || order.Total != models.NewMoney(24.48, "EUR") {
+		t.Errorf("The order should be normalized, got %s %s", order.Total, order.Currency)
+	}
+	meta := order.Metadata
+	if meta.Source != DefaultSource || meta.EventType != models.DefaultEventType || meta.Timestamp.IsZero() ||
+		meta.CorrelationID != accepted.CorrelationID || meta.Version != string(models.CurrentSchemaVersion) {
+		t.Errorf("Missing metadata should be set, got %+v", meta)
+	}
+}
+
+func TestPostInvalidOrder(t *testing.T) {
+	pub := &fakePublisher{}
+	g := New(Config{Publisher: pub})
+
+	var resp ErrorResponse
+	invalid := strings.Replace(strings.Replace(validOrder, `"ext-1"`, `""`, 1), `"quantity": 2`, `"quantity": 0`, 1)
+	if code := post(t, g, "", invalid, &resp); code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected 422, got %d", code)
+	}
+	if len(resp.Problems) != 2 || resp.Problems[0].Field != "order_id" || resp.Problems[0].Code == "" ||
+		resp.Problems[1].Field != "items[0].quantity" {
+		t.Errorf("Expected every failed rule, got %+v", resp.Problems)
+	}
+
+	unknown := strings.Replace(validOrder, `"tax"`, `"discount": 1, "tax"`, 1)
+	if code := post(t, g, "", unknown, &resp); code != http.StatusUnprocessableEntity || resp.Problems[0].Code != "ERR_SCHEMA_MISMATCH" {
+		t.Errorf("Expected a schema mismatch, got %d %+v", code, resp)
+	}
+	if code := post(t, g, "", `{"order_id":`, &resp); code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for malformed JSON, got %d", code)
+	}
+	if len(pub.orders) != 0 {
+		t.Errorf("Invalid orders should not be published, got %d", len(pub.orders))
+	}
+}
+
+func TestPostRequestLimits(t *testing.T) {
+	g := New(Config{Publisher: &fakePublisher{}, Token: "s3cret", MaxBodyBytes: 64})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/orders", strings.NewReader(validOrder))
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") == "" {
+		t.Errorf("Expected 401 with a challenge, got %d", rec.Code)
+	}
+	if code := post(t, g, "wrong", validOrder, nil); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for a wrong token, got %d", code)
+	}
+	if code := post(t, g, "s3cret", validOrder, nil); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413, got %d", code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/orders", strings.NewReader(`{}`))
+	req.Header.Set("Authorization", "Bearer s3cret")
+	req.Header.Set("Content-Type", "text/plain")
+	rec = httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("Expected 415, got %d", rec.Code)
+	}
+}
+
+func TestPostRateLimit(t *testing.T) {
+	now := time.Unix(0, 0)
+	g := New(Config{Publisher: &fakePublisher{}, RateLimit: 2, Burst: 2, Now: func() time.Time { return now }})
+
+	for i := 1; i <= 2; i++ {
+		if code := post(t, g, "", strings.Replace(validOrder, "ext-1", fmt.Sprintf("ext-%d", i), 1), nil); code != http.StatusAccepted {
+			t.Fatalf("Order %d within the burst: expected 202, got %d", i, code)
+		}
+	}
+	req := httptest.NewRequest(http.MethodPost, "/v1/orders", strings.NewReader(validOrder))
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, req)
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "1" {
+		t.Errorf("Expected 429 with Retry-After: 1, got %d %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if code := post(t, g, "", validOrder, nil); code != http.StatusAccepted {
+		t.Errorf("A token should be refilled after 500ms, got %d", code)
+	}
+}
+
+func TestPostPublishErrors(t *testing.T) {
+	pub := &fakePublisher{err: fmt.Errorf("publishing suspended: %w", retry.ErrCircuitOpen)}
+	g := New(Config{Publisher: pub})
+	if code := post(t, g, "", validOrder, nil); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 while the circuit is open, got %d", code)
+	}
+	pub.err = errors.New("broker down")
+	var resp ErrorResponse
+	if code := post(t, g, "", validOrder, &resp); code != http.StatusBadGateway || !strings.Contains(resp.Error, "broker down") {
+		t.Errorf("Expected 502, got %d %+v", code, resp)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	g := New(Config{Publisher: &fakePublisher{}, Token: "s3cret"})
+	rec := httptest.NewRecorder()
+	g.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 without token, got %d", rec.Code)
+	}
+}
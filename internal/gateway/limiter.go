@@ -0,0 +1,59 @@
+package gateway
+
+import (
+	"sync"
+	"time"
+)
+
+// limiter is a token bucket: it holds up to burst tokens, refilled at rate
+// tokens per second, and each accepted order takes one. A nil limiter
+// accepts every order.
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64   // Tokens added per second.
+	burst  float64   // Capacity of the bucket.
+	tokens float64   // Tokens available at last.
+	last   time.Time // Time of the last refill.
+}
+
+// newLimiter creates a full token bucket.
+//
+// Parameters:
+//   - rate: The tokens added per second (<= 0: no limit).
+//   - burst: The capacity of the bucket (at least 1).
+//   - now: The current time.
+//
+// Returns:
+//   - *limiter: The limiter, or nil without limit.
+func newLimiter(rate float64, burst int, now time.Time) *limiter {
+	if rate <= 0 {
+		return nil
+	}
+	capacity := float64(max(burst, 1))
+	return &limiter{rate: rate, burst: capacity, tokens: capacity, last: now}
+}
+
+// allow takes a token if one is available.
+//
+// Parameters:
+//   - now: The current time.
+//
+// Returns:
+//   - time.Duration: The wait before the next token, when refused.
+//   - bool: True if a token was taken.
+func (l *limiter) allow(now time.Time) (time.Duration, bool) {
+	if l == nil {
+		return 0, true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if elapsed := now.Sub(l.last).Seconds(); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+elapsed*l.rate)
+		l.last = now
+	}
+	if l.tokens < 1 {
+		return time.Duration((1 - l.tokens) / l.rate * float64(time.Second)), false
+	}
+	l.tokens--
+	return 0, true
+}
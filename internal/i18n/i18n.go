@@ -0,0 +1,169 @@
+/*
+Package i18n provides the translated user-facing strings of the PubSub binaries.
+
+Messages are identified by a key (e.g., "monitor.title.logs") and looked up in
+the bundle of the active locale, falling back to French, then to the key itself.
+The locale is selected from the configuration (app.locale / APP_LOCALE) or, if
+unset, from the LC_ALL, LC_MESSAGES and LANG environment variables.
+*/
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+)
+
+// Locale identifies a supported language.
+type Locale string
+
+const (
+	// LocaleFR is French, the default locale.
+	LocaleFR Locale = "fr"
+	// LocaleEN is English.
+	LocaleEN Locale = "en"
+)
+
+// DefaultLocale is used when no supported locale is configured.
+const DefaultLocale = LocaleFR
+
+var (
+	mu      sync.RWMutex
+	current = DefaultLocale
+)
+
+// ParseLocale converts a locale name such as "en", "en_US.UTF-8" or "fr-CA"
+// into a supported locale.
+//
+// Parameters:
+//   - name: The locale name.
+//
+// Returns:
+//   - Locale: The matching locale.
+//   - bool: False if the language is not supported.
+func ParseLocale(name string) (Locale, bool) {
+	lang := strings.ToLower(name)
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	switch Locale(lang) {
+	case LocaleFR:
+		return LocaleFR, true
+	case LocaleEN:
+		return LocaleEN, true
+	}
+	return DefaultLocale, false
+}
+
+// Detect selects the locale from the configured value or the environment.
+//
+// Parameters:
+//   - configured: The locale from the configuration (empty to use the environment).
+//
+// Returns:
+//   - Locale: The configured locale if supported, otherwise the first supported
+//     locale among LC_ALL, LC_MESSAGES and LANG, otherwise DefaultLocale.
+func Detect(configured string) Locale {
+	candidates := []string{configured, os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")}
+	for _, c := range candidates {
+		if c == "" {
+			continue
+		}
+		if l, ok := ParseLocale(c); ok {
+			return l
+		}
+	}
+	return DefaultLocale
+}
+
+// SetLocale changes the active locale.
+//
+// Parameters:
+//   - l: The locale to activate.
+func SetLocale(l Locale) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = l
+}
+
+// Current returns the active locale.
+//
+// Returns:
+//   - Locale: The active locale.
+func Current() Locale {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// T returns the message of the given key in the active locale.
+// If args are provided, the message is used as a fmt format.
+//
+// Parameters:
+//   - key: The message key.
+//   - args: Optional format arguments.
+//
+// Returns:
+//   - string: The translated message (the key itself if unknown).
+func T(key string, args ...interface{}) string {
+	msg, ok := bundles[Current()][key]
+	if !ok {
+		msg, ok = bundles[DefaultLocale][key]
+	}
+	if !ok {
+		msg = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// LocalizeError renders an error of pkg/models in the active locale: the
+// message of each coded error is replaced by its translation, the field path
+// and the details around it are kept (e.g., "items[0].quantity: la quantité
+// doit être positive"). Errors joined by errors.Join are rendered one per line.
+//
+// Parameters:
+//   - err: The error.
+//
+// Returns:
+//   - string: The translated message (err.Error() if err wraps no coded error).
+func LocalizeError(err error) string {
+	if err == nil {
+		return ""
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		lines := make([]string, 0, len(joined.Unwrap()))
+		for _, e := range joined.Unwrap() {
+			lines = append(lines, LocalizeError(e))
+		}
+		return strings.Join(lines, "\n")
+	}
+	code, ok := models.CodeOf(err)
+	if !ok {
+		return err.Error()
+	}
+	return LocalizeErrorText(string(code), err.Error())
+}
+
+// LocalizeErrorText renders in the active locale an error message written with
+// its code, as in the error and error_code fields of tracker.events.
+//
+// Parameters:
+//   - code: The pkg/models error code (e.g., "ERR_ORDER_ID_REQUIRED").
+//   - text: The English error message.
+//
+// Returns:
+//   - string: The translated message (text if the code is unknown).
+func LocalizeErrorText(code, text string) string {
+	key := "error." + code
+	english, ok := bundles[LocaleEN][key]
+	if !ok || !strings.Contains(text, english) {
+		return text
+	}
+	return strings.Replace(text, english, T(key), 1)
+}
@@ -0,0 +1,104 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseLocale vérifie la reconnaissance des noms de locale.
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		name string
+		want Locale
+		ok   bool
+	}{
+		{"fr", LocaleFR, true},
+		{"en_US.UTF-8", LocaleEN, true},
+		{"fr-CA", LocaleFR, true},
+		{"EN", LocaleEN, true},
+		{"de_DE.UTF-8", DefaultLocale, false},
+		{"C", DefaultLocale, false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseLocale(tt.name)
+		assert.Equal(t, tt.want, got, tt.name)
+		assert.Equal(t, tt.ok, ok, tt.name)
+	}
+}
+
+// TestDetect vérifie la priorité entre la configuration et l'environnement.
+func TestDetect(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+
+	assert.Equal(t, LocaleEN, Detect(""))
+	assert.Equal(t, LocaleFR, Detect("fr"))
+	// Une valeur configurée non supportée laisse place à l'environnement
+	assert.Equal(t, LocaleEN, Detect("de"))
+
+	t.Setenv("LANG", "C.UTF-8")
+	assert.Equal(t, DefaultLocale, Detect(""))
+}
+
+// TestT vérifie la traduction, le formatage et les replis.
+func TestT(t *testing.T) {
+	defer SetLocale(DefaultLocale)
+
+	SetLocale(LocaleFR)
+	assert.Equal(t, "Qualité", T("monitor.title.quality_gauge"))
+	assert.Equal(t, "BON (75)", T("monitor.quality.good", 75.0))
+
+	SetLocale(LocaleEN)
+	assert.Equal(t, "Quality", T("monitor.title.quality_gauge"))
+	assert.Equal(t, "GOOD (75)", T("monitor.quality.good", 75.0))
+
+	assert.Equal(t, "unknown.key", T("unknown.key"))
+}
+
+// TestBundlesComplete vérifie que chaque locale définit les mêmes clés.
+func TestBundlesComplete(t *testing.T) {
+	for key := range bundles[LocaleFR] {
+		assert.Contains(t, bundles[LocaleEN], key, "clé absente en anglais")
+	}
+	for key := range bundles[LocaleEN] {
+		assert.Contains(t, bundles[LocaleFR], key, "clé absente en français")
+	}
+}
+
+// TestErrorCatalogComplete vérifie que chaque erreur codée de pkg/models est
+// traduite, et que le message anglais est celui de l'erreur.
+func TestErrorCatalogComplete(t *testing.T) {
+	for _, err := range models.CodedErrors() {
+		code, ok := models.CodeOf(err)
+		assert.True(t, ok, err.Error())
+		assert.Equal(t, err.Error(), bundles[LocaleEN]["error."+string(code)], code)
+		assert.NotEmpty(t, bundles[LocaleFR]["error."+string(code)], code)
+	}
+}
+
+// TestLocalizeError vérifie la traduction des erreurs de validation, de leur
+// chemin de champ et de leurs détails.
+func TestLocalizeError(t *testing.T) {
+	defer SetLocale(DefaultLocale)
+	order := models.Order{OrderID: "o1", Sequence: 1, Status: "pending", CustomerInfo: models.CustomerInfo{CustomerID: "c1", Name: "Test"},
+		Items: []models.OrderItem{{ItemID: "i1", ItemName: "Thé", Quantity: 0, UnitPrice: models.FromCents(100, "")}}}
+	err := order.Validate()
+
+	SetLocale(LocaleFR)
+	assert.Equal(t, "items[0].quantity: la quantité doit être positive", LocalizeError(err))
+	assert.Equal(t, "la devise doit être un code ISO 4217: \"yen\"",
+		LocalizeError(fmt.Errorf("%w: %q", models.ErrInvalidCurrency, "yen")))
+	assert.Equal(t, "items[0].quantity: la quantité doit être positive\ntotal: le total ne correspond pas à sous-total + taxe + frais de port: expected 0.00, got 1.00",
+		LocalizeError(errors.Join(err, &models.FieldError{Field: "total", Err: fmt.Errorf("%w: expected 0.00, got 1.00", models.ErrInvalidTotal)})))
+	assert.Equal(t, "boom", LocalizeError(errors.New("boom")))
+	assert.Equal(t, "", LocalizeError(nil))
+
+	SetLocale(LocaleEN)
+	assert.Equal(t, err.Error(), LocalizeError(err))
+	assert.Equal(t, "other text", LocalizeErrorText("ERR_QUANTITY_NOT_POSITIVE", "other text"))
+}
@@ -0,0 +1,375 @@
+package i18n
+
+// bundles contains the messages of every supported locale.
+var bundles = map[Locale]map[string]string{
+	LocaleFR: {
+		// Monitor - titres des widgets
+		"monitor.title.success_gauge":      "Taux de Succès",
+		"monitor.title.quality_gauge":      "Qualité",
+		"monitor.title.logs":               "Logs Récents (tracker.log)",
+		"monitor.title.events":             "Événements Récents (tracker.events)",
+		"monitor.title.throughput":         "Débit Messages (msg/s)",
+		"monitor.title.throughput_stats":   "Débit Messages (msg/s) | actuel %.2f | p50 %.2f | p95 %.2f | max %.2f",
+		"monitor.title.success_rate":       "Taux de Succès (%)",
+		"monitor.title.message_size":       "Taille des Messages (octets)",
+		"monitor.title.partitions":         "Partitions (messages)",
+		"monitor.title.partitions_offsets": "Partitions | dernier offset %s",
+		"monitor.title.diagnostics":        "Diagnostics d'analyse",
+		"monitor.title.kafka":              "Kafka",
+		"monitor.title.latency":            "Latence de livraison (ms)",
+		"monitor.title.latency_history":    "Latence de livraison p99 (ms)",
+		"monitor.title.help":               "Aide (? ou Échap pour fermer)",
+		"monitor.title.details":            "Détails (Échap pour fermer)",
+		"monitor.details.error":            "Erreur [%s]: %s",
+
+		// Monitor - tables
+		"monitor.metrics.header.metric":   "Métrique",
+		"monitor.metrics.header.value":    "Valeur",
+		"monitor.metrics.received":        "Messages reçus",
+		"monitor.metrics.processed":       "Messages traités",
+		"monitor.metrics.failed":          "Messages échoués",
+		"monitor.metrics.throughput":      "Débit (msg/s)",
+		"monitor.metrics.success_rate":    "Taux de succès",
+		"monitor.metrics.last_update":     "Dernière màj",
+		"monitor.health.header.indicator": "Indicateur",
+		"monitor.health.header.status":    "Statut",
+		"monitor.health.global":           "Santé Globale",
+		"monitor.health.throughput":       "Débit",
+		"monitor.health.errors":           "Erreurs",
+		"monitor.health.uptime":           "Uptime",
+		"monitor.health.processes":        "Processus",
+
+		// Monitor - textes de santé
+		"monitor.status.unknown":    "● INCONNU",
+		"monitor.status.excellent":  "● EXCELLENT",
+		"monitor.status.good":       "● BON",
+		"monitor.status.warning":    "● ATTENTION",
+		"monitor.status.critical":   "● CRITIQUE",
+		"monitor.status.normal":     "● NORMAL",
+		"monitor.status.low":        "● FAIBLE",
+		"monitor.status.stopped":    "● ARRÊTÉ",
+		"monitor.status.no_error":   "● AUCUN",
+		"monitor.status.recent":     "● RÉCENT",
+		"monitor.status.active":     "● ACTIF",
+		"monitor.quality.excellent": "EXCELLENT (%.0f)",
+		"monitor.quality.good":      "BON (%.0f)",
+		"monitor.quality.medium":    "MOYEN (%.0f)",
+		"monitor.quality.low":       "FAIBLE (%.0f)",
+
+		// Monitor - listes
+		"monitor.logs.waiting":   "En attente de logs...",
+		"monitor.events.waiting": "En attente d'événements...",
+
+		// Monitor - barre d'état et aide
+		"monitor.file.waiting":           "en attente",
+		"monitor.file.watching":          "OK",
+		"monitor.file.missing":           "absent",
+		"monitor.process.unknown":        "?",
+		"monitor.process.up":             "UP",
+		"monitor.process.down":           "DOWN",
+		"monitor.filter.none":            "aucun",
+		"monitor.filter.errors":          "erreurs uniquement",
+		"monitor.state.running":          "▶ en cours",
+		"monitor.state.paused":           "⏸ EN PAUSE",
+		"monitor.statusbar":              "%s | Fichiers: %s | Filtres: %s | Rafraîchissement: %s | Dernière màj: %s | ?: aide",
+		"monitor.help.quit":              "Quitter le moniteur",
+		"monitor.help.help":              "Afficher / masquer cette aide",
+		"monitor.help.pause":             "Mettre en pause / reprendre le rafraîchissement",
+		"monitor.help.filter":            "Filtrer les logs et événements en erreur",
+		"monitor.help.rate":              "Accélérer / ralentir le rafraîchissement (100ms à 5s)",
+		"monitor.help.click":             "Afficher les détails d'un log ou d'un événement",
+		"monitor.help.drag":              "Redimensionner les panneaux via leurs séparateurs",
+		"monitor.help.escape":            "Fermer la fenêtre de détails ou d'aide",
+		"monitor.help.export":            "Exporter les graphiques en images (SVG ou PNG)",
+		"monitor.export.done":            "Export: %d fichier(s) dans %s",
+		"monitor.export.failed":          "Échec de l'export: %v",
+		"monitor.playback.speed":         "Lecture: x%g",
+		"monitor.help.playback":          "Ralentir / accélérer la lecture d'une session",
+		"monitor.help.key.click":         "Clic",
+		"monitor.help.key.drag":          "Glisser",
+		"monitor.help.key.escape":        "Échap",
+		"monitor.diagnostics.none":       "Aucune ligne invalide",
+		"monitor.diagnostics.count":      "%s: %d ligne(s) invalide(s)",
+		"monitor.diagnostics.last":       "  Dernière: %s",
+		"monitor.kafka.disabled":         "Sondage désactivé (monitor.cluster_probe)",
+		"monitor.kafka.pending":          "Interrogation du cluster...",
+		"monitor.kafka.unreachable":      "Injoignable: %s",
+		"monitor.kafka.brokers":          "Brokers: %d",
+		"monitor.kafka.partitions":       "Partitions (%s): %d",
+		"monitor.kafka.isr_ok":           "ISR: OK",
+		"monitor.kafka.under_replicated": "ISR: %d partition(s) sous-répliquée(s)",
+		"monitor.kafka.offline":          "%d partition(s) sans leader",
+		"monitor.latency.disabled":       "Panneau désactivé (monitor.latency_file)",
+		"monitor.latency.pending":        "En attente de pubsub latency (%s)...",
+		"monitor.latency.total":          "Total: %d msg | p50 %.1f | p99 %.1f",
+		"monitor.latency.unstamped":      "%d message(s) sans en-tête sent-at",
+		"monitor.latency.stale":          "Sonde inactive depuis %s",
+
+		// Monitor - résumé console
+		"monitor.summary.header":       "=== Résumé du moniteur [%s] (uptime %s) ===",
+		"monitor.summary.messages":     "Messages reçus: %d | traités: %d | échoués: %d | erreurs: %d",
+		"monitor.summary.throughput":   "Débit: %.2f msg/s %s (p50 %.2f | p95 %.2f | max %.2f)",
+		"monitor.summary.success_rate": "Taux de succès: %.2f%% %s",
+		"monitor.summary.quality":      "Qualité: %s",
+		"monitor.summary.clock_skew":   "Décalage d'horloge: %d entrée(s) horodatée(s) dans le futur (dernier écart %.1fs)",
+		"monitor.summary.dlq":          "DLQ: %d message(s) envoyé(s) | %d erreur(s) d'envoi",
+		"monitor.summary.orders":       "Commandes: %d | clients: %d | articles: %d",
+		"monitor.summary.revenue":      "Chiffre d'affaires %s: %s (%d commande(s) | p50 %s | p95 %s | max %s)",
+
+		// Bannières console
+		"producer.started":       "🟢 Le producteur est démarré et prêt à envoyer des messages...",
+		"producer.publishing":    "📤 Publication vers le sujet '%s'",
+		"producer.stop_signal":   "\n⚠️  Signal d'arrêt reçu. Arrêt de la production de nouveaux messages...",
+		"producer.flushing":      "⏳ Envoi des messages restants dans la file...",
+		"producer.unsent":        "⚠️  %d messages n'ont pas pu être envoyés.",
+		"producer.all_sent":      "✅ Tous les messages ont été envoyés avec succès.",
+		"tracker.running":        "🟢 Le consommateur est en cours d'exécution...",
+		"tracker.log_file":       "📝 Logs d'observabilité système dans %s",
+		"tracker.events_file":    "📋 Journalisation complète des messages dans %s",
+		"tracker.stop_signal":    "\n⚠️ Signal d'arrêt reçu...",
+		"tracker.stopped":        "🔴 Consommateur arrêté.",
+		"tracker.order.received": "📦 COMMANDE REÇUE #%d (ID: %s)",
+		"tracker.order.customer": "Client: %s (%s)",
+		"tracker.order.status":   "Statut: %s | Total: %s %s",
+		"tracker.order.items":    "Articles:",
+		"common.init_error":      "Erreur fatale lors de l'initialisation: %v",
+		"common.config_file":     "Configuration chargée depuis %s",
+		"common.config_defaults": "Aucun fichier de configuration trouvé, valeurs par défaut utilisées",
+		"common.remote_changed":  "Configuration distante modifiée, redémarrez pour l'appliquer",
+		"common.remote_invalid":  "Configuration distante invalide ignorée: %v",
+
+		// dlqctl browse - inspecteur de la DLQ
+		"dlq.title.list":      "Dead Letter Queue (%d messages) | Entrée: détails | r: rejouer | d: écarter | q: quitter",
+		"dlq.empty":           "Aucun message dans la DLQ",
+		"dlq.invalid":         "enveloppe invalide: %v",
+		"dlq.statusbar":       "j/k: sélection | Entrée: détails | Échap: fermer | r: rejouer | d: écarter | q: quitter",
+		"dlq.state.pending":   "en attente",
+		"dlq.state.replayed":  "rejoué",
+		"dlq.state.discarded": "écarté",
+		"dlq.action.ok":       "Message %s %s",
+		"dlq.action.done":     "Message %s déjà traité (%s)",
+		"dlq.action.failed":   "Échec de l'action sur le message %s: %v",
+		"dlq.details":         "Position DLQ: %d/%d\nOrigine: %s@%d/%d\nTentatives: %d (palier %d)\nPremier échec: %s\nÉchec: %s\nTraitement: %s (groupe %s, machine %s)\nÉtat: %s\nErreur [%s]: %s\n\n%s",
+
+		// Erreurs de pkg/models, par code (voir LocalizeError)
+		"error.ERR_ORDER_ID_REQUIRED":                   "order_id est obligatoire",
+		"error.ERR_SEQUENCE_NOT_POSITIVE":               "la séquence doit être positive",
+		"error.ERR_STATUS_REQUIRED":                     "le statut est obligatoire",
+		"error.ERR_ITEMS_REQUIRED":                      "la commande doit contenir au moins un article",
+		"error.ERR_CUSTOMER_ID_REQUIRED":                "customer_id est obligatoire",
+		"error.ERR_CUSTOMER_NAME_REQUIRED":              "le nom du client est obligatoire",
+		"error.ERR_EMAIL_INVALID":                       "format d'e-mail invalide",
+		"error.ERR_ITEM_ID_REQUIRED":                    "item_id est obligatoire",
+		"error.ERR_ITEM_NAME_REQUIRED":                  "item_name est obligatoire",
+		"error.ERR_QUANTITY_NOT_POSITIVE":               "la quantité doit être positive",
+		"error.ERR_UNIT_PRICE_NOT_POSITIVE":             "unit_price doit être positif",
+		"error.ERR_TOTAL_PRICE_MISMATCH":                "total_price ne correspond pas à quantité * unit_price",
+		"error.ERR_SUBTOTAL_MISMATCH":                   "le sous-total ne correspond pas à la somme des articles",
+		"error.ERR_TAX_NEGATIVE":                        "la taxe doit être positive ou nulle",
+		"error.ERR_TOTAL_MISMATCH":                      "le total ne correspond pas à sous-total + taxe + frais de port",
+		"error.ERR_SCHEMA_VERSION_UNSUPPORTED":          "version du schéma de commande non supportée",
+		"error.ERR_SCHEMA_MISMATCH":                     "la commande ne correspond pas au schéma",
+		"error.ERR_EVENT_TYPE_UNKNOWN":                  "type d'événement inconnu",
+		"error.ERR_REASON_REQUIRED":                     "le motif est obligatoire",
+		"error.ERR_AMOUNT_NOT_POSITIVE":                 "le montant doit être positif",
+		"error.ERR_REFUND_NEGATIVE":                     "le remboursement doit être positif ou nul",
+		"error.ERR_STOCK_AVAILABLE":                     "la quantité disponible couvre la quantité demandée",
+		"error.ERR_CHANGES_REQUIRED":                    "le delta doit contenir au moins une modification",
+		"error.ERR_ORDER_MISMATCH":                      "les commandes ont des order_id différents",
+		"error.ERR_DIFF_CONFLICT":                       "la valeur courante ne correspond pas à l'ancienne valeur du delta",
+		"error.ERR_CURRENCY_INVALID":                    "la devise doit être un code ISO 4217",
+		"error.ERR_CURRENCY_MISMATCH":                   "les montants doivent être dans la même devise",
+		"error.ERR_CLOUDEVENT_ATTRIBUTE_REQUIRED":       "attribut CloudEvent obligatoire",
+		"error.ERR_CLOUDEVENT_SPEC_VERSION_UNSUPPORTED": "version de la spécification CloudEvents non supportée",
+		"error.ERR_CLOUDEVENT_CONTENT_TYPE_UNSUPPORTED": "type de contenu CloudEvent non supporté",
+		"error.ERR_FIELD_UNKNOWN":                       "champ inconnu",
+		"error.ERR_FIELD_WRONG_TYPE":                    "type incorrect",
+		"error.ERR_FIELD_REQUIRED":                      "champ obligatoire absent",
+		"error.ERR_PARTITION_STRATEGY_UNKNOWN":          "stratégie de partitionnement inconnue",
+	},
+	LocaleEN: {
+		// Monitor - widget titles
+		"monitor.title.success_gauge":      "Success Rate",
+		"monitor.title.quality_gauge":      "Quality",
+		"monitor.title.logs":               "Recent Logs (tracker.log)",
+		"monitor.title.events":             "Recent Events (tracker.events)",
+		"monitor.title.throughput":         "Message Throughput (msg/s)",
+		"monitor.title.throughput_stats":   "Message Throughput (msg/s) | current %.2f | p50 %.2f | p95 %.2f | max %.2f",
+		"monitor.title.success_rate":       "Success Rate (%)",
+		"monitor.title.message_size":       "Message Size (bytes)",
+		"monitor.title.partitions":         "Partitions (messages)",
+		"monitor.title.partitions_offsets": "Partitions | last offset %s",
+		"monitor.title.diagnostics":        "Parse Diagnostics",
+		"monitor.title.kafka":              "Kafka",
+		"monitor.title.latency":            "Delivery Latency (ms)",
+		"monitor.title.latency_history":    "Delivery Latency p99 (ms)",
+		"monitor.title.help":               "Help (? or Esc to close)",
+		"monitor.title.details":            "Details (Esc to close)",
+		"monitor.details.error":            "Error [%s]: %s",
+
+		// Monitor - tables
+		"monitor.metrics.header.metric":   "Metric",
+		"monitor.metrics.header.value":    "Value",
+		"monitor.metrics.received":        "Messages received",
+		"monitor.metrics.processed":       "Messages processed",
+		"monitor.metrics.failed":          "Messages failed",
+		"monitor.metrics.throughput":      "Throughput (msg/s)",
+		"monitor.metrics.success_rate":    "Success rate",
+		"monitor.metrics.last_update":     "Last update",
+		"monitor.health.header.indicator": "Indicator",
+		"monitor.health.header.status":    "Status",
+		"monitor.health.global":           "Overall Health",
+		"monitor.health.throughput":       "Throughput",
+		"monitor.health.errors":           "Errors",
+		"monitor.health.uptime":           "Uptime",
+		"monitor.health.processes":        "Processes",
+
+		// Monitor - health texts
+		"monitor.status.unknown":    "● UNKNOWN",
+		"monitor.status.excellent":  "● EXCELLENT",
+		"monitor.status.good":       "● GOOD",
+		"monitor.status.warning":    "● WARNING",
+		"monitor.status.critical":   "● CRITICAL",
+		"monitor.status.normal":     "● NORMAL",
+		"monitor.status.low":        "● LOW",
+		"monitor.status.stopped":    "● STOPPED",
+		"monitor.status.no_error":   "● NONE",
+		"monitor.status.recent":     "● RECENT",
+		"monitor.status.active":     "● ACTIVE",
+		"monitor.quality.excellent": "EXCELLENT (%.0f)",
+		"monitor.quality.good":      "GOOD (%.0f)",
+		"monitor.quality.medium":    "FAIR (%.0f)",
+		"monitor.quality.low":       "POOR (%.0f)",
+
+		// Monitor - lists
+		"monitor.logs.waiting":   "Waiting for logs...",
+		"monitor.events.waiting": "Waiting for events...",
+
+		// Monitor - status bar and help
+		"monitor.file.waiting":           "waiting",
+		"monitor.file.watching":          "OK",
+		"monitor.file.missing":           "missing",
+		"monitor.process.unknown":        "?",
+		"monitor.process.up":             "UP",
+		"monitor.process.down":           "DOWN",
+		"monitor.filter.none":            "none",
+		"monitor.filter.errors":          "errors only",
+		"monitor.state.running":          "▶ running",
+		"monitor.state.paused":           "⏸ PAUSED",
+		"monitor.statusbar":              "%s | Files: %s | Filters: %s | Refresh: %s | Last update: %s | ?: help",
+		"monitor.help.quit":              "Quit the monitor",
+		"monitor.help.help":              "Show / hide this help",
+		"monitor.help.pause":             "Pause / resume refreshing",
+		"monitor.help.filter":            "Show only failed logs and events",
+		"monitor.help.rate":              "Speed up / slow down refreshing (100ms to 5s)",
+		"monitor.help.click":             "Show the details of a log or event",
+		"monitor.help.drag":              "Resize panes using their dividers",
+		"monitor.help.escape":            "Close the details or help window",
+		"monitor.help.export":            "Export the charts as images (SVG or PNG)",
+		"monitor.export.done":            "Export: %d file(s) in %s",
+		"monitor.export.failed":          "Export failed: %v",
+		"monitor.playback.speed":         "Playback: x%g",
+		"monitor.help.playback":          "Slow down / speed up session playback",
+		"monitor.help.key.click":         "Click",
+		"monitor.help.key.drag":          "Drag",
+		"monitor.help.key.escape":        "Esc",
+		"monitor.diagnostics.none":       "No invalid line",
+		"monitor.diagnostics.count":      "%s: %d invalid line(s)",
+		"monitor.diagnostics.last":       "  Last: %s",
+		"monitor.kafka.disabled":         "Probing disabled (monitor.cluster_probe)",
+		"monitor.kafka.pending":          "Querying cluster...",
+		"monitor.kafka.unreachable":      "Unreachable: %s",
+		"monitor.kafka.brokers":          "Brokers: %d",
+		"monitor.kafka.partitions":       "Partitions (%s): %d",
+		"monitor.kafka.isr_ok":           "ISR: OK",
+		"monitor.kafka.under_replicated": "ISR: %d under-replicated partition(s)",
+		"monitor.kafka.offline":          "%d partition(s) without leader",
+		"monitor.latency.disabled":       "Panel disabled (monitor.latency_file)",
+		"monitor.latency.pending":        "Waiting for pubsub latency (%s)...",
+		"monitor.latency.total":          "Total: %d msg | p50 %.1f | p99 %.1f",
+		"monitor.latency.unstamped":      "%d message(s) without sent-at header",
+		"monitor.latency.stale":          "Probe idle for %s",
+
+		// Monitor - console summary
+		"monitor.summary.header":       "=== Monitor summary [%s] (uptime %s) ===",
+		"monitor.summary.messages":     "Messages received: %d | processed: %d | failed: %d | errors: %d",
+		"monitor.summary.throughput":   "Throughput: %.2f msg/s %s (p50 %.2f | p95 %.2f | max %.2f)",
+		"monitor.summary.success_rate": "Success rate: %.2f%% %s",
+		"monitor.summary.quality":      "Quality: %s",
+		"monitor.summary.clock_skew":   "Clock skew: %d entry(ies) timestamped in the future (last offset %.1fs)",
+		"monitor.summary.dlq":          "DLQ: %d message(s) sent | %d send error(s)",
+		"monitor.summary.orders":       "Orders: %d | customers: %d | items: %d",
+		"monitor.summary.revenue":      "Revenue %s: %s (%d order(s) | p50 %s | p95 %s | max %s)",
+
+		// Console banners
+		"producer.started":       "🟢 The producer is started and ready to send messages...",
+		"producer.publishing":    "📤 Publishing to topic '%s'",
+		"producer.stop_signal":   "\n⚠️  Stop signal received. Stopping new message production...",
+		"producer.flushing":      "⏳ Sending remaining messages in queue...",
+		"producer.unsent":        "⚠️  %d messages could not be sent.",
+		"producer.all_sent":      "✅ All messages sent successfully.",
+		"tracker.running":        "🟢 The consumer is running...",
+		"tracker.log_file":       "📝 System observability logs in %s",
+		"tracker.events_file":    "📋 Full message journal in %s",
+		"tracker.stop_signal":    "\n⚠️ Stop signal received...",
+		"tracker.stopped":        "🔴 Consumer stopped.",
+		"tracker.order.received": "📦 ORDER RECEIVED #%d (ID: %s)",
+		"tracker.order.customer": "Customer: %s (%s)",
+		"tracker.order.status":   "Status: %s | Total: %s %s",
+		"tracker.order.items":    "Items:",
+		"common.init_error":      "Fatal error during initialization: %v",
+		"common.config_file":     "Configuration loaded from %s",
+		"common.config_defaults": "No configuration file found, using defaults",
+		"common.remote_changed":  "Remote configuration changed, restart to apply it",
+		"common.remote_invalid":  "Invalid remote configuration ignored: %v",
+
+		// dlqctl browse - DLQ inspector
+		"dlq.title.list":      "Dead Letter Queue (%d messages) | Enter: details | r: replay | d: discard | q: quit",
+		"dlq.empty":           "No message in the DLQ",
+		"dlq.invalid":         "invalid envelope: %v",
+		"dlq.statusbar":       "j/k: select | Enter: details | Esc: close | r: replay | d: discard | q: quit",
+		"dlq.state.pending":   "pending",
+		"dlq.state.replayed":  "replayed",
+		"dlq.state.discarded": "discarded",
+		"dlq.action.ok":       "Message %s %s",
+		"dlq.action.done":     "Message %s already handled (%s)",
+		"dlq.action.failed":   "Action on message %s failed: %v",
+		"dlq.details":         "DLQ position: %d/%d\nOrigin: %s@%d/%d\nAttempts: %d (tier %d)\nFirst seen: %s\nFailed at: %s\nHandler: %s (group %s, host %s)\nState: %s\nError [%s]: %s\n\n%s",
+
+		// pkg/models errors, by code (see LocalizeError)
+		"error.ERR_ORDER_ID_REQUIRED":                   "order_id is required",
+		"error.ERR_SEQUENCE_NOT_POSITIVE":               "sequence must be positive",
+		"error.ERR_STATUS_REQUIRED":                     "status is required",
+		"error.ERR_ITEMS_REQUIRED":                      "order must contain at least one item",
+		"error.ERR_CUSTOMER_ID_REQUIRED":                "customer_id is required",
+		"error.ERR_CUSTOMER_NAME_REQUIRED":              "customer name is required",
+		"error.ERR_EMAIL_INVALID":                       "invalid email format",
+		"error.ERR_ITEM_ID_REQUIRED":                    "item_id is required",
+		"error.ERR_ITEM_NAME_REQUIRED":                  "item_name is required",
+		"error.ERR_QUANTITY_NOT_POSITIVE":               "quantity must be positive",
+		"error.ERR_UNIT_PRICE_NOT_POSITIVE":             "unit_price must be positive",
+		"error.ERR_TOTAL_PRICE_MISMATCH":                "total_price does not match quantity * unit_price",
+		"error.ERR_SUBTOTAL_MISMATCH":                   "subtotal does not match sum of items",
+		"error.ERR_TAX_NEGATIVE":                        "tax must be positive or zero",
+		"error.ERR_TOTAL_MISMATCH":                      "total is inconsistent with subtotal + tax + shipping fee",
+		"error.ERR_SCHEMA_VERSION_UNSUPPORTED":          "unsupported order schema version",
+		"error.ERR_SCHEMA_MISMATCH":                     "order does not match the schema",
+		"error.ERR_EVENT_TYPE_UNKNOWN":                  "unknown event type",
+		"error.ERR_REASON_REQUIRED":                     "reason is required",
+		"error.ERR_AMOUNT_NOT_POSITIVE":                 "amount must be positive",
+		"error.ERR_REFUND_NEGATIVE":                     "refund must be positive or zero",
+		"error.ERR_STOCK_AVAILABLE":                     "available quantity covers the requested quantity",
+		"error.ERR_CHANGES_REQUIRED":                    "diff must contain at least one change",
+		"error.ERR_ORDER_MISMATCH":                      "orders have different order_id",
+		"error.ERR_DIFF_CONFLICT":                       "current value does not match the old value of the diff",
+		"error.ERR_CURRENCY_INVALID":                    "currency must be an ISO 4217 code",
+		"error.ERR_CURRENCY_MISMATCH":                   "amounts must share the same currency",
+		"error.ERR_CLOUDEVENT_ATTRIBUTE_REQUIRED":       "cloudevent attribute is required",
+		"error.ERR_CLOUDEVENT_SPEC_VERSION_UNSUPPORTED": "unsupported cloudevents spec version",
+		"error.ERR_CLOUDEVENT_CONTENT_TYPE_UNSUPPORTED": "unsupported cloudevent data content type",
+		"error.ERR_FIELD_UNKNOWN":                       "unknown field",
+		"error.ERR_FIELD_WRONG_TYPE":                    "wrong type",
+		"error.ERR_FIELD_REQUIRED":                      "missing required field",
+		"error.ERR_PARTITION_STRATEGY_UNKNOWN":          "unknown partition strategy",
+	},
+}
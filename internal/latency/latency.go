@@ -0,0 +1,323 @@
+/*
+Package latency measures the delivery latency of the orders published by the
+producer.
+
+With producer.stamp_send_time, the producer stamps every message with its send
+time (models.HeaderSentAt, read from a models.SendClock). The probe reads the
+topic with a consumer group of its own and computes, for each partition, the
+distribution of the delay between the send time and the reception. Every
+interval, it streams the distribution of the elapsed window as a JSON line
+(Snapshot), shown by the latency panel of the monitor; when it stops, the
+distribution of the whole run is its summary.
+*/
+package latency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/agbruneau/PubSub/pkg/transport"
+)
+
+// Stats summarizes a latency distribution, in milliseconds.
+type Stats struct {
+	Count int64   `json:"count"` // Measured messages.
+	Min   float64 `json:"min"`
+	Mean  float64 `json:"mean"`
+	P50   float64 `json:"p50"`
+	P90   float64 `json:"p90"`
+	P99   float64 `json:"p99"`
+	Max   float64 `json:"max"`
+}
+
+// PartitionStats is the latency distribution of a partition.
+type PartitionStats struct {
+	Partition int32 `json:"partition"`
+	Stats
+}
+
+// Snapshot is the latency distribution of the messages received between
+// Start and End: a window of the stream, or the whole run for the summary.
+type Snapshot struct {
+	Topic      string           `json:"topic"`
+	Start      time.Time        `json:"start"`
+	End        time.Time        `json:"end"`
+	Total      Stats            `json:"total"`      // All partitions.
+	Partitions []PartitionStats `json:"partitions"` // In ascending partition order.
+	Unstamped  int64            `json:"unstamped"`  // Messages without a valid sent-at header.
+}
+
+// Config contains the parameters of the probe.
+type Config struct {
+	Topic       string        // Measured topic.
+	Interval    time.Duration // Duration of each streamed window.
+	ReadTimeout time.Duration // Timeout of each read of the subscriber (at most Interval).
+	Duration    time.Duration // Duration of the run (0: until ctx is cancelled).
+}
+
+// DefaultConfig returns the default parameters of the probe: 5 s windows on
+// the orders topic, until stopped.
+//
+// Returns:
+//   - Config: The default configuration.
+func DefaultConfig() Config {
+	return Config{
+		Topic:       "orders",
+		Interval:    5 * time.Second,
+		ReadTimeout: 100 * time.Millisecond,
+	}
+}
+
+// Recorder accumulates the latencies of the received messages, for the
+// current window and for the whole run. It is not safe for concurrent use.
+type Recorder struct {
+	topic  string
+	window samples // Current window, reset by Window.
+	run    samples // Whole run.
+}
+
+// samples holds the latencies of a period, per partition.
+type samples struct {
+	start     time.Time
+	latencies map[int32][]time.Duration
+	unstamped int64
+}
+
+// NewRecorder creates a recorder whose first window starts at now.
+//
+// Parameters:
+//   - topic: The measured topic.
+//   - now: The start of the run.
+//
+// Returns:
+//   - *Recorder: The recorder.
+func NewRecorder(topic string, now time.Time) *Recorder {
+	return &Recorder{topic: topic, window: newSamples(now), run: newSamples(now)}
+}
+
+// newSamples creates an empty period.
+//
+// Parameters:
+//   - start: The start of the period.
+//
+// Returns:
+//   - samples: The period.
+func newSamples(start time.Time) samples {
+	return samples{start: start, latencies: make(map[int32][]time.Duration)}
+}
+
+// Observe records the latency of a received message. A send time after the
+// reception (clock skew between the producer and probe hosts) counts as a
+// zero latency.
+//
+// Parameters:
+//   - msg: The message.
+//   - at: The reception time.
+func (r *Recorder) Observe(msg *transport.Message, at time.Time) {
+	value, _ := msg.Header(models.HeaderSentAt)
+	sentAt, err := models.ParseSentAt(value)
+	if err != nil {
+		r.window.unstamped++
+		r.run.unstamped++
+		return
+	}
+	d := at.Sub(sentAt)
+	if d < 0 {
+		d = 0
+	}
+	r.window.latencies[msg.Partition] = append(r.window.latencies[msg.Partition], d)
+	r.run.latencies[msg.Partition] = append(r.run.latencies[msg.Partition], d)
+}
+
+// Window returns the distribution of the current window and starts a new one.
+//
+// Parameters:
+//   - now: The end of the window.
+//
+// Returns:
+//   - Snapshot: The window distribution.
+func (r *Recorder) Window(now time.Time) Snapshot {
+	s := r.window.snapshot(r.topic, now)
+	r.window = newSamples(now)
+	return s
+}
+
+// Summary returns the distribution of the whole run.
+//
+// Parameters:
+//   - now: The end of the run.
+//
+// Returns:
+//   - Snapshot: The run distribution.
+func (r *Recorder) Summary(now time.Time) Snapshot {
+	return r.run.snapshot(r.topic, now)
+}
+
+// snapshot computes the distribution of the period.
+//
+// Parameters:
+//   - topic: The measured topic.
+//   - end: The end of the period.
+//
+// Returns:
+//   - Snapshot: The distribution.
+func (s samples) snapshot(topic string, end time.Time) Snapshot {
+	snap := Snapshot{Topic: topic, Start: s.start, End: end, Partitions: []PartitionStats{}, Unstamped: s.unstamped}
+	var all []time.Duration
+	for partition, latencies := range s.latencies {
+		all = append(all, latencies...)
+		snap.Partitions = append(snap.Partitions, PartitionStats{Partition: partition, Stats: summarize(latencies)})
+	}
+	sort.Slice(snap.Partitions, func(i, j int) bool { return snap.Partitions[i].Partition < snap.Partitions[j].Partition })
+	snap.Total = summarize(all)
+	return snap
+}
+
+// summarize computes the distribution, with nearest-rank percentiles.
+//
+// Parameters:
+//   - latencies: The latencies; sorted in place.
+//
+// Returns:
+//   - Stats: The distribution (zero without latency).
+func summarize(latencies []time.Duration) Stats {
+	n := len(latencies)
+	if n == 0 {
+		return Stats{}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	var sum time.Duration
+	for _, l := range latencies {
+		sum += l
+	}
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p / 100 * float64(n)))
+		if rank < 1 {
+			rank = 1
+		}
+		return millis(latencies[rank-1])
+	}
+	return Stats{
+		Count: int64(n),
+		Min:   millis(latencies[0]),
+		Mean:  millis(sum / time.Duration(n)),
+		P50:   percentile(50),
+		P90:   percentile(90),
+		P99:   percentile(99),
+		Max:   millis(latencies[n-1]),
+	}
+}
+
+// millis converts a duration to milliseconds, rounded to the microsecond.
+//
+// Parameters:
+//   - d: The duration.
+//
+// Returns:
+//   - float64: The milliseconds.
+func millis(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Microsecond)) / 1000
+}
+
+// Run executes the probe: it subscribes to the topic, measures the latency of
+// every received message and writes the distribution of each window to the
+// stream, until cfg.Duration elapsed or ctx is cancelled; the last window ends
+// with the run.
+//
+// Parameters:
+//   - ctx: Cancelling it stops the probe.
+//   - cfg: The probe parameters.
+//   - sub: The subscriber, not yet subscribed, member of a group of its own.
+//   - stream: The destination of the window snapshots, one JSON object per line.
+//
+// Returns:
+//   - Snapshot: The distribution of the whole run.
+//   - error: An error if the interval is not positive, the subscription fails
+//     or a snapshot cannot be written.
+func Run(ctx context.Context, cfg Config, sub transport.Subscriber, stream io.Writer) (Snapshot, error) {
+	if cfg.Interval <= 0 {
+		return Snapshot{}, fmt.Errorf("latency: interval must be positive (got %s)", cfg.Interval)
+	}
+	if err := sub.Subscribe([]string{cfg.Topic}); err != nil {
+		return Snapshot{}, fmt.Errorf("latency: subscribe: %w", err)
+	}
+
+	if cfg.ReadTimeout <= 0 || cfg.ReadTimeout > cfg.Interval {
+		cfg.ReadTimeout = cfg.Interval
+	}
+
+	start := time.Now()
+	rec := NewRecorder(cfg.Topic, start)
+	enc := json.NewEncoder(stream)
+	next := start.Add(cfg.Interval)
+	for {
+		now := time.Now()
+		stopped := ctx.Err() != nil || (cfg.Duration > 0 && now.Sub(start) >= cfg.Duration)
+		if stopped || !now.Before(next) {
+			// The last window, shorter, ends with the run
+			if err := enc.Encode(rec.Window(now)); err != nil {
+				return rec.Summary(now), fmt.Errorf("latency: write snapshot: %w", err)
+			}
+			next = now.Add(cfg.Interval)
+		}
+		if stopped {
+			return rec.Summary(now), nil
+		}
+
+		msg, err := sub.Receive(cfg.ReadTimeout)
+		switch {
+		case err == nil:
+			rec.Observe(msg, time.Now())
+		case errors.Is(err, transport.ErrTimeout):
+		default:
+			// Broker unavailable or transient read error: retried on the next read
+			time.Sleep(cfg.ReadTimeout)
+		}
+	}
+}
+
+// WriteJSON writes the indented JSON snapshot.
+//
+// Parameters:
+//   - w: The destination.
+//
+// Returns:
+//   - error: A write error.
+func (s Snapshot) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// WriteText writes the human-readable distribution, one row per partition.
+//
+// Parameters:
+//   - w: The destination.
+//
+// Returns:
+//   - error: A write error.
+func (s Snapshot) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "Partition\tMessages\tMin\tMean\tp50\tp90\tp99\tMax\t\n")
+	row := func(name string, st Stats) {
+		fmt.Fprintf(tw, "%s\t%d\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t%.3f\t\n", name, st.Count, st.Min, st.Mean, st.P50, st.P90, st.P99, st.Max)
+	}
+	for _, p := range s.Partitions {
+		row(fmt.Sprintf("P%d", p.Partition), p.Stats)
+	}
+	row("Total", s.Total)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "Latency in ms on topic %s over %.1fs; %d message(s) without %s header\n",
+		s.Topic, s.End.Sub(s.Start).Seconds(), s.Unstamped, models.HeaderSentAt)
+	return err
+}
@@ -0,0 +1,145 @@
+package latency
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/agbruneau/PubSub/pkg/transport"
+	"github.com/agbruneau/PubSub/pkg/transport/memory"
+)
+
+// stamped returns a message of a partition sent at the given time.
+func stamped(partition int32, sentAt time.Time) *transport.Message {
+	return &transport.Message{
+		Topic:     "orders",
+		Partition: partition,
+		Headers:   []transport.Header{{Key: models.HeaderSentAt, Value: []byte(strconv.FormatInt(sentAt.UnixNano(), 10))}},
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	start := time.Unix(1000, 0)
+	rec := NewRecorder("orders", start)
+	at := start.Add(time.Second)
+	for i := 1; i <= 10; i++ {
+		rec.Observe(stamped(1, at.Add(-time.Duration(i)*time.Millisecond)), at)
+	}
+	rec.Observe(stamped(0, at.Add(-20*time.Millisecond)), at)
+	rec.Observe(stamped(0, at.Add(time.Second)), at) // Producer clock ahead of the probe.
+	rec.Observe(&transport.Message{Topic: "orders"}, at)
+
+	window := rec.Window(at)
+	if len(window.Partitions) != 2 || window.Partitions[0].Partition != 0 || window.Partitions[1].Partition != 1 {
+		t.Fatalf("Expected partitions 0 and 1, got %+v", window.Partitions)
+	}
+	want := Stats{Count: 10, Min: 1, Mean: 5.5, P50: 5, P90: 9, P99: 10, Max: 10}
+	if window.Partitions[1].Stats != want {
+		t.Errorf("Expected %+v for partition 1, got %+v", want, window.Partitions[1].Stats)
+	}
+	if p0 := window.Partitions[0].Stats; p0.Count != 2 || p0.Min != 0 || p0.Max != 20 {
+		t.Errorf("Unexpected partition 0 distribution %+v", p0)
+	}
+	if window.Total.Count != 12 || window.Total.Max != 20 || window.Unstamped != 1 {
+		t.Errorf("Unexpected total %+v (%d unstamped)", window.Total, window.Unstamped)
+	}
+
+	// The next window starts empty; the summary covers the whole run.
+	next := rec.Window(at.Add(time.Second))
+	if next.Total.Count != 0 || len(next.Partitions) != 0 || !next.Start.Equal(at) {
+		t.Errorf("Expected an empty window starting at %s, got %+v", at, next)
+	}
+	if summary := rec.Summary(at); summary.Total.Count != 12 || !summary.Start.Equal(start) {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+}
+
+func TestRunMemoryBroker(t *testing.T) {
+	b := memory.NewBroker(2)
+	pub := b.NewProducer()
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		pub.Publish(stamped(int32(i%2), now.Add(-5*time.Millisecond)), nil)
+	}
+	pub.Publish(&transport.Message{Topic: "orders", Partition: transport.AnyPartition}, nil)
+
+	cfg := DefaultConfig()
+	cfg.Interval = 50 * time.Millisecond
+	cfg.ReadTimeout = 10 * time.Millisecond
+	cfg.Duration = 300 * time.Millisecond
+	var stream bytes.Buffer
+	summary, err := Run(context.Background(), cfg, b.NewConsumer("probe"), &stream)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if summary.Total.Count != 5 || summary.Unstamped != 1 || len(summary.Partitions) != 2 {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+	if summary.Total.Min < 5 {
+		t.Errorf("Expected latencies of at least 5ms, got %+v", summary.Total)
+	}
+
+	var windows, measured int64
+	scanner := bufio.NewScanner(&stream)
+	for scanner.Scan() {
+		var snap Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snap); err != nil {
+			t.Fatalf("Invalid snapshot %q: %v", scanner.Text(), err)
+		}
+		windows++
+		measured += snap.Total.Count
+	}
+	if windows < 2 || measured != 5 {
+		t.Errorf("Expected several windows measuring 5 messages, got %d windows and %d messages", windows, measured)
+	}
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Interval = 0
+	if _, err := Run(context.Background(), cfg, memory.NewBroker(1).NewConsumer("probe"), &bytes.Buffer{}); err == nil {
+		t.Error("Expected an error for a zero interval")
+	}
+}
+
+func TestSnapshotOutput(t *testing.T) {
+	snap := Snapshot{
+		Topic:      "orders",
+		Start:      time.Unix(0, 0),
+		End:        time.Unix(10, 0),
+		Total:      Stats{Count: 3, P99: 12.5},
+		Partitions: []PartitionStats{{Partition: 2, Stats: Stats{Count: 3, P99: 12.5}}},
+		Unstamped:  4,
+	}
+
+	var text bytes.Buffer
+	if err := snap.WriteText(&text); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, want := range []string{"P2", "Total", "12.500", "over 10.0s", "4 message(s) without sent-at"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Expected %q in the summary, got:\n%s", want, text.String())
+		}
+	}
+
+	var out bytes.Buffer
+	if err := snap.WriteJSON(&out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded Snapshot
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON summary: %v", err)
+	}
+	if decoded.Partitions[0].Partition != 2 || decoded.Partitions[0].P99 != 12.5 || decoded.Unstamped != 4 {
+		t.Errorf("Unexpected JSON summary %s", out.String())
+	}
+	if !strings.Contains(out.String(), `"p99": 12.5`) {
+		t.Errorf("Expected the partition stats to be inlined, got %s", out.String())
+	}
+}
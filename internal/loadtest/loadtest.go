@@ -0,0 +1,372 @@
+/*
+Package loadtest measures the throughput and the end-to-end latency of the
+configured broker.
+
+A run publishes generated orders at a fixed rate through a transport.Publisher
+and reads them back with an embedded transport.Subscriber. Every message
+carries the run ID, its sequence number and its send time as headers, so that
+the subscriber measures the latency of each message and counts the lost and
+duplicated ones; the messages of other runs are ignored.
+*/
+package loadtest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/fake"
+	"github.com/agbruneau/PubSub/pkg/transport"
+)
+
+// Headers of the load test messages.
+const (
+	// HeaderRun is the ID of the run that published the message.
+	HeaderRun = "loadtest-run"
+	// HeaderSequence is the sequence number of the message in its run, or
+	// probeSequence for the warm-up probes.
+	HeaderSequence = "loadtest-seq"
+	// HeaderSentAt is the send time of the message, in Unix nanoseconds.
+	HeaderSentAt = "loadtest-sent-at"
+)
+
+// probeSequence marks the warm-up probes, published until the subscriber
+// receives one (it then reads every partition of the topic).
+const probeSequence = "probe"
+
+// Pacing of a run.
+const (
+	// publishInterval is the interval between two publishing bursts.
+	publishInterval = 10 * time.Millisecond
+	// probeInterval is the interval between two warm-up probes.
+	probeInterval = 500 * time.Millisecond
+	// pollInterval is the polling interval of the drain.
+	pollInterval = 10 * time.Millisecond
+	// reportsBufferSize is the capacity of the delivery report channel.
+	reportsBufferSize = 10000
+)
+
+// Config contains the parameters of a load test run.
+type Config struct {
+	RunID        string        // Run ID (empty: generated by Run).
+	Topic        string        // Topic of the messages.
+	Rate         int           // Target rate, in messages per second.
+	Duration     time.Duration // Publishing duration.
+	WarmUp       time.Duration // Maximum wait for the subscriber before publishing.
+	Drain        time.Duration // Maximum wait for the messages after the last publish.
+	ReadTimeout  time.Duration // Timeout of each read of the subscriber.
+	FlushTimeout time.Duration // Maximum wait for the deliveries after the last publish.
+	Generator    fake.Config   // Generation of the published orders.
+}
+
+// DefaultConfig returns the default parameters of a run: 1000 messages per
+// second for one minute on the orders topic.
+//
+// Returns:
+//   - Config: The default configuration.
+func DefaultConfig() Config {
+	return Config{
+		Topic:        "orders",
+		Rate:         1000,
+		Duration:     time.Minute,
+		WarmUp:       30 * time.Second,
+		Drain:        30 * time.Second,
+		ReadTimeout:  100 * time.Millisecond,
+		FlushTimeout: 15 * time.Second,
+		Generator:    fake.DefaultConfig(),
+	}
+}
+
+// NewRunID returns a random run ID.
+//
+// Returns:
+//   - string: 16 hexadecimal characters.
+func NewRunID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
+
+// run holds the counters of a run, shared by the publishing loop, the
+// delivery report handler and the subscriber.
+type run struct {
+	cfg Config
+
+	sent           int64 // Messages accepted by the publisher.
+	publishErrors  int64 // Messages rejected by the publisher.
+	delivered      int64 // Messages confirmed by the broker.
+	deliveryErrors int64 // Messages whose delivery failed.
+	receiveErrors  int64 // Failed reads of the subscriber.
+
+	mu          sync.Mutex
+	seen        map[int64]bool  // Sequence numbers received.
+	duplicates  int64           // Messages received more than once.
+	latencies   []time.Duration // End-to-end latency of each first reception.
+	lastRecv    time.Time       // Last reception.
+	ready       chan struct{}   // Closed on the first probe received.
+	readyClosed bool
+}
+
+// Run executes a load test: it waits for the subscriber to receive a warm-up
+// probe, publishes at the target rate for the configured duration, then
+// waits for the remaining messages before computing the report.
+//
+// Parameters:
+//   - ctx: Cancelling it stops publishing early; the report covers the messages already sent.
+//   - cfg: The run parameters.
+//   - pub: The publisher.
+//   - sub: The subscriber, not yet subscribed, member of a group of its own.
+//
+// Returns:
+//   - *Report: The report of the run.
+//   - error: An error if the parameters are invalid, the subscription fails
+//     or the subscriber receives no probe before cfg.WarmUp.
+func Run(ctx context.Context, cfg Config, pub transport.Publisher, sub transport.Subscriber) (*Report, error) {
+	if cfg.Rate <= 0 || cfg.Duration <= 0 {
+		return nil, fmt.Errorf("loadtest: rate and duration must be positive (got %d/s for %s)", cfg.Rate, cfg.Duration)
+	}
+	if cfg.RunID == "" {
+		cfg.RunID = NewRunID()
+	}
+	if err := sub.Subscribe([]string{cfg.Topic}); err != nil {
+		return nil, fmt.Errorf("loadtest: subscribe: %w", err)
+	}
+
+	r := &run{cfg: cfg, seen: make(map[int64]bool), ready: make(chan struct{})}
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.receive(sub, stop)
+	}()
+	defer func() {
+		close(stop)
+		wg.Wait()
+	}()
+
+	reports := make(chan transport.Delivery, reportsBufferSize)
+	go r.handleReports(reports, stop)
+
+	if err := r.warmUp(ctx, pub); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	r.publish(ctx, pub, reports, start)
+	published := time.Since(start)
+	pub.Flush(cfg.FlushTimeout)
+	r.drain(ctx)
+
+	return r.report(start, published), nil
+}
+
+// warmUp publishes probes until the subscriber receives one.
+//
+// Parameters:
+//   - ctx: The run context.
+//   - pub: The publisher.
+//
+// Returns:
+//   - error: An error if no probe is received before cfg.WarmUp.
+func (r *run) warmUp(ctx context.Context, pub transport.Publisher) error {
+	deadline := time.NewTimer(r.cfg.WarmUp)
+	defer deadline.Stop()
+	ticker := time.NewTicker(probeInterval)
+	defer ticker.Stop()
+	for {
+		probe := r.message(probeSequence, nil)
+		if err := pub.Publish(probe, nil); err != nil {
+			return fmt.Errorf("loadtest: publish probe: %w", err)
+		}
+		select {
+		case <-r.ready:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return fmt.Errorf("loadtest: no probe received from topic %s within %s", r.cfg.Topic, r.cfg.WarmUp)
+		case <-ticker.C:
+		}
+	}
+}
+
+// publish sends generated orders at the target rate, in bursts every
+// publishInterval, until the duration elapsed or ctx is cancelled.
+//
+// Parameters:
+//   - ctx: The run context.
+//   - pub: The publisher.
+//   - reports: The delivery report channel.
+//   - start: The start of publishing.
+func (r *run) publish(ctx context.Context, pub transport.Publisher, reports chan<- transport.Delivery, start time.Time) {
+	generator := fake.New(r.cfg.Generator)
+	total := int64(float64(r.cfg.Rate) * r.cfg.Duration.Seconds())
+	ticker := time.NewTicker(publishInterval)
+	defer ticker.Stop()
+
+	var seq int64
+	for seq < total {
+		due := int64(float64(r.cfg.Rate) * time.Since(start).Seconds())
+		if due > total {
+			due = total
+		}
+		for ; seq < due; seq++ {
+			order, err := generator.Order(int(seq) + 1)
+			var value []byte
+			if err == nil {
+				value, err = json.Marshal(order)
+			}
+			if err == nil {
+				err = pub.Publish(r.message(strconv.FormatInt(seq, 10), value), reports)
+			}
+			if err != nil {
+				atomic.AddInt64(&r.publishErrors, 1)
+				continue
+			}
+			atomic.AddInt64(&r.sent, 1)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// message returns a load test message.
+//
+// Parameters:
+//   - seq: The sequence number, or probeSequence.
+//   - value: The payload.
+//
+// Returns:
+//   - *transport.Message: The message, stamped with the current time.
+func (r *run) message(seq string, value []byte) *transport.Message {
+	return &transport.Message{
+		Topic:     r.cfg.Topic,
+		Partition: transport.AnyPartition,
+		Value:     value,
+		Headers: []transport.Header{
+			{Key: HeaderRun, Value: []byte(r.cfg.RunID)},
+			{Key: HeaderSequence, Value: []byte(seq)},
+			{Key: HeaderSentAt, Value: []byte(strconv.FormatInt(time.Now().UnixNano(), 10))},
+		},
+	}
+}
+
+// handleReports counts the delivery reports until stop is closed.
+//
+// Parameters:
+//   - reports: The delivery report channel.
+//   - stop: Closed at the end of the run.
+func (r *run) handleReports(reports <-chan transport.Delivery, stop <-chan struct{}) {
+	for {
+		select {
+		case d := <-reports:
+			if d.Err != nil {
+				atomic.AddInt64(&r.deliveryErrors, 1)
+			} else {
+				atomic.AddInt64(&r.delivered, 1)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// receive reads the messages of the run until stop is closed.
+//
+// Parameters:
+//   - sub: The subscriber.
+//   - stop: Closed at the end of the run.
+func (r *run) receive(sub transport.Subscriber, stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		msg, err := sub.Receive(r.cfg.ReadTimeout)
+		if errors.Is(err, transport.ErrTimeout) {
+			continue
+		}
+		if err != nil {
+			atomic.AddInt64(&r.receiveErrors, 1)
+			time.Sleep(r.cfg.ReadTimeout)
+			continue
+		}
+		r.record(msg, time.Now())
+	}
+}
+
+// record counts a received message, if it belongs to the run.
+//
+// Parameters:
+//   - msg: The message.
+//   - at: The reception time.
+func (r *run) record(msg *transport.Message, at time.Time) {
+	if id, _ := msg.Header(HeaderRun); id != r.cfg.RunID {
+		return
+	}
+	seqHeader, _ := msg.Header(HeaderSequence)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if seqHeader == probeSequence {
+		if !r.readyClosed {
+			r.readyClosed = true
+			close(r.ready)
+		}
+		return
+	}
+	seq, err := strconv.ParseInt(seqHeader, 10, 64)
+	if err != nil {
+		return
+	}
+	if r.seen[seq] {
+		r.duplicates++
+		return
+	}
+	r.seen[seq] = true
+	if sentAt, ok := msg.Header(HeaderSentAt); ok {
+		if nanos, err := strconv.ParseInt(sentAt, 10, 64); err == nil {
+			r.latencies = append(r.latencies, at.Sub(time.Unix(0, nanos)))
+		}
+	}
+	r.lastRecv = at
+}
+
+// received returns the number of distinct messages received.
+//
+// Returns:
+//   - int64: The distinct messages.
+func (r *run) received() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return int64(len(r.seen))
+}
+
+// drain waits until every sent message is received, for at most cfg.Drain.
+//
+// Parameters:
+//   - ctx: The run context; cancelling it ends the wait.
+func (r *run) drain(ctx context.Context) {
+	deadline := time.Now().Add(r.cfg.Drain)
+	for r.received() < atomic.LoadInt64(&r.sent) && time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/transport"
+	"github.com/agbruneau/PubSub/pkg/transport/memory"
+)
+
+// testConfig returns a short run on the in-memory broker.
+func testConfig() Config {
+	cfg := DefaultConfig()
+	cfg.Topic = "loadtest"
+	cfg.Rate = 2000
+	cfg.Duration = 200 * time.Millisecond
+	cfg.WarmUp = 5 * time.Second
+	cfg.Drain = 5 * time.Second
+	cfg.ReadTimeout = 10 * time.Millisecond
+	cfg.FlushTimeout = time.Second
+	return cfg
+}
+
+func TestRunMemoryBroker(t *testing.T) {
+	b := memory.NewBroker(2)
+	// A message of another run is ignored.
+	b.NewProducer().Publish(&transport.Message{
+		Topic:   "loadtest",
+		Headers: []transport.Header{{Key: HeaderRun, Value: []byte("other")}, {Key: HeaderSequence, Value: []byte("0")}},
+	}, nil)
+
+	sub := b.NewConsumer("loadtest")
+	defer sub.Close()
+	rep, err := Run(context.Background(), testConfig(), b.NewProducer(), sub)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rep.Sent != 400 || rep.Delivered != 400 || rep.Received != 400 {
+		t.Errorf("Expected 400 messages sent, delivered and received, got %+v", rep)
+	}
+	if rep.Lost != 0 || rep.Duplicates != 0 || rep.PublishErrors != 0 || rep.DeliveryErrors != 0 {
+		t.Errorf("Expected no loss, duplicate or error, got %+v", rep)
+	}
+	if rep.RunID == "" || rep.PublishRate <= 0 || rep.ReceiveRate <= 0 {
+		t.Errorf("Expected a run ID and positive rates, got %+v", rep)
+	}
+	if rep.Latency.Max < rep.Latency.P50 || rep.Latency.P99 < rep.Latency.P50 {
+		t.Errorf("Unexpected latency summary %+v", rep.Latency)
+	}
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+	b := memory.NewBroker(1)
+	cfg := testConfig()
+	cfg.Rate = 0
+	if _, err := Run(context.Background(), cfg, b.NewProducer(), b.NewConsumer("loadtest")); err == nil {
+		t.Error("Expected an error for a zero rate")
+	}
+}
+
+func TestRunWarmUpTimeout(t *testing.T) {
+	b := memory.NewBroker(1)
+	cfg := testConfig()
+	cfg.WarmUp = 50 * time.Millisecond
+	// The probes go to another topic: none ever arrives.
+	sub := b.NewConsumer("loadtest")
+	if _, err := Run(context.Background(), cfg, redirect{b.NewProducer(), "elsewhere"}, sub); err == nil {
+		t.Error("Expected a warm-up error")
+	}
+}
+
+// redirect publishes every message to another topic.
+type redirect struct {
+	*memory.Producer
+	topic string
+}
+
+func (r redirect) Publish(msg *transport.Message, reports chan<- transport.Delivery) error {
+	copied := *msg
+	copied.Topic = r.topic
+	return r.Producer.Publish(&copied, reports)
+}
+
+func TestRecordDuplicates(t *testing.T) {
+	r := &run{cfg: Config{RunID: "run"}, seen: make(map[int64]bool), ready: make(chan struct{})}
+	msg := (&run{cfg: Config{RunID: "run"}}).message("3", nil)
+	r.record(msg, time.Now())
+	r.record(msg, time.Now())
+	if r.received() != 1 || r.duplicates != 1 || len(r.latencies) != 1 {
+		t.Errorf("Expected 1 message and 1 duplicate, got %d and %d", r.received(), r.duplicates)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	got := summarize(latencies)
+	want := Latency{Min: 1, Mean: 50.5, P50: 50, P90: 90, P95: 95, P99: 99, P999: 100, Max: 100}
+	if got != want {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+	if summarize(nil) != (Latency{}) {
+		t.Error("Expected a zero summary without latency")
+	}
+}
+
+func TestReportOutput(t *testing.T) {
+	rep := &Report{RunID: "abc", Topic: "orders", TargetRate: 2000, Sent: 10, Received: 9, Lost: 1, Latency: Latency{P99: 12.5}}
+
+	var text bytes.Buffer
+	if err := rep.WriteText(&text); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, want := range []string{"abc", "target 2000 msg/s", "10 sent", "1 / 0", "p99 12.500"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("Expected %q in the report, got:\n%s", want, text.String())
+		}
+	}
+
+	var out bytes.Buffer
+	if err := rep.WriteJSON(&out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON report: %v", err)
+	}
+	if decoded["lost"] != 1.0 || decoded["latency_ms"].(map[string]interface{})["p99"] != 12.5 {
+		t.Errorf("Unexpected JSON report %s", out.String())
+	}
+}
@@ -0,0 +1,167 @@
+package loadtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+)
+
+// Report is the result of a load test run. Rates are in messages per second
+// and latencies in milliseconds.
+type Report struct {
+	RunID           string  `json:"run_id"`
+	Topic           string  `json:"topic"`
+	TargetRate      int     `json:"target_rate"`
+	DurationSeconds float64 `json:"duration_seconds"` // Actual publishing duration.
+
+	Sent           int64 `json:"sent"`            // Messages accepted by the publisher.
+	PublishErrors  int64 `json:"publish_errors"`  // Messages rejected by the publisher.
+	Delivered      int64 `json:"delivered"`       // Messages confirmed by the broker.
+	DeliveryErrors int64 `json:"delivery_errors"` // Messages whose delivery failed.
+	Received       int64 `json:"received"`        // Distinct messages received.
+	Duplicates     int64 `json:"duplicates"`      // Extra receptions of a message.
+	Lost           int64 `json:"lost"`            // Sent messages never received.
+	ReceiveErrors  int64 `json:"receive_errors"`  // Failed reads of the subscriber.
+
+	PublishRate float64 `json:"publish_rate"` // Achieved publishing throughput.
+	ReceiveRate float64 `json:"receive_rate"` // Achieved end-to-end throughput.
+
+	Latency Latency `json:"latency_ms"`
+}
+
+// Latency summarizes the end-to-end latencies of a run, in milliseconds.
+type Latency struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	P999 float64 `json:"p999"`
+	Max  float64 `json:"max"`
+}
+
+// report computes the report of a finished run.
+//
+// Parameters:
+//   - start: The start of publishing.
+//   - published: The publishing duration.
+//
+// Returns:
+//   - *Report: The report.
+func (r *run) report(start time.Time, published time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rep := &Report{
+		RunID:           r.cfg.RunID,
+		Topic:           r.cfg.Topic,
+		TargetRate:      r.cfg.Rate,
+		DurationSeconds: published.Seconds(),
+		Sent:            atomic.LoadInt64(&r.sent),
+		PublishErrors:   atomic.LoadInt64(&r.publishErrors),
+		Delivered:       atomic.LoadInt64(&r.delivered),
+		DeliveryErrors:  atomic.LoadInt64(&r.deliveryErrors),
+		Received:        int64(len(r.seen)),
+		Duplicates:      r.duplicates,
+		ReceiveErrors:   atomic.LoadInt64(&r.receiveErrors),
+		Latency:         summarize(r.latencies),
+	}
+	rep.Lost = rep.Sent - rep.Received
+	if rep.Lost < 0 {
+		rep.Lost = 0
+	}
+	if published > 0 {
+		rep.PublishRate = float64(rep.Sent) / published.Seconds()
+	}
+	if window := r.lastRecv.Sub(start); rep.Received > 0 && window > 0 {
+		rep.ReceiveRate = float64(rep.Received) / window.Seconds()
+	}
+	return rep
+}
+
+// summarize computes the latency summary, with nearest-rank percentiles.
+//
+// Parameters:
+//   - latencies: The latencies; sorted in place.
+//
+// Returns:
+//   - Latency: The summary (zero without latency).
+func summarize(latencies []time.Duration) Latency {
+	n := len(latencies)
+	if n == 0 {
+		return Latency{}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	var sum time.Duration
+	for _, l := range latencies {
+		sum += l
+	}
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p / 100 * float64(n)))
+		if rank < 1 {
+			rank = 1
+		}
+		return millis(latencies[rank-1])
+	}
+	return Latency{
+		Min:  millis(latencies[0]),
+		Mean: millis(sum / time.Duration(n)),
+		P50:  percentile(50),
+		P90:  percentile(90),
+		P95:  percentile(95),
+		P99:  percentile(99),
+		P999: percentile(99.9),
+		Max:  millis(latencies[n-1]),
+	}
+}
+
+// millis converts a duration to milliseconds, rounded to the microsecond.
+//
+// Parameters:
+//   - d: The duration.
+//
+// Returns:
+//   - float64: The milliseconds.
+func millis(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Microsecond)) / 1000
+}
+
+// WriteJSON writes the indented JSON report.
+//
+// Parameters:
+//   - w: The destination.
+//
+// Returns:
+//   - error: A write error.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteText writes the human-readable report.
+//
+// Parameters:
+//   - w: The destination.
+//
+// Returns:
+//   - error: A write error.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Run\t%s (topic %s)\n", r.RunID, r.Topic)
+	fmt.Fprintf(tw, "Duration\t%.1fs\n", r.DurationSeconds)
+	fmt.Fprintf(tw, "Throughput\t%.0f msg/s published, %.0f msg/s received (target %d msg/s)\n", r.PublishRate, r.ReceiveRate, r.TargetRate)
+	fmt.Fprintf(tw, "Messages\t%d sent, %d delivered, %d received\n", r.Sent, r.Delivered, r.Received)
+	fmt.Fprintf(tw, "Errors\t%d publish, %d delivery, %d receive\n", r.PublishErrors, r.DeliveryErrors, r.ReceiveErrors)
+	fmt.Fprintf(tw, "Lost / duplicated\t%d / %d\n", r.Lost, r.Duplicates)
+	l := r.Latency
+	fmt.Fprintf(tw, "Latency (ms)\tmin %.3f  mean %.3f  p50 %.3f  p90 %.3f  p95 %.3f  p99 %.3f  p99.9 %.3f  max %.3f\n",
+		l.Min, l.Mean, l.P50, l.P90, l.P95, l.P99, l.P999, l.Max)
+	return tw.Flush()
+}
@@ -0,0 +1,188 @@
+package monitor
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// ErrKafkaUnsupported is returned when cluster probing is requested from a
+// monitor built without the "kafka" build tag.
+var ErrKafkaUnsupported = errors.New("monitor built without kafka support (use -tags kafka)")
+
+// ClusterMetadata summarizes the Kafka cluster state relevant to the monitored topic.
+type ClusterMetadata struct {
+	Brokers         int // Number of brokers in the cluster.
+	Partitions      int // Number of partitions of the topic.
+	UnderReplicated int // Partitions whose in-sync replicas are fewer than their replicas.
+	Offline         int // Partitions without a leader.
+}
+
+// MetadataSource queries the Kafka cluster metadata.
+// This abstraction keeps the monitor free of the Kafka client unless built with
+// the "kafka" tag, and simplifies testing.
+type MetadataSource interface {
+	// Metadata returns the cluster metadata for a topic.
+	//
+	// Parameters:
+	//   - topic: The monitored topic.
+	//   - timeout: The maximum wait time for the brokers to answer.
+	//
+	// Returns:
+	//   - ClusterMetadata: The cluster summary.
+	//   - error: An error if the cluster is unreachable or the topic is unknown.
+	Metadata(topic string, timeout time.Duration) (ClusterMetadata, error)
+}
+
+// ClusterHealth is the result of the last cluster probe.
+type ClusterHealth struct {
+	Topic     string          // The monitored topic.
+	Metadata  ClusterMetadata // The last metadata (zero if the probe failed).
+	Err       string          // The probe error ("" if the cluster answered).
+	CheckedAt time.Time       // Time of the last probe (zero before the first one).
+}
+
+var (
+	clusterMu      sync.RWMutex
+	clusterHealth  ClusterHealth
+	clusterEnabled bool
+)
+
+// ClusterHealthState returns the result of the last cluster probe.
+//
+// Returns:
+//   - ClusterHealth: The last probe result.
+//   - bool: False if cluster probing is disabled.
+func ClusterHealthState() (ClusterHealth, bool) {
+	clusterMu.RLock()
+	defer clusterMu.RUnlock()
+	return clusterHealth, clusterEnabled
+}
+
+// setClusterHealth records a cluster probe result and enables the cluster panel.
+//
+// Parameters:
+//   - h: The probe result.
+func setClusterHealth(h ClusterHealth) {
+	clusterMu.Lock()
+	defer clusterMu.Unlock()
+	clusterHealth = h
+	clusterEnabled = true
+}
+
+// ProbeCluster queries the cluster metadata once.
+//
+// Parameters:
+//   - src: The metadata source.
+//   - topic: The monitored topic.
+//
+// Returns:
+//   - ClusterHealth: The probe result.
+func ProbeCluster(src MetadataSource, topic string) ClusterHealth {
+	h := ClusterHealth{Topic: topic, CheckedAt: time.Now()}
+	md, err := src.Metadata(topic, ProbeTimeout)
+	if err != nil {
+		h.Err = err.Error()
+		return h
+	}
+	h.Metadata = md
+	return h
+}
+
+// WatchCluster periodically probes the Kafka cluster and records its health for
+// the Kafka panel and the global health status. It never returns, like MonitorFile.
+//
+// Parameters:
+//   - src: The metadata source.
+//   - topic: The monitored topic.
+//   - interval: The interval between two probes (ProbeInterval if not positive).
+func WatchCluster(src MetadataSource, topic string, interval time.Duration) {
+	if interval <= 0 {
+		interval = ProbeInterval
+	}
+	setClusterHealth(ClusterHealth{Topic: topic})
+	for {
+		setClusterHealth(ProbeCluster(src, topic))
+		time.Sleep(interval)
+	}
+}
+
+// GetClusterStatus determines the health status of the cluster.
+// An unreachable cluster, a missing topic or a partition without leader is
+// critical; under-replicated partitions are a warning.
+//
+// Parameters:
+//   - h: The last probe result.
+//
+// Returns:
+//   - HealthStatus: The health status (HealthGood before the first probe).
+//   - ui.Color: The associated color.
+func GetClusterStatus(h ClusterHealth) (HealthStatus, ui.Color) {
+	switch {
+	case h.CheckedAt.IsZero():
+		return HealthGood, ui.ColorWhite
+	case h.Err != "", h.Metadata.Brokers == 0, h.Metadata.Offline > 0:
+		return HealthCritical, ui.ColorRed
+	case h.Metadata.UnderReplicated > 0:
+		return HealthWarning, ui.ColorYellow
+	default:
+		return HealthGood, ui.ColorGreen
+	}
+}
+
+// CreateKafkaPanel initializes the Kafka broker health widget.
+//
+// Returns:
+//   - *widgets.Paragraph: The initialized paragraph widget.
+func CreateKafkaPanel() *widgets.Paragraph {
+	panel := widgets.NewParagraph()
+	panel.Title = i18n.T("monitor.title.kafka")
+	panel.Text = i18n.T("monitor.kafka.disabled")
+	panel.TextStyle = ui.NewStyle(ui.ColorWhite)
+	panel.WrapText = true
+	return panel
+}
+
+// UpdateKafkaPanel displays the broker count, the topic partition count and
+// the ISR status of the last cluster probe.
+//
+// Parameters:
+//   - panel: The Kafka widget.
+//   - h: The last probe result.
+//   - enabled: Whether cluster probing is enabled.
+func UpdateKafkaPanel(panel *widgets.Paragraph, h ClusterHealth, enabled bool) {
+	_, color := GetClusterStatus(h)
+	panel.TextStyle = ui.NewStyle(color)
+
+	switch {
+	case !enabled:
+		panel.Text = i18n.T("monitor.kafka.disabled")
+		return
+	case h.CheckedAt.IsZero():
+		panel.Text = i18n.T("monitor.kafka.pending")
+		return
+	case h.Err != "":
+		panel.Text = i18n.T("monitor.kafka.unreachable", h.Err)
+		return
+	}
+
+	md := h.Metadata
+	lines := []string{
+		i18n.T("monitor.kafka.brokers", md.Brokers),
+		i18n.T("monitor.kafka.partitions", h.Topic, md.Partitions),
+	}
+	if md.UnderReplicated == 0 {
+		lines = append(lines, i18n.T("monitor.kafka.isr_ok"))
+	} else {
+		lines = append(lines, i18n.T("monitor.kafka.under_replicated", md.UnderReplicated))
+	}
+	if md.Offline > 0 {
+		lines = append(lines, i18n.T("monitor.kafka.offline", md.Offline))
+	}
+	panel.Text = strings.Join(lines, "\n")
+}
@@ -0,0 +1,84 @@
+//go:build kafka
+// +build kafka
+
+package monitor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// kafkaMetadataSource queries the cluster metadata through a Kafka admin client.
+type kafkaMetadataSource struct {
+	admin *kafka.AdminClient // The admin client.
+}
+
+// NewKafkaMetadataSource creates a metadata source connected to the given broker.
+//
+// Parameters:
+//   - broker: The Kafka broker address.
+//   - properties: Additional librdkafka properties (e.g., TLS/SASL security), may be nil.
+//
+// Returns:
+//   - MetadataSource: The metadata source.
+//   - error: An error if the admin client cannot be created.
+func NewKafkaMetadataSource(broker string, properties map[string]string) (MetadataSource, error) {
+	configMap := kafka.ConfigMap{"bootstrap.servers": broker}
+	for name, value := range properties {
+		configMap[name] = value
+	}
+	admin, err := kafka.NewAdminClient(&configMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka admin client: %w", err)
+	}
+	return &kafkaMetadataSource{admin: admin}, nil
+}
+
+// Metadata returns the cluster metadata for a topic.
+//
+// Parameters:
+//   - topic: The monitored topic.
+//   - timeout: The maximum wait time for the brokers to answer.
+//
+// Returns:
+//   - ClusterMetadata: The cluster summary.
+//   - error: An error if the cluster is unreachable or the topic is unknown.
+func (s *kafkaMetadataSource) Metadata(topic string, timeout time.Duration) (ClusterMetadata, error) {
+	md, err := s.admin.GetMetadata(&topic, false, int(timeout/time.Millisecond))
+	if err != nil {
+		return ClusterMetadata{}, err
+	}
+	return clusterMetadataFrom(md, topic)
+}
+
+// clusterMetadataFrom summarizes Kafka metadata for a topic.
+//
+// Parameters:
+//   - md: The raw Kafka metadata.
+//   - topic: The monitored topic.
+//
+// Returns:
+//   - ClusterMetadata: The cluster summary.
+//   - error: An error if the topic is missing from the metadata.
+func clusterMetadataFrom(md *kafka.Metadata, topic string) (ClusterMetadata, error) {
+	t, ok := md.Topics[topic]
+	if !ok {
+		return ClusterMetadata{}, fmt.Errorf("topic %s not found", topic)
+	}
+	if t.Error.Code() != kafka.ErrNoError {
+		return ClusterMetadata{}, fmt.Errorf("topic %s: %v", topic, t.Error)
+	}
+
+	summary := ClusterMetadata{Brokers: len(md.Brokers), Partitions: len(t.Partitions)}
+	for _, p := range t.Partitions {
+		if p.Leader < 0 {
+			summary.Offline++
+		}
+		if len(p.Isrs) < len(p.Replicas) {
+			summary.UnderReplicated++
+		}
+	}
+	return summary, nil
+}
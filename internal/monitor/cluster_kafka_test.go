@@ -0,0 +1,33 @@
+//go:build kafka
+// +build kafka
+
+package monitor
+
+import (
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestClusterMetadataFrom vérifie le résumé des métadonnées Kafka.
+func TestClusterMetadataFrom(t *testing.T) {
+	md := &kafka.Metadata{
+		Brokers: []kafka.BrokerMetadata{{ID: 1}, {ID: 2}},
+		Topics: map[string]kafka.TopicMetadata{
+			"orders": {Topic: "orders", Partitions: []kafka.PartitionMetadata{
+				{ID: 0, Leader: 1, Replicas: []int32{1, 2}, Isrs: []int32{1, 2}},
+				{ID: 1, Leader: 2, Replicas: []int32{1, 2}, Isrs: []int32{2}},
+				{ID: 2, Leader: -1, Replicas: []int32{1, 2}, Isrs: []int32{}},
+			}},
+		},
+	}
+
+	summary, err := clusterMetadataFrom(md, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, ClusterMetadata{Brokers: 2, Partitions: 3, UnderReplicated: 2, Offline: 1}, summary)
+
+	_, err = clusterMetadataFrom(md, "absent")
+	assert.Error(t, err)
+}
@@ -0,0 +1,18 @@
+//go:build !kafka
+// +build !kafka
+
+package monitor
+
+// NewKafkaMetadataSource is unavailable without the "kafka" build tag, which
+// keeps the default monitor binary free of the CGO Kafka client.
+//
+// Parameters:
+//   - broker: The Kafka broker address (unused).
+//   - properties: Additional librdkafka properties (unused).
+//
+// Returns:
+//   - MetadataSource: Always nil.
+//   - error: ErrKafkaUnsupported.
+func NewKafkaMetadataSource(broker string, properties map[string]string) (MetadataSource, error) {
+	return nil, ErrKafkaUnsupported
+}
@@ -0,0 +1,81 @@
+package monitor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+	ui "github.com/gizak/termui/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeMetadataSource est une source de métadonnées simulée.
+type fakeMetadataSource struct {
+	md  ClusterMetadata
+	err error
+}
+
+// Metadata retourne les métadonnées simulées.
+func (f *fakeMetadataSource) Metadata(topic string, timeout time.Duration) (ClusterMetadata, error) {
+	return f.md, f.err
+}
+
+// TestGetClusterStatus vérifie le statut de santé selon le résultat du sondage.
+func TestGetClusterStatus(t *testing.T) {
+	healthy := ProbeCluster(&fakeMetadataSource{md: ClusterMetadata{Brokers: 1, Partitions: 3}}, "orders")
+	status, color := GetClusterStatus(healthy)
+	assert.Equal(t, HealthGood, status)
+	assert.Equal(t, ui.ColorGreen, color)
+
+	degraded := ProbeCluster(&fakeMetadataSource{md: ClusterMetadata{Brokers: 3, Partitions: 3, UnderReplicated: 1}}, "orders")
+	status, _ = GetClusterStatus(degraded)
+	assert.Equal(t, HealthWarning, status)
+
+	offline := ProbeCluster(&fakeMetadataSource{md: ClusterMetadata{Brokers: 1, Partitions: 3, Offline: 1}}, "orders")
+	status, _ = GetClusterStatus(offline)
+	assert.Equal(t, HealthCritical, status)
+
+	down := ProbeCluster(&fakeMetadataSource{err: errors.New("all brokers down")}, "orders")
+	status, color = GetClusterStatus(down)
+	assert.Equal(t, HealthCritical, status)
+	assert.Equal(t, ui.ColorRed, color)
+
+	// Avant le premier sondage, le cluster n'influence pas la santé globale
+	status, _ = GetClusterStatus(ClusterHealth{Topic: "orders"})
+	assert.Equal(t, HealthGood, status)
+}
+
+// TestUpdateKafkaPanel vérifie le contenu du panneau Kafka.
+func TestUpdateKafkaPanel(t *testing.T) {
+	panel := CreateKafkaPanel()
+
+	UpdateKafkaPanel(panel, ClusterHealth{}, false)
+	assert.Equal(t, i18n.T("monitor.kafka.disabled"), panel.Text)
+
+	h := ProbeCluster(&fakeMetadataSource{md: ClusterMetadata{Brokers: 3, Partitions: 6, UnderReplicated: 2}}, "orders")
+	UpdateKafkaPanel(panel, h, true)
+	assert.Contains(t, panel.Text, i18n.T("monitor.kafka.brokers", 3))
+	assert.Contains(t, panel.Text, i18n.T("monitor.kafka.partitions", "orders", 6))
+	assert.Contains(t, panel.Text, i18n.T("monitor.kafka.under_replicated", 2))
+	assert.Equal(t, ui.ColorYellow, panel.TextStyle.Fg)
+
+	h = ProbeCluster(&fakeMetadataSource{err: errors.New("timeout")}, "orders")
+	UpdateKafkaPanel(panel, h, true)
+	assert.Equal(t, i18n.T("monitor.kafka.unreachable", "timeout"), panel.Text)
+}
+
+// TestUpdateHealthDashboardCluster vérifie que l'indisponibilité du cluster dégrade la santé globale.
+func TestUpdateHealthDashboardCluster(t *testing.T) {
+	defer func() {
+		clusterMu.Lock()
+		clusterHealth, clusterEnabled = ClusterHealth{}, false
+		clusterMu.Unlock()
+	}()
+
+	setClusterHealth(ProbeCluster(&fakeMetadataSource{err: errors.New("all brokers down")}, "orders"))
+
+	dashboard := CreateHealthDashboard()
+	UpdateHealthDashboard(dashboard, &Metrics{CurrentSuccessRate: 100, CurrentMessagesPerSec: 1})
+	assert.Equal(t, i18n.T("monitor.status.critical"), dashboard.Rows[1][1])
+}
@@ -0,0 +1,155 @@
+package monitor
+
+import (
+	"time"
+
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// Dashboard groups all the widgets rendered by the monitor TUI.
+// It centralizes creation, layout, and rendering so that the entry point
+// does not have to track every widget individually.
+type Dashboard struct {
+	MetricsTable    *widgets.Table     // Raw counters table.
+	HealthDashboard *widgets.Table     // Health indicators table.
+	SuccessGauge    *widgets.Gauge     // Success rate gauge.
+	QualityGauge    *widgets.Gauge     // Quality score gauge.
+	LogList         *widgets.List      // Recent logs list.
+	EventList       *widgets.List      // Recent events list.
+	MPSChart        *widgets.Plot      // Throughput chart.
+	SRChart         *widgets.Plot      // Success rate chart.
+	SizeChart       *widgets.BarChart  // Message size distribution chart.
+	PartitionChart  *widgets.BarChart  // Message distribution per Kafka partition.
+	Diagnostics     *widgets.Paragraph // Parse failures panel.
+	Kafka           *widgets.Paragraph // Kafka broker and topic health panel.
+	Latency         *widgets.Paragraph // Delivery latency per partition, streamed by the latency probe.
+	StatusBar       *widgets.Paragraph // Bottom status bar.
+
+	Filter   ViewFilter // Filters applied to the logs and events lists.
+	Layout   Layout     // Pane sizes, adjustable with the mouse.
+	width    int        // Last known terminal width.
+	height   int        // Last known terminal height.
+	dragging divider    // Divider currently dragged with the mouse.
+}
+
+// statusBarHeight is the height of the bottom status bar.
+const statusBarHeight = 3
+
+// NewDashboard creates all the dashboard widgets.
+//
+// Returns:
+//   - *Dashboard: The initialized dashboard.
+func NewDashboard() *Dashboard {
+	return &Dashboard{
+		MetricsTable:    CreateMetricsTable(),
+		HealthDashboard: CreateHealthDashboard(),
+		SuccessGauge:    CreateSuccessRateGauge(),
+		QualityGauge:    CreateQualityGauge(),
+		LogList:         CreateLogList(),
+		EventList:       CreateEventList(),
+		MPSChart:        CreateMessagesPerSecondChart(),
+		SRChart:         CreateSuccessRateChart(),
+		SizeChart:       CreateMessageSizeChart(),
+		PartitionChart:  CreatePartitionChart(),
+		Diagnostics:     CreateDiagnosticsPanel(),
+		Kafka:           CreateKafkaPanel(),
+		Latency:         CreateLatencyPanel(),
+		StatusBar:       CreateStatusBar(),
+		Layout:          DefaultLayout(),
+	}
+}
+
+// Resize lays out the widgets for the given terminal dimensions.
+//
+// The grid is split into 4 sections:
+//  1. Top: metrics, health and gauges (Layout.TopHeight)
+//  2. Middle: logs and events (Layout.MiddleHeight, split at Layout.SplitRatio)
+//  3. Bottom: throughput, success rate, message size and partition charts, Kafka health, delivery latency and parse diagnostics stacked (remaining height)
+//  4. Status bar (height 3)
+//
+// Parameters:
+//   - termWidth: The terminal width.
+//   - termHeight: The terminal height.
+func (d *Dashboard) Resize(termWidth, termHeight int) {
+	d.width, d.height = termWidth, termHeight
+
+	topY := d.Layout.TopHeight
+	middleY := topY + d.Layout.MiddleHeight
+	statusY := termHeight - statusBarHeight
+	splitX := d.splitX()
+	gaugeX := 50 + (termWidth-50)/2
+	gaugeSplitY := topY * 4 / 9
+
+	d.MetricsTable.SetRect(0, 0, 50, topY)
+	d.HealthDashboard.SetRect(50, 0, gaugeX, topY)
+	d.SuccessGauge.SetRect(gaugeX, 0, termWidth, gaugeSplitY)
+	d.QualityGauge.SetRect(gaugeX, gaugeSplitY, termWidth, topY)
+
+	d.LogList.SetRect(0, topY, splitX, middleY)
+	d.EventList.SetRect(splitX, topY, termWidth, middleY)
+
+	fifthWidth := termWidth / 5
+	d.MPSChart.SetRect(0, middleY, fifthWidth, statusY)
+	d.SRChart.SetRect(fifthWidth, middleY, 2*fifthWidth, statusY)
+	d.SizeChart.SetRect(2*fifthWidth, middleY, 3*fifthWidth, statusY)
+	d.PartitionChart.SetRect(3*fifthWidth, middleY, 4*fifthWidth, statusY)
+	thirdHeight := (statusY - middleY) / 3
+	kafkaY, latencyY := middleY+thirdHeight, middleY+2*thirdHeight
+	d.Kafka.SetRect(4*fifthWidth, middleY, termWidth, kafkaY)
+	d.Latency.SetRect(4*fifthWidth, kafkaY, termWidth, latencyY)
+	d.Diagnostics.SetRect(4*fifthWidth, latencyY, termWidth, statusY)
+
+	d.StatusBar.SetRect(0, statusY, termWidth, termHeight)
+}
+
+// Drawables returns the widgets in rendering order.
+//
+// Returns:
+//   - []ui.Drawable: The widgets to pass to ui.Render.
+func (d *Dashboard) Drawables() []ui.Drawable {
+	return []ui.Drawable{
+		d.MetricsTable,
+		d.HealthDashboard,
+		d.SuccessGauge,
+		d.QualityGauge,
+		d.LogList,
+		d.EventList,
+		d.MPSChart,
+		d.SRChart,
+		d.SizeChart,
+		d.PartitionChart,
+		d.Kafka,
+		d.Latency,
+		d.Diagnostics,
+		d.StatusBar,
+	}
+}
+
+// Refresh updates every dashboard widget with the latest metrics.
+//
+// Parameters:
+//   - d: The dashboard to update.
+func (m *Monitor) Refresh(d *Dashboard) {
+	m.UpdateUI(d.MetricsTable, d.HealthDashboard, d.LogList, d.EventList, d.MPSChart, d.SRChart)
+	UpdateDiagnosticsPanel(d.Diagnostics, ParseFailures())
+	cluster, enabled := ClusterHealthState()
+	UpdateKafkaPanel(d.Kafka, cluster, enabled)
+	probe, watched := LatencyPanelState()
+	UpdateLatencyPanel(d.Latency, probe, watched, time.Now())
+
+	m.Metrics.mu.RLock()
+	defer m.Metrics.mu.RUnlock()
+
+	if d.Filter.ErrorsOnly {
+		UpdateLogList(d.LogList, d.Filter.Logs(m.Metrics.RecentLogs))
+		UpdateEventList(d.EventList, d.Filter.Events(m.Metrics.RecentEvents))
+	}
+	UpdateGauges(d.SuccessGauge, d.QualityGauge, m.Metrics)
+	if m.Metrics.MessageSizes != nil {
+		UpdateMessageSizeChart(d.SizeChart, m.Metrics.MessageSizes)
+	}
+	if m.Metrics.Partitions != nil {
+		UpdatePartitionChart(d.PartitionChart, m.Metrics.Partitions)
+	}
+}
@@ -0,0 +1,61 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	ui "github.com/gizak/termui/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestUpdateGauges vérifie le pourcentage et la couleur des jauges.
+func TestUpdateGauges(t *testing.T) {
+	successGauge := CreateSuccessRateGauge()
+	qualityGauge := CreateQualityGauge()
+
+	metrics := &Metrics{
+		CurrentSuccessRate:    100.0,
+		CurrentMessagesPerSec: 1.0,
+		Uptime:                time.Minute,
+	}
+	UpdateGauges(successGauge, qualityGauge, metrics)
+
+	assert.Equal(t, 100, successGauge.Percent)
+	assert.Equal(t, ui.ColorGreen, successGauge.BarColor)
+	assert.Contains(t, successGauge.Label, "100.00%")
+	assert.Equal(t, 100, qualityGauge.Percent)
+	assert.Equal(t, ui.ColorGreen, qualityGauge.BarColor)
+
+	metrics.CurrentSuccessRate = 50.0
+	metrics.CurrentMessagesPerSec = 0
+	metrics.ErrorCount = 20
+	UpdateGauges(successGauge, qualityGauge, metrics)
+
+	assert.Equal(t, 50, successGauge.Percent)
+	assert.Equal(t, ui.ColorRed, successGauge.BarColor)
+	assert.Equal(t, ui.ColorRed, qualityGauge.BarColor)
+}
+
+// TestClampPercent vérifie le bornage des pourcentages.
+func TestClampPercent(t *testing.T) {
+	assert.Equal(t, 0, clampPercent(-5))
+	assert.Equal(t, 42, clampPercent(42.7))
+	assert.Equal(t, 100, clampPercent(150))
+}
+
+// TestDashboardRefresh vérifie que le tableau de bord complet est mis à jour.
+func TestDashboardRefresh(t *testing.T) {
+	m := New()
+	m.Metrics.MessagesReceived = 10
+	m.Metrics.CurrentSuccessRate = 90.0
+
+	d := NewDashboard()
+	d.Resize(160, 40)
+	m.Refresh(d)
+
+	assert.Len(t, d.Drawables(), 14)
+	assert.Equal(t, "10", d.MetricsTable.Rows[1][1])
+	assert.Equal(t, 90, d.SuccessGauge.Percent)
+	assert.Equal(t, 37, d.MPSChart.Max.Y)
+	assert.Equal(t, 40, d.StatusBar.Max.Y)
+}
@@ -0,0 +1,113 @@
+package monitor
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// maxBadLineLength is the maximum number of characters kept from an unparsable line.
+const maxBadLineLength = 80
+
+// ParseFailure describes the lines of a monitored file that could not be parsed.
+type ParseFailure struct {
+	Count    int    // Number of unparsable lines.
+	LastLine string // Last unparsable line, truncated to maxBadLineLength characters.
+}
+
+var (
+	parseFailuresMu sync.RWMutex
+	parseFailures   = make(map[string]ParseFailure)
+)
+
+// recordParseFailure records a line of the given file that could not be parsed.
+//
+// Parameters:
+//   - filename: The monitored file path.
+//   - line: The unparsable line.
+func recordParseFailure(filename, line string) {
+	parseFailuresMu.Lock()
+	defer parseFailuresMu.Unlock()
+	f := parseFailures[filename]
+	f.Count++
+	f.LastLine = truncate(line, maxBadLineLength)
+	parseFailures[filename] = f
+}
+
+// ParseFailures returns the parse failures of every monitored file.
+//
+// Returns:
+//   - map[string]ParseFailure: A copy of the failures keyed by file path.
+func ParseFailures() map[string]ParseFailure {
+	parseFailuresMu.RLock()
+	defer parseFailuresMu.RUnlock()
+	failures := make(map[string]ParseFailure, len(parseFailures))
+	for k, v := range parseFailures {
+		failures[k] = v
+	}
+	return failures
+}
+
+// truncate shortens a string to at most n characters, appending "..." if cut.
+//
+// Parameters:
+//   - s: The string to shorten.
+//   - n: The maximum number of characters.
+//
+// Returns:
+//   - string: The shortened string.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n]) + "..."
+}
+
+// CreateDiagnosticsPanel initializes the parse diagnostics widget.
+//
+// Returns:
+//   - *widgets.Paragraph: The initialized paragraph widget.
+func CreateDiagnosticsPanel() *widgets.Paragraph {
+	panel := widgets.NewParagraph()
+	panel.Title = i18n.T("monitor.title.diagnostics")
+	panel.Text = i18n.T("monitor.diagnostics.none")
+	panel.TextStyle = ui.NewStyle(ui.ColorGreen)
+	panel.WrapText = true
+	return panel
+}
+
+// UpdateDiagnosticsPanel displays the number of unparsable lines and the last
+// bad line of each monitored file, so that corrupt or mismatched formats are visible.
+//
+// Parameters:
+//   - panel: The diagnostics widget.
+//   - failures: The parse failures keyed by file path.
+func UpdateDiagnosticsPanel(panel *widgets.Paragraph, failures map[string]ParseFailure) {
+	files := make([]string, 0, len(failures))
+	for name, f := range failures {
+		if f.Count > 0 {
+			files = append(files, name)
+		}
+	}
+	if len(files) == 0 {
+		panel.Text = i18n.T("monitor.diagnostics.none")
+		panel.TextStyle = ui.NewStyle(ui.ColorGreen)
+		return
+	}
+	sort.Strings(files)
+
+	var b strings.Builder
+	for _, name := range files {
+		f := failures[name]
+		b.WriteString(i18n.T("monitor.diagnostics.count", filepath.Base(name), f.Count) + "\n")
+		b.WriteString(i18n.T("monitor.diagnostics.last", f.LastLine) + "\n")
+	}
+	panel.Text = b.String()
+	panel.TextStyle = ui.NewStyle(ui.ColorRed)
+}
@@ -0,0 +1,35 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRecordParseFailure vérifie le comptage et la troncature de la dernière ligne invalide.
+func TestRecordParseFailure(t *testing.T) {
+	file := "diagnostics-test.log"
+	recordParseFailure(file, "first bad line")
+	recordParseFailure(file, strings.Repeat("x", maxBadLineLength+20))
+
+	f := ParseFailures()[file]
+	assert.Equal(t, 2, f.Count)
+	assert.Equal(t, strings.Repeat("x", maxBadLineLength)+"...", f.LastLine)
+}
+
+// TestUpdateDiagnosticsPanel vérifie l'affichage des erreurs d'analyse par fichier.
+func TestUpdateDiagnosticsPanel(t *testing.T) {
+	panel := CreateDiagnosticsPanel()
+
+	UpdateDiagnosticsPanel(panel, nil)
+	assert.Equal(t, "Aucune ligne invalide", panel.Text)
+
+	UpdateDiagnosticsPanel(panel, map[string]ParseFailure{
+		"logs/tracker.log":    {Count: 3, LastLine: "{broken"},
+		"logs/tracker.events": {},
+	})
+	assert.Contains(t, panel.Text, "tracker.log: 3 ligne(s) invalide(s)")
+	assert.Contains(t, panel.Text, "Dernière: {broken")
+	assert.NotContains(t, panel.Text, "tracker.events")
+}
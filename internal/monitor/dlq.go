@@ -0,0 +1,250 @@
+package monitor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/internal/retry"
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// DLQState describes what happened to a dead-lettered message in the browser.
+type DLQState string
+
+const (
+	// DLQPending means the message has not been acted upon yet.
+	DLQPending DLQState = "pending"
+	// DLQReplayed means the message was republished to its original topic.
+	DLQReplayed DLQState = "replayed"
+	// DLQDiscarded means the message was discarded.
+	DLQDiscarded DLQState = "discarded"
+)
+
+// String returns the translated state.
+//
+// Returns:
+//   - string: The state text in the active locale.
+func (s DLQState) String() string {
+	return i18n.T("dlq.state." + string(s))
+}
+
+// DLQEntry is a dead-lettered message listed by the browser.
+type DLQEntry struct {
+	Record retry.DLQRecord // Message read from the DLQ topic.
+	State  DLQState        // Action taken on the message.
+}
+
+// DLQActions performs the actions requested from the browser.
+type DLQActions interface {
+	// Replay republishes the message to its original topic.
+	Replay(entry DLQEntry) error
+	// Discard drops the message so that it is no longer listed.
+	Discard(entry DLQEntry) error
+}
+
+// DLQBrowser groups the widgets of the DLQ inspector: the list of failed
+// messages, a details popup showing the payload, and a status bar.
+type DLQBrowser struct {
+	List      *widgets.List      // Failed messages list.
+	Details   *widgets.Paragraph // Selected message details popup.
+	StatusBar *widgets.Paragraph // Bottom status bar.
+
+	entries     []DLQEntry // Listed messages, in DLQ order.
+	actions     DLQActions // Replay and discard handler.
+	showDetails bool       // True while the details popup is displayed.
+}
+
+// NewDLQBrowser creates the DLQ inspector widgets.
+//
+// Parameters:
+//   - records: The messages read from the DLQ topic.
+//   - actions: The handler of the replay and discard actions.
+//
+// Returns:
+//   - *DLQBrowser: The initialized browser.
+func NewDLQBrowser(records []retry.DLQRecord, actions DLQActions) *DLQBrowser {
+	list := widgets.NewList()
+	list.Title = i18n.T("dlq.title.list", len(records))
+	list.TextStyle = ui.NewStyle(ui.ColorWhite)
+	list.SelectedRowStyle = ui.NewStyle(ui.ColorBlack, ui.ColorWhite)
+
+	b := &DLQBrowser{
+		List:      list,
+		Details:   CreateDetailsPopup(),
+		StatusBar: CreateStatusBar(),
+		actions:   actions,
+	}
+	for _, rec := range records {
+		b.entries = append(b.entries, DLQEntry{Record: rec, State: DLQPending})
+	}
+	b.StatusBar.Text = i18n.T("dlq.statusbar")
+	b.refreshList()
+	return b
+}
+
+// Entries returns the listed messages and their state.
+//
+// Returns:
+//   - []DLQEntry: A copy of the entries, in DLQ order.
+func (b *DLQBrowser) Entries() []DLQEntry {
+	return append([]DLQEntry(nil), b.entries...)
+}
+
+// Resize lays out the widgets for the given terminal dimensions.
+//
+// Parameters:
+//   - termWidth: The terminal width.
+//   - termHeight: The terminal height.
+func (b *DLQBrowser) Resize(termWidth, termHeight int) {
+	statusY := termHeight - statusBarHeight
+	b.List.SetRect(0, 0, termWidth, statusY)
+	b.StatusBar.SetRect(0, statusY, termWidth, termHeight)
+	CenterPopup(b.Details, termWidth, termHeight)
+}
+
+// Drawables returns the widgets in rendering order.
+//
+// Returns:
+//   - []ui.Drawable: The widgets to pass to ui.Render.
+func (b *DLQBrowser) Drawables() []ui.Drawable {
+	drawables := []ui.Drawable{b.List, b.StatusBar}
+	if b.showDetails {
+		drawables = append(drawables, b.Details)
+	}
+	return drawables
+}
+
+// HandleKey applies a keyboard event to the browser.
+//
+// Keys: j/k or the arrows move the selection, Enter shows the details of the
+// selected message, Escape closes them, r replays and d discards the selected
+// message, q or Ctrl+C quits.
+//
+// Parameters:
+//   - id: The termui event ID (e.g., "j", "<Enter>").
+//
+// Returns:
+//   - bool: False if the browser must be closed.
+func (b *DLQBrowser) HandleKey(id string) bool {
+	switch id {
+	case "q", "<C-c>":
+		return false
+	case "j", "<Down>":
+		if len(b.entries) > 0 {
+			b.List.ScrollDown()
+		}
+	case "k", "<Up>":
+		b.List.ScrollUp()
+	case "<Enter>":
+		if entry, ok := b.selected(); ok {
+			b.Details.Text = formatDLQDetails(entry)
+			b.showDetails = true
+		}
+	case "<Escape>":
+		b.showDetails = false
+	case "r":
+		b.act(DLQReplayed, func(entry DLQEntry) error { return b.actions.Replay(entry) })
+	case "d":
+		b.act(DLQDiscarded, func(entry DLQEntry) error { return b.actions.Discard(entry) })
+	}
+	return true
+}
+
+// selected returns the entry under the list cursor.
+//
+// Returns:
+//   - DLQEntry: The selected entry.
+//   - bool: False if the list is empty.
+func (b *DLQBrowser) selected() (DLQEntry, bool) {
+	i := b.List.SelectedRow
+	if i < 0 || i >= len(b.entries) {
+		return DLQEntry{}, false
+	}
+	return b.entries[i], true
+}
+
+// act runs an action on the selected pending message and reports its outcome
+// in the status bar.
+//
+// Parameters:
+//   - state: The state of the message once the action succeeds.
+//   - action: The action.
+func (b *DLQBrowser) act(state DLQState, action func(DLQEntry) error) {
+	entry, ok := b.selected()
+	if !ok {
+		return
+	}
+	position := fmt.Sprintf("%d/%d", entry.Record.Partition, entry.Record.Offset)
+	if entry.State != DLQPending {
+		b.StatusBar.Text = i18n.T("dlq.action.done", position, entry.State)
+		return
+	}
+	if err := action(entry); err != nil {
+		b.StatusBar.Text = i18n.T("dlq.action.failed", position, err)
+		return
+	}
+	b.entries[b.List.SelectedRow].State = state
+	b.StatusBar.Text = i18n.T("dlq.action.ok", position, state)
+	b.refreshList()
+}
+
+// refreshList rebuilds the list rows from the entries.
+func (b *DLQBrowser) refreshList() {
+	if len(b.entries) == 0 {
+		b.List.Rows = []string{i18n.T("dlq.empty")}
+		return
+	}
+	rows := make([]string, len(b.entries))
+	for i, entry := range b.entries {
+		rows[i] = formatDLQRow(entry)
+	}
+	b.List.Rows = rows
+}
+
+// formatDLQRow formats a list row: DLQ position, original position, attempts,
+// state and last error.
+//
+// Parameters:
+//   - entry: The listed message.
+//
+// Returns:
+//   - string: The row text.
+func formatDLQRow(entry DLQEntry) string {
+	rec := entry.Record
+	if rec.Err != nil {
+		return fmt.Sprintf("%d/%-6d %s", rec.Partition, rec.Offset, i18n.T("dlq.invalid", rec.Err))
+	}
+	msg := rec.Message
+	return fmt.Sprintf("%d/%-6d %s@%d/%d  x%d  [%s]  %s", rec.Partition, rec.Offset,
+		msg.OriginalTopic, msg.OriginalPartition, msg.OriginalOffset, msg.Attempts,
+		entry.State, truncate(msg.LastError, 120))
+}
+
+// formatDLQDetails formats the details popup of a message, with its payload
+// indented when it is JSON.
+//
+// Parameters:
+//   - entry: The selected message.
+//
+// Returns:
+//   - string: The details text.
+func formatDLQDetails(entry DLQEntry) string {
+	rec := entry.Record
+	if rec.Err != nil {
+		return i18n.T("dlq.invalid", rec.Err)
+	}
+	msg := rec.Message
+	payload := string(msg.Payload)
+	var indented bytes.Buffer
+	if json.Indent(&indented, msg.Payload, "", "  ") == nil {
+		payload = indented.String()
+	}
+	return i18n.T("dlq.details",
+		rec.Partition, rec.Offset, msg.OriginalTopic, msg.OriginalPartition, msg.OriginalOffset,
+		msg.Attempts, msg.RetryTier, msg.FirstSeen.Format(time.RFC3339), msg.FailedAt.Format(time.RFC3339),
+		msg.Handler, msg.ConsumerGroup, msg.Hostname, entry.State, msg.ErrorClass, msg.LastError, payload)
+}
@@ -0,0 +1,103 @@
+package monitor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDLQActions enregistre les actions demandées par le navigateur de DLQ.
+type fakeDLQActions struct {
+	replayed  []int64
+	discarded []int64
+	err       error
+}
+
+func (f *fakeDLQActions) Replay(entry DLQEntry) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.replayed = append(f.replayed, entry.Record.Offset)
+	return nil
+}
+
+func (f *fakeDLQActions) Discard(entry DLQEntry) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.discarded = append(f.discarded, entry.Record.Offset)
+	return nil
+}
+
+// dlqRecords retourne deux messages DLQ valides et une enveloppe invalide.
+func dlqRecords() []retry.DLQRecord {
+	return []retry.DLQRecord{
+		{Partition: 0, Offset: 4, Message: retry.FailedMessage{OriginalTopic: "orders", OriginalOffset: 40, Attempts: 3, LastError: "json invalide", Payload: []byte(`{"order_id":"a"}`)}},
+		{Partition: 0, Offset: 5, Message: retry.FailedMessage{OriginalTopic: "orders", OriginalOffset: 41, Attempts: 1, LastError: "timeout", Payload: []byte("brut")}},
+		{Partition: 0, Offset: 6, Err: errors.New("original_topic absent")},
+	}
+}
+
+// TestFormatDLQRow vérifie les colonnes d'une ligne de la liste.
+func TestFormatDLQRow(t *testing.T) {
+	records := dlqRecords()
+	row := formatDLQRow(DLQEntry{Record: records[0], State: DLQPending})
+	assert.Contains(t, row, "0/4")
+	assert.Contains(t, row, "orders@0/40")
+	assert.Contains(t, row, "x3")
+	assert.Contains(t, row, "json invalide")
+
+	assert.Contains(t, formatDLQRow(DLQEntry{Record: records[2]}), "original_topic absent")
+}
+
+// TestFormatDLQDetails vérifie l'indentation du contenu JSON dans les détails.
+func TestFormatDLQDetails(t *testing.T) {
+	records := dlqRecords()
+	assert.Contains(t, formatDLQDetails(DLQEntry{Record: records[0], State: DLQPending}), "{\n  \"order_id\": \"a\"\n}")
+	assert.Contains(t, formatDLQDetails(DLQEntry{Record: records[1], State: DLQPending}), "brut")
+}
+
+// TestDLQBrowserActions vérifie la navigation, le rejeu et l'écartement des messages.
+func TestDLQBrowserActions(t *testing.T) {
+	actions := &fakeDLQActions{}
+	b := NewDLQBrowser(dlqRecords(), actions)
+	b.Resize(120, 40)
+	assert.Len(t, b.List.Rows, 3)
+
+	assert.True(t, b.HandleKey("r"))
+	assert.Equal(t, []int64{4}, actions.replayed)
+	assert.Equal(t, DLQReplayed, b.Entries()[0].State)
+
+	// Un message déjà traité n'est pas rejoué une seconde fois
+	b.HandleKey("d")
+	assert.Empty(t, actions.discarded)
+	assert.Equal(t, i18n.T("dlq.action.done", "0/4", DLQReplayed), b.StatusBar.Text)
+
+	b.HandleKey("j")
+	b.HandleKey("d")
+	assert.Equal(t, []int64{5}, actions.discarded)
+	assert.Equal(t, DLQDiscarded, b.Entries()[1].State)
+
+	b.HandleKey("<Enter>")
+	assert.Len(t, b.Drawables(), 3)
+	assert.Contains(t, b.Details.Text, "brut")
+	b.HandleKey("<Escape>")
+	assert.Len(t, b.Drawables(), 2)
+
+	assert.False(t, b.HandleKey("q"))
+}
+
+// TestDLQBrowserActionError vérifie qu'une action en échec laisse le message en attente.
+func TestDLQBrowserActionError(t *testing.T) {
+	b := NewDLQBrowser(dlqRecords(), &fakeDLQActions{err: errors.New("broker indisponible")})
+	b.HandleKey("r")
+	assert.Equal(t, DLQPending, b.Entries()[0].State)
+	assert.Contains(t, b.StatusBar.Text, "broker indisponible")
+
+	empty := NewDLQBrowser(nil, &fakeDLQActions{})
+	assert.True(t, empty.HandleKey("r"))
+	assert.Equal(t, []string{i18n.T("dlq.empty")}, empty.List.Rows)
+}
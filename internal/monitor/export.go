@@ -0,0 +1,291 @@
+package monitor
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+)
+
+// Export formats supported by ExportCharts.
+const (
+	ExportFormatSVG = "svg"
+	ExportFormatPNG = "png"
+)
+
+// Default dimensions of exported charts, in pixels.
+const (
+	exportWidth  = 800
+	exportHeight = 400
+	exportMargin = 50
+)
+
+// ChartSeries is a metric history exported as an image.
+type ChartSeries struct {
+	Name   string    // File name prefix (e.g., "throughput").
+	Title  string    // Chart title.
+	Max    float64   // Fixed upper bound of the Y axis (0 for automatic).
+	Values []float64 // Samples in chronological order.
+}
+
+// ChartSeries returns the exportable metric histories.
+//
+// Returns:
+//   - []ChartSeries: The throughput, success rate and delivery latency histories.
+func (m *Monitor) ChartSeries() []ChartSeries {
+	m.Metrics.mu.RLock()
+	defer m.Metrics.mu.RUnlock()
+
+	return []ChartSeries{
+		{
+			Name:   "throughput",
+			Title:  i18n.T("monitor.title.throughput"),
+			Values: append([]float64(nil), m.Metrics.MessagesPerSecond...),
+		},
+		{
+			Name:   "success-rate",
+			Title:  i18n.T("monitor.title.success_rate"),
+			Max:    100,
+			Values: append([]float64(nil), m.Metrics.SuccessRateHistory...),
+		},
+		{
+			Name:   "latency",
+			Title:  i18n.T("monitor.title.latency_history"),
+			Values: append([]float64(nil), m.Metrics.LatencyHistory...),
+		},
+	}
+}
+
+// ExportCharts writes every metric history as an image in the given directory.
+// Files are named "<series>-<YYYYMMDD-HHMMSS>.<format>".
+//
+// Parameters:
+//   - dir: The destination directory (created if needed).
+//   - format: ExportFormatSVG or ExportFormatPNG.
+//
+// Returns:
+//   - []string: The paths of the written files.
+//   - error: An error if the format is unknown or writing fails.
+func (m *Monitor) ExportCharts(dir, format string) ([]string, error) {
+	format = strings.ToLower(format)
+	if format != ExportFormatSVG && format != ExportFormatPNG {
+		return nil, fmt.Errorf("unsupported export format %q (expected svg or png)", format)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory %s: %w", dir, err)
+	}
+
+	stamp := m.now().Format("20060102-150405")
+	var paths []string
+	for _, s := range m.ChartSeries() {
+		path := filepath.Join(dir, fmt.Sprintf("%s-%s.%s", s.Name, stamp, format))
+		if err := writeChartFile(path, s, format); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// writeChartFile renders a series to a file.
+//
+// Parameters:
+//   - path: The destination file.
+//   - s: The series to render.
+//   - format: ExportFormatSVG or ExportFormatPNG.
+//
+// Returns:
+//   - error: An error if the file cannot be written.
+func writeChartFile(path string, s ChartSeries, format string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	if format == ExportFormatPNG {
+		err = WritePNG(file, s, exportWidth, exportHeight)
+	} else {
+		err = WriteSVG(file, s, exportWidth, exportHeight)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// chartPoints projects the series values into the plot area.
+//
+// Parameters:
+//   - s: The series.
+//   - width: The image width.
+//   - height: The image height.
+//
+// Returns:
+//   - []image.Point: The projected points, left to right.
+//   - float64: The upper bound of the Y axis.
+func chartPoints(s ChartSeries, width, height int) ([]image.Point, float64) {
+	yMax := s.Max
+	if yMax <= 0 {
+		for _, v := range s.Values {
+			if v > yMax {
+				yMax = v
+			}
+		}
+		if yMax <= 0 {
+			yMax = 1
+		}
+	}
+
+	plotW := width - 2*exportMargin
+	plotH := height - 2*exportMargin
+	points := make([]image.Point, len(s.Values))
+	for i, v := range s.Values {
+		x := exportMargin
+		if len(s.Values) > 1 {
+			x += i * plotW / (len(s.Values) - 1)
+		}
+		ratio := v / yMax
+		if ratio < 0 {
+			ratio = 0
+		} else if ratio > 1 {
+			ratio = 1
+		}
+		points[i] = image.Pt(x, height-exportMargin-int(ratio*float64(plotH)))
+	}
+	return points, yMax
+}
+
+// WriteSVG renders a series as an SVG line chart with axes, bounds and title.
+//
+// Parameters:
+//   - w: The destination writer.
+//   - s: The series to render.
+//   - width: The image width in pixels.
+//   - height: The image height in pixels.
+//
+// Returns:
+//   - error: An error if writing fails.
+func WriteSVG(w io.Writer, s ChartSeries, width, height int) error {
+	points, yMax := chartPoints(s, width, height)
+	bottom := height - exportMargin
+	right := width - exportMargin
+
+	var title strings.Builder
+	if err := xml.EscapeText(&title, []byte(s.Title)); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height)
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="white"/>`+"\n")
+	fmt.Fprintf(bw, `<text x="%d" y="%d" font-family="sans-serif" font-size="16" text-anchor="middle">%s</text>`+"\n", width/2, exportMargin/2, title.String())
+	fmt.Fprintf(bw, `<polyline points="%d,%d %d,%d %d,%d" fill="none" stroke="gray"/>`+"\n", exportMargin, exportMargin, exportMargin, bottom, right, bottom)
+	fmt.Fprintf(bw, `<text x="%d" y="%d" font-family="sans-serif" font-size="12" text-anchor="end">%.2f</text>`+"\n", exportMargin-5, exportMargin+4, yMax)
+	fmt.Fprintf(bw, `<text x="%d" y="%d" font-family="sans-serif" font-size="12" text-anchor="end">0</text>`+"\n", exportMargin-5, bottom+4)
+	fmt.Fprintf(bw, `<text x="%d" y="%d" font-family="sans-serif" font-size="12" text-anchor="end">%d</text>`+"\n", right, bottom+20, len(s.Values))
+
+	if len(points) > 0 {
+		coords := make([]string, len(points))
+		for i, p := range points {
+			coords[i] = fmt.Sprintf("%d,%d", p.X, p.Y)
+		}
+		fmt.Fprintf(bw, `<polyline points="%s" fill="none" stroke="steelblue" stroke-width="2"/>`+"\n", strings.Join(coords, " "))
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+// WritePNG renders a series as a PNG line chart. The image has no text
+// (the standard library has no font rasterizer): use SVG for labeled charts.
+//
+// Parameters:
+//   - w: The destination writer.
+//   - s: The series to render.
+//   - width: The image width in pixels.
+//   - height: The image height in pixels.
+//
+// Returns:
+//   - error: An error if encoding fails.
+func WritePNG(w io.Writer, s ChartSeries, width, height int) error {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.White)
+		}
+	}
+
+	axis := color.RGBA{R: 128, G: 128, B: 128, A: 255}
+	line := color.RGBA{R: 70, G: 130, B: 180, A: 255}
+	bottom := height - exportMargin
+	drawLine(img, image.Pt(exportMargin, exportMargin), image.Pt(exportMargin, bottom), axis)
+	drawLine(img, image.Pt(exportMargin, bottom), image.Pt(width-exportMargin, bottom), axis)
+
+	points, _ := chartPoints(s, width, height)
+	for i := 1; i < len(points); i++ {
+		drawLine(img, points[i-1], points[i], line)
+	}
+	if len(points) == 1 {
+		img.Set(points[0].X, points[0].Y, line)
+	}
+	return png.Encode(w, img)
+}
+
+// drawLine draws a segment with Bresenham's algorithm.
+//
+// Parameters:
+//   - img: The destination image.
+//   - from: The start point.
+//   - to: The end point.
+//   - c: The line color.
+func drawLine(img *image.RGBA, from, to image.Point, c color.Color) {
+	dx, dy := abs(to.X-from.X), -abs(to.Y-from.Y)
+	sx, sy := 1, 1
+	if from.X > to.X {
+		sx = -1
+	}
+	if from.Y > to.Y {
+		sy = -1
+	}
+	err := dx + dy
+	x, y := from.X, from.Y
+	for {
+		img.Set(x, y, c)
+		if x == to.X && y == to.Y {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+	}
+}
+
+// abs returns the absolute value of an integer.
+//
+// Parameters:
+//   - v: The value.
+//
+// Returns:
+//   - int: |v|.
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,69 @@
+package monitor
+
+import (
+	"bytes"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriteSVG vérifie le rendu SVG d'une série.
+func TestWriteSVG(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteSVG(&buf, ChartSeries{Title: "Débit <test>", Values: []float64{0, 2, 4}}, 200, 150)
+	require.NoError(t, err)
+
+	svg := buf.String()
+	assert.True(t, strings.HasPrefix(svg, "<svg"))
+	assert.Contains(t, svg, "Débit &lt;test&gt;")
+	assert.Contains(t, svg, ">4.00<")
+	// 3 points : de la marge gauche à la marge droite, du bas vers le haut
+	assert.Contains(t, svg, `points="50,100 100,75 150,50"`)
+}
+
+// TestWritePNG vérifie que l'image PNG est valide et aux bonnes dimensions.
+func TestWritePNG(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WritePNG(&buf, ChartSeries{Max: 100, Values: []float64{100, 50, 0}}, 200, 150))
+
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, 200, img.Bounds().Dx())
+	assert.Equal(t, 150, img.Bounds().Dy())
+
+	// Le premier point (100%) est dessiné en haut de la zone de tracé
+	r, g, b, _ := img.At(50, 50).RGBA()
+	assert.NotEqual(t, [3]uint32{0xffff, 0xffff, 0xffff}, [3]uint32{r, g, b})
+}
+
+// TestExportCharts vérifie l'écriture des fichiers d'export.
+func TestExportCharts(t *testing.T) {
+	m := New()
+	m.SetClock(func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) })
+	m.Metrics.MessagesPerSecond = []float64{1, 2, 3}
+	m.Metrics.SuccessRateHistory = []float64{100, 90, 95}
+	m.Metrics.LatencyHistory = []float64{12.5, 40, 18}
+
+	dir := filepath.Join(t.TempDir(), "exports")
+	paths, err := m.ExportCharts(dir, "PNG")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "throughput-20240102-030405.png"),
+		filepath.Join(dir, "success-rate-20240102-030405.png"),
+		filepath.Join(dir, "latency-20240102-030405.png"),
+	}, paths)
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		require.NoError(t, err)
+		assert.NotZero(t, info.Size())
+	}
+
+	_, err = m.ExportCharts(dir, "gif")
+	assert.Error(t, err)
+}
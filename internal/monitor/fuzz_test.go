@@ -0,0 +1,42 @@
+package monitor
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+)
+
+// FuzzParseEventLine vérifie que l'analyse d'une ligne de tracker.events et
+// son traitement par le moniteur ne paniquent jamais, quelle que soit l'entrée.
+func FuzzParseEventLine(f *testing.F) {
+	for _, name := range models.Fixtures() {
+		raw, err := models.Fixture(name)
+		if err != nil {
+			f.Fatalf("Unexpected error: %v", err)
+		}
+		var line bytes.Buffer
+		if err := json.Compact(&line, raw); err == nil {
+			f.Add(line.String())
+		}
+	}
+	f.Add(`{"timestamp":"invalide","kafka_partition":-1,"message_size":-5}`)
+	f.Add(`{invalid json`)
+
+	f.Fuzz(func(t *testing.T, line string) {
+		m := New()
+		eventChan := make(chan models.EventEntry, 1)
+		if parseAndSendEventEntry(line, eventChan) {
+			event := <-eventChan
+			m.ProcessEvent(event)
+			_ = formatEventRow(event)
+		}
+		logChan := make(chan models.LogEntry, 1)
+		if parseAndSendLogEntry(line, logChan) {
+			entry := <-logChan
+			m.ProcessLog(entry)
+			_ = formatLogRow(entry)
+		}
+	})
+}
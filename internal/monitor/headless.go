@@ -0,0 +1,99 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+)
+
+// FormatSummary formats a metrics snapshot as a human-readable console summary.
+//
+// Parameters:
+//   - s: The snapshot to format.
+//
+// Returns:
+//   - string: The multi-line summary, terminated by a newline.
+func FormatSummary(s Snapshot) string {
+	_, healthText, _ := GetHealthStatus(s.CurrentSuccessRate)
+	_, throughputText, _ := GetThroughputStatus(s.CurrentMessagesPerSec)
+	qualityText, _ := getQualityText(s.QualityScore)
+
+	var b strings.Builder
+	fmt.Fprintln(&b, i18n.T("monitor.summary.header",
+		s.Timestamp.Format("15:04:05"), formatUptime(time.Duration(s.UptimeSeconds*float64(time.Second)))))
+	fmt.Fprintln(&b, i18n.T("monitor.summary.messages",
+		s.MessagesReceived, s.MessagesProcessed, s.MessagesFailed, s.ErrorCount))
+	fmt.Fprintln(&b, i18n.T("monitor.summary.throughput",
+		s.CurrentMessagesPerSec, throughputText, s.Throughput.P50, s.Throughput.P95, s.Throughput.Max))
+	fmt.Fprintln(&b, i18n.T("monitor.summary.success_rate", s.CurrentSuccessRate, healthText))
+	fmt.Fprintln(&b, i18n.T("monitor.summary.quality", qualityText))
+	if s.SkewedEntries > 0 {
+		fmt.Fprintln(&b, i18n.T("monitor.summary.clock_skew", s.SkewedEntries, s.ClockOffsetSeconds))
+	}
+	if s.DLQMessagesSent > 0 || s.DLQSendErrors > 0 {
+		fmt.Fprintln(&b, i18n.T("monitor.summary.dlq", s.DLQMessagesSent, s.DLQSendErrors))
+	}
+	if s.Orders.Orders > 0 {
+		fmt.Fprintln(&b, i18n.T("monitor.summary.orders", s.Orders.Orders, s.Orders.Customers, s.Orders.Items))
+		currencies := make([]string, 0, len(s.Orders.Currencies))
+		for currency := range s.Orders.Currencies {
+			currencies = append(currencies, currency)
+		}
+		sort.Strings(currencies)
+		for _, currency := range currencies {
+			c := s.Orders.Currencies[currency]
+			fmt.Fprintln(&b, i18n.T("monitor.summary.revenue", currency, c.Revenue, c.Orders, c.P50, c.P95, c.Max))
+		}
+	}
+	return b.String()
+}
+
+// RunHeadless periodically writes a metrics summary instead of rendering the TUI.
+// It blocks until ctx is cancelled, then writes a final summary.
+//
+// Parameters:
+//   - ctx: The context signaling shutdown.
+//   - w: The destination of the summaries (e.g., os.Stdout or a file).
+//   - interval: The interval between two summaries (must be > 0).
+//
+// Returns:
+//   - error: An error if the interval is not positive or writing a summary fails.
+func (m *Monitor) RunHeadless(ctx context.Context, w io.Writer, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("summary interval must be > 0 (got %s)", interval)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return m.writeSummary(w)
+		case <-ticker.C:
+			if err := m.writeSummary(w); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeSummary refreshes the uptime and writes one summary.
+//
+// Parameters:
+//   - w: The destination writer.
+//
+// Returns:
+//   - error: An error if writing fails.
+func (m *Monitor) writeSummary(w io.Writer) error {
+	m.UpdateUptime()
+
+	if _, err := io.WriteString(w, FormatSummary(m.Snapshot())+"\n"); err != nil {
+		return fmt.Errorf("failed to write summary: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFormatSummary vérifie le contenu du résumé console.
+func TestFormatSummary(t *testing.T) {
+	summary := FormatSummary(Snapshot{
+		Timestamp:             time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		UptimeSeconds:         90,
+		MessagesReceived:      100,
+		MessagesProcessed:     98,
+		MessagesFailed:        2,
+		CurrentMessagesPerSec: 0.5,
+		CurrentSuccessRate:    98,
+		QualityScore:          95,
+		Throughput:            ThroughputStats{P50: 0.4, P95: 0.6, Max: 0.7},
+	})
+
+	assert.Contains(t, summary, "[12:00:00]")
+	assert.Contains(t, summary, "uptime 2m")
+	assert.Contains(t, summary, "reçus: 100 | traités: 98 | échoués: 2")
+	assert.Contains(t, summary, "p95 0.60")
+	assert.Contains(t, summary, "98.00% ● EXCELLENT")
+	assert.Contains(t, summary, "EXCELLENT (95)")
+}
+
+// TestFormatSummaryOrders vérifie les statistiques métier des commandes
+// désérialisées dans le résumé console.
+func TestFormatSummaryOrders(t *testing.T) {
+	raw, err := models.Fixture("event_entry")
+	if err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+	var entry models.EventEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+	m := New()
+	assert.NotContains(t, FormatSummary(m.Snapshot()), "Commandes")
+
+	m.ProcessEvent(entry)
+	m.ProcessEvent(entry)
+	summary := FormatSummary(m.Snapshot())
+	assert.Contains(t, summary, "Commandes: 2 | clients: 1 | articles: 6")
+	assert.Contains(t, summary, "Chiffre d'affaires EUR: 20.36 (2 commande(s) | p50 10.18 | p95 10.18 | max 10.18)")
+}
+
+// TestRunHeadless vérifie l'écriture périodique et le résumé final.
+func TestRunHeadless(t *testing.T) {
+	m := New()
+	m.Metrics.MessagesReceived = 7
+
+	var buf bytes.Buffer
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- m.RunHeadless(ctx, &buf, 10*time.Millisecond)
+	}()
+
+	time.Sleep(35 * time.Millisecond)
+	cancel()
+	assert.NoError(t, <-done)
+
+	out := buf.String()
+	assert.GreaterOrEqual(t, strings.Count(out, "=== Résumé du moniteur"), 2)
+	assert.Contains(t, out, "reçus: 7")
+}
+
+// TestRunHeadlessRejectsInterval vérifie qu'un intervalle nul ou négatif est
+// refusé au lieu de faire paniquer time.NewTicker.
+func TestRunHeadlessRejectsInterval(t *testing.T) {
+	m := New()
+	for _, interval := range []time.Duration{0, -time.Second} {
+		var buf bytes.Buffer
+		assert.Error(t, m.RunHeadless(context.Background(), &buf, interval))
+		assert.Empty(t, buf.String())
+	}
+}
@@ -0,0 +1,106 @@
+package monitor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/pkg/models"
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// KeyBinding describes a keyboard or mouse shortcut of the TUI.
+type KeyBinding struct {
+	Keys        string // The key(s) triggering the action (or the i18n key of their name).
+	Description string // The i18n key of what the action does.
+}
+
+// KeyBindings lists all the shortcuts available in the TUI.
+var KeyBindings = []KeyBinding{
+	{"q, Ctrl+C", "monitor.help.quit"},
+	{"?", "monitor.help.help"},
+	{"p", "monitor.help.pause"},
+	{"e", "monitor.help.filter"},
+	{"+ / -", "monitor.help.rate"},
+	{"x", "monitor.help.export"},
+	{"< / >", "monitor.help.playback"},
+	{"monitor.help.key.click", "monitor.help.click"},
+	{"monitor.help.key.drag", "monitor.help.drag"},
+	{"monitor.help.key.escape", "monitor.help.escape"},
+}
+
+// CreateHelpOverlay initializes the help overlay listing all key bindings.
+//
+// Returns:
+//   - *widgets.Paragraph: The initialized paragraph widget.
+func CreateHelpOverlay() *widgets.Paragraph {
+	help := widgets.NewParagraph()
+	help.Title = i18n.T("monitor.title.help")
+	help.TextStyle = ui.NewStyle(ui.ColorWhite)
+	help.BorderStyle = ui.NewStyle(ui.ColorCyan)
+
+	var b strings.Builder
+	for _, kb := range KeyBindings {
+		fmt.Fprintf(&b, "[%-10s](fg:yellow,mod:bold) %s\n", i18n.T(kb.Keys), i18n.T(kb.Description))
+	}
+	help.Text = b.String()
+	return help
+}
+
+// ViewFilter describes the filters applied to the logs and events lists.
+type ViewFilter struct {
+	ErrorsOnly bool // Only display ERROR logs and failed events.
+}
+
+// Active returns the names of the active filters.
+//
+// Returns:
+//   - []string: The active filter names.
+func (f ViewFilter) Active() []string {
+	var active []string
+	if f.ErrorsOnly {
+		active = append(active, i18n.T("monitor.filter.errors"))
+	}
+	return active
+}
+
+// Logs returns the log entries matching the filter.
+//
+// Parameters:
+//   - logs: The log entries.
+//
+// Returns:
+//   - []models.LogEntry: The matching entries, in the same order.
+func (f ViewFilter) Logs(logs []models.LogEntry) []models.LogEntry {
+	if !f.ErrorsOnly {
+		return logs
+	}
+	filtered := make([]models.LogEntry, 0, len(logs))
+	for _, l := range logs {
+		if l.Level == models.LogLevelERROR {
+			filtered = append(filtered, l)
+		}
+	}
+	return filtered
+}
+
+// Events returns the event entries matching the filter.
+//
+// Parameters:
+//   - events: The event entries.
+//
+// Returns:
+//   - []models.EventEntry: The matching entries, in the same order.
+func (f ViewFilter) Events(events []models.EventEntry) []models.EventEntry {
+	if !f.ErrorsOnly {
+		return events
+	}
+	filtered := make([]models.EventEntry, 0, len(events))
+	for _, e := range events {
+		if !e.Deserialized {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
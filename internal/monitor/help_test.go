@@ -0,0 +1,79 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCreateHelpOverlay vérifie que l'aide liste tous les raccourcis.
+func TestCreateHelpOverlay(t *testing.T) {
+	help := CreateHelpOverlay()
+	for _, kb := range KeyBindings {
+		assert.Contains(t, help.Text, i18n.T(kb.Description))
+	}
+}
+
+// TestViewFilter vérifie le filtrage des logs et événements en erreur.
+func TestViewFilter(t *testing.T) {
+	logs := []models.LogEntry{
+		{Level: models.LogLevelINFO, Message: "ok"},
+		{Level: models.LogLevelERROR, Message: "ko"},
+	}
+	events := []models.EventEntry{
+		{EventType: "message.received", Deserialized: true},
+		{EventType: "message.received", Deserialized: false},
+	}
+
+	var none ViewFilter
+	assert.Empty(t, none.Active())
+	assert.Len(t, none.Logs(logs), 2)
+	assert.Len(t, none.Events(events), 2)
+
+	errorsOnly := ViewFilter{ErrorsOnly: true}
+	assert.Equal(t, []string{"erreurs uniquement"}, errorsOnly.Active())
+	assert.Equal(t, []models.LogEntry{logs[1]}, errorsOnly.Logs(logs))
+	assert.Equal(t, []models.EventEntry{events[1]}, errorsOnly.Events(events))
+}
+
+// TestEntryDetailsWithFilter vérifie que les détails correspondent à la liste filtrée.
+func TestEntryDetailsWithFilter(t *testing.T) {
+	m := New()
+	m.ProcessLog(models.LogEntry{Level: models.LogLevelERROR, Message: "échec"})
+	m.ProcessLog(models.LogEntry{Level: models.LogLevelINFO, Message: "succès"})
+
+	text, ok := m.EntryDetails(PaneLogs, 0, ViewFilter{ErrorsOnly: true})
+	assert.True(t, ok)
+	assert.Contains(t, text, "échec")
+
+	_, ok = m.EntryDetails(PaneLogs, 1, ViewFilter{ErrorsOnly: true})
+	assert.False(t, ok)
+}
+
+// TestEntryDetailsLocalizedError vérifie que l'erreur codée d'un événement est
+// traduite dans la locale active.
+func TestEntryDetailsLocalizedError(t *testing.T) {
+	m := New()
+	m.ProcessEvent(models.EventEntry{
+		EventType: "message.received.deserialization_error",
+		Error:     "items[0].quantity: quantity must be positive",
+		ErrorCode: "ERR_QUANTITY_NOT_POSITIVE",
+	})
+
+	text, ok := m.EntryDetails(PaneEvents, 0, ViewFilter{})
+	assert.True(t, ok)
+	assert.Contains(t, text, "Erreur [ERR_QUANTITY_NOT_POSITIVE]: items[0].quantity: la quantité doit être positive")
+}
+
+// TestHelpOverlayEnglish vérifie la traduction de l'aide en anglais.
+func TestHelpOverlayEnglish(t *testing.T) {
+	i18n.SetLocale(i18n.LocaleEN)
+	defer i18n.SetLocale(i18n.DefaultLocale)
+
+	help := CreateHelpOverlay()
+	assert.Equal(t, "Help (? or Esc to close)", help.Title)
+	assert.Contains(t, help.Text, "Quit the monitor")
+	assert.Contains(t, help.Text, "Esc")
+}
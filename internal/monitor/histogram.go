@@ -0,0 +1,110 @@
+package monitor
+
+import (
+	"fmt"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// MessageSizeBuckets defines the upper bounds (exclusive, in bytes) of the message size histogram.
+// An additional overflow bucket collects every message larger than the last bound.
+var MessageSizeBuckets = []int{256, 512, 1024, 2048, 4096}
+
+// SizeHistogram counts messages per size bucket.
+type SizeHistogram struct {
+	Bounds []int   // Upper bounds of each bucket in bytes.
+	Counts []int64 // Message count per bucket (len(Bounds)+1, the last being the overflow).
+}
+
+// NewSizeHistogram creates an empty histogram for the given bucket bounds.
+//
+// Parameters:
+//   - bounds: The ascending upper bounds of each bucket in bytes.
+//
+// Returns:
+//   - *SizeHistogram: The initialized histogram.
+func NewSizeHistogram(bounds []int) *SizeHistogram {
+	return &SizeHistogram{
+		Bounds: bounds,
+		Counts: make([]int64, len(bounds)+1),
+	}
+}
+
+// Observe records a message size in the matching bucket.
+//
+// Parameters:
+//   - size: The message size in bytes.
+func (h *SizeHistogram) Observe(size int) {
+	for i, bound := range h.Bounds {
+		if size < bound {
+			h.Counts[i]++
+			return
+		}
+	}
+	h.Counts[len(h.Counts)-1]++
+}
+
+// Labels returns a short label for each bucket.
+//
+// Returns:
+//   - []string: The bucket labels (e.g., "<256", "<1K", ">=4K").
+func (h *SizeHistogram) Labels() []string {
+	labels := make([]string, 0, len(h.Counts))
+	for _, bound := range h.Bounds {
+		labels = append(labels, "<"+formatBytes(bound))
+	}
+	if len(h.Bounds) > 0 {
+		labels = append(labels, ">="+formatBytes(h.Bounds[len(h.Bounds)-1]))
+	} else {
+		labels = append(labels, "*")
+	}
+	return labels
+}
+
+// formatBytes formats a byte count compactly.
+//
+// Parameters:
+//   - n: The number of bytes.
+//
+// Returns:
+//   - string: The formatted size (e.g., "512", "2K").
+func formatBytes(n int) string {
+	if n >= 1024 && n%1024 == 0 {
+		return fmt.Sprintf("%dK", n/1024)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// CreateMessageSizeChart initializes the message size distribution widget.
+//
+// Returns:
+//   - *widgets.BarChart: The initialized bar chart widget.
+func CreateMessageSizeChart() *widgets.BarChart {
+	chart := widgets.NewBarChart()
+	chart.Title = i18n.T("monitor.title.message_size")
+	chart.Data = []float64{0}
+	chart.Labels = []string{"-"}
+	chart.BarWidth = 5
+	chart.BarGap = 1
+	chart.BarColors = []ui.Color{ui.ColorMagenta}
+	chart.LabelStyles = []ui.Style{ui.NewStyle(ui.ColorWhite)}
+	chart.NumStyles = []ui.Style{ui.NewStyle(ui.ColorBlack)}
+	chart.SetRect(106, 19, 160, 29)
+	return chart
+}
+
+// UpdateMessageSizeChart updates the message size distribution chart.
+//
+// Parameters:
+//   - chart: The bar chart widget to update.
+//   - h: The message size histogram.
+func UpdateMessageSizeChart(chart *widgets.BarChart, h *SizeHistogram) {
+	data := make([]float64, len(h.Counts))
+	for i, c := range h.Counts {
+		data[i] = float64(c)
+	}
+	chart.Data = data
+	chart.Labels = h.Labels()
+}
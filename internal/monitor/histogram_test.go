@@ -0,0 +1,39 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSizeHistogramObserve vérifie la répartition des tailles dans les buckets.
+func TestSizeHistogramObserve(t *testing.T) {
+	h := NewSizeHistogram([]int{100, 1024})
+
+	h.Observe(0)
+	h.Observe(99)
+	h.Observe(100)
+	h.Observe(5000)
+
+	assert.Equal(t, []int64{2, 1, 1}, h.Counts)
+	assert.Equal(t, []string{"<100", "<1K", ">=1K"}, h.Labels())
+}
+
+// TestProcessEventRecordsMessageSize vérifie que ProcessEvent alimente l'histogramme.
+func TestProcessEventRecordsMessageSize(t *testing.T) {
+	m := New()
+	m.ProcessEvent(models.EventEntry{
+		Timestamp:    models.Now(),
+		Deserialized: true,
+		MessageSize:  300,
+	})
+
+	assert.Equal(t, int64(1), m.Metrics.MessageSizes.Counts[1])
+
+	chart := CreateMessageSizeChart()
+	UpdateMessageSizeChart(chart, m.Metrics.MessageSizes)
+	assert.Len(t, chart.Data, len(MessageSizeBuckets)+1)
+	assert.Equal(t, 1.0, chart.Data[1])
+	assert.Equal(t, "<512", chart.Labels[1])
+}
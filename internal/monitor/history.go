@@ -0,0 +1,146 @@
+package monitor
+
+// ringBuffer is a fixed-capacity FIFO of float64 samples.
+type ringBuffer struct {
+	data  []float64 // Underlying storage.
+	start int       // Index of the oldest sample.
+	count int       // Number of stored samples.
+}
+
+// newRingBuffer creates an empty ring buffer.
+//
+// Parameters:
+//   - capacity: The maximum number of samples.
+//
+// Returns:
+//   - *ringBuffer: The initialized buffer.
+func newRingBuffer(capacity int) *ringBuffer {
+	return &ringBuffer{data: make([]float64, capacity)}
+}
+
+// push appends a sample, evicting the oldest one when the buffer is full.
+//
+// Parameters:
+//   - v: The sample to append.
+//
+// Returns:
+//   - float64: The evicted sample (meaningful only if evicted is true).
+//   - bool: True if a sample was evicted.
+func (r *ringBuffer) push(v float64) (float64, bool) {
+	if len(r.data) == 0 {
+		return v, true
+	}
+	if r.count < len(r.data) {
+		r.data[(r.start+r.count)%len(r.data)] = v
+		r.count++
+		return 0, false
+	}
+	evicted := r.data[r.start]
+	r.data[r.start] = v
+	r.start = (r.start + 1) % len(r.data)
+	return evicted, true
+}
+
+// appendTo appends the samples in chronological order to dst.
+//
+// Parameters:
+//   - dst: The destination slice.
+//
+// Returns:
+//   - []float64: The extended slice.
+func (r *ringBuffer) appendTo(dst []float64) []float64 {
+	for i := 0; i < r.count; i++ {
+		dst = append(dst, r.data[(r.start+i)%len(r.data)])
+	}
+	return dst
+}
+
+// historyTier is one resolution level of a TieredHistory.
+type historyTier struct {
+	buf     *ringBuffer // Samples kept at this resolution.
+	pending float64     // Sum of samples waiting to be aggregated into this tier.
+	pendN   int         // Number of samples waiting to be aggregated into this tier.
+}
+
+// TieredHistory keeps a bounded time series for long-running sessions.
+//
+// The first tier stores the most recent samples at full resolution. Samples
+// evicted from a tier are averaged by groups of factor and pushed to the next,
+// coarser tier. Samples evicted from the last tier are dropped, so memory stays
+// bounded to tiers * capacity samples while older data remains visible.
+type TieredHistory struct {
+	tiers  []*historyTier
+	factor int
+}
+
+// NewTieredHistory creates an empty tiered history.
+//
+// Parameters:
+//   - capacity: The number of samples kept per tier.
+//   - tiers: The number of resolution tiers (at least 1).
+//   - factor: The number of samples averaged into one sample of the next tier (at least 1).
+//
+// Returns:
+//   - *TieredHistory: The initialized history.
+func NewTieredHistory(capacity, tiers, factor int) *TieredHistory {
+	if tiers < 1 {
+		tiers = 1
+	}
+	if factor < 1 {
+		factor = 1
+	}
+	h := &TieredHistory{
+		tiers:  make([]*historyTier, tiers),
+		factor: factor,
+	}
+	for i := range h.tiers {
+		h.tiers[i] = &historyTier{buf: newRingBuffer(capacity)}
+	}
+	return h
+}
+
+// Add records a new sample at full resolution.
+//
+// Parameters:
+//   - v: The sample value.
+func (h *TieredHistory) Add(v float64) {
+	for i := 0; i < len(h.tiers); i++ {
+		evicted, ok := h.tiers[i].buf.push(v)
+		if !ok || i == len(h.tiers)-1 {
+			return
+		}
+
+		next := h.tiers[i+1]
+		next.pending += evicted
+		next.pendN++
+		if next.pendN < h.factor {
+			return
+		}
+		v = next.pending / float64(next.pendN)
+		next.pending, next.pendN = 0, 0
+	}
+}
+
+// Values returns all retained samples, oldest (most downsampled) first.
+//
+// Returns:
+//   - []float64: The samples in chronological order.
+func (h *TieredHistory) Values() []float64 {
+	values := make([]float64, 0, h.Len())
+	for i := len(h.tiers) - 1; i >= 0; i-- {
+		values = h.tiers[i].buf.appendTo(values)
+	}
+	return values
+}
+
+// Len returns the number of retained samples.
+//
+// Returns:
+//   - int: The number of samples across all tiers.
+func (h *TieredHistory) Len() int {
+	n := 0
+	for _, t := range h.tiers {
+		n += t.buf.count
+	}
+	return n
+}
@@ -0,0 +1,67 @@
+package monitor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTieredHistoryFullResolution vérifie la conservation des échantillons récents.
+func TestTieredHistoryFullResolution(t *testing.T) {
+	h := NewTieredHistory(4, 2, 2)
+	h.Add(1)
+	h.Add(2)
+	h.Add(3)
+
+	assert.Equal(t, []float64{1, 2, 3}, h.Values())
+	assert.Equal(t, 3, h.Len())
+}
+
+// TestTieredHistoryDownsampling vérifie l'agrégation des anciens échantillons.
+func TestTieredHistoryDownsampling(t *testing.T) {
+	h := NewTieredHistory(2, 2, 2)
+	for i := 1; i <= 6; i++ {
+		h.Add(float64(i))
+	}
+
+	// 1 et 2 sont moyennés (1.5), 3 et 4 aussi (3.5) ; 5 et 6 restent en pleine résolution.
+	assert.Equal(t, []float64{1.5, 3.5, 5, 6}, h.Values())
+}
+
+// TestTieredHistoryBounded vérifie que la mémoire reste bornée.
+func TestTieredHistoryBounded(t *testing.T) {
+	h := NewTieredHistory(10, 3, 4)
+	for i := 0; i < 10000; i++ {
+		h.Add(float64(i))
+	}
+
+	assert.Equal(t, 30, h.Len())
+	values := h.Values()
+	assert.Equal(t, 9999.0, values[len(values)-1])
+	for i := 1; i < len(values); i++ {
+		assert.Less(t, values[i-1], values[i], "les valeurs doivent rester chronologiques")
+	}
+}
+
+// TestProcessLogHistoryDownsampled vérifie que les historiques du moniteur restent bornés.
+func TestProcessLogHistoryDownsampled(t *testing.T) {
+	m := New()
+	for i := 0; i < MaxHistorySize*10; i++ {
+		m.ProcessLog(models.LogEntry{
+			Timestamp: models.Now(),
+			Level:     models.LogLevelINFO,
+			Message:   "Métriques système périodiques",
+			Metadata: map[string]interface{}{
+				"messages_per_second":  fmt.Sprintf("%d", i),
+				"success_rate_percent": "100.0",
+			},
+		})
+	}
+
+	assert.LessOrEqual(t, len(m.Metrics.MessagesPerSecond), MaxHistorySize*HistoryTiers)
+	assert.Greater(t, len(m.Metrics.MessagesPerSecond), MaxHistorySize)
+	assert.Equal(t, float64(MaxHistorySize*10-1), m.Metrics.MessagesPerSecond[len(m.Metrics.MessagesPerSecond)-1])
+	assert.Equal(t, len(m.Metrics.MessagesPerSecond), len(m.Metrics.SuccessRateHistory))
+}
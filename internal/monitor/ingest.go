@@ -0,0 +1,107 @@
+package monitor
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+)
+
+// IngestHistory processes the content already on disk for a watched file:
+// its rotated segments (compressed or not), oldest first, then the complete
+// lines of the active file. Entries are processed directly rather than through
+// the watcher channels, so none is dropped however large the history is.
+//
+// Parameters:
+//   - path: The path of the active file.
+//   - kind: The content kind.
+//
+// Returns:
+//   - int64: The offset of the active file up to which lines were ingested,
+//     to be passed to MonitorFileFrom (0 if the file does not exist yet).
+//   - error: An error if a segment cannot be read.
+func (m *Monitor) IngestHistory(path string, kind FileKind) (int64, error) {
+	segments, err := HistorySegments(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var offset int64
+	for _, segment := range segments {
+		var rc io.ReadCloser
+		if segment == path {
+			rc, err = os.Open(segment)
+		} else {
+			rc, err = OpenHistoryFile(segment)
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := m.ingestLines(rc, path, kind)
+		rc.Close()
+		if err != nil {
+			return 0, err
+		}
+		if segment == path {
+			offset = n
+		}
+	}
+	return offset, nil
+}
+
+// ingestLines processes the complete JSON lines of src. A trailing line
+// without newline is left for the watcher, as in readNewLines.
+//
+// Parameters:
+//   - src: The lines source.
+//   - filename: The watched file (used to count parse failures).
+//   - kind: The content kind.
+//
+// Returns:
+//   - int64: The number of bytes of complete lines read.
+//   - error: An error if reading fails.
+func (m *Monitor) ingestLines(src io.Reader, filename string, kind FileKind) (int64, error) {
+	reader := bufio.NewReader(src)
+	var pos int64
+	for {
+		line, err := reader.ReadString('\n')
+		if err == io.EOF {
+			return pos, nil
+		}
+		if err != nil {
+			return pos, err
+		}
+		pos += int64(len(line))
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		switch kind {
+		case FileKindEvents:
+			var entry models.EventEntry
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				recordParseFailure(filename, line)
+				continue
+			}
+			m.ProcessEvent(entry)
+		default:
+			var entry models.LogEntry
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				recordParseFailure(filename, line)
+				continue
+			}
+			m.ProcessLog(entry)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package monitor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIngestHistory vérifie l'ingestion des segments rotatifs et du fichier actif,
+// et que l'offset retourné s'arrête à la dernière ligne complète.
+func TestIngestHistory(t *testing.T) {
+	dir := t.TempDir()
+	active := filepath.Join(dir, "tracker.events")
+
+	writeGzip(t, active+".1.gz",
+		`{"timestamp":"2024-01-01T10:00:00Z","event_type":"message.received","deserialized":true}`+"\n")
+	require.NoError(t, os.Chtimes(active+".1.gz", time.Now().Add(-time.Hour), time.Now().Add(-time.Hour)))
+
+	complete := strings.Join([]string{
+		`{"timestamp":"2024-01-01T10:00:05Z","event_type":"message.received","deserialized":true}`,
+		`{"timestamp":"2024-01-01T10:00:10Z","event_type":"message.received.deserialization_error","deserialized":false}`,
+	}, "\n") + "\n"
+	partial := `{"timestamp":"2024-01-01T10:00:15Z"`
+	require.NoError(t, os.WriteFile(active, []byte(complete+partial), 0644))
+
+	m := New()
+	offset, err := m.IngestHistory(active, FileKindEvents)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(len(complete)), offset)
+	assert.Equal(t, int64(3), m.Metrics.MessagesReceived)
+	assert.Equal(t, int64(2), m.Metrics.MessagesProcessed)
+	assert.Equal(t, int64(1), m.Metrics.MessagesFailed)
+}
+
+// TestIngestHistoryMissing vérifie qu'un fichier ou répertoire absent n'est pas une erreur.
+func TestIngestHistoryMissing(t *testing.T) {
+	m := New()
+	offset, err := m.IngestHistory(filepath.Join(t.TempDir(), "absent", "tracker.log"), FileKindLogs)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), offset)
+	assert.Equal(t, int64(0), m.Metrics.MessagesReceived)
+}
@@ -0,0 +1,219 @@
+package monitor
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/internal/latency"
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// latencyStaleWindows is the number of probe windows without a new snapshot
+// after which the latency panel reports the probe as stale.
+const latencyStaleWindows = 3
+
+// LatencyState is the last snapshot streamed by the latency probe.
+type LatencyState struct {
+	Path     string           // The watched stream file.
+	Snapshot latency.Snapshot // The last snapshot (zero before the first one).
+	Received bool             // Whether a snapshot has been read.
+}
+
+var (
+	latencyMu      sync.RWMutex
+	latencyState   LatencyState
+	latencyEnabled bool
+)
+
+// LatencyPanelState returns the last snapshot of the latency probe.
+//
+// Returns:
+//   - LatencyState: The last snapshot.
+//   - bool: False if no stream file is watched.
+func LatencyPanelState() (LatencyState, bool) {
+	latencyMu.RLock()
+	defer latencyMu.RUnlock()
+	return latencyState, latencyEnabled
+}
+
+// setLatencyState records the watched stream and enables the latency panel.
+//
+// Parameters:
+//   - s: The latency state.
+func setLatencyState(s LatencyState) {
+	latencyMu.Lock()
+	defer latencyMu.Unlock()
+	latencyState = s
+	latencyEnabled = true
+}
+
+// WatchLatency follows the stream file of the latency probe (pubsub latency)
+// and records its last snapshot for the latency panel, the quality score and
+// the latency history of the monitor. The file may not exist yet, and is read
+// again from the start when truncated. It never returns, like MonitorFile.
+//
+// Parameters:
+//   - path: The stream file.
+//   - interval: The polling interval (ProbeInterval if not positive).
+func (m *Monitor) WatchLatency(path string, interval time.Duration) {
+	if interval <= 0 {
+		interval = ProbeInterval
+	}
+	state := LatencyState{Path: path}
+	setLatencyState(state)
+	var offset int64
+	for {
+		snap, found, next := readLatencyStream(path, offset)
+		offset = next
+		if found {
+			state.Snapshot, state.Received = snap, true
+			setLatencyState(state)
+			m.recordLatency(snap)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// recordLatency records a snapshot of the latency probe. Its p99 feeds the
+// quality score and, for a window with measured messages, the latency history.
+//
+// Parameters:
+//   - snap: The snapshot.
+func (m *Monitor) recordLatency(snap latency.Snapshot) {
+	m.Metrics.mu.Lock()
+	defer m.Metrics.mu.Unlock()
+	m.Metrics.latency = snap
+	if snap.Total.Count > 0 {
+		m.Metrics.LatencyHistory = recordHistory(&m.Metrics.latencyHistory, snap.Total.P99)
+	}
+}
+
+// probeLatency returns the p99 delivery latency of the last snapshot of the
+// latency probe. The caller must hold the metrics lock.
+//
+// Parameters:
+//   - now: The current time.
+//
+// Returns:
+//   - time.Duration: The latency, 0 if unavailable, stale or without measured messages.
+func (m *Metrics) probeLatency(now time.Time) time.Duration {
+	s := m.latency
+	if s.Total.Count == 0 || latencyStale(s, now) {
+		return 0
+	}
+	return time.Duration(s.Total.P99 * float64(time.Millisecond))
+}
+
+// latencyStale reports whether the probe wrote no snapshot after s for
+// latencyStaleWindows windows.
+//
+// Parameters:
+//   - s: The last snapshot.
+//   - now: The current time.
+//
+// Returns:
+//   - bool: True if the snapshot is stale.
+func latencyStale(s latency.Snapshot, now time.Time) bool {
+	window := s.End.Sub(s.Start)
+	return window > 0 && now.Sub(s.End) > latencyStaleWindows*window
+}
+
+// readLatencyStream reads the complete snapshot lines appended to the stream
+// file since an offset. Invalid lines are skipped.
+//
+// Parameters:
+//   - path: The stream file.
+//   - offset: The offset of the first unread line.
+//
+// Returns:
+//   - latency.Snapshot: The last snapshot read.
+//   - bool: Whether a snapshot was read.
+//   - int64: The offset following the last complete line.
+func readLatencyStream(path string, offset int64) (latency.Snapshot, bool, int64) {
+	var last latency.Snapshot
+	file, err := os.Open(path)
+	if err != nil {
+		return last, false, offset
+	}
+	defer file.Close()
+	if info, err := file.Stat(); err != nil || info.Size() < offset {
+		offset = 0
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return last, false, offset
+	}
+
+	found := false
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			// Incomplete last line: read again once the probe finished writing it
+			return last, found, offset
+		}
+		offset += int64(len(line))
+		var snap latency.Snapshot
+		if json.Unmarshal(line, &snap) == nil {
+			last, found = snap, true
+		}
+	}
+}
+
+// CreateLatencyPanel initializes the delivery latency widget.
+//
+// Returns:
+//   - *widgets.Paragraph: The initialized paragraph widget.
+func CreateLatencyPanel() *widgets.Paragraph {
+	panel := widgets.NewParagraph()
+	panel.Title = i18n.T("monitor.title.latency")
+	panel.Text = i18n.T("monitor.latency.disabled")
+	panel.TextStyle = ui.NewStyle(ui.ColorWhite)
+	panel.WrapText = false
+	return panel
+}
+
+// UpdateLatencyPanel displays the p50 and p99 delivery latency of each
+// partition in the last window of the latency probe. A snapshot older than
+// latencyStaleWindows windows is shown in yellow.
+//
+// Parameters:
+//   - panel: The latency widget.
+//   - s: The last snapshot.
+//   - enabled: Whether a stream file is watched.
+//   - now: The current time.
+func UpdateLatencyPanel(panel *widgets.Paragraph, s LatencyState, enabled bool, now time.Time) {
+	panel.TextStyle = ui.NewStyle(ui.ColorWhite)
+	switch {
+	case !enabled:
+		panel.Text = i18n.T("monitor.latency.disabled")
+		return
+	case !s.Received:
+		panel.Text = i18n.T("monitor.latency.pending", s.Path)
+		return
+	}
+
+	snap := s.Snapshot
+	lines := []string{i18n.T("monitor.latency.total", snap.Total.Count, snap.Total.P50, snap.Total.P99)}
+	for _, p := range snap.Partitions {
+		lines = append(lines, fmt.Sprintf("P%d: p50 %.1f | p99 %.1f | max %.1f", p.Partition, p.P50, p.P99, p.Max))
+	}
+	if snap.Unstamped > 0 {
+		lines = append(lines, i18n.T("monitor.latency.unstamped", snap.Unstamped))
+	}
+
+	if latencyStale(snap, now) {
+		panel.TextStyle = ui.NewStyle(ui.ColorYellow)
+		lines = append(lines, i18n.T("monitor.latency.stale", now.Sub(snap.End).Round(time.Second)))
+	} else if snap.Total.Count > 0 {
+		panel.TextStyle = ui.NewStyle(ui.ColorGreen)
+	}
+	panel.Text = strings.Join(lines, "\n")
+}
@@ -0,0 +1,121 @@
+package monitor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/internal/latency"
+	ui "github.com/gizak/termui/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appendSnapshot ajoute un instantané au fichier de flux.
+func appendSnapshot(t *testing.T, path string, snap latency.Snapshot, suffix string) {
+	t.Helper()
+	line, err := json.Marshal(snap)
+	require.NoError(t, err)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	defer file.Close()
+	_, err = file.Write(append(line, suffix...))
+	require.NoError(t, err)
+}
+
+// TestReadLatencyStream vérifie la lecture incrémentale du flux de la sonde de latence.
+func TestReadLatencyStream(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "latency.jsonl")
+
+	// Fichier absent : rien à lire
+	_, found, offset := readLatencyStream(path, 0)
+	assert.False(t, found)
+	assert.Zero(t, offset)
+
+	appendSnapshot(t, path, latency.Snapshot{Topic: "orders", Total: latency.Stats{Count: 1}}, "\n")
+	appendSnapshot(t, path, latency.Snapshot{Topic: "orders", Total: latency.Stats{Count: 2}}, "\ninvalide\n")
+	snap, found, offset := readLatencyStream(path, 0)
+	assert.True(t, found)
+	assert.Equal(t, int64(2), snap.Total.Count, "Le dernier instantané valide doit être retenu")
+
+	// Une ligne incomplète est relue une fois terminée
+	appendSnapshot(t, path, latency.Snapshot{Total: latency.Stats{Count: 3}}, "")
+	_, found, next := readLatencyStream(path, offset)
+	assert.False(t, found)
+	assert.Equal(t, offset, next)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	_, err = file.WriteString("\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	snap, found, _ = readLatencyStream(path, next)
+	assert.True(t, found)
+	assert.Equal(t, int64(3), snap.Total.Count)
+
+	// Un fichier tronqué est relu depuis le début
+	require.NoError(t, os.WriteFile(path, nil, 0644))
+	appendSnapshot(t, path, latency.Snapshot{Total: latency.Stats{Count: 4}}, "\n")
+	snap, found, _ = readLatencyStream(path, offset+1000)
+	assert.True(t, found)
+	assert.Equal(t, int64(4), snap.Total.Count)
+}
+
+// TestUpdateLatencyPanel vérifie le contenu du panneau de latence.
+func TestUpdateLatencyPanel(t *testing.T) {
+	panel := CreateLatencyPanel()
+	now := time.Now()
+
+	UpdateLatencyPanel(panel, LatencyState{}, false, now)
+	assert.Equal(t, i18n.T("monitor.latency.disabled"), panel.Text)
+
+	UpdateLatencyPanel(panel, LatencyState{Path: "logs/latency.jsonl"}, true, now)
+	assert.Equal(t, i18n.T("monitor.latency.pending", "logs/latency.jsonl"), panel.Text)
+
+	snap := latency.Snapshot{
+		Start:      now.Add(-5 * time.Second),
+		End:        now,
+		Total:      latency.Stats{Count: 30, P50: 2, P99: 9.5},
+		Partitions: []latency.PartitionStats{{Partition: 0, Stats: latency.Stats{Count: 30, P50: 2, P99: 9.5, Max: 12}}},
+		Unstamped:  3,
+	}
+	UpdateLatencyPanel(panel, LatencyState{Snapshot: snap, Received: true}, true, now)
+	assert.Contains(t, panel.Text, i18n.T("monitor.latency.total", int64(30), 2.0, 9.5))
+	assert.Contains(t, panel.Text, "P0: p50 2.0 | p99 9.5 | max 12.0")
+	assert.Contains(t, panel.Text, i18n.T("monitor.latency.unstamped", int64(3)))
+	assert.Equal(t, ui.ColorGreen, panel.TextStyle.Fg)
+
+	// Sans nouvel instantané depuis plus de trois fenêtres, la sonde est signalée inactive
+	UpdateLatencyPanel(panel, LatencyState{Snapshot: snap, Received: true}, true, now.Add(time.Minute))
+	assert.Contains(t, panel.Text, i18n.T("monitor.latency.stale", time.Minute))
+	assert.Equal(t, ui.ColorYellow, panel.TextStyle.Fg)
+}
+
+// TestRecordLatencyFeedsQuality vérifie que le p99 de la sonde alimente le
+// score de qualité et l'historique de latence, et qu'il est ignoré une fois périmé.
+func TestRecordLatencyFeedsQuality(t *testing.T) {
+	m := New()
+	cfg := m.Metrics.quality
+	cfg.LatencyWeight = 20
+	cfg.LatencyTarget = 100 * time.Millisecond
+	m.SetQualityConfig(cfg)
+	m.Metrics.CurrentSuccessRate = 100
+	m.Metrics.CurrentMessagesPerSec = 1
+	perfect := m.Metrics.qualityScore()
+
+	end := time.Now()
+	slow := latency.Snapshot{Start: end.Add(-5 * time.Second), End: end, Total: latency.Stats{Count: 10, P99: 400}}
+	m.recordLatency(slow)
+	assert.Equal(t, []float64{400}, m.Metrics.LatencyHistory)
+	assert.Equal(t, 400*time.Millisecond, m.Metrics.probeLatency(end))
+	// 20 points réduits à 20 * 100/400 sur 120
+	assert.InDelta(t, 100.0*(100+5)/120, m.Metrics.qualityScore(), 0.001)
+	assert.Less(t, m.Metrics.qualityScore(), perfect)
+
+	assert.Zero(t, m.Metrics.probeLatency(end.Add(time.Minute)), "stale snapshot")
+	m.recordLatency(latency.Snapshot{Start: end, End: end.Add(5 * time.Second)})
+	assert.Len(t, m.Metrics.LatencyHistory, 1, "windows without messages are not recorded")
+	assert.InDelta(t, perfect, m.Metrics.qualityScore(), 0.001)
+}
@@ -10,13 +10,17 @@ import (
 	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/internal/latency"
 	"github.com/agbruneau/PubSub/pkg/models"
 	ui "github.com/gizak/termui/v3"
 	"github.com/gizak/termui/v3/widgets"
@@ -39,50 +43,63 @@ const (
 	MaxRecentLogs           = config.MonitorMaxRecentLogs
 	MaxRecentEvents         = config.MonitorMaxRecentEvents
 	MaxHistorySize          = config.MonitorMaxHistorySize
+	HistoryTiers            = config.MonitorHistoryTiers
+	HistoryDownsampleFactor = config.MonitorHistoryDownsampleFactor
 	LogChannelBuffer        = config.MonitorLogChannelBuffer
 	EventChannelBuffer      = config.MonitorEventChannelBuffer
-	SuccessRateExcellent    = config.MonitorSuccessRateExcellent
-	SuccessRateGood         = config.MonitorSuccessRateGood
-	ThroughputNormal        = config.MonitorThroughputNormal
-	ThroughputLow           = config.MonitorThroughputLow
-	ErrorTimeoutCritical    = config.MonitorErrorTimeoutCritical
-	ErrorTimeoutWarning     = config.MonitorErrorTimeoutWarning
 	QualityThroughputHigh   = config.MonitorQualityThroughputHigh
 	QualityThroughputMedium = config.MonitorQualityThroughputMedium
 	QualityThroughputLow    = config.MonitorQualityThroughputLow
-	QualityScoreExcellent   = config.MonitorQualityScoreExcellent
-	QualityScoreGood        = config.MonitorQualityScoreGood
-	QualityScoreMedium      = config.MonitorQualityScoreMedium
 	FileCheckInterval       = config.MonitorFileCheckInterval
 	FilePollInterval        = config.MonitorFilePollInterval
 	UIUpdateInterval        = config.MonitorUIUpdateInterval
-	MaxLogRowLength         = config.MonitorMaxLogRowLength
-	MaxEventRowLength       = config.MonitorMaxEventRowLength
-	TruncateSuffix          = config.MonitorTruncateSuffix
+	MinUIUpdateInterval     = config.MonitorMinUIUpdateInterval
+	MaxUIUpdateInterval     = config.MonitorMaxUIUpdateInterval
+	MaxClockSkew            = config.MonitorMaxClockSkew
+	ProbeInterval           = config.MonitorProbeInterval
+	ProbeTimeout            = config.MonitorProbeTimeout
 )
 
 // Metrics aggregates and manages the state of all metrics collected by the monitor.
 type Metrics struct {
 	mu                    sync.RWMutex
-	StartTime             time.Time           // Monitor start time.
-	MessagesReceived      int64               // Total number of messages received.
-	MessagesProcessed     int64               // Total number of messages processed successfully.
-	MessagesFailed        int64               // Total number of failed messages.
-	MessagesPerSecond     []float64           // Message throughput history.
-	SuccessRateHistory    []float64           // Success rate history.
-	RecentLogs            []models.LogEntry   // List of recent logs.
-	RecentEvents          []models.EventEntry // List of recent events.
-	LastUpdateTime        time.Time           // Last metrics update time.
-	Uptime                time.Duration       // Uptime duration.
-	CurrentMessagesPerSec float64             // Current throughput.
-	CurrentSuccessRate    float64             // Current success rate.
-	ErrorCount            int64               // Total number of errors.
-	LastErrorTime         time.Time           // Time of the last error.
+	StartTime             time.Time               // Monitor start time.
+	MessagesReceived      int64                   // Total number of messages received.
+	MessagesProcessed     int64                   // Total number of messages processed successfully.
+	MessagesFailed        int64                   // Total number of failed messages.
+	MessagesPerSecond     []float64               // Message throughput history (chronological view of mpsHistory).
+	SuccessRateHistory    []float64               // Success rate history (chronological view of srHistory).
+	LatencyHistory        []float64               // p99 delivery latency history in ms, from the latency probe (chronological view of latencyHistory).
+	RecentLogs            []models.LogEntry       // List of recent logs.
+	RecentEvents          []models.EventEntry     // List of recent events.
+	LastUpdateTime        time.Time               // Last metrics update time.
+	Uptime                time.Duration           // Uptime duration.
+	CurrentMessagesPerSec float64                 // Current throughput.
+	CurrentSuccessRate    float64                 // Current success rate.
+	ErrorCount            int64                   // Total number of errors.
+	LastErrorTime         time.Time               // Time of the last error.
+	MessageSizes          *SizeHistogram          // Message size distribution.
+	Partitions            *PartitionStats         // Message count and last offset per Kafka partition.
+	Orders                *models.OrderAggregator // Business statistics of the deserialized orders.
+	mpsHistory            *TieredHistory          // Downsampled throughput storage.
+	srHistory             *TieredHistory          // Downsampled success rate storage.
+	latencyHistory        *TieredHistory          // Downsampled latency storage.
+	latency               latency.Snapshot        // Last snapshot of the latency probe (zero if none).
+	quality               config.QualityConfig    // Quality score formula.
+	ClockOffset           time.Duration           // Last measured advance of tracker timestamps over the local clock (0 if within tolerance).
+	SkewedEntries         int64                   // Number of entries timestamped beyond the skew tolerance.
+	DLQMessagesSent       int64                   // Messages delivered to the DLQ, as reported by the tracker.
+	DLQSendErrors         int64                   // DLQ send errors, as reported by the tracker.
+	DLQLastSentTime       time.Time               // Time of the last DLQ delivery (zero if none).
+	DLQLastErrorTime      time.Time               // Time of the last DLQ send error (zero if none).
+	timeline              eventTimeline           // Event timeline used for rates and error age.
 }
 
 // Monitor encapsulates all monitoring functionalities.
 type Monitor struct {
-	Metrics *Metrics // The monitored metrics.
+	Metrics  *Metrics                 // The monitored metrics.
+	clock    func() time.Time         // Time source (time.Now unless replaying history).
+	recorder atomic.Pointer[Recorder] // Session recorder (nil unless recording).
 }
 
 // New creates a new Monitor instance.
@@ -98,10 +115,46 @@ func New() *Monitor {
 			MessagesPerSecond:  make([]float64, 0, MaxHistorySize),
 			SuccessRateHistory: make([]float64, 0, MaxHistorySize),
 			LastErrorTime:      time.Time{},
+			MessageSizes:       NewSizeHistogram(MessageSizeBuckets),
+			Partitions:         NewPartitionStats(),
+			Orders:             models.NewOrderAggregator(),
+			mpsHistory:         NewTieredHistory(MaxHistorySize, HistoryTiers, HistoryDownsampleFactor),
+			srHistory:          NewTieredHistory(MaxHistorySize, HistoryTiers, HistoryDownsampleFactor),
+			quality:            config.DefaultQualityConfig(),
+			timeline:           eventTimeline{source: TimeSourceEvent, maxSkew: MaxClockSkew},
 		},
 	}
 }
 
+// SetClock replaces the time source used to timestamp metric updates.
+// This is used when replaying historical files so that rates follow the
+// original timeline.
+//
+// Parameters:
+//   - now: The time source (nil restores time.Now).
+func (m *Monitor) SetClock(now func() time.Time) {
+	m.clock = now
+}
+
+// now returns the current time according to the monitor clock.
+//
+// Returns:
+//   - time.Time: The current time.
+func (m *Monitor) now() time.Time {
+	if m.clock != nil {
+		return m.clock()
+	}
+	return time.Now()
+}
+
+// UpdateUptime refreshes the uptime from the start time and the monitor clock.
+func (m *Monitor) UpdateUptime() {
+	now := m.now()
+	m.Metrics.mu.Lock()
+	defer m.Metrics.mu.Unlock()
+	m.Metrics.Uptime = now.Sub(m.Metrics.StartTime)
+}
+
 // WaitForFile waits for the specified file to exist and returns an open file descriptor.
 // This function blocks until the file is accessible.
 //
@@ -142,15 +195,20 @@ func waitForFileRecreation(filename string) *os.File {
 // Parameters:
 //   - line: The JSON text line to parse.
 //   - logChan: The channel to send the parsed log entry to.
-func parseAndSendLogEntry(line string, logChan chan<- models.LogEntry) {
+//
+// Returns:
+//   - bool: False if the line is not a valid JSON log entry.
+func parseAndSendLogEntry(line string, logChan chan<- models.LogEntry) bool {
 	var entry models.LogEntry
-	if err := json.Unmarshal([]byte(line), &entry); err == nil {
-		select {
-		case logChan <- entry:
-		default:
-			// Channel full, ignore
-		}
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		return false
+	}
+	select {
+	case logChan <- entry:
+	default:
+		// Channel full, ignore
 	}
+	return true
 }
 
 // parseAndSendEventEntry parses a JSON line and sends it to the appropriate channel.
@@ -158,57 +216,68 @@ func parseAndSendLogEntry(line string, logChan chan<- models.LogEntry) {
 // Parameters:
 //   - line: The JSON text line to parse.
 //   - eventChan: The channel to send the parsed event entry to.
-func parseAndSendEventEntry(line string, eventChan chan<- models.EventEntry) {
+//
+// Returns:
+//   - bool: False if the line is not a valid JSON event entry.
+func parseAndSendEventEntry(line string, eventChan chan<- models.EventEntry) bool {
 	var entry models.EventEntry
-	if err := json.Unmarshal([]byte(line), &entry); err == nil {
-		select {
-		case eventChan <- entry:
-		default:
-			// Channel full, ignore
-		}
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		return false
 	}
+	select {
+	case eventChan <- entry:
+	default:
+		// Channel full, ignore
+	}
+	return true
 }
 
-// readNewLines reads new lines from the file and sends them to the channels.
+// readNewLines reads new complete lines from the file and sends them to the channels.
+// Lines are parsed as log entries if logChan is set, as event entries otherwise,
+// so that any configured path can be watched. A trailing line without newline is
+// left unread, since the tracker may still be writing it: the returned position
+// stays at its start so that it is read whole once the newline arrives.
 //
 // Parameters:
 //   - file: The file descriptor.
-//   - filename: The file name (to identify the log type).
+//   - filename: The file name (used to count parse failures).
 //   - currentPos: The current reading position in the file.
 //   - logChan: The channel for logs.
 //   - eventChan: The channel for events.
 //
 // Returns:
-//   - int64: The new reading position.
+//   - int64: The new reading position (end of the last complete line).
 func readNewLines(file *os.File, filename string, currentPos int64, logChan chan<- models.LogEntry, eventChan chan<- models.EventEntry) int64 {
-	_, err := file.Seek(currentPos, 0)
+	_, err := file.Seek(currentPos, io.SeekStart)
 	if err != nil {
 		return currentPos
 	}
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.TrimSpace(line) == "" {
-			continue
+	reader := bufio.NewReader(file)
+	pos := currentPos
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			// Incomplete trailing line (or read error): keep it for the next read
+			return pos
 		}
+		pos += int64(len(line))
 
-		if filename == config.TrackerLogFile {
-			parseAndSendLogEntry(line, logChan)
-		} else if filename == config.TrackerEventsFile {
-			parseAndSendEventEntry(line, eventChan)
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
 		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return currentPos
-	}
 
-	newPos, err := file.Seek(0, os.SEEK_CUR)
-	if err != nil {
-		return currentPos
+		parsed := true
+		if logChan != nil {
+			parsed = parseAndSendLogEntry(line, logChan)
+		} else if eventChan != nil {
+			parsed = parseAndSendEventEntry(line, eventChan)
+		}
+		if !parsed {
+			recordParseFailure(filename, line)
+		}
 	}
-	return newPos
 }
 
 // MonitorFile continuously monitors a file, similar to `tail -f`.
@@ -218,14 +287,30 @@ func readNewLines(file *os.File, filename string, currentPos int64, logChan chan
 //   - logChan: The channel to send logs to.
 //   - eventChan: The channel to send events to.
 func MonitorFile(filename string, logChan chan<- models.LogEntry, eventChan chan<- models.EventEntry) {
+	MonitorFileFrom(filename, 0, logChan, eventChan)
+}
+
+// MonitorFileFrom continuously monitors a file from the given offset, e.g. after
+// its existing content has been ingested with IngestHistory.
+//
+// Parameters:
+//   - filename: The path of the file to monitor.
+//   - offset: The position from which to read new lines.
+//   - logChan: The channel to send logs to.
+//   - eventChan: The channel to send events to.
+func MonitorFileFrom(filename string, offset int64, logChan chan<- models.LogEntry, eventChan chan<- models.EventEntry) {
+	setFileState(filename, FileWaiting)
 	file := WaitForFile(filename)
-	var currentPos int64
+	setFileState(filename, FileWatching)
+	currentPos := offset
 
 	for {
 		stat, err := os.Stat(filename)
 		if err != nil {
+			setFileState(filename, FileMissing)
 			file.Close()
 			file = waitForFileRecreation(filename)
+			setFileState(filename, FileWatching)
 			currentPos = 0
 			continue
 		}
@@ -240,6 +325,10 @@ func MonitorFile(filename string, logChan chan<- models.LogEntry, eventChan chan
 			newPos := readNewLines(file, filename, currentPos, logChan, eventChan)
 			file.Close()
 			file = WaitForFile(filename)
+			if newPos == currentPos {
+				// Only a partial line is available: wait for the writer to complete it
+				time.Sleep(FilePollInterval)
+			}
 			currentPos = newPos
 		} else {
 			time.Sleep(FilePollInterval)
@@ -252,6 +341,8 @@ func MonitorFile(filename string, logChan chan<- models.LogEntry, eventChan chan
 // Parameters:
 //   - entry: The log entry to process.
 func (m *Monitor) ProcessLog(entry models.LogEntry) {
+	now := m.now()
+	m.recordLog(now, entry)
 	m.Metrics.mu.Lock()
 	defer m.Metrics.mu.Unlock()
 
@@ -260,9 +351,10 @@ func (m *Monitor) ProcessLog(entry models.LogEntry) {
 		m.Metrics.RecentLogs = m.Metrics.RecentLogs[1:]
 	}
 
+	eventTime := m.Metrics.eventTime(entry.Timestamp, now)
 	if entry.Level == models.LogLevelERROR {
 		m.Metrics.ErrorCount++
-		m.Metrics.LastErrorTime = time.Now()
+		m.Metrics.LastErrorTime = eventTime
 	}
 
 	if entry.Message == "Métriques système périodiques" && entry.Metadata != nil {
@@ -277,25 +369,78 @@ func (m *Monitor) ProcessLog(entry models.LogEntry) {
 		}
 		if mpsStr, ok := entry.Metadata["messages_per_second"].(string); ok {
 			if mps, err := strconv.ParseFloat(mpsStr, 64); err == nil {
-				m.Metrics.MessagesPerSecond = append(m.Metrics.MessagesPerSecond, mps)
-				if len(m.Metrics.MessagesPerSecond) > MaxHistorySize {
-					m.Metrics.MessagesPerSecond = m.Metrics.MessagesPerSecond[1:]
-				}
+				m.Metrics.MessagesPerSecond = recordHistory(&m.Metrics.mpsHistory, mps)
 				m.Metrics.CurrentMessagesPerSec = mps
 			}
 		}
 		if srStr, ok := entry.Metadata["success_rate_percent"].(string); ok {
 			if sr, err := strconv.ParseFloat(srStr, 64); err == nil {
-				m.Metrics.SuccessRateHistory = append(m.Metrics.SuccessRateHistory, sr)
-				if len(m.Metrics.SuccessRateHistory) > MaxHistorySize {
-					m.Metrics.SuccessRateHistory = m.Metrics.SuccessRateHistory[1:]
-				}
+				m.Metrics.SuccessRateHistory = recordHistory(&m.Metrics.srHistory, sr)
 				m.Metrics.CurrentSuccessRate = sr
 			}
 		}
+		m.Metrics.recordDLQStats(entry.Metadata)
 	}
 
-	m.Metrics.LastUpdateTime = time.Now()
+	m.Metrics.LastUpdateTime = now
+}
+
+// recordDLQStats updates the DLQ statistics from a periodic metrics entry.
+// The tracker only reports them when its retry topics are enabled.
+//
+// Parameters:
+//   - metadata: The metadata of the periodic metrics entry.
+func (m *Metrics) recordDLQStats(metadata map[string]interface{}) {
+	if sent, ok := metadata["dlq_messages_sent"].(float64); ok {
+		m.DLQMessagesSent = int64(sent)
+	}
+	if errs, ok := metadata["dlq_send_errors"].(float64); ok {
+		m.DLQSendErrors = int64(errs)
+	}
+	if s, ok := metadata["dlq_last_sent_time"].(string); ok {
+		if at, err := time.Parse(time.RFC3339, s); err == nil {
+			m.DLQLastSentTime = at
+		}
+	}
+	if s, ok := metadata["dlq_last_error_time"].(string); ok {
+		if at, err := time.Parse(time.RFC3339, s); err == nil {
+			m.DLQLastErrorTime = at
+		}
+	}
+}
+
+// recordHistory adds a sample to a tiered history, creating it if needed.
+//
+// Parameters:
+//   - h: The history to update.
+//   - v: The sample value.
+//
+// Returns:
+//   - []float64: The chronological view of the history.
+func recordHistory(h **TieredHistory, v float64) []float64 {
+	if *h == nil {
+		*h = NewTieredHistory(MaxHistorySize, HistoryTiers, HistoryDownsampleFactor)
+	}
+	(*h).Add(v)
+	return (*h).Values()
+}
+
+// RecentEvents returns a copy of the most recent events, oldest first.
+//
+// Parameters:
+//   - n: The maximum number of events (0 or less: every kept event).
+//
+// Returns:
+//   - []models.EventEntry: The events.
+func (m *Monitor) RecentEvents(n int) []models.EventEntry {
+	m.Metrics.mu.RLock()
+	defer m.Metrics.mu.RUnlock()
+
+	events := m.Metrics.RecentEvents
+	if n > 0 && len(events) > n {
+		events = events[len(events)-n:]
+	}
+	return append([]models.EventEntry(nil), events...)
 }
 
 // ProcessEvent processes an event entry from tracker.events and updates metrics.
@@ -303,6 +448,14 @@ func (m *Monitor) ProcessLog(entry models.LogEntry) {
 // Parameters:
 //   - entry: The event entry to process.
 func (m *Monitor) ProcessEvent(entry models.EventEntry) {
+	now := m.now()
+	m.recordEvent(now, entry)
+	if entry.Deserialized && len(entry.OrderFull) > 0 && m.Metrics.Orders != nil {
+		var order models.Order
+		if err := json.Unmarshal(entry.OrderFull, &order); err == nil {
+			m.Metrics.Orders.Add(order)
+		}
+	}
 	m.Metrics.mu.Lock()
 	defer m.Metrics.mu.Unlock()
 
@@ -311,31 +464,37 @@ func (m *Monitor) ProcessEvent(entry models.EventEntry) {
 		m.Metrics.RecentEvents = m.Metrics.RecentEvents[1:]
 	}
 
+	eventTime := m.Metrics.eventTime(entry.Timestamp, now)
 	if entry.Deserialized {
 		m.Metrics.MessagesProcessed++
 	} else {
 		m.Metrics.MessagesFailed++
 		m.Metrics.ErrorCount++
-		m.Metrics.LastErrorTime = time.Now()
+		m.Metrics.LastErrorTime = eventTime
 	}
 	m.Metrics.MessagesReceived++
+	if m.Metrics.MessageSizes != nil {
+		m.Metrics.MessageSizes.Observe(entry.MessageSize)
+	}
+	if m.Metrics.Partitions != nil {
+		m.Metrics.Partitions.Observe(entry.KafkaPartition, entry.KafkaOffset)
+	}
 
-	uptime := time.Since(m.Metrics.StartTime)
-	if uptime.Seconds() > 0 {
-		m.Metrics.CurrentMessagesPerSec = float64(m.Metrics.MessagesReceived) / uptime.Seconds()
+	if elapsed := m.Metrics.rateWindow(now); elapsed.Seconds() > 0 {
+		m.Metrics.CurrentMessagesPerSec = float64(m.Metrics.MessagesReceived) / elapsed.Seconds()
 	}
 	if m.Metrics.MessagesReceived > 0 {
 		m.Metrics.CurrentSuccessRate = float64(m.Metrics.MessagesProcessed) / float64(m.Metrics.MessagesReceived) * 100
 	}
 
-	m.Metrics.LastUpdateTime = time.Now()
+	m.Metrics.LastUpdateTime = now
 }
 
 // StatusThreshold defines a threshold for status evaluation.
 type StatusThreshold struct {
 	MinValue float64      // The minimum value for this threshold.
 	Status   HealthStatus // The associated health status.
-	Text     string       // The text to display.
+	Text     string       // The i18n key of the text to display.
 	Color    ui.Color     // The color to use.
 }
 
@@ -352,29 +511,45 @@ type StatusThreshold struct {
 func evaluateStatus(value float64, thresholds []StatusThreshold) (HealthStatus, string, ui.Color) {
 	for _, t := range thresholds {
 		if value >= t.MinValue {
-			return t.Status, t.Text, t.Color
+			return t.Status, i18n.T(t.Text), t.Color
 		}
 	}
 	if len(thresholds) > 0 {
 		last := thresholds[len(thresholds)-1]
-		return last.Status, last.Text, last.Color
+		return last.Status, i18n.T(last.Text), last.Color
 	}
-	return HealthCritical, "● INCONNU", ui.ColorRed
+	return HealthCritical, i18n.T("monitor.status.unknown"), ui.ColorRed
 }
 
-var (
-	healthThresholds = []StatusThreshold{
-		{SuccessRateExcellent, HealthGood, "● EXCELLENT", ui.ColorGreen},
-		{SuccessRateGood, HealthWarning, "● BON", ui.ColorYellow},
-		{0, HealthCritical, "● CRITIQUE", ui.ColorRed},
+// healthThresholds returns the success rate thresholds.
+//
+// Parameters:
+//   - t: The configured thresholds.
+//
+// Returns:
+//   - []StatusThreshold: The ordered thresholds.
+func healthThresholds(t config.ThresholdsConfig) []StatusThreshold {
+	return []StatusThreshold{
+		{t.SuccessRateExcellent, HealthGood, "monitor.status.excellent", ui.ColorGreen},
+		{t.SuccessRateGood, HealthWarning, "monitor.status.good", ui.ColorYellow},
+		{0, HealthCritical, "monitor.status.critical", ui.ColorRed},
 	}
+}
 
-	throughputThresholds = []StatusThreshold{
-		{ThroughputNormal, HealthGood, "● NORMAL", ui.ColorGreen},
-		{ThroughputLow, HealthWarning, "● FAIBLE", ui.ColorYellow},
-		{0, HealthCritical, "● ARRÊTÉ", ui.ColorRed},
+// throughputThresholds returns the throughput thresholds.
+//
+// Parameters:
+//   - t: The configured thresholds.
+//
+// Returns:
+//   - []StatusThreshold: The ordered thresholds.
+func throughputThresholds(t config.ThresholdsConfig) []StatusThreshold {
+	return []StatusThreshold{
+		{t.ThroughputNormal, HealthGood, "monitor.status.normal", ui.ColorGreen},
+		{t.ThroughputLow, HealthWarning, "monitor.status.low", ui.ColorYellow},
+		{0, HealthCritical, "monitor.status.stopped", ui.ColorRed},
 	}
-)
+}
 
 // GetHealthStatus evaluates the success rate and returns a health status.
 //
@@ -386,7 +561,7 @@ var (
 //   - string: The status text.
 //   - ui.Color: The status color.
 func GetHealthStatus(successRate float64) (HealthStatus, string, ui.Color) {
-	return evaluateStatus(successRate, healthThresholds)
+	return evaluateStatus(successRate, healthThresholds(currentThresholds()))
 }
 
 // GetThroughputStatus evaluates the message throughput and returns a health status.
@@ -399,7 +574,7 @@ func GetHealthStatus(successRate float64) (HealthStatus, string, ui.Color) {
 //   - string: The status text.
 //   - ui.Color: The status color.
 func GetThroughputStatus(mps float64) (HealthStatus, string, ui.Color) {
-	return evaluateStatus(mps, throughputThresholds)
+	return evaluateStatus(mps, throughputThresholds(currentThresholds()))
 }
 
 // GetErrorStatus evaluates errors and returns a health status.
@@ -413,20 +588,37 @@ func GetThroughputStatus(mps float64) (HealthStatus, string, ui.Color) {
 //   - string: The status text.
 //   - ui.Color: The status color.
 func GetErrorStatus(errorCount int64, lastErrorTime time.Time) (HealthStatus, string, ui.Color) {
+	return GetErrorStatusAt(errorCount, lastErrorTime, time.Now())
+}
+
+// GetErrorStatusAt evaluates errors at a given time of the event timeline.
+//
+// Parameters:
+//   - errorCount: The total number of errors.
+//   - lastErrorTime: The time of the last error.
+//   - now: The current time on the same timeline as lastErrorTime.
+//
+// Returns:
+//   - HealthStatus: The health status.
+//   - string: The status text.
+//   - ui.Color: The status color.
+func GetErrorStatusAt(errorCount int64, lastErrorTime, now time.Time) (HealthStatus, string, ui.Color) {
 	if errorCount == 0 {
-		return HealthGood, "● AUCUN", ui.ColorGreen
+		return HealthGood, i18n.T("monitor.status.no_error"), ui.ColorGreen
 	}
 
-	timeSinceError := time.Since(lastErrorTime)
-	if timeSinceError > ErrorTimeoutWarning {
-		return HealthGood, "● AUCUN", ui.ColorGreen
-	} else if timeSinceError > ErrorTimeoutCritical {
-		return HealthWarning, "● RÉCENT", ui.ColorYellow
+	t := currentThresholds()
+	timeSinceError := now.Sub(lastErrorTime)
+	if timeSinceError > t.ErrorWarning {
+		return HealthGood, i18n.T("monitor.status.no_error"), ui.ColorGreen
+	} else if timeSinceError > t.ErrorCritical {
+		return HealthWarning, i18n.T("monitor.status.recent"), ui.ColorYellow
 	}
-	return HealthCritical, "● ACTIF", ui.ColorRed
+	return HealthCritical, i18n.T("monitor.status.active"), ui.ColorRed
 }
 
-// CalculateQualityScore calculates a global quality score (0-100).
+// CalculateQualityScore calculates a global quality score (0-100) with the
+// default formula (see config.DefaultQualityConfig).
 //
 // Parameters:
 //   - successRate: The success rate in percentage.
@@ -437,32 +629,11 @@ func GetErrorStatus(errorCount int64, lastErrorTime time.Time) (HealthStatus, st
 // Returns:
 //   - float64: The calculated quality score.
 func CalculateQualityScore(successRate, mps float64, errorCount int64, uptime time.Duration) float64 {
-	successScore := (successRate / 100.0) * 50.0
-
-	throughputScore := 0.0
-	if mps >= QualityThroughputHigh {
-		throughputScore = 30.0
-	} else if mps >= QualityThroughputMedium {
-		throughputScore = 25.0
-	} else if mps >= QualityThroughputLow {
-		throughputScore = 15.0
-	} else if mps > 0 {
-		throughputScore = 10.0
-	}
-
-	errorScore := 20.0
-	if errorCount > 0 {
-		errorPenalty := float64(errorCount) * 2.0
-		if errorPenalty > 20.0 {
-			errorPenalty = 20.0
-		}
-		errorScore = 20.0 - errorPenalty
-		if errorScore < 0 {
-			errorScore = 0
-		}
-	}
-
-	return successScore + throughputScore + errorScore
+	return QualityScore(config.DefaultQualityConfig(), QualityInputs{
+		SuccessRate: successRate,
+		MPS:         mps,
+		ErrorCount:  errorCount,
+	})
 }
 
 // CreateMetricsTable initializes the metrics table widget.
@@ -472,13 +643,13 @@ func CalculateQualityScore(successRate, mps float64, errorCount int64, uptime ti
 func CreateMetricsTable() *widgets.Table {
 	table := widgets.NewTable()
 	table.Rows = [][]string{
-		{"Métrique", "Valeur"},
-		{"Messages reçus", "0"},
-		{"Messages traités", "0"},
-		{"Messages échoués", "0"},
-		{"Débit (msg/s)", "0.00"},
-		{"Taux de succès", "0.00%"},
-		{"Dernière màj", "-"},
+		{i18n.T("monitor.metrics.header.metric"), i18n.T("monitor.metrics.header.value")},
+		{i18n.T("monitor.metrics.received"), "0"},
+		{i18n.T("monitor.metrics.processed"), "0"},
+		{i18n.T("monitor.metrics.failed"), "0"},
+		{i18n.T("monitor.metrics.throughput"), "0.00"},
+		{i18n.T("monitor.metrics.success_rate"), "0.00%"},
+		{i18n.T("monitor.metrics.last_update"), "-"},
 	}
 	table.TextStyle = ui.NewStyle(ui.ColorWhite)
 	table.RowStyles[0] = ui.NewStyle(ui.ColorYellow, ui.ColorClear, ui.ModifierBold)
@@ -494,13 +665,11 @@ func CreateMetricsTable() *widgets.Table {
 func CreateHealthDashboard() *widgets.Table {
 	table := widgets.NewTable()
 	table.Rows = [][]string{
-		{"Indicateur", "Statut"},
-		{"Santé Globale", "●"},
-		{"Taux Succès", "●"},
-		{"Débit", "●"},
-		{"Erreurs", "●"},
-		{"Uptime", "-"},
-		{"Qualité", "-"},
+		{i18n.T("monitor.health.header.indicator"), i18n.T("monitor.health.header.status")},
+		{i18n.T("monitor.health.global"), "●"},
+		{i18n.T("monitor.health.throughput"), "●"},
+		{i18n.T("monitor.health.errors"), "●"},
+		{i18n.T("monitor.health.uptime"), "-"},
 	}
 	table.TextStyle = ui.NewStyle(ui.ColorWhite)
 	table.RowStyles[0] = ui.NewStyle(ui.ColorYellow, ui.ColorClear, ui.ModifierBold)
@@ -509,14 +678,42 @@ func CreateHealthDashboard() *widgets.Table {
 	return table
 }
 
+// CreateSuccessRateGauge initializes the success rate gauge widget.
+//
+// Returns:
+//   - *widgets.Gauge: The initialized gauge widget.
+func CreateSuccessRateGauge() *widgets.Gauge {
+	gauge := widgets.NewGauge()
+	gauge.Title = i18n.T("monitor.title.success_gauge")
+	gauge.Percent = 0
+	gauge.BarColor = ui.ColorGreen
+	gauge.LabelStyle = ui.NewStyle(ui.ColorWhite)
+	gauge.SetRect(110, 0, 160, 4)
+	return gauge
+}
+
+// CreateQualityGauge initializes the quality score gauge widget.
+//
+// Returns:
+//   - *widgets.Gauge: The initialized gauge widget.
+func CreateQualityGauge() *widgets.Gauge {
+	gauge := widgets.NewGauge()
+	gauge.Title = i18n.T("monitor.title.quality_gauge")
+	gauge.Percent = 0
+	gauge.BarColor = ui.ColorGreen
+	gauge.LabelStyle = ui.NewStyle(ui.ColorWhite)
+	gauge.SetRect(110, 4, 160, 9)
+	return gauge
+}
+
 // CreateLogList initializes the log list widget.
 //
 // Returns:
 //   - *widgets.List: The initialized list widget.
 func CreateLogList() *widgets.List {
 	list := widgets.NewList()
-	list.Title = "Logs Récents (tracker.log)"
-	list.Rows = []string{"En attente de logs..."}
+	list.Title = i18n.T("monitor.title.logs")
+	list.Rows = []string{i18n.T("monitor.logs.waiting")}
 	list.TextStyle = ui.NewStyle(ui.ColorWhite)
 	list.SelectedRowStyle = ui.NewStyle(ui.ColorBlack, ui.ColorWhite)
 	list.WrapText = true
@@ -530,8 +727,8 @@ func CreateLogList() *widgets.List {
 //   - *widgets.List: The initialized list widget.
 func CreateEventList() *widgets.List {
 	list := widgets.NewList()
-	list.Title = "Événements Récents (tracker.events)"
-	list.Rows = []string{"En attente d'événements..."}
+	list.Title = i18n.T("monitor.title.events")
+	list.Rows = []string{i18n.T("monitor.events.waiting")}
 	list.TextStyle = ui.NewStyle(ui.ColorWhite)
 	list.SelectedRowStyle = ui.NewStyle(ui.ColorBlack, ui.ColorWhite)
 	list.WrapText = true
@@ -545,7 +742,7 @@ func CreateEventList() *widgets.List {
 //   - *widgets.Plot: The initialized plot widget.
 func CreateMessagesPerSecondChart() *widgets.Plot {
 	plot := widgets.NewPlot()
-	plot.Title = "Débit Messages (msg/s)"
+	plot.Title = i18n.T("monitor.title.throughput")
 	plot.Data = [][]float64{{}}
 	plot.SetRect(0, 19, 80, 29)
 	plot.AxesColor = ui.ColorWhite
@@ -560,7 +757,7 @@ func CreateMessagesPerSecondChart() *widgets.Plot {
 //   - *widgets.Plot: The initialized plot widget.
 func CreateSuccessRateChart() *widgets.Plot {
 	plot := widgets.NewPlot()
-	plot.Title = "Taux de Succès (%)"
+	plot.Title = i18n.T("monitor.title.success_rate")
 	plot.Data = [][]float64{{}}
 	plot.SetRect(80, 19, 160, 29)
 	plot.AxesColor = ui.ColorWhite
@@ -576,13 +773,13 @@ func CreateSuccessRateChart() *widgets.Plot {
 //   - m: The current metrics.
 func UpdateMetricsTable(table *widgets.Table, m *Metrics) {
 	table.Rows = [][]string{
-		{"Métrique", "Valeur"},
-		{"Messages reçus", fmt.Sprintf("%d", m.MessagesReceived)},
-		{"Messages traités", fmt.Sprintf("%d", m.MessagesProcessed)},
-		{"Messages échoués", fmt.Sprintf("%d", m.MessagesFailed)},
-		{"Débit (msg/s)", fmt.Sprintf("%.2f", m.CurrentMessagesPerSec)},
-		{"Taux de succès", fmt.Sprintf("%.2f%%", m.CurrentSuccessRate)},
-		{"Dernière màj", m.LastUpdateTime.Format("15:04:05")},
+		{i18n.T("monitor.metrics.header.metric"), i18n.T("monitor.metrics.header.value")},
+		{i18n.T("monitor.metrics.received"), fmt.Sprintf("%d", m.MessagesReceived)},
+		{i18n.T("monitor.metrics.processed"), fmt.Sprintf("%d", m.MessagesProcessed)},
+		{i18n.T("monitor.metrics.failed"), fmt.Sprintf("%d", m.MessagesFailed)},
+		{i18n.T("monitor.metrics.throughput"), fmt.Sprintf("%.2f", m.CurrentMessagesPerSec)},
+		{i18n.T("monitor.metrics.success_rate"), fmt.Sprintf("%.2f%%", m.CurrentSuccessRate)},
+		{i18n.T("monitor.metrics.last_update"), m.LastUpdateTime.Format("15:04:05")},
 	}
 }
 
@@ -592,12 +789,13 @@ func UpdateMetricsTable(table *widgets.Table, m *Metrics) {
 //   - successStatus: The success rate status.
 //   - throughputStatus: The throughput status.
 //   - errorStatus: The error status.
+//   - others: Additional statuses (e.g., the Kafka cluster status).
 //
 // Returns:
 //   - HealthStatus: The global status.
 //   - string: The status text.
 //   - ui.Color: The status color.
-func getGlobalHealthStatus(successStatus, throughputStatus, errorStatus HealthStatus) (HealthStatus, string, ui.Color) {
+func getGlobalHealthStatus(successStatus, throughputStatus, errorStatus HealthStatus, others ...HealthStatus) (HealthStatus, string, ui.Color) {
 	globalStatus := successStatus
 	if throughputStatus > globalStatus {
 		globalStatus = throughputStatus
@@ -605,14 +803,19 @@ func getGlobalHealthStatus(successStatus, throughputStatus, errorStatus HealthSt
 	if errorStatus > globalStatus {
 		globalStatus = errorStatus
 	}
+	for _, s := range others {
+		if s > globalStatus {
+			globalStatus = s
+		}
+	}
 
 	switch globalStatus {
 	case HealthWarning:
-		return globalStatus, "● ATTENTION", ui.ColorYellow
+		return globalStatus, i18n.T("monitor.status.warning"), ui.ColorYellow
 	case HealthCritical:
-		return globalStatus, "● CRITIQUE", ui.ColorRed
+		return globalStatus, i18n.T("monitor.status.critical"), ui.ColorRed
 	default:
-		return globalStatus, "● EXCELLENT", ui.ColorGreen
+		return globalStatus, i18n.T("monitor.status.excellent"), ui.ColorGreen
 	}
 }
 
@@ -625,14 +828,15 @@ func getGlobalHealthStatus(successStatus, throughputStatus, errorStatus HealthSt
 //   - string: The qualitative text.
 //   - ui.Color: The associated color.
 func getQualityText(qualityScore float64) (string, ui.Color) {
-	if qualityScore >= QualityScoreExcellent {
-		return fmt.Sprintf("EXCELLENT (%.0f)", qualityScore), ui.ColorGreen
-	} else if qualityScore >= QualityScoreGood {
-		return fmt.Sprintf("BON (%.0f)", qualityScore), ui.ColorYellow
-	} else if qualityScore >= QualityScoreMedium {
-		return fmt.Sprintf("MOYEN (%.0f)", qualityScore), ui.ColorYellow
+	t := currentThresholds()
+	if qualityScore >= t.QualityExcellent {
+		return i18n.T("monitor.quality.excellent", qualityScore), ui.ColorGreen
+	} else if qualityScore >= t.QualityGood {
+		return i18n.T("monitor.quality.good", qualityScore), ui.ColorYellow
+	} else if qualityScore >= t.QualityMedium {
+		return i18n.T("monitor.quality.medium", qualityScore), ui.ColorYellow
 	}
-	return fmt.Sprintf("FAIBLE (%.0f)", qualityScore), ui.ColorRed
+	return i18n.T("monitor.quality.low", qualityScore), ui.ColorRed
 }
 
 // formatUptime formats the uptime duration into a readable string.
@@ -651,40 +855,82 @@ func formatUptime(uptime time.Duration) string {
 	return fmt.Sprintf("%.0fs", uptime.Seconds())
 }
 
-// UpdateHealthDashboard updates the health dashboard.
+// UpdateHealthDashboard updates the health dashboard. A process liveness row
+// is added when producer/tracker probing is enabled (see WatchProcesses), and
+// the Kafka cluster status is part of the global health when probed (see WatchCluster).
 //
 // Parameters:
 //   - dashboard: The table widget to update.
 //   - m: The current metrics.
 func UpdateHealthDashboard(dashboard *widgets.Table, m *Metrics) {
-	successStatus, successText, successColor := GetHealthStatus(m.CurrentSuccessRate)
+	successStatus, _, _ := GetHealthStatus(m.CurrentSuccessRate)
 	throughputStatus, throughputText, throughputColor := GetThroughputStatus(m.CurrentMessagesPerSec)
-	errorStatus, errorText, errorColor := GetErrorStatus(m.ErrorCount, m.LastErrorTime)
+	errorStatus, errorText, errorColor := GetErrorStatusAt(m.ErrorCount, m.LastErrorTime, m.eventNow(time.Now()))
 
-	_, globalText, globalColor := getGlobalHealthStatus(successStatus, throughputStatus, errorStatus)
+	cluster, _ := ClusterHealthState()
+	clusterStatus, _ := GetClusterStatus(cluster)
+
+	_, globalText, globalColor := getGlobalHealthStatus(successStatus, throughputStatus, errorStatus, clusterStatus)
 
-	qualityScore := CalculateQualityScore(m.CurrentSuccessRate, m.CurrentMessagesPerSec, m.ErrorCount, m.Uptime)
-	qualityText, qualityColor := getQualityText(qualityScore)
 	uptimeStr := formatUptime(m.Uptime)
 
 	dashboard.Rows = [][]string{
-		{"Indicateur", "Statut"},
-		{"Santé Globale", globalText},
-		{"Taux Succès", successText},
-		{"Débit", throughputText},
-		{"Erreurs", errorText},
-		{"Uptime", uptimeStr},
-		{"Qualité", qualityText},
+		{i18n.T("monitor.health.header.indicator"), i18n.T("monitor.health.header.status")},
+		{i18n.T("monitor.health.global"), globalText},
+		{i18n.T("monitor.health.throughput"), throughputText},
+		{i18n.T("monitor.health.errors"), errorText},
+		{i18n.T("monitor.health.uptime"), uptimeStr},
 	}
 
 	dashboard.RowStyles = make(map[int]ui.Style)
 	dashboard.RowStyles[0] = ui.NewStyle(ui.ColorYellow, ui.ColorClear, ui.ModifierBold)
 	dashboard.RowStyles[1] = ui.NewStyle(globalColor, ui.ColorClear, ui.ModifierBold)
-	dashboard.RowStyles[2] = ui.NewStyle(successColor, ui.ColorClear)
-	dashboard.RowStyles[3] = ui.NewStyle(throughputColor, ui.ColorClear)
-	dashboard.RowStyles[4] = ui.NewStyle(errorColor, ui.ColorClear)
-	dashboard.RowStyles[5] = ui.NewStyle(ui.ColorCyan, ui.ColorClear)
-	dashboard.RowStyles[6] = ui.NewStyle(qualityColor, ui.ColorClear, ui.ModifierBold)
+	dashboard.RowStyles[2] = ui.NewStyle(throughputColor, ui.ColorClear)
+	dashboard.RowStyles[3] = ui.NewStyle(errorColor, ui.ColorClear)
+	dashboard.RowStyles[4] = ui.NewStyle(ui.ColorCyan, ui.ColorClear)
+
+	if statuses := ProcessStates(); len(statuses) > 0 {
+		processText, processColor := formatProcessStates(statuses)
+		dashboard.Rows = append(dashboard.Rows, []string{i18n.T("monitor.health.processes"), processText})
+		dashboard.RowStyles[5] = ui.NewStyle(processColor, ui.ColorClear, ui.ModifierBold)
+	}
+}
+
+// clampPercent converts a value to an integer percentage bounded to [0, 100].
+//
+// Parameters:
+//   - value: The value to convert.
+//
+// Returns:
+//   - int: The bounded percentage.
+func clampPercent(value float64) int {
+	if value < 0 {
+		return 0
+	}
+	if value > 100 {
+		return 100
+	}
+	return int(value)
+}
+
+// UpdateGauges updates the success rate and quality score gauges.
+// The bar color follows the same thresholds as the health dashboard.
+//
+// Parameters:
+//   - successGauge: The success rate gauge widget.
+//   - qualityGauge: The quality score gauge widget.
+//   - m: The current metrics.
+func UpdateGauges(successGauge, qualityGauge *widgets.Gauge, m *Metrics) {
+	_, successText, successColor := GetHealthStatus(m.CurrentSuccessRate)
+	successGauge.Percent = clampPercent(m.CurrentSuccessRate)
+	successGauge.BarColor = successColor
+	successGauge.Label = fmt.Sprintf("%.2f%% %s", m.CurrentSuccessRate, successText)
+
+	qualityScore := m.qualityScore()
+	qualityText, qualityColor := getQualityText(qualityScore)
+	qualityGauge.Percent = clampPercent(qualityScore)
+	qualityGauge.BarColor = qualityColor
+	qualityGauge.Label = qualityText
 }
 
 // formatLogRow formats a log entry for display.
@@ -700,16 +946,8 @@ func formatLogRow(log models.LogEntry) string {
 		levelIcon = "🔴"
 	}
 
-	timeStr := log.Timestamp
-	if len(timeStr) > 19 {
-		timeStr = timeStr[11:19]
-	}
-
-	row := fmt.Sprintf("%s [%s] %s", levelIcon, timeStr, log.Message)
-	if len(row) > MaxLogRowLength {
-		row = row[:MaxLogRowLength-len(TruncateSuffix)] + TruncateSuffix
-	}
-	return row
+	t := currentThresholds()
+	return truncateRow(fmt.Sprintf("%s [%s] %s", levelIcon, log.Timestamp.Clock(), log.Message), t.MaxLogRowLength, t.TruncateSuffix)
 }
 
 // UpdateLogList updates the list of recent logs.
@@ -723,7 +961,7 @@ func UpdateLogList(list *widgets.List, logs []models.LogEntry) {
 		rows = append(rows, formatLogRow(logs[i]))
 	}
 	if len(rows) == 0 {
-		rows = []string{"En attente de logs..."}
+		rows = []string{i18n.T("monitor.logs.waiting")}
 	}
 	list.Rows = rows
 }
@@ -741,16 +979,8 @@ func formatEventRow(event models.EventEntry) string {
 		status = "✅"
 	}
 
-	timeStr := event.Timestamp
-	if len(timeStr) > 19 {
-		timeStr = timeStr[11:19]
-	}
-
-	row := fmt.Sprintf("%s [%s] Offset: %d | %s", status, timeStr, event.KafkaOffset, event.EventType)
-	if len(row) > MaxEventRowLength {
-		row = row[:MaxEventRowLength-len(TruncateSuffix)] + TruncateSuffix
-	}
-	return row
+	t := currentThresholds()
+	return truncateRow(fmt.Sprintf("%s [%s] Offset: %d | %s", status, event.Timestamp.Clock(), event.KafkaOffset, event.EventType), t.MaxEventRowLength, t.TruncateSuffix)
 }
 
 // UpdateEventList updates the list of recent events.
@@ -764,7 +994,7 @@ func UpdateEventList(list *widgets.List, events []models.EventEntry) {
 		rows = append(rows, formatEventRow(events[i]))
 	}
 	if len(rows) == 0 {
-		rows = []string{"En attente d'événements..."}
+		rows = []string{i18n.T("monitor.events.waiting")}
 	}
 	list.Rows = rows
 }
@@ -808,4 +1038,5 @@ func (m *Monitor) UpdateUI(table *widgets.Table, healthDashboard *widgets.Table,
 	UpdateLogList(logList, m.Metrics.RecentLogs)
 	UpdateEventList(eventList, m.Metrics.RecentEvents)
 	UpdateCharts(mpsChart, srChart, m.Metrics.MessagesPerSecond, m.Metrics.SuccessRateHistory)
+	UpdateThroughputTitle(mpsChart, m.Metrics.CurrentMessagesPerSec, CalculateThroughputStats(m.Metrics.MessagesPerSecond))
 }
@@ -1,6 +1,8 @@
 package monitor
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
@@ -65,6 +67,39 @@ func TestParseAndSendEventEntry(t *testing.T) {
 	}
 }
 
+// TestParseFixtureEntries vérifie la lecture des entrées du corpus partagé,
+// écrites sur une ligne comme dans les fichiers du tracker.
+func TestParseFixtureEntries(t *testing.T) {
+	compactFixture := func(name string) string {
+		raw, err := models.Fixture(name)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var line bytes.Buffer
+		if err := json.Compact(&line, raw); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return line.String()
+	}
+
+	eventChan := make(chan models.EventEntry, 1)
+	if !parseAndSendEventEntry(compactFixture("event_entry"), eventChan) {
+		t.Fatal("Expected the event entry fixture to be parsed")
+	}
+	event := <-eventChan
+	if event.KafkaOffset != 41 || !event.Deserialized || event.Timestamp.Clock() != "12:00:01" {
+		t.Errorf("Unexpected event entry: %+v", event)
+	}
+
+	logChan := make(chan models.LogEntry, 1)
+	if !parseAndSendLogEntry(compactFixture("log_entry"), logChan) {
+		t.Fatal("Expected the log entry fixture to be parsed")
+	}
+	if entry := <-logChan; entry.Level != models.LogLevelINFO || entry.Service != "order-tracker" {
+		t.Errorf("Unexpected log entry: %+v", entry)
+	}
+}
+
 func TestWaitForFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	filePath := filepath.Join(tmpDir, "wait_test.log")
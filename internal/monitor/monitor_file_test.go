@@ -63,3 +63,92 @@ func TestReadNewLines(t *testing.T) {
 		t.Error("Expected second log entry")
 	}
 }
+
+func TestReadNewLinesCustomEventsPath(t *testing.T) {
+	eventsFile := filepath.Join(t.TempDir(), "custom-audit.jsonl")
+	if err := os.WriteFile(eventsFile, []byte("{\"event_type\":\"message.received\",\"deserialized\":true}\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test events file: %v", err)
+	}
+	f, err := os.Open(eventsFile)
+	if err != nil {
+		t.Fatalf("Failed to open test events file: %v", err)
+	}
+	defer f.Close()
+
+	eventChan := make(chan models.EventEntry, 10)
+	readNewLines(f, eventsFile, 0, nil, eventChan)
+
+	select {
+	case e := <-eventChan:
+		if e.EventType != "message.received" {
+			t.Errorf("Expected 'message.received', got '%s'", e.EventType)
+		}
+	default:
+		t.Error("Expected event entry from a non-default path")
+	}
+}
+
+func TestReadNewLinesPartialLine(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "partial.log")
+	f, err := os.Create(logFile)
+	if err != nil {
+		t.Fatalf("Failed to create test log file: %v", err)
+	}
+	defer f.Close()
+
+	logChan := make(chan models.LogEntry, 10)
+
+	// The tracker is mid-write: the second object has no newline yet
+	f.WriteString("{\"level\":\"INFO\",\"message\":\"msg1\"}\n{\"level\":\"INFO\",")
+	f.Sync()
+
+	pos := readNewLines(f, logFile, 0, logChan, nil)
+	if want := int64(len("{\"level\":\"INFO\",\"message\":\"msg1\"}\n")); pos != want {
+		t.Errorf("Expected position %d (end of the complete line), got %d", want, pos)
+	}
+	if len(logChan) != 1 {
+		t.Fatalf("Expected 1 log entry, got %d", len(logChan))
+	}
+	<-logChan
+
+	// The write completes
+	f.WriteString("\"message\":\"msg2\"}\n")
+	f.Sync()
+
+	readNewLines(f, logFile, pos, logChan, nil)
+	select {
+	case l := <-logChan:
+		if l.Message != "msg2" {
+			t.Errorf("Expected 'msg2', got '%s'", l.Message)
+		}
+	default:
+		t.Error("Expected the completed log entry")
+	}
+
+	if n := ParseFailures()[logFile].Count; n != 0 {
+		t.Errorf("Expected no parse failure, got %d", n)
+	}
+}
+
+func TestReadNewLinesCountsParseFailures(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "corrupt.log")
+	content := "not json\n{\"level\":\"INFO\",\"message\":\"ok\"}\n{broken\n"
+	if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test log file: %v", err)
+	}
+	f, err := os.Open(logFile)
+	if err != nil {
+		t.Fatalf("Failed to open test log file: %v", err)
+	}
+	defer f.Close()
+
+	logChan := make(chan models.LogEntry, 10)
+	readNewLines(f, logFile, 0, logChan, nil)
+
+	if len(logChan) != 1 {
+		t.Errorf("Expected 1 valid log entry, got %d", len(logChan))
+	}
+	if n := ParseFailures()[logFile].Count; n != 2 {
+		t.Errorf("Expected 2 parse failures, got %d", n)
+	}
+}
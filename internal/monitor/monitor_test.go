@@ -1,6 +1,7 @@
 package monitor
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -29,7 +30,7 @@ func TestProcessLog(t *testing.T) {
 
 	// Process an info log
 	entry := models.LogEntry{
-		Timestamp: time.Now().Format(time.RFC3339),
+		Timestamp: models.Now(),
 		Level:     models.LogLevelINFO,
 		Message:   "Test message",
 	}
@@ -48,7 +49,7 @@ func TestProcessLogError(t *testing.T) {
 
 	// Process an error log
 	entry := models.LogEntry{
-		Timestamp: time.Now().Format(time.RFC3339),
+		Timestamp: models.Now(),
 		Level:     models.LogLevelERROR,
 		Message:   "Error message",
 	}
@@ -67,7 +68,7 @@ func TestProcessLogMetrics(t *testing.T) {
 
 	// Process a metrics log
 	entry := models.LogEntry{
-		Timestamp: time.Now().Format(time.RFC3339),
+		Timestamp: models.Now(),
 		Level:     models.LogLevelINFO,
 		Message:   "Métriques système périodiques",
 		Metadata: map[string]interface{}{
@@ -95,6 +96,38 @@ func TestProcessLogMetrics(t *testing.T) {
 	if m.Metrics.CurrentSuccessRate != 95.0 {
 		t.Errorf("Expected 95%% success rate, got %f", m.Metrics.CurrentSuccessRate)
 	}
+	if m.Metrics.DLQMessagesSent != 0 || m.Snapshot().DLQLastSentTime != nil {
+		t.Error("Expected no DLQ statistics without retry topics")
+	}
+}
+
+func TestProcessLogDLQStats(t *testing.T) {
+	m := New()
+	m.ProcessLog(models.LogEntry{
+		Timestamp: models.Now(),
+		Level:     models.LogLevelINFO,
+		Message:   "Métriques système périodiques",
+		Metadata: map[string]interface{}{
+			"dlq_messages_sent":   float64(4),
+			"dlq_send_errors":     float64(1),
+			"dlq_last_sent_time":  "2024-03-01T12:00:00Z",
+			"dlq_last_error_time": "2024-03-01T11:00:00Z",
+		},
+	})
+
+	s := m.Snapshot()
+	if s.DLQMessagesSent != 4 || s.DLQSendErrors != 1 {
+		t.Errorf("Expected 4 DLQ messages and 1 send error, got %d and %d", s.DLQMessagesSent, s.DLQSendErrors)
+	}
+	if s.DLQLastSentTime == nil || !s.DLQLastSentTime.Equal(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected last DLQ delivery time %v", s.DLQLastSentTime)
+	}
+	if s.DLQLastErrorTime == nil || s.DLQLastErrorTime.Hour() != 11 {
+		t.Errorf("Unexpected last DLQ error time %v", s.DLQLastErrorTime)
+	}
+	if !strings.Contains(FormatSummary(s), "DLQ: 4 message(s) envoyé(s) | 1 erreur(s) d'envoi") {
+		t.Errorf("Expected the DLQ line in the summary:\n%s", FormatSummary(s))
+	}
 }
 
 func TestProcessEvent(t *testing.T) {
@@ -103,7 +136,7 @@ func TestProcessEvent(t *testing.T) {
 
 	// Process a successful event
 	entry := models.EventEntry{
-		Timestamp:    time.Now().Format(time.RFC3339),
+		Timestamp:    models.Now(),
 		EventType:    "order_received",
 		Deserialized: true,
 		KafkaOffset:  123,
@@ -127,7 +160,7 @@ func TestProcessEventFailed(t *testing.T) {
 
 	// Process a failed event
 	entry := models.EventEntry{
-		Timestamp:    time.Now().Format(time.RFC3339),
+		Timestamp:    models.Now(),
 		EventType:    "deserialization_failed",
 		Deserialized: false,
 		KafkaOffset:  456,
@@ -218,7 +251,7 @@ func TestMaxRecentLogs(t *testing.T) {
 	// Add more than max logs
 	for i := 0; i < MaxRecentLogs+10; i++ {
 		entry := models.LogEntry{
-			Timestamp: time.Now().Format(time.RFC3339),
+			Timestamp: models.Now(),
 			Level:     models.LogLevelINFO,
 			Message:   "Test",
 		}
@@ -237,7 +270,7 @@ func TestMaxRecentEvents(t *testing.T) {
 	// Add more than max events
 	for i := 0; i < MaxRecentEvents+10; i++ {
 		entry := models.EventEntry{
-			Timestamp:    time.Now().Format(time.RFC3339),
+			Timestamp:    models.Now(),
 			EventType:    "test",
 			Deserialized: true,
 		}
@@ -249,6 +282,25 @@ func TestMaxRecentEvents(t *testing.T) {
 	}
 }
 
+func TestRecentEvents(t *testing.T) {
+	m := New()
+	for i := int64(0); i < 5; i++ {
+		m.ProcessEvent(models.EventEntry{Timestamp: models.Now(), KafkaOffset: i, Deserialized: true})
+	}
+
+	events := m.RecentEvents(2)
+	if len(events) != 2 || events[0].KafkaOffset != 3 || events[1].KafkaOffset != 4 {
+		t.Errorf("Expected the 2 most recent events, got %+v", events)
+	}
+	events[0].KafkaOffset = 42
+	if m.Metrics.RecentEvents[3].KafkaOffset != 3 {
+		t.Error("Expected a copy of the recent events")
+	}
+	if all := m.RecentEvents(0); len(all) != 5 {
+		t.Errorf("Expected every event, got %d", len(all))
+	}
+}
+
 func TestFormatUptime(t *testing.T) {
 	tests := []struct {
 		duration time.Duration
@@ -316,7 +368,7 @@ func TestUpdateLists(t *testing.T) {
 
 	// Test with data
 	logs := []models.LogEntry{
-		{Timestamp: "2024-01-01T10:00:00Z", Level: models.LogLevelINFO, Message: "Test"},
+		{Timestamp: mustTimestamp("2024-01-01T10:00:00Z"), Level: models.LogLevelINFO, Message: "Test"},
 	}
 	UpdateLogList(logList, logs)
 	if len(logList.Rows) != 1 {
@@ -324,7 +376,7 @@ func TestUpdateLists(t *testing.T) {
 	}
 
 	events := []models.EventEntry{
-		{Timestamp: "2024-01-01T10:00:00Z", EventType: "test", Deserialized: true, KafkaOffset: 1},
+		{Timestamp: mustTimestamp("2024-01-01T10:00:00Z"), EventType: "test", Deserialized: true, KafkaOffset: 1},
 	}
 	UpdateEventList(eventList, events)
 	if len(eventList.Rows) != 1 {
@@ -350,3 +402,9 @@ func TestUpdateCharts(t *testing.T) {
 		t.Errorf("Expected 3 MPS data points, got %d", len(mpsChart.Data[0]))
 	}
 }
+
+// mustTimestamp parses an RFC3339 timestamp for the test fixtures.
+func mustTimestamp(s string) models.Timestamp {
+	ts, _ := models.ParseTimestamp(s)
+	return ts
+}
@@ -20,12 +20,12 @@ func TestUpdateUI(t *testing.T) {
 	m.Metrics.CurrentMessagesPerSec = 5.5
 	m.Metrics.CurrentSuccessRate = 90.0
 	m.Metrics.RecentLogs = append(m.Metrics.RecentLogs, models.LogEntry{
-		Timestamp: time.Now().Format(time.RFC3339),
+		Timestamp: models.Now(),
 		Level:     models.LogLevelINFO,
 		Message:   "Test log",
 	})
 	m.Metrics.RecentEvents = append(m.Metrics.RecentEvents, models.EventEntry{
-		Timestamp:    time.Now().Format(time.RFC3339),
+		Timestamp:    models.Now(),
 		EventType:    "Test event",
 		Deserialized: true,
 	})
@@ -103,7 +103,7 @@ func TestGetQualityText(t *testing.T) {
 // TestFormatLogRow vérifie le formatage des logs.
 func TestFormatLogRow(t *testing.T) {
 	entry := models.LogEntry{
-		Timestamp: "2024-01-01T12:00:00Z",
+		Timestamp: mustTimestamp("2024-01-01T12:00:00Z"),
 		Level:     models.LogLevelERROR,
 		Message:   "Erreur critique",
 	}
@@ -117,7 +117,7 @@ func TestFormatLogRow(t *testing.T) {
 // TestFormatEventRow vérifie le formatage des événements.
 func TestFormatEventRow(t *testing.T) {
 	entry := models.EventEntry{
-		Timestamp:    "2024-01-01T12:00:00Z",
+		Timestamp:    mustTimestamp("2024-01-01T12:00:00Z"),
 		EventType:    "order_created",
 		Deserialized: true,
 		KafkaOffset:  123,
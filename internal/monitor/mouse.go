@@ -0,0 +1,259 @@
+package monitor
+
+import (
+	"encoding/json"
+	"image"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/pkg/models"
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// Layout holds the user-adjustable dimensions of the dashboard grid.
+// It is kept for the whole session so that resizing the terminal preserves
+// the pane sizes chosen with the mouse.
+type Layout struct {
+	TopHeight    int     // Height of the metrics/health section.
+	MiddleHeight int     // Height of the logs/events section.
+	SplitRatio   float64 // Horizontal position of the logs/events divider (0-1).
+}
+
+// DefaultLayout returns the initial dashboard layout.
+//
+// Returns:
+//   - Layout: The default layout.
+func DefaultLayout() Layout {
+	return Layout{TopHeight: 9, MiddleHeight: 10, SplitRatio: 0.5}
+}
+
+// Minimum pane sizes enforced while dragging dividers.
+const (
+	minSectionHeight = 4
+	minPaneWidth     = 10
+)
+
+// divider identifies a draggable pane boundary.
+type divider int
+
+const (
+	dividerNone   divider = iota
+	dividerTop            // Between the top and middle sections.
+	dividerMiddle         // Between the middle and bottom sections.
+	dividerSplit          // Between the logs and events lists.
+)
+
+// Pane identifies a selectable dashboard pane.
+type Pane int
+
+const (
+	// PaneNone means that the click did not hit a selectable pane.
+	PaneNone Pane = iota
+	// PaneLogs is the recent logs list.
+	PaneLogs
+	// PaneEvents is the recent events list.
+	PaneEvents
+)
+
+// HandleMouseDown processes a mouse press or drag at the given position.
+// A press on a pane divider starts a drag; subsequent drag events move it.
+// A press inside the logs or events list selects the row under the cursor.
+//
+// Parameters:
+//   - mouse: The termui mouse payload.
+//
+// Returns:
+//   - Pane: The pane whose row was selected (PaneNone otherwise).
+//   - int: The selected row index in the displayed list.
+func (d *Dashboard) HandleMouseDown(mouse ui.Mouse) (Pane, int) {
+	if mouse.Drag && d.dragging != dividerNone {
+		d.moveDivider(mouse.X, mouse.Y)
+		return PaneNone, 0
+	}
+
+	if div := d.dividerAt(mouse.X, mouse.Y); div != dividerNone {
+		d.dragging = div
+		return PaneNone, 0
+	}
+
+	pt := image.Pt(mouse.X, mouse.Y)
+	if row, ok := selectRow(d.LogList, pt); ok {
+		return PaneLogs, row
+	}
+	if row, ok := selectRow(d.EventList, pt); ok {
+		return PaneEvents, row
+	}
+	return PaneNone, 0
+}
+
+// HandleMouseRelease ends any divider drag in progress.
+func (d *Dashboard) HandleMouseRelease() {
+	d.dragging = dividerNone
+}
+
+// dividerAt returns the divider located at the given position.
+//
+// Parameters:
+//   - x: The column.
+//   - y: The row.
+//
+// Returns:
+//   - divider: The divider under the cursor, or dividerNone.
+func (d *Dashboard) dividerAt(x, y int) divider {
+	top := d.Layout.TopHeight
+	middle := top + d.Layout.MiddleHeight
+	switch {
+	case y == top-1 || y == top:
+		return dividerTop
+	case y == middle-1 || y == middle:
+		return dividerMiddle
+	case y > top && y < middle-1 && (x == d.splitX()-1 || x == d.splitX()):
+		return dividerSplit
+	}
+	return dividerNone
+}
+
+// moveDivider moves the divider being dragged to the given position and re-lays out the widgets.
+//
+// Parameters:
+//   - x: The column.
+//   - y: The row.
+func (d *Dashboard) moveDivider(x, y int) {
+	switch d.dragging {
+	case dividerTop:
+		maxTop := d.height - d.Layout.MiddleHeight - minSectionHeight - statusBarHeight
+		d.Layout.TopHeight = clampInt(y, minSectionHeight, maxTop)
+	case dividerMiddle:
+		maxMiddle := d.height - d.Layout.TopHeight - minSectionHeight - statusBarHeight
+		d.Layout.MiddleHeight = clampInt(y-d.Layout.TopHeight, minSectionHeight, maxMiddle)
+	case dividerSplit:
+		if d.width > 0 {
+			x = clampInt(x, minPaneWidth, d.width-minPaneWidth)
+			d.Layout.SplitRatio = float64(x) / float64(d.width)
+		}
+	}
+	d.Resize(d.width, d.height)
+}
+
+// splitX returns the column of the logs/events divider.
+//
+// Returns:
+//   - int: The divider column.
+func (d *Dashboard) splitX() int {
+	return int(float64(d.width) * d.Layout.SplitRatio)
+}
+
+// clampInt bounds a value to [lo, hi]. If hi < lo, lo is returned.
+//
+// Parameters:
+//   - v: The value.
+//   - lo: The lower bound.
+//   - hi: The upper bound.
+//
+// Returns:
+//   - int: The bounded value.
+func clampInt(v, lo, hi int) int {
+	if v > hi {
+		v = hi
+	}
+	if v < lo {
+		v = lo
+	}
+	return v
+}
+
+// selectRow selects the list row located at the given point.
+//
+// Parameters:
+//   - list: The list widget.
+//   - pt: The clicked point.
+//
+// Returns:
+//   - int: The selected row index.
+//   - bool: True if the point is inside the list rows.
+func selectRow(list *widgets.List, pt image.Point) (int, bool) {
+	if !pt.In(list.Inner) {
+		return 0, false
+	}
+	row := pt.Y - list.Inner.Min.Y
+	if row >= len(list.Rows) {
+		return 0, false
+	}
+	list.SelectedRow = row
+	return row, true
+}
+
+// CreateDetailsPopup initializes the popup used to display the selected entry.
+//
+// Returns:
+//   - *widgets.Paragraph: The initialized paragraph widget.
+func CreateDetailsPopup() *widgets.Paragraph {
+	popup := widgets.NewParagraph()
+	popup.Title = i18n.T("monitor.title.details")
+	popup.TextStyle = ui.NewStyle(ui.ColorWhite)
+	popup.BorderStyle = ui.NewStyle(ui.ColorYellow)
+	return popup
+}
+
+// CenterPopup positions a popup in the middle of the terminal.
+//
+// Parameters:
+//   - popup: The popup widget.
+//   - termWidth: The terminal width.
+//   - termHeight: The terminal height.
+func CenterPopup(popup ui.Drawable, termWidth, termHeight int) {
+	w, h := termWidth*3/4, termHeight*3/4
+	x, y := (termWidth-w)/2, (termHeight-h)/2
+	popup.SetRect(x, y, x+w, y+h)
+}
+
+// EntryDetails returns the full JSON content of the entry displayed at the given row.
+// Rows are displayed newest first, as in UpdateLogList and UpdateEventList,
+// after applying the view filter. An entry with an error code is followed by
+// its error in the active locale.
+//
+// Parameters:
+//   - pane: The pane containing the row.
+//   - row: The displayed row index.
+//   - filter: The filter applied to the displayed lists.
+//
+// Returns:
+//   - string: The indented JSON of the entry.
+//   - bool: False if no entry matches the row.
+func (m *Monitor) EntryDetails(pane Pane, row int, filter ViewFilter) (string, bool) {
+	m.Metrics.mu.RLock()
+	defer m.Metrics.mu.RUnlock()
+
+	var entry interface{}
+	var code models.ErrorCode
+	var text string
+	switch pane {
+	case PaneLogs:
+		logs := filter.Logs(m.Metrics.RecentLogs)
+		idx := len(logs) - 1 - row
+		if idx < 0 || idx >= len(logs) {
+			return "", false
+		}
+		entry = logs[idx]
+		code, text = logs[idx].ErrorCode, logs[idx].Error
+	case PaneEvents:
+		events := filter.Events(m.Metrics.RecentEvents)
+		idx := len(events) - 1 - row
+		if idx < 0 || idx >= len(events) {
+			return "", false
+		}
+		entry = events[idx]
+		code, text = events[idx].ErrorCode, events[idx].Error
+	default:
+		return "", false
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return "", false
+	}
+	if code != "" {
+		return string(data) + "\n\n" + i18n.T("monitor.details.error", code, i18n.LocalizeErrorText(string(code), text)), true
+	}
+	return string(data), true
+}
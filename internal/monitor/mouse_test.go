@@ -0,0 +1,71 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+	ui "github.com/gizak/termui/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHandleMouseDownSelectsRow vérifie la sélection d'une ligne au clic.
+func TestHandleMouseDownSelectsRow(t *testing.T) {
+	d := NewDashboard()
+	d.Resize(160, 40)
+	d.EventList.Rows = []string{"a", "b", "c"}
+
+	pane, row := d.HandleMouseDown(ui.Mouse{X: d.EventList.Inner.Min.X + 2, Y: d.EventList.Inner.Min.Y + 1})
+	assert.Equal(t, PaneEvents, pane)
+	assert.Equal(t, 1, row)
+	assert.Equal(t, 1, d.EventList.SelectedRow)
+
+	// Clic sous la dernière ligne : aucune sélection
+	pane, _ = d.HandleMouseDown(ui.Mouse{X: d.EventList.Inner.Min.X + 2, Y: d.EventList.Inner.Min.Y + 5})
+	assert.Equal(t, PaneNone, pane)
+}
+
+// TestDragDividers vérifie le redimensionnement des panneaux par glisser-déposer.
+func TestDragDividers(t *testing.T) {
+	d := NewDashboard()
+	d.Resize(160, 40)
+
+	// Séparateur entre la section du haut et les listes
+	d.HandleMouseDown(ui.Mouse{X: 10, Y: 9})
+	d.HandleMouseDown(ui.Mouse{X: 10, Y: 12, Drag: true})
+	d.HandleMouseRelease()
+	assert.Equal(t, 12, d.Layout.TopHeight)
+	assert.Equal(t, 12, d.LogList.Min.Y)
+
+	// Séparateur vertical entre logs et événements
+	d.HandleMouseDown(ui.Mouse{X: 80, Y: 15})
+	d.HandleMouseDown(ui.Mouse{X: 120, Y: 15, Drag: true})
+	d.HandleMouseRelease()
+	assert.Equal(t, 120, d.LogList.Max.X)
+
+	// La disposition est conservée après un redimensionnement du terminal
+	d.Resize(200, 50)
+	assert.Equal(t, 12, d.LogList.Min.Y)
+	assert.Equal(t, 150, d.LogList.Max.X)
+
+	// Un glissement sans séparateur actif ne change rien
+	d.HandleMouseDown(ui.Mouse{X: 5, Y: 30, Drag: true})
+	assert.Equal(t, 12, d.Layout.TopHeight)
+}
+
+// TestEntryDetails vérifie l'affichage détaillé de l'entrée sélectionnée.
+func TestEntryDetails(t *testing.T) {
+	m := New()
+	m.ProcessLog(models.LogEntry{Timestamp: models.Now(), Level: models.LogLevelINFO, Message: "ancien"})
+	m.ProcessLog(models.LogEntry{Timestamp: models.Now(), Level: models.LogLevelINFO, Message: "récent"})
+
+	text, ok := m.EntryDetails(PaneLogs, 0, ViewFilter{})
+	assert.True(t, ok)
+	assert.Contains(t, text, `"message": "récent"`)
+
+	_, ok = m.EntryDetails(PaneLogs, 5, ViewFilter{})
+	assert.False(t, ok)
+	_, ok = m.EntryDetails(PaneEvents, 0, ViewFilter{})
+	assert.False(t, ok)
+	_, ok = m.EntryDetails(PaneNone, 0, ViewFilter{})
+	assert.False(t, ok)
+}
@@ -0,0 +1,73 @@
+package monitor
+
+import (
+	"sync"
+	"time"
+)
+
+// pacer replays entries on their original timeline: it waits between two
+// entries according to their timestamps and the speed, and drives the
+// monitor clock (see Monitor.SetClock) from these timestamps. It is shared by
+// Replayer and Player.
+type pacer struct {
+	monitor *Monitor              // The monitor to feed.
+	sleep   func(d time.Duration) // Pacing function (time.Sleep, replaceable in tests).
+	mu      sync.Mutex            // Protects speed and last.
+	speed   float64               // Speed factor (0 means instantly).
+	last    time.Time             // Timestamp of the last entry.
+}
+
+// newPacer creates a pacer driving the clock of the given monitor.
+//
+// Parameters:
+//   - m: The monitor to feed.
+//   - speed: The speed factor (1 = original timing, 0 = instantly).
+//
+// Returns:
+//   - pacer: The initialized pacer, to embed; the caller installs its clock.
+func newPacer(m *Monitor, speed float64) pacer {
+	return pacer{monitor: m, speed: speed, sleep: time.Sleep}
+}
+
+// now returns the timestamp of the last entry, or the local time before the first one.
+//
+// Returns:
+//   - time.Time: The replay clock.
+func (p *pacer) now() time.Time {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.last.IsZero() {
+		return time.Now()
+	}
+	return p.last
+}
+
+// advance moves the clock to the timestamp of the next entry, sleeping if
+// paced. The first entry sets the start time of the metrics; an entry older
+// than the clock neither waits nor moves it back.
+//
+// Parameters:
+//   - t: The timestamp of the next entry (ignored if zero).
+func (p *pacer) advance(t time.Time) {
+	if t.IsZero() {
+		return
+	}
+
+	p.mu.Lock()
+	last, speed := p.last, p.speed
+	p.mu.Unlock()
+
+	if last.IsZero() {
+		p.monitor.Metrics.mu.Lock()
+		p.monitor.Metrics.StartTime = t
+		p.monitor.Metrics.mu.Unlock()
+	} else if speed > 0 && t.After(last) {
+		p.sleep(time.Duration(float64(t.Sub(last)) / speed))
+	}
+
+	if t.After(last) {
+		p.mu.Lock()
+		p.last = t
+		p.mu.Unlock()
+	}
+}
@@ -0,0 +1,99 @@
+package monitor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// PartitionStats counts messages and tracks the last offset per Kafka partition,
+// so that partition skew from the producer keying strategy is visible.
+type PartitionStats struct {
+	Counts      map[int32]int64 // Message count per partition.
+	LastOffsets map[int32]int64 // Last observed offset per partition.
+}
+
+// NewPartitionStats creates empty partition statistics.
+//
+// Returns:
+//   - *PartitionStats: The initialized statistics.
+func NewPartitionStats() *PartitionStats {
+	return &PartitionStats{
+		Counts:      make(map[int32]int64),
+		LastOffsets: make(map[int32]int64),
+	}
+}
+
+// Observe records a message read from a partition.
+//
+// Parameters:
+//   - partition: The Kafka partition.
+//   - offset: The message offset in the partition.
+func (s *PartitionStats) Observe(partition int32, offset int64) {
+	s.Counts[partition]++
+	if last, ok := s.LastOffsets[partition]; !ok || offset > last {
+		s.LastOffsets[partition] = offset
+	}
+}
+
+// Partitions returns the observed partitions in ascending order.
+//
+// Returns:
+//   - []int32: The sorted partition numbers.
+func (s *PartitionStats) Partitions() []int32 {
+	partitions := make([]int32, 0, len(s.Counts))
+	for p := range s.Counts {
+		partitions = append(partitions, p)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+	return partitions
+}
+
+// CreatePartitionChart initializes the partition distribution widget.
+//
+// Returns:
+//   - *widgets.BarChart: The initialized bar chart widget.
+func CreatePartitionChart() *widgets.BarChart {
+	chart := widgets.NewBarChart()
+	chart.Title = i18n.T("monitor.title.partitions")
+	chart.Data = []float64{0}
+	chart.Labels = []string{"-"}
+	chart.BarWidth = 5
+	chart.BarGap = 1
+	chart.BarColors = []ui.Color{ui.ColorBlue}
+	chart.LabelStyles = []ui.Style{ui.NewStyle(ui.ColorWhite)}
+	chart.NumStyles = []ui.Style{ui.NewStyle(ui.ColorBlack)}
+	return chart
+}
+
+// UpdatePartitionChart updates the partition distribution chart. Bars show the
+// message count per partition and the title lists the last offset of each one.
+//
+// Parameters:
+//   - chart: The bar chart widget to update.
+//   - s: The partition statistics.
+func UpdatePartitionChart(chart *widgets.BarChart, s *PartitionStats) {
+	partitions := s.Partitions()
+	if len(partitions) == 0 {
+		chart.Title = i18n.T("monitor.title.partitions")
+		chart.Data = []float64{0}
+		chart.Labels = []string{"-"}
+		return
+	}
+
+	data := make([]float64, len(partitions))
+	labels := make([]string, len(partitions))
+	offsets := make([]string, len(partitions))
+	for i, p := range partitions {
+		data[i] = float64(s.Counts[p])
+		labels[i] = fmt.Sprintf("P%d", p)
+		offsets[i] = fmt.Sprintf("P%d@%d", p, s.LastOffsets[p])
+	}
+	chart.Data = data
+	chart.Labels = labels
+	chart.Title = i18n.T("monitor.title.partitions_offsets", strings.Join(offsets, " "))
+}
@@ -0,0 +1,47 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPartitionStatsObserve vérifie le comptage et le dernier offset par partition.
+func TestPartitionStatsObserve(t *testing.T) {
+	s := NewPartitionStats()
+
+	s.Observe(2, 10)
+	s.Observe(0, 5)
+	s.Observe(2, 11)
+	s.Observe(2, 3) // offset plus ancien: le dernier offset reste 11
+
+	assert.Equal(t, []int32{0, 2}, s.Partitions())
+	assert.Equal(t, int64(3), s.Counts[2])
+	assert.Equal(t, int64(11), s.LastOffsets[2])
+	assert.Equal(t, int64(5), s.LastOffsets[0])
+}
+
+// TestProcessEventRecordsPartition vérifie que ProcessEvent alimente le graphique des partitions.
+func TestProcessEventRecordsPartition(t *testing.T) {
+	m := New()
+	chart := CreatePartitionChart()
+
+	UpdatePartitionChart(chart, m.Metrics.Partitions)
+	assert.Equal(t, []string{"-"}, chart.Labels)
+
+	for i, p := range []int32{0, 1, 1} {
+		m.ProcessEvent(models.EventEntry{
+			Timestamp:      models.Now(),
+			Deserialized:   true,
+			KafkaPartition: p,
+			KafkaOffset:    int64(100 + i),
+		})
+	}
+
+	UpdatePartitionChart(chart, m.Metrics.Partitions)
+	assert.Equal(t, []float64{1, 2}, chart.Data)
+	assert.Equal(t, []string{"P0", "P1"}, chart.Labels)
+	assert.Equal(t, i18n.T("monitor.title.partitions_offsets", "P0@100 P1@102"), chart.Title)
+}
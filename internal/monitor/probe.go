@@ -0,0 +1,166 @@
+package monitor
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/i18n"
+	ui "github.com/gizak/termui/v3"
+)
+
+// ProcessState describes the liveness of a probed process.
+type ProcessState string
+
+const (
+	// ProcessUnknown means the process has not been probed yet.
+	ProcessUnknown ProcessState = "unknown"
+	// ProcessUp means the process is running (PID alive or health endpoint answering 2xx).
+	ProcessUp ProcessState = "up"
+	// ProcessDown means the process is not running or its health endpoint fails.
+	ProcessDown ProcessState = "down"
+)
+
+// String returns the translated state.
+//
+// Returns:
+//   - string: The localized state label.
+func (s ProcessState) String() string {
+	return i18n.T("monitor.process." + string(s))
+}
+
+// ProcessStatus is the last probe result of a process.
+type ProcessStatus struct {
+	Name  string       // The process name.
+	State ProcessState // The liveness state.
+}
+
+var (
+	processStatesMu sync.RWMutex
+	processStates   []ProcessStatus
+)
+
+// ProcessStates returns the last probe result of every configured process,
+// in configuration order.
+//
+// Returns:
+//   - []ProcessStatus: A copy of the statuses (empty if probing is disabled).
+func ProcessStates() []ProcessStatus {
+	processStatesMu.RLock()
+	defer processStatesMu.RUnlock()
+	return append([]ProcessStatus(nil), processStates...)
+}
+
+// setProcessStates replaces the recorded probe results.
+//
+// Parameters:
+//   - statuses: The new statuses.
+func setProcessStates(statuses []ProcessStatus) {
+	processStatesMu.Lock()
+	defer processStatesMu.Unlock()
+	processStates = statuses
+}
+
+// WatchProcesses periodically probes the given processes and records their
+// liveness for the health dashboard. It never returns, like MonitorFile.
+//
+// Parameters:
+//   - probes: The processes to probe.
+//   - interval: The interval between two probes (ProbeInterval if not positive).
+func WatchProcesses(probes []config.ProcessProbe, interval time.Duration) {
+	if interval <= 0 {
+		interval = ProbeInterval
+	}
+	statuses := make([]ProcessStatus, len(probes))
+	for i, p := range probes {
+		statuses[i] = ProcessStatus{Name: p.Name, State: ProcessUnknown}
+	}
+	setProcessStates(statuses)
+
+	client := &http.Client{Timeout: ProbeTimeout}
+	for {
+		statuses := make([]ProcessStatus, len(probes))
+		for i, p := range probes {
+			statuses[i] = ProcessStatus{Name: p.Name, State: ProbeProcess(p, client)}
+		}
+		setProcessStates(statuses)
+		time.Sleep(interval)
+	}
+}
+
+// ProbeProcess checks whether a process is alive, through its health endpoint
+// if one is configured, or else through its PID file.
+//
+// Parameters:
+//   - p: The process to probe.
+//   - client: The HTTP client used for health endpoints.
+//
+// Returns:
+//   - ProcessState: ProcessUp or ProcessDown.
+func ProbeProcess(p config.ProcessProbe, client *http.Client) ProcessState {
+	if p.HealthURL != "" {
+		resp, err := client.Get(p.HealthURL)
+		if err != nil {
+			return ProcessDown
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return ProcessDown
+		}
+		return ProcessUp
+	}
+
+	pid, err := readPIDFile(p.PIDFile)
+	if err != nil || !processAlive(pid) {
+		return ProcessDown
+	}
+	return ProcessUp
+}
+
+// readPIDFile reads a process ID written by start.sh.
+//
+// Parameters:
+//   - path: The PID file path.
+//
+// Returns:
+//   - int: The process ID.
+//   - error: An error if the file is missing or invalid.
+func readPIDFile(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("invalid PID file %s", path)
+	}
+	return pid, nil
+}
+
+// formatProcessStates renders the process statuses on one health dashboard row.
+//
+// Parameters:
+//   - statuses: The process statuses.
+//
+// Returns:
+//   - string: The row text (e.g., "producer UP · tracker DOWN").
+//   - ui.Color: Red if a process is down, green if all are up, white otherwise.
+func formatProcessStates(statuses []ProcessStatus) (string, ui.Color) {
+	parts := make([]string, len(statuses))
+	color := ui.ColorGreen
+	for i, s := range statuses {
+		parts[i] = s.Name + " " + s.State.String()
+		switch {
+		case s.State == ProcessDown:
+			color = ui.ColorRed
+		case s.State == ProcessUnknown && color == ui.ColorGreen:
+			color = ui.ColorWhite
+		}
+	}
+	return strings.Join(parts, " · "), color
+}
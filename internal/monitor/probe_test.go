@@ -0,0 +1,73 @@
+package monitor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	ui "github.com/gizak/termui/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestProbeProcessPIDFile vérifie la détection par fichier PID.
+func TestProbeProcessPIDFile(t *testing.T) {
+	dir := t.TempDir()
+	alive := filepath.Join(dir, "tracker.pid")
+	require.NoError(t, os.WriteFile(alive, []byte(strconv.Itoa(os.Getpid())+"\n"), 0644))
+	invalid := filepath.Join(dir, "producer.pid")
+	require.NoError(t, os.WriteFile(invalid, []byte("abc"), 0644))
+
+	client := &http.Client{Timeout: ProbeTimeout}
+	assert.Equal(t, ProcessUp, ProbeProcess(config.ProcessProbe{Name: "tracker", PIDFile: alive}, client))
+	assert.Equal(t, ProcessDown, ProbeProcess(config.ProcessProbe{Name: "producer", PIDFile: invalid}, client))
+	assert.Equal(t, ProcessDown, ProbeProcess(config.ProcessProbe{Name: "producer", PIDFile: filepath.Join(dir, "absent.pid")}, client))
+}
+
+// TestProbeProcessHealthURL vérifie la détection par endpoint de santé.
+func TestProbeProcessHealthURL(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthy.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	client := &http.Client{Timeout: ProbeTimeout}
+	// L'endpoint a priorité sur le fichier PID
+	assert.Equal(t, ProcessUp, ProbeProcess(config.ProcessProbe{HealthURL: healthy.URL, PIDFile: "absent.pid"}, client))
+	assert.Equal(t, ProcessDown, ProbeProcess(config.ProcessProbe{HealthURL: failing.URL}, client))
+
+	failing.Close()
+	assert.Equal(t, ProcessDown, ProbeProcess(config.ProcessProbe{HealthURL: failing.URL}, client))
+}
+
+// TestUpdateHealthDashboardProcesses vérifie l'affichage de la ligne des processus.
+func TestUpdateHealthDashboardProcesses(t *testing.T) {
+	defer setProcessStates(nil)
+
+	dashboard := CreateHealthDashboard()
+	m := &Metrics{}
+
+	UpdateHealthDashboard(dashboard, m)
+	assert.Len(t, dashboard.Rows, 5)
+
+	setProcessStates([]ProcessStatus{
+		{Name: "producer", State: ProcessUp},
+		{Name: "tracker", State: ProcessDown},
+	})
+	UpdateHealthDashboard(dashboard, m)
+	require.Len(t, dashboard.Rows, 6)
+	assert.Equal(t, "producer UP · tracker DOWN", dashboard.Rows[5][1])
+	assert.Equal(t, ui.ColorRed, dashboard.RowStyles[5].Fg)
+
+	setProcessStates([]ProcessStatus{{Name: "producer", State: ProcessUp}})
+	UpdateHealthDashboard(dashboard, m)
+	assert.Equal(t, ui.ColorGreen, dashboard.RowStyles[5].Fg)
+}
@@ -0,0 +1,27 @@
+//go:build !windows
+
+package monitor
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// processAlive reports whether a process with the given ID exists.
+// Signal 0 performs the existence check without affecting the process;
+// EPERM means the process exists but belongs to another user.
+//
+// Parameters:
+//   - pid: The process ID.
+//
+// Returns:
+//   - bool: True if the process exists.
+func processAlive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = proc.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,22 @@
+//go:build windows
+
+package monitor
+
+import "os"
+
+// processAlive reports whether a process with the given ID exists.
+// On Windows, FindProcess opens a handle and fails if the process is gone.
+//
+// Parameters:
+//   - pid: The process ID.
+//
+// Returns:
+//   - bool: True if the process exists.
+func processAlive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	proc.Release()
+	return true
+}
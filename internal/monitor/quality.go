@@ -0,0 +1,122 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+)
+
+// QualityInputs contains the metrics used to compute the quality score.
+// Latency and Lag are optional: a zero value means the metric is unavailable
+// and the corresponding component is left out of the score.
+type QualityInputs struct {
+	SuccessRate float64       // Success rate in percentage.
+	MPS         float64       // Throughput in messages per second.
+	ErrorCount  int64         // Total number of errors.
+	Latency     time.Duration // p99 delivery latency measured by the latency probe (0 if unavailable).
+	Lag         int64         // Consumer lag in messages (0 if unavailable).
+}
+
+// QualityScore computes the quality score (0-100) with a configurable formula.
+//
+// Each component earns up to its weight:
+//   - success: SuccessWeight * successRate / 100
+//   - throughput: the score of the first bucket whose MinMPS is reached (only if mps > 0)
+//   - errors: ErrorWeight - ErrorPenalty * errorCount, floored at 0
+//   - latency: LatencyWeight, scaled down by target/latency above the target
+//   - lag: LagWeight, scaled down by target/lag above the target
+//
+// The sum is normalized over the weights of the components in use.
+//
+// Parameters:
+//   - cfg: The quality formula.
+//   - in: The metrics to score.
+//
+// Returns:
+//   - float64: The quality score.
+func QualityScore(cfg config.QualityConfig, in QualityInputs) float64 {
+	score := cfg.SuccessWeight * in.SuccessRate / 100.0
+	total := cfg.SuccessWeight
+
+	maxThroughput := 0.0
+	for _, b := range cfg.ThroughputBuckets {
+		if b.Score > maxThroughput {
+			maxThroughput = b.Score
+		}
+	}
+	total += maxThroughput
+	if in.MPS > 0 {
+		for _, b := range cfg.ThroughputBuckets {
+			if in.MPS >= b.MinMPS {
+				score += b.Score
+				break
+			}
+		}
+	}
+
+	errorScore := cfg.ErrorWeight - cfg.ErrorPenalty*float64(in.ErrorCount)
+	if errorScore < 0 {
+		errorScore = 0
+	}
+	score += errorScore
+	total += cfg.ErrorWeight
+
+	if cfg.LatencyWeight > 0 && in.Latency > 0 {
+		target := cfg.LatencyTarget
+		score += cfg.LatencyWeight * targetRatio(float64(target), float64(in.Latency))
+		total += cfg.LatencyWeight
+	}
+	if cfg.LagWeight > 0 && in.Lag > 0 {
+		score += cfg.LagWeight * targetRatio(float64(cfg.LagTargetMessages), float64(in.Lag))
+		total += cfg.LagWeight
+	}
+
+	if total <= 0 {
+		return 0
+	}
+	return score * 100.0 / total
+}
+
+// targetRatio returns 1 when value meets the target, target/value otherwise.
+//
+// Parameters:
+//   - target: The target value (lower is better).
+//   - value: The measured value.
+//
+// Returns:
+//   - float64: The ratio in [0, 1].
+func targetRatio(target, value float64) float64 {
+	if value <= target {
+		return 1
+	}
+	return target / value
+}
+
+// SetQualityConfig replaces the quality score formula.
+//
+// Parameters:
+//   - cfg: The quality formula, typically AppConfig.Monitor.Quality.
+func (m *Monitor) SetQualityConfig(cfg config.QualityConfig) {
+	m.Metrics.mu.Lock()
+	defer m.Metrics.mu.Unlock()
+	m.Metrics.quality = cfg
+}
+
+// qualityScore computes the quality score of the current metrics.
+// The default formula is used if none was configured.
+// The caller must hold the metrics lock.
+//
+// Returns:
+//   - float64: The quality score.
+func (m *Metrics) qualityScore() float64 {
+	cfg := m.quality
+	if cfg.SuccessWeight == 0 && cfg.ErrorWeight == 0 && len(cfg.ThroughputBuckets) == 0 {
+		cfg = config.DefaultQualityConfig()
+	}
+	return QualityScore(cfg, QualityInputs{
+		SuccessRate: m.CurrentSuccessRate,
+		MPS:         m.CurrentMessagesPerSec,
+		ErrorCount:  m.ErrorCount,
+		Latency:     m.probeLatency(time.Now()),
+	})
+}
@@ -0,0 +1,52 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestQualityScoreDefault vérifie que la formule par défaut reproduit l'ancien calcul.
+func TestQualityScoreDefault(t *testing.T) {
+	cfg := config.DefaultQualityConfig()
+
+	assert.InDelta(t, 100.0, QualityScore(cfg, QualityInputs{SuccessRate: 100, MPS: 1}), 0.001)
+	assert.InDelta(t, 25.0+15.0+18.0, QualityScore(cfg, QualityInputs{SuccessRate: 50, MPS: 0.2, ErrorCount: 1}), 0.001)
+	assert.InDelta(t, 25.0, QualityScore(cfg, QualityInputs{SuccessRate: 50, ErrorCount: 50}), 0.001)
+}
+
+// TestQualityScoreCustom vérifie la normalisation avec des poids personnalisés et la latence.
+func TestQualityScoreCustom(t *testing.T) {
+	cfg := config.QualityConfig{
+		SuccessWeight: 50,
+		ErrorWeight:   25,
+		ErrorPenalty:  5,
+		LatencyWeight: 25,
+		LatencyTarget: 100 * time.Millisecond,
+	}
+
+	// Latence indisponible : score normalisé sur succès et erreurs
+	assert.InDelta(t, 100.0, QualityScore(cfg, QualityInputs{SuccessRate: 100}), 0.001)
+
+	// Latence deux fois supérieure à la cible : moitié des points de latence
+	score := QualityScore(cfg, QualityInputs{SuccessRate: 100, Latency: 200 * time.Millisecond})
+	assert.InDelta(t, 87.5, score, 0.001)
+
+	// Latence dans la cible : tous les points
+	score = QualityScore(cfg, QualityInputs{SuccessRate: 100, Latency: 50 * time.Millisecond})
+	assert.InDelta(t, 100.0, score, 0.001)
+}
+
+// TestSetQualityConfig vérifie que le snapshot utilise la formule configurée.
+func TestSetQualityConfig(t *testing.T) {
+	m := New()
+	m.Metrics.CurrentSuccessRate = 100
+	m.SetQualityConfig(config.QualityConfig{SuccessWeight: 100})
+
+	assert.InDelta(t, 100.0, m.Snapshot().QualityScore, 0.001)
+
+	m.Metrics.CurrentSuccessRate = 40
+	assert.InDelta(t, 40.0, m.Snapshot().QualityScore, 0.001)
+}
@@ -0,0 +1,352 @@
+package monitor
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+)
+
+// FileKind identifies the content of a tracker output file.
+type FileKind int
+
+const (
+	// FileKindLogs identifies a structured log file (tracker.log).
+	FileKindLogs FileKind = iota
+	// FileKindEvents identifies an audit trail file (tracker.events).
+	FileKindEvents
+)
+
+// DetectFileKind infers the file content from its name.
+// Rotated segments (e.g., tracker.events.1.gz) are recognized as well.
+//
+// Parameters:
+//   - path: The file path.
+//
+// Returns:
+//   - FileKind: FileKindEvents if the name contains ".events", FileKindLogs otherwise.
+func DetectFileKind(path string) FileKind {
+	if strings.Contains(filepath.Base(path), ".events") {
+		return FileKindEvents
+	}
+	return FileKindLogs
+}
+
+// HistorySegments lists the rotated segments of a file followed by the file itself,
+// oldest first. Segments are the files named "<base>.*" or "<base>-*" in the same
+// directory (e.g., tracker.events.1, tracker.events.2.gz), ordered by modification time.
+//
+// Parameters:
+//   - path: The path of the active file.
+//
+// Returns:
+//   - []string: The segment paths in chronological order.
+//   - error: An error if the directory cannot be read.
+func HistorySegments(path string) ([]string, error) {
+	dir := filepath.Dir(path)
+	base := filepath.Base(path)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	type segment struct {
+		path    string
+		modTime time.Time
+	}
+	var segments []segment
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || name == base {
+			continue
+		}
+		if !strings.HasPrefix(name, base+".") && !strings.HasPrefix(name, base+"-") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segment{path: filepath.Join(dir, name), modTime: info.ModTime()})
+	}
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].modTime.Before(segments[j].modTime)
+	})
+
+	paths := make([]string, 0, len(segments)+1)
+	for _, s := range segments {
+		paths = append(paths, s.path)
+	}
+	if _, err := os.Stat(path); err == nil {
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// OpenHistoryFile opens a history segment, transparently decompressing ".gz" files.
+//
+// Parameters:
+//   - path: The segment path.
+//
+// Returns:
+//   - io.ReadCloser: The (decompressed) content.
+//   - error: An error if the file cannot be opened or is not valid gzip.
+func OpenHistoryFile(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return file, nil
+	}
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to decompress %s: %w", path, err)
+	}
+	return &gzipFile{Reader: gz, file: file}, nil
+}
+
+// gzipFile closes both the gzip reader and the underlying file.
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+// Close closes the gzip stream and the file.
+//
+// Returns:
+//   - error: The first error encountered.
+func (g *gzipFile) Close() error {
+	gzErr := g.Reader.Close()
+	if err := g.file.Close(); err != nil {
+		return err
+	}
+	return gzErr
+}
+
+// Replayer re-drives a Monitor from historical tracker files.
+type Replayer struct {
+	pacer // Replay clock.
+}
+
+// NewReplayer creates a replayer feeding the given monitor.
+// The monitor clock is driven by the replayed timestamps so that rates
+// reflect the original timeline rather than the replay duration.
+//
+// Parameters:
+//   - m: The monitor to feed.
+//   - speed: The speed factor (1 = real time, 10 = ten times faster, 0 = instantly).
+//
+// Returns:
+//   - *Replayer: The initialized replayer.
+func NewReplayer(m *Monitor, speed float64) *Replayer {
+	r := &Replayer{pacer: newPacer(m, speed)}
+	m.SetClock(r.now)
+	return r
+}
+
+// ReplayFiles replays all segments of the given files. The entries of the
+// files (e.g., tracker.events and tracker.log) are merged by timestamp, so
+// that a paced replay reconstructs the original timeline across files.
+//
+// Parameters:
+//   - paths: The active file paths; their rotated segments are included automatically.
+//
+// Returns:
+//   - error: An error if a segment cannot be read.
+func (r *Replayer) ReplayFiles(paths []string) error {
+	streams := make([]*entryStream, 0, len(paths))
+	for _, path := range paths {
+		segments, err := HistorySegments(path)
+		if err != nil {
+			return err
+		}
+		if len(segments) == 0 {
+			return fmt.Errorf("no history found for %s", path)
+		}
+		streams = append(streams, &entryStream{kind: DetectFileKind(path), segments: segments})
+	}
+	return r.replayStreams(streams)
+}
+
+// Replay reads JSON lines from src and feeds them to the monitor, pacing
+// them according to their timestamps and the replay speed.
+//
+// Parameters:
+//   - src: The JSON lines source.
+//   - kind: The content kind.
+//
+// Returns:
+//   - error: An error if reading fails.
+func (r *Replayer) Replay(src io.Reader, kind FileKind) error {
+	s := &entryStream{kind: kind}
+	s.start(io.NopCloser(src), "")
+	return r.replayStreams([]*entryStream{s})
+}
+
+// replayStreams feeds the entries of the streams to the monitor in timestamp
+// order. Each stream being chronological, this is a k-way merge; with the
+// few files of an analysis, the oldest head is found by a linear scan. Equal
+// timestamps keep the order of the streams.
+//
+// Parameters:
+//   - streams: The streams, in argument order.
+//
+// Returns:
+//   - error: An error if a stream cannot be read.
+func (r *Replayer) replayStreams(streams []*entryStream) error {
+	defer func() {
+		for _, s := range streams {
+			s.close()
+		}
+	}()
+	for _, s := range streams {
+		if err := s.next(); err != nil {
+			return err
+		}
+	}
+
+	for {
+		var oldest *entryStream
+		for _, s := range streams {
+			if s.ok && (oldest == nil || s.order.Before(oldest.order)) {
+				oldest = s
+			}
+		}
+		if oldest == nil {
+			return nil
+		}
+
+		r.advance(oldest.head.time)
+		switch oldest.kind {
+		case FileKindEvents:
+			r.monitor.ProcessEvent(oldest.head.event)
+		default:
+			r.monitor.ProcessLog(oldest.head.log)
+		}
+		if err := oldest.next(); err != nil {
+			return err
+		}
+	}
+}
+
+// replayEntry is a decoded line of a tracker file.
+type replayEntry struct {
+	time  time.Time         // Timestamp of the entry (zero if absent).
+	log   models.LogEntry   // The entry of a log file.
+	event models.EventEntry // The entry of an events file.
+}
+
+// entryStream reads the entries of the segments of a file, oldest first.
+// Invalid lines are skipped.
+type entryStream struct {
+	kind     FileKind       // Content kind.
+	segments []string       // Segments not opened yet.
+	segment  string         // Current segment ("" for a reader passed to Replay).
+	rc       io.ReadCloser  // Content of the current segment (nil between segments).
+	scanner  *bufio.Scanner // Lines of the current segment.
+	head     replayEntry    // Next entry, valid when ok.
+	ok       bool           // Whether head is valid.
+	order    time.Time      // Merge position of head: an entry without timestamp keeps the previous one.
+}
+
+// start reads the given segment.
+//
+// Parameters:
+//   - rc: The segment content.
+//   - segment: The segment path, used in errors.
+func (s *entryStream) start(rc io.ReadCloser, segment string) {
+	s.rc, s.segment = rc, segment
+	s.scanner = bufio.NewScanner(rc)
+	s.scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+}
+
+// close closes the current segment.
+func (s *entryStream) close() {
+	if s.rc != nil {
+		s.rc.Close()
+	}
+	s.rc, s.scanner = nil, nil
+}
+
+// next reads the next entry into head, opening the following segments as
+// needed; ok is false at the end of the last segment.
+//
+// Returns:
+//   - error: An error if a segment cannot be opened or read.
+func (s *entryStream) next() error {
+	s.ok = false
+	for {
+		if s.scanner == nil {
+			if len(s.segments) == 0 {
+				return nil
+			}
+			path := s.segments[0]
+			s.segments = s.segments[1:]
+			rc, err := OpenHistoryFile(path)
+			if err != nil {
+				return err
+			}
+			s.start(rc, path)
+		}
+
+		for s.scanner.Scan() {
+			if entry, ok := decodeEntry(s.scanner.Bytes(), s.kind); ok {
+				if !entry.time.IsZero() {
+					s.order = entry.time
+				}
+				s.head, s.ok = entry, true
+				return nil
+			}
+		}
+		err := s.scanner.Err()
+		if err != nil && s.segment != "" {
+			err = fmt.Errorf("failed to replay %s: %w", s.segment, err)
+		}
+		s.close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// decodeEntry decodes a JSON line of a tracker file.
+//
+// Parameters:
+//   - line: The line.
+//   - kind: The content kind.
+//
+// Returns:
+//   - replayEntry: The entry.
+//   - bool: False for a blank or invalid line.
+func decodeEntry(line []byte, kind FileKind) (replayEntry, bool) {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return replayEntry{}, false
+	}
+	var e replayEntry
+	switch kind {
+	case FileKindEvents:
+		if err := json.Unmarshal(line, &e.event); err != nil {
+			return replayEntry{}, false
+		}
+		e.time = e.event.Timestamp.Time
+	default:
+		if err := json.Unmarshal(line, &e.log); err != nil {
+			return replayEntry{}, false
+		}
+		e.time = e.log.Timestamp.Time
+	}
+	return e, true
+}
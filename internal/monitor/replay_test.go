@@ -0,0 +1,131 @@
+package monitor
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeGzip écrit un contenu compressé dans un fichier.
+func writeGzip(t *testing.T, path, content string) {
+	t.Helper()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	_, err = gz.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+}
+
+// TestDetectFileKind vérifie la détection du type de fichier.
+func TestDetectFileKind(t *testing.T) {
+	assert.Equal(t, FileKindEvents, DetectFileKind("logs/tracker.events"))
+	assert.Equal(t, FileKindEvents, DetectFileKind("logs/tracker.events.2.gz"))
+	assert.Equal(t, FileKindLogs, DetectFileKind("logs/tracker.log"))
+}
+
+// TestHistorySegments vérifie l'ordre chronologique des segments rotatifs.
+func TestHistorySegments(t *testing.T) {
+	dir := t.TempDir()
+	active := filepath.Join(dir, "tracker.events")
+	older := filepath.Join(dir, "tracker.events.2.gz")
+	recent := filepath.Join(dir, "tracker.events.1")
+
+	require.NoError(t, os.WriteFile(active, nil, 0644))
+	require.NoError(t, os.WriteFile(recent, nil, 0644))
+	writeGzip(t, older, "")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tracker.log"), nil, 0644))
+
+	now := time.Now()
+	require.NoError(t, os.Chtimes(older, now.Add(-2*time.Hour), now.Add(-2*time.Hour)))
+	require.NoError(t, os.Chtimes(recent, now.Add(-time.Hour), now.Add(-time.Hour)))
+
+	segments, err := HistorySegments(active)
+	require.NoError(t, err)
+	assert.Equal(t, []string{older, recent, active}, segments)
+}
+
+// TestReplayFilesInstant vérifie la relecture instantanée avec segments compressés.
+func TestReplayFilesInstant(t *testing.T) {
+	dir := t.TempDir()
+	active := filepath.Join(dir, "tracker.events")
+
+	writeGzip(t, active+".1.gz",
+		`{"timestamp":"2024-01-01T10:00:00Z","event_type":"message.received","deserialized":true,"message_size":100}`+"\n")
+	require.NoError(t, os.Chtimes(active+".1.gz", time.Now().Add(-time.Hour), time.Now().Add(-time.Hour)))
+	require.NoError(t, os.WriteFile(active, []byte(strings.Join([]string{
+		`{"timestamp":"2024-01-01T10:00:05Z","event_type":"message.received","deserialized":true,"message_size":100}`,
+		`not json`,
+		`{"timestamp":"2024-01-01T10:00:10Z","event_type":"message.received.deserialization_error","deserialized":false}`,
+	}, "\n")+"\n"), 0644))
+
+	m := New()
+	r := NewReplayer(m, 0)
+	require.NoError(t, r.ReplayFiles([]string{active}))
+
+	assert.Equal(t, int64(3), m.Metrics.MessagesReceived)
+	assert.Equal(t, int64(2), m.Metrics.MessagesProcessed)
+	assert.Equal(t, int64(1), m.Metrics.MessagesFailed)
+	// 3 messages sur 10 secondes de chronologie originale
+	assert.InDelta(t, 0.3, m.Metrics.CurrentMessagesPerSec, 0.001)
+
+	m.UpdateUptime()
+	assert.Equal(t, 10*time.Second, m.Metrics.Uptime)
+}
+
+// TestReplayPaced vérifie que la relecture respecte la chronologie et le facteur de vitesse.
+func TestReplayPaced(t *testing.T) {
+	m := New()
+	r := NewReplayer(m, 2)
+	var slept []time.Duration
+	r.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	input := `{"timestamp":"2024-01-01T10:00:00Z","level":"INFO","message":"a"}
+{"timestamp":"2024-01-01T10:00:10Z","level":"ERROR","message":"b"}
+`
+	require.NoError(t, r.Replay(strings.NewReader(input), FileKindLogs))
+
+	assert.Equal(t, []time.Duration{5 * time.Second}, slept)
+	assert.Equal(t, int64(1), m.Metrics.ErrorCount)
+	assert.Equal(t, "2024-01-01T10:00:10Z", m.Metrics.LastErrorTime.UTC().Format(time.RFC3339))
+}
+
+// TestReplayFilesMerged vérifie que les entrées des fichiers d'événements et
+// de logs sont fusionnées par horodatage avant d'être cadencées.
+func TestReplayFilesMerged(t *testing.T) {
+	dir := t.TempDir()
+	events := filepath.Join(dir, "tracker.events")
+	logs := filepath.Join(dir, "tracker.log")
+	require.NoError(t, os.WriteFile(events, []byte(strings.Join([]string{
+		`{"timestamp":"2024-01-01T10:00:00Z","event_type":"message.received","deserialized":true,"message_size":100}`,
+		`{"timestamp":"2024-01-01T10:00:10Z","event_type":"message.received","deserialized":true,"message_size":100}`,
+	}, "\n")+"\n"), 0644))
+	writeGzip(t, logs+".1.gz", `{"timestamp":"2024-01-01T10:00:02Z","level":"INFO","message":"a"}`+"\n")
+	require.NoError(t, os.Chtimes(logs+".1.gz", time.Now().Add(-time.Hour), time.Now().Add(-time.Hour)))
+	require.NoError(t, os.WriteFile(logs, []byte(`{"timestamp":"2024-01-01T10:00:05Z","level":"ERROR","message":"b"}`+"\n"), 0644))
+
+	m := New()
+	r := NewReplayer(m, 1)
+	var slept []time.Duration
+	r.sleep = func(d time.Duration) { slept = append(slept, d) }
+	require.NoError(t, r.ReplayFiles([]string{events, logs}))
+
+	assert.Equal(t, []time.Duration{2 * time.Second, 3 * time.Second, 5 * time.Second}, slept)
+	assert.Equal(t, int64(2), m.Metrics.MessagesReceived)
+	assert.Equal(t, int64(1), m.Metrics.ErrorCount)
+	assert.Equal(t, "2024-01-01T10:00:05Z", m.Metrics.LastErrorTime.UTC().Format(time.RFC3339))
+}
+
+// TestReplayFilesMissing vérifie l'erreur quand aucun historique n'existe.
+func TestReplayFilesMissing(t *testing.T) {
+	r := NewReplayer(New(), 0)
+	err := r.ReplayFiles([]string{filepath.Join(t.TempDir(), "tracker.events")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,227 @@
+package monitor
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+)
+
+// Session record kinds.
+const (
+	RecordKindLog   = "log"
+	RecordKindEvent = "event"
+)
+
+// Playback speed bounds for Player.Faster and Player.Slower.
+const (
+	minPlaybackSpeed = 0.125
+	maxPlaybackSpeed = 64
+)
+
+// SessionRecord is one line of a session recording: an entry processed by
+// the monitor and the time at which it was processed.
+type SessionRecord struct {
+	Time  time.Time          `json:"time"`            // Processing time.
+	Kind  string             `json:"kind"`            // RecordKindLog or RecordKindEvent.
+	Log   *models.LogEntry   `json:"log,omitempty"`   // The log entry (Kind == RecordKindLog).
+	Event *models.EventEntry `json:"event,omitempty"` // The event entry (Kind == RecordKindEvent).
+}
+
+// Recorder writes the entries processed by a monitor to a session file.
+type Recorder struct {
+	mu  sync.Mutex
+	w   *bufio.Writer
+	err error // First write error, reported by Flush.
+}
+
+// NewRecorder creates a recorder writing JSON lines to w.
+//
+// Parameters:
+//   - w: The destination of the recording.
+//
+// Returns:
+//   - *Recorder: The initialized recorder.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: bufio.NewWriter(w)}
+}
+
+// record appends a record to the session.
+//
+// Parameters:
+//   - rec: The record to write.
+func (r *Recorder) record(rec SessionRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		r.err = err
+		return
+	}
+	data = append(data, '\n')
+	if _, err := r.w.Write(data); err != nil {
+		r.err = err
+	}
+}
+
+// Flush writes buffered records to the underlying writer.
+//
+// Returns:
+//   - error: The first error encountered while recording or flushing.
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return fmt.Errorf("failed to record session: %w", r.err)
+	}
+	if err := r.w.Flush(); err != nil {
+		return fmt.Errorf("failed to record session: %w", err)
+	}
+	return nil
+}
+
+// SetRecorder records every entry processed by the monitor from now on.
+//
+// Parameters:
+//   - r: The recorder (nil stops recording).
+func (m *Monitor) SetRecorder(r *Recorder) {
+	m.recorder.Store(r)
+}
+
+// recordLog records a processed log entry if recording is enabled.
+//
+// Parameters:
+//   - now: The processing time.
+//   - entry: The log entry.
+func (m *Monitor) recordLog(now time.Time, entry models.LogEntry) {
+	if r := m.recorder.Load(); r != nil {
+		r.record(SessionRecord{Time: now, Kind: RecordKindLog, Log: &entry})
+	}
+}
+
+// recordEvent records a processed event entry if recording is enabled.
+//
+// Parameters:
+//   - now: The processing time.
+//   - entry: The event entry.
+func (m *Monitor) recordEvent(now time.Time, entry models.EventEntry) {
+	if r := m.recorder.Load(); r != nil {
+		r.record(SessionRecord{Time: now, Kind: RecordKindEvent, Event: &entry})
+	}
+}
+
+// Player re-drives a Monitor from a session recording at an adjustable speed.
+type Player struct {
+	pacer // Playback clock; speed is adjustable with Faster and Slower.
+}
+
+// NewPlayer creates a player feeding the given monitor. As with Replayer,
+// the monitor clock follows the recorded times so that rates are reproduced.
+//
+// Parameters:
+//   - m: The monitor to feed.
+//   - speed: The speed factor (1 = as recorded, 2 = twice as fast, 0 = instantly).
+//
+// Returns:
+//   - *Player: The initialized player.
+func NewPlayer(m *Monitor, speed float64) *Player {
+	p := &Player{pacer: newPacer(m, speed)}
+	m.SetClock(p.now)
+	return p
+}
+
+// Speed returns the current playback speed.
+//
+// Returns:
+//   - float64: The speed factor.
+func (p *Player) Speed() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.speed
+}
+
+// Faster doubles the playback speed.
+//
+// Returns:
+//   - float64: The new speed factor.
+func (p *Player) Faster() float64 {
+	return p.scaleSpeed(2)
+}
+
+// Slower halves the playback speed.
+//
+// Returns:
+//   - float64: The new speed factor.
+func (p *Player) Slower() float64 {
+	return p.scaleSpeed(0.5)
+}
+
+// scaleSpeed multiplies the playback speed, within the allowed bounds.
+// An instant playback (speed 0) is not affected.
+//
+// Parameters:
+//   - factor: The multiplier.
+//
+// Returns:
+//   - float64: The new speed factor.
+func (p *Player) scaleSpeed(factor float64) float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.speed <= 0 {
+		return p.speed
+	}
+	p.speed *= factor
+	if p.speed < minPlaybackSpeed {
+		p.speed = minPlaybackSpeed
+	}
+	if p.speed > maxPlaybackSpeed {
+		p.speed = maxPlaybackSpeed
+	}
+	return p.speed
+}
+
+// Play reads a session recording and feeds its entries to the monitor,
+// waiting between entries according to the recorded timing and the speed.
+//
+// Parameters:
+//   - src: The session recording.
+//
+// Returns:
+//   - error: An error if a record is invalid or reading fails.
+func (p *Player) Play(src io.Reader) error {
+	scanner := bufio.NewScanner(src)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		var rec SessionRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return fmt.Errorf("invalid session record at line %d: %w", line, err)
+		}
+		p.advance(rec.Time)
+
+		switch {
+		case rec.Kind == RecordKindLog && rec.Log != nil:
+			p.monitor.ProcessLog(*rec.Log)
+		case rec.Kind == RecordKindEvent && rec.Event != nil:
+			p.monitor.ProcessEvent(*rec.Event)
+		default:
+			return fmt.Errorf("invalid session record at line %d: unknown kind %q", line, rec.Kind)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,74 @@
+package monitor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRecordAndPlay vérifie qu'une session enregistrée est rejouée à l'identique.
+func TestRecordAndPlay(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	clock := start
+
+	var buf bytes.Buffer
+	recorded := New()
+	recorded.SetClock(func() time.Time { return clock })
+	recorder := NewRecorder(&buf)
+	recorded.SetRecorder(recorder)
+
+	recorded.ProcessEvent(models.EventEntry{EventType: "message.received", Deserialized: true})
+	clock = start.Add(2 * time.Second)
+	recorded.ProcessLog(models.LogEntry{Level: models.LogLevelERROR, Message: "échec"})
+	clock = start.Add(3 * time.Second)
+	recorded.ProcessEvent(models.EventEntry{EventType: "message.received", Deserialized: false})
+	require.NoError(t, recorder.Flush())
+	assert.Equal(t, 3, strings.Count(buf.String(), "\n"))
+
+	played := New()
+	player := NewPlayer(played, 2)
+	var sleeps []time.Duration
+	player.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+
+	require.NoError(t, player.Play(&buf))
+	assert.Equal(t, []time.Duration{time.Second, 500 * time.Millisecond}, sleeps)
+	assert.Equal(t, recorded.Metrics.MessagesReceived, played.Metrics.MessagesReceived)
+	assert.Equal(t, recorded.Metrics.MessagesFailed, played.Metrics.MessagesFailed)
+	assert.Equal(t, recorded.Metrics.ErrorCount, played.Metrics.ErrorCount)
+	assert.Equal(t, start, played.Metrics.StartTime)
+	assert.Equal(t, start.Add(3*time.Second), played.now())
+}
+
+// TestPlayInvalidRecord vérifie le signalement d'une ligne invalide.
+func TestPlayInvalidRecord(t *testing.T) {
+	player := NewPlayer(New(), 0)
+
+	err := player.Play(strings.NewReader("{\"kind\":\"unknown\"}\n"))
+	assert.ErrorContains(t, err, "line 1")
+
+	err = player.Play(strings.NewReader("\nnot json\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
+
+// TestPlayerSpeed vérifie les bornes de la vitesse de lecture.
+func TestPlayerSpeed(t *testing.T) {
+	player := NewPlayer(New(), 1)
+	assert.Equal(t, 2.0, player.Faster())
+	for i := 0; i < 20; i++ {
+		player.Faster()
+	}
+	assert.Equal(t, float64(maxPlaybackSpeed), player.Speed())
+	for i := 0; i < 20; i++ {
+		player.Slower()
+	}
+	assert.Equal(t, minPlaybackSpeed, player.Speed())
+
+	// Une lecture instantanée reste instantanée
+	instant := NewPlayer(New(), 0)
+	assert.Equal(t, 0.0, instant.Faster())
+}
@@ -0,0 +1,146 @@
+package monitor
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// ThroughputStats contains percentile statistics of the throughput history.
+type ThroughputStats struct {
+	P50 float64 `json:"p50"` // Median throughput (msg/s).
+	P95 float64 `json:"p95"` // 95th percentile throughput (msg/s).
+	Max float64 `json:"max"` // Maximum observed throughput (msg/s).
+}
+
+// CalculateThroughputStats computes p50, p95 and max over a throughput history.
+//
+// Parameters:
+//   - history: The throughput samples in messages per second.
+//
+// Returns:
+//   - ThroughputStats: The computed statistics (zero values if history is empty).
+func CalculateThroughputStats(history []float64) ThroughputStats {
+	if len(history) == 0 {
+		return ThroughputStats{}
+	}
+
+	sorted := make([]float64, len(history))
+	copy(sorted, history)
+	sort.Float64s(sorted)
+
+	return ThroughputStats{
+		P50: percentile(sorted, 50),
+		P95: percentile(sorted, 95),
+		Max: sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the p-th percentile of sorted values using the nearest-rank method.
+//
+// Parameters:
+//   - sorted: The values sorted in ascending order (must not be empty).
+//   - p: The percentile to compute (0-100).
+//
+// Returns:
+//   - float64: The percentile value.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// UpdateThroughputTitle displays the current throughput and its percentiles in the chart title.
+//
+// Parameters:
+//   - chart: The throughput chart widget.
+//   - current: The current throughput.
+//   - stats: The throughput statistics.
+func UpdateThroughputTitle(chart *widgets.Plot, current float64, stats ThroughputStats) {
+	chart.Title = i18n.T("monitor.title.throughput_stats",
+		current, stats.P50, stats.P95, stats.Max)
+}
+
+// Snapshot is a point-in-time, serializable copy of the monitor metrics.
+type Snapshot struct {
+	Timestamp             time.Time         `json:"timestamp"`                     // Snapshot creation time.
+	UptimeSeconds         float64           `json:"uptime_seconds"`                // Monitor uptime in seconds.
+	MessagesReceived      int64             `json:"messages_received"`             // Total number of messages received.
+	MessagesProcessed     int64             `json:"messages_processed"`            // Total number of messages processed successfully.
+	MessagesFailed        int64             `json:"messages_failed"`               // Total number of failed messages.
+	CurrentMessagesPerSec float64           `json:"messages_per_second"`           // Current throughput.
+	CurrentSuccessRate    float64           `json:"success_rate_percent"`          // Current success rate.
+	ErrorCount            int64             `json:"error_count"`                   // Total number of errors.
+	QualityScore          float64           `json:"quality_score"`                 // Global quality score (0-100).
+	Throughput            ThroughputStats   `json:"throughput_percentiles"`        // Throughput percentiles.
+	ClockOffsetSeconds    float64           `json:"clock_offset_seconds"`          // Advance of tracker timestamps over the local clock.
+	SkewedEntries         int64             `json:"skewed_entries"`                // Entries timestamped beyond the skew tolerance.
+	DLQMessagesSent       int64             `json:"dlq_messages_sent"`             // Messages delivered to the DLQ.
+	DLQSendErrors         int64             `json:"dlq_send_errors"`               // DLQ send errors.
+	DLQLastSentTime       *time.Time        `json:"dlq_last_sent_time,omitempty"`  // Time of the last DLQ delivery.
+	DLQLastErrorTime      *time.Time        `json:"dlq_last_error_time,omitempty"` // Time of the last DLQ send error.
+	Orders                models.OrderStats `json:"orders"`                        // Business statistics of the deserialized orders.
+}
+
+// Snapshot captures the current metrics in an exportable form.
+//
+// Returns:
+//   - Snapshot: The metrics snapshot.
+func (m *Monitor) Snapshot() Snapshot {
+	m.Metrics.mu.RLock()
+	defer m.Metrics.mu.RUnlock()
+
+	return Snapshot{
+		Timestamp:             time.Now().UTC(),
+		UptimeSeconds:         m.Metrics.Uptime.Seconds(),
+		MessagesReceived:      m.Metrics.MessagesReceived,
+		MessagesProcessed:     m.Metrics.MessagesProcessed,
+		MessagesFailed:        m.Metrics.MessagesFailed,
+		CurrentMessagesPerSec: m.Metrics.CurrentMessagesPerSec,
+		CurrentSuccessRate:    m.Metrics.CurrentSuccessRate,
+		ErrorCount:            m.Metrics.ErrorCount,
+		QualityScore:          m.Metrics.qualityScore(),
+		Throughput:            CalculateThroughputStats(m.Metrics.MessagesPerSecond),
+		ClockOffsetSeconds:    m.Metrics.ClockOffset.Seconds(),
+		SkewedEntries:         m.Metrics.SkewedEntries,
+		DLQMessagesSent:       m.Metrics.DLQMessagesSent,
+		DLQSendErrors:         m.Metrics.DLQSendErrors,
+		DLQLastSentTime:       optionalTime(m.Metrics.DLQLastSentTime),
+		DLQLastErrorTime:      optionalTime(m.Metrics.DLQLastErrorTime),
+		Orders:                m.orderStats(),
+	}
+}
+
+// orderStats returns the business statistics of the deserialized orders.
+//
+// Returns:
+//   - models.OrderStats: The statistics (empty if the monitor has no aggregator).
+func (m *Monitor) orderStats() models.OrderStats {
+	if m.Metrics.Orders == nil {
+		return models.OrderStats{}
+	}
+	return m.Metrics.Orders.Snapshot()
+}
+
+// optionalTime converts a time to a pointer omitted from JSON when zero.
+//
+// Parameters:
+//   - t: The time.
+//
+// Returns:
+//   - *time.Time: A copy of t (nil if t is zero).
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
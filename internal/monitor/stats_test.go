@@ -0,0 +1,50 @@
+package monitor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCalculateThroughputStats vérifie le calcul des percentiles.
+func TestCalculateThroughputStats(t *testing.T) {
+	assert.Equal(t, ThroughputStats{}, CalculateThroughputStats(nil))
+
+	history := []float64{5, 1, 4, 2, 3, 6, 7, 8, 9, 10}
+	stats := CalculateThroughputStats(history)
+	assert.Equal(t, 5.0, stats.P50)
+	assert.Equal(t, 10.0, stats.P95)
+	assert.Equal(t, 10.0, stats.Max)
+
+	// L'historique original ne doit pas être trié
+	assert.Equal(t, 5.0, history[0])
+
+	single := CalculateThroughputStats([]float64{2.5})
+	assert.Equal(t, ThroughputStats{P50: 2.5, P95: 2.5, Max: 2.5}, single)
+}
+
+// TestUpdateThroughputTitle vérifie l'affichage des percentiles.
+func TestUpdateThroughputTitle(t *testing.T) {
+	chart := CreateMessagesPerSecondChart()
+	UpdateThroughputTitle(chart, 1.5, ThroughputStats{P50: 1, P95: 2, Max: 3})
+
+	assert.Contains(t, chart.Title, "actuel 1.50")
+	assert.Contains(t, chart.Title, "p95 2.00")
+	assert.Contains(t, chart.Title, "max 3.00")
+}
+
+// TestSnapshot vérifie que l'instantané exporté contient les percentiles.
+func TestSnapshot(t *testing.T) {
+	m := New()
+	m.Metrics.MessagesReceived = 10
+	m.Metrics.MessagesPerSecond = []float64{1, 2, 3}
+
+	snap := m.Snapshot()
+	assert.Equal(t, int64(10), snap.MessagesReceived)
+	assert.Equal(t, 3.0, snap.Throughput.Max)
+
+	data, err := json.Marshal(snap)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"throughput_percentiles":{"p50":2,"p95":3,"max":3}`)
+}
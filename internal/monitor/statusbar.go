@@ -0,0 +1,188 @@
+package monitor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/i18n"
+	ui "github.com/gizak/termui/v3"
+	"github.com/gizak/termui/v3/widgets"
+)
+
+// RefreshRate holds the UI refresh interval adjustable at runtime.
+// The interval is halved or doubled on each step and bounded between
+// MinUIUpdateInterval and MaxUIUpdateInterval.
+type RefreshRate struct {
+	interval time.Duration
+}
+
+// NewRefreshRate creates a refresh rate with the given initial interval.
+//
+// Parameters:
+//   - initial: The initial interval (clamped to the allowed bounds).
+//
+// Returns:
+//   - *RefreshRate: The initialized refresh rate.
+func NewRefreshRate(initial time.Duration) *RefreshRate {
+	return &RefreshRate{interval: clampInterval(initial)}
+}
+
+// clampInterval bounds an interval to the allowed refresh range.
+//
+// Parameters:
+//   - d: The interval to bound.
+//
+// Returns:
+//   - time.Duration: The bounded interval.
+func clampInterval(d time.Duration) time.Duration {
+	if d < MinUIUpdateInterval {
+		return MinUIUpdateInterval
+	}
+	if d > MaxUIUpdateInterval {
+		return MaxUIUpdateInterval
+	}
+	return d
+}
+
+// Interval returns the current refresh interval.
+//
+// Returns:
+//   - time.Duration: The interval.
+func (r *RefreshRate) Interval() time.Duration {
+	return r.interval
+}
+
+// Faster halves the refresh interval.
+//
+// Returns:
+//   - time.Duration: The new interval.
+func (r *RefreshRate) Faster() time.Duration {
+	r.interval = clampInterval(r.interval / 2)
+	return r.interval
+}
+
+// Slower doubles the refresh interval.
+//
+// Returns:
+//   - time.Duration: The new interval.
+func (r *RefreshRate) Slower() time.Duration {
+	r.interval = clampInterval(r.interval * 2)
+	return r.interval
+}
+
+// FileState describes the watch state of a monitored file.
+type FileState string
+
+const (
+	// FileWaiting means the file does not exist yet.
+	FileWaiting FileState = "waiting"
+	// FileWatching means the file is open and being tailed.
+	FileWatching FileState = "watching"
+	// FileMissing means the file disappeared and is awaited again.
+	FileMissing FileState = "missing"
+)
+
+// String returns the translated state.
+//
+// Returns:
+//   - string: The state text in the active locale.
+func (s FileState) String() string {
+	return i18n.T("monitor.file." + string(s))
+}
+
+var (
+	fileStatesMu sync.RWMutex
+	fileStates   = make(map[string]FileState)
+)
+
+// setFileState records the watch state of a file.
+//
+// Parameters:
+//   - filename: The monitored file path.
+//   - state: The new state.
+func setFileState(filename string, state FileState) {
+	fileStatesMu.Lock()
+	defer fileStatesMu.Unlock()
+	fileStates[filename] = state
+}
+
+// FileStates returns the watch state of every monitored file.
+//
+// Returns:
+//   - map[string]FileState: A copy of the states keyed by file path.
+func FileStates() map[string]FileState {
+	fileStatesMu.RLock()
+	defer fileStatesMu.RUnlock()
+	states := make(map[string]FileState, len(fileStates))
+	for k, v := range fileStates {
+		states[k] = v
+	}
+	return states
+}
+
+// StatusInfo contains the information displayed in the status bar.
+type StatusInfo struct {
+	RefreshInterval time.Duration        // Current UI refresh interval.
+	LastRefresh     time.Time            // Time of the last UI refresh.
+	Paused          bool                 // True if UI refreshes are paused.
+	Filters         []string             // Active view filters.
+	Files           map[string]FileState // Watch state of the monitored files.
+	Message         string               // Result of the last user action (e.g., an export).
+}
+
+// CreateStatusBar initializes the bottom status bar widget.
+//
+// Returns:
+//   - *widgets.Paragraph: The initialized paragraph widget.
+func CreateStatusBar() *widgets.Paragraph {
+	bar := widgets.NewParagraph()
+	bar.Border = true
+	bar.TextStyle = ui.NewStyle(ui.ColorCyan)
+	bar.Text = "-"
+	bar.SetRect(0, 29, 160, 32)
+	return bar
+}
+
+// UpdateStatusBar updates the status bar text.
+//
+// Parameters:
+//   - bar: The status bar widget.
+//   - status: The status information to display.
+func UpdateStatusBar(bar *widgets.Paragraph, status StatusInfo) {
+	lastRefresh := "-"
+	if !status.LastRefresh.IsZero() {
+		lastRefresh = status.LastRefresh.Format("15:04:05")
+	}
+
+	files := make([]string, 0, len(status.Files))
+	for name, state := range status.Files {
+		files = append(files, fmt.Sprintf("%s: %s", name, state))
+	}
+	sort.Strings(files)
+	filesText := "-"
+	if len(files) > 0 {
+		filesText = strings.Join(files, ", ")
+	}
+
+	filtersText := i18n.T("monitor.filter.none")
+	if len(status.Filters) > 0 {
+		filtersText = strings.Join(status.Filters, ", ")
+	}
+
+	stateText := i18n.T("monitor.state.running")
+	bar.TextStyle = ui.NewStyle(ui.ColorCyan)
+	if status.Paused {
+		stateText = i18n.T("monitor.state.paused")
+		bar.TextStyle = ui.NewStyle(ui.ColorYellow, ui.ColorClear, ui.ModifierBold)
+	}
+
+	if status.Message != "" {
+		stateText += " | " + status.Message
+	}
+
+	bar.Text = i18n.T("monitor.statusbar",
+		stateText, filesText, filtersText, status.RefreshInterval, lastRefresh)
+}
@@ -0,0 +1,66 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRefreshRateBounds vérifie les bornes de la fréquence de rafraîchissement.
+func TestRefreshRateBounds(t *testing.T) {
+	r := NewRefreshRate(500 * time.Millisecond)
+
+	assert.Equal(t, 250*time.Millisecond, r.Faster())
+	assert.Equal(t, 125*time.Millisecond, r.Faster())
+	assert.Equal(t, MinUIUpdateInterval, r.Faster())
+	assert.Equal(t, MinUIUpdateInterval, r.Faster())
+
+	for i := 0; i < 10; i++ {
+		r.Slower()
+	}
+	assert.Equal(t, MaxUIUpdateInterval, r.Interval())
+
+	assert.Equal(t, MaxUIUpdateInterval, NewRefreshRate(time.Hour).Interval())
+}
+
+// TestUpdateStatusBar vérifie le contenu de la barre d'état.
+func TestUpdateStatusBar(t *testing.T) {
+	bar := CreateStatusBar()
+	UpdateStatusBar(bar, StatusInfo{
+		RefreshInterval: 250 * time.Millisecond,
+		LastRefresh:     time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC),
+	})
+
+	assert.Contains(t, bar.Text, "250ms")
+	assert.Contains(t, bar.Text, "12:30:00")
+}
+
+// TestUpdateStatusBarState vérifie l'affichage de la pause, des filtres et de l'état des fichiers.
+func TestUpdateStatusBarState(t *testing.T) {
+	bar := CreateStatusBar()
+	UpdateStatusBar(bar, StatusInfo{
+		RefreshInterval: time.Second,
+		Paused:          true,
+		Filters:         []string{"erreurs uniquement"},
+		Files:           map[string]FileState{"tracker.log": FileWatching, "tracker.events": FileMissing},
+	})
+
+	assert.Contains(t, bar.Text, "EN PAUSE")
+	assert.Contains(t, bar.Text, "Filtres: erreurs uniquement")
+	assert.Contains(t, bar.Text, "tracker.events: absent, tracker.log: OK")
+	assert.Contains(t, bar.Text, "Dernière màj: -")
+}
+
+// TestFileStates vérifie l'enregistrement de l'état des fichiers surveillés.
+func TestFileStates(t *testing.T) {
+	setFileState("test.log", FileWaiting)
+	setFileState("test.log", FileWatching)
+
+	states := FileStates()
+	assert.Equal(t, FileWatching, states["test.log"])
+
+	// La copie retournée ne doit pas modifier le registre
+	states["test.log"] = FileMissing
+	assert.Equal(t, FileWatching, FileStates()["test.log"])
+}
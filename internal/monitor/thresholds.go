@@ -0,0 +1,48 @@
+package monitor
+
+import (
+	"sync/atomic"
+
+	"github.com/agbruneau/PubSub/internal/config"
+)
+
+// thresholds holds the configured status thresholds and display limits
+// (nil until SetThresholds is called).
+var thresholds atomic.Pointer[config.ThresholdsConfig]
+
+// SetThresholds replaces the health status thresholds and the row truncation
+// limits used by the dashboard and the headless summaries. It may be called
+// while the UI is running (e.g., on a remote configuration update).
+//
+// Parameters:
+//   - t: The thresholds (see config.DefaultThresholdsConfig).
+func SetThresholds(t config.ThresholdsConfig) {
+	thresholds.Store(&t)
+}
+
+// currentThresholds returns the thresholds in use.
+//
+// Returns:
+//   - config.ThresholdsConfig: The configured thresholds, else the defaults.
+func currentThresholds() config.ThresholdsConfig {
+	if t := thresholds.Load(); t != nil {
+		return *t
+	}
+	return config.DefaultThresholdsConfig()
+}
+
+// truncateRow shortens a displayed row to a maximum length.
+//
+// Parameters:
+//   - row: The row.
+//   - max: The maximum length in bytes.
+//   - suffix: The suffix marking a truncated row.
+//
+// Returns:
+//   - string: The row, truncated with the suffix if longer than max.
+func truncateRow(row string, max int, suffix string) string {
+	if len(row) <= max || max <= len(suffix) {
+		return row
+	}
+	return row[:max-len(suffix)] + suffix
+}
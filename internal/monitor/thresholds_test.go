@@ -0,0 +1,59 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSetThresholds vérifie que les seuils configurés remplacent les valeurs par défaut.
+func TestSetThresholds(t *testing.T) {
+	t.Cleanup(func() { SetThresholds(config.DefaultThresholdsConfig()) })
+
+	th := config.DefaultThresholdsConfig()
+	th.SuccessRateExcellent = 99
+	th.ThroughputNormal = 2
+	th.ErrorCritical = 10 * time.Millisecond
+	th.ErrorWarning = 20 * time.Millisecond
+	th.QualityExcellent = 95
+	SetThresholds(th)
+
+	status, _, _ := GetHealthStatus(98)
+	assert.Equal(t, HealthWarning, status, "98% sous le seuil excellent de 99%")
+	status, _, _ = GetThroughputStatus(1)
+	assert.Equal(t, HealthWarning, status, "1 msg/s sous le seuil normal de 2 msg/s")
+	status, _, _ = GetErrorStatusAt(1, time.Unix(0, 0), time.Unix(0, 0).Add(15*time.Millisecond))
+	assert.Equal(t, HealthWarning, status, "erreur entre les seuils critique et avertissement")
+	text, _ := getQualityText(92)
+	assert.Contains(t, text, "92")
+	assert.NotEqual(t, getQualityTextDefault(t, 92), text, "92 n'est plus excellent")
+}
+
+// getQualityTextDefault retourne le texte de qualité avec les seuils par défaut.
+func getQualityTextDefault(t *testing.T, score float64) string {
+	t.Helper()
+	current := currentThresholds()
+	SetThresholds(config.DefaultThresholdsConfig())
+	defer SetThresholds(current)
+	text, _ := getQualityText(score)
+	return text
+}
+
+// TestRowTruncation vérifie la longueur et le suffixe de troncature configurables.
+func TestRowTruncation(t *testing.T) {
+	t.Cleanup(func() { SetThresholds(config.DefaultThresholdsConfig()) })
+
+	th := config.DefaultThresholdsConfig()
+	th.MaxLogRowLength = 30
+	th.TruncateSuffix = "~"
+	SetThresholds(th)
+
+	row := formatLogRow(models.LogEntry{Level: models.LogLevelINFO, Message: strings.Repeat("x", 100)})
+	assert.Len(t, row, 30)
+	assert.True(t, strings.HasSuffix(row, "~"))
+	assert.Equal(t, "court", truncateRow("court", 30, "~"))
+}
@@ -0,0 +1,118 @@
+package monitor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+)
+
+// Time sources for rate and error-age calculations.
+const (
+	// TimeSourceEvent uses the timestamps written by the tracker, so that
+	// rates are not affected by clock differences or by reading old files.
+	TimeSourceEvent = "event"
+	// TimeSourceLocal uses the local clock at processing time.
+	TimeSourceLocal = "local"
+)
+
+// eventTimeline tracks the tracker timeline from entry timestamps.
+type eventTimeline struct {
+	source     string        // TimeSourceEvent or TimeSourceLocal.
+	maxSkew    time.Duration // Tolerated advance of timestamps over the local clock.
+	first      time.Time     // Earliest event time seen.
+	latest     time.Time     // Latest event time seen.
+	observedAt time.Time     // Local time at which latest was seen.
+}
+
+// SetTimeConfig configures the timeline of rate and error-age calculations.
+//
+// Parameters:
+//   - source: TimeSourceEvent or TimeSourceLocal.
+//   - maxSkew: The tolerated advance of tracker timestamps over the local clock.
+//
+// Returns:
+//   - error: An error if the source is unknown.
+func (m *Monitor) SetTimeConfig(source string, maxSkew time.Duration) error {
+	if source != TimeSourceEvent && source != TimeSourceLocal {
+		return fmt.Errorf("unknown time source %q (expected %s or %s)", source, TimeSourceEvent, TimeSourceLocal)
+	}
+	m.Metrics.mu.Lock()
+	defer m.Metrics.mu.Unlock()
+	m.Metrics.timeline.source = source
+	m.Metrics.timeline.maxSkew = maxSkew
+	return nil
+}
+
+// eventTime places an entry on the event timeline and advances it.
+// Entries without timestamp are placed at the estimated current event time.
+// Timestamps ahead of the local clock by more than the tolerance are counted
+// as skewed and the offset is recorded; older timestamps are expected when
+// reading files written before the monitor started. The caller must hold the lock.
+//
+// Parameters:
+//   - timestamp: The timestamp written by the tracker.
+//   - local: The local processing time.
+//
+// Returns:
+//   - time.Time: The time of the entry on the timeline.
+func (m *Metrics) eventTime(timestamp models.Timestamp, local time.Time) time.Time {
+	tl := &m.timeline
+	if tl.source == TimeSourceLocal {
+		return local
+	}
+
+	if timestamp.IsZero() {
+		return m.eventNow(local)
+	}
+	ts := timestamp.Time
+
+	if advance := ts.Sub(local); advance > tl.maxSkew {
+		m.SkewedEntries++
+		m.ClockOffset = advance
+	} else {
+		m.ClockOffset = 0
+	}
+
+	if tl.first.IsZero() || ts.Before(tl.first) {
+		tl.first = ts
+	}
+	if tl.latest.IsZero() || !ts.Before(tl.latest) {
+		tl.latest = ts
+		tl.observedAt = local
+	}
+	return ts
+}
+
+// eventNow estimates the current time on the event timeline: the latest event
+// time plus the local time elapsed since it was seen. The caller must hold the lock.
+//
+// Parameters:
+//   - local: The current local time.
+//
+// Returns:
+//   - time.Time: The estimated event time.
+func (m *Metrics) eventNow(local time.Time) time.Time {
+	tl := &m.timeline
+	if tl.source == TimeSourceLocal || tl.latest.IsZero() {
+		return local
+	}
+	return tl.latest.Add(local.Sub(tl.observedAt))
+}
+
+// rateWindow returns the duration over which the message rate is computed:
+// the span of event timestamps, or the time since StartTime with the local source.
+// The caller must hold the lock.
+//
+// Parameters:
+//   - local: The current local time.
+//
+// Returns:
+//   - time.Duration: The rate window.
+func (m *Metrics) rateWindow(local time.Time) time.Duration {
+	tl := &m.timeline
+	if tl.source == TimeSourceLocal || tl.latest.IsZero() {
+		return local.Sub(m.StartTime)
+	}
+	return tl.latest.Sub(tl.first)
+}
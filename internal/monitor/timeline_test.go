@@ -0,0 +1,82 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEventTimelineRate vérifie que le débit suit les horodatages du tracker,
+// même lorsque les entrées sont lues d'un coup (fichier ancien).
+func TestEventTimelineRate(t *testing.T) {
+	m := New()
+	local := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	m.SetClock(func() time.Time { return local })
+
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		m.ProcessEvent(models.EventEntry{
+			Timestamp:    models.NewTimestamp(base.Add(time.Duration(i) * 2 * time.Second)),
+			Deserialized: true,
+		})
+	}
+
+	// 5 messages sur 8 secondes de chronologie du tracker
+	assert.InDelta(t, 5.0/8.0, m.Metrics.CurrentMessagesPerSec, 0.001)
+	assert.Zero(t, m.Metrics.SkewedEntries)
+}
+
+// TestEventTimelineErrorAge vérifie que l'ancienneté des erreurs ne dépend pas du décalage d'horloge.
+func TestEventTimelineErrorAge(t *testing.T) {
+	m := New()
+	local := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	m.SetClock(func() time.Time { return local })
+
+	// Horloge du tracker en avance de 10 minutes
+	tracker := local.Add(10 * time.Minute)
+	m.ProcessEvent(models.EventEntry{Timestamp: models.NewTimestamp(tracker), Deserialized: false})
+
+	assert.Equal(t, int64(1), m.Metrics.SkewedEntries)
+	assert.Equal(t, 10*time.Minute, m.Metrics.ClockOffset)
+	assert.Equal(t, tracker, m.Metrics.LastErrorTime)
+
+	// 2 minutes plus tard (horloge locale), l'erreur est récente et non « future »
+	status, _, _ := GetErrorStatusAt(m.Metrics.ErrorCount, m.Metrics.LastErrorTime, m.Metrics.eventNow(local.Add(2*time.Minute)))
+	assert.Equal(t, HealthWarning, status)
+
+	snapshot := m.Snapshot()
+	assert.InDelta(t, 600, snapshot.ClockOffsetSeconds, 0.001)
+	assert.Contains(t, FormatSummary(snapshot), "Décalage d'horloge: 1 entrée(s)")
+}
+
+// TestLocalTimeSource vérifie le mode historique basé sur l'horloge locale.
+func TestLocalTimeSource(t *testing.T) {
+	m := New()
+	require.NoError(t, m.SetTimeConfig(TimeSourceLocal, time.Second))
+	local := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	m.Metrics.StartTime = local.Add(-4 * time.Second)
+	m.SetClock(func() time.Time { return local })
+
+	m.ProcessEvent(models.EventEntry{Timestamp: mustTimestamp("2020-01-01T00:00:00Z"), Deserialized: false})
+
+	assert.InDelta(t, 0.25, m.Metrics.CurrentMessagesPerSec, 0.001)
+	assert.Equal(t, local, m.Metrics.LastErrorTime)
+
+	assert.Error(t, m.SetTimeConfig("gps", time.Second))
+}
+
+// TestEventTimelineMissingTimestamp vérifie le repli sur l'horloge estimée pour une entrée sans horodatage.
+func TestEventTimelineMissingTimestamp(t *testing.T) {
+	m := New()
+	local := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	m.SetClock(func() time.Time { return local })
+
+	m.ProcessLog(models.LogEntry{Timestamp: mustTimestamp("2024-01-01T09:00:00Z"), Level: models.LogLevelINFO})
+	local = local.Add(5 * time.Second)
+	m.ProcessLog(models.LogEntry{Level: models.LogLevelERROR})
+
+	assert.Equal(t, time.Date(2024, 1, 1, 9, 0, 5, 0, time.UTC), m.Metrics.LastErrorTime)
+}
@@ -1,38 +1,33 @@
 package producer
 
 import (
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/transport"
 	"github.com/stretchr/testify/mock"
 )
 
-// MockKafkaProducer est un mock pour l'interface KafkaProducer.
-type MockKafkaProducer struct {
+// MockPublisher est un mock pour l'interface transport.Publisher.
+type MockPublisher struct {
 	mock.Mock
 }
 
-func (m *MockKafkaProducer) Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error {
-	args := m.Called(msg, deliveryChan)
-	// Simuler l'envoi d'un événement de livraison si deliveryChan n'est pas nil
-	if deliveryChan != nil {
+func (m *MockPublisher) Publish(msg *transport.Message, reports chan<- transport.Delivery) error {
+	args := m.Called(msg, reports)
+	// Simuler l'envoi d'un rapport de livraison si reports n'est pas nil
+	if reports != nil {
 		go func() {
-			deliveryChan <- &kafka.Message{
-				TopicPartition: kafka.TopicPartition{
-					Topic:     msg.TopicPartition.Topic,
-					Partition: 0,
-					Offset:    0,
-					Error:     nil,
-				},
-			}
+			reports <- transport.Delivery{Message: &transport.Message{Topic: msg.Topic}}
 		}()
 	}
 	return args.Error(0)
 }
 
-func (m *MockKafkaProducer) Flush(timeoutMs int) int {
-	args := m.Called(timeoutMs)
+func (m *MockPublisher) Flush(timeout time.Duration) int {
+	args := m.Called(timeout)
 	return args.Int(0)
 }
 
-func (m *MockKafkaProducer) Close() {
+func (m *MockPublisher) Close() {
 	m.Called()
 }
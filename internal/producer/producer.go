@@ -1,35 +1,50 @@
 /*
-Package producer provides the Kafka message producer for the PubSub system.
+Package producer provides the order producer of the PubSub system.
 
-This package implements the logic for generating orders and publishing to Kafka,
-following the Event-Carried State Transfer (ECST) pattern.
+This package implements the logic for generating orders and publishing them
+through a transport.Publisher (Kafka by default), following the Event-Carried
+State Transfer (ECST) pattern.
 */
 package producer
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"time"
 
+	"github.com/agbruneau/PubSub/internal/chaos"
 	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/drivers"
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/agbruneau/PubSub/pkg/fake"
 	"github.com/agbruneau/PubSub/pkg/models"
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
-	"github.com/google/uuid"
+	"github.com/agbruneau/PubSub/pkg/transport"
 )
 
 // Config contains the producer service configuration.
 // It can be loaded from environment variables.
 type Config struct {
-	KafkaBroker     string        // Kafka broker address.
-	Topic           string        // Kafka topic for publication.
-	MessageInterval time.Duration // Interval between messages.
-	FlushTimeout    int           // Timeout in ms for final flush.
-	TaxRate         float64       // Tax rate to apply.
-	ShippingFee     float64       // Shipping fee.
-	Currency        string        // Default currency.
-	PaymentMethod   string        // Default payment method.
-	Warehouse       string        // Default warehouse.
+	KafkaBroker     string              // Comma-separated bootstrap broker addresses.
+	Topic           string              // Kafka topic for publication.
+	MessageInterval time.Duration       // Interval between messages.
+	FlushTimeout    int                 // Timeout in ms for final flush.
+	TaxRate         float64             // Tax rate to apply.
+	ShippingFee     float64             // Shipping fee.
+	Currency        string              // Default currency.
+	PaymentMethod   string              // Default payment method.
+	Warehouse       string              // Default warehouse.
+	Locale          string              // Locale of the generated customers and items ("fr" or "en").
+	Seed            int64               // Seed of the order generator (0: not reproducible).
+	PartitionKey    string              // Order field keying the messages (see models.ParsePartitionStrategy).
+	StampSendTime   bool                // Add the models.HeaderSentAt header measured by the latency probe.
+	ChaosFile       string              // State file of the faults injected by pubsub chaos ("": disabled).
+	StatsFile       string              // File of the live statistics shown by pubsub web ("": disabled).
+	Properties      map[string]string   // librdkafka properties (kafka.client tuning and security).
+	Breaker         retry.BreakerConfig // Publish circuit breaker (zero FailureThreshold: disabled).
+	Retry           retry.Config        // Retries of a rejected publish (zero MaxAttempts: a single attempt).
 }
 
 // NewConfig creates a configuration with default values,
@@ -42,17 +57,21 @@ func NewConfig() *Config {
 		KafkaBroker:     config.DefaultKafkaBroker,
 		Topic:           config.DefaultTopic,
 		MessageInterval: config.ProducerMessageInterval,
-		FlushTimeout:    config.FlushTimeoutMs,
+		FlushTimeout:    int(config.ProducerFlushTimeout / time.Millisecond),
 		TaxRate:         config.ProducerDefaultTaxRate,
 		ShippingFee:     config.ProducerDefaultShippingFee,
 		Currency:        config.ProducerDefaultCurrency,
 		PaymentMethod:   config.ProducerDefaultPayment,
 		Warehouse:       config.ProducerDefaultWarehouse,
+		Locale:          fake.DefaultLocale,
+		PartitionKey:    config.ProducerPartitionKey,
+		Properties:      config.DefaultConfig().Kafka.Client.ProducerProperties(),
+		Breaker:         retry.DefaultBreakerConfig(),
 	}
 
 	// Override from environment variables
 	if broker := os.Getenv("KAFKA_BROKER"); broker != "" {
-		cfg.KafkaBroker = broker
+		cfg.KafkaBroker = config.ParseBrokerList(broker).String()
 	}
 	if topic := os.Getenv("KAFKA_TOPIC"); topic != "" {
 		cfg.Topic = topic
@@ -61,38 +80,51 @@ func NewConfig() *Config {
 	return cfg
 }
 
-// OrderTemplate defines a template for generating test orders.
-type OrderTemplate struct {
-	User     string  // Customer identifier.
-	Item     string  // Item name.
-	Quantity int     // Ordered quantity.
-	Price    float64 // Unit price.
-}
-
-// DefaultOrderTemplates contains default order templates.
-var DefaultOrderTemplates = []OrderTemplate{
-	{User: "client01", Item: "espresso", Quantity: 2, Price: 2.50},
-	{User: "client02", Item: "cappuccino", Quantity: 3, Price: 3.20},
-	{User: "client03", Item: "latte", Quantity: 4, Price: 3.50},
-	{User: "client04", Item: "macchiato", Quantity: 5, Price: 3.00},
-	{User: "client05", Item: "flat white", Quantity: 6, Price: 3.30},
-	{User: "client06", Item: "mocha", Quantity: 7, Price: 4.00},
-	{User: "client07", Item: "americano", Quantity: 8, Price: 2.80},
-	{User: "client08", Item: "chai latte", Quantity: 9, Price: 3.80},
-	{User: "client09", Item: "matcha", Quantity: 10, Price: 4.50},
-	{User: "client10", Item: "strawberry smoothie", Quantity: 11, Price: 5.50},
+// ConfigFrom creates the producer configuration from the shared application
+// configuration (flags, environment, YAML and defaults already resolved).
+//
+// Parameters:
+//   - cfg: The application configuration.
+//
+// Returns:
+//   - *Config: The producer configuration.
+func ConfigFrom(cfg *config.AppConfig) *Config {
+	return &Config{
+		KafkaBroker:     cfg.Kafka.Brokers.String(),
+		Topic:           cfg.Kafka.Topic,
+		MessageInterval: cfg.GetProducerInterval(),
+		FlushTimeout:    int(cfg.GetFlushTimeout() / time.Millisecond),
+		TaxRate:         config.ProducerDefaultTaxRate,
+		ShippingFee:     config.ProducerDefaultShippingFee,
+		Currency:        config.ProducerDefaultCurrency,
+		PaymentMethod:   config.ProducerDefaultPayment,
+		Warehouse:       config.ProducerDefaultWarehouse,
+		Locale:          string(i18n.Detect(cfg.App.Locale)),
+		PartitionKey:    cfg.Producer.PartitionKey,
+		StampSendTime:   cfg.Producer.StampSendTime,
+		ChaosFile:       cfg.GetChaosFile(),
+		StatsFile:       cfg.Producer.StatsFile,
+		Properties:      cfg.ProducerProperties(),
+		Breaker:         retry.BreakerConfigFrom(cfg.Retry.CircuitBreaker),
+		Retry:           retry.ConfigFrom(cfg.Retry),
+	}
 }
 
-// OrderProducer is the main service handling Kafka message production.
-// It encapsulates the Kafka producer, configuration, and order templates.
+// OrderProducer is the main service handling message production.
+// It encapsulates the publisher, configuration, and order generator.
 type OrderProducer struct {
-	config       *Config         // Producer configuration.
-	producer     KafkaProducer   // Interface for testability.
-	rawProducer  *kafka.Producer // Keep a reference for delivery reports.
-	deliveryChan chan kafka.Event
-	templates    []OrderTemplate // Order templates to use.
-	sequence     int             // Internal sequencer for IDs.
-	running      bool            // Running state.
+	config       *Config             // Producer configuration.
+	producer     transport.Publisher // Publisher of the broker driver (mocked in tests).
+	deliveryChan chan transport.Delivery
+	breaker      *retry.CircuitBreaker // Suspends publishing after repeated publish errors (nil: disabled).
+	generator    *fake.Generator       // Generator of the published orders.
+	clock        *models.SendClock     // Send time of the messages (nil: not stamped).
+	chaos        *chaos.Injector       // Payload corruption of pubsub chaos (nil: disabled).
+	sequence     int                   // Internal sequencer for IDs.
+	started      time.Time             // Creation time, reported in the statistics.
+	counters     counters              // Published, delivered and failed orders.
+	statsStop    chan struct{}         // Stops the statistics writer (nil: no statistics file).
+	statsDone    chan struct{}         // Closed once the last statistics are written.
 }
 
 // New creates a new instance of the OrderProducer service.
@@ -103,175 +135,269 @@ type OrderProducer struct {
 // Returns:
 //   - *OrderProducer: The created instance.
 func New(cfg *Config) *OrderProducer {
-	return &OrderProducer{
+	p := &OrderProducer{
 		config:    cfg,
-		templates: DefaultOrderTemplates,
+		breaker:   retry.NewCircuitBreaker("producer", cfg.Breaker, logBreakerChange),
+		generator: fake.New(GeneratorConfig(cfg)),
 		sequence:  1,
+		started:   time.Now().UTC(),
+		counters:  newCounters(cfg.Topic),
+	}
+	if cfg.StampSendTime {
+		p.clock = models.NewSendClock()
+	}
+	if cfg.ChaosFile != "" {
+		p.chaos = chaos.NewInjector(cfg.ChaosFile)
 	}
+	return p
 }
 
-// Initialize initializes the Kafka producer.
-// Creates the connection to the broker and starts the report handler.
+// Initialize creates the publisher of the configured broker: Kafka, RabbitMQ
+// for an AMQP URL, or the in-process broker shared by the process
+// (config.MemoryBroker), and starts the report handler and, with a
+// statistics file, its writer.
 //
 // Returns:
 //   - error: An error if connection fails.
 func (p *OrderProducer) Initialize() error {
 	var err error
-	p.rawProducer, err = kafka.NewProducer(&kafka.ConfigMap{
-		"bootstrap.servers": p.config.KafkaBroker,
-	})
+	p.producer, err = drivers.NewPublisher(p.config.KafkaBroker, p.config.Properties)
 	if err != nil {
-		return fmt.Errorf("failed to create Kafka producer: %w", err)
+		return err
 	}
-	p.producer = newKafkaProducerWrapper(p.rawProducer)
-
-	p.deliveryChan = make(chan kafka.Event, config.ProducerDeliveryChannelSize)
+	p.deliveryChan = make(chan transport.Delivery, config.ProducerDeliveryChannelSize)
 	go p.handleDeliveryReports()
+	if p.config.StatsFile != "" {
+		p.statsStop, p.statsDone = make(chan struct{}), make(chan struct{})
+		go p.reportStats(p.statsStop, p.statsDone)
+	}
 
 	return nil
 }
 
+// logBreakerChange prints a publish circuit breaker transition as a logfmt line.
+//
+// Parameters:
+//   - change: The transition.
+func logBreakerChange(change retry.StateChange) {
+	fmt.Println(change)
+}
+
 // handleDeliveryReports processes delivery reports in a dedicated goroutine.
-// Logs success or failure for each produced message.
+// Logs success or failure for each produced message, and records it in the
+// circuit breaker.
 func (p *OrderProducer) handleDeliveryReports() {
-	for e := range p.deliveryChan {
-		m := e.(*kafka.Message)
-		if m.TopicPartition.Error != nil {
-			fmt.Printf("❌ Message delivery failed: %v\n", m.TopicPartition.Error)
+	for d := range p.deliveryChan {
+		if d.Err != nil {
+			p.counters.failed.Inc()
+			p.breaker.Failure()
+			fmt.Printf("❌ Message delivery failed: %v\n", d.Err)
 		} else {
+			p.counters.delivered.Inc()
+			p.breaker.Success()
 			fmt.Printf("✅ Message delivered to topic %s (partition %d) at offset %d\n",
-				*m.TopicPartition.Topic,
-				m.TopicPartition.Partition,
-				m.TopicPartition.Offset)
+				d.Message.Topic,
+				d.Message.Partition,
+				d.Message.Offset)
 		}
 	}
 }
 
-// GenerateOrder creates an enriched order from a template and a sequence number.
+// GeneratorConfig returns the order generator configuration of the producer,
+// shared by the load test (pubsub loadtest).
+//
+// Parameters:
+//   - cfg: The producer configuration.
+//
+// Returns:
+//   - fake.Config: The default generation, with the producer locale, seed and pricing.
+func GeneratorConfig(cfg *Config) fake.Config {
+	gen := fake.DefaultConfig()
+	gen.Seed = cfg.Seed
+	gen.Locale = cfg.Locale
+	gen.TaxRate = cfg.TaxRate
+	gen.ShippingFee = models.NewMoney(cfg.ShippingFee, cfg.Currency)
+	gen.Currency = cfg.Currency
+	gen.PaymentMethod = cfg.PaymentMethod
+	gen.Warehouse = cfg.Warehouse
+	return gen
+}
+
+// GenerateOrder creates an enriched order with the order generator.
 //
 // Parameters:
-//   - template: The order template to use.
 //   - sequence: The unique sequence number.
 //
 // Returns:
 //   - models.Order: The complete generated order.
-func (p *OrderProducer) GenerateOrder(template OrderTemplate, sequence int) models.Order {
-	// Financial calculations
-	itemTotal := float64(template.Quantity) * template.Price
-	tax := itemTotal * p.config.TaxRate
-	total := itemTotal + tax + p.config.ShippingFee
+//   - error: An error if the generated order is invalid.
+func (p *OrderProducer) GenerateOrder(sequence int) (models.Order, error) {
+	return p.generator.Order(sequence)
+}
 
-	const initialStock = 100
-	availableQty := initialStock - template.Quantity
-	inStock := availableQty >= 0
+// messageKey returns the Kafka message key of an order, according to the
+// configured partition strategy.
+//
+// Parameters:
+//   - order: The order.
+//
+// Returns:
+//   - []byte: The message key.
+//   - error: An error if the partition strategy is unknown.
+func (p *OrderProducer) messageKey(order models.Order) ([]byte, error) {
+	strategy, err := models.ParsePartitionStrategy(p.config.PartitionKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid partition key: %w", err)
+	}
+	key, err := order.PartitionKey(strategy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid partition key: %w", err)
+	}
+	return []byte(key), nil
+}
 
-	return models.Order{
-		OrderID:  uuid.New().String(),
-		Sequence: sequence,
-		Status:   "pending",
-		Items: []models.OrderItem{
-			{
-				ItemID:     fmt.Sprintf("item-%s", template.Item),
-				ItemName:   template.Item,
-				Quantity:   template.Quantity,
-				UnitPrice:  template.Price,
-				TotalPrice: itemTotal,
-			},
-		},
-		SubTotal:      itemTotal,
-		Tax:           tax,
-		ShippingFee:   p.config.ShippingFee,
-		Total:         total,
-		Currency:      p.config.Currency,
-		PaymentMethod: p.config.PaymentMethod,
-		DeliveryNotes: fmt.Sprintf("Deliver to %d Rue de la Paix, 75000 Paris", sequence),
-		Metadata: models.OrderMetadata{
-			Timestamp:     time.Now().UTC().Format(time.RFC3339),
-			Version:       "1.1",
-			EventType:     "order.created",
-			Source:        "producer-service",
-			CorrelationID: uuid.New().String(),
-		},
-		CustomerInfo: models.CustomerInfo{
-			CustomerID:   template.User,
-			Name:         fmt.Sprintf("Client %s", template.User),
-			Email:        fmt.Sprintf("%s@example.com", template.User),
-			Phone:        "+33 6 00 00 00 00",
-			Address:      fmt.Sprintf("%d Rue de la Paix, 75000 Paris", sequence),
-			LoyaltyLevel: "silver",
-		},
-		Inventory: models.InventoryStatus{
-			ItemID:       fmt.Sprintf("item-%s", template.Item),
-			ItemName:     template.Item,
-			AvailableQty: availableQty,
-			ReservedQty:  template.Quantity,
-			UnitPrice:    template.Price,
-			InStock:      inStock,
-			Warehouse:    p.config.Warehouse,
-		},
+// traceHeaders returns the message headers propagating the trace context of a
+// message, read by the tracker.
+//
+// Parameters:
+//   - trace: The trace context.
+//
+// Returns:
+//   - []transport.Header: The traceparent and correlation-id headers.
+func traceHeaders(trace models.TraceContext) []transport.Header {
+	return []transport.Header{
+		{Key: models.HeaderTraceParent, Value: []byte(trace.TraceParent())},
+		{Key: models.HeaderCorrelationID, Value: []byte(trace.CorrelationID)},
 	}
 }
 
-// ProduceOrder generates and sends an order to the Kafka topic.
-// Selects an order template in a round-robin fashion.
+// ProduceOrder generates an order and publishes it with PublishOrder.
 //
 // Returns:
-//   - error: An error if production fails.
+//   - error: An error if production fails, or wrapping retry.ErrCircuitOpen
+//     while publishing is suspended by the circuit breaker.
 func (p *OrderProducer) ProduceOrder() error {
-	template := p.templates[(p.sequence-1)%len(p.templates)]
-	order := p.GenerateOrder(template, p.sequence)
+	order, err := p.GenerateOrder(p.sequence)
+	if err != nil {
+		return fmt.Errorf("invalid order: %w", err)
+	}
+	if err := p.PublishOrder(order); err != nil {
+		return err
+	}
+	p.sequence++
+	return nil
+}
+
+// PublishOrder normalizes (see models.Order.Normalize) and sends an order to
+// the topic. It is used by the production loop and by the HTTP ingestion
+// gateway, and is safe for concurrent use.
+//
+// Parameters:
+//   - order: The order to publish.
+//
+// Returns:
+//   - error: An error if publishing fails, or wrapping retry.ErrCircuitOpen
+//     while publishing is suspended by the circuit breaker.
+func (p *OrderProducer) PublishOrder(order models.Order) error {
+	if err := order.Normalize(); err != nil {
+		return fmt.Errorf("invalid order: %w", err)
+	}
 
 	value, err := json.Marshal(order)
 	if err != nil {
 		return fmt.Errorf("JSON marshaling error: %w", err)
 	}
+	if corrupted, ok := p.chaos.Corrupt(value); ok {
+		value = corrupted
+		fmt.Printf("💥 Chaos: corrupted payload for order %s\n", order.OrderID)
+	}
+	key, err := p.messageKey(order)
+	if err != nil {
+		return err
+	}
 
-	topic := p.config.Topic
-	err = p.producer.Produce(&kafka.Message{
-		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
-		Value:          value,
-	}, p.deliveryChan)
+	if err := p.breaker.Allow(); err != nil {
+		return fmt.Errorf("publishing suspended: %w", err)
+	}
+
+	headers := traceHeaders(models.NewTraceContext(order.Metadata.CorrelationID))
+	if p.clock != nil {
+		// Stamped last, just before handing the message to the publisher
+		headers = append(headers, transport.Header{Key: models.HeaderSentAt, Value: p.clock.Header()})
+	}
+	err = p.publish(&transport.Message{
+		Topic:     p.config.Topic,
+		Partition: transport.AnyPartition,
+		Key:       key,
+		Value:     value,
+		Headers:   headers,
+	})
 
 	if err != nil {
+		p.counters.failed.Inc()
+		p.breaker.Failure()
 		return fmt.Errorf("error producing message: %w", err)
 	}
 
-	p.sequence++
+	p.counters.published.Inc()
 	return nil
 }
 
-// Run starts the message production loop.
-// Continues until a stop signal is received on stopChan.
+// publish hands a message to the publisher, retrying a rejected publish (e.g.
+// a full local queue) as configured by Config.Retry. The retries are recorded
+// in retry.DefaultStats under producer.produce.
+//
+// Parameters:
+//   - msg: The message to publish.
+//
+// Returns:
+//   - error: The error of the last attempt.
+func (p *OrderProducer) publish(msg *transport.Message) error {
+	cfg := p.config.Retry
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	cfg.Observer = retry.DefaultStats.Observer("producer.produce")
+	return retry.Do(context.Background(), cfg, func() error {
+		return p.producer.Publish(msg, p.deliveryChan)
+	}).Err
+}
+
+// Run runs the message production loop until ctx is cancelled.
 //
 // Parameters:
-//   - stopChan: The stop signal channel.
-func (p *OrderProducer) Run(stopChan <-chan os.Signal) {
-	p.running = true
-	for p.running {
+//   - ctx: Stops the production.
+//
+// Returns:
+//   - error: Always nil; the signature matches lifecycle.Manager.Go.
+func (p *OrderProducer) Run(ctx context.Context) error {
+	for {
+		if err := p.ProduceOrder(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
 		select {
-		case <-stopChan:
-			fmt.Println("\n⚠️  Stop signal received. Stopping new message production...")
-			p.running = false
-		default:
-			if err := p.ProduceOrder(); err != nil {
-				fmt.Printf("Error: %v\n", err)
-			}
-			time.Sleep(p.config.MessageInterval)
+		case <-ctx.Done():
+			fmt.Println(i18n.T("producer.stop_signal"))
+			return nil
+		case <-time.After(p.config.MessageInterval):
 		}
 	}
 }
 
 // Close gracefully closes the producer and flushes pending messages.
-// This method blocks until messages are flushed or timeout is reached.
+// This method blocks until messages are flushed or timeout is reached, then
+// writes the final statistics.
 func (p *OrderProducer) Close() {
-	fmt.Println("⏳ Sending remaining messages in queue...")
-	remainingMessages := p.producer.Flush(p.config.FlushTimeout)
+	fmt.Println(i18n.T("producer.flushing"))
+	remainingMessages := p.producer.Flush(time.Duration(p.config.FlushTimeout) * time.Millisecond)
 	if remainingMessages > 0 {
-		fmt.Printf("⚠️  %d messages could not be sent.\n", remainingMessages)
+		fmt.Println(i18n.T("producer.unsent", remainingMessages))
 	} else {
-		fmt.Println("✅ All messages sent successfully.")
+		fmt.Println(i18n.T("producer.all_sent"))
 	}
-	if p.rawProducer != nil {
-		p.rawProducer.Close()
+	p.producer.Close()
+	if p.statsStop != nil {
+		close(p.statsStop)
+		<-p.statsDone
 	}
 }
@@ -1,30 +1,34 @@
 package producer
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/agbruneau/PubSub/internal/chaos"
+	"github.com/agbruneau/PubSub/internal/retry"
 	"github.com/agbruneau/PubSub/pkg/models"
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/agbruneau/PubSub/pkg/transport"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-// TestProduceOrderWithMock vérifie que ProduceOrder appelle correctement le producteur Kafka.
+// TestProduceOrderWithMock vérifie que ProduceOrder appelle correctement le publisher.
 func TestProduceOrderWithMock(t *testing.T) {
 	cfg := NewConfig()
 	producer := New(cfg)
-	mockProducer := new(MockKafkaProducer)
+	mockProducer := new(MockPublisher)
 	producer.producer = mockProducer
 
 	// Configurer le mock
-	mockProducer.On("Produce", mock.MatchedBy(func(msg *kafka.Message) bool {
+	mockProducer.On("Publish", mock.MatchedBy(func(msg *transport.Message) bool {
 		// Vérifier le sujet
-		if *msg.TopicPartition.Topic != cfg.Topic {
+		if msg.Topic != cfg.Topic || msg.Partition != transport.AnyPartition {
 			return false
 		}
 		// Vérifier que le message est un JSON valide
@@ -32,7 +36,16 @@ func TestProduceOrderWithMock(t *testing.T) {
 		if err := json.Unmarshal(msg.Value, &order); err != nil {
 			return false
 		}
-		return true
+		// Vérifier les en-têtes de trace
+		if len(msg.Headers) != 2 || msg.Headers[1].Value == nil ||
+			string(msg.Headers[1].Value) != order.Metadata.CorrelationID {
+			return false
+		}
+		if _, ok := models.ParseTraceParent(string(msg.Headers[0].Value)); !ok {
+			return false
+		}
+		// Vérifier la clé de partitionnement (par client par défaut)
+		return string(msg.Key) == order.CustomerInfo.CustomerID
 	}), mock.Anything).Return(nil)
 
 	// Exécuter la méthode
@@ -44,17 +57,97 @@ func TestProduceOrderWithMock(t *testing.T) {
 	assert.Equal(t, 2, producer.sequence, "La séquence devrait être incrémentée")
 }
 
+// TestProduceOrderNormalizes vérifie que les commandes sont normalisées avant publication.
+func TestProduceOrderNormalizes(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Currency = "jpy"
+	producer := New(cfg)
+	mockProducer := new(MockPublisher)
+	producer.producer = mockProducer
+
+	mockProducer.On("Publish", mock.MatchedBy(func(msg *transport.Message) bool {
+		var order models.Order
+		if err := json.Unmarshal(msg.Value, &order); err != nil {
+			return false
+		}
+		return order.Currency == "JPY" && order.Total.Cents()%100 == 0 && order.Validate() == nil
+	}), mock.Anything).Return(nil)
+
+	assert.NoError(t, producer.ProduceOrder())
+	mockProducer.AssertExpectations(t)
+
+	cfg.Currency = "yen"
+	assert.ErrorIs(t, New(cfg).ProduceOrder(), models.ErrInvalidCurrency)
+}
+
+// TestProduceOrderStampSendTime vérifie l'horodatage d'envoi mesuré par la sonde de latence.
+func TestProduceOrderStampSendTime(t *testing.T) {
+	cfg := NewConfig()
+	cfg.StampSendTime = true
+	producer := New(cfg)
+	mockProducer := new(MockPublisher)
+	producer.producer = mockProducer
+
+	before := time.Now()
+	mockProducer.On("Publish", mock.MatchedBy(func(msg *transport.Message) bool {
+		value, ok := msg.Header(models.HeaderSentAt)
+		if !ok {
+			return false
+		}
+		sentAt, err := models.ParseSentAt(value)
+		return err == nil && !sentAt.Before(before.Add(-time.Second)) && !sentAt.After(time.Now().Add(time.Second))
+	}), mock.Anything).Return(nil)
+
+	assert.NoError(t, producer.ProduceOrder())
+	mockProducer.AssertExpectations(t)
+}
+
+// TestProduceOrderChaosCorrupt vérifie la corruption des messages par une panne payload_corrupt.
+func TestProduceOrderChaosCorrupt(t *testing.T) {
+	cfg := NewConfig()
+	cfg.ChaosFile = filepath.Join(t.TempDir(), "chaos.json")
+	now := time.Now()
+	fault := chaos.Fault{Kind: chaos.FaultPayloadCorrupt, From: now.Add(-time.Second), Until: now.Add(time.Minute)}
+	assert.NoError(t, chaos.WriteState(cfg.ChaosFile, chaos.State{Faults: []chaos.Fault{fault}}))
+	producer := New(cfg)
+	mockProducer := new(MockPublisher)
+	producer.producer = mockProducer
+
+	mockProducer.On("Publish", mock.MatchedBy(func(msg *transport.Message) bool {
+		return len(msg.Value) > 0 && !json.Valid(msg.Value)
+	}), mock.Anything).Return(nil)
+
+	assert.NoError(t, producer.ProduceOrder())
+	mockProducer.AssertExpectations(t)
+	assert.Equal(t, 2, producer.sequence, "Un message corrompu est publié comme les autres")
+}
+
+// TestProduceOrderPartitionKey vérifie le rejet d'une stratégie de partitionnement inconnue.
+func TestProduceOrderPartitionKey(t *testing.T) {
+	cfg := NewConfig()
+	cfg.PartitionKey = "country"
+	producer := New(cfg)
+	mockProducer := new(MockPublisher)
+	producer.producer = mockProducer
+
+	err := producer.ProduceOrder()
+
+	assert.ErrorIs(t, err, models.ErrUnknownPartitionStrategy)
+	mockProducer.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	assert.Equal(t, 1, producer.sequence, "La séquence ne devrait pas être incrémentée")
+}
+
 // TestProduceOrderError vérifie la gestion des erreurs lors de la production.
 func TestProduceOrderError(t *testing.T) {
 	cfg := NewConfig()
 	producer := New(cfg)
-	mockProducer := new(MockKafkaProducer)
+	mockProducer := new(MockPublisher)
 	producer.producer = mockProducer
 
 	expectedErr := assert.AnError
 
 	// Configurer le mock pour retourner une erreur
-	mockProducer.On("Produce", mock.Anything, mock.Anything).Return(expectedErr)
+	mockProducer.On("Publish", mock.Anything, mock.Anything).Return(expectedErr)
 
 	// Exécuter la méthode
 	err := producer.ProduceOrder()
@@ -64,39 +157,56 @@ func TestProduceOrderError(t *testing.T) {
 	assert.Contains(t, err.Error(), expectedErr.Error())
 	mockProducer.AssertExpectations(t)
 	// La séquence ne devrait pas être incrémentée en cas d'erreur de production selon le code actuel ?
-	// Vérifions le code: p.sequence++ est appelé APRÈS p.producer.Produce
-	// Donc si Produce retourne une erreur, sequence++ N'EST PAS appelé.
+	// Vérifions le code: p.sequence++ est appelé APRÈS p.producer.Publish
+	// Donc si Publish retourne une erreur, sequence++ N'EST PAS appelé.
 	assert.Equal(t, 1, producer.sequence, "La séquence ne devrait pas être incrémentée en cas d'erreur")
 }
 
-// TestRun vérifie que Run appelle ProduceOrder en boucle.
-// Note: C'est difficile de tester Run directement car c'est une boucle infinie.
-// On peut tester une itération ou utiliser un timeout.
+// TestPublishOrderRetry vérifie qu'une publication refusée est relancée selon
+// Config.Retry et enregistrée dans retry.DefaultStats.
+func TestPublishOrderRetry(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Retry = retry.Config{MaxAttempts: 2, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, Multiplier: 1}
+	producer := New(cfg)
+	mockProducer := new(MockPublisher)
+	producer.producer = mockProducer
+	mockProducer.On("Publish", mock.Anything, mock.Anything).Return(assert.AnError).Once()
+	mockProducer.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+	before := retry.DefaultStats.Get("producer.produce")
+	assert.NoError(t, producer.ProduceOrder())
+	mockProducer.AssertNumberOfCalls(t, "Publish", 2)
+	assert.Equal(t, int64(2), retry.DefaultStats.Get("producer.produce").Attempts-before.Attempts)
+	assert.Equal(t, 2, producer.sequence)
+}
+
+// TestRun vérifie que Run appelle ProduceOrder en boucle jusqu'à l'annulation du contexte.
 func TestRun(t *testing.T) {
 	cfg := NewConfig()
 	cfg.MessageInterval = 1 * time.Millisecond // Intervalle court pour le test
 	producer := New(cfg)
-	mockProducer := new(MockKafkaProducer)
+	mockProducer := new(MockPublisher)
 	producer.producer = mockProducer
 
-	// On s'attend à ce que Produce soit appelé au moins une fois
-	mockProducer.On("Produce", mock.Anything, mock.Anything).Return(nil)
-
-	stopChan := make(chan os.Signal, 1)
+	// On s'attend à ce que Publish soit appelé au moins une fois
+	mockProducer.On("Publish", mock.Anything, mock.Anything).Return(nil)
 
 	// Démarrer Run dans une goroutine
-	go producer.Run(stopChan)
-
-	// Laisser tourner un peu
-	time.Sleep(10 * time.Millisecond)
-
-	// Arrêter
-	stopChan <- os.Interrupt
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- producer.Run(ctx) }()
 
-	// Attendre que la goroutine finisse (pas de mécanisme de waitgroup dans le code actuel, on assume que ça s'arrête vite)
+	// Laisser tourner un peu, puis arrêter
 	time.Sleep(10 * time.Millisecond)
+	cancel()
 
-	assert.False(t, producer.running)
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Run ne s'est pas arrêté après l'annulation du contexte")
+	}
+	mockProducer.AssertCalled(t, "Publish", mock.Anything, mock.Anything)
 }
 
 func TestHandleDeliveryReports(t *testing.T) {
@@ -109,33 +219,21 @@ func TestHandleDeliveryReports(t *testing.T) {
 	producer := New(cfg)
 
 	// Create channels
-	producer.deliveryChan = make(chan kafka.Event, 10)
+	producer.deliveryChan = make(chan transport.Delivery, 10)
 
 	// Start handler in background
 	go producer.handleDeliveryReports()
 
-	// Send a success message
-	topic := "test-topic"
-	msgSuccess := &kafka.Message{
-		TopicPartition: kafka.TopicPartition{
-			Topic:     &topic,
-			Partition: 0,
-			Offset:    1,
-			Error:     nil,
-		},
+	// Send a success report
+	producer.deliveryChan <- transport.Delivery{
+		Message: &transport.Message{Topic: "test-topic", Partition: 0, Offset: 1},
 	}
-	producer.deliveryChan <- msgSuccess
-
-	// Send a failure message
-	msgFail := &kafka.Message{
-		TopicPartition: kafka.TopicPartition{
-			Topic:     &topic,
-			Partition: 0,
-			Offset:    0,
-			Error:     assert.AnError,
-		},
+
+	// Send a failure report
+	producer.deliveryChan <- transport.Delivery{
+		Message: &transport.Message{Topic: "test-topic"},
+		Err:     assert.AnError,
 	}
-	producer.deliveryChan <- msgFail
 
 	// Allow time for processing
 	time.Sleep(50 * time.Millisecond)
@@ -159,14 +257,63 @@ func TestHandleDeliveryReports(t *testing.T) {
 func TestClose(t *testing.T) {
 	cfg := NewConfig()
 	producer := New(cfg)
-	mockProducer := new(MockKafkaProducer)
+	mockProducer := new(MockPublisher)
 	producer.producer = mockProducer
 
-	// Flush should be called
-	mockProducer.On("Flush", mock.Anything).Return(0)
+	// Flush then Close should be called
+	mockProducer.On("Flush", time.Duration(cfg.FlushTimeout)*time.Millisecond).Return(0)
+	mockProducer.On("Close").Return()
 
-	// Call Close (rawProducer is nil, but we patched Close to handle it)
 	producer.Close()
 
 	mockProducer.AssertExpectations(t)
 }
+
+// TestProduceOrderCircuitOpen vérifie que le disjoncteur suspend la publication après des erreurs.
+func TestProduceOrderCircuitOpen(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Breaker = retry.BreakerConfig{FailureThreshold: 2, ResetTimeout: time.Hour}
+	producer := New(cfg)
+	mockProducer := new(MockPublisher)
+	producer.producer = mockProducer
+
+	mockProducer.On("Publish", mock.Anything, mock.Anything).Return(assert.AnError).Twice()
+
+	assert.Error(t, producer.ProduceOrder())
+	assert.Error(t, producer.ProduceOrder())
+	err := producer.ProduceOrder()
+
+	assert.ErrorIs(t, err, retry.ErrCircuitOpen)
+	assert.Equal(t, 1, producer.sequence)
+	mockProducer.AssertNumberOfCalls(t, "Publish", 2)
+}
+
+// TestStats vérifie les compteurs du producteur et le fichier de statistiques écrit à la fermeture.
+func TestStats(t *testing.T) {
+	cfg := NewConfig()
+	cfg.StatsFile = filepath.Join(t.TempDir(), "logs", "producer-stats.json")
+	producer := New(cfg)
+	mockProducer := new(MockPublisher)
+	producer.producer = mockProducer
+	producer.statsStop, producer.statsDone = make(chan struct{}), make(chan struct{})
+	go producer.reportStats(producer.statsStop, producer.statsDone)
+
+	mockProducer.On("Publish", mock.Anything, mock.Anything).Return(nil).Once()
+	mockProducer.On("Publish", mock.Anything, mock.Anything).Return(assert.AnError).Once()
+	mockProducer.On("Flush", mock.Anything).Return(0)
+	mockProducer.On("Close").Return()
+	assert.NoError(t, producer.ProduceOrder())
+	assert.Error(t, producer.ProduceOrder())
+
+	producer.Close()
+
+	stats, err := ReadStats(cfg.StatsFile)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), stats.Published)
+	assert.Equal(t, int64(1), stats.Failed)
+	assert.Equal(t, cfg.Topic, stats.Topic)
+	assert.Equal(t, "closed", stats.Breaker)
+
+	_, err = ReadStats(filepath.Join(t.TempDir(), "absent.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -1,7 +1,13 @@
 package producer
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/pkg/fake"
+	"github.com/agbruneau/PubSub/pkg/models"
 )
 
 // TestGenerateOrder vérifie que GenerateOrder crée une commande valide.
@@ -9,14 +15,17 @@ func TestGenerateOrder(t *testing.T) {
 	cfg := NewConfig()
 	producer := New(cfg)
 
-	template := OrderTemplate{
-		User:     "test-user",
-		Item:     "test-item",
-		Quantity: 3,
-		Price:    10.00,
-	}
+	gen := GeneratorConfig(cfg)
+	gen.Seed = 1
+	gen.Customers = 1
+	gen.Items = []fake.Item{{ID: "item-test-item", Name: "test-item", Price: models.NewMoney(10.00, "")}}
+	gen.Quantity = fake.Constant(3)
+	producer.generator = fake.New(gen)
 
-	order := producer.GenerateOrder(template, 1)
+	order, err := producer.GenerateOrder(1)
+	if err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
 
 	// Vérifier les champs de base
 	if order.OrderID == "" {
@@ -41,24 +50,24 @@ func TestGenerateOrder(t *testing.T) {
 	}
 
 	// Vérifier les calculs financiers
-	expectedSubTotal := 30.00 // 3 * 10.00
+	expectedSubTotal := models.FromCents(3000, cfg.Currency) // 3 * 10.00
 	if order.SubTotal != expectedSubTotal {
-		t.Errorf("Attendu que SubTotal soit %.2f, reçu %.2f", expectedSubTotal, order.SubTotal)
+		t.Errorf("Attendu que SubTotal soit %s, reçu %s", expectedSubTotal, order.SubTotal)
 	}
 
-	expectedTax := expectedSubTotal * cfg.TaxRate
+	expectedTax := expectedSubTotal.MulRate(cfg.TaxRate)
 	if order.Tax != expectedTax {
-		t.Errorf("Attendu que Tax soit %.2f, reçu %.2f", expectedTax, order.Tax)
+		t.Errorf("Attendu que Tax soit %s, reçu %s", expectedTax, order.Tax)
 	}
 
-	expectedTotal := expectedSubTotal + expectedTax + cfg.ShippingFee
-	if order.Total != expectedTotal {
-		t.Errorf("Attendu que Total soit %.2f, reçu %.2f", expectedTotal, order.Total)
+	expectedTotal, err := models.Sum(expectedSubTotal, expectedTax, models.NewMoney(cfg.ShippingFee, cfg.Currency))
+	if err != nil || order.Total != expectedTotal {
+		t.Errorf("Attendu que Total soit %s, reçu %s", expectedTotal, order.Total)
 	}
 
 	// Vérifier les infos client
-	if order.CustomerInfo.CustomerID != "test-user" {
-		t.Errorf("Attendu que CustomerID soit 'test-user', reçu %s", order.CustomerInfo.CustomerID)
+	if order.CustomerInfo.CustomerID != "client01" {
+		t.Errorf("Attendu que CustomerID soit 'client01', reçu %s", order.CustomerInfo.CustomerID)
 	}
 
 	// Vérifier les métadonnées
@@ -68,6 +77,32 @@ func TestGenerateOrder(t *testing.T) {
 	if order.Metadata.Source != "producer-service" {
 		t.Errorf("Attendu que Source soit 'producer-service', reçu %s", order.Metadata.Source)
 	}
+
+	// Vérifier la conformité au schéma JSON des commandes
+	data, err := json.Marshal(order)
+	if err != nil {
+		t.Fatalf("Erreur de sérialisation inattendue: %v", err)
+	}
+	if err := models.ValidateJSON(data); err != nil {
+		t.Errorf("Attendu une commande conforme au schéma, reçu %v", err)
+	}
+
+	// Vérifier que la commande a la forme de celle du corpus partagé
+	fixture, err := models.Fixture("order_v1.1")
+	if err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+	var generated, canonical map[string]interface{}
+	_ = json.Unmarshal(data, &generated)
+	_ = json.Unmarshal(fixture, &canonical)
+	for field := range canonical {
+		if _, ok := generated[field]; !ok {
+			t.Errorf("Attendu le champ %q du corpus dans la commande générée", field)
+		}
+	}
+	if len(generated) != len(canonical) {
+		t.Errorf("Attendu %d champs comme dans le corpus, reçu %d", len(canonical), len(generated))
+	}
 }
 
 // TestNewConfig vérifie que la configuration par défaut est correctement créée.
@@ -91,26 +126,40 @@ func TestNewConfig(t *testing.T) {
 	}
 }
 
-// TestDefaultOrderTemplates vérifie que les modèles par défaut sont définis.
-func TestDefaultOrderTemplates(t *testing.T) {
-	if len(DefaultOrderTemplates) == 0 {
-		t.Error("Attendu que DefaultOrderTemplates ait au moins un modèle")
+// TestConfigFrom vérifie la conversion de la configuration partagée.
+func TestConfigFrom(t *testing.T) {
+	appCfg := config.DefaultConfig()
+	appCfg.Kafka.Brokers = config.BrokerList{"broker:9092", "broker2:9092"}
+	appCfg.Producer.Interval = 500 * time.Millisecond
+	appCfg.Kafka.Client.Linger = 20 * time.Millisecond
+
+	cfg := ConfigFrom(appCfg)
+
+	if cfg.KafkaBroker != "broker:9092,broker2:9092" {
+		t.Errorf("Attendu KafkaBroker 'broker:9092,broker2:9092', obtenu %s", cfg.KafkaBroker)
+	}
+	if cfg.MessageInterval != 500*time.Millisecond {
+		t.Errorf("Attendu MessageInterval 500ms, obtenu %v", cfg.MessageInterval)
+	}
+	if cfg.Currency == "" {
+		t.Error("Attendu que Currency soit défini")
+	}
+	if cfg.Properties["linger.ms"] != "20" {
+		t.Errorf("Attendu linger.ms '20', obtenu %q", cfg.Properties["linger.ms"])
+	}
+	if _, ok := cfg.Properties["auto.offset.reset"]; ok {
+		t.Error("Les propriétés du consommateur ne doivent pas être transmises au producteur")
+	}
+	if cfg.ChaosFile != "" {
+		t.Errorf("Attendu ChaosFile vide sans chaos.enabled, obtenu %q", cfg.ChaosFile)
+	}
+	if cfg.StatsFile != config.ProducerStatsFile {
+		t.Errorf("Attendu StatsFile %q, obtenu %q", config.ProducerStatsFile, cfg.StatsFile)
 	}
 
-	// Vérifier que tous les modèles ont les champs requis
-	for i, template := range DefaultOrderTemplates {
-		if template.User == "" {
-			t.Errorf("Modèle %d: Attendu que User soit défini", i)
-		}
-		if template.Item == "" {
-			t.Errorf("Modèle %d: Attendu que Item soit défini", i)
-		}
-		if template.Quantity <= 0 {
-			t.Errorf("Modèle %d: Attendu que Quantity soit positif, reçu %d", i, template.Quantity)
-		}
-		if template.Price <= 0 {
-			t.Errorf("Modèle %d: Attendu que Price soit positif, reçu %f", i, template.Price)
-		}
+	appCfg.Chaos.Enabled = true
+	if cfg := ConfigFrom(appCfg); cfg.ChaosFile != config.ChaosStateFile {
+		t.Errorf("Attendu ChaosFile %q, obtenu %q", config.ChaosStateFile, cfg.ChaosFile)
 	}
 }
 
@@ -125,48 +174,28 @@ func TestNew(t *testing.T) {
 	if producer.sequence != 1 {
 		t.Errorf("Attendu que la séquence commence à 1, reçu %d", producer.sequence)
 	}
-	if len(producer.templates) == 0 {
-		t.Error("Attendu que templates soit défini")
+	if producer.generator == nil {
+		t.Error("Attendu que generator soit défini")
 	}
 }
 
-// TestProduceOrderRoundRobinStartsAtFirstTemplate verifies that the first
-// order uses template[0], not template[1]. This tests the fix for the
-// off-by-one bug where (sequence % len) skipped index 0 because sequence starts at 1.
-func TestProduceOrderRoundRobinStartsAtFirstTemplate(t *testing.T) {
+// TestGenerateOrderSeeded vérifie qu'une graine fixe rend la génération reproductible.
+func TestGenerateOrderSeeded(t *testing.T) {
 	cfg := NewConfig()
-	producer := New(cfg)
-
-	// Verify sequence starts at 1 (precondition)
-	if producer.sequence != 1 {
-		t.Fatalf("Expected sequence to start at 1, got %d", producer.sequence)
-	}
-
-	// The first order should use template[0]
-	expectedTemplate := producer.templates[0]
-	order := producer.GenerateOrder(expectedTemplate, producer.sequence)
+	cfg.Seed = 42
+	first, second := New(cfg), New(cfg)
 
-	// Simulate what ProduceOrder does for template selection
-	// With the fix: (1-1) % 10 = 0, so template[0] should be selected
-	selectedIndex := (producer.sequence - 1) % len(producer.templates)
-	if selectedIndex != 0 {
-		t.Errorf("Expected first order to use template index 0, got index %d", selectedIndex)
-	}
-
-	// Verify the order matches the first template
-	if order.CustomerInfo.CustomerID != expectedTemplate.User {
-		t.Errorf("Expected CustomerID '%s', got '%s'", expectedTemplate.User, order.CustomerInfo.CustomerID)
-	}
-	if order.Items[0].ItemName != expectedTemplate.Item {
-		t.Errorf("Expected item '%s', got '%s'", expectedTemplate.Item, order.Items[0].ItemName)
-	}
-
-	// Verify round-robin cycles correctly through all templates
-	for i := 1; i <= len(producer.templates); i++ {
-		expectedIdx := (i - 1) % len(producer.templates)
-		actualIdx := (i - 1) % len(producer.templates)
-		if expectedIdx != actualIdx {
-			t.Errorf("Sequence %d: expected template index %d, got %d", i, expectedIdx, actualIdx)
+	for sequence := 1; sequence <= 20; sequence++ {
+		a, err := first.GenerateOrder(sequence)
+		if err != nil {
+			t.Fatalf("Erreur inattendue: %v", err)
+		}
+		b, _ := second.GenerateOrder(sequence)
+		if a.OrderID != b.OrderID || a.CustomerInfo != b.CustomerInfo || a.Total != b.Total || len(a.Items) != len(b.Items) {
+			t.Fatalf("Séquence %d: attendu des commandes identiques, reçu %+v et %+v", sequence, a, b)
+		}
+		if a.Currency != cfg.Currency || a.PaymentMethod != cfg.PaymentMethod || a.Inventory.Warehouse != cfg.Warehouse {
+			t.Errorf("Séquence %d: attendu la tarification du producteur, reçu %+v", sequence, a)
 		}
 	}
 }
@@ -0,0 +1,148 @@
+package producer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/pkg/metrics"
+)
+
+// Stats is a point-in-time copy of the producer counters, written to the
+// statistics file (producer.stats_file) shown by pubsub web.
+type Stats struct {
+	Timestamp         time.Time `json:"timestamp"`           // Snapshot creation time.
+	StartTime         time.Time `json:"start_time"`          // Producer start time.
+	Topic             string    `json:"topic"`               // Topic of the published orders.
+	Published         int64     `json:"published"`           // Orders handed to the publisher.
+	Delivered         int64     `json:"delivered"`           // Orders acknowledged by the broker.
+	Failed            int64     `json:"failed"`              // Orders the publisher rejected or failed to deliver.
+	MessagesPerSecond float64   `json:"messages_per_second"` // Deliveries per second since the previous snapshot.
+	Breaker           string    `json:"breaker"`             // State of the publish circuit breaker.
+}
+
+// counters are the producer counters, updated by the production loop and
+// the delivery report handler, and exposed by pkg/metrics.
+type counters struct {
+	published *metrics.Counter
+	delivered *metrics.Counter
+	failed    *metrics.Counter
+}
+
+// newCounters creates the producer counters and registers them in metrics.Default.
+//
+// Parameters:
+//   - topic: The topic of the published orders, label of the counters.
+//
+// Returns:
+//   - counters: The counters.
+func newCounters(topic string) counters {
+	labels := map[string]string{"topic": topic}
+	c := counters{
+		published: metrics.NewCounter(metrics.Opts{Name: "pubsub_producer_published_total", Help: "Orders handed to the publisher.", Labels: labels}),
+		delivered: metrics.NewCounter(metrics.Opts{Name: "pubsub_producer_delivered_total", Help: "Orders acknowledged by the broker.", Labels: labels}),
+		failed:    metrics.NewCounter(metrics.Opts{Name: "pubsub_producer_failed_total", Help: "Orders the publisher rejected or failed to deliver.", Labels: labels}),
+	}
+	metrics.MustRegister(c.published, c.delivered, c.failed)
+	return c
+}
+
+// Stats returns the current counters of the producer.
+//
+// Returns:
+//   - Stats: The counters (MessagesPerSecond is only set in the statistics file).
+func (p *OrderProducer) Stats() Stats {
+	return Stats{
+		Timestamp: time.Now().UTC(),
+		StartTime: p.started,
+		Topic:     p.config.Topic,
+		Published: int64(p.counters.published.Value()),
+		Delivered: int64(p.counters.delivered.Value()),
+		Failed:    int64(p.counters.failed.Value()),
+		Breaker:   p.breaker.State().String(),
+	}
+}
+
+// reportStats writes the statistics file every config.ProducerStatsInterval
+// until stop is closed, then writes it a last time and closes done.
+//
+// Parameters:
+//   - stop: The channel signaling shutdown.
+//   - done: Closed once the last statistics are written.
+func (p *OrderProducer) reportStats(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(config.ProducerStatsInterval)
+	defer ticker.Stop()
+
+	previous := p.Stats()
+	for {
+		select {
+		case <-stop:
+			p.writeStats(&previous)
+			return
+		case <-ticker.C:
+			p.writeStats(&previous)
+		}
+	}
+}
+
+// writeStats writes the current statistics, with the delivery rate since previous.
+//
+// Parameters:
+//   - previous: The statistics previously written, replaced by the current ones.
+func (p *OrderProducer) writeStats(previous *Stats) {
+	s := p.Stats()
+	if elapsed := s.Timestamp.Sub(previous.Timestamp).Seconds(); elapsed > 0 {
+		s.MessagesPerSecond = float64(s.Delivered-previous.Delivered) / elapsed
+	}
+	if err := WriteStats(p.config.StatsFile, s); err != nil {
+		fmt.Printf("⚠️  Failed to write producer statistics: %v\n", err)
+	}
+	*previous = s
+}
+
+// WriteStats atomically replaces the statistics file.
+//
+// Parameters:
+//   - path: The statistics file (its directory is created if needed).
+//   - s: The statistics.
+//
+// Returns:
+//   - error: An error if the file cannot be written.
+func WriteStats(path string, s Stats) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// ReadStats reads the statistics file written by a running producer.
+//
+// Parameters:
+//   - path: The statistics file.
+//
+// Returns:
+//   - Stats: The statistics.
+//   - error: An error if the file cannot be read (fs.ErrNotExist before the producer starts).
+func ReadStats(path string) (Stats, error) {
+	var s Stats
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("invalid producer statistics %s: %w", path, err)
+	}
+	return s, nil
+}
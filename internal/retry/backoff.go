@@ -0,0 +1,161 @@
+package retry
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+)
+
+// Backoff calcule le délai d'attente avant une relance (voir Config.Backoff).
+type Backoff interface {
+	// Delay retourne le délai avant la relance suivant une tentative échouée.
+	//
+	// Paramètres:
+	//   - attempt: Le numéro de la tentative échouée (à partir de 1).
+	//   - previous: Le délai précédent (0 avant la première relance).
+	//
+	// Retourne:
+	//   - time.Duration: Le délai à attendre.
+	Delay(attempt int, previous time.Duration) time.Duration
+}
+
+// ConstantBackoff attend le même délai avant chaque relance.
+type ConstantBackoff struct {
+	Interval time.Duration // Délai entre les relances.
+	Jitter   float64       // Variation aléatoire relative (ex. 0.25 pour ±25 %).
+}
+
+// Delay retourne le délai constant, avec jitter.
+//
+// Paramètres:
+//   - attempt: Le numéro de la tentative échouée (ignoré).
+//   - previous: Le délai précédent (ignoré).
+//
+// Retourne:
+//   - time.Duration: Le délai à attendre.
+func (b ConstantBackoff) Delay(attempt int, previous time.Duration) time.Duration {
+	return applyJitter(float64(b.Interval), b.Jitter)
+}
+
+// ExponentialBackoff multiplie le délai par Multiplier à chaque relance,
+// jusqu'à Max.
+type ExponentialBackoff struct {
+	Initial    time.Duration // Délai avant la première relance.
+	Max        time.Duration // Délai maximum.
+	Multiplier float64       // Facteur appliqué à chaque relance.
+	Jitter     float64       // Variation aléatoire relative (ex. 0.25 pour ±25 %).
+}
+
+// Delay retourne Initial × Multiplier^(attempt-1), plafonné à Max, avec jitter.
+//
+// Paramètres:
+//   - attempt: Le numéro de la tentative échouée.
+//   - previous: Le délai précédent (ignoré).
+//
+// Retourne:
+//   - time.Duration: Le délai à attendre.
+func (b ExponentialBackoff) Delay(attempt int, previous time.Duration) time.Duration {
+	delay := float64(b.Initial) * math.Pow(b.Multiplier, float64(attempt-1))
+	return applyJitter(math.Min(delay, float64(b.Max)), b.Jitter)
+}
+
+// DecorrelatedJitterBackoff tire chaque délai au hasard entre Base et trois fois
+// le délai précédent, plafonné à Max ("decorrelated jitter"), ce qui étale les
+// relances de clients ayant échoué en même temps.
+type DecorrelatedJitterBackoff struct {
+	Base time.Duration // Délai minimum.
+	Max  time.Duration // Délai maximum.
+}
+
+// Delay retourne un délai aléatoire dans [Base, min(Max, 3 × previous)].
+//
+// Paramètres:
+//   - attempt: Le numéro de la tentative échouée (ignoré).
+//   - previous: Le délai précédent (0 avant la première relance).
+//
+// Retourne:
+//   - time.Duration: Le délai à attendre.
+func (b DecorrelatedJitterBackoff) Delay(attempt int, previous time.Duration) time.Duration {
+	upper := math.Min(float64(b.Max), 3*math.Max(float64(previous), float64(b.Base)))
+	if upper <= float64(b.Base) {
+		return time.Duration(upper)
+	}
+	return time.Duration(float64(b.Base) + rand.Float64()*(upper-float64(b.Base)))
+}
+
+// FibonacciBackoff fait croître le délai selon la suite de Fibonacci
+// (Initial, Initial, 2×Initial, 3×Initial, 5×Initial...), jusqu'à Max : plus
+// progressif que l'exponentiel.
+type FibonacciBackoff struct {
+	Initial time.Duration // Délai avant la première relance.
+	Max     time.Duration // Délai maximum.
+	Jitter  float64       // Variation aléatoire relative (ex. 0.25 pour ±25 %).
+}
+
+// Delay retourne Initial × F(attempt), plafonné à Max, avec jitter.
+//
+// Paramètres:
+//   - attempt: Le numéro de la tentative échouée.
+//   - previous: Le délai précédent (ignoré).
+//
+// Retourne:
+//   - time.Duration: Le délai à attendre.
+func (b FibonacciBackoff) Delay(attempt int, previous time.Duration) time.Duration {
+	a, c := 1.0, 1.0
+	for i := 1; i < attempt && float64(b.Initial)*a < float64(b.Max); i++ {
+		a, c = c, a+c
+	}
+	return applyJitter(math.Min(float64(b.Initial)*a, float64(b.Max)), b.Jitter)
+}
+
+// applyJitter ajoute une variation aléatoire uniforme de ±jitter à un délai.
+//
+// Paramètres:
+//   - delay: Le délai en nanosecondes.
+//   - jitter: La variation relative (0 pour aucune).
+//
+// Retourne:
+//   - time.Duration: Le délai avec jitter.
+func applyJitter(delay, jitter float64) time.Duration {
+	return time.Duration(delay + delay*jitter*(rand.Float64()*2-1))
+}
+
+// BackoffFrom crée la stratégie de backoff sélectionnée par retry.backoff dans
+// la configuration de l'application.
+//
+// Paramètres:
+//   - cfg: La section retry.
+//
+// Retourne:
+//   - Backoff: La stratégie (exponentielle pour une valeur vide ou inconnue).
+func BackoffFrom(cfg config.RetryConfig) Backoff {
+	switch cfg.Backoff {
+	case config.BackoffConstant:
+		return ConstantBackoff{Interval: cfg.InitialDelay, Jitter: cfg.Jitter}
+	case config.BackoffDecorrelatedJitter:
+		return DecorrelatedJitterBackoff{Base: cfg.InitialDelay, Max: cfg.MaxDelay}
+	case config.BackoffFibonacci:
+		return FibonacciBackoff{Initial: cfg.InitialDelay, Max: cfg.MaxDelay, Jitter: cfg.Jitter}
+	}
+	return ExponentialBackoff{Initial: cfg.InitialDelay, Max: cfg.MaxDelay, Multiplier: cfg.Multiplier, Jitter: cfg.Jitter}
+}
+
+// ConfigFrom crée la configuration de relance à partir de la section retry de
+// la configuration de l'application.
+//
+// Paramètres:
+//   - cfg: La section retry.
+//
+// Retourne:
+//   - Config: La configuration de relance, sans budget.
+func ConfigFrom(cfg config.RetryConfig) Config {
+	return Config{
+		MaxAttempts:  cfg.MaxAttempts,
+		InitialDelay: cfg.InitialDelay,
+		MaxDelay:     cfg.MaxDelay,
+		Multiplier:   cfg.Multiplier,
+		Backoff:      BackoffFrom(cfg),
+	}
+}
@@ -0,0 +1,111 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+)
+
+// samples est le nombre de tirages par vérification de bornes.
+const samples = 1000
+
+// checkBounds vérifie que tous les délais tirés restent dans [min, max].
+func checkBounds(t *testing.T, name string, b Backoff, attempt int, previous, min, max time.Duration) {
+	t.Helper()
+	for i := 0; i < samples; i++ {
+		if d := b.Delay(attempt, previous); d < min || d > max {
+			t.Fatalf("%s attempt %d: delay %v out of [%v, %v]", name, attempt, d, min, max)
+		}
+	}
+}
+
+func TestConstantBackoff(t *testing.T) {
+	b := ConstantBackoff{Interval: 100 * time.Millisecond, Jitter: 0.1}
+	for attempt := 1; attempt <= 5; attempt++ {
+		checkBounds(t, "constant", b, attempt, 0, 90*time.Millisecond, 110*time.Millisecond)
+	}
+	if d := (ConstantBackoff{Interval: time.Second}).Delay(3, 0); d != time.Second {
+		t.Errorf("Expected exact delay without jitter, got %v", d)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := ExponentialBackoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2, Jitter: 0.25}
+	checkBounds(t, "exponential", b, 1, 0, 75*time.Millisecond, 125*time.Millisecond)
+	checkBounds(t, "exponential", b, 3, 0, 300*time.Millisecond, 500*time.Millisecond)
+	checkBounds(t, "exponential", b, 10, 0, 750*time.Millisecond, 1250*time.Millisecond)
+}
+
+func TestDecorrelatedJitterBackoff(t *testing.T) {
+	b := DecorrelatedJitterBackoff{Base: 100 * time.Millisecond, Max: time.Second}
+	checkBounds(t, "decorrelated", b, 1, 0, 100*time.Millisecond, 300*time.Millisecond)
+	checkBounds(t, "decorrelated", b, 2, 200*time.Millisecond, 100*time.Millisecond, 600*time.Millisecond)
+	checkBounds(t, "decorrelated", b, 5, 800*time.Millisecond, 100*time.Millisecond, time.Second)
+
+	// Les délais sont répartis sur l'intervalle, pas concentrés sur une borne
+	var low, high int
+	for i := 0; i < samples; i++ {
+		if b.Delay(1, 0) < 200*time.Millisecond {
+			low++
+		} else {
+			high++
+		}
+	}
+	if low < samples/4 || high < samples/4 {
+		t.Errorf("Expected delays spread over [100ms, 300ms], got %d below and %d above 200ms", low, high)
+	}
+}
+
+func TestFibonacciBackoff(t *testing.T) {
+	b := FibonacciBackoff{Initial: 100 * time.Millisecond, Max: time.Second}
+	want := []time.Duration{100, 100, 200, 300, 500, 800, 1000, 1000}
+	for i, ms := range want {
+		if d := b.Delay(i+1, 0); d != ms*time.Millisecond {
+			t.Errorf("Attempt %d: expected %v, got %v", i+1, ms*time.Millisecond, d)
+		}
+	}
+	b.Jitter = 0.5
+	checkBounds(t, "fibonacci", b, 5, 0, 250*time.Millisecond, 750*time.Millisecond)
+}
+
+func TestBackoffFrom(t *testing.T) {
+	cfg := config.DefaultConfig().Retry
+	tests := []struct {
+		name string
+		want Backoff
+	}{
+		{config.BackoffConstant, ConstantBackoff{Interval: cfg.InitialDelay, Jitter: cfg.Jitter}},
+		{config.BackoffExponential, ExponentialBackoff{Initial: cfg.InitialDelay, Max: cfg.MaxDelay, Multiplier: cfg.Multiplier, Jitter: cfg.Jitter}},
+		{config.BackoffDecorrelatedJitter, DecorrelatedJitterBackoff{Base: cfg.InitialDelay, Max: cfg.MaxDelay}},
+		{config.BackoffFibonacci, FibonacciBackoff{Initial: cfg.InitialDelay, Max: cfg.MaxDelay, Jitter: cfg.Jitter}},
+		{"", ExponentialBackoff{Initial: cfg.InitialDelay, Max: cfg.MaxDelay, Multiplier: cfg.Multiplier, Jitter: cfg.Jitter}},
+	}
+	for _, tt := range tests {
+		cfg.Backoff = tt.name
+		if got := BackoffFrom(cfg); got != tt.want {
+			t.Errorf("%q: expected %#v, got %#v", tt.name, tt.want, got)
+		}
+	}
+}
+
+// recordingBackoff retourne un délai nul et enregistre les appels.
+type recordingBackoff struct {
+	attempts []int
+}
+
+func (b *recordingBackoff) Delay(attempt int, previous time.Duration) time.Duration {
+	b.attempts = append(b.attempts, attempt)
+	return 0
+}
+
+func TestDoUsesBackoff(t *testing.T) {
+	backoff := &recordingBackoff{}
+	cfg := Config{MaxAttempts: 3, Backoff: backoff}
+	Do(context.Background(), cfg, func() error { return errors.New("fail") })
+	if len(backoff.attempts) != 2 || backoff.attempts[0] != 1 || backoff.attempts[1] != 2 {
+		t.Errorf("Expected the backoff to be asked for attempts 1 and 2, got %v", backoff.attempts)
+	}
+}
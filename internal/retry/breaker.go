@@ -0,0 +1,286 @@
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+)
+
+// BreakerState est l'état d'un disjoncteur (circuit breaker).
+type BreakerState int
+
+const (
+	// StateClosed laisse passer les appels et compte les échecs consécutifs.
+	StateClosed BreakerState = iota
+	// StateOpen rejette les appels jusqu'à l'expiration du délai de réarmement.
+	StateOpen
+	// StateHalfOpen laisse passer un appel d'essai pour décider de refermer ou de rouvrir le circuit.
+	StateHalfOpen
+)
+
+// String retourne le nom de l'état.
+//
+// Retourne:
+//   - string: closed, open ou half-open.
+func (s BreakerState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("BreakerState(%d)", int(s))
+}
+
+// ErrCircuitOpen est retourné lorsqu'un appel est rejeté par un disjoncteur ouvert.
+var ErrCircuitOpen = errors.New("circuit ouvert")
+
+// BreakerConfig contient la configuration d'un disjoncteur.
+type BreakerConfig struct {
+	FailureThreshold int           // Échecs consécutifs ouvrant le circuit (0 désactive le disjoncteur).
+	ResetTimeout     time.Duration // Durée d'ouverture avant un appel d'essai.
+	SuccessThreshold int           // Succès consécutifs en semi-ouvert refermant le circuit.
+}
+
+// DefaultBreakerConfig retourne une configuration de disjoncteur par défaut.
+//
+// Retourne:
+//   - BreakerConfig: La configuration initialisée avec les constantes config.CircuitBreaker*.
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		FailureThreshold: config.CircuitBreakerFailureThreshold,
+		ResetTimeout:     config.CircuitBreakerResetTimeout,
+		SuccessThreshold: config.CircuitBreakerSuccessThreshold,
+	}
+}
+
+// BreakerConfigFrom crée la configuration du disjoncteur à partir de la
+// section retry.circuit_breaker de la configuration de l'application.
+//
+// Paramètres:
+//   - cfg: La section retry.circuit_breaker.
+//
+// Retourne:
+//   - BreakerConfig: La configuration (FailureThreshold nul si le disjoncteur est désactivé).
+func BreakerConfigFrom(cfg config.CircuitBreakerConfig) BreakerConfig {
+	if !cfg.Enabled {
+		return BreakerConfig{}
+	}
+	return BreakerConfig{
+		FailureThreshold: cfg.FailureThreshold,
+		ResetTimeout:     cfg.ResetTimeout,
+		SuccessThreshold: cfg.SuccessThreshold,
+	}
+}
+
+// StateChange décrit une transition d'état d'un disjoncteur.
+type StateChange struct {
+	Name     string       // Nom du disjoncteur (ex. producer, tracker).
+	From     BreakerState // État précédent.
+	To       BreakerState // Nouvel état.
+	Failures int          // Échecs consécutifs au moment de la transition.
+	At       time.Time    // Heure de la transition.
+}
+
+// String formate la transition en paires clé=valeur (logfmt).
+//
+// Retourne:
+//   - string: La transition (ex. level=warn msg=... breaker=producer from=closed to=open failures=3).
+func (c StateChange) String() string {
+	level := "info"
+	if c.To == StateOpen {
+		level = "warn"
+	}
+	return fmt.Sprintf("level=%s msg=%q breaker=%s from=%s to=%s failures=%d",
+		level, "circuit breaker state change", c.Name, c.From, c.To, c.Failures)
+}
+
+// CircuitBreaker coupe les appels vers une dépendance défaillante : après
+// FailureThreshold échecs consécutifs, le circuit s'ouvre et les appels sont
+// rejetés avec ErrCircuitOpen pendant ResetTimeout ; un appel d'essai
+// (semi-ouvert) referme ensuite le circuit ou le rouvre. Un disjoncteur nil
+// laisse passer tous les appels.
+type CircuitBreaker struct {
+	name          string
+	cfg           BreakerConfig
+	onStateChange func(StateChange) // Appelé à chaque transition (peut être nil).
+	now           func() time.Time  // Horloge, remplaçable pour les tests.
+
+	mu        sync.Mutex
+	state     BreakerState
+	failures  int       // Échecs consécutifs.
+	successes int       // Succès consécutifs en semi-ouvert.
+	openedAt  time.Time // Heure de la dernière ouverture.
+	probing   bool      // Un appel d'essai est en cours.
+}
+
+// NewCircuitBreaker crée un disjoncteur fermé.
+//
+// Paramètres:
+//   - name: Le nom du disjoncteur, repris dans les transitions.
+//   - cfg: La configuration du disjoncteur.
+//   - onStateChange: La fonction appelée à chaque transition (peut être nil).
+//
+// Retourne:
+//   - *CircuitBreaker: Le disjoncteur, ou nil si cfg.FailureThreshold est nul (désactivé).
+func NewCircuitBreaker(name string, cfg BreakerConfig, onStateChange func(StateChange)) *CircuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		return nil
+	}
+	if cfg.SuccessThreshold <= 0 {
+		cfg.SuccessThreshold = 1
+	}
+	return &CircuitBreaker{
+		name:          name,
+		cfg:           cfg,
+		onStateChange: onStateChange,
+		now:           time.Now,
+	}
+}
+
+// State retourne l'état courant du disjoncteur.
+//
+// Retourne:
+//   - BreakerState: L'état (StateClosed pour un disjoncteur nil).
+func (b *CircuitBreaker) State() BreakerState {
+	if b == nil {
+		return StateClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Allow indique si un appel peut être tenté. Un circuit ouvert depuis plus de
+// ResetTimeout passe en semi-ouvert et autorise un seul appel d'essai à la fois.
+//
+// Retourne:
+//   - error: ErrCircuitOpen si l'appel est rejeté, nil sinon.
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	var change *StateChange
+	defer func() {
+		b.mu.Unlock()
+		b.notify(change)
+	}()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.cfg.ResetTimeout {
+			return ErrCircuitOpen
+		}
+		change = b.transition(StateHalfOpen)
+		b.probing = true
+	case StateHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Success enregistre la réussite d'un appel autorisé.
+func (b *CircuitBreaker) Success() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	var change *StateChange
+	defer func() {
+		b.mu.Unlock()
+		b.notify(change)
+	}()
+
+	b.failures = 0
+	if b.state != StateHalfOpen {
+		return
+	}
+	b.probing = false
+	b.successes++
+	if b.successes >= b.cfg.SuccessThreshold {
+		change = b.transition(StateClosed)
+	}
+}
+
+// Failure enregistre l'échec d'un appel autorisé.
+func (b *CircuitBreaker) Failure() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	var change *StateChange
+	defer func() {
+		b.mu.Unlock()
+		b.notify(change)
+	}()
+
+	b.failures++
+	switch {
+	case b.state == StateHalfOpen:
+		b.probing = false
+		change = b.transition(StateOpen)
+	case b.state == StateClosed && b.failures >= b.cfg.FailureThreshold:
+		change = b.transition(StateOpen)
+	}
+}
+
+// Execute exécute une fonction à travers le disjoncteur. Les erreurs
+// permanentes (voir Permanent) ne comptent pas comme des échecs de la
+// dépendance.
+//
+// Paramètres:
+//   - fn: La fonction à exécuter.
+//
+// Retourne:
+//   - error: ErrCircuitOpen si l'appel est rejeté, sinon l'erreur de fn.
+func (b *CircuitBreaker) Execute(fn func() error) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+	err := fn()
+	if err != nil && !IsPermanent(err) {
+		b.Failure()
+	} else {
+		b.Success()
+	}
+	return err
+}
+
+// transition change l'état du disjoncteur (verrou détenu).
+//
+// Paramètres:
+//   - to: Le nouvel état.
+//
+// Retourne:
+//   - *StateChange: La transition à notifier après libération du verrou.
+func (b *CircuitBreaker) transition(to BreakerState) *StateChange {
+	change := &StateChange{Name: b.name, From: b.state, To: to, Failures: b.failures, At: b.now()}
+	b.state = to
+	b.successes = 0
+	switch to {
+	case StateOpen:
+		b.openedAt = change.At
+	case StateClosed:
+		b.failures = 0
+	}
+	return change
+}
+
+// notify transmet une transition à la fonction de rappel.
+//
+// Paramètres:
+//   - change: La transition (nil si l'état n'a pas changé).
+func (b *CircuitBreaker) notify(change *StateChange) {
+	if change != nil && b.onStateChange != nil {
+		b.onStateChange(*change)
+	}
+}
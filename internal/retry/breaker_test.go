@@ -0,0 +1,117 @@
+package retry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+)
+
+// newTestBreaker crée un disjoncteur à horloge manuelle qui enregistre ses transitions.
+func newTestBreaker(cfg BreakerConfig) (*CircuitBreaker, *time.Time, *[]StateChange) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var changes []StateChange
+	b := NewCircuitBreaker("test", cfg, func(c StateChange) { changes = append(changes, c) })
+	b.now = func() time.Time { return now }
+	return b, &now, &changes
+}
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	b, _, changes := newTestBreaker(BreakerConfig{FailureThreshold: 2, ResetTimeout: time.Second})
+	fail := errors.New("broker down")
+
+	for i := 0; i < 2; i++ {
+		if err := b.Execute(func() error { return fail }); err != fail {
+			t.Fatalf("Expected the call error, got %v", err)
+		}
+	}
+	if b.State() != StateOpen {
+		t.Fatalf("Expected open state, got %s", b.State())
+	}
+	called := false
+	if err := b.Execute(func() error { called = true; return nil }); !errors.Is(err, ErrCircuitOpen) || called {
+		t.Errorf("Expected the call to be rejected, got %v (called %v)", err, called)
+	}
+	if len(*changes) != 1 || (*changes)[0].From != StateClosed || (*changes)[0].To != StateOpen || (*changes)[0].Failures != 2 {
+		t.Errorf("Expected one closed -> open transition, got %v", *changes)
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	b, now, changes := newTestBreaker(BreakerConfig{FailureThreshold: 1, ResetTimeout: time.Second, SuccessThreshold: 1})
+	b.Failure()
+
+	*now = now.Add(time.Second)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Expected a trial call after the reset timeout, got %v", err)
+	}
+	if b.State() != StateHalfOpen {
+		t.Fatalf("Expected half-open state, got %s", b.State())
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected a single trial call at a time, got %v", err)
+	}
+
+	// Échec de l'essai : le circuit se rouvre pour un nouveau délai
+	b.Failure()
+	if b.State() != StateOpen || b.Allow() == nil {
+		t.Fatalf("Expected the circuit to reopen, got %s", b.State())
+	}
+
+	*now = now.Add(time.Second)
+	if err := b.Execute(func() error { return nil }); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if b.State() != StateClosed {
+		t.Errorf("Expected closed state after a successful trial, got %s", b.State())
+	}
+
+	want := []BreakerState{StateOpen, StateHalfOpen, StateOpen, StateHalfOpen, StateClosed}
+	if len(*changes) != len(want) {
+		t.Fatalf("Expected %d transitions, got %v", len(want), *changes)
+	}
+	for i, state := range want {
+		if (*changes)[i].To != state {
+			t.Errorf("Transition %d: expected %s, got %s", i, state, (*changes)[i].To)
+		}
+	}
+}
+
+func TestBreakerIgnoresPermanentErrors(t *testing.T) {
+	b, _, _ := newTestBreaker(BreakerConfig{FailureThreshold: 1, ResetTimeout: time.Second})
+	b.Execute(func() error { return Permanent(errors.New("invalid")) })
+	if b.State() != StateClosed {
+		t.Errorf("Expected permanent errors not to open the circuit, got %s", b.State())
+	}
+}
+
+func TestNilBreaker(t *testing.T) {
+	b := NewCircuitBreaker("disabled", BreakerConfig{}, nil)
+	if b != nil {
+		t.Fatal("Expected a nil breaker for a zero failure threshold")
+	}
+	b.Failure()
+	if err := b.Allow(); err != nil || b.State() != StateClosed {
+		t.Errorf("Expected a nil breaker to allow every call, got %v", err)
+	}
+}
+
+func TestBreakerConfigFrom(t *testing.T) {
+	cfg := config.DefaultConfig().Retry.CircuitBreaker
+	if got := BreakerConfigFrom(cfg); got != DefaultBreakerConfig() {
+		t.Errorf("Expected the default configuration, got %+v", got)
+	}
+	cfg.Enabled = false
+	if got := BreakerConfigFrom(cfg); got.FailureThreshold != 0 {
+		t.Errorf("Expected a disabled breaker, got %+v", got)
+	}
+}
+
+func TestStateChangeString(t *testing.T) {
+	c := StateChange{Name: "producer", From: StateClosed, To: StateOpen, Failures: 2}
+	want := `level=warn msg="circuit breaker state change" breaker=producer from=closed to=open failures=2`
+	if got := c.String(); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,79 @@
+package retry
+
+import (
+	"sync"
+	"time"
+)
+
+// Budget limite le nombre de relances sur une fenêtre de temps, partagé entre
+// les appels qui l'utilisent (voir Config.Budget). Lorsqu'une panne provoque
+// une avalanche d'échecs, le budget épuisé fait échouer les appels dès leur
+// première tentative au lieu de multiplier la charge sur le cluster. Un budget
+// nil est illimité.
+type Budget struct {
+	maxRetries int
+	window     time.Duration
+	now        func() time.Time // Horloge, remplaçable pour les tests.
+
+	mu          sync.Mutex
+	windowStart time.Time // Début de la fenêtre courante.
+	used        int       // Relances consommées dans la fenêtre courante.
+}
+
+// NewBudget crée un budget de relances.
+//
+// Paramètres:
+//   - maxRetries: Le nombre maximum de relances par fenêtre.
+//   - window: La durée de la fenêtre.
+//
+// Retourne:
+//   - *Budget: Le budget, plein.
+func NewBudget(maxRetries int, window time.Duration) *Budget {
+	return &Budget{
+		maxRetries: maxRetries,
+		window:     window,
+		now:        time.Now,
+	}
+}
+
+// Acquire consomme une relance du budget.
+//
+// Retourne:
+//   - bool: Vrai si la relance est autorisée, faux si le budget de la fenêtre est épuisé.
+func (b *Budget) Acquire() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.roll()
+	if b.used >= b.maxRetries {
+		return false
+	}
+	b.used++
+	return true
+}
+
+// Remaining retourne le nombre de relances encore disponibles dans la fenêtre courante.
+//
+// Retourne:
+//   - int: Les relances restantes (-1 pour un budget nil, illimité).
+func (b *Budget) Remaining() int {
+	if b == nil {
+		return -1
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.roll()
+	return b.maxRetries - b.used
+}
+
+// roll démarre une nouvelle fenêtre si la courante est écoulée (verrou détenu).
+func (b *Budget) roll() {
+	if now := b.now(); now.Sub(b.windowStart) >= b.window {
+		b.windowStart = now
+		b.used = 0
+	}
+}
@@ -0,0 +1,107 @@
+package retry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+)
+
+// Decision indique comment traiter une erreur (voir Classifier).
+type Decision struct {
+	Permanent bool          // L'erreur ne doit pas être relancée.
+	After     time.Duration // Délai imposé avant la relance (0 : délai du backoff).
+}
+
+var (
+	// DecisionRetry relance après le délai du backoff.
+	DecisionRetry = Decision{}
+	// DecisionPermanent abandonne immédiatement, comme une erreur enveloppée par Permanent.
+	DecisionPermanent = Decision{Permanent: true}
+)
+
+// Classes d'erreur portées par les messages DLQ (voir Decision.Class).
+const (
+	// ErrorClassPermanent désigne une erreur que relancer ne peut pas corriger.
+	ErrorClassPermanent = "permanent"
+	// ErrorClassThrottled désigne une erreur relancée après un délai imposé.
+	ErrorClassThrottled = "throttled"
+	// ErrorClassTransient désigne une erreur relancée après le délai du backoff.
+	ErrorClassTransient = "transient"
+)
+
+// Class retourne la classe d'erreur de la décision.
+//
+// Retourne:
+//   - string: ErrorClassPermanent, ErrorClassThrottled ou ErrorClassTransient.
+func (d Decision) Class() string {
+	switch {
+	case d.Permanent:
+		return ErrorClassPermanent
+	case d.After > 0:
+		return ErrorClassThrottled
+	}
+	return ErrorClassTransient
+}
+
+// RetryAfter relance après un délai imposé (ex. délai demandé par le broker)
+// au lieu du délai du backoff.
+//
+// Paramètres:
+//   - d: Le délai avant la relance.
+//
+// Retourne:
+//   - Decision: La décision.
+func RetryAfter(d time.Duration) Decision {
+	return Decision{After: d}
+}
+
+// Classifier associe une erreur à une décision de relance, pour centraliser la
+// politique d'un appelant (voir Config.Classifier) au lieu d'envelopper chaque
+// erreur avec Permanent. Une erreur enveloppée par Permanent reste permanente
+// quel que soit le classificateur.
+type Classifier func(err error) Decision
+
+// DefaultClassifier rend permanentes les erreurs que relancer ne peut pas
+// corriger : contexte annulé ou expiré, validation d'une commande (voir
+// models.IsValidationError) et JSON invalide. Les autres erreurs sont relancées.
+//
+// Paramètres:
+//   - err: L'erreur à classer.
+//
+// Retourne:
+//   - Decision: La décision.
+func DefaultClassifier(err error) Decision {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return DecisionPermanent
+	case models.IsValidationError(err):
+		return DecisionPermanent
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return DecisionPermanent
+	}
+	return DecisionRetry
+}
+
+// classify retourne la décision de relance d'une erreur.
+//
+// Paramètres:
+//   - err: L'erreur de la tentative.
+//   - cfg: La configuration de relance.
+//
+// Retourne:
+//   - Decision: DecisionPermanent pour une PermanentError, sinon la décision de
+//     cfg.Classifier (DecisionRetry s'il est nil).
+func classify(err error, cfg Config) Decision {
+	if IsPermanent(err) {
+		return DecisionPermanent
+	}
+	if cfg.Classifier == nil {
+		return DecisionRetry
+	}
+	return cfg.Classifier(err)
+}
@@ -0,0 +1,43 @@
+//go:build kafka
+// +build kafka
+
+package retry
+
+import (
+	"errors"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// queueFullDelay est l'attente avant de republier quand la file locale du producteur est pleine.
+const queueFullDelay = 100 * time.Millisecond
+
+// KafkaClassifier classe les kafka.Error : les erreurs fatales et celles que
+// relancer ne corrige pas (message trop gros, argument invalide, topic invalide
+// ou non autorisé) sont permanentes, une file locale pleine est relancée après
+// queueFullDelay, les autres sont relancées. Les erreurs non Kafka sont
+// classées par DefaultClassifier.
+//
+// Paramètres:
+//   - err: L'erreur à classer.
+//
+// Retourne:
+//   - Decision: La décision.
+func KafkaClassifier(err error) Decision {
+	var kafkaErr kafka.Error
+	if !errors.As(err, &kafkaErr) {
+		return DefaultClassifier(err)
+	}
+	if kafkaErr.IsFatal() {
+		return DecisionPermanent
+	}
+	switch kafkaErr.Code() {
+	case kafka.ErrQueueFull:
+		return RetryAfter(queueFullDelay)
+	case kafka.ErrMsgSizeTooLarge, kafka.ErrRecordListTooLarge, kafka.ErrInvalidArg,
+		kafka.ErrTopicException, kafka.ErrTopicAuthorizationFailed:
+		return DecisionPermanent
+	}
+	return DecisionRetry
+}
@@ -0,0 +1,32 @@
+//go:build kafka
+// +build kafka
+
+package retry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+func TestKafkaClassifier(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Decision
+	}{
+		{"fatal", kafka.NewError(kafka.ErrAllBrokersDown, "fatal", true), DecisionPermanent},
+		{"message too large", kafka.NewError(kafka.ErrMsgSizeTooLarge, "too large", false), DecisionPermanent},
+		{"wrapped authorization", fmt.Errorf("produce: %w", kafka.NewError(kafka.ErrTopicAuthorizationFailed, "denied", false)), DecisionPermanent},
+		{"queue full", kafka.NewError(kafka.ErrQueueFull, "queue full", false), RetryAfter(queueFullDelay)},
+		{"brokers down", kafka.NewError(kafka.ErrAllBrokersDown, "down", false), DecisionRetry},
+		{"context", context.Canceled, DecisionPermanent},
+	}
+	for _, tt := range tests {
+		if got := KafkaClassifier(tt.err); got != tt.want {
+			t.Errorf("%s: expected %+v, got %+v", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package retry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+)
+
+func TestDefaultClassifier(t *testing.T) {
+	var order models.Order
+	jsonErr := json.Unmarshal([]byte(`{"sequence": "one"}`), &order)
+
+	tests := []struct {
+		name string
+		err  error
+		want Decision
+	}{
+		{"canceled", context.Canceled, DecisionPermanent},
+		{"deadline", fmt.Errorf("read: %w", context.DeadlineExceeded), DecisionPermanent},
+		{"validation", fmt.Errorf("item 1: %w", models.ErrInvalidQuantity), DecisionPermanent},
+		{"json", jsonErr, DecisionPermanent},
+		{"transient", errors.New("connection reset"), DecisionRetry},
+	}
+	for _, tt := range tests {
+		if got := DefaultClassifier(tt.err); got != tt.want {
+			t.Errorf("%s: expected %+v, got %+v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestDoClassifier(t *testing.T) {
+	errInvalid := errors.New("invalid")
+	errThrottled := errors.New("throttled")
+	cfg := Config{
+		MaxAttempts:  3,
+		InitialDelay: time.Hour, // jamais utilisé : le classificateur impose le délai
+		MaxDelay:     time.Hour,
+		Multiplier:   2.0,
+		Classifier: func(err error) Decision {
+			if errors.Is(err, errInvalid) {
+				return DecisionPermanent
+			}
+			return RetryAfter(time.Millisecond)
+		},
+	}
+
+	calls := 0
+	result := Do(context.Background(), cfg, func() error {
+		calls++
+		return errInvalid
+	})
+	if result.Err != errInvalid || calls != 1 {
+		t.Errorf("Expected no retry of a permanent decision, got %d calls and %v", calls, result.Err)
+	}
+
+	var delays []time.Duration
+	result = DoWithCallback(context.Background(), cfg, func() error {
+		return errThrottled
+	}, func(attempt int, err error, nextDelay time.Duration) {
+		delays = append(delays, nextDelay)
+	})
+	if result.Attempts != 3 || len(delays) != 2 || delays[0] != time.Millisecond {
+		t.Errorf("Expected 3 attempts with the imposed delay, got %+v and %v", result, delays)
+	}
+}
+
+func TestPermanentWinsOverClassifier(t *testing.T) {
+	cfg := Config{MaxAttempts: 3, Classifier: func(error) Decision { return DecisionRetry }}
+	calls := 0
+	Do(context.Background(), cfg, func() error {
+		calls++
+		return Permanent(errors.New("invalid"))
+	})
+	if calls != 1 {
+		t.Errorf("Expected a PermanentError not to be retried, got %d calls", calls)
+	}
+}
+
+func TestDecisionClass(t *testing.T) {
+	cases := map[string]Decision{
+		ErrorClassPermanent: DecisionPermanent,
+		ErrorClassThrottled: RetryAfter(time.Second),
+		ErrorClassTransient: DecisionRetry,
+	}
+	for want, d := range cases {
+		if got := d.Class(); got != want {
+			t.Errorf("%+v: expected %s, got %s", d, want, got)
+		}
+	}
+}
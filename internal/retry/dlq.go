@@ -1,36 +1,85 @@
-//go:build kafka
-// +build kafka
-
 package retry
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/agbruneau/PubSub/pkg/metrics"
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 )
 
-// FailedMessage représente un message qui a échoué lors du traitement.
-type FailedMessage struct {
-	OriginalTopic     string          `json:"original_topic"`     // Le sujet Kafka d'origine.
-	OriginalPartition int32           `json:"original_partition"` // La partition Kafka d'origine.
-	OriginalOffset    int64           `json:"original_offset"`    // Le décalage (offset) d'origine.
-	OriginalTimestamp time.Time       `json:"original_timestamp"` // L'horodatage d'origine du message.
-	FailedAt          time.Time       `json:"failed_at"`          // L'heure de l'échec.
-	Attempts          int             `json:"attempts"`           // Le nombre de tentatives effectuées.
-	LastError         string          `json:"last_error"`         // Le dernier message d'erreur rencontré.
-	Payload           json.RawMessage `json:"payload"`            // Le contenu brut du message.
+// En-têtes des messages DLQ, en plus de original-topic, error et retry-tier.
+const (
+	// HeaderAttempts porte le nombre de tentatives effectuées avant l'abandon.
+	HeaderAttempts = "attempts"
+	// HeaderErrorClass porte la classe de la dernière erreur (voir Decision.Class).
+	HeaderErrorClass = "error-class"
+	// HeaderConsumerGroup porte le groupe de consommateurs du traitement en échec.
+	HeaderConsumerGroup = "consumer-group"
+	// HeaderHostname porte la machine du traitement en échec.
+	HeaderHostname = "hostname"
+	// HeaderHandler porte le nom du traitement en échec.
+	HeaderHandler = "handler"
+	// HeaderFirstSeen porte l'heure (RFC 3339) du premier échec du message,
+	// posée par TierRouter au premier palier et conservée jusqu'à la DLQ.
+	HeaderFirstSeen = "first-seen"
+	// HeaderReprocessCount porte le nombre de retraitements automatiques d'un
+	// message depuis la DLQ, conservé par les topics de relance jusqu'à la DLQ.
+	HeaderReprocessCount = "reprocess-count"
+)
+
+// dlqDeliveryChannelSize est la capacité du canal des rapports de livraison de la DLQ.
+const dlqDeliveryChannelSize = 1000
+
+// KafkaProducer définit les opérations du producteur Kafka (implémenté par
+// *kafka.Producer). Cette abstraction permet l'injection de dépendances et
+// simplifie les tests.
+type KafkaProducer interface {
+	// Produce envoie un message à Kafka de manière asynchrone.
+	//
+	// Paramètres:
+	//   - msg: Le message Kafka à envoyer.
+	//   - deliveryChan: Le canal de notification de livraison (optionnel).
+	//
+	// Retourne:
+	//   - error: Une erreur si l'envoi échoue.
+	Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error
+
+	// Flush attend la livraison de tous les messages, au plus timeoutMs millisecondes.
+	//
+	// Paramètres:
+	//   - timeoutMs: Le temps d'attente maximal en millisecondes.
+	//
+	// Retourne:
+	//   - int: Le nombre de messages restant dans la file.
+	Flush(timeoutMs int) int
+
+	// Close ferme le producteur et libère ses ressources.
+	Close()
 }
 
 // DeadLetterQueue gère l'envoi des messages échoués vers un topic DLQ (Dead Letter Queue).
 type DeadLetterQueue struct {
-	producer *kafka.Producer // Le producteur Kafka interne.
-	topic    string          // Le nom du topic DLQ.
-	enabled  bool            // Indique si la DLQ est activée.
-	mu       sync.Mutex      // Mutex pour protéger les statistiques.
-	stats    DLQStats        // Statistiques d'envoi.
+	producer   KafkaProducer    // Le producteur Kafka interne.
+	deliveries chan kafka.Event // Les rapports de livraison des messages DLQ.
+	done       chan struct{}    // Fermé à la fin du traitement des rapports de livraison.
+	topic      string           // Le nom du topic DLQ.
+	enabled    bool             // Indique si la DLQ est activée.
+	confirm    time.Duration    // Attente de la confirmation de chaque envoi (0 : envoi asynchrone).
+	group      string           // Groupe de consommateurs du traitement en échec.
+	handler    string           // Nom du traitement en échec.
+	hostname   string           // Machine du traitement en échec.
+	classifier Classifier       // Classificateur des erreurs (nil : seules les PermanentError sont permanentes).
+	retryCfg   Config           // Relances des envois et des reprises (MaxAttempts nul : une seule tentative).
+	metrics    *dlqMetrics      // Statistiques d'envoi (nil : DLQ désactivée).
+
+	fallback   string     // Fichier de secours des messages non publiés ("" : désactivé).
+	fallbackMu sync.Mutex // Sérialise les écritures et la reprise du fichier de secours.
 }
 
 // DLQStats contient des statistiques sur les opérations de la DLQ.
@@ -39,6 +88,40 @@ type DLQStats struct {
 	SendErrors    int64     // Nombre d'erreurs lors de l'envoi à la DLQ.
 	LastSentTime  time.Time // Heure du dernier envoi réussi.
 	LastErrorTime time.Time // Heure de la dernière erreur.
+
+	FallbackSaved     int64 // Messages écrits dans le fichier de secours après un échec d'envoi.
+	FallbackRecovered int64 // Messages du fichier de secours republiés dans la DLQ.
+}
+
+// dlqMetrics sont les statistiques d'envoi d'une DLQ, exposées par pkg/metrics.
+type dlqMetrics struct {
+	sent              *metrics.Counter
+	sendErrors        *metrics.Counter
+	lastSent          *metrics.Gauge
+	lastError         *metrics.Gauge
+	fallbackSaved     *metrics.Counter
+	fallbackRecovered *metrics.Counter
+}
+
+// newDLQMetrics crée les statistiques d'une DLQ et les enregistre dans metrics.Default.
+//
+// Paramètres:
+//   - topic: Le topic DLQ, étiquette des métriques.
+//
+// Retourne:
+//   - *dlqMetrics: Les statistiques, à zéro.
+func newDLQMetrics(topic string) *dlqMetrics {
+	labels := map[string]string{"topic": topic}
+	m := &dlqMetrics{
+		sent:              metrics.NewCounter(metrics.Opts{Name: "pubsub_dlq_messages_sent_total", Help: "Messages delivered to the DLQ.", Labels: labels}),
+		sendErrors:        metrics.NewCounter(metrics.Opts{Name: "pubsub_dlq_send_errors_total", Help: "Messages that could not be delivered to the DLQ.", Labels: labels}),
+		lastSent:          metrics.NewGauge(metrics.Opts{Name: "pubsub_dlq_last_sent_timestamp_seconds", Help: "Time of the last delivery to the DLQ.", Labels: labels}),
+		lastError:         metrics.NewGauge(metrics.Opts{Name: "pubsub_dlq_last_error_timestamp_seconds", Help: "Time of the last DLQ send error.", Labels: labels}),
+		fallbackSaved:     metrics.NewCounter(metrics.Opts{Name: "pubsub_dlq_fallback_saved_total", Help: "Messages written to the DLQ fallback file after a send error.", Labels: labels}),
+		fallbackRecovered: metrics.NewCounter(metrics.Opts{Name: "pubsub_dlq_fallback_recovered_total", Help: "Messages of the DLQ fallback file republished to the DLQ.", Labels: labels}),
+	}
+	metrics.MustRegister(m.sent, m.sendErrors, m.lastSent, m.lastError, m.fallbackSaved, m.fallbackRecovered)
+	return m
 }
 
 // NewDeadLetterQueue crée un nouveau gestionnaire de DLQ.
@@ -47,54 +130,144 @@ type DLQStats struct {
 //   - broker: L'adresse du broker Kafka.
 //   - topic: Le nom du topic DLQ.
 //   - enabled: Booléen pour activer ou désactiver la DLQ.
+//   - properties: Propriétés librdkafka supplémentaires (ex. sécurité TLS/SASL), peut être nil.
 //
 // Retourne:
 //   - *DeadLetterQueue: Une nouvelle instance initialisée.
 //   - error: Une erreur si la création du producteur échoue.
-func NewDeadLetterQueue(broker, topic string, enabled bool) (*DeadLetterQueue, error) {
+func NewDeadLetterQueue(broker, topic string, enabled bool, properties map[string]string) (*DeadLetterQueue, error) {
 	if !enabled {
 		return &DeadLetterQueue{enabled: false}, nil
 	}
 
-	producer, err := kafka.NewProducer(&kafka.ConfigMap{
-		"bootstrap.servers": broker,
-		"acks":              "all",
-	})
+	configMap := kafka.ConfigMap{"bootstrap.servers": broker}
+	for name, value := range properties {
+		configMap[name] = value
+	}
+	configMap["acks"] = "all" // la DLQ ne doit perdre aucun message
+	producer, err := kafka.NewProducer(&configMap)
 	if err != nil {
 		return nil, fmt.Errorf("échec de la création du producteur DLQ: %w", err)
 	}
+	go drainProducerEvents(producer)
+
+	return NewDeadLetterQueueWithProducer(producer, topic), nil
+}
 
+// NewDeadLetterQueueWithProducer crée une DLQ activée publiant avec un producteur existant.
+//
+// Paramètres:
+//   - producer: Le producteur Kafka (fermé par Close).
+//   - topic: Le nom du topic DLQ.
+//
+// Retourne:
+//   - *DeadLetterQueue: Une nouvelle instance initialisée.
+func NewDeadLetterQueueWithProducer(producer KafkaProducer, topic string) *DeadLetterQueue {
 	dlq := &DeadLetterQueue{
-		producer: producer,
-		topic:    topic,
-		enabled:  true,
+		producer:   producer,
+		deliveries: make(chan kafka.Event, dlqDeliveryChannelSize),
+		done:       make(chan struct{}),
+		topic:      topic,
+		enabled:    true,
+		metrics:    newDLQMetrics(topic),
 	}
+	dlq.hostname, _ = os.Hostname()
 
 	// Démarrer le gestionnaire de rapports de livraison
 	go dlq.handleDeliveryReports()
 
-	return dlq, nil
+	return dlq
+}
+
+// drainProducerEvents consomme les événements généraux d'un producteur Kafka,
+// les rapports de livraison de la DLQ passant par son propre canal.
+//
+// Paramètres:
+//   - producer: Le producteur.
+func drainProducerEvents(producer *kafka.Producer) {
+	for range producer.Events() {
+	}
 }
 
 // handleDeliveryReports traite les rapports de livraison de manière asynchrone.
 // Cette méthode tourne en tâche de fond pour mettre à jour les statistiques.
 func (d *DeadLetterQueue) handleDeliveryReports() {
-	for e := range d.producer.Events() {
-		switch ev := e.(type) {
-		case *kafka.Message:
-			d.mu.Lock()
+	defer close(d.done)
+	for e := range d.deliveries {
+		if ev, ok := e.(*kafka.Message); ok {
+			d.recordDelivery(ev.TopicPartition.Error)
 			if ev.TopicPartition.Error != nil {
-				d.stats.SendErrors++
-				d.stats.LastErrorTime = time.Now()
-			} else {
-				d.stats.MessagesSent++
-				d.stats.LastSentTime = time.Now()
+				d.saveFallback(ev.Value)
 			}
-			d.mu.Unlock()
 		}
 	}
 }
 
+// recordDelivery met à jour les statistiques après un envoi.
+//
+// Paramètres:
+//   - err: L'erreur de mise en file ou de livraison (nil si le message est livré).
+func (d *DeadLetterQueue) recordDelivery(err error) {
+	if err != nil {
+		d.metrics.sendErrors.Inc()
+		d.metrics.lastError.SetTime(time.Now())
+	} else {
+		d.metrics.sent.Inc()
+		d.metrics.lastSent.SetTime(time.Now())
+	}
+}
+
+// SetConfirmTimeout active l'envoi confirmé : Send attend alors la livraison
+// de chaque message, au plus timeout, et retourne son erreur.
+//
+// Paramètres:
+//   - timeout: L'attente maximale de la confirmation (0 : envoi asynchrone).
+func (d *DeadLetterQueue) SetConfirmTimeout(timeout time.Duration) {
+	d.confirm = timeout
+}
+
+// SetRetry relance les envois vers la DLQ et les republications du fichier de
+// secours, avant le recours au fichier de secours ou l'abandon de la reprise.
+// Les relances sont enregistrées dans DefaultStats sous dlq.send et dlq.recover.
+//
+// Paramètres:
+//   - cfg: La configuration de relance (voir ConfigFrom).
+func (d *DeadLetterQueue) SetRetry(cfg Config) {
+	d.retryCfg = cfg
+}
+
+// attempt exécute une opération de la DLQ avec ses relances.
+//
+// Paramètres:
+//   - operation: Le nom de l'opération dans DefaultStats.
+//   - fn: L'opération.
+//
+// Retourne:
+//   - error: L'erreur de la dernière tentative.
+func (d *DeadLetterQueue) attempt(operation string, fn func() error) error {
+	cfg := d.retryCfg
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.Observer != nil {
+		cfg.Observer = Observers{cfg.Observer, DefaultStats.Observer(operation)}
+	} else {
+		cfg.Observer = DefaultStats.Observer(operation)
+	}
+	return Do(context.Background(), cfg, fn).Err
+}
+
+// SetSource décrit le traitement dont la DLQ reçoit les messages en échec,
+// pour le tri des messages DLQ.
+//
+// Paramètres:
+//   - consumerGroup: Le groupe de consommateurs du traitement.
+//   - handler: Le nom du traitement (ex. order-tracker).
+//   - classifier: Le classificateur des erreurs du traitement (nil : seules les PermanentError sont permanentes).
+func (d *DeadLetterQueue) SetSource(consumerGroup, handler string, classifier Classifier) {
+	d.group, d.handler, d.classifier = consumerGroup, handler, classifier
+}
+
 // Send envoie un message échoué vers la DLQ.
 //
 // Paramètres:
@@ -103,23 +276,38 @@ func (d *DeadLetterQueue) handleDeliveryReports() {
 //   - lastErr: La dernière erreur rencontrée.
 //
 // Retourne:
-//   - error: Une erreur si la sérialisation ou l'envoi échoue.
+//   - error: Une erreur si la sérialisation ou la mise en file échoue, ou, en
+//     envoi confirmé, si la livraison échoue ou n'est pas confirmée à temps,
+//     après les relances (voir SetRetry) ; nil si le message a été écrit dans
+//     le fichier de secours (voir SetFallback).
 func (d *DeadLetterQueue) Send(originalMsg *kafka.Message, attempts int, lastErr error) error {
 	if !d.enabled {
 		return nil
 	}
 
 	// Création du message DLQ
+	failedAt := time.Now().UTC()
 	failedMsg := FailedMessage{
+		SchemaVersion:     FailedMessageSchemaVersion,
 		OriginalTopic:     *originalMsg.TopicPartition.Topic,
 		OriginalPartition: originalMsg.TopicPartition.Partition,
 		OriginalOffset:    int64(originalMsg.TopicPartition.Offset),
 		OriginalTimestamp: originalMsg.Timestamp,
-		FailedAt:          time.Now().UTC(),
+		FailedAt:          failedAt,
 		Attempts:          attempts,
 		LastError:         lastErr.Error(),
+		ErrorClass:        classify(lastErr, Config{Classifier: d.classifier}).Class(),
+		ConsumerGroup:     d.group,
+		Hostname:          d.hostname,
+		Handler:           d.handler,
+		RetryTier:         parseRetryTier(headerValue(originalMsg.Headers, HeaderRetryTier)),
+		ReprocessCount:    parseRetryTier(headerValue(originalMsg.Headers, HeaderReprocessCount)),
+		FirstSeen:         failedAt,
 		Payload:           json.RawMessage(originalMsg.Value),
 	}
+	if firstSeen, err := time.Parse(time.RFC3339Nano, headerValue(originalMsg.Headers, HeaderFirstSeen)); err == nil {
+		failedMsg.FirstSeen = firstSeen
+	}
 
 	payload, err := json.Marshal(failedMsg)
 	if err != nil {
@@ -127,16 +315,75 @@ func (d *DeadLetterQueue) Send(originalMsg *kafka.Message, attempts int, lastErr
 	}
 
 	// Envoi vers le topic DLQ
-	d.producer.Produce(&kafka.Message{
-		TopicPartition: kafka.TopicPartition{Topic: &d.topic, Partition: kafka.PartitionAny},
-		Value:          payload,
-		Headers: []kafka.Header{
-			{Key: "original-topic", Value: []byte(failedMsg.OriginalTopic)},
-			{Key: "error", Value: []byte(failedMsg.LastError)},
-			{Key: "attempts", Value: []byte(fmt.Sprintf("%d", attempts))},
-		},
-	}, nil)
+	err = d.attempt("dlq.send", func() error {
+		deliveryChan := d.deliveries
+		if d.confirm > 0 {
+			deliveryChan = make(chan kafka.Event, 1)
+		}
+		err := d.producer.Produce(&kafka.Message{
+			TopicPartition: kafka.TopicPartition{Topic: &d.topic, Partition: kafka.PartitionAny},
+			Value:          payload,
+			Headers:        failedMessageHeaders(failedMsg),
+		}, deliveryChan)
+		if err != nil {
+			d.recordDelivery(err)
+			return fmt.Errorf("échec de l'envoi vers la DLQ %s: %w", d.topic, err)
+		}
+		if d.confirm == 0 {
+			return nil
+		}
+		return d.awaitDelivery(deliveryChan)
+	})
+	if err != nil {
+		return d.fallbackOr(payload, err)
+	}
+	return nil
+}
+
+// failedMessageHeaders retourne les en-têtes d'un message DLQ, reprenant le
+// contexte d'échec de son enveloppe.
+//
+// Paramètres:
+//   - msg: L'enveloppe du message.
+//
+// Retourne:
+//   - []kafka.Header: Les en-têtes.
+func failedMessageHeaders(msg FailedMessage) []kafka.Header {
+	return []kafka.Header{
+		{Key: HeaderOriginalTopic, Value: []byte(msg.OriginalTopic)},
+		{Key: HeaderError, Value: []byte(msg.LastError)},
+		{Key: HeaderAttempts, Value: []byte(strconv.Itoa(msg.Attempts))},
+		{Key: HeaderErrorClass, Value: []byte(msg.ErrorClass)},
+		{Key: HeaderConsumerGroup, Value: []byte(msg.ConsumerGroup)},
+		{Key: HeaderHostname, Value: []byte(msg.Hostname)},
+		{Key: HeaderHandler, Value: []byte(msg.Handler)},
+		{Key: HeaderRetryTier, Value: []byte(strconv.Itoa(msg.RetryTier))},
+		{Key: HeaderFirstSeen, Value: []byte(formatRetryAt(msg.FirstSeen))},
+		{Key: HeaderReprocessCount, Value: []byte(strconv.Itoa(msg.ReprocessCount))},
+	}
+}
 
+// awaitDelivery attend la confirmation de livraison d'un envoi confirmé.
+//
+// Paramètres:
+//   - deliveryChan: Le canal de livraison du message.
+//
+// Retourne:
+//   - error: Une erreur si la livraison échoue ou n'est pas confirmée avant le délai.
+func (d *DeadLetterQueue) awaitDelivery(deliveryChan chan kafka.Event) error {
+	var err error
+	select {
+	case e := <-deliveryChan:
+		if m, ok := e.(*kafka.Message); ok {
+			err = m.TopicPartition.Error
+		}
+	case <-time.After(d.confirm):
+		err = fmt.Errorf("livraison non confirmée après %s", d.confirm)
+	}
+	d.recordDelivery(err)
+	if err != nil {
+		return fmt.Errorf("échec de la livraison vers la DLQ %s: %w", d.topic, err)
+	}
 	return nil
 }
 
@@ -145,9 +392,17 @@ func (d *DeadLetterQueue) Send(originalMsg *kafka.Message, attempts int, lastErr
 // Retourne:
 //   - DLQStats: Les statistiques d'utilisation de la DLQ.
 func (d *DeadLetterQueue) GetStats() DLQStats {
-	d.mu.Lock()
-	defer d.mu.Unlock()
-	return d.stats
+	if d.metrics == nil {
+		return DLQStats{}
+	}
+	return DLQStats{
+		MessagesSent:      int64(d.metrics.sent.Value()),
+		SendErrors:        int64(d.metrics.sendErrors.Value()),
+		LastSentTime:      d.metrics.lastSent.Time(),
+		LastErrorTime:     d.metrics.lastError.Time(),
+		FallbackSaved:     int64(d.metrics.fallbackSaved.Value()),
+		FallbackRecovered: int64(d.metrics.fallbackRecovered.Value()),
+	}
 }
 
 // Close ferme proprement le producteur DLQ.
@@ -156,13 +411,41 @@ func (d *DeadLetterQueue) Close() {
 	if d.producer != nil {
 		d.producer.Flush(5000)
 		d.producer.Close()
+		close(d.deliveries)
+		<-d.done
 	}
 }
 
 // IsEnabled retourne si la DLQ est activée.
 //
 // Retourne:
-//   - bool: Vrai si la DLQ est activée.
+//   - bool: Vrai si la DLQ est activée (faux pour une DLQ nil).
 func (d *DeadLetterQueue) IsEnabled() bool {
-	return d.enabled
+	return d != nil && d.enabled
+}
+
+// Topic retourne le nom du topic DLQ.
+//
+// Retourne:
+//   - string: Le topic ("" si la DLQ est désactivée).
+func (d *DeadLetterQueue) Topic() string {
+	return d.topic
+}
+
+// headerValue retourne la valeur d'un en-tête.
+//
+// Paramètres:
+//   - headers: Les en-têtes du message.
+//   - key: Le nom de l'en-tête.
+//
+// Retourne:
+//   - string: La valeur de la dernière occurrence ("" si absent).
+func headerValue(headers []kafka.Header, key string) string {
+	value := ""
+	for _, h := range headers {
+		if h.Key == key {
+			value = string(h.Value)
+		}
+	}
+	return value
 }
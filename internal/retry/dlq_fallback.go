@@ -0,0 +1,231 @@
+package retry
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// fallbackDeliveryTimeout est l'attente maximale de la livraison d'un message
+// republié depuis le fichier de secours.
+const fallbackDeliveryTimeout = 10 * time.Second
+
+// SetFallback active le fichier de secours : un message que la DLQ ne parvient
+// pas à publier (broker indisponible, livraison en échec) y est ajouté, une
+// enveloppe JSON par ligne, puis republié par RecoverFallback au retour du
+// broker. Un envoi confirmé non confirmé à temps peut ainsi être publié deux
+// fois.
+//
+// Paramètres:
+//   - path: Le chemin du fichier de secours ("" : désactivé).
+func (d *DeadLetterQueue) SetFallback(path string) {
+	d.fallback = path
+}
+
+// fallbackOr écrit un message non publié dans le fichier de secours.
+//
+// Paramètres:
+//   - payload: L'enveloppe JSON du message.
+//   - sendErr: L'erreur d'envoi.
+//
+// Retourne:
+//   - error: nil si le message a été écrit, sinon sendErr, accompagnée de l'erreur d'écriture.
+func (d *DeadLetterQueue) fallbackOr(payload []byte, sendErr error) error {
+	if d.fallback == "" {
+		return sendErr
+	}
+	if err := d.saveFallback(payload); err != nil {
+		return errors.Join(sendErr, err)
+	}
+	return nil
+}
+
+// saveFallback ajoute un message au fichier de secours.
+//
+// Paramètres:
+//   - payload: L'enveloppe JSON du message.
+//
+// Retourne:
+//   - error: Une erreur si le fichier ne peut pas être écrit (nil si le secours est désactivé).
+func (d *DeadLetterQueue) saveFallback(payload []byte) error {
+	if d.fallback == "" {
+		return nil
+	}
+	d.fallbackMu.Lock()
+	defer d.fallbackMu.Unlock()
+
+	file, err := os.OpenFile(d.fallback, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("impossible d'ouvrir le fichier de secours de la DLQ: %w", err)
+	}
+	_, err = file.Write(append(bytes.TrimSpace(payload), '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("impossible d'écrire dans le fichier de secours de la DLQ: %w", err)
+	}
+
+	d.metrics.fallbackSaved.Inc()
+	return nil
+}
+
+// RecoverFallback republie dans la DLQ les messages du fichier de secours, dans
+// leur ordre d'écriture, en attendant la livraison de chacun. La reprise
+// s'arrête au premier message dont les relances (voir SetRetry) échouent ; les messages restants sont conservés dans le
+// fichier, supprimé une fois vidé.
+//
+// Retourne:
+//   - int: Le nombre de messages republiés.
+//   - error: Une erreur si le fichier ne peut pas être lu ou réécrit, ou si une republication échoue.
+func (d *DeadLetterQueue) RecoverFallback() (int, error) {
+	if !d.IsEnabled() || d.fallback == "" {
+		return 0, nil
+	}
+	d.fallbackMu.Lock()
+	defer d.fallbackMu.Unlock()
+
+	lines, err := readFallback(d.fallback)
+	if err != nil || len(lines) == 0 {
+		return 0, err
+	}
+	recovered := 0
+	var sendErr error
+	for _, line := range lines {
+		if sendErr = d.attempt("dlq.recover", func() error { return d.republish(line) }); sendErr != nil {
+			break
+		}
+		recovered++
+	}
+
+	d.metrics.fallbackRecovered.Add(float64(recovered))
+
+	if err := writeFallback(d.fallback, lines[recovered:]); err != nil {
+		return recovered, err
+	}
+	return recovered, sendErr
+}
+
+// RunFallbackRecovery vide le fichier de secours au démarrage puis à chaque
+// intervalle, jusqu'à l'annulation du contexte.
+//
+// Paramètres:
+//   - ctx: Le contexte d'arrêt.
+//   - interval: L'intervalle entre deux reprises.
+//   - onRecover: Appelé après chaque reprise ayant republié un message ou échoué (peut être nil).
+func (d *DeadLetterQueue) RunFallbackRecovery(ctx context.Context, interval time.Duration, onRecover func(recovered int, err error)) {
+	if !d.IsEnabled() || d.fallback == "" || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if recovered, err := d.RecoverFallback(); (recovered > 0 || err != nil) && onRecover != nil {
+			onRecover(recovered, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// republish publie une enveloppe du fichier de secours et attend sa livraison.
+//
+// Paramètres:
+//   - payload: L'enveloppe JSON du message.
+//
+// Retourne:
+//   - error: Une erreur si la publication ou la livraison échoue.
+func (d *DeadLetterQueue) republish(payload []byte) error {
+	var headers []kafka.Header
+	if msg, err := DecodeFailedMessage(payload); err == nil {
+		headers = failedMessageHeaders(msg)
+	}
+	timeout := fallbackDeliveryTimeout
+	if d.confirm > 0 {
+		timeout = d.confirm
+	}
+
+	delivery := make(chan kafka.Event, 1)
+	err := d.producer.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &d.topic, Partition: kafka.PartitionAny},
+		Value:          payload,
+		Headers:        headers,
+	}, delivery)
+	if err == nil {
+		select {
+		case e := <-delivery:
+			if m, ok := e.(*kafka.Message); ok {
+				err = m.TopicPartition.Error
+			}
+		case <-time.After(timeout):
+			err = fmt.Errorf("livraison non confirmée après %s", timeout)
+		}
+	}
+	d.recordDelivery(err)
+	if err != nil {
+		return fmt.Errorf("échec de la reprise vers la DLQ %s: %w", d.topic, err)
+	}
+	return nil
+}
+
+// readFallback lit les messages du fichier de secours.
+//
+// Paramètres:
+//   - path: Le chemin du fichier.
+//
+// Retourne:
+//   - [][]byte: Les enveloppes, dans leur ordre d'écriture (aucune si le fichier n'existe pas).
+//   - error: Une erreur si le fichier ne peut pas être lu.
+func readFallback(path string) ([][]byte, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("impossible de lire le fichier de secours de la DLQ: %w", err)
+	}
+	var lines [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+			lines = append(lines, append([]byte(nil), line...))
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// writeFallback remplace le fichier de secours par les messages restants, ou
+// le supprime s'il n'en reste aucun.
+//
+// Paramètres:
+//   - path: Le chemin du fichier.
+//   - lines: Les enveloppes restantes.
+//
+// Retourne:
+//   - error: Une erreur si le fichier ne peut pas être réécrit ou supprimé.
+func writeFallback(path string, lines [][]byte) error {
+	if len(lines) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("impossible de supprimer le fichier de secours de la DLQ: %w", err)
+		}
+		return nil
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(bytes.Join(lines, []byte("\n")), '\n'), 0644); err != nil {
+		return fmt.Errorf("impossible de réécrire le fichier de secours de la DLQ: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("impossible de réécrire le fichier de secours de la DLQ: %w", err)
+	}
+	return nil
+}
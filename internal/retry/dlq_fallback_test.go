@@ -0,0 +1,122 @@
+package retry
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDeadLetterQueueFallback vérifie l'écriture d'un message non publié dans
+// le fichier de secours puis sa reprise au retour du broker.
+func TestDeadLetterQueueFallback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dlq-fallback.events")
+	producer := new(MockKafkaProducer)
+	brokerDown := kafka.NewError(kafka.ErrAllBrokersDown, "brokers down", false)
+	producer.On("Produce", mock.Anything, mock.Anything).Return(brokerDown).Twice()
+	dlq := NewDeadLetterQueueWithProducer(producer, "orders-dlq")
+	dlq.SetFallback(path)
+
+	require.NoError(t, dlq.Send(failedKafkaMessage(), 3, errors.New("json invalide")))
+	require.NoError(t, dlq.Send(failedKafkaMessage(), 1, errors.New("timeout")))
+	lines, err := readFallback(path)
+	require.NoError(t, err)
+	assert.Len(t, lines, 2)
+
+	// Broker toujours indisponible : le fichier est conservé
+	producer.On("Produce", mock.Anything, mock.Anything).Return(brokerDown).Once()
+	recovered, err := dlq.RecoverFallback()
+	assert.ErrorIs(t, err, brokerDown)
+	assert.Zero(t, recovered)
+
+	producer.On("Produce", mock.Anything, mock.Anything).Return(nil)
+	recovered, err = dlq.RecoverFallback()
+	require.NoError(t, err)
+	assert.Equal(t, 2, recovered)
+	assert.NoFileExists(t, path)
+
+	republished := producer.Calls[len(producer.Calls)-1].Arguments.Get(0).(*kafka.Message)
+	assert.Equal(t, "orders-dlq", *republished.TopicPartition.Topic)
+	assert.Equal(t, "timeout", headerValue(republished.Headers, HeaderError))
+	failed, err := DecodeFailedMessage(republished.Value)
+	require.NoError(t, err)
+	assert.Equal(t, 1, failed.Attempts)
+
+	stats := dlq.GetStats()
+	assert.Equal(t, int64(2), stats.FallbackSaved)
+	assert.Equal(t, int64(2), stats.FallbackRecovered)
+	assert.Equal(t, int64(2), stats.MessagesSent)
+}
+
+// TestDeadLetterQueueRetry vérifie que les envois et les reprises sont relancés
+// avant le recours au fichier de secours, et enregistrés dans DefaultStats.
+func TestDeadLetterQueueRetry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dlq-fallback.events")
+	brokerDown := kafka.NewError(kafka.ErrAllBrokersDown, "brokers down", false)
+	newDLQ := func() *DeadLetterQueue {
+		producer := new(MockKafkaProducer)
+		producer.On("Produce", mock.Anything, mock.Anything).Return(brokerDown).Once()
+		producer.On("Produce", mock.Anything, mock.Anything).Return(nil)
+		dlq := NewDeadLetterQueueWithProducer(producer, "orders-dlq")
+		dlq.SetFallback(path)
+		dlq.SetRetry(Config{MaxAttempts: 2, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, Multiplier: 1})
+		return dlq
+	}
+
+	before := DefaultStats.Get("dlq.send")
+	require.NoError(t, newDLQ().Send(failedKafkaMessage(), 1, errors.New("timeout")))
+	assert.NoFileExists(t, path)
+	after := DefaultStats.Get("dlq.send")
+	assert.Equal(t, int64(2), after.Attempts-before.Attempts)
+	assert.Equal(t, int64(1), after.Successes-before.Successes)
+
+	require.NoError(t, writeFallback(path, [][]byte{[]byte(`{"original_topic":"orders"}`)}))
+	before = DefaultStats.Get("dlq.recover")
+	recovered, err := newDLQ().RecoverFallback()
+	require.NoError(t, err)
+	assert.Equal(t, 1, recovered)
+	assert.NoFileExists(t, path)
+	assert.Equal(t, int64(2), DefaultStats.Get("dlq.recover").Attempts-before.Attempts)
+}
+
+// TestDeadLetterQueueFallbackDeliveryError vérifie qu'une livraison asynchrone
+// en échec est écrite dans le fichier de secours.
+func TestDeadLetterQueueFallbackDeliveryError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dlq-fallback.events")
+	producer := &MockKafkaProducer{deliveryErr: kafka.NewError(kafka.ErrMsgTimedOut, "timeout", false)}
+	producer.On("Produce", mock.Anything, mock.Anything).Return(nil)
+	producer.On("Flush", 5000).Return(0)
+	producer.On("Close").Return()
+	dlq := NewDeadLetterQueueWithProducer(producer, "orders-dlq")
+	dlq.SetFallback(path)
+
+	require.NoError(t, dlq.Send(failedKafkaMessage(), 1, errors.New("ko")))
+	dlq.Close()
+
+	lines, err := readFallback(path)
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	failed, err := DecodeFailedMessage(lines[0])
+	require.NoError(t, err)
+	assert.Equal(t, "orders", failed.OriginalTopic)
+}
+
+// TestDeadLetterQueueFallbackUnwritable vérifie que l'erreur d'envoi est
+// retournée si le fichier de secours ne peut pas être écrit.
+func TestDeadLetterQueueFallbackUnwritable(t *testing.T) {
+	producer := new(MockKafkaProducer)
+	queueFull := kafka.NewError(kafka.ErrQueueFull, "queue full", false)
+	producer.On("Produce", mock.Anything, mock.Anything).Return(queueFull)
+	dlq := NewDeadLetterQueueWithProducer(producer, "orders-dlq")
+	dlq.SetFallback(filepath.Join(t.TempDir(), "absent", "dlq-fallback.events"))
+
+	err := dlq.Send(failedKafkaMessage(), 1, errors.New("ko"))
+	assert.ErrorIs(t, err, queueFull)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,171 @@
+package retry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockKafkaProducer est un mock pour l'interface KafkaProducer. Chaque message
+// produit est livré sur le canal de livraison, avec l'erreur deliveryErr, sauf
+// si undelivered est vrai.
+type MockKafkaProducer struct {
+	mock.Mock
+	deliveryErr error
+	undelivered bool
+}
+
+func (m *MockKafkaProducer) Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error {
+	args := m.Called(msg, deliveryChan)
+	if args.Error(0) == nil && deliveryChan != nil && !m.undelivered {
+		delivered := *msg
+		delivered.TopicPartition.Error = m.deliveryErr
+		deliveryChan <- &delivered
+	}
+	return args.Error(0)
+}
+
+func (m *MockKafkaProducer) Flush(timeoutMs int) int {
+	args := m.Called(timeoutMs)
+	return args.Int(0)
+}
+
+func (m *MockKafkaProducer) Close() {
+	m.Called()
+}
+
+// failedKafkaMessage retourne un message du topic orders en échec.
+func failedKafkaMessage() *kafka.Message {
+	topic := "orders"
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: 2, Offset: 42},
+		Value:          []byte(`{"order_id":"a"}`),
+	}
+}
+
+// TestDeadLetterQueueSend vérifie l'enveloppe, les en-têtes et les statistiques d'un envoi.
+func TestDeadLetterQueueSend(t *testing.T) {
+	producer := new(MockKafkaProducer)
+	producer.On("Produce", mock.Anything, mock.Anything).Return(nil)
+	producer.On("Flush", 5000).Return(0)
+	producer.On("Close").Return()
+	dlq := NewDeadLetterQueueWithProducer(producer, "orders-dlq")
+	dlq.SetSource("order-tracker-group", "order-tracker", DefaultClassifier)
+
+	firstSeen := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	msg := failedKafkaMessage()
+	msg.Headers = []kafka.Header{
+		{Key: HeaderRetryTier, Value: []byte("2")},
+		{Key: HeaderFirstSeen, Value: []byte(formatRetryAt(firstSeen))},
+	}
+	require.NoError(t, dlq.Send(msg, 3, errors.New("json invalide")))
+	dlq.Close()
+	producer.AssertExpectations(t)
+
+	sent := producer.Calls[0].Arguments.Get(0).(*kafka.Message)
+	assert.Equal(t, "orders-dlq", *sent.TopicPartition.Topic)
+	failed, err := DecodeFailedMessage(sent.Value)
+	require.NoError(t, err)
+	assert.Equal(t, "orders", failed.OriginalTopic)
+	assert.Equal(t, int32(2), failed.OriginalPartition)
+	assert.Equal(t, int64(42), failed.OriginalOffset)
+	assert.Equal(t, 3, failed.Attempts)
+	assert.Equal(t, "json invalide", failed.LastError)
+	assert.JSONEq(t, `{"order_id":"a"}`, string(failed.Payload))
+	assert.Equal(t, ErrorClassTransient, failed.ErrorClass)
+	assert.Equal(t, "order-tracker-group", failed.ConsumerGroup)
+	assert.Equal(t, "order-tracker", failed.Handler)
+	assert.NotEmpty(t, failed.Hostname)
+	assert.Equal(t, FailedMessageSchemaVersion, failed.SchemaVersion)
+	assert.Equal(t, 2, failed.RetryTier)
+	assert.True(t, firstSeen.Equal(failed.FirstSeen))
+
+	assert.Equal(t, "3", headerValue(sent.Headers, HeaderAttempts))
+	assert.Equal(t, ErrorClassTransient, headerValue(sent.Headers, HeaderErrorClass))
+	assert.Equal(t, "order-tracker-group", headerValue(sent.Headers, HeaderConsumerGroup))
+	assert.Equal(t, "order-tracker", headerValue(sent.Headers, HeaderHandler))
+	assert.Equal(t, failed.Hostname, headerValue(sent.Headers, HeaderHostname))
+	assert.Equal(t, "2", headerValue(sent.Headers, HeaderRetryTier))
+	assert.Equal(t, formatRetryAt(firstSeen), headerValue(sent.Headers, HeaderFirstSeen))
+
+	stats := dlq.GetStats()
+	assert.Equal(t, int64(1), stats.MessagesSent)
+	assert.Zero(t, stats.SendErrors)
+	assert.WithinDuration(t, time.Now(), stats.LastSentTime, time.Second)
+}
+
+// TestDeadLetterQueueDeliveryError vérifie le décompte des livraisons en échec.
+func TestDeadLetterQueueDeliveryError(t *testing.T) {
+	producer := &MockKafkaProducer{deliveryErr: kafka.NewError(kafka.ErrMsgTimedOut, "timeout", false)}
+	producer.On("Produce", mock.Anything, mock.Anything).Return(nil)
+	producer.On("Flush", 5000).Return(0)
+	producer.On("Close").Return()
+	dlq := NewDeadLetterQueueWithProducer(producer, "orders-dlq")
+
+	require.NoError(t, dlq.Send(failedKafkaMessage(), 1, errors.New("timeout")))
+	dlq.Close()
+
+	sent := producer.Calls[0].Arguments.Get(0).(*kafka.Message)
+	failed, err := DecodeFailedMessage(sent.Value)
+	require.NoError(t, err)
+	assert.Zero(t, failed.RetryTier)
+	assert.Equal(t, failed.FailedAt, failed.FirstSeen, "Expected first_seen to default to failed_at")
+
+	stats := dlq.GetStats()
+	assert.Zero(t, stats.MessagesSent)
+	assert.Equal(t, int64(1), stats.SendErrors)
+}
+
+// TestDeadLetterQueueProduceError vérifie la propagation et le décompte d'un échec de mise en file.
+func TestDeadLetterQueueProduceError(t *testing.T) {
+	producer := new(MockKafkaProducer)
+	queueFull := kafka.NewError(kafka.ErrQueueFull, "queue full", false)
+	producer.On("Produce", mock.Anything, mock.Anything).Return(queueFull)
+	dlq := NewDeadLetterQueueWithProducer(producer, "orders-dlq")
+
+	err := dlq.Send(failedKafkaMessage(), 1, errors.New("ko"))
+	assert.ErrorIs(t, err, queueFull)
+	stats := dlq.GetStats()
+	assert.Equal(t, int64(1), stats.SendErrors)
+	assert.False(t, stats.LastErrorTime.IsZero())
+}
+
+// TestDeadLetterQueueConfirmedSend vérifie que l'envoi confirmé attend et retourne le sort de la livraison.
+func TestDeadLetterQueueConfirmedSend(t *testing.T) {
+	newDLQ := func(producer *MockKafkaProducer) *DeadLetterQueue {
+		producer.On("Produce", mock.Anything, mock.Anything).Return(nil)
+		dlq := NewDeadLetterQueueWithProducer(producer, "orders-dlq")
+		dlq.SetConfirmTimeout(20 * time.Millisecond)
+		return dlq
+	}
+
+	delivered := newDLQ(new(MockKafkaProducer))
+	require.NoError(t, delivered.Send(failedKafkaMessage(), 1, errors.New("ko")))
+	assert.Equal(t, int64(1), delivered.GetStats().MessagesSent)
+
+	deliveryErr := kafka.NewError(kafka.ErrMsgTimedOut, "timeout", false)
+	failed := newDLQ(&MockKafkaProducer{deliveryErr: deliveryErr})
+	assert.ErrorIs(t, failed.Send(failedKafkaMessage(), 1, errors.New("ko")), deliveryErr)
+	assert.Equal(t, int64(1), failed.GetStats().SendErrors)
+
+	unconfirmed := newDLQ(&MockKafkaProducer{undelivered: true})
+	assert.ErrorContains(t, unconfirmed.Send(failedKafkaMessage(), 1, errors.New("ko")), "non confirmée")
+	assert.Equal(t, int64(1), unconfirmed.GetStats().SendErrors)
+}
+
+// TestDeadLetterQueueDisabled vérifie qu'une DLQ désactivée ne publie rien.
+func TestDeadLetterQueueDisabled(t *testing.T) {
+	dlq, err := NewDeadLetterQueue("localhost:9092", "orders-dlq", false, nil)
+	require.NoError(t, err)
+	assert.False(t, dlq.IsEnabled())
+	assert.NoError(t, dlq.Send(failedKafkaMessage(), 1, errors.New("ko")))
+	dlq.Close()
+
+	var none *DeadLetterQueue
+	assert.False(t, none.IsEnabled())
+}
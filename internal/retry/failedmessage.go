@@ -0,0 +1,102 @@
+package retry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// FailedMessageSchemaVersion est la version du format de l'enveloppe DLQ
+// écrite par cette version (schema_version absent = 1, l'enveloppe sans classe
+// d'erreur ni contexte d'échec).
+const FailedMessageSchemaVersion = 2
+
+// FailedMessage représente un message qui a échoué lors du traitement. C'est
+// l'enveloppe JSON des messages du topic DLQ.
+type FailedMessage struct {
+	SchemaVersion     int             `json:"schema_version"`     // La version du format de l'enveloppe (voir FailedMessageSchemaVersion).
+	OriginalTopic     string          `json:"original_topic"`     // Le sujet Kafka d'origine.
+	OriginalPartition int32           `json:"original_partition"` // La partition Kafka d'origine.
+	OriginalOffset    int64           `json:"original_offset"`    // Le décalage (offset) d'origine.
+	OriginalTimestamp time.Time       `json:"original_timestamp"` // L'horodatage d'origine du message.
+	FailedAt          time.Time       `json:"failed_at"`          // L'heure de l'échec.
+	Attempts          int             `json:"attempts"`           // Le nombre de tentatives effectuées.
+	LastError         string          `json:"last_error"`         // Le dernier message d'erreur rencontré.
+	ErrorClass        string          `json:"error_class"`        // La classe de l'erreur (permanent, throttled ou transient).
+	ConsumerGroup     string          `json:"consumer_group"`     // Le groupe de consommateurs du traitement en échec.
+	Hostname          string          `json:"hostname"`           // La machine du traitement en échec.
+	Handler           string          `json:"handler"`            // Le nom du traitement en échec.
+	RetryTier         int             `json:"retry_tier"`         // Le dernier palier de relance traversé (0 : aucun).
+	FirstSeen         time.Time       `json:"first_seen"`         // L'heure du premier échec du message.
+	ReprocessCount    int             `json:"reprocess_count"`    // Le nombre de retraitements automatiques depuis la DLQ (voir Reprocessor).
+	Payload           json.RawMessage `json:"payload"`            // Le contenu brut du message.
+}
+
+// Validate vérifie la cohérence de l'enveloppe.
+//
+// Retourne:
+//   - error: Une erreur si la version n'est pas prise en charge ou si un champ est absent ou invalide.
+func (m *FailedMessage) Validate() error {
+	switch {
+	case m.SchemaVersion < 1 || m.SchemaVersion > FailedMessageSchemaVersion:
+		return fmt.Errorf("schema_version %d non prise en charge (1 à %d)", m.SchemaVersion, FailedMessageSchemaVersion)
+	case m.OriginalTopic == "":
+		return errors.New("original_topic absent")
+	case m.OriginalPartition < 0:
+		return fmt.Errorf("original_partition négative: %d", m.OriginalPartition)
+	case m.OriginalOffset < 0:
+		return fmt.Errorf("original_offset négatif: %d", m.OriginalOffset)
+	case m.Attempts < 0:
+		return fmt.Errorf("attempts négatif: %d", m.Attempts)
+	case m.RetryTier < 0:
+		return fmt.Errorf("retry_tier négatif: %d", m.RetryTier)
+	case m.ReprocessCount < 0:
+		return fmt.Errorf("reprocess_count négatif: %d", m.ReprocessCount)
+	}
+	switch m.ErrorClass {
+	case "", ErrorClassPermanent, ErrorClassThrottled, ErrorClassTransient:
+		return nil
+	}
+	return fmt.Errorf("error_class inconnue: %q", m.ErrorClass)
+}
+
+// DecodeFailedMessage lit l'enveloppe d'un message du topic DLQ, la migre vers
+// FailedMessageSchemaVersion et la valide. Une enveloppe d'une version plus
+// récente que ce binaire est refusée.
+//
+// Paramètres:
+//   - data: La valeur du message DLQ.
+//
+// Retourne:
+//   - FailedMessage: Le message échoué, au format courant.
+//   - error: Une erreur si la valeur n'est pas une enveloppe DLQ valide.
+func DecodeFailedMessage(data []byte) (FailedMessage, error) {
+	var msg FailedMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return FailedMessage{}, fmt.Errorf("message DLQ invalide: %w", err)
+	}
+	if msg.SchemaVersion == 0 {
+		msg.SchemaVersion = 1
+	}
+	if err := msg.Validate(); err != nil {
+		return FailedMessage{}, fmt.Errorf("message DLQ invalide: %w", err)
+	}
+	migrateFailedMessage(&msg)
+	return msg, nil
+}
+
+// migrateFailedMessage complète une enveloppe d'une version antérieure avec
+// les valeurs par défaut des champs ajoutés depuis.
+//
+// Paramètres:
+//   - msg: L'enveloppe validée, migrée en place.
+func migrateFailedMessage(msg *FailedMessage) {
+	if msg.SchemaVersion < 2 {
+		// Version 2 : contexte d'échec ; le premier échec est au mieux celui de l'envoi en DLQ
+		if msg.FirstSeen.IsZero() {
+			msg.FirstSeen = msg.FailedAt
+		}
+	}
+	msg.SchemaVersion = FailedMessageSchemaVersion
+}
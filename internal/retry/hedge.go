@@ -0,0 +1,71 @@
+package retry
+
+import (
+	"context"
+	"time"
+)
+
+// Hedge exécute fn et, si elle n'a pas abouti après delay, lance une seconde
+// tentative en parallèle (requête couverte) pour limiter la latence de queue
+// d'appels idempotents (lectures, statistiques). Le premier succès est retourné
+// et la tentative perdante est annulée via son contexte. Si la première
+// tentative échoue avant delay, la seconde est lancée immédiatement ; une
+// erreur permanente (voir Permanent) est retournée sans attendre l'autre
+// tentative.
+//
+// Paramètres:
+//   - ctx: Le contexte pour l'annulation et les délais.
+//   - delay: Le délai avant le lancement de la seconde tentative.
+//   - fn: La fonction à exécuter, qui doit respecter le contexte reçu.
+//
+// Retourne:
+//   - T: La valeur de la première tentative réussie (valeur zéro de T en cas d'échec).
+//   - error: L'erreur de la dernière tentative échouée, ou celle du contexte.
+func Hedge[T any](ctx context.Context, delay time.Duration, fn func(ctx context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // annule la tentative perdante
+
+	type outcome struct {
+		value T
+		err   error
+	}
+	results := make(chan outcome, 2)
+	launched, pending := 0, 0
+	launch := func() {
+		launched++
+		pending++
+		go func() {
+			v, err := fn(ctx)
+			results <- outcome{value: v, err: err}
+		}()
+	}
+
+	launch()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var zero T
+	for {
+		select {
+		case <-timer.C:
+			if launched < 2 {
+				launch()
+			}
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				return r.value, nil
+			}
+			if IsPermanent(r.err) {
+				return zero, r.err
+			}
+			if launched < 2 {
+				launch()
+			} else if pending == 0 {
+				return zero, r.err
+			}
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+	}
+}
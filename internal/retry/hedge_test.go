@@ -0,0 +1,65 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHedgeSecondAttemptWins vérifie que la tentative couverte l'emporte sur une tentative lente, annulée ensuite.
+func TestHedgeSecondAttemptWins(t *testing.T) {
+	var calls atomic.Int32
+	loserCancelled := make(chan struct{})
+	value, err := Hedge(context.Background(), 10*time.Millisecond, func(ctx context.Context) (string, error) {
+		if calls.Add(1) == 1 {
+			<-ctx.Done()
+			close(loserCancelled)
+			return "", ctx.Err()
+		}
+		return "hedged", nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hedged", value)
+	select {
+	case <-loserCancelled:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the slow attempt to be cancelled")
+	}
+}
+
+// TestHedgeFastAttempt vérifie qu'aucune seconde tentative n'est lancée si la première aboutit à temps.
+func TestHedgeFastAttempt(t *testing.T) {
+	var calls atomic.Int32
+	value, err := Hedge(context.Background(), time.Second, func(context.Context) (int, error) {
+		calls.Add(1)
+		return 42, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, value)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+// TestHedgeFailures vérifie la relance immédiate après un échec et l'abandon sur erreur permanente.
+func TestHedgeFailures(t *testing.T) {
+	var calls atomic.Int32
+	_, err := Hedge(context.Background(), time.Second, func(context.Context) (int, error) {
+		calls.Add(1)
+		return 0, errors.New("ko")
+	})
+	assert.EqualError(t, err, "ko")
+	assert.Equal(t, int32(2), calls.Load(), "Expected the hedged attempt to start right after the failure")
+
+	calls.Store(0)
+	_, err = Hedge(context.Background(), time.Second, func(context.Context) (int, error) {
+		calls.Add(1)
+		return 0, Permanent(errors.New("invalide"))
+	})
+	assert.True(t, IsPermanent(err))
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,72 @@
+package retry
+
+import "time"
+
+// Observer est notifié du déroulement de chaque opération relancée par Do,
+// DoContext, DoWithCallback ou DoValue (voir Config.Observer), pour y brancher
+// uniformément compteurs, journalisation structurée ou traces. Les méthodes
+// sont appelées de façon synchrone dans la boucle de relance et doivent rester
+// rapides.
+type Observer interface {
+	// OnAttempt est appelée avant chaque tentative (à partir de 1).
+	OnAttempt(attempt int)
+	// OnRetry est appelée après une tentative échouée, avant l'attente de la relance.
+	OnRetry(attempt int, err error, delay time.Duration)
+	// OnGiveUp est appelée quand l'opération échoue définitivement : erreur
+	// permanente, tentatives ou budget épuisés, contexte annulé.
+	OnGiveUp(result Result)
+}
+
+// SuccessObserver est implémentée par les observateurs notifiés aussi des
+// opérations réussies (ex. StatsRegistry).
+type SuccessObserver interface {
+	// OnSuccess est appelée quand l'opération réussit.
+	OnSuccess(result Result)
+}
+
+// Observers combine plusieurs observateurs, notifiés dans l'ordre.
+type Observers []Observer
+
+// OnAttempt notifie chaque observateur.
+//
+// Paramètres:
+//   - attempt: Le numéro de la tentative.
+func (o Observers) OnAttempt(attempt int) {
+	for _, obs := range o {
+		obs.OnAttempt(attempt)
+	}
+}
+
+// OnRetry notifie chaque observateur.
+//
+// Paramètres:
+//   - attempt: Le numéro de la tentative échouée.
+//   - err: L'erreur de la tentative.
+//   - delay: Le délai avant la relance.
+func (o Observers) OnRetry(attempt int, err error, delay time.Duration) {
+	for _, obs := range o {
+		obs.OnRetry(attempt, err, delay)
+	}
+}
+
+// OnGiveUp notifie chaque observateur.
+//
+// Paramètres:
+//   - result: Le résultat de l'opération abandonnée.
+func (o Observers) OnGiveUp(result Result) {
+	for _, obs := range o {
+		obs.OnGiveUp(result)
+	}
+}
+
+// OnSuccess notifie les observateurs implémentant SuccessObserver.
+//
+// Paramètres:
+//   - result: Le résultat de l'opération réussie.
+func (o Observers) OnSuccess(result Result) {
+	for _, obs := range o {
+		if s, ok := obs.(SuccessObserver); ok {
+			s.OnSuccess(result)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingObserver consigne les notifications reçues.
+type recordingObserver struct {
+	events []string
+}
+
+func (r *recordingObserver) OnAttempt(attempt int) {
+	r.events = append(r.events, fmt.Sprintf("attempt %d", attempt))
+}
+
+func (r *recordingObserver) OnRetry(attempt int, err error, delay time.Duration) {
+	r.events = append(r.events, fmt.Sprintf("retry %d: %v", attempt, err))
+}
+
+func (r *recordingObserver) OnGiveUp(result Result) {
+	r.events = append(r.events, fmt.Sprintf("give up after %d: %v", result.Attempts, result.Err))
+}
+
+// TestObserverNotifications vérifie les notifications d'une opération abandonnée puis réussie.
+func TestObserverNotifications(t *testing.T) {
+	first, second := &recordingObserver{}, &recordingObserver{}
+	cfg := DefaultConfig()
+	cfg.MaxAttempts = 2
+	cfg.InitialDelay = time.Millisecond
+	cfg.Observer = Observers{first, second}
+
+	result := Do(context.Background(), cfg, func() error { return errors.New("ko") })
+	assert.Error(t, result.Err)
+	want := []string{"attempt 1", "retry 1: ko", "attempt 2", "give up after 2: ko"}
+	assert.Equal(t, want, first.events)
+	assert.Equal(t, want, second.events)
+
+	succeeded := &recordingObserver{}
+	cfg.Observer = succeeded
+	calls := 0
+	_, result = DoValue(context.Background(), cfg, func() (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, errors.New("ko")
+		}
+		return 42, nil
+	})
+	assert.NoError(t, result.Err)
+	assert.Equal(t, []string{"attempt 1", "retry 1: ko", "attempt 2"}, succeeded.events)
+}
+
+// TestObserverPermanentError vérifie qu'une erreur permanente est abandonnée sans relance.
+func TestObserverPermanentError(t *testing.T) {
+	obs := &recordingObserver{}
+	cfg := DefaultConfig()
+	cfg.Observer = obs
+
+	DoContext(context.Background(), cfg, func(context.Context) error { return Permanent(errors.New("invalide")) })
+	assert.Equal(t, []string{"attempt 1", "give up after 1: invalide"}, obs.events)
+}
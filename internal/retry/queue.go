@@ -0,0 +1,295 @@
+package retry
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrQueueFull est retournée par RetryQueue.Push quand la file a atteint sa capacité.
+var ErrQueueFull = errors.New("file de relance pleine")
+
+// defaultQueueCapacity est la capacité d'une RetryQueue sans QueueOptions.Capacity.
+const defaultQueueCapacity = 1000
+
+// QueueOptions contient le dimensionnement d'une RetryQueue.
+type QueueOptions struct {
+	Capacity    int           // Éléments en attente au plus (0 : 1000).
+	MinInterval time.Duration // Intervalle minimal entre deux re-livraisons (0 : aucune limite).
+}
+
+// queueEntry est un élément en attente de sa prochaine tentative.
+type queueEntry[T any] struct {
+	item    T
+	attempt int           // Tentatives déjà effectuées.
+	delay   time.Duration // Dernier délai d'attente.
+	due     time.Time     // Heure de la prochaine tentative.
+	start   time.Time     // Heure du premier échec.
+	index   int           // Position dans le tas.
+}
+
+// queueHeap est un tas minimum des éléments par heure de prochaine tentative.
+type queueHeap[T any] []*queueEntry[T]
+
+func (h queueHeap[T]) Len() int           { return len(h) }
+func (h queueHeap[T]) Less(i, j int) bool { return h[i].due.Before(h[j].due) }
+func (h queueHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+func (h *queueHeap[T]) Push(x any) {
+	entry := x.(*queueEntry[T])
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+func (h *queueHeap[T]) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return entry
+}
+
+// RetryQueue relance de façon asynchrone des éléments en échec (ex. messages
+// Kafka) : au lieu de bloquer la boucle de consommation pendant le backoff,
+// l'appelant confie l'élément à la file (Push), et le travailleur (Run) le
+// re-livre à handle à l'échéance de son délai, dans l'ordre des échéances et au
+// plus une fois par MinInterval. Les délais, le nombre de tentatives, le budget,
+// le classificateur et l'observateur sont ceux de Config ; OnRetry est appelée
+// sous le verrou de la file et ne doit pas la rappeler.
+type RetryQueue[T any] struct {
+	cfg      Config
+	opts     QueueOptions
+	handle   func(ctx context.Context, item T) error
+	onGiveUp func(item T, result Result)
+	now      func() time.Time
+
+	mu    sync.Mutex
+	items queueHeap[T]
+	wake  chan struct{} // Signale au travailleur un nouvel élément.
+}
+
+// NewRetryQueue crée une file de relance.
+//
+// Paramètres:
+//   - cfg: La configuration de relance (MaxAttempts compte le premier échec, confié par Push).
+//   - opts: Le dimensionnement de la file.
+//   - handle: Re-livre un élément ; une erreur le replanifie ou l'abandonne.
+//   - onGiveUp: Appelé pour chaque élément abandonné (peut être nil).
+//
+// Retourne:
+//   - *RetryQueue[T]: La file, à vider avec Run.
+func NewRetryQueue[T any](cfg Config, opts QueueOptions, handle func(ctx context.Context, item T) error, onGiveUp func(item T, result Result)) *RetryQueue[T] {
+	if opts.Capacity <= 0 {
+		opts.Capacity = defaultQueueCapacity
+	}
+	if onGiveUp == nil {
+		onGiveUp = func(T, Result) {}
+	}
+	return &RetryQueue[T]{
+		cfg:      cfg,
+		opts:     opts,
+		handle:   handle,
+		onGiveUp: onGiveUp,
+		now:      time.Now,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Push confie à la file un élément dont la première tentative a échoué. Un
+// élément que la configuration ne relance pas (erreur permanente, tentatives
+// ou budget épuisés) est abandonné aussitôt.
+//
+// Paramètres:
+//   - item: L'élément en échec.
+//   - err: L'erreur de la première tentative.
+//
+// Retourne:
+//   - error: ErrQueueFull si l'élément devait être relancé mais que la file est pleine (il n'est pas pris en charge).
+func (q *RetryQueue[T]) Push(item T, err error) error {
+	q.mu.Lock()
+	now := q.now()
+	entry := &queueEntry[T]{item: item, attempt: 1, start: now}
+	result, scheduled, full := q.schedule(entry, err, false, now, true)
+	q.mu.Unlock()
+	if full {
+		return ErrQueueFull
+	}
+	if !scheduled {
+		q.giveUp(entry.item, result)
+		return nil
+	}
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Len retourne le nombre d'éléments en attente.
+//
+// Retourne:
+//   - int: La taille de la file.
+func (q *RetryQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// Run re-livre les éléments arrivés à échéance jusqu'à l'annulation du
+// contexte. Les éléments encore en attente restent dans la file.
+//
+// Paramètres:
+//   - ctx: Le contexte d'arrêt, transmis à handle.
+func (q *RetryQueue[T]) Run(ctx context.Context) {
+	var last time.Time
+	for {
+		entry, wait := q.next()
+		if entry == nil {
+			timer := time.NewTimer(wait)
+			if wait == 0 {
+				timer.Stop() // file vide : attendre un nouvel élément
+			}
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-q.wake:
+			case <-timer.C:
+			}
+			timer.Stop()
+			continue
+		}
+
+		if pause := q.opts.MinInterval - q.now().Sub(last); !last.IsZero() && pause > 0 {
+			select {
+			case <-ctx.Done():
+				q.requeue(entry)
+				return
+			case <-time.After(pause):
+			}
+		}
+		last = q.now()
+		q.deliver(ctx, entry)
+	}
+}
+
+// next retire l'élément arrivé à échéance le plus ancien.
+//
+// Retourne:
+//   - *queueEntry[T]: L'élément, ou nil si aucun n'est arrivé à échéance.
+//   - time.Duration: L'attente avant la prochaine échéance (0 si la file est vide).
+func (q *RetryQueue[T]) next() (*queueEntry[T], time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.items) == 0 {
+		return nil, 0
+	}
+	if wait := q.items[0].due.Sub(q.now()); wait > 0 {
+		return nil, wait
+	}
+	return heap.Pop(&q.items).(*queueEntry[T]), 0
+}
+
+// requeue remet un élément retiré dans la file.
+//
+// Paramètres:
+//   - entry: L'élément.
+func (q *RetryQueue[T]) requeue(entry *queueEntry[T]) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	heap.Push(&q.items, entry)
+}
+
+// deliver exécute une tentative et replanifie ou abandonne l'élément en échec.
+//
+// Paramètres:
+//   - ctx: Le contexte d'arrêt.
+//   - entry: L'élément.
+func (q *RetryQueue[T]) deliver(ctx context.Context, entry *queueEntry[T]) {
+	entry.attempt++
+	if q.cfg.Observer != nil {
+		q.cfg.Observer.OnAttempt(entry.attempt)
+	}
+	timedOut, err := runAttempt(ctx, q.cfg.AttemptTimeout, func(ctx context.Context) error {
+		return q.handle(ctx, entry.item)
+	})
+	if err == nil {
+		if s, ok := q.cfg.Observer.(SuccessObserver); ok {
+			s.OnSuccess(q.result(entry, nil, false))
+		}
+		return
+	}
+
+	q.mu.Lock()
+	result, scheduled, _ := q.schedule(entry, err, timedOut, q.now(), false)
+	q.mu.Unlock()
+	if !scheduled {
+		q.giveUp(entry.item, result)
+	}
+}
+
+// schedule replanifie un élément en échec après son délai de backoff. Doit
+// être appelée sous q.mu.
+//
+// Paramètres:
+//   - entry: L'élément.
+//   - err: L'erreur de sa dernière tentative.
+//   - timedOut: Vrai si la tentative a expiré (Config.AttemptTimeout) : relancée comme avec DoContext.
+//   - now: L'heure courante.
+//   - bounded: Vrai pour un nouvel élément, refusé si la file est pleine.
+//
+// Retourne:
+//   - Result: Le résultat de l'élément s'il est abandonné.
+//   - bool: Vrai si l'élément a été replanifié, faux s'il doit être abandonné (voir giveUp).
+//   - bool: Vrai si le nouvel élément est refusé, la file étant pleine.
+func (q *RetryQueue[T]) schedule(entry *queueEntry[T], err error, timedOut bool, now time.Time, bounded bool) (Result, bool, bool) {
+	decision := classify(err, q.cfg)
+	if timedOut && !IsPermanent(err) {
+		decision = DecisionRetry
+	}
+	if decision.Permanent || entry.attempt >= q.cfg.MaxAttempts {
+		return q.result(entry, err, false), false, false
+	}
+	if bounded && len(q.items) >= q.opts.Capacity {
+		return Result{}, false, true
+	}
+	if !q.cfg.Budget.Acquire() {
+		return q.result(entry, err, true), false, false
+	}
+	entry.delay = nextDelay(entry.attempt, entry.delay, decision, q.cfg)
+	entry.due = now.Add(entry.delay)
+	if q.cfg.Observer != nil {
+		q.cfg.Observer.OnRetry(entry.attempt, err, entry.delay)
+	}
+	heap.Push(&q.items, entry)
+	return Result{}, true, false
+}
+
+// giveUp notifie l'abandon d'un élément.
+//
+// Paramètres:
+//   - item: L'élément.
+//   - result: Son résultat.
+func (q *RetryQueue[T]) giveUp(item T, result Result) {
+	if q.cfg.Observer != nil {
+		q.cfg.Observer.OnGiveUp(result)
+	}
+	q.onGiveUp(item, result)
+}
+
+// result construit le résultat d'un élément.
+//
+// Paramètres:
+//   - entry: L'élément.
+//   - err: L'erreur finale (nil si succès).
+//   - budgetExhausted: Vrai si l'abandon est dû au budget de relances.
+//
+// Retourne:
+//   - Result: Le résultat.
+func (q *RetryQueue[T]) result(entry *queueEntry[T], err error, budgetExhausted bool) Result {
+	return Result{Attempts: entry.attempt, Duration: q.now().Sub(entry.start), Err: err, BudgetExhausted: budgetExhausted}
+}
@@ -0,0 +1,82 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRetryQueueRedelivers vérifie la re-livraison par ordre d'échéance puis l'abandon après MaxAttempts.
+func TestRetryQueueRedelivers(t *testing.T) {
+	var mu sync.Mutex
+	var delivered []string
+	gaveUp := make(chan Result, 1)
+	cfg := Config{MaxAttempts: 3, Backoff: ConstantBackoff{Interval: 10 * time.Millisecond}}
+	q := NewRetryQueue(cfg, QueueOptions{}, func(ctx context.Context, item string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		delivered = append(delivered, item)
+		if item == "ko" {
+			return errors.New("toujours en échec")
+		}
+		return nil
+	}, func(item string, result Result) {
+		gaveUp <- result
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go q.Run(ctx)
+	require.NoError(t, q.Push("ok", errors.New("timeout")))
+	require.NoError(t, q.Push("ko", errors.New("timeout")))
+
+	select {
+	case result := <-gaveUp:
+		assert.Equal(t, 3, result.Attempts)
+		assert.EqualError(t, result.Err, "toujours en échec")
+	case <-time.After(time.Second):
+		t.Fatal("Expected the failing item to be given up")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"ok", "ko", "ko"}, delivered)
+	assert.Zero(t, q.Len())
+}
+
+// TestRetryQueueBounds vérifie la capacité de la file et l'abandon immédiat des erreurs permanentes.
+func TestRetryQueueBounds(t *testing.T) {
+	var gaveUp []int
+	cfg := Config{MaxAttempts: 3, Backoff: ConstantBackoff{Interval: time.Hour}}
+	q := NewRetryQueue(cfg, QueueOptions{Capacity: 1}, func(context.Context, int) error { return nil },
+		func(item int, result Result) { gaveUp = append(gaveUp, item) })
+
+	require.NoError(t, q.Push(1, errors.New("timeout")))
+	assert.ErrorIs(t, q.Push(2, errors.New("timeout")), ErrQueueFull)
+	assert.NoError(t, q.Push(3, Permanent(errors.New("invalide"))))
+	assert.Equal(t, []int{3}, gaveUp)
+	assert.Equal(t, 1, q.Len())
+}
+
+// TestRetryQueueMinInterval vérifie l'espacement des re-livraisons.
+func TestRetryQueueMinInterval(t *testing.T) {
+	times := make(chan time.Time, 2)
+	cfg := Config{MaxAttempts: 2, Backoff: ConstantBackoff{}}
+	q := NewRetryQueue(cfg, QueueOptions{MinInterval: 30 * time.Millisecond}, func(context.Context, int) error {
+		times <- time.Now()
+		return nil
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, q.Push(1, errors.New("timeout")))
+	require.NoError(t, q.Push(2, errors.New("timeout")))
+	go q.Run(ctx)
+
+	first, second := <-times, <-times
+	assert.GreaterOrEqual(t, second.Sub(first), 30*time.Millisecond)
+}
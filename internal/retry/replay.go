@@ -0,0 +1,114 @@
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// ErrStopReplay est retournée par ReplayOptions.Approve pour arrêter le rejeu
+// (les messages déjà rejoués restent dans le rapport).
+var ErrStopReplay = errors.New("rejeu interrompu")
+
+// ReplayAction est le sort d'un message DLQ lors d'un rejeu.
+type ReplayAction string
+
+const (
+	// ReplayReplayed signale un message republié sur son topic d'origine.
+	ReplayReplayed ReplayAction = "REPLAYED"
+	// ReplayDryRun signale un message qui aurait été republié (--dry-run).
+	ReplayDryRun ReplayAction = "DRY-RUN"
+	// ReplaySkipped signale un message refusé lors de l'approbation.
+	ReplaySkipped ReplayAction = "SKIPPED"
+	// ReplayFailed signale une enveloppe invalide ou une republication en échec.
+	ReplayFailed ReplayAction = "FAILED"
+)
+
+// ReplayOptions sélectionne les messages DLQ à rejouer.
+type ReplayOptions struct {
+	FromOffset  int64                             // Premier offset DLQ rejoué (-1 : depuis le début).
+	ToOffset    int64                             // Dernier offset DLQ rejoué (-1 : jusqu'à la fin).
+	DryRun      bool                              // Rapporte les messages sans les republier.
+	Approve     func(FailedMessage) (bool, error) // Approuve chaque message (nil : tous ; ErrStopReplay arrête).
+	IdleTimeout time.Duration                     // Fin du rejeu après ce délai sans nouveau message.
+}
+
+// inRange indique si un offset DLQ fait partie de la plage rejouée.
+//
+// Paramètres:
+//   - offset: L'offset du message DLQ.
+//
+// Retourne:
+//   - bool: Vrai si l'offset est dans [FromOffset, ToOffset].
+func (o ReplayOptions) inRange(offset int64) bool {
+	return (o.FromOffset < 0 || offset >= o.FromOffset) && (o.ToOffset < 0 || offset <= o.ToOffset)
+}
+
+// DLQRecord est un message lu sur le topic DLQ.
+type DLQRecord struct {
+	Partition int32         // Partition DLQ du message.
+	Offset    int64         // Offset DLQ du message.
+	Message   FailedMessage // Enveloppe du message (vide si Err est défini).
+	Err       error         // Erreur de lecture de l'enveloppe (nil si elle est valide).
+}
+
+// ReplayEntry est une ligne du rapport de rejeu.
+type ReplayEntry struct {
+	Partition      int32        // Partition DLQ du message.
+	Offset         int64        // Offset DLQ du message.
+	OriginalTopic  string       // Topic de republication.
+	OriginalOffset int64        // Offset du message d'origine.
+	Action         ReplayAction // Sort du message.
+	Detail         string       // Erreur, ou dernière erreur de traitement du message.
+}
+
+// ReplayReport est le rapport d'un rejeu, dans l'ordre de lecture de la DLQ.
+type ReplayReport struct {
+	DryRun  bool          // Rejeu à blanc (--dry-run).
+	Entries []ReplayEntry // Messages lus dans la plage d'offsets.
+}
+
+// Count retourne le nombre de messages ayant connu un sort.
+//
+// Paramètres:
+//   - action: Le sort.
+//
+// Retourne:
+//   - int: Le nombre de messages.
+func (r ReplayReport) Count(action ReplayAction) int {
+	n := 0
+	for _, e := range r.Entries {
+		if e.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// WriteReplayReport écrit le rapport de rejeu en colonnes alignées
+// « sort  partition/offset  topic@offset d'origine  détail », suivies d'un total.
+//
+// Paramètres:
+//   - w: La destination.
+//   - report: Le rapport.
+//
+// Retourne:
+//   - error: Une erreur si l'écriture échoue.
+func WriteReplayReport(w io.Writer, report ReplayReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, e := range report.Entries {
+		fmt.Fprintf(tw, "%s\t%d/%d\t%s@%d\t%s\n", e.Action, e.Partition, e.Offset, e.OriginalTopic, e.OriginalOffset, e.Detail)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	replayed, suffix := report.Count(ReplayReplayed), ""
+	if report.DryRun {
+		replayed, suffix = report.Count(ReplayDryRun), " (dry run)"
+	}
+	_, err := fmt.Fprintf(w, "%d read, %d replayed, %d skipped, %d failed%s\n",
+		len(report.Entries), replayed, report.Count(ReplaySkipped), report.Count(ReplayFailed), suffix)
+	return err
+}
@@ -0,0 +1,212 @@
+//go:build kafka
+// +build kafka
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// HeaderReplayedFrom porte la position DLQ (topic/partition/offset) d'un message rejoué.
+const HeaderReplayedFrom = "replayed-from"
+
+// replayDeliveryTimeout est l'attente maximale de la confirmation d'une republication.
+const replayDeliveryTimeout = 10 * time.Second
+
+// replayConsumer regroupe les opérations du consommateur de la DLQ (implémenté par *kafka.Consumer).
+type replayConsumer interface {
+	SubscribeTopics(topics []string, rebalanceCb kafka.RebalanceCb) error
+	ReadMessage(timeout time.Duration) (*kafka.Message, error)
+}
+
+// Replayer consomme le topic DLQ, désenveloppe les FailedMessage et republie
+// leur contenu sur leur topic d'origine, avec l'en-tête replayed-from.
+type Replayer struct {
+	consumer        replayConsumer
+	producer        messageProducer
+	topic           string
+	deliveryTimeout time.Duration
+}
+
+// NewReplayer crée un rejoueur de DLQ.
+//
+// Paramètres:
+//   - consumer: Le consommateur de la DLQ (sans commit des offsets, lecture depuis le début).
+//   - producer: Le producteur republiant les messages.
+//   - topic: Le topic DLQ.
+//
+// Retourne:
+//   - *Replayer: Le rejoueur.
+func NewReplayer(consumer replayConsumer, producer messageProducer, topic string) *Replayer {
+	return &Replayer{consumer: consumer, producer: producer, topic: topic, deliveryTimeout: replayDeliveryTimeout}
+}
+
+// Replay lit la DLQ jusqu'à IdleTimeout sans nouveau message et rejoue les
+// messages de la plage d'offsets approuvés. Chaque republication attend sa
+// confirmation de livraison.
+//
+// Paramètres:
+//   - ctx: Le contexte d'arrêt.
+//   - opts: La sélection des messages.
+//
+// Retourne:
+//   - ReplayReport: Le rapport des messages lus dans la plage.
+//   - error: Une erreur si la lecture de la DLQ échoue (le rapport reste valide).
+func (r *Replayer) Replay(ctx context.Context, opts ReplayOptions) (ReplayReport, error) {
+	report := ReplayReport{DryRun: opts.DryRun}
+	err := r.read(ctx, opts.IdleTimeout, func(msg *kafka.Message) bool {
+		if !opts.inRange(int64(msg.TopicPartition.Offset)) {
+			return true
+		}
+		entry, err := r.replay(msg, opts)
+		if errors.Is(err, ErrStopReplay) {
+			return false
+		}
+		report.Entries = append(report.Entries, entry)
+		return true
+	})
+	return report, err
+}
+
+// Browse lit la DLQ jusqu'à idleTimeout sans nouveau message, sans rien republier.
+//
+// Paramètres:
+//   - ctx: Le contexte d'arrêt.
+//   - idleTimeout: La fin de la lecture après ce délai sans nouveau message.
+//
+// Retourne:
+//   - []DLQRecord: Les messages lus, dans l'ordre de lecture.
+//   - error: Une erreur si la lecture de la DLQ échoue (les messages déjà lus restent valides).
+func (r *Replayer) Browse(ctx context.Context, idleTimeout time.Duration) ([]DLQRecord, error) {
+	var records []DLQRecord
+	err := r.read(ctx, idleTimeout, func(msg *kafka.Message) bool {
+		records = append(records, decodeRecord(msg))
+		return true
+	})
+	return records, err
+}
+
+// read s'abonne à la DLQ et passe chaque message lu à fn, jusqu'à idleTimeout
+// sans nouveau message, l'annulation du contexte ou l'arrêt demandé par fn.
+//
+// Paramètres:
+//   - ctx: Le contexte d'arrêt.
+//   - idleTimeout: La fin de la lecture après ce délai sans nouveau message.
+//   - fn: Traite un message et retourne faux pour arrêter la lecture.
+//
+// Retourne:
+//   - error: Une erreur si l'abonnement ou la lecture échoue.
+func (r *Replayer) read(ctx context.Context, idleTimeout time.Duration, fn func(*kafka.Message) bool) error {
+	if err := r.consumer.SubscribeTopics([]string{r.topic}, nil); err != nil {
+		return fmt.Errorf("impossible de s'abonner au topic DLQ: %w", err)
+	}
+	for ctx.Err() == nil {
+		msg, err := r.consumer.ReadMessage(idleTimeout)
+		if err != nil {
+			var kafkaErr kafka.Error
+			if errors.As(err, &kafkaErr) && kafkaErr.Code() == kafka.ErrTimedOut {
+				return nil // fin de la DLQ
+			}
+			return fmt.Errorf("erreur de lecture de la DLQ: %w", err)
+		}
+		if !fn(msg) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// decodeRecord lit l'enveloppe d'un message DLQ.
+//
+// Paramètres:
+//   - msg: Le message DLQ.
+//
+// Retourne:
+//   - DLQRecord: Le message et sa position.
+func decodeRecord(msg *kafka.Message) DLQRecord {
+	rec := DLQRecord{Partition: msg.TopicPartition.Partition, Offset: int64(msg.TopicPartition.Offset)}
+	rec.Message, rec.Err = DecodeFailedMessage(msg.Value)
+	return rec
+}
+
+// replay traite un message DLQ de la plage rejouée.
+//
+// Paramètres:
+//   - msg: Le message DLQ.
+//   - opts: La sélection des messages.
+//
+// Retourne:
+//   - ReplayEntry: La ligne du rapport.
+//   - error: ErrStopReplay si l'approbation arrête le rejeu.
+func (r *Replayer) replay(msg *kafka.Message, opts ReplayOptions) (ReplayEntry, error) {
+	rec := decodeRecord(msg)
+	entry := ReplayEntry{Partition: rec.Partition, Offset: rec.Offset}
+	if rec.Err != nil {
+		entry.Action, entry.Detail = ReplayFailed, rec.Err.Error()
+		return entry, nil
+	}
+	failed := rec.Message
+	entry.OriginalTopic, entry.OriginalOffset, entry.Detail = failed.OriginalTopic, failed.OriginalOffset, failed.LastError
+
+	if opts.Approve != nil {
+		approved, err := opts.Approve(failed)
+		if err != nil {
+			return entry, err
+		}
+		if !approved {
+			entry.Action = ReplaySkipped
+			return entry, nil
+		}
+	}
+	if opts.DryRun {
+		entry.Action = ReplayDryRun
+		return entry, nil
+	}
+
+	if err := r.Republish(rec); err != nil {
+		entry.Action, entry.Detail = ReplayFailed, err.Error()
+		return entry, nil
+	}
+	entry.Action = ReplayReplayed
+	return entry, nil
+}
+
+// Republish republie le contenu d'un message DLQ sur son topic d'origine et
+// attend sa confirmation de livraison.
+//
+// Paramètres:
+//   - rec: Le message DLQ.
+//
+// Retourne:
+//   - error: Une erreur si l'enveloppe est invalide ou si la publication ou la livraison échoue.
+func (r *Replayer) Republish(rec DLQRecord) error {
+	if rec.Err != nil {
+		return rec.Err
+	}
+	topic := rec.Message.OriginalTopic
+	delivery := make(chan kafka.Event, 1)
+	err := r.producer.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Value:          rec.Message.Payload,
+		Headers: []kafka.Header{
+			{Key: HeaderReplayedFrom, Value: []byte(fmt.Sprintf("%s/%d/%d", r.topic, rec.Partition, rec.Offset))},
+		},
+	}, delivery)
+	if err != nil {
+		return err
+	}
+	select {
+	case e := <-delivery:
+		if m, ok := e.(*kafka.Message); ok && m.TopicPartition.Error != nil {
+			return m.TopicPartition.Error
+		}
+		return nil
+	case <-time.After(r.deliveryTimeout):
+		return fmt.Errorf("livraison non confirmée après %s", r.deliveryTimeout)
+	}
+}
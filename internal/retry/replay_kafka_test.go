@@ -0,0 +1,114 @@
+//go:build kafka
+// +build kafka
+
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// fakeDLQConsumer retourne les messages d'une DLQ puis un délai expiré.
+type fakeDLQConsumer struct {
+	messages []*kafka.Message
+}
+
+func (c *fakeDLQConsumer) SubscribeTopics(topics []string, rebalanceCb kafka.RebalanceCb) error {
+	return nil
+}
+func (c *fakeDLQConsumer) ReadMessage(timeout time.Duration) (*kafka.Message, error) {
+	if len(c.messages) == 0 {
+		return nil, kafka.NewError(kafka.ErrTimedOut, "timeout", false)
+	}
+	msg := c.messages[0]
+	c.messages = c.messages[1:]
+	return msg, nil
+}
+
+func dlqMessages() []*kafka.Message {
+	topic := "orders-dlq"
+	values := []string{
+		`{"original_topic":"orders","original_offset":10,"attempts":3,"last_error":"timeout","payload":{"order_id":"a"}}`,
+		`{"original_topic":"orders","original_offset":11,"attempts":3,"last_error":"timeout","payload":{"order_id":"b"}}`,
+		`corrupted`,
+	}
+	messages := make([]*kafka.Message, len(values))
+	for i, v := range values {
+		messages[i] = &kafka.Message{
+			TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: 0, Offset: kafka.Offset(i)},
+			Value:          []byte(v),
+		}
+	}
+	return messages
+}
+
+func TestReplayerReplaysAll(t *testing.T) {
+	producer := &fakeProducer{}
+	r := NewReplayer(&fakeDLQConsumer{messages: dlqMessages()}, producer, "orders-dlq")
+
+	report, err := r.Replay(context.Background(), ReplayOptions{FromOffset: -1, ToOffset: -1, IdleTimeout: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.Count(ReplayReplayed) != 2 || report.Count(ReplayFailed) != 1 {
+		t.Errorf("Expected 2 replayed and 1 failed, got %+v", report.Entries)
+	}
+	if len(producer.messages) != 2 || string(producer.messages[1].Value) != `{"order_id":"b"}` || *producer.messages[1].TopicPartition.Topic != "orders" {
+		t.Fatalf("Expected the payloads to be republished to orders, got %v", producer.messages)
+	}
+	if got := headerValue(producer.messages[0].Headers, HeaderReplayedFrom); got != "orders-dlq/0/0" {
+		t.Errorf("Unexpected replayed-from header %q", got)
+	}
+}
+
+func TestReplayerRangeDryRunAndApproval(t *testing.T) {
+	producer := &fakeProducer{}
+	r := NewReplayer(&fakeDLQConsumer{messages: dlqMessages()}, producer, "orders-dlq")
+	report, _ := r.Replay(context.Background(), ReplayOptions{FromOffset: 1, ToOffset: 1, DryRun: true, IdleTimeout: time.Millisecond})
+	if len(report.Entries) != 1 || report.Entries[0].Action != ReplayDryRun || report.Entries[0].OriginalOffset != 11 {
+		t.Errorf("Expected offset 1 only, as a dry run, got %+v", report.Entries)
+	}
+	if len(producer.messages) != 0 {
+		t.Error("Expected no message published by a dry run")
+	}
+
+	r = NewReplayer(&fakeDLQConsumer{messages: dlqMessages()}, producer, "orders-dlq")
+	answers := []bool{false}
+	report, _ = r.Replay(context.Background(), ReplayOptions{FromOffset: -1, ToOffset: -1, IdleTimeout: time.Millisecond,
+		Approve: func(FailedMessage) (bool, error) {
+			if len(answers) == 0 {
+				return false, ErrStopReplay
+			}
+			ok := answers[0]
+			answers = answers[1:]
+			return ok, nil
+		}})
+	if len(report.Entries) != 1 || report.Entries[0].Action != ReplaySkipped || len(producer.messages) != 0 {
+		t.Errorf("Expected one skipped message then a stop, got %+v", report.Entries)
+	}
+}
+
+func TestReplayerBrowseAndRepublish(t *testing.T) {
+	producer := &fakeProducer{}
+	r := NewReplayer(&fakeDLQConsumer{messages: dlqMessages()}, producer, "orders-dlq")
+
+	records, err := r.Browse(context.Background(), time.Millisecond)
+	if err != nil || len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d (%v)", len(records), err)
+	}
+	if records[1].Offset != 1 || records[1].Message.OriginalOffset != 11 || records[2].Err == nil {
+		t.Errorf("Unexpected records %+v", records)
+	}
+	if len(producer.messages) != 0 {
+		t.Error("Expected Browse to publish nothing")
+	}
+	if err := r.Republish(records[2]); err == nil {
+		t.Error("Expected an invalid envelope not to be republished")
+	}
+	if err := r.Republish(records[1]); err != nil || len(producer.messages) != 1 {
+		t.Errorf("Expected one republished message, got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package retry
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDecodeFailedMessage(t *testing.T) {
+	msg, err := DecodeFailedMessage([]byte(`{"original_topic":"orders","original_offset":12,"attempts":3,"payload":{"order_id":"1"}}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if msg.OriginalTopic != "orders" || msg.OriginalOffset != 12 || string(msg.Payload) != `{"order_id":"1"}` {
+		t.Errorf("Unexpected message %+v", msg)
+	}
+	for _, data := range []string{`not json`, `{"attempts":1}`} {
+		if _, err := DecodeFailedMessage([]byte(data)); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+}
+
+func TestReplayOptionsRange(t *testing.T) {
+	opts := ReplayOptions{FromOffset: 5, ToOffset: 10}
+	for offset, want := range map[int64]bool{4: false, 5: true, 10: true, 11: false} {
+		if got := opts.inRange(offset); got != want {
+			t.Errorf("offset %d: expected %v, got %v", offset, want, got)
+		}
+	}
+	if !(ReplayOptions{FromOffset: -1, ToOffset: -1}).inRange(0) {
+		t.Error("Expected an unbounded range to include every offset")
+	}
+}
+
+func TestWriteReplayReport(t *testing.T) {
+	report := ReplayReport{DryRun: true, Entries: []ReplayEntry{
+		{Partition: 0, Offset: 1, OriginalTopic: "orders", OriginalOffset: 40, Action: ReplayDryRun, Detail: "timeout"},
+		{Partition: 0, Offset: 2, Action: ReplayFailed, Detail: "message DLQ invalide"},
+	}}
+	var buf bytes.Buffer
+	if err := WriteReplayReport(&buf, report); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "DRY-RUN  0/1  orders@40") {
+		t.Errorf("Expected an aligned row, got:\n%s", out)
+	}
+	if !strings.Contains(out, "2 read, 1 replayed, 0 skipped, 1 failed (dry run)") {
+		t.Errorf("Expected the totals, got:\n%s", out)
+	}
+}
+
+func TestDecodeFailedMessageVersions(t *testing.T) {
+	legacy, err := DecodeFailedMessage([]byte(`{"original_topic":"orders","failed_at":"2024-03-01T12:00:00Z","payload":{}}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if legacy.SchemaVersion != FailedMessageSchemaVersion || !legacy.FirstSeen.Equal(legacy.FailedAt) {
+		t.Errorf("Expected a legacy envelope migrated with first_seen = failed_at, got %+v", legacy)
+	}
+	for _, data := range []string{
+		`{"schema_version":99,"original_topic":"orders"}`,
+		`{"original_topic":"orders","original_offset":-1}`,
+		`{"original_topic":"orders","error_class":"fatal"}`,
+	} {
+		if _, err := DecodeFailedMessage([]byte(data)); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+}
+
+func TestFailedMessageValidate(t *testing.T) {
+	msg := FailedMessage{SchemaVersion: FailedMessageSchemaVersion, OriginalTopic: "orders", ErrorClass: ErrorClassPermanent}
+	if err := msg.Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	msg.Attempts = -1
+	if err := msg.Validate(); err == nil || !strings.Contains(err.Error(), "attempts") {
+		t.Errorf("Expected an attempts error, got %v", err)
+	}
+}
+
+func TestReprocessorStatsString(t *testing.T) {
+	stats := ReprocessorStats{Read: 3, Republished: 2, Parked: 1}
+	want := `level=info msg="dlq reprocessor" read=3 republished=2 parked=1 errors=0`
+	if got := stats.String(); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
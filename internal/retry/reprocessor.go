@@ -0,0 +1,47 @@
+package retry
+
+import (
+	"fmt"
+	"time"
+)
+
+// HeaderParkReason porte la raison pour laquelle le retraiteur a écarté un
+// message DLQ vers le topic de parking.
+const HeaderParkReason = "park-reason"
+
+// Raisons de parking d'un message DLQ (en-tête park-reason).
+const (
+	// ParkInvalid désigne une enveloppe DLQ illisible.
+	ParkInvalid = "invalid"
+	// ParkPermanent désigne une erreur que relancer ne peut pas corriger.
+	ParkPermanent = "permanent"
+	// ParkExhausted désigne un message déjà retraité ReprocessorConfig.MaxReprocess fois.
+	ParkExhausted = "exhausted"
+)
+
+// ReprocessorConfig contient la configuration du retraiteur de la DLQ.
+type ReprocessorConfig struct {
+	Topic        string        // Topic DLQ consommé.
+	ParkTopic    string        // Topic recevant les messages non retraitables.
+	CoolDown     time.Duration // Délai après l'échec (failed_at) avant la republication.
+	MaxReprocess int           // Retraitements d'un message avant son parking.
+	PollTimeout  time.Duration // Délai de lecture, qui borne aussi le retard de republication.
+}
+
+// ReprocessorStats contient l'activité du retraiteur de la DLQ.
+type ReprocessorStats struct {
+	Read         int64     // Messages DLQ lus.
+	Republished  int64     // Messages republiés sur leur topic d'origine.
+	Parked       int64     // Messages écartés vers le topic de parking.
+	Errors       int64     // Publications en échec (message relu ensuite).
+	LastActivity time.Time // Heure de la dernière republication ou du dernier parking.
+}
+
+// String formate l'activité en paires clé=valeur (logfmt).
+//
+// Retourne:
+//   - string: L'activité (ex. level=info msg="dlq reprocessor" read=3 republished=2 parked=1 errors=0).
+func (s ReprocessorStats) String() string {
+	return fmt.Sprintf("level=info msg=%q read=%d republished=%d parked=%d errors=%d",
+		"dlq reprocessor", s.Read, s.Republished, s.Parked, s.Errors)
+}
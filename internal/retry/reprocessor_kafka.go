@@ -0,0 +1,187 @@
+//go:build kafka
+// +build kafka
+
+package retry
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// Reprocessor consomme la DLQ en continu : un message dont l'erreur est
+// retraitable (classe transient ou throttled) est republié sur son topic
+// d'origine une fois CoolDown écoulé depuis son échec, avec l'en-tête
+// reprocess-count incrémenté ; les autres messages, et ceux retraités
+// MaxReprocess fois, sont écartés vers ParkTopic avec l'en-tête park-reason.
+// Un message en avance suspend sa partition, comme RetryConsumer. L'offset
+// n'est enregistré qu'après la confirmation de livraison de la republication
+// ou du parking : le consommateur doit être créé avec enable.auto.offset.store
+// à false.
+type Reprocessor struct {
+	consumer        retryConsumerClient
+	producer        messageProducer
+	cfg             ReprocessorConfig
+	deliveryTimeout time.Duration
+	paused          map[string]pausedPartition
+	now             func() time.Time
+
+	mu    sync.Mutex
+	stats ReprocessorStats
+}
+
+// NewReprocessor crée un retraiteur de la DLQ.
+//
+// Paramètres:
+//   - consumer: Le consommateur Kafka de la DLQ (enable.auto.offset.store à false).
+//   - producer: Le producteur republiant et écartant les messages.
+//   - cfg: La configuration du retraiteur.
+//
+// Retourne:
+//   - *Reprocessor: Le retraiteur.
+func NewReprocessor(consumer retryConsumerClient, producer messageProducer, cfg ReprocessorConfig) *Reprocessor {
+	return &Reprocessor{
+		consumer:        consumer,
+		producer:        producer,
+		cfg:             cfg,
+		deliveryTimeout: replayDeliveryTimeout,
+		paused:          make(map[string]pausedPartition),
+		now:             time.Now,
+	}
+}
+
+// Run s'abonne à la DLQ et traite ses messages jusqu'à l'annulation du contexte.
+//
+// Paramètres:
+//   - ctx: Le contexte d'arrêt.
+//
+// Retourne:
+//   - error: Une erreur si l'abonnement échoue, nil après l'annulation.
+func (r *Reprocessor) Run(ctx context.Context) error {
+	if err := r.consumer.SubscribeTopics([]string{r.cfg.Topic}, nil); err != nil {
+		return fmt.Errorf("impossible de s'abonner au topic DLQ: %w", err)
+	}
+	for ctx.Err() == nil {
+		r.poll()
+	}
+	return nil
+}
+
+// Stats retourne l'activité du retraiteur.
+//
+// Retourne:
+//   - ReprocessorStats: Les compteurs depuis le démarrage.
+func (r *Reprocessor) Stats() ReprocessorStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
+
+// poll reprend les partitions arrivées à échéance puis traite le message suivant.
+func (r *Reprocessor) poll() {
+	now := r.now()
+	for key, p := range r.paused {
+		if !now.Before(p.resumeAt) && r.consumer.Resume([]kafka.TopicPartition{p.partition}) == nil {
+			delete(r.paused, key)
+		}
+	}
+	msg, err := r.consumer.ReadMessage(r.cfg.PollTimeout)
+	if err != nil {
+		return // délai expiré ou erreur transitoire : la boucle relit
+	}
+	r.handle(msg)
+}
+
+// handle republie, écarte ou diffère un message DLQ.
+//
+// Paramètres:
+//   - msg: Le message lu sur la DLQ.
+func (r *Reprocessor) handle(msg *kafka.Message) {
+	failed, err := DecodeFailedMessage(msg.Value)
+	if err != nil {
+		r.finish(msg, r.park(msg, ParkInvalid), false)
+		return
+	}
+	switch {
+	case failed.ErrorClass != ErrorClassTransient && failed.ErrorClass != ErrorClassThrottled:
+		r.finish(msg, r.park(msg, ParkPermanent), false)
+		return
+	case failed.ReprocessCount >= r.cfg.MaxReprocess:
+		r.finish(msg, r.park(msg, ParkExhausted), false)
+		return
+	}
+
+	if due := failed.FailedAt.Add(r.cfg.CoolDown); r.now().Before(due) {
+		partition := msg.TopicPartition
+		partition.Error = nil
+		if r.consumer.Pause([]kafka.TopicPartition{partition}) != nil {
+			return
+		}
+		r.consumer.Seek(partition, 0)
+		r.paused[partitionKey(partition)] = pausedPartition{partition: partition, resumeAt: due}
+		return
+	}
+
+	topic := failed.OriginalTopic
+	err = deliver(r.producer, &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Value:          failed.Payload,
+		Headers: []kafka.Header{
+			{Key: HeaderReplayedFrom, Value: []byte(fmt.Sprintf("%s/%d/%d", r.cfg.Topic, msg.TopicPartition.Partition, msg.TopicPartition.Offset))},
+			{Key: HeaderReprocessCount, Value: []byte(strconv.Itoa(failed.ReprocessCount + 1))},
+			{Key: HeaderFirstSeen, Value: []byte(formatRetryAt(failed.FirstSeen))},
+		},
+	}, r.deliveryTimeout)
+	r.finish(msg, err, true)
+}
+
+// park publie un message DLQ tel quel sur le topic de parking et attend sa
+// confirmation de livraison.
+//
+// Paramètres:
+//   - msg: Le message DLQ.
+//   - reason: La raison du parking (voir ParkInvalid, ParkPermanent et ParkExhausted).
+//
+// Retourne:
+//   - error: Une erreur si la publication ou la livraison échoue, ou si elle n'est pas confirmée à temps.
+func (r *Reprocessor) park(msg *kafka.Message, reason string) error {
+	headers := append(append([]kafka.Header(nil), msg.Headers...), kafka.Header{Key: HeaderParkReason, Value: []byte(reason)})
+	return deliver(r.producer, &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &r.cfg.ParkTopic, Partition: kafka.PartitionAny},
+		Key:            msg.Key,
+		Value:          msg.Value,
+		Headers:        headers,
+	}, r.deliveryTimeout)
+}
+
+// finish enregistre l'offset d'un message livré, ou le relit au prochain tour
+// si la publication ou la livraison a échoué, et met à jour les statistiques.
+//
+// Paramètres:
+//   - msg: Le message DLQ.
+//   - err: L'erreur de publication ou de livraison.
+//   - republished: Vrai pour une republication, faux pour un parking.
+func (r *Reprocessor) finish(msg *kafka.Message, err error, republished bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		// Relire le message au prochain tour plutôt que de le perdre
+		partition := msg.TopicPartition
+		partition.Error = nil
+		r.consumer.Seek(partition, 0)
+		r.stats.Errors++
+		return
+	}
+	r.consumer.StoreMessage(msg)
+	r.stats.Read++
+	if republished {
+		r.stats.Republished++
+	} else {
+		r.stats.Parked++
+	}
+	r.stats.LastActivity = r.now()
+}
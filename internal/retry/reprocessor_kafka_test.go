@@ -0,0 +1,139 @@
+//go:build kafka
+// +build kafka
+
+package retry
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// dlqMessage retourne un message du topic DLQ enveloppant un échec de la classe donnée.
+func dlqMessage(t *testing.T, offset kafka.Offset, class string, reprocessed int, failedAt time.Time) *kafka.Message {
+	t.Helper()
+	value, err := json.Marshal(FailedMessage{
+		SchemaVersion:  FailedMessageSchemaVersion,
+		OriginalTopic:  "orders",
+		FailedAt:       failedAt,
+		FirstSeen:      failedAt.Add(-time.Hour),
+		ErrorClass:     class,
+		ReprocessCount: reprocessed,
+		Payload:        json.RawMessage(`{"order_id":"a"}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	topic := "orders-dlq"
+	return &kafka.Message{TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: 0, Offset: offset}, Value: value}
+}
+
+func testReprocessor(consumer *fakeRetryConsumer, producer *fakeProducer, now *time.Time) *Reprocessor {
+	r := NewReprocessor(consumer, producer, ReprocessorConfig{
+		Topic:        "orders-dlq",
+		ParkTopic:    "orders-dlq-parked",
+		CoolDown:     5 * time.Minute,
+		MaxReprocess: 2,
+		PollTimeout:  time.Second,
+	})
+	r.now = func() time.Time { return *now }
+	r.deliveryTimeout = time.Millisecond
+	return r
+}
+
+func TestReprocessorRepublishesAfterCoolDown(t *testing.T) {
+	consumer, producer := &fakeRetryConsumer{}, &fakeProducer{}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	r := testReprocessor(consumer, producer, &now)
+	msg := dlqMessage(t, 7, ErrorClassTransient, 1, now.Add(-time.Minute))
+
+	// Refroidissement en cours : partition suspendue sur le message
+	r.handle(msg)
+	if consumer.paused != 1 || len(consumer.seeks) != 1 || len(producer.messages) != 0 {
+		t.Fatalf("Expected the partition to be paused during the cool-down, got %+v", consumer)
+	}
+
+	now = now.Add(4 * time.Minute)
+	r.poll()
+	if consumer.resumed != 1 || len(r.paused) != 0 {
+		t.Fatalf("Expected the partition to be resumed after the cool-down, got %+v", consumer)
+	}
+	r.handle(msg)
+	if len(producer.messages) != 1 {
+		t.Fatalf("Expected the message to be republished, got %v", producer.messages)
+	}
+	out := producer.messages[0]
+	if *out.TopicPartition.Topic != "orders" || string(out.Value) != `{"order_id":"a"}` {
+		t.Errorf("Expected the payload republished to orders, got %s on %s", out.Value, *out.TopicPartition.Topic)
+	}
+	if headerValue(out.Headers, HeaderReprocessCount) != "2" || headerValue(out.Headers, HeaderReplayedFrom) != "orders-dlq/0/7" {
+		t.Errorf("Expected reprocess-count 2 and replayed-from orders-dlq/0/7, got %v", out.Headers)
+	}
+	if stats := r.Stats(); stats.Republished != 1 || stats.Read != 1 || consumer.stored != 1 {
+		t.Errorf("Expected one republished message, got %+v", stats)
+	}
+}
+
+func TestReprocessorParksMessages(t *testing.T) {
+	consumer, producer := &fakeRetryConsumer{}, &fakeProducer{}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	r := testReprocessor(consumer, producer, &now)
+	invalidTopic := "orders-dlq"
+
+	r.handle(dlqMessage(t, 1, ErrorClassPermanent, 0, now.Add(-time.Hour)))
+	r.handle(dlqMessage(t, 2, ErrorClassThrottled, 2, now.Add(-time.Hour)))
+	r.handle(&kafka.Message{TopicPartition: kafka.TopicPartition{Topic: &invalidTopic, Offset: 3}, Value: []byte("brut")})
+
+	want := []string{ParkPermanent, ParkExhausted, ParkInvalid}
+	if len(producer.messages) != len(want) {
+		t.Fatalf("Expected %d parked messages, got %d", len(want), len(producer.messages))
+	}
+	for i, reason := range want {
+		parked := producer.messages[i]
+		if *parked.TopicPartition.Topic != "orders-dlq-parked" || headerValue(parked.Headers, HeaderParkReason) != reason {
+			t.Errorf("Message %d: expected parked with reason %s, got %v", i, reason, parked.Headers)
+		}
+	}
+	if stats := r.Stats(); stats.Parked != 3 || stats.Republished != 0 {
+		t.Errorf("Expected three parked messages, got %+v", stats)
+	}
+}
+
+func TestReprocessorPublishError(t *testing.T) {
+	consumer, producer := &fakeRetryConsumer{}, &fakeProducer{err: errors.New("queue full")}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	r := testReprocessor(consumer, producer, &now)
+
+	r.handle(dlqMessage(t, 9, ErrorClassTransient, 0, now.Add(-time.Hour)))
+	if consumer.stored != 0 || len(consumer.seeks) != 1 || consumer.seeks[0] != 9 {
+		t.Errorf("Expected the message to be read again, got %+v", consumer)
+	}
+	if stats := r.Stats(); stats.Errors != 1 {
+		t.Errorf("Expected one publish error, got %+v", stats)
+	}
+}
+
+func TestReprocessorDeliveryError(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for name, producer := range map[string]*fakeProducer{
+		"delivery error":     {deliveryErr: kafka.NewError(kafka.ErrMsgTimedOut, "timed out", false)},
+		"no delivery report": {noReport: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			consumer := &fakeRetryConsumer{}
+			r := testReprocessor(consumer, producer, &now)
+
+			r.handle(dlqMessage(t, 9, ErrorClassTransient, 0, now.Add(-time.Hour)))
+			r.handle(dlqMessage(t, 10, ErrorClassPermanent, 0, now.Add(-time.Hour)))
+			if consumer.stored != 0 || len(consumer.seeks) != 2 || consumer.seeks[0] != 9 || consumer.seeks[1] != 10 {
+				t.Errorf("Expected both messages to be read again, got %+v", consumer)
+			}
+			if stats := r.Stats(); stats.Errors != 2 || stats.Republished != 0 || stats.Parked != 0 {
+				t.Errorf("Expected two delivery errors, got %+v", stats)
+			}
+		})
+	}
+}
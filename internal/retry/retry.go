@@ -17,6 +17,12 @@ type Config struct {
 	InitialDelay time.Duration // Délai initial avant la première relance.
 	MaxDelay     time.Duration // Délai maximum entre les relances.
 	Multiplier   float64       // Multiplicateur pour le backoff exponentiel.
+	Backoff      Backoff       // Stratégie de délai (nil : exponentielle avec ±25 % de jitter, voir calculateDelay).
+	Budget       *Budget       // Budget de relances partagé entre les appels (nil : illimité).
+	Classifier   Classifier    // Décision de relance par erreur (nil : seules les PermanentError sont abandonnées).
+
+	AttemptTimeout time.Duration // Délai de chaque tentative de DoContext (0 : seul le délai du contexte s'applique).
+	Observer       Observer      // Notifié des tentatives, relances et abandons (nil : aucun ; voir Observers pour en combiner plusieurs).
 }
 
 // DefaultConfig retourne une configuration de relance par défaut.
@@ -84,11 +90,16 @@ type Result struct {
 	Attempts int           // Nombre de tentatives effectuées.
 	Duration time.Duration // Durée totale de toutes les tentatives.
 	Err      error         // Erreur finale (nil si succès).
+
+	BudgetExhausted bool // Les relances ont été abandonnées faute de budget (voir Config.Budget).
 }
 
 // Do exécute la fonction donnée avec une logique de relance.
-// La fonction est relancée jusqu'à ce qu'elle réussisse, retourne une erreur permanente,
-// ou que le nombre maximum de tentatives soit atteint.
+// La fonction est relancée jusqu'à ce qu'elle réussisse, retourne une erreur permanente
+// (voir Permanent et Config.Classifier),
+// que le nombre maximum de tentatives soit atteint ou que le budget de relances
+// (cfg.Budget) soit épuisé. La fonction ne recevant pas de contexte,
+// cfg.AttemptTimeout est sans effet : voir DoContext.
 //
 // Paramètres:
 //   - ctx: Le contexte pour l'annulation et les délais.
@@ -98,62 +109,25 @@ type Result struct {
 // Retourne:
 //   - Result: Le résultat contenant le nombre de tentatives, la durée et l'erreur finale.
 func Do(ctx context.Context, cfg Config, fn func() error) Result {
-	start := time.Now()
-	var lastErr error
-
-	for attempt := 1; attempt <= cfg.MaxAttempts; attempt++ {
-		// Vérification de l'annulation du contexte
-		select {
-		case <-ctx.Done():
-			return Result{
-				Attempts: attempt,
-				Duration: time.Since(start),
-				Err:      ctx.Err(),
-			}
-		default:
-		}
-
-		// Exécution de la fonction
-		err := fn()
-		if err == nil {
-			return Result{
-				Attempts: attempt,
-				Duration: time.Since(start),
-				Err:      nil,
-			}
-		}
-
-		lastErr = err
-
-		// Ne pas relancer les erreurs permanentes
-		if IsPermanent(err) {
-			return Result{
-				Attempts: attempt,
-				Duration: time.Since(start),
-				Err:      err,
-			}
-		}
-
-		// Ne pas dormir après la dernière tentative
-		if attempt < cfg.MaxAttempts {
-			delay := calculateDelay(attempt, cfg)
-			select {
-			case <-ctx.Done():
-				return Result{
-					Attempts: attempt,
-					Duration: time.Since(start),
-					Err:      ctx.Err(),
-				}
-			case <-time.After(delay):
-			}
-		}
-	}
+	return DoWithCallback(ctx, cfg, fn, nil)
+}
 
-	return Result{
-		Attempts: cfg.MaxAttempts,
-		Duration: time.Since(start),
-		Err:      lastErr,
-	}
+// DoContext est comme Do pour une fonction recevant le contexte de sa
+// tentative. Si cfg.AttemptTimeout est positif, chaque tentative s'exécute sous
+// son propre délai : une tentative bloquée (ex. appel Kafka sans réponse) est
+// interrompue et relancée au lieu de consommer tout le délai de ctx. L'expiration
+// du délai d'une tentative est relancée même si le classificateur rend
+// context.DeadlineExceeded permanente ; l'expiration de ctx reste définitive.
+//
+// Paramètres:
+//   - ctx: Le contexte pour l'annulation et les délais.
+//   - cfg: La configuration de relance.
+//   - fn: La fonction à exécuter, qui doit respecter le contexte reçu.
+//
+// Retourne:
+//   - Result: Le résultat contenant le nombre de tentatives, la durée et l'erreur finale.
+func DoContext(ctx context.Context, cfg Config, fn func(ctx context.Context) error) Result {
+	return run(ctx, cfg, fn, nil)
 }
 
 // calculateDelay calcule le délai pour une tentative donnée en utilisant un backoff exponentiel avec jitter.
@@ -180,6 +154,27 @@ func calculateDelay(attempt int, cfg Config) time.Duration {
 	return time.Duration(delay)
 }
 
+// nextDelay calcule le délai avant une relance : celui imposé par la décision
+// du classificateur, sinon celui de la stratégie de la configuration.
+//
+// Paramètres:
+//   - attempt: Le numéro de la tentative échouée.
+//   - previous: Le délai précédent (0 avant la première relance).
+//   - decision: La décision du classificateur pour l'erreur.
+//   - cfg: La configuration de relance.
+//
+// Retourne:
+//   - time.Duration: La durée à attendre avant la prochaine tentative.
+func nextDelay(attempt int, previous time.Duration, decision Decision, cfg Config) time.Duration {
+	if decision.After > 0 {
+		return decision.After
+	}
+	if cfg.Backoff == nil {
+		return calculateDelay(attempt, cfg)
+	}
+	return cfg.Backoff.Delay(attempt, previous)
+}
+
 // DoWithCallback est comme Do mais appelle le callback fourni après chaque tentative échouée.
 // Ceci est utile pour la journalisation ou les métriques.
 //
@@ -192,10 +187,51 @@ func calculateDelay(attempt int, cfg Config) time.Duration {
 // Retourne:
 //   - Result: Le résultat de l'opération.
 func DoWithCallback(ctx context.Context, cfg Config, fn func() error, onRetry func(attempt int, err error, nextDelay time.Duration)) Result {
+	cfg.AttemptTimeout = 0 // fn ne peut pas être interrompue
+	return run(ctx, cfg, func(context.Context) error { return fn() }, onRetry)
+}
+
+// run est la boucle de relance commune à Do, DoContext et DoWithCallback.
+//
+// Paramètres:
+//   - ctx: Le contexte pour l'annulation et les délais.
+//   - cfg: La configuration de relance.
+//   - fn: La fonction à exécuter, recevant le contexte de sa tentative.
+//   - onRetry: La fonction de rappel exécutée après un échec (peut être nil).
+//
+// Retourne:
+//   - Result: Le résultat de l'opération.
+func run(ctx context.Context, cfg Config, fn func(ctx context.Context) error, onRetry func(attempt int, err error, nextDelay time.Duration)) Result {
+	result := attempts(ctx, cfg, fn, onRetry)
+	if cfg.Observer == nil {
+		return result
+	}
+	if result.Err != nil {
+		cfg.Observer.OnGiveUp(result)
+	} else if s, ok := cfg.Observer.(SuccessObserver); ok {
+		s.OnSuccess(result)
+	}
+	return result
+}
+
+// attempts exécute les tentatives de run et notifie cfg.Observer de chaque
+// tentative et relance.
+//
+// Paramètres:
+//   - ctx: Le contexte pour l'annulation et les délais.
+//   - cfg: La configuration de relance.
+//   - fn: La fonction à exécuter, recevant le contexte de sa tentative.
+//   - onRetry: La fonction de rappel exécutée après un échec (peut être nil).
+//
+// Retourne:
+//   - Result: Le résultat de l'opération.
+func attempts(ctx context.Context, cfg Config, fn func(ctx context.Context) error, onRetry func(attempt int, err error, nextDelay time.Duration)) Result {
 	start := time.Now()
 	var lastErr error
+	var delay time.Duration
 
 	for attempt := 1; attempt <= cfg.MaxAttempts; attempt++ {
+		// Vérification de l'annulation du contexte
 		select {
 		case <-ctx.Done():
 			return Result{
@@ -206,7 +242,11 @@ func DoWithCallback(ctx context.Context, cfg Config, fn func() error, onRetry fu
 		default:
 		}
 
-		err := fn()
+		// Exécution de la fonction
+		if cfg.Observer != nil {
+			cfg.Observer.OnAttempt(attempt)
+		}
+		timedOut, err := runAttempt(ctx, cfg.AttemptTimeout, fn)
 		if err == nil {
 			return Result{
 				Attempts: attempt,
@@ -217,7 +257,12 @@ func DoWithCallback(ctx context.Context, cfg Config, fn func() error, onRetry fu
 
 		lastErr = err
 
-		if IsPermanent(err) {
+		// Ne pas relancer les erreurs permanentes
+		decision := classify(err, cfg)
+		if timedOut && !IsPermanent(err) {
+			decision = DecisionRetry
+		}
+		if decision.Permanent {
 			return Result{
 				Attempts: attempt,
 				Duration: time.Since(start),
@@ -225,8 +270,20 @@ func DoWithCallback(ctx context.Context, cfg Config, fn func() error, onRetry fu
 			}
 		}
 
+		// Ne pas dormir après la dernière tentative
 		if attempt < cfg.MaxAttempts {
-			delay := calculateDelay(attempt, cfg)
+			if !cfg.Budget.Acquire() {
+				return Result{
+					Attempts:        attempt,
+					Duration:        time.Since(start),
+					Err:             err,
+					BudgetExhausted: true,
+				}
+			}
+			delay = nextDelay(attempt, delay, decision, cfg)
+			if cfg.Observer != nil {
+				cfg.Observer.OnRetry(attempt, err, delay)
+			}
 			if onRetry != nil {
 				onRetry(attempt, err, delay)
 			}
@@ -248,3 +305,47 @@ func DoWithCallback(ctx context.Context, cfg Config, fn func() error, onRetry fu
 		Err:      lastErr,
 	}
 }
+
+// runAttempt exécute une tentative, sous son propre délai si timeout est positif.
+//
+// Paramètres:
+//   - ctx: Le contexte de l'opération.
+//   - timeout: Le délai de la tentative (0 : délai de ctx).
+//   - fn: La fonction à exécuter.
+//
+// Retourne:
+//   - bool: Vrai si la tentative a échoué après l'expiration de son propre délai, ctx restant valide.
+//   - error: L'erreur de la tentative.
+func runAttempt(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) (bool, error) {
+	if timeout <= 0 {
+		return false, fn(ctx)
+	}
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := fn(attemptCtx)
+	timedOut := err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded)
+	return timedOut, err
+}
+
+// DoValue est comme Do pour une fonction retournant une valeur typée, avec la
+// même sémantique de backoff et d'erreurs permanentes.
+//
+// Paramètres:
+//   - ctx: Le contexte pour l'annulation et les délais.
+//   - cfg: La configuration de relance.
+//   - fn: La fonction à exécuter, retournant une valeur et une erreur.
+//
+// Retourne:
+//   - T: La valeur de la tentative réussie (valeur zéro de T en cas d'échec).
+//   - Result: Le résultat contenant le nombre de tentatives, la durée et l'erreur finale.
+func DoValue[T any](ctx context.Context, cfg Config, fn func() (T, error)) (T, Result) {
+	var value T
+	result := Do(ctx, cfg, func() error {
+		v, err := fn()
+		if err == nil {
+			value = v
+		}
+		return err
+	})
+	return value, result
+}
@@ -52,3 +52,43 @@ func TestPermanentErrorMethod(t *testing.T) {
 func TestPermanentNil(t *testing.T) {
 	assert.Nil(t, Permanent(nil))
 }
+
+// TestDoContextAttemptTimeout vérifie qu'une tentative bloquée est interrompue et relancée.
+func TestDoContextAttemptTimeout(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.InitialDelay = time.Millisecond
+	cfg.AttemptTimeout = 20 * time.Millisecond
+	cfg.Classifier = DefaultClassifier
+
+	calls := 0
+	result := DoContext(context.Background(), cfg, func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			<-ctx.Done() // appel bloqué jusqu'au délai de la tentative
+			return ctx.Err()
+		}
+		return nil
+	})
+
+	assert.NoError(t, result.Err)
+	assert.Equal(t, 2, result.Attempts)
+}
+
+// TestDoContextParentDeadline vérifie que l'expiration du contexte de l'opération reste définitive.
+func TestDoContextParentDeadline(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxAttempts = 5
+	cfg.InitialDelay = time.Millisecond
+	cfg.AttemptTimeout = time.Second
+	cfg.Classifier = DefaultClassifier
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	result := DoContext(ctx, cfg, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	assert.ErrorIs(t, result.Err, context.DeadlineExceeded)
+	assert.Equal(t, 1, result.Attempts)
+}
@@ -172,3 +172,110 @@ func TestCalculateDelay(t *testing.T) {
 		t.Errorf("Third delay out of range: %v", delay3)
 	}
 }
+
+func TestDoValue(t *testing.T) {
+	cfg := Config{
+		MaxAttempts:  3,
+		InitialDelay: time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		Multiplier:   2.0,
+	}
+
+	callCount := 0
+	value, result := DoValue(context.Background(), cfg, func() (int, error) {
+		callCount++
+		if callCount < 2 {
+			return -1, errors.New("temporary error")
+		}
+		return 42, nil
+	})
+
+	if result.Err != nil {
+		t.Errorf("Expected no error, got %v", result.Err)
+	}
+	if value != 42 {
+		t.Errorf("Expected value 42, got %d", value)
+	}
+	if result.Attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", result.Attempts)
+	}
+}
+
+func TestDoValueFailureReturnsZero(t *testing.T) {
+	cfg := Config{
+		MaxAttempts:  2,
+		InitialDelay: time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		Multiplier:   2.0,
+	}
+
+	value, result := DoValue(context.Background(), cfg, func() (string, error) {
+		return "partial", Permanent(errors.New("invalid"))
+	})
+
+	if !IsPermanent(result.Err) {
+		t.Errorf("Expected permanent error, got %v", result.Err)
+	}
+	if value != "" {
+		t.Errorf("Expected zero value, got %q", value)
+	}
+	if result.Attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", result.Attempts)
+	}
+}
+
+func TestDoBudgetExhausted(t *testing.T) {
+	budget := NewBudget(2, time.Minute)
+	cfg := Config{
+		MaxAttempts:  5,
+		InitialDelay: time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		Multiplier:   2.0,
+		Budget:       budget,
+	}
+	fail := errors.New("broker down")
+
+	// Le premier appel consomme tout le budget
+	result := Do(context.Background(), cfg, func() error { return fail })
+	if !result.BudgetExhausted || result.Attempts != 3 || result.Err != fail {
+		t.Errorf("Expected 3 attempts then an exhausted budget, got %+v", result)
+	}
+
+	// Les appels suivants échouent dès la première tentative
+	callCount := 0
+	result = DoWithCallback(context.Background(), cfg, func() error {
+		callCount++
+		return fail
+	}, nil)
+	if !result.BudgetExhausted || callCount != 1 {
+		t.Errorf("Expected fail-fast after 1 call, got %d calls and %+v", callCount, result)
+	}
+
+	// Un succès ne consomme pas de budget
+	result = Do(context.Background(), cfg, func() error { return nil })
+	if result.Err != nil || result.BudgetExhausted {
+		t.Errorf("Expected success without budget, got %+v", result)
+	}
+}
+
+func TestBudgetWindow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	budget := NewBudget(1, time.Second)
+	budget.now = func() time.Time { return now }
+
+	if !budget.Acquire() {
+		t.Fatal("Expected the first retry to be allowed")
+	}
+	if budget.Acquire() || budget.Remaining() != 0 {
+		t.Error("Expected the budget to be exhausted")
+	}
+	now = now.Add(time.Second)
+	if budget.Remaining() != 1 || !budget.Acquire() {
+		t.Error("Expected the budget to refill in a new window")
+	}
+
+	var unlimited *Budget
+	if !unlimited.Acquire() || unlimited.Remaining() != -1 {
+		t.Error("Expected a nil budget to be unlimited")
+	}
+}
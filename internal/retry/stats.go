@@ -0,0 +1,138 @@
+package retry
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultStats est le registre de statistiques de relance partagé du processus,
+// exporté dans les métriques périodiques du tracker.
+var DefaultStats = NewStatsRegistry()
+
+// OperationStats contient les statistiques de relance d'une opération nommée.
+type OperationStats struct {
+	Calls          int64   `json:"calls"`           // Exécutions de l'opération (Do, DoContext...).
+	Attempts       int64   `json:"attempts"`        // Tentatives, première incluse.
+	Successes      int64   `json:"successes"`       // Exécutions réussies.
+	GiveUps        int64   `json:"give_ups"`        // Exécutions abandonnées (voir Observer.OnGiveUp).
+	BackoffSeconds float64 `json:"backoff_seconds"` // Temps total d'attente entre les tentatives.
+}
+
+// StatsRegistry agrège les statistiques de relance par opération nommée. Il
+// s'attache à une opération par son observateur :
+//
+//	cfg.Observer = retry.DefaultStats.Observer("dlq.recover")
+type StatsRegistry struct {
+	mu  sync.Mutex
+	ops map[string]*OperationStats
+}
+
+// NewStatsRegistry crée un registre vide.
+//
+// Retourne:
+//   - *StatsRegistry: Le registre.
+func NewStatsRegistry() *StatsRegistry {
+	return &StatsRegistry{ops: make(map[string]*OperationStats)}
+}
+
+// Observer retourne l'observateur enregistrant les statistiques d'une opération.
+//
+// Paramètres:
+//   - name: Le nom de l'opération (ex. producer.produce).
+//
+// Retourne:
+//   - Observer: L'observateur, à placer dans Config.Observer (éventuellement via Observers).
+func (r *StatsRegistry) Observer(name string) Observer {
+	return &statsObserver{registry: r, name: name}
+}
+
+// Get retourne les statistiques d'une opération.
+//
+// Paramètres:
+//   - name: Le nom de l'opération.
+//
+// Retourne:
+//   - OperationStats: Les statistiques (nulles si l'opération n'a jamais été exécutée).
+func (r *StatsRegistry) Get(name string) OperationStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stats, ok := r.ops[name]; ok {
+		return *stats
+	}
+	return OperationStats{}
+}
+
+// Snapshot retourne les statistiques de toutes les opérations.
+//
+// Retourne:
+//   - map[string]OperationStats: Une copie des statistiques, par nom d'opération.
+func (r *StatsRegistry) Snapshot() map[string]OperationStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	snapshot := make(map[string]OperationStats, len(r.ops))
+	for name, stats := range r.ops {
+		snapshot[name] = *stats
+	}
+	return snapshot
+}
+
+// update modifie les statistiques d'une opération sous le verrou du registre.
+//
+// Paramètres:
+//   - name: Le nom de l'opération.
+//   - fn: La modification.
+func (r *StatsRegistry) update(name string, fn func(*OperationStats)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats, ok := r.ops[name]
+	if !ok {
+		stats = &OperationStats{}
+		r.ops[name] = stats
+	}
+	fn(stats)
+}
+
+// statsObserver enregistre les notifications d'une opération dans un registre.
+type statsObserver struct {
+	registry *StatsRegistry
+	name     string
+}
+
+// OnAttempt compte la tentative, et l'exécution à la première tentative.
+//
+// Paramètres:
+//   - attempt: Le numéro de la tentative.
+func (o *statsObserver) OnAttempt(attempt int) {
+	o.registry.update(o.name, func(s *OperationStats) {
+		s.Attempts++
+		if attempt == 1 {
+			s.Calls++
+		}
+	})
+}
+
+// OnRetry cumule le délai avant la relance.
+//
+// Paramètres:
+//   - attempt: Le numéro de la tentative échouée.
+//   - err: L'erreur de la tentative.
+//   - delay: Le délai avant la relance.
+func (o *statsObserver) OnRetry(attempt int, err error, delay time.Duration) {
+	o.registry.update(o.name, func(s *OperationStats) { s.BackoffSeconds += delay.Seconds() })
+}
+
+// OnGiveUp compte l'abandon.
+//
+// Paramètres:
+//   - result: Le résultat de l'opération abandonnée.
+func (o *statsObserver) OnGiveUp(result Result) {
+	o.registry.update(o.name, func(s *OperationStats) { s.GiveUps++ })
+}
+
+// OnSuccess compte le succès.
+//
+// Paramètres:
+//   - result: Le résultat de l'opération réussie.
+func (o *statsObserver) OnSuccess(result Result) {
+	o.registry.update(o.name, func(s *OperationStats) { s.Successes++ })
+}
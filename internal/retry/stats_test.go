@@ -0,0 +1,42 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStatsRegistry vérifie l'agrégation des tentatives, succès, abandons et attentes par opération.
+func TestStatsRegistry(t *testing.T) {
+	registry := NewStatsRegistry()
+	cfg := Config{MaxAttempts: 3, Backoff: ConstantBackoff{Interval: time.Millisecond}}
+
+	cfg.Observer = registry.Observer("produce")
+	calls := 0
+	Do(context.Background(), cfg, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("ko")
+		}
+		return nil
+	})
+	Do(context.Background(), cfg, func() error { return Permanent(errors.New("invalide")) })
+
+	cfg.Observer = Observers{registry.Observer("read")}
+	Do(context.Background(), cfg, func() error { return nil })
+
+	produce := registry.Get("produce")
+	assert.Equal(t, int64(2), produce.Calls)
+	assert.Equal(t, int64(4), produce.Attempts)
+	assert.Equal(t, int64(1), produce.Successes)
+	assert.Equal(t, int64(1), produce.GiveUps)
+	assert.InDelta(t, 0.002, produce.BackoffSeconds, 1e-9)
+
+	snapshot := registry.Snapshot()
+	assert.Len(t, snapshot, 2)
+	assert.Equal(t, int64(1), snapshot["read"].Successes, "Expected Observers to forward OnSuccess")
+	assert.Zero(t, registry.Get("absent"))
+}
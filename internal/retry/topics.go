@@ -0,0 +1,134 @@
+package retry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// En-têtes des messages publiés sur les topics de relance.
+const (
+	// HeaderRetryAt porte l'heure (RFC 3339) à partir de laquelle le message peut être réinjecté.
+	HeaderRetryAt = "retry-at"
+	// HeaderOriginalTopic porte le topic dans lequel le message est réinjecté.
+	HeaderOriginalTopic = "original-topic"
+	// HeaderRetryTier porte le palier (à partir de 1) du dernier topic de relance traversé.
+	HeaderRetryTier = "retry-tier"
+	// HeaderError porte la dernière erreur de traitement.
+	HeaderError = "error"
+)
+
+// RetryTiers décrit les topics de relance non bloquante d'un topic principal :
+// un message en échec est publié sur le topic du palier suivant (ex.
+// orders-retry-1m puis orders-retry-5m), puis envoyé à la DLQ après le dernier.
+type RetryTiers struct {
+	Topic  string          // Topic principal (ex. orders).
+	Delays []time.Duration // Délai de chaque palier, dans l'ordre des relances.
+}
+
+// RetryTopicName retourne le nom du topic de relance d'un délai.
+//
+// Paramètres:
+//   - topic: Le topic principal.
+//   - delay: Le délai du palier.
+//
+// Retourne:
+//   - string: Le nom du topic (ex. orders-retry-1m pour une minute).
+func RetryTopicName(topic string, delay time.Duration) string {
+	return topic + "-retry-" + formatTierDelay(delay)
+}
+
+// formatTierDelay formate un délai sans unités nulles en fin de durée
+// (1m plutôt que 1m0s, 1h plutôt que 1h0m0s).
+//
+// Paramètres:
+//   - delay: Le délai.
+//
+// Retourne:
+//   - string: Le délai formaté.
+func formatTierDelay(delay time.Duration) string {
+	s := delay.String()
+	if strings.HasSuffix(s, "m0s") || strings.HasSuffix(s, "h0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// Topics retourne les noms des topics de relance, dans l'ordre des paliers.
+//
+// Retourne:
+//   - []string: Les noms des topics.
+func (r RetryTiers) Topics() []string {
+	topics := make([]string, len(r.Delays))
+	for i, delay := range r.Delays {
+		topics[i] = RetryTopicName(r.Topic, delay)
+	}
+	return topics
+}
+
+// Next retourne le palier suivant un palier donné.
+//
+// Paramètres:
+//   - tier: Le palier courant (0 pour un message jamais relancé).
+//
+// Retourne:
+//   - int: Le palier suivant (à partir de 1).
+//   - string: Le topic de relance de ce palier.
+//   - time.Duration: Le délai de ce palier.
+//   - bool: Faux si le dernier palier est déjà atteint (le message part en DLQ).
+func (r RetryTiers) Next(tier int) (int, string, time.Duration, bool) {
+	if tier < 0 {
+		tier = 0
+	}
+	if tier >= len(r.Delays) {
+		return 0, "", 0, false
+	}
+	delay := r.Delays[tier]
+	return tier + 1, RetryTopicName(r.Topic, delay), delay, true
+}
+
+// formatRetryAt formate la valeur de l'en-tête retry-at.
+//
+// Paramètres:
+//   - at: L'heure de réinjection.
+//
+// Retourne:
+//   - string: L'heure au format RFC 3339 (UTC, nanosecondes).
+func formatRetryAt(at time.Time) string {
+	return at.UTC().Format(time.RFC3339Nano)
+}
+
+// parseRetryAt lit la valeur de l'en-tête retry-at.
+//
+// Paramètres:
+//   - value: La valeur de l'en-tête.
+//
+// Retourne:
+//   - time.Time: L'heure de réinjection.
+//   - error: Une erreur si la valeur n'est pas une heure RFC 3339.
+func parseRetryAt(value string) (time.Time, error) {
+	at, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("en-tête %s invalide %q: %w", HeaderRetryAt, value, err)
+	}
+	return at, nil
+}
+
+// parseRetryTier lit la valeur de l'en-tête retry-tier (ou d'un autre compteur, comme reprocess-count).
+//
+// Paramètres:
+//   - value: La valeur de l'en-tête (vide pour un message jamais relancé).
+//
+// Retourne:
+//   - int: Le palier (0 si la valeur est vide ou invalide).
+func parseRetryTier(value string) int {
+	tier, err := strconv.Atoi(value)
+	if err != nil || tier < 0 {
+		return 0
+	}
+	return tier
+}
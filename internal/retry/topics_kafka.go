@@ -0,0 +1,333 @@
+//go:build kafka
+// +build kafka
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// reinjectDeliveryTimeout est l'attente maximale de la confirmation d'une
+// publication sur un topic de relance ou d'une réinjection.
+const reinjectDeliveryTimeout = 10 * time.Second
+
+// messageProducer publie les messages des topics de relance (implémenté par *kafka.Producer).
+type messageProducer interface {
+	Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error
+}
+
+// deadLetterSender reçoit les messages qui ont épuisé les paliers (implémenté par *DeadLetterQueue).
+type deadLetterSender interface {
+	Send(originalMsg *kafka.Message, attempts int, lastErr error) error
+	IsEnabled() bool
+	Topic() string
+}
+
+// TierRouter aiguille les messages en échec : vers la DLQ si l'erreur est
+// permanente ou si le dernier palier est atteint, sinon vers le topic de
+// relance du palier suivant, avec les en-têtes retry-at, original-topic,
+// retry-tier, error et, au premier palier, first-seen. Un message que le topic
+// de relance ne reçoit pas (livraison en échec ou non confirmée à temps) part
+// en DLQ, dont le fichier de secours prend le relais si elle est indisponible.
+type TierRouter struct {
+	producer        messageProducer
+	tiers           RetryTiers
+	dlq             deadLetterSender
+	classifier      Classifier
+	deliveryTimeout time.Duration
+	now             func() time.Time
+}
+
+// NewTierRouter crée un aiguilleur de messages en échec.
+//
+// Paramètres:
+//   - producer: Le producteur publiant sur les topics de relance.
+//   - tiers: Les paliers de relance du topic principal.
+//   - dlq: La DLQ recevant les messages abandonnés.
+//   - classifier: Le classificateur des erreurs (nil : seules les PermanentError vont directement en DLQ).
+//
+// Retourne:
+//   - *TierRouter: L'aiguilleur.
+func NewTierRouter(producer messageProducer, tiers RetryTiers, dlq *DeadLetterQueue, classifier Classifier) *TierRouter {
+	return &TierRouter{producer: producer, tiers: tiers, dlq: dlq, classifier: classifier, deliveryTimeout: reinjectDeliveryTimeout, now: time.Now}
+}
+
+// Route publie un message en échec sur le topic de relance du palier suivant,
+// et attend sa confirmation de livraison, ou l'envoie à la DLQ.
+//
+// Paramètres:
+//   - msg: Le message dont le traitement a échoué.
+//   - err: L'erreur de traitement.
+//
+// Retourne:
+//   - string: Le topic de destination ("" si le message est abandonné, DLQ désactivée).
+//   - error: Une erreur si ni le topic de relance ni la DLQ ne reçoivent le message.
+func (r *TierRouter) Route(msg *kafka.Message, err error) (string, error) {
+	tier := parseRetryTier(headerValue(msg.Headers, HeaderRetryTier))
+	next, topic, delay, ok := r.tiers.Next(tier)
+	if classify(err, Config{Classifier: r.classifier}).Permanent {
+		ok = false
+	}
+	if !ok {
+		if !r.dlq.IsEnabled() {
+			return "", nil
+		}
+		if sendErr := r.dlq.Send(msg, tier+1, err); sendErr != nil {
+			return "", sendErr
+		}
+		return r.dlq.Topic(), nil
+	}
+
+	originalTopic := r.tiers.Topic
+	if msg.TopicPartition.Topic != nil {
+		originalTopic = *msg.TopicPartition.Topic
+	}
+	headers := withoutRetryHeaders(msg.Headers)
+	headers = append(headers,
+		kafka.Header{Key: HeaderRetryAt, Value: []byte(formatRetryAt(r.now().Add(delay)))},
+		kafka.Header{Key: HeaderOriginalTopic, Value: []byte(originalTopic)},
+		kafka.Header{Key: HeaderRetryTier, Value: []byte(strconv.Itoa(next))},
+		kafka.Header{Key: HeaderError, Value: []byte(err.Error())},
+	)
+	if headerValue(msg.Headers, HeaderFirstSeen) == "" {
+		headers = append(headers, kafka.Header{Key: HeaderFirstSeen, Value: []byte(formatRetryAt(r.now()))})
+	}
+	publishErr := deliver(r.producer, &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Key:            msg.Key,
+		Value:          msg.Value,
+		Headers:        headers,
+	}, r.deliveryTimeout)
+	if publishErr == nil {
+		return topic, nil
+	}
+
+	// Le palier n'a pas reçu le message : la DLQ le conserve plutôt que de le perdre
+	publishErr = fmt.Errorf("échec de la publication sur %s: %w", topic, publishErr)
+	if !r.dlq.IsEnabled() {
+		return "", publishErr
+	}
+	if sendErr := r.dlq.Send(msg, tier+1, err); sendErr != nil {
+		return "", errors.Join(publishErr, sendErr)
+	}
+	return r.dlq.Topic(), nil
+}
+
+// retryConsumerClient regroupe les opérations du consommateur des topics de
+// relance (implémenté par *kafka.Consumer).
+type retryConsumerClient interface {
+	SubscribeTopics(topics []string, rebalanceCb kafka.RebalanceCb) error
+	ReadMessage(timeout time.Duration) (*kafka.Message, error)
+	StoreMessage(m *kafka.Message) ([]kafka.TopicPartition, error)
+	Seek(partition kafka.TopicPartition, ignoredTimeoutMs int) error
+	Pause(partitions []kafka.TopicPartition) error
+	Resume(partitions []kafka.TopicPartition) error
+}
+
+// pausedPartition est une partition de relance suspendue jusqu'à l'heure de
+// réinjection de son prochain message.
+type pausedPartition struct {
+	partition kafka.TopicPartition
+	resumeAt  time.Time
+}
+
+// RetryConsumer consomme les topics de relance et réinjecte chaque message
+// dans son topic d'origine une fois son heure retry-at atteinte. Un message
+// en avance suspend sa partition (les messages d'un palier arrivent dans
+// l'ordre de leur échéance) sans bloquer les autres paliers. L'offset n'est
+// enregistré qu'après la confirmation de livraison de la réinjection : le
+// consommateur doit être créé avec enable.auto.offset.store à false.
+type RetryConsumer struct {
+	consumer        retryConsumerClient
+	producer        messageProducer
+	tiers           RetryTiers
+	pollTimeout     time.Duration
+	deliveryTimeout time.Duration
+	paused          map[string]pausedPartition
+	onReinject      func(msg *kafka.Message, topic string)
+	now             func() time.Time
+}
+
+// NewRetryConsumer crée le consommateur des topics de relance.
+//
+// Paramètres:
+//   - consumer: Le consommateur Kafka (enable.auto.offset.store à false).
+//   - producer: Le producteur réinjectant les messages.
+//   - tiers: Les paliers de relance du topic principal.
+//   - pollTimeout: Le délai de lecture, qui borne aussi le retard de réinjection.
+//   - onReinject: Appelé après chaque réinjection (peut être nil).
+//
+// Retourne:
+//   - *RetryConsumer: Le consommateur.
+func NewRetryConsumer(consumer retryConsumerClient, producer messageProducer, tiers RetryTiers, pollTimeout time.Duration, onReinject func(msg *kafka.Message, topic string)) *RetryConsumer {
+	if onReinject == nil {
+		onReinject = func(*kafka.Message, string) {}
+	}
+	return &RetryConsumer{
+		consumer:        consumer,
+		producer:        producer,
+		tiers:           tiers,
+		pollTimeout:     pollTimeout,
+		deliveryTimeout: reinjectDeliveryTimeout,
+		paused:          make(map[string]pausedPartition),
+		onReinject:      onReinject,
+		now:             time.Now,
+	}
+}
+
+// Run s'abonne aux topics de relance et réinjecte les messages jusqu'à
+// l'annulation du contexte.
+//
+// Paramètres:
+//   - ctx: Le contexte d'arrêt.
+//
+// Retourne:
+//   - error: Une erreur si l'abonnement échoue, nil après l'annulation.
+func (c *RetryConsumer) Run(ctx context.Context) error {
+	if err := c.consumer.SubscribeTopics(c.tiers.Topics(), nil); err != nil {
+		return fmt.Errorf("impossible de s'abonner aux topics de relance: %w", err)
+	}
+	for ctx.Err() == nil {
+		c.poll()
+	}
+	return nil
+}
+
+// poll reprend les partitions arrivées à échéance puis traite le message suivant.
+func (c *RetryConsumer) poll() {
+	c.resumeDue()
+	msg, err := c.consumer.ReadMessage(c.pollTimeout)
+	if err != nil {
+		return // délai expiré ou erreur transitoire : la boucle relit
+	}
+	c.handle(msg)
+}
+
+// resumeDue reprend la lecture des partitions dont l'échéance est atteinte.
+func (c *RetryConsumer) resumeDue() {
+	now := c.now()
+	for key, p := range c.paused {
+		if now.Before(p.resumeAt) {
+			continue
+		}
+		if c.consumer.Resume([]kafka.TopicPartition{p.partition}) == nil {
+			delete(c.paused, key)
+		}
+	}
+}
+
+// handle réinjecte un message arrivé à échéance, ou suspend sa partition en
+// la repositionnant sur ce message.
+//
+// Paramètres:
+//   - msg: Le message lu sur un topic de relance.
+func (c *RetryConsumer) handle(msg *kafka.Message) {
+	if at, err := parseRetryAt(headerValue(msg.Headers, HeaderRetryAt)); err == nil && c.now().Before(at) {
+		partition := msg.TopicPartition
+		partition.Error = nil
+		if c.consumer.Pause([]kafka.TopicPartition{partition}) != nil {
+			return
+		}
+		c.consumer.Seek(partition, 0)
+		c.paused[partitionKey(partition)] = pausedPartition{partition: partition, resumeAt: at}
+		return
+	}
+
+	topic := headerValue(msg.Headers, HeaderOriginalTopic)
+	if topic == "" {
+		topic = c.tiers.Topic
+	}
+	if err := c.reinject(msg, topic); err != nil {
+		// Relire le message au prochain tour plutôt que de le perdre
+		partition := msg.TopicPartition
+		partition.Error = nil
+		c.consumer.Seek(partition, 0)
+		return
+	}
+	c.consumer.StoreMessage(msg)
+	c.onReinject(msg, topic)
+}
+
+// reinject publie un message dans son topic d'origine et attend sa
+// confirmation de livraison.
+//
+// Paramètres:
+//   - msg: Le message lu sur un topic de relance.
+//   - topic: Le topic d'origine.
+//
+// Retourne:
+//   - error: Une erreur si la publication ou la livraison échoue, ou si elle n'est pas confirmée à temps.
+func (c *RetryConsumer) reinject(msg *kafka.Message, topic string) error {
+	return deliver(c.producer, &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Key:            msg.Key,
+		Value:          msg.Value,
+		Headers:        msg.Headers,
+	}, c.deliveryTimeout)
+}
+
+// deliver publie un message et attend sa confirmation de livraison : Produce
+// ne fait que le mettre en file.
+//
+// Paramètres:
+//   - producer: Le producteur.
+//   - msg: Le message à publier.
+//   - timeout: L'attente maximale de la confirmation.
+//
+// Retourne:
+//   - error: Une erreur si la publication ou la livraison échoue, ou si elle n'est pas confirmée à temps.
+func deliver(producer messageProducer, msg *kafka.Message, timeout time.Duration) error {
+	delivery := make(chan kafka.Event, 1)
+	if err := producer.Produce(msg, delivery); err != nil {
+		return err
+	}
+	select {
+	case e := <-delivery:
+		if m, ok := e.(*kafka.Message); ok && m.TopicPartition.Error != nil {
+			return m.TopicPartition.Error
+		}
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("livraison non confirmée après %s", timeout)
+	}
+}
+
+// partitionKey identifie une partition de relance.
+//
+// Paramètres:
+//   - p: La partition.
+//
+// Retourne:
+//   - string: La clé topic/partition.
+func partitionKey(p kafka.TopicPartition) string {
+	topic := ""
+	if p.Topic != nil {
+		topic = *p.Topic
+	}
+	return fmt.Sprintf("%s/%d", topic, p.Partition)
+}
+
+// withoutRetryHeaders copie les en-têtes d'un message sans ceux des topics de relance.
+//
+// Paramètres:
+//   - headers: Les en-têtes du message.
+//
+// Retourne:
+//   - []kafka.Header: Les autres en-têtes.
+func withoutRetryHeaders(headers []kafka.Header) []kafka.Header {
+	kept := make([]kafka.Header, 0, len(headers)+4)
+	for _, h := range headers {
+		switch h.Key {
+		case HeaderRetryAt, HeaderOriginalTopic, HeaderRetryTier, HeaderError:
+			continue
+		}
+		kept = append(kept, h)
+	}
+	return kept
+}
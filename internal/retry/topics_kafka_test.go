@@ -0,0 +1,260 @@
+//go:build kafka
+// +build kafka
+
+package retry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// fakeProducer enregistre les messages publiés.
+type fakeProducer struct {
+	messages    []*kafka.Message
+	err         error
+	deliveryErr error // Erreur des rapports de livraison.
+	noReport    bool  // N'envoie aucun rapport de livraison.
+}
+
+func (p *fakeProducer) Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.messages = append(p.messages, msg)
+	if deliveryChan != nil && !p.noReport {
+		report := *msg
+		report.TopicPartition.Error = p.deliveryErr
+		deliveryChan <- &report
+	}
+	return nil
+}
+
+// fakeDLQ enregistre les messages envoyés à la DLQ.
+type fakeDLQ struct {
+	attempts []int
+	disabled bool
+}
+
+func (d *fakeDLQ) Send(originalMsg *kafka.Message, attempts int, lastErr error) error {
+	d.attempts = append(d.attempts, attempts)
+	return nil
+}
+func (d *fakeDLQ) IsEnabled() bool { return !d.disabled }
+func (d *fakeDLQ) Topic() string   { return "orders-dlq" }
+
+// fakeRetryConsumer enregistre les suspensions, reprises et offsets enregistrés.
+type fakeRetryConsumer struct {
+	paused, resumed, stored int
+	seeks                   []kafka.Offset
+}
+
+func (c *fakeRetryConsumer) SubscribeTopics(topics []string, rebalanceCb kafka.RebalanceCb) error {
+	return nil
+}
+func (c *fakeRetryConsumer) ReadMessage(timeout time.Duration) (*kafka.Message, error) {
+	return nil, kafka.NewError(kafka.ErrTimedOut, "timeout", false)
+}
+func (c *fakeRetryConsumer) StoreMessage(m *kafka.Message) ([]kafka.TopicPartition, error) {
+	c.stored++
+	return nil, nil
+}
+func (c *fakeRetryConsumer) Seek(partition kafka.TopicPartition, ignoredTimeoutMs int) error {
+	c.seeks = append(c.seeks, partition.Offset)
+	return nil
+}
+func (c *fakeRetryConsumer) Pause(partitions []kafka.TopicPartition) error {
+	c.paused++
+	return nil
+}
+func (c *fakeRetryConsumer) Resume(partitions []kafka.TopicPartition) error {
+	c.resumed++
+	return nil
+}
+
+func testTiers() RetryTiers {
+	return RetryTiers{Topic: "orders", Delays: []time.Duration{time.Minute, 5 * time.Minute}}
+}
+
+func TestTierRouterWalksTiersThenDLQ(t *testing.T) {
+	producer := &fakeProducer{}
+	dlq := &fakeDLQ{}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	router := &TierRouter{producer: producer, tiers: testTiers(), dlq: dlq, deliveryTimeout: time.Second, now: func() time.Time { return now }}
+
+	topic := "orders"
+	msg := &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic},
+		Key:            []byte("k"),
+		Value:          []byte(`{}`),
+		Headers:        []kafka.Header{{Key: "trace-id", Value: []byte("t1")}},
+	}
+	failure := errors.New("stock service unavailable")
+
+	dest, err := router.Route(msg, failure)
+	if err != nil || dest != "orders-retry-1m" {
+		t.Fatalf("Expected orders-retry-1m, got %q (%v)", dest, err)
+	}
+	published := producer.messages[0]
+	if got := headerValue(published.Headers, HeaderRetryAt); got != formatRetryAt(now.Add(time.Minute)) {
+		t.Errorf("Unexpected retry-at %s", got)
+	}
+	if headerValue(published.Headers, HeaderRetryTier) != "1" || headerValue(published.Headers, HeaderOriginalTopic) != "orders" {
+		t.Errorf("Unexpected headers %v", published.Headers)
+	}
+	if headerValue(published.Headers, "trace-id") != "t1" || string(published.Key) != "k" {
+		t.Error("Expected the key and other headers to be kept")
+	}
+	firstSeen := formatRetryAt(now)
+	if got := headerValue(published.Headers, HeaderFirstSeen); got != firstSeen {
+		t.Errorf("Expected first-seen %s, got %s", firstSeen, got)
+	}
+
+	// Message réinjecté qui échoue de nouveau : palier suivant, puis DLQ
+	now = now.Add(time.Minute)
+	msg.Headers = published.Headers
+	if dest, _ := router.Route(msg, failure); dest != "orders-retry-5m" {
+		t.Errorf("Expected orders-retry-5m, got %q", dest)
+	}
+	if n := len(producer.messages[1].Headers); n != 6 {
+		t.Errorf("Expected the retry headers to be replaced (6 headers), got %d", n)
+	}
+	if got := headerValue(producer.messages[1].Headers, HeaderFirstSeen); got != firstSeen {
+		t.Errorf("Expected first-seen to be kept (%s), got %s", firstSeen, got)
+	}
+	msg.Headers = producer.messages[1].Headers
+	if dest, _ := router.Route(msg, failure); dest != "orders-dlq" {
+		t.Errorf("Expected orders-dlq after the last tier, got %q", dest)
+	}
+	if len(dlq.attempts) != 1 || dlq.attempts[0] != 3 {
+		t.Errorf("Expected one DLQ message after 3 attempts, got %v", dlq.attempts)
+	}
+}
+
+func TestTierRouterPermanentErrorGoesToDLQ(t *testing.T) {
+	producer := &fakeProducer{}
+	dlq := &fakeDLQ{}
+	router := &TierRouter{producer: producer, tiers: testTiers(), dlq: dlq, classifier: DefaultClassifier, deliveryTimeout: time.Second, now: time.Now}
+
+	topic := "orders"
+	msg := &kafka.Message{TopicPartition: kafka.TopicPartition{Topic: &topic}}
+	if dest, _ := router.Route(msg, Permanent(errors.New("bad order"))); dest != "orders-dlq" {
+		t.Errorf("Expected orders-dlq, got %q", dest)
+	}
+	if len(producer.messages) != 0 {
+		t.Error("Expected no retry topic message for a permanent error")
+	}
+}
+
+func TestTierRouterFailedDeliveryGoesToDLQ(t *testing.T) {
+	topic := "orders"
+	failure := errors.New("stock service unavailable")
+	for name, producer := range map[string]*fakeProducer{
+		"produce error":      {err: errors.New("queue full")},
+		"delivery error":     {deliveryErr: kafka.NewError(kafka.ErrMsgTimedOut, "timed out", false)},
+		"no delivery report": {noReport: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dlq := &fakeDLQ{}
+			router := &TierRouter{producer: producer, tiers: testTiers(), dlq: dlq, deliveryTimeout: time.Millisecond, now: time.Now}
+
+			msg := &kafka.Message{TopicPartition: kafka.TopicPartition{Topic: &topic}}
+			dest, err := router.Route(msg, failure)
+			if err != nil || dest != "orders-dlq" {
+				t.Fatalf("Expected orders-dlq, got %q (%v)", dest, err)
+			}
+			if len(dlq.attempts) != 1 || dlq.attempts[0] != 1 {
+				t.Errorf("Expected one DLQ message after 1 attempt, got %v", dlq.attempts)
+			}
+
+			dlq.disabled = true
+			if dest, err := router.Route(msg, failure); err == nil || dest != "" {
+				t.Errorf("Expected an error without DLQ, got %q (%v)", dest, err)
+			}
+		})
+	}
+}
+
+func TestRetryConsumerDelaysThenReinjects(t *testing.T) {
+	consumer := &fakeRetryConsumer{}
+	producer := &fakeProducer{}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	var reinjected []string
+	c := NewRetryConsumer(consumer, producer, testTiers(), time.Second, func(msg *kafka.Message, topic string) {
+		reinjected = append(reinjected, topic)
+	})
+	c.now = func() time.Time { return now }
+
+	topic := "orders-retry-1m"
+	msg := &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: 0, Offset: 42},
+		Value:          []byte(`{}`),
+		Headers: []kafka.Header{
+			{Key: HeaderRetryAt, Value: []byte(formatRetryAt(now.Add(30 * time.Second)))},
+			{Key: HeaderOriginalTopic, Value: []byte("orders")},
+			{Key: HeaderRetryTier, Value: []byte("1")},
+		},
+	}
+
+	// En avance : partition suspendue et repositionnée sur le message
+	c.handle(msg)
+	if consumer.paused != 1 || len(consumer.seeks) != 1 || consumer.seeks[0] != 42 || len(producer.messages) != 0 {
+		t.Fatalf("Expected the partition to be paused at offset 42, got %+v", consumer)
+	}
+	c.resumeDue()
+	if consumer.resumed != 0 {
+		t.Error("Expected the partition to stay paused before retry-at")
+	}
+
+	// Échéance atteinte : reprise puis réinjection dans le topic d'origine
+	now = now.Add(30 * time.Second)
+	c.resumeDue()
+	if consumer.resumed != 1 || len(c.paused) != 0 {
+		t.Errorf("Expected the partition to be resumed, got %+v", consumer)
+	}
+	c.handle(msg)
+	if len(producer.messages) != 1 || *producer.messages[0].TopicPartition.Topic != "orders" {
+		t.Fatalf("Expected the message to be re-injected into orders, got %v", producer.messages)
+	}
+	if headerValue(producer.messages[0].Headers, HeaderRetryTier) != "1" {
+		t.Error("Expected the retry-tier header to be kept for the next failure")
+	}
+	if consumer.stored != 1 || len(reinjected) != 1 {
+		t.Errorf("Expected the offset to be stored after re-injection, got %d", consumer.stored)
+	}
+}
+
+func TestRetryConsumerKeepsMessageOnProduceError(t *testing.T) {
+	consumer := &fakeRetryConsumer{}
+	producer := &fakeProducer{err: kafka.NewError(kafka.ErrQueueFull, "queue full", false)}
+	c := NewRetryConsumer(consumer, producer, testTiers(), time.Second, nil)
+
+	topic := "orders-retry-1m"
+	c.handle(&kafka.Message{TopicPartition: kafka.TopicPartition{Topic: &topic, Offset: 7}})
+	if consumer.stored != 0 || len(consumer.seeks) != 1 || consumer.seeks[0] != 7 {
+		t.Errorf("Expected the message to be read again, got %+v", consumer)
+	}
+}
+
+func TestRetryConsumerKeepsMessageOnDeliveryError(t *testing.T) {
+	topic := "orders-retry-1m"
+	for name, producer := range map[string]*fakeProducer{
+		"delivery error": {deliveryErr: kafka.NewError(kafka.ErrMsgTimedOut, "message timed out", false)},
+		"no report":      {noReport: true},
+	} {
+		consumer := &fakeRetryConsumer{}
+		reinjected := 0
+		c := NewRetryConsumer(consumer, producer, testTiers(), time.Second, func(*kafka.Message, string) { reinjected++ })
+		c.deliveryTimeout = time.Millisecond
+
+		c.handle(&kafka.Message{TopicPartition: kafka.TopicPartition{Topic: &topic, Offset: 9}})
+		if len(producer.messages) != 1 {
+			t.Fatalf("%s: expected a re-injection attempt, got %d", name, len(producer.messages))
+		}
+		if consumer.stored != 0 || reinjected != 0 || len(consumer.seeks) != 1 || consumer.seeks[0] != 9 {
+			t.Errorf("%s: expected the message to be read again without storing its offset, got %+v", name, consumer)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package retry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryTopicName(t *testing.T) {
+	tests := []struct {
+		delay time.Duration
+		want  string
+	}{
+		{time.Minute, "orders-retry-1m"},
+		{5 * time.Minute, "orders-retry-5m"},
+		{90 * time.Second, "orders-retry-1m30s"},
+		{10 * time.Second, "orders-retry-10s"},
+		{time.Hour, "orders-retry-1h"},
+		{500 * time.Millisecond, "orders-retry-500ms"},
+	}
+	for _, tt := range tests {
+		if got := RetryTopicName("orders", tt.delay); got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.delay, tt.want, got)
+		}
+	}
+}
+
+func TestRetryTiersNext(t *testing.T) {
+	tiers := RetryTiers{Topic: "orders", Delays: []time.Duration{time.Minute, 5 * time.Minute}}
+
+	next, topic, delay, ok := tiers.Next(0)
+	if !ok || next != 1 || topic != "orders-retry-1m" || delay != time.Minute {
+		t.Errorf("tier 0: got %d %s %s %v", next, topic, delay, ok)
+	}
+	next, topic, delay, ok = tiers.Next(1)
+	if !ok || next != 2 || topic != "orders-retry-5m" || delay != 5*time.Minute {
+		t.Errorf("tier 1: got %d %s %s %v", next, topic, delay, ok)
+	}
+	if _, _, _, ok := tiers.Next(2); ok {
+		t.Error("Expected no tier after the last one")
+	}
+	if got := tiers.Topics(); len(got) != 2 || got[0] != "orders-retry-1m" || got[1] != "orders-retry-5m" {
+		t.Errorf("Unexpected topics %v", got)
+	}
+}
+
+func TestRetryHeaders(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 500, time.UTC)
+	parsed, err := parseRetryAt(formatRetryAt(at))
+	if err != nil || !parsed.Equal(at) {
+		t.Errorf("Expected %s, got %s (%v)", at, parsed, err)
+	}
+	if _, err := parseRetryAt("soon"); err == nil {
+		t.Error("Expected error for an invalid retry-at")
+	}
+	for value, want := range map[string]int{"": 0, "2": 2, "x": 0, "-1": 0} {
+		if got := parseRetryTier(value); got != want {
+			t.Errorf("parseRetryTier(%q): expected %d, got %d", value, want, got)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package tracker
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"strings"
 	"testing"
@@ -21,22 +22,22 @@ func TestTracker_Run_LogsGenericErrors(t *testing.T) {
 	tracker := newTestTracker(&eventBuf, &logBuf)
 
 	// Create and inject mock consumer
-	mockConsumer := new(MockKafkaConsumer)
+	mockConsumer := new(MockSubscriber)
 	tracker.consumer = mockConsumer
 
 	// Define the generic error we expect to see
 	genericErr := errors.New("something went wrong")
 
 	// 2. Expectation
-	// The Run loop calls ReadMessage. We make it return the generic error.
+	// The Run loop calls Receive. We make it return the generic error.
 	// We use .Maybe() or .Times() because it might be called in a loop.
 	// Since the bug is a busy loop, it will be called MANY times.
 	// We just need it to answer at least once.
-	mockConsumer.On("ReadMessage", mock.AnythingOfType("time.Duration")).Return(nil, genericErr)
+	mockConsumer.On("Receive", mock.AnythingOfType("time.Duration")).Return(nil, genericErr)
 
 	// 3. Execution
 	// Run tracker in background
-	go tracker.Run()
+	go tracker.Run(context.Background())
 
 	// Give it a moment to loop a few times
 	time.Sleep(100 * time.Millisecond)
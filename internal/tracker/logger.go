@@ -2,21 +2,22 @@ package tracker
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"sync"
-	"time"
 
 	"github.com/agbruneau/PubSub/internal/config"
 	"github.com/agbruneau/PubSub/pkg/models"
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/agbruneau/PubSub/pkg/transport"
 )
 
 // Logger gère l'écriture concurrente et sécurisée dans un fichier de log.
 type Logger struct {
-	file    *os.File      // Le descripteur de fichier.
-	encoder *json.Encoder // L'encodeur JSON pour écrire dans le fichier.
-	mu      sync.Mutex    // Mutex pour assurer l'écriture thread-safe.
+	file      *os.File      // Le descripteur de fichier.
+	encoder   *json.Encoder // L'encodeur JSON pour écrire dans le fichier.
+	mu        sync.Mutex    // Mutex pour assurer l'écriture thread-safe.
+	redactPII bool          // Masque les données personnelles des événements (voir LogEvent).
 }
 
 // NewLogger initialise un nouveau Logger pour un fichier donné.
@@ -45,19 +46,7 @@ func NewLogger(filename string) (*Logger, error) {
 //   - message: Le message principal.
 //   - metadata: Des données contextuelles supplémentaires.
 func (l *Logger) Log(level models.LogLevel, message string, metadata map[string]interface{}) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	entry := models.LogEntry{
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Level:     level,
-		Message:   message,
-		Service:   config.TrackerServiceName,
-		Metadata:  metadata,
-	}
-	if err := l.encoder.Encode(entry); err != nil {
-		fmt.Fprintf(os.Stderr, "Erreur d'encodage du log: %v\n", err)
-	}
+	l.LogWithTrace(level, message, nil, models.TraceContext{}, metadata)
 }
 
 // LogError est un raccourci pour écrire un message d'erreur dans le fichier journal.
@@ -67,57 +56,93 @@ func (l *Logger) Log(level models.LogLevel, message string, metadata map[string]
 //   - err: L'erreur originale.
 //   - metadata: Des données contextuelles supplémentaires.
 func (l *Logger) LogError(message string, err error, metadata map[string]interface{}) {
+	l.LogWithTrace(models.LogLevelERROR, message, err, models.TraceContext{}, metadata)
+}
+
+// LogWithTrace écrit une entrée structurée liée à la trace distribuée du
+// message traité, pour la joindre aux traces des autres services.
+//
+// Paramètres:
+//   - level: Le niveau de sévérité du log (INFO, ERROR).
+//   - message: Le message principal.
+//   - err: L'erreur originale (nil si aucune).
+//   - trace: Le contexte de trace du message (vide si aucun).
+//   - metadata: Des données contextuelles supplémentaires.
+func (l *Logger) LogWithTrace(level models.LogLevel, message string, err error, trace models.TraceContext, metadata map[string]interface{}) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	if metadata == nil {
-		metadata = make(map[string]interface{})
-	}
 	entry := models.LogEntry{
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Level:     models.LogLevelERROR,
-		Message:   message,
-		Service:   config.TrackerServiceName,
-		Error:     err.Error(),
-		Metadata:  metadata,
+		Timestamp:     models.Now(),
+		Level:         level,
+		Message:       message,
+		Service:       config.TrackerServiceName,
+		TraceID:       trace.TraceID,
+		SpanID:        trace.SpanID,
+		CorrelationID: trace.CorrelationID,
+		Metadata:      metadata,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		entry.ErrorCode, _ = models.CodeOf(err)
+		if entry.Metadata == nil {
+			entry.Metadata = make(map[string]interface{})
+		}
 	}
 	if encodeErr := l.encoder.Encode(entry); encodeErr != nil {
-		fmt.Fprintf(os.Stderr, "Erreur d'encodage du log d'erreur: %v\n", encodeErr)
+		fmt.Fprintf(os.Stderr, "Erreur d'encodage du log: %v\n", encodeErr)
 	}
 }
 
 // LogEvent écrit un enregistrement complet de message dans le fichier d'événements.
 // Cette fonction est le cœur de l'implémentation du modèle "Audit Trail".
 // Elle est appelée pour CHAQUE message reçu, valide ou non, garantissant
-// qu'aucune donnée entrante n'est perdue.
+// qu'aucune donnée entrante n'est perdue. Si redactPII est activé, la
+// commande est écrite masquée (models.Order.MaskForLogging) et le message brut,
+// qui ne peut pas être masqué, n'est pas écrit.
 //
 // Paramètres:
-//   - msg: Le message Kafka brut.
+//   - msg: Le message brut.
 //   - order: La commande désérialisée (peut être nil si échec).
-//   - deserializationError: L'erreur de désérialisation éventuelle.
-func (l *Logger) LogEvent(msg *kafka.Message, order *models.Order, deserializationError error) {
+//   - trace: Le contexte de trace du message.
+//   - deserializationError: L'erreur de désérialisation éventuelle ; une erreur
+//     models.ErrSchemaMismatch donne un événement "message.received.schema_mismatch".
+func (l *Logger) LogEvent(msg *transport.Message, order *models.Order, trace models.TraceContext, deserializationError error) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
 	eventType := "message.received"
 	deserialized := order != nil
 
-	if deserializationError != nil {
+	if errors.Is(deserializationError, models.ErrSchemaMismatch) {
+		eventType = "message.received.schema_mismatch"
+	} else if deserializationError != nil {
 		eventType = "message.received.deserialization_error"
 	}
 
 	event := models.EventEntry{
-		Timestamp:      time.Now().UTC().Format(time.RFC3339),
+		Timestamp:      models.Now(),
 		EventType:      eventType,
-		KafkaTopic:     *msg.TopicPartition.Topic,
-		KafkaPartition: msg.TopicPartition.Partition,
-		KafkaOffset:    int64(msg.TopicPartition.Offset),
+		TraceID:        trace.TraceID,
+		SpanID:         trace.SpanID,
+		CorrelationID:  trace.CorrelationID,
+		KafkaTopic:     msg.Topic,
+		KafkaPartition: msg.Partition,
+		KafkaOffset:    msg.Offset,
 		RawMessage:     string(msg.Value),
 		MessageSize:    len(msg.Value),
 		Deserialized:   deserialized,
 	}
 
+	if l.redactPII {
+		event.RawMessage = ""
+	}
+
 	if deserialized {
+		if l.redactPII {
+			masked := order.MaskForLogging()
+			order = &masked
+		}
 		orderJSON, marshalErr := json.Marshal(order)
 		if marshalErr != nil {
 			fmt.Fprintf(os.Stderr, "Erreur de sérialisation de la commande: %v\n", marshalErr)
@@ -128,6 +153,7 @@ func (l *Logger) LogEvent(msg *kafka.Message, order *models.Order, deserializati
 
 	if deserializationError != nil {
 		event.Error = deserializationError.Error()
+		event.ErrorCode, _ = models.CodeOf(deserializationError)
 	}
 
 	if err := l.encoder.Encode(event); err != nil {
@@ -3,30 +3,65 @@ package tracker
 import (
 	"time"
 
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/agbruneau/PubSub/pkg/transport"
 	"github.com/stretchr/testify/mock"
 )
 
-// MockKafkaConsumer est un mock pour l'interface KafkaConsumer.
-type MockKafkaConsumer struct {
+// MockSubscriber est un mock pour l'interface transport.Subscriber.
+type MockSubscriber struct {
 	mock.Mock
 }
 
-func (m *MockKafkaConsumer) SubscribeTopics(topics []string, rebalanceCb kafka.RebalanceCb) error {
-	args := m.Called(topics, rebalanceCb)
+func (m *MockSubscriber) Subscribe(topics []string) error {
+	args := m.Called(topics)
 	return args.Error(0)
 }
 
-func (m *MockKafkaConsumer) ReadMessage(timeout time.Duration) (*kafka.Message, error) {
+func (m *MockSubscriber) Receive(timeout time.Duration) (*transport.Message, error) {
 	args := m.Called(timeout)
 	msg := args.Get(0)
 	if msg == nil {
 		return nil, args.Error(1)
 	}
-	return msg.(*kafka.Message), args.Error(1)
+	return msg.(*transport.Message), args.Error(1)
 }
 
-func (m *MockKafkaConsumer) Close() error {
+func (m *MockSubscriber) Close() error {
 	args := m.Called()
 	return args.Error(0)
 }
+
+// MockCommitSubscriber est un mock d'un abonné qui enregistre les positions
+// explicitement (transport.Committer), comme le pilote Kafka.
+type MockCommitSubscriber struct {
+	MockSubscriber
+}
+
+func (m *MockCommitSubscriber) Commit(msg *transport.Message) error {
+	args := m.Called(msg)
+	return args.Error(0)
+}
+
+// MockFailureRouter est un mock pour l'interface failureRouter.
+type MockFailureRouter struct {
+	mock.Mock
+}
+
+func (m *MockFailureRouter) Route(msg *transport.Message, err error) (string, error) {
+	args := m.Called(msg, err)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFailureRouter) Start() {
+	m.Called()
+}
+
+func (m *MockFailureRouter) Close() {
+	m.Called()
+}
+
+func (m *MockFailureRouter) DLQStats() retry.DLQStats {
+	args := m.Called()
+	return args.Get(0).(retry.DLQStats)
+}
@@ -0,0 +1,167 @@
+//go:build kafka
+// +build kafka
+
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/agbruneau/PubSub/pkg/transport"
+	"github.com/agbruneau/PubSub/pkg/transport/kafkadriver"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// retryTopics regroupe les composants des topics de relance non bloquante :
+// l'aiguilleur des messages en échec, la DLQ du dernier palier et le
+// consommateur qui réinjecte les messages dans le topic principal.
+type retryTopics struct {
+	producer    *kafka.Producer
+	dlq         *retry.DeadLetterQueue
+	router      *retry.TierRouter
+	rawConsumer *kafka.Consumer
+	consumer    *retry.RetryConsumer
+	recovery    time.Duration
+	onRecover   func(recovered int, err error)
+	ctx         context.Context
+	cancel      context.CancelFunc
+	done        chan struct{}
+	started     bool
+	mu          sync.Mutex
+}
+
+// newRetryTopics crée les topics de relance du tracker.
+//
+// Paramètres:
+//   - cfg: La configuration du tracker (RetryTiers non vide).
+//   - onReinject: Appelé après chaque réinjection.
+//   - onRecover: Appelé après chaque reprise du fichier de secours de la DLQ.
+//
+// Retourne:
+//   - failureRouter: Les topics de relance.
+//   - error: Une erreur si un client Kafka ne peut pas être créé.
+func newRetryTopics(cfg *Config, onReinject func(msg *transport.Message, topic string), onRecover func(recovered int, err error)) (failureRouter, error) {
+	producerMap := kafka.ConfigMap{"bootstrap.servers": cfg.KafkaBroker}
+	for name, value := range cfg.ProducerProperties {
+		producerMap[name] = value
+	}
+	producer, err := kafka.NewProducer(&producerMap)
+	if err != nil {
+		return nil, fmt.Errorf("échec de la création du producteur des relances: %w", err)
+	}
+	go drainEvents(producer)
+
+	dlq, err := retry.NewDeadLetterQueue(cfg.KafkaBroker, cfg.DLQTopic, cfg.DLQTopic != "", cfg.ProducerProperties)
+	if err != nil {
+		producer.Close()
+		return nil, err
+	}
+	dlq.SetConfirmTimeout(cfg.DLQConfirmTimeout)
+	dlq.SetSource(cfg.ConsumerGroup, config.TrackerServiceName, retry.DefaultClassifier)
+	dlq.SetFallback(cfg.DLQFallbackFile)
+	dlq.SetRetry(cfg.DLQRetry)
+
+	consumerMap := kafka.ConfigMap{
+		"bootstrap.servers": cfg.KafkaBroker,
+		"group.id":          cfg.ConsumerGroup + config.RetryTopicConsumerGroupSuffix,
+	}
+	for name, value := range cfg.Properties {
+		consumerMap[name] = value
+	}
+	consumerMap["enable.auto.offset.store"] = false // offset enregistré après la réinjection
+	rawConsumer, err := kafka.NewConsumer(&consumerMap)
+	if err != nil {
+		dlq.Close()
+		producer.Close()
+		return nil, fmt.Errorf("échec de la création du consommateur des relances: %w", err)
+	}
+
+	tiers := retry.RetryTiers{Topic: cfg.Topic, Delays: cfg.RetryTiers}
+	reinjected := func(msg *kafka.Message, topic string) {
+		onReinject(kafkadriver.FromKafka(msg), topic)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &retryTopics{
+		producer:    producer,
+		dlq:         dlq,
+		router:      retry.NewTierRouter(producer, tiers, dlq, retry.DefaultClassifier),
+		rawConsumer: rawConsumer,
+		consumer:    retry.NewRetryConsumer(rawConsumer, producer, tiers, cfg.ReadTimeout, reinjected),
+		recovery:    cfg.DLQRecovery,
+		onRecover:   onRecover,
+		ctx:         ctx,
+		cancel:      cancel,
+		done:        make(chan struct{}),
+	}, nil
+}
+
+// drainEvents consomme les événements généraux du producteur des relances,
+// les rapports de livraison passant par les canaux de Route et de la réinjection.
+//
+// Paramètres:
+//   - producer: Le producteur.
+func drainEvents(producer *kafka.Producer) {
+	for range producer.Events() {
+	}
+}
+
+// Route publie un message en échec sur le topic de relance suivant, ou l'envoie à la DLQ.
+//
+// Paramètres:
+//   - msg: Le message en échec.
+//   - err: L'erreur de traitement.
+//
+// Retourne:
+//   - string: Le topic de destination ("" si le message est abandonné).
+//   - error: Une erreur si la publication échoue.
+func (r *retryTopics) Route(msg *transport.Message, err error) (string, error) {
+	return r.router.Route(kafkadriver.ToKafka(msg), err)
+}
+
+// DLQStats retourne les statistiques d'envoi de la DLQ du dernier palier.
+//
+// Retourne:
+//   - retry.DLQStats: Les statistiques (nulles si la DLQ est désactivée).
+func (r *retryTopics) DLQStats() retry.DLQStats {
+	return r.dlq.GetStats()
+}
+
+// Start réinjecte les messages des topics de relance, et reprend le fichier
+// de secours de la DLQ, jusqu'à l'appel de Close.
+func (r *retryTopics) Start() {
+	r.mu.Lock()
+	if r.ctx.Err() != nil {
+		r.mu.Unlock()
+		return // déjà fermé
+	}
+	r.started = true
+	r.mu.Unlock()
+	defer close(r.done)
+
+	recovered := make(chan struct{})
+	go func() {
+		defer close(recovered)
+		r.dlq.RunFallbackRecovery(r.ctx, r.recovery, r.onRecover)
+	}()
+	r.consumer.Run(r.ctx)
+	<-recovered
+}
+
+// Close arrête la réinjection puis ferme les clients Kafka des relances.
+func (r *retryTopics) Close() {
+	r.mu.Lock()
+	r.cancel()
+	started := r.started
+	r.mu.Unlock()
+	if started {
+		<-r.done
+	}
+	r.rawConsumer.Close()
+	r.producer.Flush(int(config.ProducerFlushTimeout.Milliseconds()))
+	r.producer.Close()
+	r.dlq.Close()
+}
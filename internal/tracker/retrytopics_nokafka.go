@@ -0,0 +1,25 @@
+//go:build !kafka
+// +build !kafka
+
+package tracker
+
+import (
+	"errors"
+
+	"github.com/agbruneau/PubSub/pkg/transport"
+)
+
+// newRetryTopics est indisponible sans le tag de compilation "kafka", dont
+// dépendent la DLQ et le consommateur des topics de relance.
+//
+// Paramètres:
+//   - cfg: La configuration du tracker (inutilisée).
+//   - onReinject: Appelé après chaque réinjection (inutilisé).
+//   - onRecover: Appelé après chaque reprise du fichier de secours de la DLQ (inutilisé).
+//
+// Retourne:
+//   - failureRouter: Toujours nil.
+//   - error: Une erreur indiquant de compiler avec -tags kafka.
+func newRetryTopics(cfg *Config, onReinject func(msg *transport.Message, topic string), onRecover func(recovered int, err error)) (failureRouter, error) {
+	return nil, errors.New("retry.topics requiert la compilation avec -tags kafka")
+}
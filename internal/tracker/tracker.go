@@ -1,5 +1,6 @@
 /*
-Package tracker fournit le consommateur de messages Kafka pour le système PubSub.
+Package tracker fournit le consommateur de messages du système PubSub, qui lit
+les commandes au travers d'un transport.Subscriber (Kafka par défaut).
 
 Ce paquet implémente la consommation de messages, la journalisation et la collecte de métriques,
 suivant les modèles "Audit Trail" et "Application Health Monitoring".
@@ -7,29 +8,57 @@ suivant les modèles "Audit Trail" et "Application Health Monitoring".
 package tracker
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/agbruneau/PubSub/internal/chaos"
 	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/drivers"
+	"github.com/agbruneau/PubSub/internal/i18n"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/agbruneau/PubSub/pkg/metrics"
 	"github.com/agbruneau/PubSub/pkg/models"
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/agbruneau/PubSub/pkg/transport"
 )
 
+// ErrProcessingPanic signale une panique récupérée pendant le traitement d'un
+// message, traitée comme un échec de traitement (relance ou DLQ).
+var ErrProcessingPanic = errors.New("panique lors du traitement")
+
+// ErrConsumerStopped signale l'arrêt du consommateur après MaxErrors erreurs de
+// lecture consécutives (broker injoignable).
+var ErrConsumerStopped = errors.New("consommateur arrêté après des erreurs de lecture consécutives")
+
 // Config contient la configuration du service tracker.
 // Elle peut être chargée à partir de variables d'environnement.
 type Config struct {
-	KafkaBroker     string        // Adresse du broker Kafka.
-	ConsumerGroup   string        // Groupe de consommateurs Kafka.
-	Topic           string        // Sujet Kafka à consommer.
-	LogFile         string        // Fichier de journal système.
-	EventsFile      string        // Fichier de piste d'audit.
-	MetricsInterval time.Duration // Intervalle entre les métriques périodiques.
-	ReadTimeout     time.Duration // Délai de lecture des messages.
-	MaxErrors       int           // Nombre maximum d'erreurs consécutives.
+	KafkaBroker     string              // Adresses des brokers d'amorçage, séparées par des virgules.
+	ConsumerGroup   string              // Groupe de consommateurs Kafka.
+	Topic           string              // Sujet Kafka à consommer.
+	LogFile         string              // Fichier de journal système.
+	EventsFile      string              // Fichier de piste d'audit.
+	MetricsInterval time.Duration       // Intervalle entre les métriques périodiques.
+	ReadTimeout     time.Duration       // Délai de lecture des messages.
+	MaxErrors       int                 // Nombre maximum d'erreurs consécutives.
+	Properties      map[string]string   // Propriétés librdkafka (réglage kafka.client et sécurité).
+	Breaker         retry.BreakerConfig // Disjoncteur des lectures Kafka (FailureThreshold nul : désactivé).
+	StrictDecoding  bool                // Rejette les commandes portant des champs inconnus du schéma.
+	NormalizeOrders bool                // Normalise la devise et les montants des commandes reçues.
+	RedactPII       bool                // Masque les données personnelles écrites dans la piste d'audit.
+	ChaosFile       string              // Fichier d'état des pannes injectées par pubsub chaos (vide : désactivé).
+
+	RetryTiers         []time.Duration   // Délais des topics de relance non bloquante (vide : désactivés).
+	DLQTopic           string            // Topic DLQ après le dernier palier (vide : messages abandonnés).
+	DLQConfirmTimeout  time.Duration     // Attente de la confirmation de chaque envoi DLQ (0 : envoi asynchrone).
+	DLQFallbackFile    string            // Fichier de secours des messages que la DLQ ne parvient pas à publier (vide : désactivé).
+	DLQRecovery        time.Duration     // Intervalle de reprise du fichier de secours de la DLQ.
+	DLQRetry           retry.Config      // Relances des envois et des reprises de la DLQ.
+	ProducerProperties map[string]string // Propriétés librdkafka du producteur des relances et de la DLQ.
 }
 
 // NewConfig crée une configuration avec des valeurs par défaut,
@@ -47,11 +76,13 @@ func NewConfig() *Config {
 		MetricsInterval: config.TrackerMetricsInterval,
 		ReadTimeout:     config.TrackerConsumerReadTimeout,
 		MaxErrors:       config.TrackerMaxConsecutiveErrors,
+		Properties:      config.DefaultConfig().Kafka.Client.ConsumerProperties(),
+		Breaker:         retry.DefaultBreakerConfig(),
 	}
 
 	// Surcharger depuis les variables d'environnement
 	if broker := os.Getenv("KAFKA_BROKER"); broker != "" {
-		cfg.KafkaBroker = broker
+		cfg.KafkaBroker = config.ParseBrokerList(broker).String()
 	}
 	if group := os.Getenv("KAFKA_CONSUMER_GROUP"); group != "" {
 		cfg.ConsumerGroup = group
@@ -63,15 +94,76 @@ func NewConfig() *Config {
 	return cfg
 }
 
-// SystemMetrics collecte les métriques de performance du consommateur.
-// L'accès à cette structure est protégé par un mutex pour la sécurité des threads.
+// ConfigFrom crée la configuration du tracker à partir de la configuration
+// partagée de l'application (options, environnement, YAML et valeurs par défaut déjà résolus).
+//
+// Paramètres:
+//   - cfg: La configuration de l'application.
+//
+// Retourne:
+//   - *Config: La configuration du tracker.
+func ConfigFrom(cfg *config.AppConfig) *Config {
+	c := &Config{
+		KafkaBroker:     cfg.Kafka.Brokers.String(),
+		ConsumerGroup:   cfg.Kafka.ConsumerGroup,
+		Topic:           cfg.Kafka.Topic,
+		LogFile:         cfg.Tracker.LogFile,
+		EventsFile:      cfg.Tracker.EventsFile,
+		MetricsInterval: cfg.GetMetricsInterval(),
+		ReadTimeout:     cfg.GetReadTimeout(),
+		MaxErrors:       cfg.Tracker.MaxConsecutiveErrors,
+		Properties:      cfg.ConsumerProperties(),
+		Breaker:         retry.BreakerConfigFrom(cfg.Retry.CircuitBreaker),
+		StrictDecoding:  cfg.Tracker.StrictDecoding,
+		NormalizeOrders: cfg.Tracker.NormalizeOrders,
+		RedactPII:       cfg.Tracker.RedactPII,
+		ChaosFile:       cfg.GetChaosFile(),
+	}
+	if cfg.Retry.Topics.Enabled {
+		c.RetryTiers = cfg.Retry.Topics.Tiers
+		c.ProducerProperties = cfg.ProducerProperties()
+		if cfg.DLQ.Enabled {
+			c.DLQTopic = cfg.DLQ.Topic
+			c.DLQConfirmTimeout = cfg.DLQ.ConfirmTimeout
+			c.DLQFallbackFile = cfg.DLQ.FallbackFile
+			c.DLQRecovery = cfg.DLQ.RecoveryInterval
+			c.DLQRetry = retry.ConfigFrom(cfg.Retry)
+		}
+	}
+	return c
+}
+
+// SystemMetrics collecte les métriques de performance du consommateur,
+// exposées par pkg/metrics. Les métriques sont sûres pour un accès concurrent.
 type SystemMetrics struct {
-	mu                sync.RWMutex
-	StartTime         time.Time // Heure de démarrage du suivi.
-	MessagesReceived  int64     // Nombre total de messages reçus.
-	MessagesProcessed int64     // Nombre total de messages traités avec succès.
-	MessagesFailed    int64     // Nombre total de messages échoués.
-	LastMessageTime   time.Time // Heure du dernier message reçu.
+	StartTime          time.Time          // Heure de démarrage du suivi.
+	MessagesReceived   *metrics.Counter   // Nombre total de messages reçus.
+	MessagesProcessed  *metrics.Counter   // Nombre total de messages traités avec succès.
+	MessagesFailed     *metrics.Counter   // Nombre total de messages échoués.
+	LastMessageTime    *metrics.Gauge     // Heure du dernier message reçu (secondes Unix).
+	ProcessingDuration *metrics.Histogram // Durée de traitement des messages, en secondes.
+}
+
+// newSystemMetrics crée les métriques du consommateur et les enregistre dans
+// metrics.Default.
+//
+// Paramètres:
+//   - topic: Le sujet consommé, étiquette des métriques.
+//
+// Retourne:
+//   - *SystemMetrics: Les métriques, à zéro.
+func newSystemMetrics(topic string) *SystemMetrics {
+	labels := map[string]string{"topic": topic}
+	sm := &SystemMetrics{
+		StartTime:          time.Now(),
+		MessagesReceived:   metrics.NewCounter(metrics.Opts{Name: "pubsub_tracker_messages_received_total", Help: "Messages received by the tracker.", Labels: labels}),
+		MessagesProcessed:  metrics.NewCounter(metrics.Opts{Name: "pubsub_tracker_messages_processed_total", Help: "Messages processed successfully by the tracker.", Labels: labels}),
+		MessagesFailed:     metrics.NewCounter(metrics.Opts{Name: "pubsub_tracker_messages_failed_total", Help: "Messages the tracker failed to process.", Labels: labels}),
+		LastMessageTime:    metrics.NewGauge(metrics.Opts{Name: "pubsub_tracker_last_message_timestamp_seconds", Help: "Time of the last message received by the tracker.", Labels: labels}),
+		ProcessingDuration: metrics.NewHistogram(metrics.Opts{Name: "pubsub_tracker_processing_duration_seconds", Help: "Processing time of the messages by the tracker.", Labels: labels}, nil),
+	}
+	metrics.MustRegister(sm.MessagesReceived, sm.MessagesProcessed, sm.MessagesFailed, sm.LastMessageTime, sm.ProcessingDuration)
+	return sm
 }
 
 // recordMetrics met à jour les compteurs de performance.
@@ -80,17 +172,14 @@ type SystemMetrics struct {
 //   - processed: Indique si le message a été traité avec succès.
 //   - failed: Indique si le traitement du message a échoué.
 func (sm *SystemMetrics) recordMetrics(processed, failed bool) {
-	sm.mu.Lock()
-	defer sm.mu.Unlock()
-
-	sm.MessagesReceived++
+	sm.MessagesReceived.Inc()
 	if processed {
-		sm.MessagesProcessed++
+		sm.MessagesProcessed.Inc()
 	}
 	if failed {
-		sm.MessagesFailed++
+		sm.MessagesFailed.Inc()
 	}
-	sm.LastMessageTime = time.Now()
+	sm.LastMessageTime.SetTime(time.Now())
 }
 
 // Tracker est le service principal qui gère la consommation de messages Kafka.
@@ -101,11 +190,13 @@ type Tracker struct {
 	logLogger   *Logger
 	eventLogger *Logger
 	metrics     *SystemMetrics
-	consumer    KafkaConsumer   // Interface pour la testabilité
-	rawConsumer *kafka.Consumer // Garder une référence pour la fermeture
-	stopChan    chan struct{}
-	running     bool
-	mu          sync.Mutex
+	orders      *models.OrderAggregator // Statistiques métier des commandes de la fenêtre de métriques courante.
+	consumer    transport.Subscriber    // Abonné du pilote du broker (simulé dans les tests).
+	breaker     *retry.CircuitBreaker   // Suspend les lectures après des erreurs répétées (nil : désactivé).
+	failures    failureRouter           // Redirige les messages en échec (nil : topics de relance désactivés).
+	chaos       *chaos.Injector         // Pannes injectées par pubsub chaos (nil : désactivé).
+	stopChan    chan struct{}           // Fermé par Stop : arrête la boucle et les tâches de fond.
+	stopOnce    sync.Once
 }
 
 // New crée une nouvelle instance du service Tracker.
@@ -116,15 +207,25 @@ type Tracker struct {
 // Retourne:
 //   - *Tracker: L'instance créée.
 func New(cfg *Config) *Tracker {
-	return &Tracker{
+	t := &Tracker{
 		config:   cfg,
-		metrics:  &SystemMetrics{StartTime: time.Now()},
+		metrics:  newSystemMetrics(cfg.Topic),
+		orders:   models.NewOrderAggregator(),
 		stopChan: make(chan struct{}),
 	}
+	t.breaker = retry.NewCircuitBreaker("tracker", cfg.Breaker, t.logBreakerChange)
+	if cfg.ChaosFile != "" {
+		t.chaos = chaos.NewInjector(cfg.ChaosFile)
+	}
+	return t
 }
 
-// Initialize initialise les loggers et le consommateur Kafka.
-// Configure les abonnements aux sujets Kafka.
+// Initialize initialise les loggers et l'abonné du broker configuré : Kafka,
+// RabbitMQ pour une URL AMQP, ou le broker en mémoire partagé par le processus
+// (config.MemoryBroker).
+// Configure l'abonnement au sujet. Avec pubsub chaos, les positions Kafka ne
+// sont plus enregistrées à la réception mais après le traitement (voir
+// handleMessage), pour pouvoir en abandonner.
 //
 // Retourne:
 //   - error: Une erreur si l'initialisation échoue.
@@ -142,76 +243,121 @@ func (t *Tracker) Initialize() error {
 		t.logLogger.Close()
 		return fmt.Errorf("impossible d'initialiser le logger d'événements: %w", err)
 	}
+	t.eventLogger.redactPII = t.config.RedactPII
 
 	t.logLogger.Log(models.LogLevelINFO, "Système de journalisation initialisé", map[string]interface{}{
 		"log_file":    t.config.LogFile,
 		"events_file": t.config.EventsFile,
 	})
 
-	// Initialiser le consommateur Kafka
-	t.rawConsumer, err = kafka.NewConsumer(&kafka.ConfigMap{
-		"bootstrap.servers": t.config.KafkaBroker,
-		"group.id":          t.config.ConsumerGroup,
-		"auto.offset.reset": "earliest",
-	})
+	// Initialiser l'abonné du broker
+	brokers := config.ParseBrokerList(t.config.KafkaBroker)
+	if !brokers.IsKafka() && len(t.config.RetryTiers) > 0 {
+		t.Close()
+		return fmt.Errorf("les topics de relance requièrent des brokers Kafka")
+	}
+	props := t.config.Properties
+	if t.chaos != nil {
+		props = make(map[string]string, len(t.config.Properties)+1)
+		for k, v := range t.config.Properties {
+			props[k] = v
+		}
+		props["enable.auto.offset.store"] = "false"
+	}
+	t.consumer, err = drivers.NewSubscriber(t.config.KafkaBroker, t.config.ConsumerGroup, props)
 	if err != nil {
 		t.logLogger.LogError("Erreur lors de la création du consommateur", err, nil)
 		t.Close()
-		return fmt.Errorf("impossible de créer le consommateur Kafka: %w", err)
+		return fmt.Errorf("impossible de créer le consommateur: %w", err)
 	}
-	t.consumer = newKafkaConsumerWrapper(t.rawConsumer)
 
 	// S'abonner au sujet
-	err = t.consumer.SubscribeTopics([]string{t.config.Topic}, nil)
+	err = t.consumer.Subscribe([]string{t.config.Topic})
 	if err != nil {
 		t.logLogger.LogError("Erreur lors de l'abonnement au sujet", err, map[string]interface{}{"topic": t.config.Topic})
 		t.Close()
 		return fmt.Errorf("impossible de s'abonner au sujet: %w", err)
 	}
 
+	if len(t.config.RetryTiers) > 0 {
+		t.failures, err = newRetryTopics(t.config, t.logReinjection, t.logFallbackRecovery)
+		if err != nil {
+			t.logLogger.LogError("Erreur lors de la création des topics de relance", err, nil)
+			t.Close()
+			return fmt.Errorf("impossible de créer les topics de relance: %w", err)
+		}
+	}
+
 	t.logLogger.Log(models.LogLevelINFO, "Consommateur démarré et abonné au sujet '"+t.config.Topic+"'", nil)
 	return nil
 }
 
 // Run démarre la boucle de consommation des messages.
-// Bloque jusqu'à l'appel de Stop() ou une erreur critique.
-func (t *Tracker) Run() {
-	t.mu.Lock()
-	t.running = true
-	t.mu.Unlock()
+// Bloque jusqu'à l'annulation de ctx, l'appel de Stop() ou une erreur critique.
+//
+// Paramètres:
+//   - ctx: Le contexte d'exécution ; son annulation arrête le tracker (Stop).
+//
+// Retourne:
+//   - error: ErrConsumerStopped si le consommateur s'est arrêté sur des erreurs de lecture, nil sinon.
+func (t *Tracker) Run(ctx context.Context) error {
+	// Arrêter le tracker à l'annulation de ctx ; Stop() est terminée au retour
+	stop := context.AfterFunc(ctx, t.Stop)
+	defer func() {
+		if !stop() {
+			t.Stop() // attend la fin de l'appel en cours (sync.Once)
+		}
+	}()
 
-	// Démarrer les métriques périodiques
+	// Démarrer les métriques périodiques et la réinjection des relances
 	go t.logPeriodicMetrics()
+	if t.failures != nil {
+		go t.failures.Start()
+	}
 
 	consecutiveErrors := 0
 
-	for t.isRunning() {
-		msg, err := t.consumer.ReadMessage(t.config.ReadTimeout)
+	for !t.stopped() {
+		// Circuit ouvert : suspendre les lectures jusqu'à l'appel d'essai
+		if t.breaker.Allow() != nil {
+			time.Sleep(t.config.ReadTimeout)
+			continue
+		}
+
+		msg, err := t.consumer.Receive(t.config.ReadTimeout)
 		if err != nil {
-			shouldStop := t.handleKafkaError(err, &consecutiveErrors)
-			if shouldStop {
-				break
+			if t.handleReadError(err, &consecutiveErrors) {
+				return ErrConsumerStopped
 			}
 			continue
 		}
 
 		consecutiveErrors = 0
-		t.processMessage(msg)
+		t.breaker.Success()
+		start := time.Now()
+		t.handleMessage(msg)
+		t.metrics.ProcessingDuration.ObserveDuration(time.Since(start))
 	}
+	return nil
 }
 
-// isRunning retourne vrai si le tracker est en cours d'exécution.
+// stopped retourne vrai une fois Stop() appelée.
 //
 // Retourne:
-//   - bool: L'état d'exécution.
-func (t *Tracker) isRunning() bool {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-	return t.running
+//   - bool: L'état d'arrêt.
+func (t *Tracker) stopped() bool {
+	select {
+	case <-t.stopChan:
+		return true
+	default:
+		return false
+	}
 }
 
-// handleKafkaError gère les erreurs de lecture Kafka.
-// Retourne vrai si le tracker doit s'arrêter.
+// handleReadError gère les erreurs de lecture de l'abonné.
+// Retourne vrai si le tracker doit s'arrêter. Les erreurs, hors délai de
+// lecture expiré (transport.ErrTimeout), sont comptées comme des échecs par le
+// disjoncteur.
 //
 // Paramètres:
 //   - err: L'erreur rencontrée.
@@ -219,40 +365,20 @@ func (t *Tracker) isRunning() bool {
 //
 // Retourne:
 //   - bool: Vrai s'il faut arrêter le consommateur.
-func (t *Tracker) handleKafkaError(err error, consecutiveErrors *int) bool {
-	kafkaErr, ok := err.(kafka.Error)
-	if !ok {
-		// Erreur générique (non-Kafka)
-		// On la traite comme une erreur pour éviter une boucle active silencieuse
-		t.logLogger.LogError("Erreur inattendue du consommateur", err, nil)
-		*consecutiveErrors++
-		if *consecutiveErrors >= t.config.MaxErrors {
-			t.logLogger.LogError("Trop d'erreurs consécutives (génériques), arrêt du consommateur", err, map[string]interface{}{
-				"consecutive_errors": *consecutiveErrors,
-			})
-			return true
-		}
-		// Petite pause pour éviter de spammer les logs en cas de boucle rapide d'erreurs génériques
-		time.Sleep(100 * time.Millisecond)
-		return false
-	}
-
+func (t *Tracker) handleReadError(err error, consecutiveErrors *int) bool {
 	// Timeout normal, pas une erreur
-	if kafkaErr.Code() == kafka.ErrTimedOut {
+	if errors.Is(err, transport.ErrTimeout) {
 		*consecutiveErrors = 0
+		t.breaker.Success()
 		return false
 	}
+	t.breaker.Failure()
 
-	// Vérifier si c'est une erreur de connexion critique
-	errorMsg := err.Error()
-	isShutdownError := strings.Contains(errorMsg, "brokers are down") ||
-		strings.Contains(errorMsg, "Connection refused") ||
-		kafkaErr.Code() == kafka.ErrAllBrokersDown
-
-	if isShutdownError {
+	// Broker injoignable : arrêt après MaxErrors erreurs consécutives
+	if errors.Is(err, transport.ErrUnavailable) {
 		*consecutiveErrors++
 		if *consecutiveErrors >= t.config.MaxErrors {
-			t.logLogger.Log(models.LogLevelINFO, "Kafka semble indisponible, arrêt du consommateur", map[string]interface{}{
+			t.logLogger.Log(models.LogLevelINFO, "Le broker semble indisponible, arrêt du consommateur", map[string]interface{}{
 				"consecutive_errors": *consecutiveErrors,
 				"reason":             "brokers_unavailable",
 			})
@@ -262,7 +388,8 @@ func (t *Tracker) handleKafkaError(err error, consecutiveErrors *int) bool {
 	}
 
 	// Autres erreurs
-	t.logLogger.LogError("Erreur de lecture du message Kafka", err, nil)
+	// On les compte pour éviter une boucle active silencieuse
+	t.logLogger.LogError("Erreur de lecture du message", err, nil)
 	*consecutiveErrors++
 	if *consecutiveErrors >= t.config.MaxErrors {
 		t.logLogger.LogError("Trop d'erreurs consécutives, arrêt du consommateur", err, map[string]interface{}{
@@ -270,37 +397,221 @@ func (t *Tracker) handleKafkaError(err error, consecutiveErrors *int) bool {
 		})
 		return true
 	}
-
+	// Petite pause pour éviter de spammer les logs en cas de boucle rapide d'erreurs
+	time.Sleep(100 * time.Millisecond)
 	return false
 }
 
-// processMessage traite un message Kafka individuel.
-// Désérialise, logue et met à jour les métriques.
+// logBreakerChange journalise une transition d'état du disjoncteur des lectures Kafka.
+//
+// Paramètres:
+//   - change: La transition.
+func (t *Tracker) logBreakerChange(change retry.StateChange) {
+	if t.logLogger == nil {
+		return
+	}
+	t.logLogger.Log(models.LogLevelINFO, "Changement d'état du disjoncteur Kafka", map[string]interface{}{
+		"breaker":  change.Name,
+		"from":     change.From.String(),
+		"to":       change.To.String(),
+		"failures": change.Failures,
+	})
+}
+
+// handleMessage traite un message en appliquant les pannes actives de pubsub
+// chaos : délai de traitement, panique, puis enregistrement de la position du
+// message, sauf s'il est abandonné. Sans chaos, le message est seulement traité
+// et sa position enregistrée à la réception par le pilote.
+//
+// Paramètres:
+//   - msg: Le message reçu.
+func (t *Tracker) handleMessage(msg *transport.Message) {
+	if t.chaos == nil {
+		t.processMessage(msg)
+		return
+	}
+	if delay := t.chaos.Delay(); delay > 0 {
+		time.Sleep(delay)
+	}
+	t.processSafely(msg)
+
+	// Seul le pilote Kafka enregistre les positions explicitement
+	committer, ok := t.consumer.(transport.Committer)
+	if !ok {
+		return
+	}
+	if t.chaos.DropCommit() {
+		t.logLogger.Log(models.LogLevelINFO, "Enregistrement de la position abandonné (chaos)", map[string]interface{}{
+			"kafka_offset": msg.Offset,
+		})
+		return
+	}
+	if err := committer.Commit(msg); err != nil {
+		t.logLogger.LogError("Erreur lors de l'enregistrement de la position", err, map[string]interface{}{
+			"kafka_offset": msg.Offset,
+		})
+	}
+}
+
+// processSafely traite un message en récupérant une panique du traitement,
+// provoquée par pubsub chaos ou non : le message est alors compté en échec,
+// journalisé et redirigé comme une erreur ErrProcessingPanic.
+//
+// Paramètres:
+//   - msg: Le message reçu.
+func (t *Tracker) processSafely(msg *transport.Message) {
+	defer func() {
+		if r := recover(); r != nil {
+			err := fmt.Errorf("%w: %v", ErrProcessingPanic, r)
+			trace := messageTrace(msg, nil)
+			t.metrics.recordMetrics(false, true)
+			t.logLogger.LogWithTrace(models.LogLevelERROR, "Panique lors du traitement du message", err, trace, map[string]interface{}{
+				"kafka_offset": msg.Offset,
+			})
+			t.routeFailure(msg, trace, err)
+		}
+	}()
+	if t.chaos.Panic() {
+		panic("chaos: " + chaos.FaultConsumerPanic)
+	}
+	t.processMessage(msg)
+}
+
+// processMessage traite un message individuel.
+// Désérialise la commande (migrée vers la version courante du schéma), logue
+// et met à jour les métriques. En décodage strict, une commande portant un
+// champ inconnu est une erreur models.ErrSchemaMismatch. Si la normalisation
+// est activée, une devise inconnue est une erreur models.ErrInvalidCurrency.
+// Si le masquage est activé, aucun journal ne contient de donnée personnelle.
 //
 // Paramètres:
-//   - msg: Le message Kafka reçu.
-func (t *Tracker) processMessage(msg *kafka.Message) {
-	var order models.Order
-	deserializationErr := json.Unmarshal(msg.Value, &order)
+//   - msg: Le message reçu.
+func (t *Tracker) processMessage(msg *transport.Message) {
+	decode := models.DecodeOrder
+	if t.config.StrictDecoding {
+		decode = models.DecodeOrderStrict
+	}
+	order, deserializationErr := decode(msg.Value)
+	if deserializationErr == nil && t.config.NormalizeOrders {
+		deserializationErr = order.Normalize()
+	}
 
 	// Log de l'événement (toujours)
 	var orderForLog *models.Order
 	if deserializationErr == nil {
 		orderForLog = &order
 	}
-	t.eventLogger.LogEvent(msg, orderForLog, deserializationErr)
+	trace := messageTrace(msg, orderForLog)
+	t.eventLogger.LogEvent(msg, orderForLog, trace, deserializationErr)
 
 	// Mettre à jour les métriques et traiter le message
 	if deserializationErr != nil {
 		t.metrics.recordMetrics(false, true)
-		t.logLogger.LogError("Erreur de désérialisation du message", deserializationErr, map[string]interface{}{
-			"kafka_offset": msg.TopicPartition.Offset,
-			"raw_message":  string(msg.Value),
-		})
+		metadata := map[string]interface{}{
+			"kafka_offset": msg.Offset,
+		}
+		if !t.config.RedactPII {
+			metadata["raw_message"] = string(msg.Value)
+		}
+		t.logLogger.LogWithTrace(models.LogLevelERROR, "Erreur de désérialisation du message", deserializationErr, trace, metadata)
+		t.routeFailure(msg, trace, deserializationErr)
 	} else {
 		t.metrics.recordMetrics(true, false)
-		displayOrder(&order)
+		t.orders.Add(order)
+		shown := order
+		if t.config.RedactPII {
+			shown = order.MaskForLogging()
+		}
+		displayOrder(&shown)
+	}
+}
+
+// messageTrace retourne le contexte de trace d'un message, lu dans ses
+// en-têtes traceparent et correlation-id. À défaut d'en-tête, l'identifiant
+// de corrélation est celui des métadonnées de la commande.
+//
+// Paramètres:
+//   - msg: Le message reçu.
+//   - order: La commande désérialisée (nil si échec).
+//
+// Retourne:
+//   - models.TraceContext: Le contexte de trace (vide si le message n'en porte pas).
+func messageTrace(msg *transport.Message, order *models.Order) models.TraceContext {
+	var trace models.TraceContext
+	for _, header := range msg.Headers {
+		switch header.Key {
+		case models.HeaderTraceParent:
+			if parsed, ok := models.ParseTraceParent(string(header.Value)); ok {
+				trace.TraceID, trace.SpanID = parsed.TraceID, parsed.SpanID
+			}
+		case models.HeaderCorrelationID:
+			trace.CorrelationID = string(header.Value)
+		}
+	}
+	if trace.CorrelationID == "" && order != nil {
+		trace.CorrelationID = order.Metadata.CorrelationID
 	}
+	return trace
+}
+
+// routeFailure publie un message en échec sur le topic de relance du palier
+// suivant, ou l'envoie à la DLQ, si les topics de relance sont activés.
+//
+// Paramètres:
+//   - msg: Le message dont le traitement a échoué.
+//   - trace: Le contexte de trace du message.
+//   - err: L'erreur de traitement.
+func (t *Tracker) routeFailure(msg *transport.Message, trace models.TraceContext, err error) {
+	if t.failures == nil {
+		return
+	}
+	topic, routeErr := t.failures.Route(msg, err)
+	if routeErr != nil {
+		t.logLogger.LogWithTrace(models.LogLevelERROR, "Erreur lors de la redirection du message en échec", routeErr, trace, map[string]interface{}{
+			"kafka_offset": msg.Offset,
+		})
+		return
+	}
+	if topic == "" {
+		t.logLogger.LogWithTrace(models.LogLevelERROR, "Message en échec abandonné (DLQ désactivée)", nil, trace, map[string]interface{}{
+			"kafka_offset": msg.Offset,
+		})
+		return
+	}
+	t.logLogger.LogWithTrace(models.LogLevelINFO, "Message en échec redirigé", nil, trace, map[string]interface{}{
+		"kafka_offset": msg.Offset,
+		"topic":        topic,
+	})
+}
+
+// logReinjection journalise la réinjection d'un message par le consommateur des topics de relance.
+//
+// Paramètres:
+//   - msg: Le message lu sur le topic de relance.
+//   - topic: Le topic dans lequel il est réinjecté.
+func (t *Tracker) logReinjection(msg *transport.Message, topic string) {
+	t.logLogger.Log(models.LogLevelINFO, "Message réinjecté depuis un topic de relance", map[string]interface{}{
+		"from":         msg.Topic,
+		"topic":        topic,
+		"kafka_offset": msg.Offset,
+	})
+}
+
+// logFallbackRecovery journalise la reprise du fichier de secours de la DLQ.
+//
+// Paramètres:
+//   - recovered: Le nombre de messages republiés dans la DLQ.
+//   - err: L'erreur ayant interrompu la reprise (nil si le fichier a été vidé).
+func (t *Tracker) logFallbackRecovery(recovered int, err error) {
+	metadata := map[string]interface{}{
+		"file":      t.config.DLQFallbackFile,
+		"recovered": recovered,
+	}
+	if err != nil {
+		t.logLogger.LogError("Reprise du fichier de secours de la DLQ interrompue", err, metadata)
+		return
+	}
+	t.logLogger.Log(models.LogLevelINFO, "Fichier de secours de la DLQ republié", metadata)
 }
 
 // logPeriodicMetrics écrit les métriques périodiques.
@@ -314,54 +625,85 @@ func (t *Tracker) logPeriodicMetrics() {
 		case <-t.stopChan:
 			return
 		case <-ticker.C:
-			t.metrics.mu.RLock()
-			uptime := time.Since(t.metrics.StartTime)
-			var successRate float64
-			if t.metrics.MessagesReceived > 0 {
-				successRate = float64(t.metrics.MessagesProcessed) / float64(t.metrics.MessagesReceived) * 100
-			}
-			var messagesPerSecond float64
-			if uptime.Seconds() > 0 {
-				messagesPerSecond = float64(t.metrics.MessagesReceived) / uptime.Seconds()
-			}
-			t.metrics.mu.RUnlock()
-
-			t.logLogger.Log(models.LogLevelINFO, "Métriques système périodiques", map[string]interface{}{
-				"uptime_seconds":       uptime.Seconds(),
-				"messages_received":    t.metrics.MessagesReceived,
-				"messages_processed":   t.metrics.MessagesProcessed,
-				"messages_failed":      t.metrics.MessagesFailed,
-				"success_rate_percent": fmt.Sprintf("%.2f", successRate),
-				"messages_per_second":  fmt.Sprintf("%.2f", messagesPerSecond),
-			})
+			t.logLogger.Log(models.LogLevelINFO, "Métriques système périodiques", t.periodicMetrics())
 		}
 	}
 }
 
-// Stop arrête proprement le tracker.
-// Signale l'arrêt aux goroutines et ferme le canal de stop.
-func (t *Tracker) Stop() {
-	t.mu.Lock()
-	t.running = false
-	t.mu.Unlock()
+// periodicMetrics calcule les métriques périodiques, avec les statistiques de
+// la DLQ lorsque les topics de relance sont activés, celles des opérations
+// relancées enregistrées dans retry.DefaultStats et les statistiques métier des
+// commandes traitées depuis les métriques précédentes.
+//
+// Retourne:
+//   - map[string]interface{}: Les métadonnées de l'entrée de journal des métriques.
+func (t *Tracker) periodicMetrics() map[string]interface{} {
+	uptime := time.Since(t.metrics.StartTime)
+	received := int64(t.metrics.MessagesReceived.Value())
+	processed := int64(t.metrics.MessagesProcessed.Value())
+	var successRate float64
+	if received > 0 {
+		successRate = float64(processed) / float64(received) * 100
+	}
+	var messagesPerSecond float64
+	if uptime.Seconds() > 0 {
+		messagesPerSecond = float64(received) / uptime.Seconds()
+	}
+	metadata := map[string]interface{}{
+		"uptime_seconds":       uptime.Seconds(),
+		"messages_received":    received,
+		"messages_processed":   processed,
+		"messages_failed":      int64(t.metrics.MessagesFailed.Value()),
+		"success_rate_percent": fmt.Sprintf("%.2f", successRate),
+		"messages_per_second":  fmt.Sprintf("%.2f", messagesPerSecond),
+	}
 
-	close(t.stopChan)
+	if t.failures != nil {
+		stats := t.failures.DLQStats()
+		metadata["dlq_messages_sent"] = stats.MessagesSent
+		metadata["dlq_send_errors"] = stats.SendErrors
+		if !stats.LastSentTime.IsZero() {
+			metadata["dlq_last_sent_time"] = stats.LastSentTime.UTC().Format(time.RFC3339)
+		}
+		if !stats.LastErrorTime.IsZero() {
+			metadata["dlq_last_error_time"] = stats.LastErrorTime.UTC().Format(time.RFC3339)
+		}
+	}
+	if operations := retry.DefaultStats.Snapshot(); len(operations) > 0 {
+		metadata["retry_operations"] = operations
+	}
+	if orders := t.orders.Reset(); orders.Orders > 0 {
+		metadata["orders"] = orders
+	}
+	return metadata
+}
 
-	// Log final
-	uptime := time.Since(t.metrics.StartTime)
-	t.logLogger.Log(models.LogLevelINFO, "Consommateur arrêté proprement", map[string]interface{}{
-		"uptime_seconds":           uptime.Seconds(),
-		"total_messages_received":  t.metrics.MessagesReceived,
-		"total_messages_processed": t.metrics.MessagesProcessed,
-		"total_messages_failed":    t.metrics.MessagesFailed,
+// Stop arrête proprement le tracker.
+// Signale l'arrêt aux goroutines en fermant le canal de stop ; les appels
+// suivants sont sans effet.
+func (t *Tracker) Stop() {
+	t.stopOnce.Do(func() {
+		close(t.stopChan)
+
+		// Log final
+		uptime := time.Since(t.metrics.StartTime)
+		t.logLogger.Log(models.LogLevelINFO, "Consommateur arrêté proprement", map[string]interface{}{
+			"uptime_seconds":           uptime.Seconds(),
+			"total_messages_received":  int64(t.metrics.MessagesReceived.Value()),
+			"total_messages_processed": int64(t.metrics.MessagesProcessed.Value()),
+			"total_messages_failed":    int64(t.metrics.MessagesFailed.Value()),
+		})
 	})
 }
 
 // Close libère toutes les ressources.
-// Ferme le consommateur Kafka et les fichiers journaux.
+// Ferme l'abonné et les fichiers journaux.
 func (t *Tracker) Close() {
-	if t.rawConsumer != nil {
-		t.rawConsumer.Close()
+	if t.failures != nil {
+		t.failures.Close()
+	}
+	if t.consumer != nil {
+		t.consumer.Close()
 	}
 	if t.logLogger != nil {
 		t.logLogger.Close()
@@ -377,13 +719,13 @@ func (t *Tracker) Close() {
 //   - order: La commande à afficher.
 func displayOrder(order *models.Order) {
 	fmt.Println("\n" + strings.Repeat("=", 80))
-	fmt.Printf("📦 COMMANDE REÇUE #%d (ID: %s)\n", order.Sequence, order.OrderID)
+	fmt.Println(i18n.T("tracker.order.received", order.Sequence, order.OrderID))
 	fmt.Println(strings.Repeat("-", 80))
-	fmt.Printf("Client: %s (%s)\n", order.CustomerInfo.Name, order.CustomerInfo.CustomerID)
-	fmt.Printf("Statut: %s | Total: %.2f %s\n", order.Status, order.Total, order.Currency)
-	fmt.Println("Articles:")
+	fmt.Println(i18n.T("tracker.order.customer", order.CustomerInfo.Name, order.CustomerInfo.CustomerID))
+	fmt.Println(i18n.T("tracker.order.status", order.Status, order.Total, order.Currency))
+	fmt.Println(i18n.T("tracker.order.items"))
 	for _, item := range order.Items {
-		fmt.Printf("  - %s (x%d) @ %.2f %s\n", item.ItemName, item.Quantity, item.UnitPrice, order.Currency)
+		fmt.Printf("  - %s (x%d) @ %s %s\n", item.ItemName, item.Quantity, item.UnitPrice, order.Currency)
 	}
 	fmt.Println(strings.Repeat("=", 80))
 }
@@ -2,13 +2,23 @@ package tracker
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/agbruneau/PubSub/internal/chaos"
+	"github.com/agbruneau/PubSub/internal/config"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/agbruneau/PubSub/pkg/transport"
+	"github.com/stretchr/testify/mock"
 )
 
 // newTestLogger crée un logger qui écrit dans un buffer pour les tests.
@@ -34,7 +44,8 @@ func newTestTracker(eventBuf, logBuf *bytes.Buffer) *Tracker {
 		config:      cfg,
 		logLogger:   newTestLogger(logBuf),
 		eventLogger: newTestLogger(eventBuf),
-		metrics:     &SystemMetrics{StartTime: time.Now()},
+		metrics:     newSystemMetrics(cfg.Topic),
+		orders:      models.NewOrderAggregator(),
 		stopChan:    make(chan struct{}),
 	}
 
@@ -51,10 +62,10 @@ func TestProcessMessageDeserializationFailure(t *testing.T) {
 
 	// Créer un message Kafka avec un JSON invalide
 	topic := "orders"
-	kafkaMsg := &kafka.Message{
-		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: 1, Offset: 101},
-		Value:          []byte(`{"invalid-json"`),
-		Timestamp:      time.Now(),
+	kafkaMsg := &transport.Message{
+		Topic: topic, Partition: 1, Offset: 101,
+		Value:     []byte(`{"invalid-json"`),
+		Timestamp: time.Now(),
 	}
 
 	// Appeler la fonction testée
@@ -85,10 +96,10 @@ func TestProcessMessageSuccess(t *testing.T) {
 
 	topic := "orders"
 	validOrder := `{"order_id":"test-123","sequence":1,"status":"pending","items":[],"customer_info":{"customer_id":"c1","name":"Test"}}`
-	kafkaMsg := &kafka.Message{
-		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: 0, Offset: 1},
-		Value:          []byte(validOrder),
-		Timestamp:      time.Now(),
+	kafkaMsg := &transport.Message{
+		Topic: topic, Partition: 0, Offset: 1,
+		Value:     []byte(validOrder),
+		Timestamp: time.Now(),
 	}
 
 	tracker.processMessage(kafkaMsg)
@@ -99,40 +110,213 @@ func TestProcessMessageSuccess(t *testing.T) {
 	}
 
 	// Vérifier que les métriques ont été mises à jour
-	if tracker.metrics.MessagesReceived != 1 {
-		t.Errorf("Attendu que MessagesReceived soit 1, reçu %d", tracker.metrics.MessagesReceived)
+	if tracker.metrics.MessagesReceived.Value() != 1 {
+		t.Errorf("Attendu que MessagesReceived soit 1, reçu %v", tracker.metrics.MessagesReceived.Value())
 	}
-	if tracker.metrics.MessagesProcessed != 1 {
-		t.Errorf("Attendu que MessagesProcessed soit 1, reçu %d", tracker.metrics.MessagesProcessed)
+	if tracker.metrics.MessagesProcessed.Value() != 1 {
+		t.Errorf("Attendu que MessagesProcessed soit 1, reçu %v", tracker.metrics.MessagesProcessed.Value())
+	}
+}
+
+// TestProcessMessageStrictDecoding vérifie qu'en décodage strict un champ
+// inconnu produit un événement schema_mismatch distinct.
+func TestProcessMessageStrictDecoding(t *testing.T) {
+	var eventBuf bytes.Buffer
+	var logBuf bytes.Buffer
+	tracker := newTestTracker(&eventBuf, &logBuf)
+
+	topic := "orders"
+	drifted := `{"order_id":"test-123","sequence":1,"status":"pending","priority":"high","customer_info":{"customer_id":"c1","name":"Test"}}`
+	kafkaMsg := &transport.Message{
+		Topic: topic, Partition: 0, Offset: 1,
+		Value:     []byte(drifted),
+		Timestamp: time.Now(),
+	}
+
+	tracker.processMessage(kafkaMsg)
+	if !strings.Contains(eventBuf.String(), `"event_type":"message.received"`) {
+		t.Errorf("Attendu un champ inconnu ignoré par défaut. Log: %s", eventBuf.String())
+	}
+
+	eventBuf.Reset()
+	tracker.config.StrictDecoding = true
+	tracker.processMessage(kafkaMsg)
+
+	eventLogOutput := eventBuf.String()
+	if !strings.Contains(eventLogOutput, `"event_type":"message.received.schema_mismatch"`) || !strings.Contains(eventLogOutput, `unknown field \"priority\"`) {
+		t.Errorf("Attendu un événement schema_mismatch citant le champ inconnu. Log: %s", eventLogOutput)
+	}
+	if tracker.metrics.MessagesFailed.Value() != 1 {
+		t.Errorf("Attendu que MessagesFailed soit 1, reçu %v", tracker.metrics.MessagesFailed.Value())
+	}
+}
+
+// TestProcessMessageNormalize vérifie la normalisation des commandes reçues et
+// le rejet d'une devise inconnue.
+func TestProcessMessageNormalize(t *testing.T) {
+	var eventBuf bytes.Buffer
+	var logBuf bytes.Buffer
+	tracker := newTestTracker(&eventBuf, &logBuf)
+	tracker.config.NormalizeOrders = true
+
+	topic := "orders"
+	yen := `{"order_id":"test-123","sequence":1,"status":"pending","items":[{"item_id":"i1","item_name":"Thé","quantity":2,"unit_price":12.50,"total_price":25.00}],"currency":"jpy","customer_info":{"customer_id":"c1","name":"Test"}}`
+	tracker.processMessage(&transport.Message{
+		Topic: topic, Partition: 0, Offset: 1,
+		Value: []byte(yen),
+	})
+	if !strings.Contains(eventBuf.String(), `"currency":"JPY"`) || !strings.Contains(eventBuf.String(), `"total_price":26.00`) {
+		t.Errorf("Attendu une commande normalisée en yens entiers. Log: %s", eventBuf.String())
+	}
+
+	eventBuf.Reset()
+	tracker.processMessage(&transport.Message{
+		Topic: topic, Partition: 0, Offset: 2,
+		Value: []byte(strings.Replace(yen, `"jpy"`, `"yen"`, 1)),
+	})
+	if !strings.Contains(eventBuf.String(), `"deserialized":false`) || !strings.Contains(eventBuf.String(), `"error_code":"ERR_CURRENCY_INVALID"`) {
+		t.Errorf("Attendu le rejet d'une devise inconnue. Log: %s", eventBuf.String())
+	}
+	if tracker.metrics.MessagesFailed.Value() != 1 {
+		t.Errorf("Attendu que MessagesFailed soit 1, reçu %v", tracker.metrics.MessagesFailed.Value())
+	}
+}
+
+// TestProcessMessageFixtures vérifie le traitement des commandes du corpus
+// partagé, dans chaque version du schéma.
+func TestProcessMessageFixtures(t *testing.T) {
+	for _, name := range []string{"order_v1.0", "order_v1.1"} {
+		var eventBuf bytes.Buffer
+		var logBuf bytes.Buffer
+		tracker := newTestTracker(&eventBuf, &logBuf)
+		tracker.config.StrictDecoding = true
+		tracker.config.NormalizeOrders = true
+
+		raw, err := models.Fixture(name)
+		if err != nil {
+			t.Fatalf("Erreur inattendue: %v", err)
+		}
+		topic := "orders"
+		tracker.processMessage(&transport.Message{
+			Topic: topic, Partition: 0, Offset: 1,
+			Value: raw,
+		})
+
+		if !strings.Contains(eventBuf.String(), `"deserialized":true`) || !strings.Contains(eventBuf.String(), `"version":"1.1"`) {
+			t.Errorf("%s: attendu une commande décodée et migrée en 1.1. Log: %s", name, eventBuf.String())
+		}
+		if tracker.metrics.MessagesProcessed.Value() != 1 {
+			t.Errorf("%s: attendu que MessagesProcessed soit 1, reçu %v", name, tracker.metrics.MessagesProcessed.Value())
+		}
+	}
+}
+
+// TestProcessMessageTrace vérifie que le contexte de trace des en-têtes est
+// repris dans les événements et les journaux, et que l'identifiant de
+// corrélation de la commande sert à défaut d'en-tête.
+func TestProcessMessageTrace(t *testing.T) {
+	var eventBuf bytes.Buffer
+	var logBuf bytes.Buffer
+	tracker := newTestTracker(&eventBuf, &logBuf)
+
+	topic := "orders"
+	tracker.processMessage(&transport.Message{
+		Topic: topic, Partition: 0, Offset: 1,
+		Value: []byte(`{"invalid-json"`),
+		Headers: []transport.Header{
+			{Key: models.HeaderTraceParent, Value: []byte("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")},
+			{Key: models.HeaderCorrelationID, Value: []byte("corr-42")},
+		},
+	})
+	for name, output := range map[string]string{"événements": eventBuf.String(), "système": logBuf.String()} {
+		if !strings.Contains(output, `"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`) ||
+			!strings.Contains(output, `"span_id":"00f067aa0ba902b7"`) ||
+			!strings.Contains(output, `"correlation_id":"corr-42"`) {
+			t.Errorf("Attendu le contexte de trace dans le journal %s. Log: %s", name, output)
+		}
+	}
+
+	eventBuf.Reset()
+	tracker.processMessage(&transport.Message{
+		Topic: topic, Partition: 0, Offset: 2,
+		Value: []byte(`{"order_id":"test-123","sequence":1,"status":"pending","items":[],"customer_info":{"customer_id":"c1","name":"Test"},"metadata":{"correlation_id":"corr-7"}}`),
+	})
+	if !strings.Contains(eventBuf.String(), `"event_type":"message.received","correlation_id":"corr-7"`) || strings.Contains(eventBuf.String(), `"trace_id"`) {
+		t.Errorf("Attendu l'identifiant de corrélation de la commande, sans trace. Log: %s", eventBuf.String())
+	}
+}
+
+// TestProcessMessageRedactPII vérifie que le masquage retire les données
+// personnelles de la piste d'audit, du journal système et de la console.
+func TestProcessMessageRedactPII(t *testing.T) {
+	var eventBuf bytes.Buffer
+	var logBuf bytes.Buffer
+	tracker := newTestTracker(&eventBuf, &logBuf)
+	tracker.config.RedactPII = true
+	tracker.eventLogger.redactPII = true
+
+	raw, err := models.Fixture("order_v1.1")
+	if err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	console := make(chan string)
+	go func() {
+		out, _ := io.ReadAll(r)
+		console <- string(out)
+	}()
+
+	topic := "orders"
+	tracker.processMessage(&transport.Message{
+		Topic: topic, Partition: 0, Offset: 1,
+		Value: raw,
+	})
+	tracker.processMessage(&transport.Message{
+		Topic: topic, Partition: 0, Offset: 2,
+		Value: raw[:len(raw)/2],
+	})
+
+	w.Close()
+	os.Stdout = oldStdout
+	output := eventBuf.String() + logBuf.String() + <-console
+	for _, pii := range []string{"camille.martin@example.com", "Camille Martin", "12 34 56 78", "rue de la Paix"} {
+		if strings.Contains(output, pii) {
+			t.Errorf("Attendu que %q soit masqué. Log: %s", pii, output)
+		}
+	}
+	if !strings.Contains(eventBuf.String(), `"customer_id":"client01"`) || !strings.Contains(eventBuf.String(), `"email":"sha256:`) {
+		t.Errorf("Attendu une commande masquée dans order_full. Log: %s", eventBuf.String())
 	}
 }
 
 // TestRecordMetrics vérifie que les métriques sont correctement mises à jour.
 func TestRecordMetrics(t *testing.T) {
-	metrics := &SystemMetrics{StartTime: time.Now()}
+	metrics := newSystemMetrics("test")
 
 	// Tester un message réussi
 	metrics.recordMetrics(true, false)
-	if metrics.MessagesReceived != 1 {
-		t.Errorf("Attendu que MessagesReceived soit 1, reçu %d", metrics.MessagesReceived)
+	if metrics.MessagesReceived.Value() != 1 {
+		t.Errorf("Attendu que MessagesReceived soit 1, reçu %v", metrics.MessagesReceived.Value())
 	}
-	if metrics.MessagesProcessed != 1 {
-		t.Errorf("Attendu que MessagesProcessed soit 1, reçu %d", metrics.MessagesProcessed)
+	if metrics.MessagesProcessed.Value() != 1 {
+		t.Errorf("Attendu que MessagesProcessed soit 1, reçu %v", metrics.MessagesProcessed.Value())
 	}
-	if metrics.MessagesFailed != 0 {
-		t.Errorf("Attendu que MessagesFailed soit 0, reçu %d", metrics.MessagesFailed)
+	if metrics.MessagesFailed.Value() != 0 {
+		t.Errorf("Attendu que MessagesFailed soit 0, reçu %v", metrics.MessagesFailed.Value())
 	}
 
 	// Tester un message échoué
 	metrics.recordMetrics(false, true)
-	if metrics.MessagesReceived != 2 {
-		t.Errorf("Attendu que MessagesReceived soit 2, reçu %d", metrics.MessagesReceived)
+	if metrics.MessagesReceived.Value() != 2 {
+		t.Errorf("Attendu que MessagesReceived soit 2, reçu %v", metrics.MessagesReceived.Value())
 	}
-	if metrics.MessagesProcessed != 1 {
-		t.Errorf("Attendu que MessagesProcessed soit 1, reçu %d", metrics.MessagesProcessed)
+	if metrics.MessagesProcessed.Value() != 1 {
+		t.Errorf("Attendu que MessagesProcessed soit 1, reçu %v", metrics.MessagesProcessed.Value())
 	}
-	if metrics.MessagesFailed != 1 {
-		t.Errorf("Attendu que MessagesFailed soit 1, reçu %d", metrics.MessagesFailed)
+	if metrics.MessagesFailed.Value() != 1 {
+		t.Errorf("Attendu que MessagesFailed soit 1, reçu %v", metrics.MessagesFailed.Value())
 	}
 }
 
@@ -153,3 +337,214 @@ func TestNewConfig(t *testing.T) {
 		t.Error("Attendu que MaxErrors soit positif")
 	}
 }
+
+// TestConfigFrom vérifie la conversion de la configuration partagée.
+func TestConfigFrom(t *testing.T) {
+	appCfg := config.DefaultConfig()
+	appCfg.Tracker.LogFile = "custom.log"
+	appCfg.Tracker.ReadTimeout = 250 * time.Millisecond
+	appCfg.Tracker.StrictDecoding = true
+	appCfg.Kafka.Client.SessionTimeout = 30 * time.Second
+
+	cfg := ConfigFrom(appCfg)
+
+	if cfg.LogFile != "custom.log" {
+		t.Errorf("Attendu LogFile 'custom.log', obtenu %s", cfg.LogFile)
+	}
+	if cfg.ReadTimeout != 250*time.Millisecond {
+		t.Errorf("Attendu ReadTimeout 250ms, obtenu %v", cfg.ReadTimeout)
+	}
+	if !cfg.StrictDecoding {
+		t.Error("Attendu StrictDecoding activé")
+	}
+	if cfg.Properties["session.timeout.ms"] != "30000" {
+		t.Errorf("Attendu session.timeout.ms '30000', obtenu %q", cfg.Properties["session.timeout.ms"])
+	}
+	if cfg.Properties["auto.offset.reset"] != "earliest" {
+		t.Errorf("Attendu auto.offset.reset 'earliest', obtenu %q", cfg.Properties["auto.offset.reset"])
+	}
+	if cfg.ChaosFile != "" {
+		t.Errorf("Attendu ChaosFile vide sans chaos.enabled, obtenu %q", cfg.ChaosFile)
+	}
+}
+
+// TestProcessMessageRoutesFailure vérifie qu'un message en échec est redirigé
+// vers les topics de relance et que la redirection est journalisée.
+func TestProcessMessageRoutesFailure(t *testing.T) {
+	var eventBuf bytes.Buffer
+	var logBuf bytes.Buffer
+	tracker := newTestTracker(&eventBuf, &logBuf)
+	router := new(MockFailureRouter)
+	tracker.failures = router
+
+	topic := "orders"
+	kafkaMsg := &transport.Message{
+		Topic: topic, Partition: 0, Offset: 7,
+		Value: []byte(`{"invalid-json"`),
+	}
+	router.On("Route", kafkaMsg, mock.Anything).Return("orders-dlq", nil).Once()
+
+	tracker.processMessage(kafkaMsg)
+
+	router.AssertExpectations(t)
+	if !strings.Contains(logBuf.String(), `"topic":"orders-dlq"`) {
+		t.Errorf("Attendu la journalisation de la redirection vers orders-dlq. Log: %s", logBuf.String())
+	}
+}
+
+// newChaosInjector crée un injecteur dont le fichier d'état contient des pannes
+// actives pendant une minute.
+func newChaosInjector(t *testing.T, kinds ...string) *chaos.Injector {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "chaos.json")
+	now := time.Now()
+	var state chaos.State
+	for _, kind := range kinds {
+		state.Faults = append(state.Faults, chaos.Fault{Kind: kind, From: now.Add(-time.Second), Until: now.Add(time.Minute)})
+	}
+	if err := chaos.WriteState(path, state); err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+	return chaos.NewInjector(path)
+}
+
+// TestHandleMessageChaosPanic vérifie qu'une panique injectée est récupérée,
+// comptée en échec et redirigée vers les topics de relance.
+func TestHandleMessageChaosPanic(t *testing.T) {
+	var eventBuf bytes.Buffer
+	var logBuf bytes.Buffer
+	tracker := newTestTracker(&eventBuf, &logBuf)
+	tracker.chaos = newChaosInjector(t, chaos.FaultConsumerPanic)
+	tracker.consumer = new(MockSubscriber)
+	router := new(MockFailureRouter)
+	tracker.failures = router
+
+	msg := &transport.Message{Topic: "orders", Offset: 3, Value: []byte(`{}`)}
+	router.On("Route", msg, mock.MatchedBy(func(err error) bool {
+		return errors.Is(err, ErrProcessingPanic)
+	})).Return("orders-retry-1m", nil).Once()
+
+	tracker.handleMessage(msg)
+
+	router.AssertExpectations(t)
+	if tracker.metrics.MessagesFailed.Value() != 1 || tracker.metrics.MessagesProcessed.Value() != 0 {
+		t.Errorf("Attendu 1 message en échec, obtenu %+v", tracker.metrics)
+	}
+	if !strings.Contains(logBuf.String(), "Panique lors du traitement du message") {
+		t.Errorf("Attendu la journalisation de la panique. Log: %s", logBuf.String())
+	}
+}
+
+// TestHandleMessageChaosCommit vérifie que les positions sont enregistrées
+// après le traitement, sauf pendant une panne commit_drop.
+func TestHandleMessageChaosCommit(t *testing.T) {
+	var eventBuf bytes.Buffer
+	var logBuf bytes.Buffer
+	msg := &transport.Message{Topic: "orders", Offset: 5, Value: []byte(`{"invalid-json"`)}
+
+	tracker := newTestTracker(&eventBuf, &logBuf)
+	tracker.chaos = newChaosInjector(t)
+	consumer := new(MockCommitSubscriber)
+	consumer.On("Commit", msg).Return(nil).Once()
+	tracker.consumer = consumer
+	tracker.handleMessage(msg)
+	consumer.AssertExpectations(t)
+
+	tracker = newTestTracker(&eventBuf, &logBuf)
+	tracker.chaos = newChaosInjector(t, chaos.FaultCommitDrop)
+	consumer = new(MockCommitSubscriber)
+	tracker.consumer = consumer
+	tracker.handleMessage(msg)
+	consumer.AssertNotCalled(t, "Commit", mock.Anything)
+	if !strings.Contains(logBuf.String(), "Enregistrement de la position abandonné") {
+		t.Errorf("Attendu la journalisation de l'abandon. Log: %s", logBuf.String())
+	}
+}
+
+// TestPeriodicMetricsDLQStats vérifie que les statistiques de la DLQ ne sont
+// ajoutées aux métriques périodiques qu'avec les topics de relance.
+func TestPeriodicMetricsDLQStats(t *testing.T) {
+	var eventBuf, logBuf bytes.Buffer
+	tracker := newTestTracker(&eventBuf, &logBuf)
+	if _, ok := tracker.periodicMetrics()["dlq_messages_sent"]; ok {
+		t.Error("Attendu aucune statistique DLQ sans topics de relance")
+	}
+
+	router := new(MockFailureRouter)
+	lastSent := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	router.On("DLQStats").Return(retry.DLQStats{MessagesSent: 4, SendErrors: 1, LastSentTime: lastSent})
+	tracker.failures = router
+
+	metrics := tracker.periodicMetrics()
+	if metrics["dlq_messages_sent"] != int64(4) || metrics["dlq_send_errors"] != int64(1) {
+		t.Errorf("Statistiques DLQ inattendues: %v", metrics)
+	}
+	if metrics["dlq_last_sent_time"] != "2024-03-01T12:00:00Z" {
+		t.Errorf("Attendu dlq_last_sent_time, obtenu %v", metrics["dlq_last_sent_time"])
+	}
+	if _, ok := metrics["dlq_last_error_time"]; ok {
+		t.Error("Attendu aucune heure de dernière erreur DLQ")
+	}
+}
+
+// TestPeriodicMetricsRetryStats vérifie l'export des statistiques de retry.DefaultStats.
+func TestPeriodicMetricsRetryStats(t *testing.T) {
+	var eventBuf, logBuf bytes.Buffer
+	tracker := newTestTracker(&eventBuf, &logBuf)
+	cfg := retry.Config{MaxAttempts: 1, Observer: retry.DefaultStats.Observer("tracker.test")}
+	retry.Do(context.Background(), cfg, func() error { return nil })
+
+	operations, ok := tracker.periodicMetrics()["retry_operations"].(map[string]retry.OperationStats)
+	if !ok || operations["tracker.test"].Successes != 1 {
+		t.Errorf("Attendu les statistiques de l'opération tracker.test, obtenu %v", operations)
+	}
+}
+
+// TestPeriodicMetricsOrders vérifie que les statistiques métier couvrent les
+// commandes traitées depuis les métriques précédentes.
+func TestPeriodicMetricsOrders(t *testing.T) {
+	var eventBuf, logBuf bytes.Buffer
+	tracker := newTestTracker(&eventBuf, &logBuf)
+	if _, ok := tracker.periodicMetrics()["orders"]; ok {
+		t.Error("Attendu aucune statistique métier sans commande")
+	}
+
+	raw, err := models.Fixture("order_v1.1")
+	if err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+	topic := "orders"
+	tracker.processMessage(&transport.Message{
+		Topic: topic, Partition: 0, Offset: 1,
+		Value: raw,
+	})
+
+	orders, ok := tracker.periodicMetrics()["orders"].(models.OrderStats)
+	if !ok || orders.Orders != 1 || orders.Currencies["EUR"].Revenue.Cents() != 1018 || orders.Items != 3 {
+		t.Errorf("Statistiques métier inattendues: %+v", orders)
+	}
+	if _, ok := tracker.periodicMetrics()["orders"]; ok {
+		t.Error("Attendu une nouvelle fenêtre après les métriques périodiques")
+	}
+}
+
+// TestConfigFromRetryTopics vérifie que les paliers ne sont transmis que si retry.topics est activé.
+func TestConfigFromRetryTopics(t *testing.T) {
+	appCfg := config.DefaultConfig()
+	if cfg := ConfigFrom(appCfg); len(cfg.RetryTiers) != 0 {
+		t.Errorf("Attendu aucun palier par défaut, obtenu %v", cfg.RetryTiers)
+	}
+
+	appCfg.Retry.Topics.Enabled = true
+	appCfg.DLQ.ConfirmTimeout = 2 * time.Second
+	cfg := ConfigFrom(appCfg)
+	if len(cfg.RetryTiers) != 2 || cfg.RetryTiers[0] != time.Minute || cfg.DLQTopic != "orders-dlq" {
+		t.Errorf("Attendu les paliers [1m 5m] et la DLQ orders-dlq, obtenu %v %q", cfg.RetryTiers, cfg.DLQTopic)
+	}
+	if cfg.DLQConfirmTimeout != 2*time.Second {
+		t.Errorf("Attendu l'envoi DLQ confirmé en 2s, obtenu %s", cfg.DLQConfirmTimeout)
+	}
+	if cfg.DLQFallbackFile != "dlq-fallback.events" || cfg.DLQRecovery != 30*time.Second {
+		t.Errorf("Attendu le fichier de secours dlq-fallback.events repris toutes les 30s, obtenu %q %s", cfg.DLQFallbackFile, cfg.DLQRecovery)
+	}
+}
@@ -0,0 +1,265 @@
+/*
+Package web serves the browser dashboard of the PubSub system (pubsub web),
+an alternative to the termui monitor.
+
+The Server exposes a REST API and a Server-Sent Events stream:
+
+	GET /api/tracker   tracker metrics (monitor.Snapshot)
+	GET /api/producer  live producer statistics (producer.Stats)
+	GET /api/events    most recent tracker events (?limit=N)
+	GET /api/dlq       messages of the Dead Letter Queue
+	GET /api/stream    State pushed every Config.Interval (event "state")
+
+and an embedded single-page UI at /, fed by these APIs.
+*/
+package web
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/monitor"
+	"github.com/agbruneau/PubSub/internal/producer"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/agbruneau/PubSub/pkg/models"
+)
+
+// Server defaults.
+const (
+	// DefaultAddr is the default listen address of pubsub web.
+	DefaultAddr = "localhost:8080"
+	// DefaultInterval is the default interval between two updates of the stream.
+	DefaultInterval = time.Second
+	// DefaultMaxEvents is the default number of events sent with each update.
+	DefaultMaxEvents = 50
+)
+
+// ErrDLQUnavailable is returned by /api/dlq when the Server has no DLQReader.
+var ErrDLQUnavailable = errors.New("the DLQ can only be read from Kafka brokers")
+
+//go:embed static
+var static embed.FS
+
+// DLQReader reads the messages of the Dead Letter Queue.
+//
+// Parameters:
+//   - ctx: Cancelled when the client goes away.
+//
+// Returns:
+//   - []retry.DLQRecord: The messages, in DLQ order.
+//   - error: An error if the DLQ cannot be read.
+type DLQReader func(ctx context.Context) ([]retry.DLQRecord, error)
+
+// Config contains the data sources of the Server.
+type Config struct {
+	Monitor           *monitor.Monitor // Tracker metrics, fed by the caller from the tracker files.
+	ProducerStatsFile string           // Statistics written by the producer ("": not shown).
+	DLQ               DLQReader        // DLQ source (nil: ErrDLQUnavailable).
+	Interval          time.Duration    // Interval between two updates of the stream (0: DefaultInterval).
+	MaxEvents         int              // Events sent with each update (0: DefaultMaxEvents).
+}
+
+// State is the update pushed by the stream.
+type State struct {
+	Tracker  monitor.Snapshot    `json:"tracker"`            // Tracker metrics.
+	Producer *producer.Stats     `json:"producer,omitempty"` // Producer statistics (nil until the producer writes them).
+	Events   []models.EventEntry `json:"events"`             // Most recent tracker events, oldest first.
+}
+
+// DLQMessage is a message of the Dead Letter Queue returned by /api/dlq.
+type DLQMessage struct {
+	Partition int32                `json:"partition"`         // DLQ partition.
+	Offset    int64                `json:"offset"`            // DLQ offset.
+	Message   *retry.FailedMessage `json:"message,omitempty"` // Envelope (nil if it cannot be read).
+	Error     string               `json:"error,omitempty"`   // Read error of the envelope.
+}
+
+// Server serves the dashboard and its APIs. It implements http.Handler.
+type Server struct {
+	cfg Config
+	mux *http.ServeMux
+}
+
+// New creates a Server.
+//
+// Parameters:
+//   - cfg: The data sources.
+//
+// Returns:
+//   - *Server: The server.
+func New(cfg Config) *Server {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.MaxEvents <= 0 {
+		cfg.MaxEvents = DefaultMaxEvents
+	}
+	s := &Server{cfg: cfg, mux: http.NewServeMux()}
+	assets, _ := fs.Sub(static, "static")
+	s.mux.Handle("GET /", http.FileServer(http.FS(assets)))
+	s.mux.HandleFunc("GET /api/tracker", s.handleTracker)
+	s.mux.HandleFunc("GET /api/producer", s.handleProducer)
+	s.mux.HandleFunc("GET /api/events", s.handleEvents)
+	s.mux.HandleFunc("GET /api/dlq", s.handleDLQ)
+	s.mux.HandleFunc("GET /api/stream", s.handleStream)
+	return s
+}
+
+// ServeHTTP dispatches a request to the UI or an API.
+//
+// Parameters:
+//   - w: The response writer.
+//   - r: The request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// State returns the current state of the system.
+//
+// Returns:
+//   - State: The tracker metrics, producer statistics and recent events.
+func (s *Server) State() State {
+	state := State{Tracker: s.snapshot(), Events: s.cfg.Monitor.RecentEvents(s.cfg.MaxEvents)}
+	if stats, err := s.producerStats(); err == nil {
+		state.Producer = &stats
+	}
+	return state
+}
+
+// snapshot refreshes the uptime and returns the tracker metrics.
+//
+// Returns:
+//   - monitor.Snapshot: The metrics.
+func (s *Server) snapshot() monitor.Snapshot {
+	s.cfg.Monitor.UpdateUptime()
+	return s.cfg.Monitor.Snapshot()
+}
+
+// producerStats reads the producer statistics.
+//
+// Returns:
+//   - producer.Stats: The statistics.
+//   - error: fs.ErrNotExist without statistics file, or a read error.
+func (s *Server) producerStats() (producer.Stats, error) {
+	if s.cfg.ProducerStatsFile == "" {
+		return producer.Stats{}, fs.ErrNotExist
+	}
+	return producer.ReadStats(s.cfg.ProducerStatsFile)
+}
+
+// handleTracker serves the tracker metrics.
+func (s *Server) handleTracker(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.snapshot())
+}
+
+// handleProducer serves the producer statistics, or 404 until the producer writes them.
+func (s *Server) handleProducer(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.producerStats()
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		writeError(w, http.StatusNotFound, errors.New("no producer statistics (is the producer running with producer.stats_file?)"))
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	default:
+		writeJSON(w, http.StatusOK, stats)
+	}
+}
+
+// handleEvents serves the most recent tracker events (?limit=N, default Config.MaxEvents).
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	limit := s.cfg.MaxEvents
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit %q", v))
+			return
+		}
+		limit = n
+	}
+	writeJSON(w, http.StatusOK, s.cfg.Monitor.RecentEvents(limit))
+}
+
+// handleDLQ serves the messages of the Dead Letter Queue.
+func (s *Server) handleDLQ(w http.ResponseWriter, r *http.Request) {
+	if s.cfg.DLQ == nil {
+		writeError(w, http.StatusServiceUnavailable, ErrDLQUnavailable)
+		return
+	}
+	records, err := s.cfg.DLQ(r.Context())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	messages := make([]DLQMessage, len(records))
+	for i, rec := range records {
+		messages[i] = DLQMessage{Partition: rec.Partition, Offset: rec.Offset}
+		if rec.Err != nil {
+			messages[i].Error = rec.Err.Error()
+			continue
+		}
+		msg := rec.Message
+		messages[i].Message = &msg
+	}
+	writeJSON(w, http.StatusOK, messages)
+}
+
+// handleStream pushes the State every Config.Interval as a Server-Sent Event
+// named "state", until the client goes away.
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming unsupported"))
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		data, err := json.Marshal(s.State())
+		if err != nil {
+			return
+		}
+		if _, err := fmt.Fprintf(w, "event: state\ndata: %s\n\n", data); err != nil {
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeJSON writes a JSON response.
+//
+// Parameters:
+//   - w: The response writer.
+//   - status: The HTTP status.
+//   - v: The response body.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error response ({"error": "..."}).
+//
+// Parameters:
+//   - w: The response writer.
+//   - status: The HTTP status.
+//   - err: The error.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
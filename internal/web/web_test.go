@@ -0,0 +1,152 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/internal/monitor"
+	"github.com/agbruneau/PubSub/internal/producer"
+	"github.com/agbruneau/PubSub/internal/retry"
+	"github.com/agbruneau/PubSub/pkg/models"
+)
+
+// newTestServer returns a server whose tracker has processed two events.
+func newTestServer(t *testing.T, cfg Config) *httptest.Server {
+	t.Helper()
+	cfg.Monitor = monitor.New()
+	cfg.Monitor.ProcessEvent(models.EventEntry{Timestamp: models.Now(), KafkaOffset: 1, Deserialized: true})
+	cfg.Monitor.ProcessEvent(models.EventEntry{Timestamp: models.Now(), KafkaOffset: 2, Error: "invalid order"})
+	srv := httptest.NewServer(New(cfg))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// getJSON decodes the JSON response of a GET request.
+func getJSON(t *testing.T, url string, v any) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("GET %s: invalid JSON: %v", url, err)
+	}
+	return resp.StatusCode
+}
+
+func TestTrackerAndEvents(t *testing.T) {
+	srv := newTestServer(t, Config{})
+
+	var snapshot monitor.Snapshot
+	if code := getJSON(t, srv.URL+"/api/tracker", &snapshot); code != http.StatusOK || snapshot.MessagesReceived != 2 || snapshot.MessagesFailed != 1 {
+		t.Errorf("Unexpected tracker metrics (%d): %+v", code, snapshot)
+	}
+
+	var events []models.EventEntry
+	if code := getJSON(t, srv.URL+"/api/events?limit=1", &events); code != http.StatusOK || len(events) != 1 || events[0].KafkaOffset != 2 {
+		t.Errorf("Expected the last event (%d), got %+v", code, events)
+	}
+	var failure map[string]string
+	if code := getJSON(t, srv.URL+"/api/events?limit=x", &failure); code != http.StatusBadRequest || failure["error"] == "" {
+		t.Errorf("Expected a bad request, got %d %v", code, failure)
+	}
+}
+
+func TestProducer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "producer-stats.json")
+	srv := newTestServer(t, Config{ProducerStatsFile: path})
+
+	var failure map[string]string
+	if code := getJSON(t, srv.URL+"/api/producer", &failure); code != http.StatusNotFound {
+		t.Errorf("Expected 404 before the producer writes its statistics, got %d", code)
+	}
+
+	if err := producer.WriteStats(path, producer.Stats{Topic: "orders", Delivered: 7}); err != nil {
+		t.Fatal(err)
+	}
+	var stats producer.Stats
+	if code := getJSON(t, srv.URL+"/api/producer", &stats); code != http.StatusOK || stats.Delivered != 7 {
+		t.Errorf("Unexpected producer statistics (%d): %+v", code, stats)
+	}
+}
+
+func TestDLQ(t *testing.T) {
+	var failure map[string]string
+	srv := newTestServer(t, Config{})
+	if code := getJSON(t, srv.URL+"/api/dlq", &failure); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 without DLQ reader, got %d", code)
+	}
+
+	srv = newTestServer(t, Config{DLQ: func(ctx context.Context) ([]retry.DLQRecord, error) {
+		return []retry.DLQRecord{
+			{Offset: 3, Message: retry.FailedMessage{OriginalTopic: "orders", LastError: "timeout"}},
+			{Offset: 4, Err: errors.New("invalid envelope")},
+		}, nil
+	}})
+	var messages []DLQMessage
+	if code := getJSON(t, srv.URL+"/api/dlq", &messages); code != http.StatusOK || len(messages) != 2 {
+		t.Fatalf("Unexpected DLQ (%d): %+v", code, messages)
+	}
+	if messages[0].Message == nil || messages[0].Message.LastError != "timeout" || messages[1].Error != "invalid envelope" {
+		t.Errorf("Unexpected DLQ messages %+v", messages)
+	}
+}
+
+func TestStream(t *testing.T) {
+	srv := newTestServer(t, Config{Interval: 10 * time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/stream", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Expected an event stream, got %q", ct)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	var updates []State
+	for scanner.Scan() && len(updates) < 2 {
+		line := scanner.Text()
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			var state State
+			if err := json.Unmarshal([]byte(data), &state); err != nil {
+				t.Fatalf("Invalid state %q: %v", data, err)
+			}
+			updates = append(updates, state)
+		} else if line != "" && line != "event: state" {
+			t.Errorf("Unexpected line %q", line)
+		}
+	}
+	if len(updates) != 2 || updates[1].Tracker.MessagesReceived != 2 || len(updates[1].Events) != 2 || updates[1].Producer != nil {
+		t.Errorf("Unexpected updates %+v", updates)
+	}
+}
+
+func TestUI(t *testing.T) {
+	srv := newTestServer(t, Config{})
+	resp, err := http.Get(srv.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var page strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		page.WriteString(scanner.Text())
+	}
+	if resp.StatusCode != http.StatusOK || !strings.Contains(page.String(), `new EventSource("api/stream")`) {
+		t.Errorf("Expected the dashboard page, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Package diag serves the runtime diagnostics of a PubSub process, so that a
+performance investigation (e.g., during pubsub loadtest) needs no code change:
+
+	/debug/pprof/         net/http/pprof profiles (go tool pprof http://addr/debug/pprof/heap)
+	/debug/vars           expvar variables (memstats, cmdline, goroutines, uptime_seconds)
+	POST /debug/snapshot  writes a goroutine dump and a heap profile to disk
+
+The handler exposes the internals of the process: listen on a loopback
+address only (e.g., "localhost:6060").
+*/
+package diag
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"path/filepath"
+	"runtime"
+	rpprof "runtime/pprof"
+	"sync"
+	"time"
+)
+
+// start is the start time of the process, for the uptime_seconds variable.
+var start = time.Now()
+
+// publishOnce guards the publication of the expvar variables, which panics
+// when a name is published twice.
+var publishOnce sync.Once
+
+// Snapshot describes the files written by WriteSnapshot.
+type Snapshot struct {
+	Time       time.Time `json:"time"`       // Time of the snapshot.
+	Goroutines int       `json:"goroutines"` // Number of goroutines at that time.
+	Files      []string  `json:"files"`      // Goroutine dump and heap profile.
+}
+
+// Handler returns the diagnostics endpoints.
+//
+// Parameters:
+//   - snapshotDir: The directory of the files written by POST /debug/snapshot.
+//
+// Returns:
+//   - http.Handler: The handler, serving /debug/pprof/, /debug/vars and /debug/snapshot.
+func Handler(snapshotDir string) http.Handler {
+	publishOnce.Do(func() {
+		expvar.Publish("goroutines", expvar.Func(func() any { return runtime.NumGoroutine() }))
+		expvar.Publish("uptime_seconds", expvar.Func(func() any { return time.Since(start).Seconds() }))
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("GET /debug/vars", expvar.Handler())
+	mux.HandleFunc("POST /debug/snapshot", func(w http.ResponseWriter, req *http.Request) {
+		snap, err := WriteSnapshot(snapshotDir, time.Now())
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+		json.NewEncoder(w).Encode(snap)
+	})
+	return mux
+}
+
+// WriteSnapshot writes the stacks of all goroutines (goroutine-<time>.txt)
+// and a heap profile taken after a garbage collection (heap-<time>.pb.gz,
+// readable with go tool pprof) to dir, created if needed.
+//
+// Parameters:
+//   - dir: The destination directory.
+//   - now: The time of the snapshot, used in the file names.
+//
+// Returns:
+//   - Snapshot: The written files.
+//   - error: An error if a file cannot be written.
+func WriteSnapshot(dir string, now time.Time) (Snapshot, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return Snapshot{}, fmt.Errorf("diag: %w", err)
+	}
+	stamp := now.UTC().Format("20060102T150405.000Z")
+	snap := Snapshot{Time: now, Goroutines: runtime.NumGoroutine()}
+
+	runtime.GC()
+	for _, p := range []struct {
+		name, file string
+		debug      int
+	}{
+		{"goroutine", "goroutine-" + stamp + ".txt", 2},
+		{"heap", "heap-" + stamp + ".pb.gz", 0},
+	} {
+		path := filepath.Join(dir, p.file)
+		if err := writeProfile(path, p.name, p.debug); err != nil {
+			return snap, err
+		}
+		snap.Files = append(snap.Files, path)
+	}
+	return snap, nil
+}
+
+// writeProfile writes a runtime profile to a file.
+//
+// Parameters:
+//   - path: The file.
+//   - name: The profile (see runtime/pprof.Lookup).
+//   - debug: The format (0: protobuf, 2: goroutine stacks as text).
+//
+// Returns:
+//   - error: An error if the file cannot be written.
+func writeProfile(path, name string, debug int) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("diag: %w", err)
+	}
+	if err := rpprof.Lookup(name).WriteTo(f, debug); err != nil {
+		f.Close()
+		return fmt.Errorf("diag: %s profile: %w", name, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("diag: %w", err)
+	}
+	return nil
+}
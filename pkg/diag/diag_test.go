@@ -0,0 +1,85 @@
+package diag
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestHandlerEndpoints tests that the pprof index and the expvar variables are served.
+func TestHandlerEndpoints(t *testing.T) {
+	h := Handler(t.TempDir())
+	Handler(t.TempDir()) // the variables are published once
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "goroutine") {
+		t.Errorf("Expected the pprof index, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+	var vars map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &vars); err != nil {
+		t.Fatalf("Invalid /debug/vars: %v", err)
+	}
+	for _, name := range []string{"memstats", "goroutines", "uptime_seconds"} {
+		if _, ok := vars[name]; !ok {
+			t.Errorf("Expected the %s variable", name)
+		}
+	}
+}
+
+// TestSnapshot tests that POST /debug/snapshot writes the goroutine dump and the heap profile.
+func TestSnapshot(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "debug")
+	h := Handler(dir)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/snapshot", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/snapshot", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(rec.Body.Bytes(), &snap); err != nil {
+		t.Fatalf("Invalid response: %v", err)
+	}
+	if len(snap.Files) != 2 || snap.Goroutines == 0 {
+		t.Fatalf("Unexpected snapshot %+v", snap)
+	}
+	dump, err := os.ReadFile(snap.Files[0])
+	if err != nil || !strings.Contains(string(dump), "goroutine ") {
+		t.Errorf("Expected a goroutine dump in %s (%v)", snap.Files[0], err)
+	}
+	if info, err := os.Stat(snap.Files[1]); err != nil || info.Size() == 0 {
+		t.Errorf("Expected a heap profile in %s (%v)", snap.Files[1], err)
+	}
+}
+
+// TestWriteSnapshotError tests that an unwritable directory is reported.
+func TestWriteSnapshotError(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := WriteSnapshot(file, time.Now()); err == nil {
+		t.Error("Expected an error when the directory is a file")
+	}
+
+	rec := httptest.NewRecorder()
+	Handler(file).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/snapshot", nil))
+	if rec.Code != http.StatusInternalServerError || !strings.Contains(rec.Body.String(), "error") {
+		t.Errorf("Expected a 500 JSON error, got %d %q", rec.Code, rec.Body.String())
+	}
+}
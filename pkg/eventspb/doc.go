@@ -0,0 +1,14 @@
+// Package eventspb contains the gRPC API streaming the events of the tracker
+// audit trail (pubsub events), generated from events.proto.
+//
+// A subscriber connects to the EventStream service and calls SubscribeEvents
+// with its filters:
+//
+//	conn, err := grpc.NewClient("localhost:9090", grpc.WithTransportCredentials(insecure.NewCredentials()))
+//	stream, err := eventspb.NewEventStreamClient(conn).SubscribeEvents(ctx,
+//		&eventspb.SubscribeEventsRequest{FailedOnly: true})
+//	for {
+//		event, err := stream.Recv()
+//		...
+//	}
+package eventspb
@@ -0,0 +1,386 @@
+// Live subscription to the events of the tracker audit trail (pubsub events).
+//
+// Regenerate the Go code with `make proto`.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: pkg/eventspb/events.proto
+
+package eventspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SubscribeEventsRequest selects the streamed events. Empty filters match
+// every event; set filters must all match.
+type SubscribeEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Event types (e.g., "message.received").
+	EventTypes []string `protobuf:"bytes,1,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// Source Kafka topic.
+	Topic string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	// Source Kafka partitions.
+	Partitions []int32 `protobuf:"varint,3,rep,packed,name=partitions,proto3" json:"partitions,omitempty"`
+	// Correlation ID of the message.
+	CorrelationId string `protobuf:"bytes,4,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	// Trace of the message (traceparent header).
+	TraceId string `protobuf:"bytes,5,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	// Only the messages the tracker failed to deserialize.
+	FailedOnly bool `protobuf:"varint,6,opt,name=failed_only,json=failedOnly,proto3" json:"failed_only,omitempty"`
+	// Recent matching events sent before the live ones (at most the history
+	// kept by the server).
+	Replay        uint32 `protobuf:"varint,7,opt,name=replay,proto3" json:"replay,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeEventsRequest) Reset() {
+	*x = SubscribeEventsRequest{}
+	mi := &file_pkg_eventspb_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEventsRequest) ProtoMessage() {}
+
+func (x *SubscribeEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_eventspb_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_eventspb_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubscribeEventsRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *SubscribeEventsRequest) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *SubscribeEventsRequest) GetPartitions() []int32 {
+	if x != nil {
+		return x.Partitions
+	}
+	return nil
+}
+
+func (x *SubscribeEventsRequest) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *SubscribeEventsRequest) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *SubscribeEventsRequest) GetFailedOnly() bool {
+	if x != nil {
+		return x.FailedOnly
+	}
+	return false
+}
+
+func (x *SubscribeEventsRequest) GetReplay() uint32 {
+	if x != nil {
+		return x.Replay
+	}
+	return 0
+}
+
+// Event is an event of the tracker audit trail (models.EventEntry).
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Reception timestamp.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Event type (e.g., "message.received").
+	EventType string `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// Trace of the message (traceparent header).
+	TraceId string `protobuf:"bytes,3,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	// Producer span of the message (traceparent header).
+	SpanId string `protobuf:"bytes,4,opt,name=span_id,json=spanId,proto3" json:"span_id,omitempty"`
+	// Correlation ID (correlation-id header or order metadata).
+	CorrelationId string `protobuf:"bytes,5,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	// Source Kafka topic.
+	KafkaTopic string `protobuf:"bytes,6,opt,name=kafka_topic,json=kafkaTopic,proto3" json:"kafka_topic,omitempty"`
+	// Source Kafka partition.
+	KafkaPartition int32 `protobuf:"varint,7,opt,name=kafka_partition,json=kafkaPartition,proto3" json:"kafka_partition,omitempty"`
+	// Message offset in the partition.
+	KafkaOffset int64 `protobuf:"varint,8,opt,name=kafka_offset,json=kafkaOffset,proto3" json:"kafka_offset,omitempty"`
+	// Raw message content.
+	RawMessage string `protobuf:"bytes,9,opt,name=raw_message,json=rawMessage,proto3" json:"raw_message,omitempty"`
+	// Message size in bytes.
+	MessageSize int32 `protobuf:"varint,10,opt,name=message_size,json=messageSize,proto3" json:"message_size,omitempty"`
+	// Whether deserialization was successful.
+	Deserialized bool `protobuf:"varint,11,opt,name=deserialized,proto3" json:"deserialized,omitempty"`
+	// Deserialization error, if any.
+	Error string `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+	// Code of the deserialization error (e.g., "ERR_SCHEMA_MISMATCH").
+	ErrorCode string `protobuf:"bytes,13,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	// Full content of the deserialized order, in JSON.
+	OrderJson     string `protobuf:"bytes,14,opt,name=order_json,json=orderJson,proto3" json:"order_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_pkg_eventspb_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_eventspb_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_pkg_eventspb_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Event) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Event) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *Event) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *Event) GetSpanId() string {
+	if x != nil {
+		return x.SpanId
+	}
+	return ""
+}
+
+func (x *Event) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *Event) GetKafkaTopic() string {
+	if x != nil {
+		return x.KafkaTopic
+	}
+	return ""
+}
+
+func (x *Event) GetKafkaPartition() int32 {
+	if x != nil {
+		return x.KafkaPartition
+	}
+	return 0
+}
+
+func (x *Event) GetKafkaOffset() int64 {
+	if x != nil {
+		return x.KafkaOffset
+	}
+	return 0
+}
+
+func (x *Event) GetRawMessage() string {
+	if x != nil {
+		return x.RawMessage
+	}
+	return ""
+}
+
+func (x *Event) GetMessageSize() int32 {
+	if x != nil {
+		return x.MessageSize
+	}
+	return 0
+}
+
+func (x *Event) GetDeserialized() bool {
+	if x != nil {
+		return x.Deserialized
+	}
+	return false
+}
+
+func (x *Event) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Event) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *Event) GetOrderJson() string {
+	if x != nil {
+		return x.OrderJson
+	}
+	return ""
+}
+
+var File_pkg_eventspb_events_proto protoreflect.FileDescriptor
+
+const file_pkg_eventspb_events_proto_rawDesc = "" +
+	"\n" +
+	"\x19pkg/eventspb/events.proto\x12\x10pubsub.events.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xea\x01\n" +
+	"\x16SubscribeEventsRequest\x12\x1f\n" +
+	"\vevent_types\x18\x01 \x03(\tR\n" +
+	"eventTypes\x12\x14\n" +
+	"\x05topic\x18\x02 \x01(\tR\x05topic\x12\x1e\n" +
+	"\n" +
+	"partitions\x18\x03 \x03(\x05R\n" +
+	"partitions\x12%\n" +
+	"\x0ecorrelation_id\x18\x04 \x01(\tR\rcorrelationId\x12\x19\n" +
+	"\btrace_id\x18\x05 \x01(\tR\atraceId\x12\x1f\n" +
+	"\vfailed_only\x18\x06 \x01(\bR\n" +
+	"failedOnly\x12\x16\n" +
+	"\x06replay\x18\a \x01(\rR\x06replay\"\xe4\x03\n" +
+	"\x05Event\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12\x19\n" +
+	"\btrace_id\x18\x03 \x01(\tR\atraceId\x12\x17\n" +
+	"\aspan_id\x18\x04 \x01(\tR\x06spanId\x12%\n" +
+	"\x0ecorrelation_id\x18\x05 \x01(\tR\rcorrelationId\x12\x1f\n" +
+	"\vkafka_topic\x18\x06 \x01(\tR\n" +
+	"kafkaTopic\x12'\n" +
+	"\x0fkafka_partition\x18\a \x01(\x05R\x0ekafkaPartition\x12!\n" +
+	"\fkafka_offset\x18\b \x01(\x03R\vkafkaOffset\x12\x1f\n" +
+	"\vraw_message\x18\t \x01(\tR\n" +
+	"rawMessage\x12!\n" +
+	"\fmessage_size\x18\n" +
+	" \x01(\x05R\vmessageSize\x12\"\n" +
+	"\fdeserialized\x18\v \x01(\bR\fdeserialized\x12\x14\n" +
+	"\x05error\x18\f \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\r \x01(\tR\terrorCode\x12\x1d\n" +
+	"\n" +
+	"order_json\x18\x0e \x01(\tR\torderJson2e\n" +
+	"\vEventStream\x12V\n" +
+	"\x0fSubscribeEvents\x12(.pubsub.events.v1.SubscribeEventsRequest\x1a\x17.pubsub.events.v1.Event0\x01B*Z(github.com/agbruneau/PubSub/pkg/eventspbb\x06proto3"
+
+var (
+	file_pkg_eventspb_events_proto_rawDescOnce sync.Once
+	file_pkg_eventspb_events_proto_rawDescData []byte
+)
+
+func file_pkg_eventspb_events_proto_rawDescGZIP() []byte {
+	file_pkg_eventspb_events_proto_rawDescOnce.Do(func() {
+		file_pkg_eventspb_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_eventspb_events_proto_rawDesc), len(file_pkg_eventspb_events_proto_rawDesc)))
+	})
+	return file_pkg_eventspb_events_proto_rawDescData
+}
+
+var file_pkg_eventspb_events_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_pkg_eventspb_events_proto_goTypes = []any{
+	(*SubscribeEventsRequest)(nil), // 0: pubsub.events.v1.SubscribeEventsRequest
+	(*Event)(nil),                  // 1: pubsub.events.v1.Event
+	(*timestamppb.Timestamp)(nil),  // 2: google.protobuf.Timestamp
+}
+var file_pkg_eventspb_events_proto_depIdxs = []int32{
+	2, // 0: pubsub.events.v1.Event.timestamp:type_name -> google.protobuf.Timestamp
+	0, // 1: pubsub.events.v1.EventStream.SubscribeEvents:input_type -> pubsub.events.v1.SubscribeEventsRequest
+	1, // 2: pubsub.events.v1.EventStream.SubscribeEvents:output_type -> pubsub.events.v1.Event
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_pkg_eventspb_events_proto_init() }
+func file_pkg_eventspb_events_proto_init() {
+	if File_pkg_eventspb_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_eventspb_events_proto_rawDesc), len(file_pkg_eventspb_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_eventspb_events_proto_goTypes,
+		DependencyIndexes: file_pkg_eventspb_events_proto_depIdxs,
+		MessageInfos:      file_pkg_eventspb_events_proto_msgTypes,
+	}.Build()
+	File_pkg_eventspb_events_proto = out.File
+	file_pkg_eventspb_events_proto_goTypes = nil
+	file_pkg_eventspb_events_proto_depIdxs = nil
+}
@@ -0,0 +1,136 @@
+// Live subscription to the events of the tracker audit trail (pubsub events).
+//
+// Regenerate the Go code with `make proto`.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: pkg/eventspb/events.proto
+
+package eventspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EventStream_SubscribeEvents_FullMethodName = "/pubsub.events.v1.EventStream/SubscribeEvents"
+)
+
+// EventStreamClient is the client API for EventStream service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// EventStream streams the events recorded by the tracker.
+type EventStreamClient interface {
+	// SubscribeEvents sends the events matching the request, as the tracker
+	// records them, until the client cancels the call.
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+}
+
+type eventStreamClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEventStreamClient(cc grpc.ClientConnInterface) EventStreamClient {
+	return &eventStreamClient{cc}
+}
+
+func (c *eventStreamClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &EventStream_ServiceDesc.Streams[0], EventStream_SubscribeEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EventStream_SubscribeEventsClient = grpc.ServerStreamingClient[Event]
+
+// EventStreamServer is the server API for EventStream service.
+// All implementations must embed UnimplementedEventStreamServer
+// for forward compatibility.
+//
+// EventStream streams the events recorded by the tracker.
+type EventStreamServer interface {
+	// SubscribeEvents sends the events matching the request, as the tracker
+	// records them, until the client cancels the call.
+	SubscribeEvents(*SubscribeEventsRequest, grpc.ServerStreamingServer[Event]) error
+	mustEmbedUnimplementedEventStreamServer()
+}
+
+// UnimplementedEventStreamServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEventStreamServer struct{}
+
+func (UnimplementedEventStreamServer) SubscribeEvents(*SubscribeEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedEventStreamServer) mustEmbedUnimplementedEventStreamServer() {}
+func (UnimplementedEventStreamServer) testEmbeddedByValue()                     {}
+
+// UnsafeEventStreamServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EventStreamServer will
+// result in compilation errors.
+type UnsafeEventStreamServer interface {
+	mustEmbedUnimplementedEventStreamServer()
+}
+
+func RegisterEventStreamServer(s grpc.ServiceRegistrar, srv EventStreamServer) {
+	// If the following call pancis, it indicates UnimplementedEventStreamServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EventStream_ServiceDesc, srv)
+}
+
+func _EventStream_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventStreamServer).SubscribeEvents(m, &grpc.GenericServerStream[SubscribeEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type EventStream_SubscribeEventsServer = grpc.ServerStreamingServer[Event]
+
+// EventStream_ServiceDesc is the grpc.ServiceDesc for EventStream service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EventStream_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pubsub.events.v1.EventStream",
+	HandlerType: (*EventStreamServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _EventStream_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/eventspb/events.proto",
+}
@@ -0,0 +1,83 @@
+package fake
+
+import "github.com/agbruneau/PubSub/pkg/models"
+
+// Item is a catalog entry.
+type Item struct {
+	ID    string       // Item identifier (e.g., "item-espresso").
+	Name  string       // Item name.
+	Price models.Money // Unit price.
+}
+
+// price returns a catalog price. It is unbound: the generated orders bind it
+// to Config.Currency.
+//
+// Parameters:
+//   - cents: The price in minor units.
+//
+// Returns:
+//   - models.Money: The unbound price.
+func price(cents int64) models.Money {
+	return models.FromCents(cents, "")
+}
+
+// localeData holds the words used to generate customers and orders in one
+// locale.
+type localeData struct {
+	firstNames    []string
+	lastNames     []string
+	streets       []string
+	cities        []string // City with its postal code, as written in an address.
+	addressFormat string   // Arguments: number, street, city.
+	phoneFormat   string   // Arguments: four two-digit numbers.
+	deliveryNotes string   // Argument: address.
+	items         []Item
+}
+
+// locales lists the generation data by locale code.
+var locales = map[string]localeData{
+	"fr": {
+		firstNames:    []string{"Camille", "Léa", "Manon", "Chloé", "Inès", "Lucas", "Hugo", "Louis", "Jules", "Gabriel", "Élise", "Théo"},
+		lastNames:     []string{"Martin", "Bernard", "Dubois", "Thomas", "Robert", "Richard", "Petit", "Durand", "Leroy", "Moreau", "Lefèvre", "Garnier"},
+		streets:       []string{"rue de la Paix", "avenue Victor Hugo", "boulevard Voltaire", "rue du Faubourg Saint-Antoine", "place de la République", "rue Lafayette"},
+		cities:        []string{"75001 Paris", "69002 Lyon", "13001 Marseille", "31000 Toulouse", "33000 Bordeaux", "59000 Lille"},
+		addressFormat: "%d %s, %s",
+		phoneFormat:   "+33 6 %02d %02d %02d %02d",
+		deliveryNotes: "Livrer au %s",
+		items: []Item{
+			{ID: "item-espresso", Name: "espresso", Price: price(250)},
+			{ID: "item-cappuccino", Name: "cappuccino", Price: price(320)},
+			{ID: "item-latte", Name: "latte", Price: price(350)},
+			{ID: "item-macchiato", Name: "macchiato", Price: price(300)},
+			{ID: "item-chocolat-chaud", Name: "chocolat chaud", Price: price(330)},
+			{ID: "item-the-vert", Name: "thé vert", Price: price(280)},
+			{ID: "item-croissant", Name: "croissant", Price: price(140)},
+			{ID: "item-pain-au-chocolat", Name: "pain au chocolat", Price: price(160)},
+		},
+	},
+	"en": {
+		firstNames:    []string{"Olivia", "Emma", "Amelia", "Sophia", "Grace", "Liam", "Noah", "Oliver", "James", "Henry", "Jack", "Ethan"},
+		lastNames:     []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Miller", "Davis", "Wilson", "Taylor", "Clark", "Walker", "Young"},
+		streets:       []string{"Main Street", "Maple Avenue", "Oak Street", "Park Avenue", "Elm Street", "Cedar Lane"},
+		cities:        []string{"Boston, MA 02108", "Seattle, WA 98101", "Austin, TX 78701", "Denver, CO 80202", "Portland, OR 97201", "Chicago, IL 60601"},
+		addressFormat: "%d %s, %s",
+		phoneFormat:   "+1 555 %02d%02d %02d%02d",
+		deliveryNotes: "Deliver to %s",
+		items: []Item{
+			{ID: "item-espresso", Name: "espresso", Price: price(250)},
+			{ID: "item-americano", Name: "americano", Price: price(280)},
+			{ID: "item-flat-white", Name: "flat white", Price: price(330)},
+			{ID: "item-mocha", Name: "mocha", Price: price(400)},
+			{ID: "item-chai-latte", Name: "chai latte", Price: price(380)},
+			{ID: "item-matcha", Name: "matcha", Price: price(450)},
+			{ID: "item-muffin", Name: "blueberry muffin", Price: price(300)},
+			{ID: "item-bagel", Name: "bagel", Price: price(250)},
+		},
+	},
+}
+
+// loyaltyLevels lists the loyalty levels, from the most to the least common.
+var loyaltyLevels = []string{"bronze", "silver", "gold", "platinum"}
+
+// loyaltyWeights is the relative frequency of each loyalty level.
+var loyaltyWeights = []float64{50, 30, 15, 5}
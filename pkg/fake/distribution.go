@@ -0,0 +1,119 @@
+package fake
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Distribution draws integers, e.g. the quantity of an item or the number of
+// items of an order.
+type Distribution interface {
+	// Draw returns a value drawn with the random source.
+	Draw(r *rand.Rand) int
+}
+
+// Constant always draws the same value.
+type Constant int
+
+// Draw returns the constant.
+//
+// Parameters:
+//   - r: The random source (unused).
+//
+// Returns:
+//   - int: The constant.
+func (c Constant) Draw(r *rand.Rand) int {
+	return int(c)
+}
+
+// Uniform draws a value between Min and Max, inclusive, with equal probability.
+type Uniform struct {
+	Min int // Smallest value.
+	Max int // Largest value (Min if lower).
+}
+
+// Draw returns a uniformly distributed value.
+//
+// Parameters:
+//   - r: The random source.
+//
+// Returns:
+//   - int: A value in [Min, Max].
+func (u Uniform) Draw(r *rand.Rand) int {
+	if u.Max <= u.Min {
+		return u.Min
+	}
+	return u.Min + r.Intn(u.Max-u.Min+1)
+}
+
+// Weighted draws Values[i] with probability Weights[i] / sum(Weights), e.g.
+// small quantities more often than large ones.
+type Weighted struct {
+	Values  []int     // Possible values.
+	Weights []float64 // Relative weight of each value (missing weights count as 0).
+}
+
+// Draw returns a value chosen according to the weights.
+//
+// Parameters:
+//   - r: The random source.
+//
+// Returns:
+//   - int: One of Values (0 if there is none).
+func (w Weighted) Draw(r *rand.Rand) int {
+	if len(w.Values) == 0 {
+		return 0
+	}
+	weights := make([]float64, len(w.Values))
+	copy(weights, w.Weights)
+	return w.Values[weightedIndex(r, weights)]
+}
+
+// weightedIndex draws an index with probability weights[i] / sum(weights),
+// uniformly if all the weights are zero.
+//
+// Parameters:
+//   - r: The random source.
+//   - weights: The relative weights (at least one).
+//
+// Returns:
+//   - int: An index of weights.
+func weightedIndex(r *rand.Rand, weights []float64) int {
+	var total float64
+	for _, weight := range weights {
+		total += math.Max(weight, 0)
+	}
+	if total == 0 {
+		return r.Intn(len(weights))
+	}
+	x := r.Float64() * total
+	last := 0
+	for i, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+		if x -= weight; x < 0 {
+			return i
+		}
+		last = i
+	}
+	return last // rounding error
+}
+
+// popularity returns the weights of n ranked entries following Zipf's law:
+// entry i is drawn 1/(i+1)^skew as often as the first one. A zero skew gives
+// equal weights.
+//
+// Parameters:
+//   - n: The number of entries.
+//   - skew: The Zipf exponent (>= 0).
+//
+// Returns:
+//   - []float64: The weights, in rank order.
+func popularity(n int, skew float64) []float64 {
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = 1 / math.Pow(float64(i+1), skew)
+	}
+	return weights
+}
@@ -0,0 +1,280 @@
+/*
+Package fake generates realistic test orders for the PubSub system.
+
+A Generator draws customers from a fixed pool, items from a locale catalog and
+quantities from configurable distributions. With a non-zero seed, the same
+configuration always generates the same orders, which makes load tests and unit
+tests reproducible.
+*/
+package fake
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+	"github.com/google/uuid"
+)
+
+// Generation defaults.
+const (
+	// DefaultLocale is the locale used for an empty or unknown Config.Locale.
+	DefaultLocale = "fr"
+	// DefaultCustomers is the size of the customer pool.
+	DefaultCustomers = 50
+	// DefaultCustomerSkew makes a few customers order much more often than the others.
+	DefaultCustomerSkew = 1.0
+	// DefaultItemSkew makes the first catalog items the best sellers.
+	DefaultItemSkew = 0.8
+	// DefaultStock is the stock of each item at the start and after a restock.
+	DefaultStock = 100
+)
+
+// Config describes what a Generator produces.
+type Config struct {
+	Seed          int64            // Random seed (0: seeded from the clock, not reproducible).
+	Locale        string           // Names, addresses and catalog locale ("fr" or "en").
+	Customers     int              // Size of the customer pool.
+	CustomerSkew  float64          // Zipf exponent of the customer popularity (0: uniform).
+	ItemSkew      float64          // Zipf exponent of the item popularity (0: uniform).
+	Items         []Item           // Catalog, in popularity order (nil: the locale catalog).
+	ItemsPerOrder Distribution     // Number of distinct items of an order.
+	Quantity      Distribution     // Quantity of each item.
+	TaxRate       float64          // Tax rate applied to the subtotal.
+	ShippingFee   models.Money     // Shipping fee of every order (unbound, or in Currency).
+	Currency      string           // Currency of the amounts.
+	PaymentMethod string           // Payment method of every order.
+	Warehouse     string           // Warehouse of the inventory snapshots.
+	Source        string           // Event source of the orders.
+	Clock         func() time.Time // Order timestamps (nil: time.Now).
+}
+
+// DefaultConfig returns a configuration generating French orders of one to
+// three items, mostly in small quantities.
+//
+// Returns:
+//   - Config: The default configuration, with a zero seed.
+func DefaultConfig() Config {
+	return Config{
+		Locale:        DefaultLocale,
+		Customers:     DefaultCustomers,
+		CustomerSkew:  DefaultCustomerSkew,
+		ItemSkew:      DefaultItemSkew,
+		ItemsPerOrder: Weighted{Values: []int{1, 2, 3}, Weights: []float64{60, 30, 10}},
+		Quantity:      Weighted{Values: []int{1, 2, 3, 4, 5}, Weights: []float64{40, 30, 15, 10, 5}},
+		TaxRate:       0.20,
+		ShippingFee:   price(250),
+		Currency:      models.DefaultCurrency,
+		PaymentMethod: "credit_card",
+		Warehouse:     "PARIS-01",
+		Source:        "producer-service",
+	}
+}
+
+// Generator generates orders. It is safe for concurrent use.
+type Generator struct {
+	mu             sync.Mutex
+	cfg            Config
+	rand           *rand.Rand
+	data           localeData
+	items          []Item
+	itemWeights    []float64
+	customers      []models.CustomerInfo
+	customerWeight []float64
+	stock          map[string]int
+}
+
+// New creates a generator. A zero Customers, an empty Currency and nil
+// distributions take the value of DefaultConfig; the other fields are kept as
+// is (a zero tax rate or skew is valid).
+//
+// Parameters:
+//   - cfg: The generation configuration.
+//
+// Returns:
+//   - *Generator: The generator, with its customer pool already drawn.
+func New(cfg Config) *Generator {
+	defaults := DefaultConfig()
+	if cfg.Customers <= 0 {
+		cfg.Customers = defaults.Customers
+	}
+	if cfg.ItemsPerOrder == nil {
+		cfg.ItemsPerOrder = defaults.ItemsPerOrder
+	}
+	if cfg.Quantity == nil {
+		cfg.Quantity = defaults.Quantity
+	}
+	if cfg.Currency == "" {
+		cfg.Currency = defaults.Currency
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = time.Now
+	}
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	data, ok := locales[strings.ToLower(cfg.Locale)]
+	if !ok {
+		data = locales[DefaultLocale]
+	}
+	items := cfg.Items
+	if len(items) == 0 {
+		items = data.items
+	}
+
+	g := &Generator{
+		cfg:            cfg,
+		rand:           rand.New(rand.NewSource(seed)),
+		data:           data,
+		items:          items,
+		itemWeights:    popularity(len(items), cfg.ItemSkew),
+		customerWeight: popularity(cfg.Customers, cfg.CustomerSkew),
+		stock:          make(map[string]int),
+	}
+	for i := 0; i < cfg.Customers; i++ {
+		g.customers = append(g.customers, g.newCustomer(i))
+	}
+	return g
+}
+
+// Customers returns the customer pool.
+//
+// Returns:
+//   - []models.CustomerInfo: A copy of the pool, in popularity order.
+func (g *Generator) Customers() []models.CustomerInfo {
+	return append([]models.CustomerInfo(nil), g.customers...)
+}
+
+// Order generates the next order: a customer of the pool, distinct catalog
+// items and their quantities, and the inventory snapshot of the first item.
+// Each item has DefaultStock units, restocked when an order exceeds them.
+//
+// Parameters:
+//   - sequence: The sequence number of the order.
+//
+// Returns:
+//   - models.Order: The order.
+//   - error: The validation error of the order (see models.OrderBuilder.Build).
+func (g *Generator) Order(sequence int) (models.Order, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	customer := g.customers[weightedIndex(g.rand, g.customerWeight)]
+	opts := []models.Option{
+		models.WithOrderID(g.uuid()),
+		models.WithSequence(sequence),
+		models.WithCustomer(customer),
+		models.WithPricing(g.cfg.TaxRate, g.cfg.ShippingFee, g.cfg.Currency),
+		models.WithPayment(g.cfg.PaymentMethod, fmt.Sprintf(g.data.deliveryNotes, customer.Address)),
+		models.WithMetadata(models.OrderMetadata{Source: g.cfg.Source, CorrelationID: g.uuid()}),
+		models.WithClock(g.cfg.Clock),
+	}
+	for i, item := range g.pickItems() {
+		qty := g.cfg.Quantity.Draw(g.rand)
+		opts = append(opts, models.WithItem(item.ID, item.Name, qty, item.Price))
+		if i == 0 {
+			opts = append(opts, models.WithInventory(g.reserve(item, qty)))
+		}
+	}
+	return models.NewOrder(opts...).Build()
+}
+
+// pickItems draws the distinct items of an order.
+//
+// Returns:
+//   - []Item: Between one item and the whole catalog.
+func (g *Generator) pickItems() []Item {
+	n := g.cfg.ItemsPerOrder.Draw(g.rand)
+	if n < 1 {
+		n = 1
+	}
+	if n > len(g.items) {
+		n = len(g.items)
+	}
+	weights := append([]float64(nil), g.itemWeights...)
+	picked := make([]Item, 0, n)
+	for len(picked) < n {
+		i := weightedIndex(g.rand, weights)
+		picked = append(picked, g.items[i])
+		weights[i] = 0
+	}
+	return picked
+}
+
+// reserve takes an item quantity from the stock and returns the inventory
+// snapshot before the reservation.
+//
+// Parameters:
+//   - item: The item.
+//   - qty: The ordered quantity.
+//
+// Returns:
+//   - models.InventoryStatus: The inventory state at the time of the order.
+func (g *Generator) reserve(item Item, qty int) models.InventoryStatus {
+	available, ok := g.stock[item.ID]
+	if !ok || available < qty {
+		available = DefaultStock
+	}
+	g.stock[item.ID] = available - qty
+	return models.InventoryStatus{
+		ItemID:       item.ID,
+		ItemName:     item.Name,
+		AvailableQty: available,
+		ReservedQty:  qty,
+		UnitPrice:    item.Price.WithCurrency(g.cfg.Currency),
+		InStock:      available >= qty,
+		Warehouse:    g.cfg.Warehouse,
+	}
+}
+
+// newCustomer draws the customer of rank i of the pool.
+//
+// Parameters:
+//   - i: The rank of the customer.
+//
+// Returns:
+//   - models.CustomerInfo: The customer.
+func (g *Generator) newCustomer(i int) models.CustomerInfo {
+	first := g.data.firstNames[g.rand.Intn(len(g.data.firstNames))]
+	last := g.data.lastNames[g.rand.Intn(len(g.data.lastNames))]
+	address := fmt.Sprintf(g.data.addressFormat, 1+g.rand.Intn(150),
+		g.data.streets[g.rand.Intn(len(g.data.streets))], g.data.cities[g.rand.Intn(len(g.data.cities))])
+	return models.CustomerInfo{
+		CustomerID: fmt.Sprintf("client%02d", i+1),
+		Name:       first + " " + last,
+		Email:      fmt.Sprintf("%s.%s%d@example.com", emailPart(first), emailPart(last), i+1),
+		Phone: fmt.Sprintf(g.data.phoneFormat,
+			g.rand.Intn(100), g.rand.Intn(100), g.rand.Intn(100), g.rand.Intn(100)),
+		Address:      address,
+		LoyaltyLevel: loyaltyLevels[weightedIndex(g.rand, loyaltyWeights)],
+	}
+}
+
+// uuid draws a UUID from the random source, so that seeded generators are
+// reproducible.
+//
+// Returns:
+//   - string: A version 4 UUID.
+func (g *Generator) uuid() string {
+	id, err := uuid.NewRandomFromReader(g.rand)
+	if err != nil {
+		return uuid.New().String()
+	}
+	return id.String()
+}
+
+// emailPart converts a name to the local part of an email address.
+//
+// Parameters:
+//   - name: The first or last name.
+//
+// Returns:
+//   - string: The name in lowercase, without accents nor spaces.
+func emailPart(name string) string {
+	return strings.NewReplacer("é", "e", "è", "e", "ë", "e", "ï", "i", "ô", "o", " ", "", "'", "").
+		Replace(strings.ToLower(name))
+}
@@ -0,0 +1,139 @@
+package fake
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/models"
+)
+
+// seededConfig returns the default configuration with a fixed seed and clock.
+func seededConfig(seed int64) Config {
+	cfg := DefaultConfig()
+	cfg.Seed = seed
+	cfg.Clock = func() time.Time { return time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC) }
+	return cfg
+}
+
+// TestGeneratorReproducible tests that a seed always generates the same orders.
+func TestGeneratorReproducible(t *testing.T) {
+	a, b := New(seededConfig(7)), New(seededConfig(7))
+	other := New(seededConfig(8))
+	var differs bool
+	for sequence := 1; sequence <= 50; sequence++ {
+		orderA, err := a.Order(sequence)
+		if err != nil {
+			t.Fatalf("Order %d: unexpected error: %v", sequence, err)
+		}
+		orderB, _ := b.Order(sequence)
+		orderOther, _ := other.Order(sequence)
+		if orderA.OrderID != orderB.OrderID || orderA.Metadata != orderB.Metadata || orderA.Total != orderB.Total {
+			t.Fatalf("Order %d: expected identical orders, got %+v and %+v", sequence, orderA, orderB)
+		}
+		differs = differs || orderA.OrderID != orderOther.OrderID
+	}
+	if !differs {
+		t.Error("Expected another seed to generate other orders")
+	}
+}
+
+// TestGeneratorOrders tests the generated orders: valid, drawn from the pool
+// and the catalog, with distinct items and a consistent inventory snapshot.
+func TestGeneratorOrders(t *testing.T) {
+	g := New(seededConfig(1))
+	customers := make(map[string]models.CustomerInfo)
+	for _, c := range g.Customers() {
+		customers[c.CustomerID] = c
+	}
+	if len(customers) != DefaultCustomers {
+		t.Fatalf("Expected %d customers, got %d", DefaultCustomers, len(customers))
+	}
+
+	counts := make(map[string]int)
+	for sequence := 1; sequence <= 500; sequence++ {
+		order, err := g.Order(sequence)
+		if err != nil {
+			t.Fatalf("Order %d: unexpected error: %v", sequence, err)
+		}
+		if customers[order.CustomerInfo.CustomerID] != order.CustomerInfo {
+			t.Fatalf("Order %d: customer not from the pool: %+v", sequence, order.CustomerInfo)
+		}
+		counts[order.CustomerInfo.CustomerID]++
+
+		seen := make(map[string]bool)
+		for _, item := range order.Items {
+			if seen[item.ItemID] {
+				t.Fatalf("Order %d: item %s drawn twice", sequence, item.ItemID)
+			}
+			seen[item.ItemID] = true
+		}
+		inv := order.Inventory
+		if inv.ItemID != order.Items[0].ItemID || inv.ReservedQty != order.Items[0].Quantity || !inv.InStock || inv.AvailableQty > DefaultStock {
+			t.Fatalf("Order %d: inconsistent inventory %+v", sequence, inv)
+		}
+		if !strings.HasPrefix(order.DeliveryNotes, "Livrer au ") || !strings.HasSuffix(order.CustomerInfo.Email, "@example.com") {
+			t.Fatalf("Order %d: expected French delivery notes and an email, got %+v", sequence, order)
+		}
+	}
+	if counts["client01"] <= counts["client50"] {
+		t.Errorf("Expected the first customer to order more than the last one, got %d and %d", counts["client01"], counts["client50"])
+	}
+}
+
+// TestGeneratorLocaleAndCatalog tests the locale data and a custom catalog.
+func TestGeneratorLocaleAndCatalog(t *testing.T) {
+	cfg := seededConfig(3)
+	cfg.Locale = "EN"
+	cfg.Items = []Item{{ID: "item-tea", Name: "tea", Price: models.FromCents(200, "")}}
+	cfg.ItemsPerOrder = Constant(3)
+	cfg.Quantity = Constant(60)
+	g := New(cfg)
+
+	first, err := g.Order(1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(first.Items) != 1 || first.Items[0].ItemName != "tea" || first.Total.Cents() != 12000+2400+250 {
+		t.Errorf("Expected one tea item of 60 units, got %+v", first)
+	}
+	if !strings.HasPrefix(first.DeliveryNotes, "Deliver to ") || !strings.HasPrefix(first.CustomerInfo.Phone, "+1 ") {
+		t.Errorf("Expected English customer data, got %+v", first.CustomerInfo)
+	}
+
+	// 60 of the 40 units left: the item is restocked
+	second, _ := g.Order(2)
+	if first.Inventory.AvailableQty != DefaultStock || second.Inventory.AvailableQty != DefaultStock {
+		t.Errorf("Expected a restock, got %d then %d available", first.Inventory.AvailableQty, second.Inventory.AvailableQty)
+	}
+
+	if New(Config{Locale: "de", Seed: 1}).data.deliveryNotes != locales[DefaultLocale].deliveryNotes {
+		t.Error("Expected an unknown locale to fall back to the default one")
+	}
+}
+
+// TestDistributions tests the bounds and the weights of the distributions.
+func TestDistributions(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	counts := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		v := Uniform{Min: 2, Max: 4}.Draw(r)
+		if v < 2 || v > 4 {
+			t.Fatalf("Expected a value in [2, 4], got %d", v)
+		}
+		counts[Weighted{Values: []int{1, 2, 3}, Weights: []float64{0, 9, 1}}.Draw(r)]++
+	}
+	if counts[1] != 0 || counts[2] < 800 || counts[3] == 0 {
+		t.Errorf("Expected the weights to be honored, got %v", counts)
+	}
+	if got := (Uniform{Min: 5, Max: 1}).Draw(r); got != 5 {
+		t.Errorf("Expected Min when Max is lower, got %d", got)
+	}
+	if got := (Weighted{}).Draw(r); got != 0 {
+		t.Errorf("Expected 0 without values, got %d", got)
+	}
+	if got := Constant(4).Draw(r); got != 4 {
+		t.Errorf("Expected 4, got %d", got)
+	}
+}
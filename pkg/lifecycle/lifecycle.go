@@ -0,0 +1,226 @@
+/*
+Package lifecycle runs the components of a PubSub process and shuts them down
+gracefully.
+
+A Manager starts its hooks in order, then runs its services until the process
+receives SIGINT or SIGTERM, the parent context is cancelled or a service
+ends. It then cancels the context of the services, waits for them, and stops
+the hooks in reverse order, within a shared shutdown timeout:
+
+	lc := lifecycle.New(lifecycle.DefaultTimeout)
+	lc.Append(lifecycle.Hook{Name: "producer", Start: initialize, Stop: flush})
+	lc.Go("production", prod.Run)
+	err := lc.Run(context.Background())
+
+One-shot commands that only need to stop on a signal use SignalContext.
+*/
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DefaultTimeout is the default time allowed to the services and stop hooks to finish.
+const DefaultTimeout = 15 * time.Second
+
+// Signals are the signals that stop a Manager and cancel a SignalContext.
+var Signals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+
+// ErrTimeout is returned when the services or stop hooks did not finish within the shutdown timeout.
+var ErrTimeout = errors.New("shutdown timed out")
+
+// Hook is a component started and stopped by a Manager.
+type Hook struct {
+	Name string // Name of the component, in the errors.
+
+	// Start starts the component (nil: nothing to start). Its context is
+	// cancelled when the shutdown begins, so that the goroutines it starts can
+	// follow it.
+	Start func(ctx context.Context) error
+
+	// Stop stops the component (nil: nothing to stop). Its context expires at
+	// the end of the shutdown timeout.
+	Stop func(ctx context.Context) error
+}
+
+// service is a blocking function run by a Manager until its context is cancelled.
+type service struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// Manager starts, runs and stops the components of a process.
+type Manager struct {
+	mu       sync.Mutex
+	hooks    []Hook
+	services []service
+	timeout  time.Duration
+}
+
+// New creates a Manager.
+//
+// Parameters:
+//   - timeout: The time allowed to the services and stop hooks to finish (<= 0: DefaultTimeout).
+//
+// Returns:
+//   - *Manager: The manager.
+func New(timeout time.Duration) *Manager {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Manager{timeout: timeout}
+}
+
+// Append adds a hook, started after and stopped before the hooks already added.
+//
+// Parameters:
+//   - h: The hook.
+func (m *Manager) Append(h Hook) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hooks = append(m.hooks, h)
+}
+
+// Go adds a service, run once every hook has started. A service blocks until
+// its context is cancelled; if it returns earlier, the Manager shuts down.
+//
+// Parameters:
+//   - name: The name of the service, in the errors.
+//   - run: The service.
+func (m *Manager) Go(name string, run func(ctx context.Context) error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.services = append(m.services, service{name: name, run: run})
+}
+
+// Run starts the hooks and the services, waits for a signal, the
+// cancellation of ctx or the end of a service, then shuts down. If a hook
+// fails to start, the hooks already started are stopped and its error is
+// returned. If the services do not finish within the shutdown timeout, the
+// hooks are still stopped, within a new timeout, so that they release their
+// resources.
+//
+// Parameters:
+//   - ctx: The parent context.
+//
+// Returns:
+//   - error: The start error, or the errors of the services and stop hooks
+//     (ErrTimeout if they did not finish in time), joined.
+func (m *Manager) Run(ctx context.Context) error {
+	m.mu.Lock()
+	hooks := append([]Hook(nil), m.hooks...)
+	services := append([]service(nil), m.services...)
+	m.mu.Unlock()
+
+	runCtx, cancel := SignalContext(ctx)
+	defer cancel()
+
+	for i, h := range hooks {
+		if h.Start == nil {
+			continue
+		}
+		if err := h.Start(runCtx); err != nil {
+			cancel()
+			return errors.Join(err, m.stop(hooks[:i]))
+		}
+	}
+
+	var errs []error
+	var errsMu sync.Mutex
+	var wg sync.WaitGroup
+	for _, s := range services {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer cancel() // a service ending ends the others
+			if err := s.run(runCtx); err != nil && !errors.Is(err, context.Canceled) {
+				errsMu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+				errsMu.Unlock()
+			}
+		}()
+	}
+	<-runCtx.Done()
+	cancel() // restore the default handling: a second signal kills the process
+
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), m.timeout)
+	defer stopCancel()
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	var timeoutErr error
+	select {
+	case <-finished:
+	case <-stopCtx.Done():
+		timeoutErr = fmt.Errorf("services: %w", ErrTimeout)
+		stopCtx, stopCancel = context.WithTimeout(context.Background(), m.timeout)
+		defer stopCancel()
+	}
+	stopErr := m.stopWithin(stopCtx, hooks)
+
+	// The services still running after a timeout may add their errors meanwhile
+	errsMu.Lock()
+	defer errsMu.Unlock()
+	return errors.Join(append(errs, timeoutErr, stopErr)...)
+}
+
+// stop stops hooks in reverse order within the shutdown timeout.
+//
+// Parameters:
+//   - hooks: The started hooks.
+//
+// Returns:
+//   - error: The stop errors, joined.
+func (m *Manager) stop(hooks []Hook) error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+	return m.stopWithin(ctx, hooks)
+}
+
+// stopWithin stops hooks in reverse order, skipping the remaining ones once
+// ctx expires.
+//
+// Parameters:
+//   - ctx: The shutdown context.
+//   - hooks: The started hooks.
+//
+// Returns:
+//   - error: The stop errors, joined.
+func (m *Manager) stopWithin(ctx context.Context, hooks []Hook) error {
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		h := hooks[i]
+		if h.Stop == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", h.Name, ErrTimeout))
+			continue
+		}
+		if err := h.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", h.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SignalContext returns a copy of parent cancelled on SIGINT or SIGTERM.
+//
+// Parameters:
+//   - parent: The parent context.
+//
+// Returns:
+//   - context.Context: The context.
+//   - context.CancelFunc: Releases the signal handler; call it once done.
+func SignalContext(parent context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(parent, Signals...)
+}
@@ -0,0 +1,170 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// recorder records the calls of the hooks and services.
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) add(call string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+func (r *recorder) hook(name string, startErr error) Hook {
+	return Hook{
+		Name:  name,
+		Start: func(context.Context) error { r.add("start " + name); return startErr },
+		Stop:  func(context.Context) error { r.add("stop " + name); return nil },
+	}
+}
+
+func TestRunOrder(t *testing.T) {
+	r := &recorder{}
+	lc := New(time.Second)
+	lc.Append(r.hook("a", nil))
+	lc.Append(r.hook("b", nil))
+	lc.Go("service", func(ctx context.Context) error {
+		r.add("run")
+		<-ctx.Done()
+		r.add("done")
+		return ctx.Err()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	if err := lc.Run(ctx); err != nil {
+		t.Fatalf("Run() = %v", err)
+	}
+	want := []string{"start a", "start b", "run", "done", "stop b", "stop a"}
+	if !slices.Equal(r.calls, want) {
+		t.Errorf("Calls = %v, want %v", r.calls, want)
+	}
+}
+
+func TestRunStartError(t *testing.T) {
+	r := &recorder{}
+	startErr := errors.New("broker unreachable")
+	lc := New(time.Second)
+	lc.Append(r.hook("a", nil))
+	lc.Append(r.hook("b", startErr))
+	lc.Append(r.hook("c", nil))
+	lc.Go("service", func(ctx context.Context) error { r.add("run"); return nil })
+
+	if err := lc.Run(context.Background()); !errors.Is(err, startErr) {
+		t.Fatalf("Run() = %v, want %v", err, startErr)
+	}
+	want := []string{"start a", "start b", "stop a"}
+	if !slices.Equal(r.calls, want) {
+		t.Errorf("Calls = %v, want %v", r.calls, want)
+	}
+}
+
+func TestRunServiceEnds(t *testing.T) {
+	r := &recorder{}
+	failure := errors.New("too many read errors")
+	lc := New(time.Second)
+	lc.Append(r.hook("a", nil))
+	lc.Go("consumer", func(ctx context.Context) error { return failure })
+	lc.Go("stats", func(ctx context.Context) error {
+		<-ctx.Done()
+		r.add("stats stopped")
+		return nil
+	})
+
+	err := lc.Run(context.Background())
+	if !errors.Is(err, failure) || err.Error() != "consumer: too many read errors" {
+		t.Fatalf("Run() = %v, want the consumer error", err)
+	}
+	want := []string{"start a", "stats stopped", "stop a"}
+	if !slices.Equal(r.calls, want) {
+		t.Errorf("Calls = %v, want %v", r.calls, want)
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	lc := New(20 * time.Millisecond)
+	lc.Append(Hook{Name: "slow", Stop: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+	lc.Append(Hook{Name: "late", Stop: func(ctx context.Context) error {
+		time.Sleep(30 * time.Millisecond)
+		return nil
+	}})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := lc.Run(ctx)
+	if err == nil || err.Error() != "slow: "+ErrTimeout.Error() {
+		t.Errorf("Run() = %v, want the slow hook skipped once the timeout expired", err)
+	}
+
+	lc = New(20 * time.Millisecond)
+	lc.Go("stuck", func(ctx context.Context) error { select {} })
+	if err := lc.Run(ctx); !errors.Is(err, ErrTimeout) {
+		t.Errorf("Run() = %v, want ErrTimeout for a stuck service", err)
+	}
+}
+
+func TestRunTimeoutStopsHooks(t *testing.T) {
+	r := &recorder{}
+	failure := errors.New("too many read errors")
+	lc := New(20 * time.Millisecond)
+	lc.Append(r.hook("a", nil))
+	lc.Go("consumer", func(ctx context.Context) error { return failure })
+	lateDone := make(chan struct{})
+	lc.Go("late", func(ctx context.Context) error {
+		defer close(lateDone)
+		time.Sleep(50 * time.Millisecond)
+		return errors.New("late failure")
+	})
+
+	err := lc.Run(context.Background())
+	if !errors.Is(err, failure) || !errors.Is(err, ErrTimeout) {
+		t.Errorf("Run() = %v, want the consumer error and ErrTimeout", err)
+	}
+	want := []string{"start a", "stop a"}
+	if !slices.Equal(r.calls, want) {
+		t.Errorf("Calls = %v, want %v", r.calls, want)
+	}
+	<-lateDone
+}
+
+func TestRunSignal(t *testing.T) {
+	lc := New(time.Second)
+	started := make(chan struct{})
+	lc.Go("service", func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return nil
+	})
+	go func() {
+		<-started
+		syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+	}()
+	done := make(chan error)
+	go func() { done <- lc.Run(context.Background()) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run() = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SIGTERM did not stop the manager")
+	}
+}
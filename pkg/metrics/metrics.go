@@ -0,0 +1,533 @@
+/*
+Package metrics provides the counters, gauges and histograms of the PubSub
+components, and exposes them with two backends: a Prometheus scrape endpoint
+(Handler) and an OTLP push exporter (Pusher).
+
+Each component creates its own metrics and registers them, usually in the
+process registry Default:
+
+	received := metrics.NewCounter(metrics.Opts{
+		Name:   "pubsub_tracker_messages_received_total",
+		Help:   "Messages received by the tracker.",
+		Labels: map[string]string{"topic": topic},
+	})
+	metrics.MustRegister(received)
+	received.Inc()
+
+Registering a metric with the name and labels of a registered one replaces
+it, so that a component created again (after a restart, or in tests) exposes
+its new metrics.
+*/
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Kind is the type of a metric.
+type Kind string
+
+// Kinds of metrics, named after the Prometheus types.
+const (
+	KindCounter   Kind = "counter"
+	KindGauge     Kind = "gauge"
+	KindHistogram Kind = "histogram"
+)
+
+// DefBuckets are the default histogram buckets, in seconds: from 1 ms to 10 s.
+var DefBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// ErrKindConflict is returned when registering a metric under the name of a
+// metric of another kind or help text.
+var ErrKindConflict = errors.New("metric already registered with another kind or help")
+
+// namePattern matches the valid metric and label names.
+var namePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Opts describes a metric.
+type Opts struct {
+	Name   string            // Metric name (e.g., "pubsub_producer_published_total").
+	Help   string            // Description of the metric.
+	Labels map[string]string // Constant labels of the series (e.g., the topic).
+}
+
+// Label is a label of a series.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Metric is a counter, gauge or histogram, to register in a Registry.
+type Metric interface {
+	// Opts returns the description of the metric.
+	Opts() Opts
+	// Kind returns the type of the metric.
+	Kind() Kind
+	// sample reads the current value of the metric.
+	sample() Sample
+}
+
+// Counter is a value that only increases. It is safe for concurrent use.
+type Counter struct {
+	opts Opts
+	bits atomic.Uint64 // math.Float64bits of the value.
+}
+
+// NewCounter creates a Counter.
+//
+// Parameters:
+//   - opts: The description of the counter.
+//
+// Returns:
+//   - *Counter: The counter, at zero.
+func NewCounter(opts Opts) *Counter {
+	return &Counter{opts: opts}
+}
+
+// Inc adds one to the counter.
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+// Add adds a value to the counter. Negative values are ignored.
+//
+// Parameters:
+//   - v: The value to add.
+func (c *Counter) Add(v float64) {
+	if v < 0 {
+		return
+	}
+	addFloat(&c.bits, v)
+}
+
+// Value returns the current value of the counter.
+//
+// Returns:
+//   - float64: The value.
+func (c *Counter) Value() float64 {
+	return math.Float64frombits(c.bits.Load())
+}
+
+// Opts returns the description of the counter.
+//
+// Returns:
+//   - Opts: The description.
+func (c *Counter) Opts() Opts { return c.opts }
+
+// Kind returns KindCounter.
+//
+// Returns:
+//   - Kind: The kind.
+func (c *Counter) Kind() Kind { return KindCounter }
+
+func (c *Counter) sample() Sample { return Sample{Value: c.Value()} }
+
+// Gauge is a value that goes up and down. It is safe for concurrent use.
+type Gauge struct {
+	opts Opts
+	bits atomic.Uint64 // math.Float64bits of the value.
+	read func() float64
+}
+
+// NewGauge creates a Gauge.
+//
+// Parameters:
+//   - opts: The description of the gauge.
+//
+// Returns:
+//   - *Gauge: The gauge, at zero.
+func NewGauge(opts Opts) *Gauge {
+	return &Gauge{opts: opts}
+}
+
+// NewGaugeFunc creates a Gauge whose value is read from a function at each
+// collection (e.g., a state or a queue length). Set and Add have no effect.
+//
+// Parameters:
+//   - opts: The description of the gauge.
+//   - read: Returns the current value; it must be safe for concurrent use.
+//
+// Returns:
+//   - *Gauge: The gauge.
+func NewGaugeFunc(opts Opts, read func() float64) *Gauge {
+	return &Gauge{opts: opts, read: read}
+}
+
+// Set sets the gauge.
+//
+// Parameters:
+//   - v: The value.
+func (g *Gauge) Set(v float64) {
+	g.bits.Store(math.Float64bits(v))
+}
+
+// SetTime sets the gauge to a time, in seconds since the Unix epoch.
+//
+// Parameters:
+//   - t: The time.
+func (g *Gauge) SetTime(t time.Time) {
+	g.Set(float64(t.UnixNano()) / 1e9)
+}
+
+// Add adds a value, possibly negative, to the gauge.
+//
+// Parameters:
+//   - v: The value to add.
+func (g *Gauge) Add(v float64) {
+	addFloat(&g.bits, v)
+}
+
+// Value returns the current value of the gauge.
+//
+// Returns:
+//   - float64: The value.
+func (g *Gauge) Value() float64 {
+	if g.read != nil {
+		return g.read()
+	}
+	return math.Float64frombits(g.bits.Load())
+}
+
+// Time returns the value of a gauge set with SetTime.
+//
+// Returns:
+//   - time.Time: The time (zero if the gauge is zero).
+func (g *Gauge) Time() time.Time {
+	v := g.Value()
+	if v == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(v*1e9))
+}
+
+// Opts returns the description of the gauge.
+//
+// Returns:
+//   - Opts: The description.
+func (g *Gauge) Opts() Opts { return g.opts }
+
+// Kind returns KindGauge.
+//
+// Returns:
+//   - Kind: The kind.
+func (g *Gauge) Kind() Kind { return KindGauge }
+
+func (g *Gauge) sample() Sample { return Sample{Value: g.Value()} }
+
+// Histogram counts observations, such as durations, in buckets. It is safe
+// for concurrent use.
+type Histogram struct {
+	opts    Opts
+	bounds  []float64 // Upper bounds of the buckets, increasing, without +Inf.
+	mu      sync.Mutex
+	buckets []uint64 // Observations per bucket, the last one for +Inf.
+	count   uint64
+	sum     float64
+}
+
+// NewHistogram creates a Histogram.
+//
+// Parameters:
+//   - opts: The description of the histogram.
+//   - bounds: The upper bounds of the buckets (nil: DefBuckets); +Inf is implicit.
+//
+// Returns:
+//   - *Histogram: The histogram, empty.
+func NewHistogram(opts Opts, bounds []float64) *Histogram {
+	if bounds == nil {
+		bounds = DefBuckets
+	}
+	bounds = slices.Clone(bounds)
+	sort.Float64s(bounds)
+	bounds = slices.Compact(bounds)
+	if n := len(bounds); n > 0 && math.IsInf(bounds[n-1], 1) {
+		bounds = bounds[:n-1]
+	}
+	return &Histogram{opts: opts, bounds: bounds, buckets: make([]uint64, len(bounds)+1)}
+}
+
+// Observe adds an observation.
+//
+// Parameters:
+//   - v: The observed value.
+func (h *Histogram) Observe(v float64) {
+	i := sort.SearchFloat64s(h.bounds, v) // first bound >= v
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.buckets[i]++
+	h.count++
+	h.sum += v
+}
+
+// ObserveDuration adds a duration observation, in seconds.
+//
+// Parameters:
+//   - d: The duration.
+func (h *Histogram) ObserveDuration(d time.Duration) {
+	h.Observe(d.Seconds())
+}
+
+// Count returns the number of observations.
+//
+// Returns:
+//   - uint64: The observations.
+func (h *Histogram) Count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+// Opts returns the description of the histogram.
+//
+// Returns:
+//   - Opts: The description.
+func (h *Histogram) Opts() Opts { return h.opts }
+
+// Kind returns KindHistogram.
+//
+// Returns:
+//   - Kind: The kind.
+func (h *Histogram) Kind() Kind { return KindHistogram }
+
+func (h *Histogram) sample() Sample {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	buckets := make([]Bucket, len(h.bounds)+1)
+	var cumulative uint64
+	for i, n := range h.buckets {
+		cumulative += n
+		bound := math.Inf(1)
+		if i < len(h.bounds) {
+			bound = h.bounds[i]
+		}
+		buckets[i] = Bucket{UpperBound: bound, Count: cumulative}
+	}
+	return Sample{Value: h.sum, Count: h.count, Buckets: buckets}
+}
+
+// addFloat atomically adds v to the float64 stored as bits.
+//
+// Parameters:
+//   - bits: The math.Float64bits of the value.
+//   - v: The value to add.
+func addFloat(bits *atomic.Uint64, v float64) {
+	for {
+		old := bits.Load()
+		if bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+v)) {
+			return
+		}
+	}
+}
+
+// Bucket is a cumulative histogram bucket.
+type Bucket struct {
+	UpperBound float64 // Upper bound (+Inf for the last bucket).
+	Count      uint64  // Observations lower than or equal to UpperBound.
+}
+
+// Sample is the value of a series at collection time.
+type Sample struct {
+	Labels  []Label  // Labels of the series, sorted by name.
+	Value   float64  // Value of a counter or gauge, sum of a histogram.
+	Count   uint64   // Observations of a histogram.
+	Buckets []Bucket // Cumulative buckets of a histogram, the last one for +Inf.
+}
+
+// Family is the collected series of a metric name.
+type Family struct {
+	Name    string
+	Help    string
+	Kind    Kind
+	Samples []Sample // Sorted by labels.
+}
+
+// Registry holds the metrics exposed by a process. It is safe for concurrent use.
+type Registry struct {
+	mu      sync.Mutex
+	metrics map[string]Metric // By series key (name and labels).
+	start   time.Time         // Start of the cumulative values, for OTLP.
+}
+
+// Default is the registry of the process, exposed by the pubsub commands.
+var Default = NewRegistry()
+
+// NewRegistry creates an empty Registry.
+//
+// Returns:
+//   - *Registry: The registry.
+func NewRegistry() *Registry {
+	return &Registry{metrics: make(map[string]Metric), start: time.Now()}
+}
+
+// Register adds metrics to the registry, replacing the metrics with the same
+// name and labels.
+//
+// Parameters:
+//   - ms: The metrics.
+//
+// Returns:
+//   - error: An error if a name or label is invalid, or ErrKindConflict if a
+//     name is registered with another kind or help text; no metric is added then.
+func (r *Registry) Register(ms ...Metric) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range ms {
+		if err := r.check(m); err != nil {
+			return err
+		}
+	}
+	for _, m := range ms {
+		r.metrics[seriesKey(m.Opts())] = m
+	}
+	return nil
+}
+
+// check validates a metric before its registration; r.mu must be held.
+//
+// Parameters:
+//   - m: The metric.
+//
+// Returns:
+//   - error: An error if the metric cannot be registered.
+func (r *Registry) check(m Metric) error {
+	opts := m.Opts()
+	if !namePattern.MatchString(opts.Name) {
+		return fmt.Errorf("invalid metric name %q", opts.Name)
+	}
+	for name := range opts.Labels {
+		if !namePattern.MatchString(name) || strings.HasPrefix(name, "__") || name == "le" {
+			return fmt.Errorf("metric %s: invalid label name %q", opts.Name, name)
+		}
+	}
+	for _, other := range r.metrics {
+		o := other.Opts()
+		if o.Name == opts.Name && (other.Kind() != m.Kind() || o.Help != opts.Help) {
+			return fmt.Errorf("%s: %w", opts.Name, ErrKindConflict)
+		}
+	}
+	return nil
+}
+
+// MustRegister is like Register but panics on error. The metrics of the
+// components have constant names: an error is a programming error.
+//
+// Parameters:
+//   - ms: The metrics.
+func (r *Registry) MustRegister(ms ...Metric) {
+	if err := r.Register(ms...); err != nil {
+		panic(err)
+	}
+}
+
+// Unregister removes metrics from the registry, if they are still registered.
+//
+// Parameters:
+//   - ms: The metrics.
+func (r *Registry) Unregister(ms ...Metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range ms {
+		key := seriesKey(m.Opts())
+		if r.metrics[key] == m {
+			delete(r.metrics, key)
+		}
+	}
+}
+
+// Gather collects the current values of the registered metrics.
+//
+// Returns:
+//   - []Family: The families, sorted by name.
+func (r *Registry) Gather() []Family {
+	r.mu.Lock()
+	ms := make([]Metric, 0, len(r.metrics))
+	for _, m := range r.metrics {
+		ms = append(ms, m)
+	}
+	r.mu.Unlock()
+
+	byName := make(map[string]*Family)
+	for _, m := range ms {
+		opts := m.Opts()
+		f, ok := byName[opts.Name]
+		if !ok {
+			f = &Family{Name: opts.Name, Help: opts.Help, Kind: m.Kind()}
+			byName[opts.Name] = f
+		}
+		s := m.sample()
+		s.Labels = sortedLabels(opts.Labels)
+		f.Samples = append(f.Samples, s)
+	}
+
+	families := make([]Family, 0, len(byName))
+	for _, f := range byName {
+		sort.Slice(f.Samples, func(i, j int) bool {
+			return labelsKey(f.Samples[i].Labels) < labelsKey(f.Samples[j].Labels)
+		})
+		families = append(families, *f)
+	}
+	sort.Slice(families, func(i, j int) bool { return families[i].Name < families[j].Name })
+	return families
+}
+
+// MustRegister registers metrics in Default (see Registry.MustRegister).
+//
+// Parameters:
+//   - ms: The metrics.
+func MustRegister(ms ...Metric) {
+	Default.MustRegister(ms...)
+}
+
+// sortedLabels returns labels sorted by name.
+//
+// Parameters:
+//   - labels: The labels.
+//
+// Returns:
+//   - []Label: The sorted labels.
+func sortedLabels(labels map[string]string) []Label {
+	sorted := make([]Label, 0, len(labels))
+	for name, value := range labels {
+		sorted = append(sorted, Label{Name: name, Value: value})
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+// labelsKey returns a unique key of sorted labels.
+//
+// Parameters:
+//   - labels: The sorted labels.
+//
+// Returns:
+//   - string: The key.
+func labelsKey(labels []Label) string {
+	var b strings.Builder
+	for _, l := range labels {
+		b.WriteString(l.Name)
+		b.WriteByte(0)
+		b.WriteString(l.Value)
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+// seriesKey returns a unique key of the series of a metric.
+//
+// Parameters:
+//   - opts: The description of the metric.
+//
+// Returns:
+//   - string: The key.
+func seriesKey(opts Opts) string {
+	return opts.Name + "\x00" + labelsKey(sortedLabels(opts.Labels))
+}
@@ -0,0 +1,212 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestCounterConcurrent tests that concurrent increments are all counted.
+func TestCounterConcurrent(t *testing.T) {
+	c := NewCounter(Opts{Name: "test_total", Help: "Test."})
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				c.Inc()
+			}
+		}()
+	}
+	wg.Wait()
+	c.Add(-5) // ignored: a counter only increases
+	if got := c.Value(); got != 8000 {
+		t.Errorf("Expected 8000, got %v", got)
+	}
+}
+
+// TestGaugeTime tests that a gauge set with SetTime returns the time.
+func TestGaugeTime(t *testing.T) {
+	g := NewGauge(Opts{Name: "test_timestamp_seconds", Help: "Test."})
+	if !g.Time().IsZero() {
+		t.Errorf("Expected a zero time before SetTime, got %v", g.Time())
+	}
+	now := time.Date(2024, 3, 1, 12, 0, 0, 500_000_000, time.UTC)
+	g.SetTime(now)
+	if got := g.Time(); got.Sub(now).Abs() > time.Microsecond {
+		t.Errorf("Expected %v, got %v", now, got)
+	}
+}
+
+// TestRegistryReplace tests that a metric registered again with the same
+// name and labels replaces the previous one.
+func TestRegistryReplace(t *testing.T) {
+	r := NewRegistry()
+	opts := Opts{Name: "orders_total", Help: "Orders.", Labels: map[string]string{"topic": "orders"}}
+	first, second := NewCounter(opts), NewCounter(opts)
+	other := NewCounter(Opts{Name: "orders_total", Help: "Orders.", Labels: map[string]string{"topic": "other"}})
+	first.Add(3)
+	second.Add(5)
+	r.MustRegister(first, other)
+	r.MustRegister(second)
+
+	families := r.Gather()
+	if len(families) != 1 || len(families[0].Samples) != 2 {
+		t.Fatalf("Expected 1 family with 2 series, got %+v", families)
+	}
+	if s := families[0].Samples[0]; s.Labels[0].Value != "orders" || s.Value != 5 {
+		t.Errorf("Expected the second counter for topic orders, got %+v", s)
+	}
+
+	r.Unregister(first) // already replaced: no effect
+	if got := len(r.Gather()[0].Samples); got != 2 {
+		t.Errorf("Expected 2 series after unregistering a replaced metric, got %d", got)
+	}
+}
+
+// TestRegistryRejects tests that invalid names and kind conflicts are rejected.
+func TestRegistryRejects(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister(NewCounter(Opts{Name: "requests_total", Help: "Requests."}))
+
+	tests := map[string]Metric{
+		"invalid name":  NewCounter(Opts{Name: "requests-total"}),
+		"invalid label": NewCounter(Opts{Name: "ok_total", Labels: map[string]string{"le": "1"}}),
+		"kind conflict": NewGauge(Opts{Name: "requests_total", Help: "Requests."}),
+		"help conflict": NewCounter(Opts{Name: "requests_total", Help: "Other.", Labels: map[string]string{"a": "b"}}),
+	}
+	for name, m := range tests {
+		if err := r.Register(m); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if err := r.Register(NewGauge(Opts{Name: "requests_total", Help: "Requests."})); !errors.Is(err, ErrKindConflict) {
+		t.Errorf("Expected ErrKindConflict, got %v", err)
+	}
+}
+
+// TestHandler tests the Prometheus text exposition of every kind of metric.
+func TestHandler(t *testing.T) {
+	r := NewRegistry()
+	c := NewCounter(Opts{Name: "published_total", Help: "Published orders.", Labels: map[string]string{"topic": `or"ders`}})
+	g := NewGaugeFunc(Opts{Name: "breaker_state", Help: "Breaker state."}, func() float64 { return 2 })
+	h := NewHistogram(Opts{Name: "duration_seconds", Help: "Duration."}, []float64{0.1, 1})
+	r.MustRegister(c, g, h)
+	c.Add(7)
+	h.Observe(0.05)
+	h.Observe(0.5)
+	h.Observe(3)
+
+	rec := httptest.NewRecorder()
+	Handler(r).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != PrometheusContentType {
+		t.Errorf("Expected content type %q, got %q", PrometheusContentType, ct)
+	}
+	want := `# HELP breaker_state Breaker state.
+# TYPE breaker_state gauge
+breaker_state 2
+# HELP duration_seconds Duration.
+# TYPE duration_seconds histogram
+duration_seconds_bucket{le="0.1"} 1
+duration_seconds_bucket{le="1"} 2
+duration_seconds_bucket{le="+Inf"} 3
+duration_seconds_sum 3.55
+duration_seconds_count 3
+# HELP published_total Published orders.
+# TYPE published_total counter
+published_total{topic="or\"ders"} 7
+`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("Unexpected exposition:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestPusher tests that the pushed OTLP payload carries the metrics.
+func TestPusher(t *testing.T) {
+	var mu sync.Mutex
+	var received *metricspb.MetricsData
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if ct := req.Header.Get("Content-Type"); ct != "application/x-protobuf" || req.Header.Get("Authorization") != "Bearer t" {
+			http.Error(w, "unexpected headers", http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(req.Body)
+		data := &metricspb.MetricsData{}
+		if err := proto.Unmarshal(body, data); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		received = data
+		mu.Unlock()
+	}))
+	defer collector.Close()
+
+	r := NewRegistry()
+	c := NewCounter(Opts{Name: "published_total", Help: "Published.", Labels: map[string]string{"topic": "orders"}})
+	h := NewHistogram(Opts{Name: "duration_seconds", Help: "Duration."}, []float64{1})
+	r.MustRegister(c, h)
+	c.Add(2)
+	h.Observe(0.5)
+	h.Observe(2)
+
+	p := &Pusher{Endpoint: collector.URL, Service: "pubsub-test", Registry: r, Headers: map[string]string{"Authorization": "Bearer t"}}
+	if err := p.Push(context.Background()); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	rm := received.GetResourceMetrics()[0]
+	if got := rm.GetResource().GetAttributes()[0].GetValue().GetStringValue(); got != "pubsub-test" {
+		t.Errorf("Expected service.name pubsub-test, got %q", got)
+	}
+	ms := rm.GetScopeMetrics()[0].GetMetrics()
+	if len(ms) != 2 {
+		t.Fatalf("Expected 2 metrics, got %d", len(ms))
+	}
+	hist := ms[0].GetHistogram().GetDataPoints()[0]
+	if hist.GetCount() != 2 || len(hist.GetBucketCounts()) != 2 || hist.GetBucketCounts()[0] != 1 || hist.GetBucketCounts()[1] != 1 {
+		t.Errorf("Unexpected histogram point %v", hist)
+	}
+	sum := ms[1].GetSum()
+	if !sum.GetIsMonotonic() || sum.GetDataPoints()[0].GetAsDouble() != 2 || sum.GetDataPoints()[0].GetAttributes()[0].GetKey() != "topic" {
+		t.Errorf("Unexpected counter %v", sum)
+	}
+}
+
+// TestPusherRejected tests that a collector error is returned and reported by Run.
+func TestPusherRejected(t *testing.T) {
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "quota exceeded", http.StatusTooManyRequests)
+	}))
+	defer collector.Close()
+
+	errs := make(chan error, 10)
+	p := &Pusher{Endpoint: collector.URL, Registry: NewRegistry(), Interval: time.Millisecond, OnError: func(err error) { errs <- err }}
+	err := p.Push(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "quota exceeded") {
+		t.Errorf("Expected the collector error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.Run(ctx); err != nil {
+		t.Errorf("Run: %v", err)
+	}
+	select {
+	case <-errs:
+	default:
+		t.Error("Expected the last push error to be reported")
+	}
+}
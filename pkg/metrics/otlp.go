@@ -0,0 +1,236 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// OTLP exporter defaults.
+const (
+	// DefaultOTLPInterval is the default interval between two pushes.
+	DefaultOTLPInterval = 15 * time.Second
+	// DefaultOTLPTimeout is the default timeout of a push.
+	DefaultOTLPTimeout = 10 * time.Second
+)
+
+// scopeName is the instrumentation scope of the pushed metrics.
+const scopeName = "github.com/agbruneau/PubSub/pkg/metrics"
+
+// Pusher pushes the metrics of a registry to an OpenTelemetry collector with
+// the OTLP/HTTP protocol (binary protobuf), as cumulative values.
+type Pusher struct {
+	Endpoint string            // Metrics URL of the collector (e.g., "http://localhost:4318/v1/metrics").
+	Service  string            // Value of the service.name resource attribute.
+	Interval time.Duration     // Interval between two pushes (0: DefaultOTLPInterval).
+	Headers  map[string]string // Additional request headers (e.g., authentication).
+	Registry *Registry         // Pushed metrics (nil: Default).
+	Client   *http.Client      // HTTP client (nil: timeout of DefaultOTLPTimeout).
+	OnError  func(error)       // Called with the errors of the pushes of Run (nil: ignored).
+}
+
+// Run pushes the metrics every Interval until ctx is cancelled, then pushes
+// them a last time. The errors of the pushes are reported to OnError.
+//
+// Parameters:
+//   - ctx: Stops the pushes.
+//
+// Returns:
+//   - error: Always nil; the signature matches lifecycle.Manager.Go.
+func (p *Pusher) Run(ctx context.Context) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DefaultOTLPInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			last, cancel := context.WithTimeout(context.WithoutCancel(ctx), DefaultOTLPTimeout)
+			defer cancel()
+			p.report(p.Push(last))
+			return nil
+		case <-ticker.C:
+			p.report(p.Push(ctx))
+		}
+	}
+}
+
+// report passes a push error to OnError.
+//
+// Parameters:
+//   - err: The error of the push (nil: nothing to report).
+func (p *Pusher) report(err error) {
+	if err != nil && p.OnError != nil {
+		p.OnError(err)
+	}
+}
+
+// Push sends the current values of the metrics.
+//
+// Parameters:
+//   - ctx: The context of the request.
+//
+// Returns:
+//   - error: An error if the request fails or the collector rejects it.
+func (p *Pusher) Push(ctx context.Context) error {
+	registry := p.Registry
+	if registry == nil {
+		registry = Default
+	}
+	body, err := proto.Marshal(toOTLP(registry.Gather(), p.Service, registry.start, time.Now()))
+	if err != nil {
+		return fmt.Errorf("otlp: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("otlp: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for name, value := range p.Headers {
+		req.Header.Set(name, value)
+	}
+
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultOTLPTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("otlp: %w", err)
+	}
+	defer resp.Body.Close()
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("otlp: %s rejected the metrics: %s %s", p.Endpoint, resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// toOTLP converts metric families to an OTLP payload. MetricsData is
+// wire-compatible with the ExportMetricsServiceRequest of the collector.
+//
+// Parameters:
+//   - families: The families (see Registry.Gather).
+//   - service: The service.name resource attribute.
+//   - start: The start of the cumulative values.
+//   - now: The collection time.
+//
+// Returns:
+//   - *metricspb.MetricsData: The payload.
+func toOTLP(families []Family, service string, start, now time.Time) *metricspb.MetricsData {
+	startNano, nowNano := uint64(start.UnixNano()), uint64(now.UnixNano())
+	ms := make([]*metricspb.Metric, 0, len(families))
+	for _, f := range families {
+		m := &metricspb.Metric{Name: f.Name, Description: f.Help}
+		switch f.Kind {
+		case KindCounter:
+			m.Data = &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+				DataPoints:             numberPoints(f.Samples, startNano, nowNano),
+				AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				IsMonotonic:            true,
+			}}
+		case KindGauge:
+			m.Data = &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{
+				DataPoints: numberPoints(f.Samples, startNano, nowNano),
+			}}
+		case KindHistogram:
+			m.Data = &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+				DataPoints:             histogramPoints(f.Samples, startNano, nowNano),
+				AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			}}
+		}
+		ms = append(ms, m)
+	}
+
+	return &metricspb.MetricsData{ResourceMetrics: []*metricspb.ResourceMetrics{{
+		Resource: &resourcepb.Resource{Attributes: attributes([]Label{{Name: "service.name", Value: service}})},
+		ScopeMetrics: []*metricspb.ScopeMetrics{{
+			Scope:   &commonpb.InstrumentationScope{Name: scopeName},
+			Metrics: ms,
+		}},
+	}}}
+}
+
+// numberPoints converts counter or gauge samples.
+//
+// Parameters:
+//   - samples: The samples.
+//   - start: The start of the cumulative values, in Unix nanoseconds.
+//   - now: The collection time, in Unix nanoseconds.
+//
+// Returns:
+//   - []*metricspb.NumberDataPoint: The data points.
+func numberPoints(samples []Sample, start, now uint64) []*metricspb.NumberDataPoint {
+	points := make([]*metricspb.NumberDataPoint, len(samples))
+	for i, s := range samples {
+		points[i] = &metricspb.NumberDataPoint{
+			Attributes:        attributes(s.Labels),
+			StartTimeUnixNano: start,
+			TimeUnixNano:      now,
+			Value:             &metricspb.NumberDataPoint_AsDouble{AsDouble: s.Value},
+		}
+	}
+	return points
+}
+
+// histogramPoints converts histogram samples; OTLP counts the observations
+// of each bucket, not cumulatively.
+//
+// Parameters:
+//   - samples: The samples.
+//   - start: The start of the cumulative values, in Unix nanoseconds.
+//   - now: The collection time, in Unix nanoseconds.
+//
+// Returns:
+//   - []*metricspb.HistogramDataPoint: The data points.
+func histogramPoints(samples []Sample, start, now uint64) []*metricspb.HistogramDataPoint {
+	points := make([]*metricspb.HistogramDataPoint, len(samples))
+	for i, s := range samples {
+		counts := make([]uint64, len(s.Buckets))
+		bounds := make([]float64, 0, len(s.Buckets))
+		var previous uint64
+		for j, b := range s.Buckets {
+			counts[j] = b.Count - previous
+			previous = b.Count
+			if j < len(s.Buckets)-1 {
+				bounds = append(bounds, b.UpperBound)
+			}
+		}
+		points[i] = &metricspb.HistogramDataPoint{
+			Attributes:        attributes(s.Labels),
+			StartTimeUnixNano: start,
+			TimeUnixNano:      now,
+			Count:             s.Count,
+			Sum:               proto.Float64(s.Value),
+			BucketCounts:      counts,
+			ExplicitBounds:    bounds,
+		}
+	}
+	return points
+}
+
+// attributes converts labels to OTLP attributes.
+//
+// Parameters:
+//   - labels: The labels.
+//
+// Returns:
+//   - []*commonpb.KeyValue: The attributes.
+func attributes(labels []Label) []*commonpb.KeyValue {
+	attrs := make([]*commonpb.KeyValue, len(labels))
+	for i, l := range labels {
+		attrs[i] = &commonpb.KeyValue{Key: l.Name, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: l.Value}}}
+	}
+	return attrs
+}
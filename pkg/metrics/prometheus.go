@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// PrometheusContentType is the content type of the Prometheus text exposition format.
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Handler returns the Prometheus scrape endpoint of a registry (typically
+// served on /metrics).
+//
+// Parameters:
+//   - r: The registry.
+//
+// Returns:
+//   - http.Handler: The handler, writing the metrics in the text exposition format.
+func Handler(r *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", PrometheusContentType)
+		WritePrometheus(w, r.Gather())
+	})
+}
+
+// WritePrometheus writes metric families in the Prometheus text exposition format.
+//
+// Parameters:
+//   - w: The destination.
+//   - families: The families (see Registry.Gather).
+//
+// Returns:
+//   - error: The write error.
+func WritePrometheus(w io.Writer, families []Family) error {
+	b := bufio.NewWriter(w)
+	for _, f := range families {
+		b.WriteString("# HELP " + f.Name + " " + escapeHelp(f.Help) + "\n")
+		b.WriteString("# TYPE " + f.Name + " " + string(f.Kind) + "\n")
+		for _, s := range f.Samples {
+			if f.Kind != KindHistogram {
+				writeSample(b, f.Name, s.Labels, s.Value)
+				continue
+			}
+			for _, bucket := range s.Buckets {
+				labels := append(s.Labels[:len(s.Labels):len(s.Labels)], Label{Name: "le", Value: formatFloat(bucket.UpperBound)})
+				writeSample(b, f.Name+"_bucket", labels, float64(bucket.Count))
+			}
+			writeSample(b, f.Name+"_sum", s.Labels, s.Value)
+			writeSample(b, f.Name+"_count", s.Labels, float64(s.Count))
+		}
+	}
+	return b.Flush()
+}
+
+// writeSample writes a sample line.
+//
+// Parameters:
+//   - b: The destination.
+//   - name: The sample name.
+//   - labels: The labels.
+//   - v: The value.
+func writeSample(b *bufio.Writer, name string, labels []Label, v float64) {
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(l.Name + `="` + escapeLabel(l.Value) + `"`)
+		}
+		b.WriteByte('}')
+	}
+	b.WriteString(" " + formatFloat(v) + "\n")
+}
+
+// formatFloat formats a value as Prometheus expects it (+Inf, -Inf, NaN).
+//
+// Parameters:
+//   - v: The value.
+//
+// Returns:
+//   - string: The formatted value.
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+// escapeHelp escapes a help text.
+//
+// Parameters:
+//   - s: The help text.
+//
+// Returns:
+//   - string: The escaped text.
+func escapeHelp(s string) string { return helpEscaper.Replace(s) }
+
+// escapeLabel escapes a label value.
+//
+// Parameters:
+//   - s: The label value.
+//
+// Returns:
+//   - string: The escaped value.
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// OrderSampleSize is the number of order totals an OrderAggregator keeps per
+// currency for the percentiles. Beyond it, the totals are sampled, so that a
+// long-running aggregator (e.g., the monitor's) uses bounded memory.
+const OrderSampleSize = 10000
+
+// CurrencyStats contains the revenue statistics of the orders in one currency.
+type CurrencyStats struct {
+	Orders  int   `json:"orders"`  // Number of orders.
+	Revenue Money `json:"revenue"` // Sum of the order totals.
+	P50     Money `json:"p50"`     // Median order total.
+	P95     Money `json:"p95"`     // 95th percentile order total.
+	Max     Money `json:"max"`     // Largest order total.
+}
+
+// OrderStats is a snapshot of the orders added to an OrderAggregator.
+type OrderStats struct {
+	Orders         int                      `json:"orders"`          // Number of orders.
+	Items          int                      `json:"items"`           // Number of items ordered (sum of the quantities).
+	Customers      int                      `json:"customers"`       // Number of distinct customers.
+	Currencies     map[string]CurrencyStats `json:"currencies"`      // Revenue statistics by currency.
+	ItemQuantities map[string]int           `json:"item_quantities"` // Quantity ordered by item ID.
+}
+
+// OrderAggregator accumulates business statistics over a stream of orders:
+// revenue by currency, order value percentiles, item and customer counts.
+// Counts, revenue and maximum are exact; the percentiles are exact up to
+// OrderSampleSize orders per currency, then estimated from a uniform sample
+// of that size (reservoir sampling). It is safe for concurrent use.
+type OrderAggregator struct {
+	mu         sync.Mutex
+	orders     int
+	items      int
+	customers  map[string]struct{}
+	totals     map[string]*currencyTotals
+	itemQty    map[string]int
+	sampleSize int
+}
+
+// currencyTotals accumulates the order totals of one currency.
+type currencyTotals struct {
+	orders  int
+	revenue Money
+	max     Money
+	sample  []Money // At most sampleSize totals, for the percentiles.
+}
+
+// NewOrderAggregator creates an empty aggregator.
+//
+// Returns:
+//   - *OrderAggregator: The aggregator.
+func NewOrderAggregator() *OrderAggregator {
+	a := &OrderAggregator{sampleSize: OrderSampleSize}
+	a.reset()
+	return a
+}
+
+// Add accumulates an order. Amounts are never converted: each currency has its
+// own statistics.
+//
+// Parameters:
+//   - order: The order.
+func (a *OrderAggregator) Add(order Order) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.orders++
+	a.customers[order.CustomerInfo.CustomerID] = struct{}{}
+	total := order.Total.WithCurrency(order.Currency)
+	totals, ok := a.totals[total.Currency()]
+	if !ok {
+		totals = &currencyTotals{}
+		a.totals[total.Currency()] = totals
+	}
+	totals.add(total, a.sampleSize)
+	for _, item := range order.Items {
+		a.items += item.Quantity
+		a.itemQty[item.ItemID] += item.Quantity
+	}
+}
+
+// Snapshot returns the statistics of the orders added so far.
+//
+// Returns:
+//   - OrderStats: The statistics (zero counts and empty maps if no order was added).
+func (a *OrderAggregator) Snapshot() OrderStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.snapshot()
+}
+
+// Reset returns the statistics of the orders added so far and empties the
+// aggregator, so that each snapshot covers one window.
+//
+// Returns:
+//   - OrderStats: The statistics of the window that ends.
+func (a *OrderAggregator) Reset() OrderStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	stats := a.snapshot()
+	a.reset()
+	return stats
+}
+
+// snapshot computes the statistics; the caller holds the lock.
+//
+// Returns:
+//   - OrderStats: The statistics.
+func (a *OrderAggregator) snapshot() OrderStats {
+	stats := OrderStats{
+		Orders:         a.orders,
+		Items:          a.items,
+		Customers:      len(a.customers),
+		Currencies:     make(map[string]CurrencyStats, len(a.totals)),
+		ItemQuantities: make(map[string]int, len(a.itemQty)),
+	}
+	for currency, totals := range a.totals {
+		sorted := append([]Money(nil), totals.sample...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cents() < sorted[j].Cents() })
+		stats.Currencies[currency] = CurrencyStats{
+			Orders:  totals.orders,
+			Revenue: totals.revenue,
+			P50:     percentileMoney(sorted, 50),
+			P95:     percentileMoney(sorted, 95),
+			Max:     totals.max,
+		}
+	}
+	for itemID, qty := range a.itemQty {
+		stats.ItemQuantities[itemID] = qty
+	}
+	return stats
+}
+
+// reset empties the aggregator; the caller holds the lock.
+func (a *OrderAggregator) reset() {
+	a.orders = 0
+	a.items = 0
+	a.customers = make(map[string]struct{})
+	a.totals = make(map[string]*currencyTotals)
+	a.itemQty = make(map[string]int)
+}
+
+// add accumulates an order total, replacing a random sampled total once the
+// sample is full (reservoir sampling: each total is kept with the same
+// probability).
+//
+// Parameters:
+//   - total: The order total, in the currency of c.
+//   - sampleSize: The maximum size of the sample.
+func (c *currencyTotals) add(total Money, sampleSize int) {
+	c.orders++
+	// The totals are keyed by their currency: the sum cannot fail.
+	c.revenue, _ = c.revenue.Add(total)
+	if c.orders == 1 || total.Cents() > c.max.Cents() {
+		c.max = total
+	}
+	if len(c.sample) < sampleSize {
+		c.sample = append(c.sample, total)
+		return
+	}
+	if i := rand.Intn(c.orders); i < sampleSize {
+		c.sample[i] = total
+	}
+}
+
+// percentileMoney returns the p-th percentile of sorted amounts using the
+// nearest-rank method.
+//
+// Parameters:
+//   - sorted: The amounts sorted in ascending order (must not be empty).
+//   - p: The percentile to compute (0-100).
+//
+// Returns:
+//   - Money: The percentile amount.
+func percentileMoney(sorted []Money, p int) Money {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
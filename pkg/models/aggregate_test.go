@@ -0,0 +1,99 @@
+package models
+
+import (
+	"sync"
+	"testing"
+)
+
+// aggregateOrder returns an order of a customer with one item.
+func aggregateOrder(customerID, currency string, qty int, total Money) Order {
+	return Order{
+		CustomerInfo: CustomerInfo{CustomerID: customerID},
+		Items:        []OrderItem{{ItemID: "item-" + currency, Quantity: qty}},
+		Total:        total,
+		Currency:     currency,
+	}
+}
+
+// TestOrderAggregatorSnapshot tests the counts, revenue and percentiles.
+func TestOrderAggregatorSnapshot(t *testing.T) {
+	a := NewOrderAggregator()
+	if stats := a.Snapshot(); stats.Orders != 0 || len(stats.Currencies) != 0 {
+		t.Errorf("Expected empty statistics, got %+v", stats)
+	}
+
+	for i := 1; i <= 20; i++ {
+		a.Add(aggregateOrder("c1", "EUR", 1, cents(int64(i*100))))
+	}
+	a.Add(aggregateOrder("c2", "JPY", 3, cents(150000)))
+
+	stats := a.Snapshot()
+	if stats.Orders != 21 || stats.Customers != 2 || stats.Items != 23 {
+		t.Errorf("Unexpected counts %+v", stats)
+	}
+	if stats.ItemQuantities["item-EUR"] != 20 || stats.ItemQuantities["item-JPY"] != 3 {
+		t.Errorf("Unexpected item quantities %v", stats.ItemQuantities)
+	}
+	eur := stats.Currencies["EUR"]
+	if eur != (CurrencyStats{Orders: 20, Revenue: FromCents(21000, "EUR"), P50: FromCents(1000, "EUR"), P95: FromCents(1900, "EUR"), Max: FromCents(2000, "EUR")}) {
+		t.Errorf("Unexpected EUR statistics %+v", eur)
+	}
+	if jpy := stats.Currencies["JPY"]; jpy.P50.Cents() != 150000 || jpy.P95.Cents() != 150000 || jpy.Revenue.Cents() != 150000 {
+		t.Errorf("Unexpected JPY statistics %+v", jpy)
+	}
+}
+
+// TestOrderAggregatorReset tests that Reset returns the window and empties it.
+func TestOrderAggregatorReset(t *testing.T) {
+	a := NewOrderAggregator()
+	a.Add(aggregateOrder("c1", "EUR", 2, cents(500)))
+
+	if stats := a.Reset(); stats.Orders != 1 || stats.Currencies["EUR"].Revenue.Cents() != 500 {
+		t.Errorf("Unexpected window %+v", stats)
+	}
+	if stats := a.Snapshot(); stats.Orders != 0 || stats.Customers != 0 || len(stats.ItemQuantities) != 0 {
+		t.Errorf("Expected an empty aggregator after Reset, got %+v", stats)
+	}
+}
+
+// TestOrderAggregatorSample tests that the percentile sample is bounded while
+// the counts, revenue and maximum stay exact.
+func TestOrderAggregatorSample(t *testing.T) {
+	a := NewOrderAggregator()
+	a.sampleSize = 10
+	for i := 1; i <= 1000; i++ {
+		a.Add(aggregateOrder("c1", "EUR", 1, cents(int64(i))))
+	}
+
+	if n := len(a.totals["EUR"].sample); n != 10 {
+		t.Errorf("Expected 10 sampled totals, got %d", n)
+	}
+	eur := a.Snapshot().Currencies["EUR"]
+	if eur.Orders != 1000 || eur.Revenue.Cents() != 500500 || eur.Max.Cents() != 1000 {
+		t.Errorf("Unexpected EUR statistics %+v", eur)
+	}
+	if eur.P50.Cents() < 1 || eur.P50.Cents() > eur.P95.Cents() || eur.P95.Cents() > 1000 {
+		t.Errorf("Unexpected EUR percentiles %+v", eur)
+	}
+}
+
+// TestOrderAggregatorConcurrent tests concurrent accumulation.
+func TestOrderAggregatorConcurrent(t *testing.T) {
+	a := NewOrderAggregator()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				a.Add(aggregateOrder("c1", "EUR", 1, cents(100)))
+				a.Snapshot()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if stats := a.Snapshot(); stats.Orders != 800 || stats.Currencies["EUR"].Revenue.Cents() != 80000 {
+		t.Errorf("Unexpected statistics %+v", stats)
+	}
+}
@@ -0,0 +1,237 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Order defaults filled by NewOrder.
+const (
+	DefaultOrderStatus = "pending"
+	DefaultCurrency    = "EUR"
+	DefaultEventType   = EventOrderCreated
+)
+
+// Option configures an order built by NewOrder.
+type Option func(*OrderBuilder)
+
+// OrderBuilder assembles an order from options, fills the defaults and
+// derives the amounts, so that callers only provide the business data.
+type OrderBuilder struct {
+	order       Order
+	taxRate     float64
+	shippingFee Money
+	now         func() time.Time
+}
+
+// NewOrder creates an order builder. The order gets a random UUID, the
+// pending status, the EUR currency and order.created metadata in the current
+// schema version, with a fresh correlation ID, unless options override them.
+//
+// Parameters:
+//   - opts: The options, applied in order.
+//
+// Returns:
+//   - *OrderBuilder: The builder; call Build to get the order.
+func NewOrder(opts ...Option) *OrderBuilder {
+	b := &OrderBuilder{
+		order: Order{
+			OrderID:  uuid.New().String(),
+			Status:   DefaultOrderStatus,
+			Currency: DefaultCurrency,
+			Metadata: OrderMetadata{
+				Version:       string(CurrentSchemaVersion),
+				EventType:     DefaultEventType,
+				CorrelationID: uuid.New().String(),
+			},
+		},
+		now: time.Now,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Build derives the amounts, timestamps the order and validates it. Item
+// totals are quantity * unit price, the subtotal is their sum, the tax is the
+// subtotal times the tax rate and the total adds the tax and the shipping fee.
+// Unbound prices take the currency of WithPricing.
+//
+// Returns:
+//   - Order: The order, returned even if it is invalid.
+//   - error: The first failed validation rule (see Order.Validate), or nil.
+func (b *OrderBuilder) Build() (Order, error) {
+	order := b.order
+	order.Items = append([]OrderItem(nil), b.order.Items...)
+
+	order.ShippingFee = b.shippingFee
+	order.bindCurrency()
+	if err := order.checkCurrencies(order.Currency); err != nil {
+		return order, err
+	}
+
+	totals := make([]Money, len(order.Items))
+	for i := range order.Items {
+		item := &order.Items[i]
+		item.TotalPrice = item.UnitPrice.Mul(item.Quantity)
+		totals[i] = item.TotalPrice
+	}
+	var err error
+	if order.SubTotal, err = Sum(totals...); err != nil {
+		return order, &FieldError{Field: "subtotal", Err: err}
+	}
+	order.Tax = order.SubTotal.MulRate(b.taxRate)
+	if order.Total, err = Sum(order.SubTotal, order.Tax, order.ShippingFee); err != nil {
+		return order, &FieldError{Field: "total", Err: err}
+	}
+	if order.Metadata.Timestamp.IsZero() {
+		order.Metadata.Timestamp = NewTimestamp(b.now())
+	}
+	return order, order.Validate()
+}
+
+// WithOrderID sets the order ID instead of a random UUID.
+//
+// Parameters:
+//   - id: The order ID.
+//
+// Returns:
+//   - Option: The option.
+func WithOrderID(id string) Option {
+	return func(b *OrderBuilder) { b.order.OrderID = id }
+}
+
+// WithSequence sets the sequence number.
+//
+// Parameters:
+//   - sequence: The sequence number (must be positive).
+//
+// Returns:
+//   - Option: The option.
+func WithSequence(sequence int) Option {
+	return func(b *OrderBuilder) { b.order.Sequence = sequence }
+}
+
+// WithStatus sets the status instead of "pending".
+//
+// Parameters:
+//   - status: The status.
+//
+// Returns:
+//   - Option: The option.
+func WithStatus(status string) Option {
+	return func(b *OrderBuilder) { b.order.Status = status }
+}
+
+// WithCustomer sets the customer information.
+//
+// Parameters:
+//   - customer: The customer information.
+//
+// Returns:
+//   - Option: The option.
+func WithCustomer(customer CustomerInfo) Option {
+	return func(b *OrderBuilder) { b.order.CustomerInfo = customer }
+}
+
+// WithItem adds an item; its total price is derived by Build.
+//
+// Parameters:
+//   - id: The item ID.
+//   - name: The item name.
+//   - quantity: The ordered quantity.
+//   - unitPrice: The unit price.
+//
+// Returns:
+//   - Option: The option.
+func WithItem(id, name string, quantity int, unitPrice Money) Option {
+	return func(b *OrderBuilder) {
+		b.order.Items = append(b.order.Items, OrderItem{ItemID: id, ItemName: name, Quantity: quantity, UnitPrice: unitPrice})
+	}
+}
+
+// WithInventory sets the inventory snapshot.
+//
+// Parameters:
+//   - inventory: The inventory state at the time of the order.
+//
+// Returns:
+//   - Option: The option.
+func WithInventory(inventory InventoryStatus) Option {
+	return func(b *OrderBuilder) { b.order.Inventory = inventory }
+}
+
+// WithPricing sets the tax rate, the shipping fee and the currency.
+//
+// Parameters:
+//   - taxRate: The tax rate applied to the subtotal (e.g., 0.2 for 20%).
+//   - shippingFee: The shipping fee.
+//   - currency: The currency of the amounts (e.g., "EUR").
+//
+// Returns:
+//   - Option: The option.
+func WithPricing(taxRate float64, shippingFee Money, currency string) Option {
+	return func(b *OrderBuilder) {
+		b.taxRate, b.shippingFee, b.order.Currency = taxRate, shippingFee, currency
+	}
+}
+
+// WithPayment sets the payment method and the delivery notes.
+//
+// Parameters:
+//   - method: The payment method.
+//   - deliveryNotes: The delivery notes ("" for none).
+//
+// Returns:
+//   - Option: The option.
+func WithPayment(method, deliveryNotes string) Option {
+	return func(b *OrderBuilder) { b.order.PaymentMethod, b.order.DeliveryNotes = method, deliveryNotes }
+}
+
+// WithSource sets the source of the event (e.g., "producer-service").
+//
+// Parameters:
+//   - source: The event source.
+//
+// Returns:
+//   - Option: The option.
+func WithSource(source string) Option {
+	return func(b *OrderBuilder) { b.order.Metadata.Source = source }
+}
+
+// WithMetadata replaces the event metadata. Empty fields keep their default,
+// and an empty timestamp is set by Build.
+//
+// Parameters:
+//   - metadata: The event metadata.
+//
+// Returns:
+//   - Option: The option.
+func WithMetadata(metadata OrderMetadata) Option {
+	return func(b *OrderBuilder) {
+		defaults := b.order.Metadata
+		b.order.Metadata = metadata
+		if metadata.Version == "" {
+			b.order.Metadata.Version = defaults.Version
+		}
+		if metadata.EventType == "" {
+			b.order.Metadata.EventType = defaults.EventType
+		}
+		if metadata.CorrelationID == "" {
+			b.order.Metadata.CorrelationID = defaults.CorrelationID
+		}
+	}
+}
+
+// WithClock sets the clock timestamping the order, for tests.
+//
+// Parameters:
+//   - now: The clock.
+//
+// Returns:
+//   - Option: The option.
+func WithClock(now func() time.Time) Option {
+	return func(b *OrderBuilder) { b.now = now }
+}
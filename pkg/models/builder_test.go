@@ -0,0 +1,78 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestOrderBuilder tests the defaults and the derived amounts of a built order.
+func TestOrderBuilder(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	order, err := NewOrder(
+		WithSequence(7),
+		WithCustomer(CustomerInfo{CustomerID: "cust-001", Name: "John Doe"}),
+		WithItem("item-001", "Espresso", 2, NewMoney(3.50, "")),
+		WithItem("item-002", "Cappuccino", 1, NewMoney(4.00, "")),
+		WithPricing(0.2, NewMoney(2.50, ""), "CAD"),
+		WithSource("test"),
+		WithClock(func() time.Time { return now }),
+	).Build()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if order.OrderID == "" || order.Metadata.CorrelationID == "" || order.OrderID == order.Metadata.CorrelationID {
+		t.Errorf("Expected distinct generated UUIDs, got %q and %q", order.OrderID, order.Metadata.CorrelationID)
+	}
+	if order.Status != DefaultOrderStatus || order.Currency != "CAD" {
+		t.Errorf("Expected status %q and currency CAD, got %q and %q", DefaultOrderStatus, order.Status, order.Currency)
+	}
+	if order.Metadata.Version != string(CurrentSchemaVersion) || order.Metadata.EventType != DefaultEventType || order.Metadata.Source != "test" {
+		t.Errorf("Unexpected metadata: %+v", order.Metadata)
+	}
+	if order.Metadata.Timestamp.String() != "2024-03-01T11:00:00Z" {
+		t.Errorf("Expected a UTC timestamp from the clock, got %s", order.Metadata.Timestamp)
+	}
+
+	if order.Items[0].TotalPrice.Cents() != 700 || order.Items[1].TotalPrice.Cents() != 400 {
+		t.Errorf("Expected item totals 700 and 400, got %s and %s", order.Items[0].TotalPrice, order.Items[1].TotalPrice)
+	}
+	if order.SubTotal.Cents() != 1100 || order.Tax.Cents() != 220 || order.ShippingFee.Cents() != 250 || order.Total.Cents() != 1570 {
+		t.Errorf("Unexpected amounts: subtotal %s, tax %s, shipping %s, total %s", order.SubTotal, order.Tax, order.ShippingFee, order.Total)
+	}
+}
+
+// TestOrderBuilderValidation tests that Build validates the order and that
+// explicit values override the defaults.
+func TestOrderBuilderValidation(t *testing.T) {
+	_, err := NewOrder(WithCustomer(CustomerInfo{CustomerID: "cust-001", Name: "John Doe"})).Build()
+	if !errors.Is(err, ErrInvalidSequence) {
+		t.Errorf("Expected ErrInvalidSequence, got %v", err)
+	}
+
+	b := NewOrder(
+		WithOrderID("order-123"),
+		WithSequence(1),
+		WithStatus("shipped"),
+		WithCustomer(CustomerInfo{CustomerID: "cust-001", Name: "John Doe"}),
+		WithItem("item-001", "Espresso", 1, NewMoney(3.50, "")),
+		WithMetadata(OrderMetadata{Timestamp: mustTimestamp("2024-03-01T12:00:00Z"), EventType: "order.updated"}),
+	)
+	first, err := b.Build()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if first.OrderID != "order-123" || first.Status != "shipped" || first.Metadata.EventType != "order.updated" {
+		t.Errorf("Expected the options to override the defaults, got %+v", first)
+	}
+	if first.Metadata.Timestamp.String() != "2024-03-01T12:00:00Z" || first.Metadata.Version != string(CurrentSchemaVersion) || first.Metadata.CorrelationID == "" {
+		t.Errorf("Expected empty metadata fields to keep their default, got %+v", first.Metadata)
+	}
+
+	first.Items[0].Quantity = 5
+	second, _ := b.Build()
+	if second.Items[0].Quantity != 1 {
+		t.Error("Expected built orders not to share their items")
+	}
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// CloudEvents attributes written by ToCloudEvent.
+const (
+	// CloudEventsSpecVersion is the supported version of the CloudEvents
+	// specification.
+	CloudEventsSpecVersion = "1.0"
+	// CloudEventContentType is the content type of the event data.
+	CloudEventContentType = "application/json"
+)
+
+// CloudEvent validation errors
+var (
+	ErrMissingAttribute       = newCodedError("ERR_CLOUDEVENT_ATTRIBUTE_REQUIRED", "cloudevent attribute is required")
+	ErrUnsupportedSpecVersion = newCodedError("ERR_CLOUDEVENT_SPEC_VERSION_UNSUPPORTED", "unsupported cloudevents spec version")
+	ErrUnsupportedContentType = newCodedError("ERR_CLOUDEVENT_CONTENT_TYPE_UNSUPPORTED", "unsupported cloudevent data content type")
+)
+
+// CloudEvent is an order event in the structured JSON format of the
+// CloudEvents 1.0 specification: the context attributes wrap the order, carried
+// unchanged as data.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`               // CloudEvents version ("1.0").
+	ID              string          `json:"id"`                        // Event identifier, unique for its source.
+	Source          string          `json:"source"`                    // Event source (e.g., "producer-service").
+	Type            string          `json:"type"`                      // Event type (e.g., "order.created").
+	Subject         string          `json:"subject,omitempty"`         // Identifier of the order.
+	Time            *Timestamp      `json:"time,omitempty"`            // Event creation timestamp (RFC3339).
+	DataContentType string          `json:"datacontenttype,omitempty"` // Content type of the data ("application/json").
+	CorrelationID   string          `json:"correlationid,omitempty"`   // Extension: correlation ID of the order.
+	Data            json.RawMessage `json:"data,omitempty"`            // The JSON order.
+}
+
+// ToCloudEvent wraps an order in a CloudEvent. The context attributes are
+// derived from the order metadata, so that the same order always gives the
+// same event.
+//
+// Parameters:
+//   - order: The order.
+//
+// Returns:
+//   - CloudEvent: The event, with the order as data.
+//   - error: A JSON encoding error.
+func ToCloudEvent(order Order) (CloudEvent, error) {
+	data, err := json.Marshal(order)
+	if err != nil {
+		return CloudEvent{}, err
+	}
+	eventType := order.Metadata.EventType
+	if eventType == "" {
+		eventType = EventOrderCreated
+	}
+	event := CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              fmt.Sprintf("%s:%d", order.OrderID, order.Sequence),
+		Source:          order.Metadata.Source,
+		Type:            eventType,
+		Subject:         order.OrderID,
+		DataContentType: CloudEventContentType,
+		CorrelationID:   order.Metadata.CorrelationID,
+		Data:            data,
+	}
+	if !order.Metadata.Timestamp.IsZero() {
+		timestamp := order.Metadata.Timestamp
+		event.Time = &timestamp
+	}
+	return event, nil
+}
+
+// FromCloudEvent extracts the order of a CloudEvent. The order is decoded by
+// DecodeOrder, so that it is upgraded to the current schema.
+//
+// Parameters:
+//   - event: The event, of type order.created.
+//
+// Returns:
+//   - Order: The order, in the current schema.
+//   - error: A *FieldError wrapping a validation error, or a JSON decoding error.
+func FromCloudEvent(event CloudEvent) (Order, error) {
+	if err := event.Validate(); err != nil {
+		return Order{}, err
+	}
+	if event.Type != EventOrderCreated {
+		return Order{}, &FieldError{Field: "type", Err: fmt.Errorf("%w: %q", ErrUnknownEventType, event.Type)}
+	}
+	if len(event.Data) == 0 {
+		return Order{}, &FieldError{Field: "data", Err: ErrMissingAttribute}
+	}
+	order, err := DecodeOrder(event.Data)
+	if err != nil {
+		return Order{}, err
+	}
+	if event.Subject != "" && event.Subject != order.OrderID {
+		return Order{}, &FieldError{Field: "subject", Err: fmt.Errorf("%w: %q and %q", ErrOrderMismatch, event.Subject, order.OrderID)}
+	}
+	return order, nil
+}
+
+// Validate checks the required context attributes of the event and its data
+// content type.
+//
+// Returns:
+//   - error: A *FieldError for the first failed rule, or nil.
+func (e *CloudEvent) Validate() error {
+	r := &rules{}
+	if r.check(e.SpecVersion != "", "specversion", ErrMissingAttribute) {
+		r.check(e.SpecVersion == CloudEventsSpecVersion, "specversion",
+			fmt.Errorf("%w: %q", ErrUnsupportedSpecVersion, e.SpecVersion))
+	}
+	r.check(strings.TrimSpace(e.ID) != "", "id", ErrMissingAttribute)
+	r.check(strings.TrimSpace(e.Source) != "", "source", ErrMissingAttribute)
+	r.check(strings.TrimSpace(e.Type) != "", "type", ErrMissingAttribute)
+	if e.DataContentType != "" {
+		r.check(e.DataContentType == CloudEventContentType, "datacontenttype",
+			fmt.Errorf("%w: %q", ErrUnsupportedContentType, e.DataContentType))
+	}
+	return r.err()
+}
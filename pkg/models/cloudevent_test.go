@@ -0,0 +1,74 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestCloudEventRoundTrip tests that an order survives its CloudEvent.
+func TestCloudEventRoundTrip(t *testing.T) {
+	order, err := DecodeOrder(mustFixture(t, "order_v1.1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	event, err := ToCloudEvent(order)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if event.Type != EventOrderCreated || event.Subject != order.OrderID || event.Source != order.Metadata.Source ||
+		event.CorrelationID != order.Metadata.CorrelationID || event.Time == nil || *event.Time != order.Metadata.Timestamp {
+		t.Errorf("Unexpected context attributes %+v", event)
+	}
+	decoded, err := FromCloudEvent(event)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, order) {
+		t.Errorf("Expected %+v, got %+v", order, decoded)
+	}
+
+	order.Metadata.Timestamp = Timestamp{}
+	if event, _ := ToCloudEvent(order); event.Time != nil {
+		t.Errorf("Expected no time without timestamp, got %v", event.Time)
+	}
+}
+
+// TestFromCloudEventErrors tests the rejection of invalid events.
+func TestFromCloudEventErrors(t *testing.T) {
+	order, _ := DecodeOrder(mustFixture(t, "order_v1.1"))
+	valid, _ := ToCloudEvent(order)
+
+	tests := []struct {
+		name   string
+		mutate func(*CloudEvent)
+		field  string
+		want   error
+	}{
+		{"missing specversion", func(e *CloudEvent) { e.SpecVersion = "" }, "specversion", ErrMissingAttribute},
+		{"unsupported specversion", func(e *CloudEvent) { e.SpecVersion = "0.3" }, "specversion", ErrUnsupportedSpecVersion},
+		{"missing id", func(e *CloudEvent) { e.ID = " " }, "id", ErrMissingAttribute},
+		{"missing source", func(e *CloudEvent) { e.Source = "" }, "source", ErrMissingAttribute},
+		{"missing type", func(e *CloudEvent) { e.Type = "" }, "type", ErrMissingAttribute},
+		{"content type", func(e *CloudEvent) { e.DataContentType = "application/xml" }, "datacontenttype", ErrUnsupportedContentType},
+		{"other type", func(e *CloudEvent) { e.Type = EventOrderCancelled }, "type", ErrUnknownEventType},
+		{"missing data", func(e *CloudEvent) { e.Data = nil }, "data", ErrMissingAttribute},
+		{"other subject", func(e *CloudEvent) { e.Subject = "order-999" }, "subject", ErrOrderMismatch},
+	}
+	for _, tt := range tests {
+		event := valid
+		tt.mutate(&event)
+		_, err := FromCloudEvent(event)
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) || fieldErr.Field != tt.field || !errors.Is(err, tt.want) || !IsValidationError(err) {
+			t.Errorf("%s: expected a %s error wrapping %v, got %v", tt.name, tt.field, tt.want, err)
+		}
+	}
+
+	event := valid
+	event.DataContentType = ""
+	event.Subject = ""
+	if _, err := FromCloudEvent(event); err != nil {
+		t.Errorf("Expected optional attributes to be optional, got %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidCurrency is returned for a currency that is not a known ISO 4217 code.
+var ErrInvalidCurrency = newCodedError("ERR_CURRENCY_INVALID", "currency must be an ISO 4217 code")
+
+// moneyMinorUnits is the number of decimals of Money.
+const moneyMinorUnits = 2
+
+// currencyMinorUnits lists the minor units (decimals) of the supported ISO
+// 4217 currencies.
+var currencyMinorUnits = map[string]int{
+	"AUD": 2, "BRL": 2, "CAD": 2, "CHF": 2, "CNY": 2, "CZK": 2, "DKK": 2,
+	"EUR": 2, "GBP": 2, "HKD": 2, "HUF": 2, "INR": 2, "MAD": 2, "MXN": 2,
+	"NOK": 2, "NZD": 2, "PLN": 2, "SEK": 2, "SGD": 2, "USD": 2, "ZAR": 2,
+	"CLP": 0, "ISK": 0, "JPY": 0, "KRW": 0, "VND": 0, "XAF": 0, "XOF": 0,
+	"BHD": 3, "JOD": 3, "KWD": 3, "OMR": 3, "TND": 3,
+}
+
+// MinorUnits returns the number of decimals of a currency, as held by Money:
+// currencies with three decimals are kept to the cent.
+//
+// Parameters:
+//   - currency: The ISO 4217 code (e.g., "EUR"), in upper case.
+//
+// Returns:
+//   - int: The number of decimals, from 0 to 2.
+//   - error: An error wrapping ErrInvalidCurrency.
+func MinorUnits(currency string) (int, error) {
+	units, ok := currencyMinorUnits[currency]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+	}
+	return min(units, moneyMinorUnits), nil
+}
+
+// Round rounds the amount to a number of decimals, half away from zero.
+//
+// Parameters:
+//   - decimals: The number of decimals kept (0 to 2; 2 or more keeps the amount).
+//
+// Returns:
+//   - Money: The rounded amount (e.g., 1250 cents rounded to 0 decimals gives 1300).
+func (m Money) Round(decimals int) Money {
+	step := int64(1)
+	for i := decimals; i < moneyMinorUnits; i++ {
+		step *= 10
+	}
+	if step == 1 {
+		return m
+	}
+	half := step / 2
+	if m.cents < 0 {
+		return Money{cents: -((-m.cents + half) / step * step), currency: m.currency}
+	}
+	return Money{cents: (m.cents + half) / step * step, currency: m.currency}
+}
+
+// Normalize puts the order in its canonical form before it is published: the
+// currency code in upper case, the amounts bound to it, the prices, tax and
+// shipping fee rounded to the minor units of the currency, and the item
+// totals, subtotal and total recomputed from them.
+//
+// Returns:
+//   - error: A *FieldError wrapping ErrInvalidCurrency or ErrCurrencyMismatch; the order is then unchanged.
+func (o *Order) Normalize() error {
+	currency := strings.ToUpper(strings.TrimSpace(o.Currency))
+	decimals, err := MinorUnits(currency)
+	if err != nil {
+		return &FieldError{Field: "currency", Err: err}
+	}
+	if err := o.checkCurrencies(currency); err != nil {
+		return err
+	}
+	o.Currency = currency
+	o.bindCurrency()
+
+	totals := make([]Money, len(o.Items))
+	for i := range o.Items {
+		item := &o.Items[i]
+		item.UnitPrice = item.UnitPrice.Round(decimals)
+		item.TotalPrice = item.UnitPrice.Mul(item.Quantity)
+		totals[i] = item.TotalPrice
+	}
+	o.Inventory.UnitPrice = o.Inventory.UnitPrice.Round(decimals)
+	o.Tax = o.Tax.Round(decimals)
+	o.ShippingFee = o.ShippingFee.Round(decimals)
+	// Every amount is bound to the currency: the sums cannot fail.
+	o.SubTotal, _ = Sum(totals...)
+	o.Total, _ = Sum(o.SubTotal, o.Tax, o.ShippingFee)
+	return nil
+}
+
+// bindCurrency binds the unbound amounts of the order (e.g., decoded from
+// JSON) to Order.Currency.
+func (o *Order) bindCurrency() {
+	o.eachAmount(func(_ string, m *Money) { *m = m.WithCurrency(o.Currency) })
+}
+
+// eachAmount calls fn for every amount of the order.
+//
+// Parameters:
+//   - fn: The function, called with the field path and the amount.
+func (o *Order) eachAmount(fn func(field string, m *Money)) {
+	for i := range o.Items {
+		path := fmt.Sprintf("items[%d]", i)
+		fn(fieldPath(path, "unit_price"), &o.Items[i].UnitPrice)
+		fn(fieldPath(path, "total_price"), &o.Items[i].TotalPrice)
+	}
+	fn("inventory.unit_price", &o.Inventory.UnitPrice)
+	fn("subtotal", &o.SubTotal)
+	fn("tax", &o.Tax)
+	fn("shipping_fee", &o.ShippingFee)
+	fn("total", &o.Total)
+}
+
+// checkCurrencies checks that every amount of the order is unbound or in a currency.
+//
+// Parameters:
+//   - currency: The expected currency ("" accepts any).
+//
+// Returns:
+//   - error: A *FieldError wrapping ErrCurrencyMismatch for the first mismatching amount, or nil.
+func (o *Order) checkCurrencies(currency string) error {
+	r := &rules{}
+	o.eachAmount(func(field string, m *Money) { checkCurrency(r, field, currency, *m) })
+	return r.err()
+}
+
+// checkCurrency checks that an amount is unbound or in the expected currency.
+//
+// Parameters:
+//   - r: The failed rules.
+//   - field: The field path of the amount.
+//   - currency: The expected currency ("" accepts any).
+//   - m: The amount.
+//
+// Returns:
+//   - bool: True if the currency matches.
+func checkCurrency(r *rules, field, currency string, m Money) bool {
+	if m.currency == "" || currency == "" || strings.EqualFold(m.currency, currency) {
+		return true
+	}
+	r.fail(field, fmt.Errorf("%w: expected %s, got %s", ErrCurrencyMismatch, strings.ToUpper(currency), m.currency))
+	return false
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestMoneyRound tests the rounding to the minor units, half away from zero.
+func TestMoneyRound(t *testing.T) {
+	tests := []struct {
+		amount   Money
+		decimals int
+		want     Money
+	}{
+		{cents(1234), 2, cents(1234)},
+		{cents(1234), 3, cents(1234)},
+		{cents(1250), 0, cents(1300)},
+		{cents(1249), 0, cents(1200)},
+		{cents(-1250), 0, cents(-1300)},
+		{cents(1234), 1, cents(1230)},
+		{cents(1235), 1, cents(1240)},
+	}
+	for _, tt := range tests {
+		if got := tt.amount.Round(tt.decimals); got != tt.want {
+			t.Errorf("Money(%d).Round(%d) = %d, expected %d", tt.amount.Cents(), tt.decimals, got.Cents(), tt.want.Cents())
+		}
+	}
+}
+
+// TestOrderNormalize tests the canonical currency and amounts of an order.
+func TestOrderNormalize(t *testing.T) {
+	order := Order{
+		Items: []OrderItem{
+			{ItemID: "item-001", Quantity: 2, UnitPrice: cents(1250), TotalPrice: cents(2499)},
+			{ItemID: "item-002", Quantity: 1, UnitPrice: cents(340), TotalPrice: cents(340)},
+		},
+		Inventory:   InventoryStatus{UnitPrice: cents(1250)},
+		Tax:         cents(585),
+		ShippingFee: cents(250),
+		Currency:    " jpy ",
+	}
+	if err := order.Normalize(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if order.Currency != "JPY" {
+		t.Errorf("Expected JPY, got %q", order.Currency)
+	}
+	if order.Items[0].UnitPrice.Cents() != 1300 || order.Items[0].TotalPrice.Cents() != 2600 || order.Items[1].TotalPrice.Cents() != 300 || order.Inventory.UnitPrice.Cents() != 1300 {
+		t.Errorf("Expected prices rounded to whole yens, got %+v and %+v", order.Items, order.Inventory)
+	}
+	if order.SubTotal.Cents() != 2900 || order.Tax.Cents() != 600 || order.ShippingFee.Cents() != 300 || order.Total.Cents() != 3800 {
+		t.Errorf("Unexpected amounts: subtotal %s, tax %s, shipping %s, total %s", order.SubTotal, order.Tax, order.ShippingFee, order.Total)
+	}
+
+	euros := Order{Items: []OrderItem{{Quantity: 3, UnitPrice: cents(333)}}, Currency: "EUR"}
+	if err := euros.Normalize(); err != nil || euros.Items[0].TotalPrice.Cents() != 999 || euros.Total.Cents() != 999 {
+		t.Errorf("Expected cents kept and totals recomputed, got %+v, %v", euros, err)
+	}
+
+	invalid := Order{Currency: "EURO", Items: []OrderItem{{Quantity: 1, UnitPrice: cents(100)}}}
+	err := invalid.Normalize()
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "currency" || !errors.Is(err, ErrInvalidCurrency) || !IsValidationError(err) {
+		t.Errorf("Expected a currency FieldError, got %v", err)
+	}
+	if invalid.Currency != "EURO" || invalid.Items[0].TotalPrice.Cents() != 0 {
+		t.Errorf("Expected the invalid order to be unchanged, got %+v", invalid)
+	}
+	if units, err := MinorUnits("KWD"); err != nil || units != 2 {
+		t.Errorf("Expected three-decimal currencies kept to the cent, got %d, %v", units, err)
+	}
+}
+
+// TestOrderCurrencyMismatch tests that an order rejects amounts of another
+// currency, and that decoded amounts are bound to the order currency.
+func TestOrderCurrencyMismatch(t *testing.T) {
+	order := builtOrder(t)
+	if order.Total.Currency() != "EUR" || order.Items[0].UnitPrice.Currency() != "EUR" {
+		t.Errorf("Expected the built amounts in EUR, got %q and %q", order.Total.Currency(), order.Items[0].UnitPrice.Currency())
+	}
+
+	order.ShippingFee = FromCents(order.ShippingFee.Cents(), "USD")
+	err := order.Validate()
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "shipping_fee" || !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected a shipping_fee currency mismatch, got %v", err)
+	}
+	if err := order.Normalize(); !errors.Is(err, ErrCurrencyMismatch) || order.ShippingFee.Currency() != "USD" {
+		t.Errorf("Expected Normalize to reject the mismatch and keep the order, got %v", err)
+	}
+
+	_, err = NewOrder(
+		WithSequence(1),
+		WithCustomer(CustomerInfo{CustomerID: "cust-001", Name: "John Doe"}),
+		WithItem("item-001", "Espresso", 1, NewMoney(3.50, "GBP")),
+		WithPricing(0.2, cents(250), "EUR"),
+	).Build()
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "items[0].unit_price" || !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected Build to reject a GBP price in an EUR order, got %v", err)
+	}
+
+	decoded, err := DecodeOrder([]byte(`{"items": [{"quantity": 1, "unit_price": 1.10}], "currency": "CAD"}`))
+	if err != nil || decoded.SubTotal != FromCents(110, "CAD") || decoded.Items[0].UnitPrice.Currency() != "CAD" {
+		t.Errorf("Expected the decoded amounts bound to CAD, got %+v (%v)", decoded, err)
+	}
+}
@@ -0,0 +1,247 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Order diff errors
+var (
+	ErrNoChanges     = newCodedError("ERR_CHANGES_REQUIRED", "diff must contain at least one change")
+	ErrOrderMismatch = newCodedError("ERR_ORDER_MISMATCH", "orders have different order_id")
+	ErrDiffConflict  = newCodedError("ERR_DIFF_CONFLICT", "current value does not match the old value of the diff")
+)
+
+// FieldChange is a changed order field, with its JSON values before and after
+// the update.
+type FieldChange struct {
+	Field string          `json:"field"` // Field path (e.g., "customer_info.email"); lists are changed as a whole.
+	Old   json.RawMessage `json:"old"`   // Value before the update (null if absent).
+	New   json.RawMessage `json:"new"`   // Value after the update (null to remove the field).
+}
+
+// OrderDiff is the payload of an order.updated event: the changed fields of
+// an order instead of its full ECST snapshot. The metadata of the order is
+// not part of the changes.
+type OrderDiff struct {
+	OrderID  string        `json:"order_id"` // Identifier of the updated order.
+	Changes  []FieldChange `json:"changes"`  // Changed fields, sorted by path.
+	Metadata OrderMetadata `json:"metadata"` // Event metadata.
+}
+
+// ComputeDiff computes the update of an order. Nested objects are compared
+// field by field, lists as a whole.
+//
+// Parameters:
+//   - before: The order before the update.
+//   - after: The order after the update.
+//
+// Returns:
+//   - OrderDiff: The update, correlated with the order (no changes if the orders are equal).
+//   - error: An error wrapping ErrOrderMismatch if the orders differ by their ID.
+func ComputeDiff(before, after Order) (OrderDiff, error) {
+	if before.OrderID != after.OrderID {
+		return OrderDiff{}, fmt.Errorf("%w: %q and %q", ErrOrderMismatch, before.OrderID, after.OrderID)
+	}
+	old, err := orderFields(before)
+	if err != nil {
+		return OrderDiff{}, err
+	}
+	updated, err := orderFields(after)
+	if err != nil {
+		return OrderDiff{}, err
+	}
+	delete(old, "metadata")
+	delete(updated, "metadata")
+
+	diff := OrderDiff{OrderID: after.OrderID, Metadata: followUpMetadata(after, EventOrderUpdated)}
+	if err := diffValues("", old, updated, &diff.Changes); err != nil {
+		return OrderDiff{}, err
+	}
+	return diff, nil
+}
+
+// Apply applies the update to an order. Every changed field must still hold
+// the old value of the diff, so that an update is never applied twice or to
+// another state of the order.
+//
+// Parameters:
+//   - order: The order to update.
+//
+// Returns:
+//   - Order: The updated order, with its metadata unchanged.
+//   - error: An error wrapping ErrOrderMismatch, or a *FieldError wrapping ErrDiffConflict.
+func (d *OrderDiff) Apply(order Order) (Order, error) {
+	if order.OrderID != d.OrderID {
+		return Order{}, fmt.Errorf("%w: %q and %q", ErrOrderMismatch, order.OrderID, d.OrderID)
+	}
+	doc, err := orderFields(order)
+	if err != nil {
+		return Order{}, err
+	}
+	for _, change := range d.Changes {
+		var old, updated interface{}
+		if err := decodeValue(change.Old, &old); err != nil {
+			return Order{}, &FieldError{Field: change.Field, Err: err}
+		}
+		if err := decodeValue(change.New, &updated); err != nil {
+			return Order{}, &FieldError{Field: change.Field, Err: err}
+		}
+		if current := lookupField(doc, change.Field); !reflect.DeepEqual(current, old) {
+			return Order{}, &FieldError{Field: change.Field, Err: ErrDiffConflict}
+		}
+		setField(doc, change.Field, updated)
+	}
+
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return Order{}, err
+	}
+	var result Order
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return Order{}, err
+	}
+	result.bindCurrency()
+	result.Metadata = order.Metadata
+	return result, nil
+}
+
+// Validate checks that the update event is valid.
+//
+// Returns:
+//   - error: A *FieldError for the first failed rule, or nil.
+func (d *OrderDiff) Validate() error {
+	r := &rules{}
+	r.check(strings.TrimSpace(d.OrderID) != "", "order_id", ErrEmptyOrderID)
+	r.check(len(d.Changes) > 0, "changes", ErrNoChanges)
+	return r.err()
+}
+
+// orderFields converts an order to its JSON fields.
+//
+// Parameters:
+//   - order: The order.
+//
+// Returns:
+//   - map[string]interface{}: The decoded JSON object.
+//   - error: A JSON encoding error.
+func orderFields(order Order) (map[string]interface{}, error) {
+	raw, err := json.Marshal(order)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	err = json.Unmarshal(raw, &doc)
+	return doc, err
+}
+
+// decodeValue decodes a JSON value of a change; an empty value is null.
+//
+// Parameters:
+//   - raw: The JSON value.
+//   - v: The decoded value.
+//
+// Returns:
+//   - error: A JSON decoding error.
+func decodeValue(raw json.RawMessage, v *interface{}) error {
+	if len(raw) == 0 {
+		*v = nil
+		return nil
+	}
+	return json.Unmarshal(raw, v)
+}
+
+// diffValues records the changes between two decoded JSON values.
+//
+// Parameters:
+//   - path: The field path of the values.
+//   - old: The value before the update.
+//   - updated: The value after the update.
+//   - changes: The recorded changes.
+//
+// Returns:
+//   - error: A JSON encoding error.
+func diffValues(path string, old, updated interface{}, changes *[]FieldChange) error {
+	oldObj, oldIsObj := old.(map[string]interface{})
+	newObj, newIsObj := updated.(map[string]interface{})
+	if oldIsObj && newIsObj {
+		keys := make([]string, 0, len(oldObj)+len(newObj))
+		for k := range oldObj {
+			keys = append(keys, k)
+		}
+		for k := range newObj {
+			if _, ok := oldObj[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := diffValues(fieldPath(path, k), oldObj[k], newObj[k], changes); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if reflect.DeepEqual(old, updated) {
+		return nil
+	}
+	oldRaw, err := json.Marshal(old)
+	if err != nil {
+		return err
+	}
+	newRaw, err := json.Marshal(updated)
+	if err != nil {
+		return err
+	}
+	*changes = append(*changes, FieldChange{Field: path, Old: oldRaw, New: newRaw})
+	return nil
+}
+
+// lookupField returns the value of a field path in a decoded JSON object.
+//
+// Parameters:
+//   - doc: The JSON object.
+//   - path: The field path.
+//
+// Returns:
+//   - interface{}: The value, or nil if absent.
+func lookupField(doc map[string]interface{}, path string) interface{} {
+	var value interface{} = doc
+	for _, name := range strings.Split(path, ".") {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = obj[name]
+	}
+	return value
+}
+
+// setField sets the value of a field path in a decoded JSON object, creating
+// the missing parent objects. A nil value removes the field.
+//
+// Parameters:
+//   - doc: The JSON object.
+//   - path: The field path.
+//   - value: The new value.
+func setField(doc map[string]interface{}, path string, value interface{}) {
+	names := strings.Split(path, ".")
+	obj := doc
+	for _, name := range names[:len(names)-1] {
+		child, ok := obj[name].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			obj[name] = child
+		}
+		obj = child
+	}
+	last := names[len(names)-1]
+	if value == nil {
+		delete(obj, last)
+		return
+	}
+	obj[last] = value
+}
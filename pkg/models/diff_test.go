@@ -0,0 +1,92 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestOrderDiffRoundTrip tests that applying the computed diff, after a JSON
+// round trip, gives the updated order.
+func TestOrderDiffRoundTrip(t *testing.T) {
+	before := builtOrder(t)
+	after := before
+	after.Status = "shipped"
+	after.CustomerInfo.Email = "john@example.com"
+	after.DeliveryNotes = "Leave at the door"
+	after.Items = append(append([]OrderItem(nil), before.Items...), OrderItem{ItemID: "item-002", ItemName: "Croissant", Quantity: 1, UnitPrice: FromCents(140, "EUR"), TotalPrice: FromCents(140, "EUR")})
+	after.SubTotal, after.Total = FromCents(840, "EUR"), FromCents(1258, "EUR")
+
+	diff, err := ComputeDiff(before, after)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var fields []string
+	for _, change := range diff.Changes {
+		fields = append(fields, change.Field)
+	}
+	expected := []string{"customer_info.email", "delivery_notes", "items", "status", "subtotal", "total"}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected changes %v, got %v", expected, fields)
+	}
+	if string(diff.Changes[1].Old) != "null" || string(diff.Changes[3].New) != `"shipped"` {
+		t.Errorf("Unexpected values: %s -> %s", diff.Changes[1].Old, diff.Changes[3].New)
+	}
+	if diff.Metadata.EventType != EventOrderUpdated || diff.Metadata.CorrelationID != after.Metadata.CorrelationID {
+		t.Errorf("Unexpected metadata: %+v", diff.Metadata)
+	}
+
+	raw, err := json.Marshal(diff)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	event, err := DecodeEvent(raw)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	decoded, ok := event.(OrderDiff)
+	if !ok || decoded.Validate() != nil {
+		t.Fatalf("Expected a valid OrderDiff, got %T", event)
+	}
+	updated, err := decoded.Apply(before)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(updated, after) {
+		t.Errorf("Expected %+v, got %+v", after, updated)
+	}
+
+	reverse, _ := ComputeDiff(after, before)
+	if restored, err := reverse.Apply(updated); err != nil || !reflect.DeepEqual(restored, before) {
+		t.Errorf("Expected the reverse diff to restore the order, got %v", err)
+	}
+}
+
+// TestOrderDiffErrors tests the empty diffs, the conflicts and the mismatched orders.
+func TestOrderDiffErrors(t *testing.T) {
+	before := builtOrder(t)
+	after := before
+	after.Status = "shipped"
+
+	same, _ := ComputeDiff(before, before)
+	if len(same.Changes) != 0 || !errors.Is(same.Validate(), ErrNoChanges) {
+		t.Errorf("Expected no changes between equal orders, got %+v", same.Changes)
+	}
+
+	diff, _ := ComputeDiff(before, after)
+	_, err := diff.Apply(after)
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "status" || !errors.Is(err, ErrDiffConflict) {
+		t.Errorf("Expected a status conflict when applied twice, got %v", err)
+	}
+
+	other := after
+	other.OrderID = "order-other"
+	if _, err := ComputeDiff(before, other); !errors.Is(err, ErrOrderMismatch) {
+		t.Errorf("Expected ErrOrderMismatch, got %v", err)
+	}
+	if _, err := diff.Apply(other); !errors.Is(err, ErrOrderMismatch) || !IsValidationError(err) {
+		t.Errorf("Expected a permanent ErrOrderMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package models
+
+import "errors"
+
+// ErrorCode identifies an error of this package independently of its message
+// (e.g., "ERR_ORDER_ID_REQUIRED"), so that API responses, logs and the monitor
+// can render it in their own language.
+type ErrorCode string
+
+// CodedError is an error of this package with a stable code. Its message is in
+// English; the translations are looked up by code.
+type CodedError struct {
+	Code    ErrorCode // Stable error code.
+	Message string    // English message.
+}
+
+// Error returns the English message.
+//
+// Returns:
+//   - string: The message.
+func (e *CodedError) Error() string {
+	return e.Message
+}
+
+// newCodedError creates a coded error.
+//
+// Parameters:
+//   - code: The error code.
+//   - message: The English message.
+//
+// Returns:
+//   - error: The *CodedError.
+func newCodedError(code ErrorCode, message string) error {
+	return &CodedError{Code: code, Message: message}
+}
+
+// CodeOf returns the code of the first coded error wrapped by an error.
+//
+// Parameters:
+//   - err: The error (e.g., a *FieldError).
+//
+// Returns:
+//   - ErrorCode: The code.
+//   - bool: False if err wraps no coded error.
+func CodeOf(err error) (ErrorCode, bool) {
+	var coded *CodedError
+	if !errors.As(err, &coded) {
+		return "", false
+	}
+	return coded.Code, true
+}
+
+// CodedErrors lists the coded errors of this package, for the message
+// catalogs that translate them.
+//
+// Returns:
+//   - []error: The *CodedError values.
+func CodedErrors() []error {
+	return append(append([]error(nil), validationErrors...),
+		ErrUnknownField, ErrWrongType, ErrMissingField, ErrUnknownPartitionStrategy)
+}
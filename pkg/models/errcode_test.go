@@ -0,0 +1,41 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestCodeOf tests that the code of a validation error is found through its
+// field path and details.
+func TestCodeOf(t *testing.T) {
+	order := Order{OrderID: "o1", Sequence: 1, Status: "pending", CustomerInfo: CustomerInfo{CustomerID: "c1", Name: "Test"}}
+	if code, ok := CodeOf(order.Validate()); !ok || code != "ERR_ITEMS_REQUIRED" {
+		t.Errorf("Expected ERR_ITEMS_REQUIRED, got %q, %v", code, ok)
+	}
+	if code, _ := CodeOf(fmt.Errorf("decode: %w", fmt.Errorf("%w: %q", ErrInvalidCurrency, "yen"))); code != "ERR_CURRENCY_INVALID" {
+		t.Errorf("Expected ERR_CURRENCY_INVALID, got %q", code)
+	}
+	if _, ok := CodeOf(errors.New("boom")); ok {
+		t.Error("Expected no code for an uncoded error")
+	}
+	if ErrInvalidCurrency.Error() != "currency must be an ISO 4217 code" {
+		t.Errorf("Expected the English message, got %q", ErrInvalidCurrency.Error())
+	}
+}
+
+// TestCodedErrorsUnique tests that every coded error has its own code.
+func TestCodedErrorsUnique(t *testing.T) {
+	seen := make(map[ErrorCode]error)
+	for _, err := range CodedErrors() {
+		code, ok := CodeOf(err)
+		if !ok {
+			t.Errorf("Expected a coded error, got %v", err)
+			continue
+		}
+		if other, dup := seen[code]; dup {
+			t.Errorf("Code %s used by %q and %q", code, other, err)
+		}
+		seen[code] = err
+	}
+}
@@ -0,0 +1,278 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Event types, carried by Metadata.EventType.
+const (
+	EventOrderCreated        = "order.created"
+	EventOrderUpdated        = "order.updated"
+	EventOrderCancelled      = "order.cancelled"
+	EventPaymentFailed       = "payment.failed"
+	EventInventoryOutOfStock = "inventory.out_of_stock"
+)
+
+// Event validation errors
+var (
+	ErrUnknownEventType = newCodedError("ERR_EVENT_TYPE_UNKNOWN", "unknown event type")
+	ErrEmptyReason      = newCodedError("ERR_REASON_REQUIRED", "reason is required")
+	ErrInvalidAmount    = newCodedError("ERR_AMOUNT_NOT_POSITIVE", "amount must be positive")
+	ErrInvalidRefund    = newCodedError("ERR_REFUND_NEGATIVE", "refund must be positive or zero")
+	ErrNotOutOfStock    = newCodedError("ERR_STOCK_AVAILABLE", "available quantity covers the requested quantity")
+)
+
+// OrderCancelled is the payload of an order.cancelled event.
+type OrderCancelled struct {
+	OrderID     string        `json:"order_id"`     // Identifier of the cancelled order.
+	CustomerID  string        `json:"customer_id"`  // Customer of the order.
+	Reason      string        `json:"reason"`       // Cancellation reason.
+	CancelledBy string        `json:"cancelled_by"` // Who cancelled (e.g., "customer", "system").
+	Refund      Money         `json:"refund"`       // Amount refunded to the customer.
+	Currency    string        `json:"currency"`     // Currency of the refund.
+	Metadata    OrderMetadata `json:"metadata"`     // Event metadata.
+}
+
+// PaymentFailed is the payload of a payment.failed event.
+type PaymentFailed struct {
+	OrderID       string        `json:"order_id"`       // Identifier of the order.
+	CustomerID    string        `json:"customer_id"`    // Customer of the order.
+	PaymentMethod string        `json:"payment_method"` // Payment method used.
+	Amount        Money         `json:"amount"`         // Amount of the failed payment.
+	Currency      string        `json:"currency"`       // Currency of the amount.
+	ErrorCode     string        `json:"error_code"`     // Payment provider error code (e.g., "card_declined").
+	Reason        string        `json:"reason"`         // Failure description.
+	Attempt       int           `json:"attempt"`        // Payment attempt number, from 1.
+	Metadata      OrderMetadata `json:"metadata"`       // Event metadata.
+}
+
+// InventoryOutOfStock is the payload of an inventory.out_of_stock event.
+type InventoryOutOfStock struct {
+	OrderID      string        `json:"order_id"`      // Identifier of the order that could not be served.
+	ItemID       string        `json:"item_id"`       // Identifier of the missing item.
+	ItemName     string        `json:"item_name"`     // Name of the missing item.
+	RequestedQty int           `json:"requested_qty"` // Ordered quantity.
+	AvailableQty int           `json:"available_qty"` // Quantity in stock, below the ordered one.
+	Warehouse    string        `json:"warehouse"`     // Warehouse of the stock.
+	Metadata     OrderMetadata `json:"metadata"`      // Event metadata.
+}
+
+// NewOrderCancelled creates the cancellation event of an order, which refunds
+// its total.
+//
+// Parameters:
+//   - order: The cancelled order.
+//   - reason: The cancellation reason.
+//   - by: Who cancelled the order.
+//
+// Returns:
+//   - OrderCancelled: The event, correlated with the order.
+func NewOrderCancelled(order Order, reason, by string) OrderCancelled {
+	return OrderCancelled{
+		OrderID:     order.OrderID,
+		CustomerID:  order.CustomerInfo.CustomerID,
+		Reason:      reason,
+		CancelledBy: by,
+		Refund:      order.Total.WithCurrency(order.Currency),
+		Currency:    order.Currency,
+		Metadata:    followUpMetadata(order, EventOrderCancelled),
+	}
+}
+
+// NewPaymentFailed creates the payment failure event of an order.
+//
+// Parameters:
+//   - order: The order whose payment failed.
+//   - code: The payment provider error code.
+//   - reason: The failure description.
+//   - attempt: The payment attempt number, from 1.
+//
+// Returns:
+//   - PaymentFailed: The event, correlated with the order.
+func NewPaymentFailed(order Order, code, reason string, attempt int) PaymentFailed {
+	return PaymentFailed{
+		OrderID:       order.OrderID,
+		CustomerID:    order.CustomerInfo.CustomerID,
+		PaymentMethod: order.PaymentMethod,
+		Amount:        order.Total.WithCurrency(order.Currency),
+		Currency:      order.Currency,
+		ErrorCode:     code,
+		Reason:        reason,
+		Attempt:       attempt,
+		Metadata:      followUpMetadata(order, EventPaymentFailed),
+	}
+}
+
+// NewInventoryOutOfStock creates the out-of-stock event of an order item.
+//
+// Parameters:
+//   - order: The order that could not be served.
+//   - item: The missing item.
+//   - available: The quantity in stock.
+//
+// Returns:
+//   - InventoryOutOfStock: The event, correlated with the order.
+func NewInventoryOutOfStock(order Order, item OrderItem, available int) InventoryOutOfStock {
+	return InventoryOutOfStock{
+		OrderID:      order.OrderID,
+		ItemID:       item.ItemID,
+		ItemName:     item.ItemName,
+		RequestedQty: item.Quantity,
+		AvailableQty: available,
+		Warehouse:    order.Inventory.Warehouse,
+		Metadata:     followUpMetadata(order, EventInventoryOutOfStock),
+	}
+}
+
+// Validate checks that the cancellation event is valid.
+//
+// Returns:
+//   - error: A *FieldError for the first failed rule, or nil.
+func (e *OrderCancelled) Validate() error {
+	r := &rules{}
+	r.check(strings.TrimSpace(e.OrderID) != "", "order_id", ErrEmptyOrderID)
+	r.check(strings.TrimSpace(e.Reason) != "", "reason", ErrEmptyReason)
+	if r.check(e.Refund.Sign() >= 0, "refund", ErrInvalidRefund) {
+		checkCurrency(r, "refund", e.Currency, e.Refund)
+	}
+	return r.err()
+}
+
+// Validate checks that the payment failure event is valid.
+//
+// Returns:
+//   - error: A *FieldError for the first failed rule, or nil.
+func (e *PaymentFailed) Validate() error {
+	r := &rules{}
+	r.check(strings.TrimSpace(e.OrderID) != "", "order_id", ErrEmptyOrderID)
+	if r.check(e.Amount.Sign() > 0, "amount", ErrInvalidAmount) {
+		checkCurrency(r, "amount", e.Currency, e.Amount)
+	}
+	r.check(strings.TrimSpace(e.Reason) != "", "reason", ErrEmptyReason)
+	return r.err()
+}
+
+// Validate checks that the out-of-stock event is valid.
+//
+// Returns:
+//   - error: A *FieldError for the first failed rule, or nil.
+func (e *InventoryOutOfStock) Validate() error {
+	r := &rules{}
+	r.check(strings.TrimSpace(e.OrderID) != "", "order_id", ErrEmptyOrderID)
+	r.check(strings.TrimSpace(e.ItemID) != "", "item_id", ErrInvalidItemID)
+	if r.check(e.RequestedQty > 0, "requested_qty", ErrInvalidQuantity) {
+		r.check(e.AvailableQty < e.RequestedQty, "available_qty", ErrNotOutOfStock)
+	}
+	return r.err()
+}
+
+// EventTypeOf reads the event type of a payload from its metadata. A payload
+// without event type is an order.created event, as written by the first
+// producers.
+//
+// Parameters:
+//   - raw: The JSON payload.
+//
+// Returns:
+//   - string: The event type.
+//   - error: A JSON decoding error.
+func EventTypeOf(raw []byte) (string, error) {
+	var envelope struct {
+		Metadata OrderMetadata `json:"metadata"`
+	}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return "", err
+	}
+	if envelope.Metadata.EventType == "" {
+		return EventOrderCreated, nil
+	}
+	return envelope.Metadata.EventType, nil
+}
+
+// DecodeEvent decodes a payload according to its event type.
+//
+// Parameters:
+//   - raw: The JSON payload.
+//
+// Returns:
+//   - interface{}: An Order (upgraded by DecodeOrder), an OrderDiff, an OrderCancelled, a PaymentFailed or an InventoryOutOfStock.
+//   - error: A JSON decoding error, or an error wrapping ErrUnknownEventType or ErrUnsupportedSchemaVersion.
+func DecodeEvent(raw []byte) (interface{}, error) {
+	eventType, err := EventTypeOf(raw)
+	if err != nil {
+		return nil, err
+	}
+	switch eventType {
+	case EventOrderCreated:
+		order, err := DecodeOrder(raw)
+		if err != nil {
+			return nil, err
+		}
+		return order, nil
+	case EventOrderUpdated:
+		return decodeAs[OrderDiff](raw)
+	case EventOrderCancelled:
+		return decodeAs[OrderCancelled](raw)
+	case EventPaymentFailed:
+		return decodeAs[PaymentFailed](raw)
+	case EventInventoryOutOfStock:
+		return decodeAs[InventoryOutOfStock](raw)
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownEventType, eventType)
+}
+
+// decodeAs decodes a payload into a value of type T.
+//
+// Parameters:
+//   - raw: The JSON payload.
+//
+// Returns:
+//   - interface{}: The T value.
+//   - error: A JSON decoding error.
+func decodeAs[T any](raw []byte) (interface{}, error) {
+	var event T
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return nil, err
+	}
+	if b, ok := any(&event).(currencyBinder); ok {
+		b.bindCurrency()
+	}
+	return event, nil
+}
+
+// currencyBinder is implemented by the events whose amounts are bound to
+// their Currency field once decoded.
+type currencyBinder interface {
+	bindCurrency()
+}
+
+// bindCurrency binds the decoded refund to the currency of the event.
+func (e *OrderCancelled) bindCurrency() {
+	e.Refund = e.Refund.WithCurrency(e.Currency)
+}
+
+// bindCurrency binds the decoded amount to the currency of the event.
+func (e *PaymentFailed) bindCurrency() {
+	e.Amount = e.Amount.WithCurrency(e.Currency)
+}
+
+// followUpMetadata returns the metadata of an event following an order: same
+// source, schema version and correlation ID, new type and timestamp.
+//
+// Parameters:
+//   - order: The order.
+//   - eventType: The type of the event.
+//
+// Returns:
+//   - OrderMetadata: The event metadata.
+func followUpMetadata(order Order, eventType string) OrderMetadata {
+	metadata := order.Metadata
+	metadata.EventType = eventType
+	metadata.Timestamp = Now()
+	if metadata.Version == "" {
+		metadata.Version = string(CurrentSchemaVersion)
+	}
+	return metadata
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// builtOrder returns a valid order of one item.
+func builtOrder(t *testing.T) Order {
+	t.Helper()
+	order, err := NewOrder(
+		WithSequence(1),
+		WithCustomer(CustomerInfo{CustomerID: "cust-001", Name: "John Doe"}),
+		WithItem("item-001", "Espresso", 2, cents(350)),
+		WithPricing(0.2, cents(250), "EUR"),
+		WithPayment("credit_card", ""),
+		WithInventory(InventoryStatus{Warehouse: "PARIS-01"}),
+	).Build()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return order
+}
+
+// TestFollowUpEvents tests that the events of an order share its envelope.
+func TestFollowUpEvents(t *testing.T) {
+	order := builtOrder(t)
+
+	cancelled := NewOrderCancelled(order, "changed mind", "customer")
+	if cancelled.Refund != order.Total || cancelled.Metadata.EventType != EventOrderCancelled {
+		t.Errorf("Unexpected cancellation: %+v", cancelled)
+	}
+	payment := NewPaymentFailed(order, "card_declined", "insufficient funds", 1)
+	if payment.Amount != order.Total || payment.PaymentMethod != "credit_card" || payment.Metadata.EventType != EventPaymentFailed {
+		t.Errorf("Unexpected payment failure: %+v", payment)
+	}
+	stock := NewInventoryOutOfStock(order, order.Items[0], 1)
+	if stock.RequestedQty != 2 || stock.Warehouse != "PARIS-01" || stock.Metadata.EventType != EventInventoryOutOfStock {
+		t.Errorf("Unexpected out-of-stock event: %+v", stock)
+	}
+	for _, metadata := range []OrderMetadata{cancelled.Metadata, payment.Metadata, stock.Metadata} {
+		if metadata.CorrelationID != order.Metadata.CorrelationID || metadata.Version != order.Metadata.Version {
+			t.Errorf("Expected the order envelope, got %+v", metadata)
+		}
+	}
+
+	if err := cancelled.Validate(); err != nil {
+		t.Errorf("Expected a valid cancellation, got %v", err)
+	}
+	if err := payment.Validate(); err != nil {
+		t.Errorf("Expected a valid payment failure, got %v", err)
+	}
+	if err := stock.Validate(); err != nil {
+		t.Errorf("Expected a valid out-of-stock event, got %v", err)
+	}
+}
+
+// TestEventValidation tests the rules of the event payloads.
+func TestEventValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"Cancellation without reason", (&OrderCancelled{OrderID: "o-1"}).Validate(), ErrEmptyReason},
+		{"Negative refund", (&OrderCancelled{OrderID: "o-1", Reason: "r", Refund: cents(-1)}).Validate(), ErrInvalidRefund},
+		{"Payment without amount", (&PaymentFailed{OrderID: "o-1", Reason: "r"}).Validate(), ErrInvalidAmount},
+		{"Payment without order", (&PaymentFailed{Amount: cents(100), Reason: "r"}).Validate(), ErrEmptyOrderID},
+		{"Payment in another currency", (&PaymentFailed{OrderID: "o-1", Amount: NewMoney(1, "USD"), Currency: "EUR", Reason: "r"}).Validate(), ErrCurrencyMismatch},
+		{"Enough stock", (&InventoryOutOfStock{OrderID: "o-1", ItemID: "i", RequestedQty: 2, AvailableQty: 2}).Validate(), ErrNotOutOfStock},
+		{"No quantity", (&InventoryOutOfStock{OrderID: "o-1", ItemID: "i"}).Validate(), ErrInvalidQuantity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.want) || !IsValidationError(tt.err) {
+				t.Errorf("Expected %v, got %v", tt.want, tt.err)
+			}
+		})
+	}
+}
+
+// TestDecodeEvent tests the dispatch on the event type.
+func TestDecodeEvent(t *testing.T) {
+	order := builtOrder(t)
+	for _, want := range []interface{}{
+		order,
+		NewOrderCancelled(order, "changed mind", "customer"),
+		NewPaymentFailed(order, "card_declined", "insufficient funds", 2),
+		NewInventoryOutOfStock(order, order.Items[0], 0),
+	} {
+		raw, err := json.Marshal(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := DecodeEvent(raw)
+		if err != nil {
+			t.Fatalf("Unexpected error for %T: %v", want, err)
+		}
+		gotJSON, _ := json.Marshal(got)
+		if string(gotJSON) != string(raw) {
+			t.Errorf("Expected %T %s, got %T %s", want, raw, got, gotJSON)
+		}
+		if cancelled, ok := got.(OrderCancelled); ok && cancelled.Refund != order.Total {
+			t.Errorf("Expected the decoded refund bound to %s, got %+v", order.Currency, cancelled.Refund)
+		}
+	}
+
+	if eventType, err := EventTypeOf([]byte(`{"order_id": "o-1"}`)); err != nil || eventType != EventOrderCreated {
+		t.Errorf("Expected a payload without event type to be an order.created, got %q, %v", eventType, err)
+	}
+	if _, err := DecodeEvent([]byte(`{"metadata": {"event_type": "order.refunded"}}`)); !errors.Is(err, ErrUnknownEventType) {
+		t.Errorf("Expected ErrUnknownEventType, got %v", err)
+	}
+	if _, err := DecodeEvent([]byte(`{`)); err == nil {
+		t.Error("Expected a JSON decoding error")
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+)
+
+// fixtureFS holds the canonical payload corpus of testdata: one order per
+// schema version (order_v1.0, order_v1.1), one payload per follow-up event
+// type (order_updated, order_cancelled, payment_failed,
+// inventory_out_of_stock), the v1.1 order wrapped in a CloudEvent
+// (cloudevent_order) and one entry of each tracker file (event_entry,
+// log_entry).
+//
+//go:embed testdata/*.json
+var fixtureFS embed.FS
+
+// Fixture returns a payload of the corpus, so that the tests of every
+// package check their encoding and decoding against the same payloads.
+//
+// Parameters:
+//   - name: The fixture name, without extension (e.g., "order_v1.1"; see Fixtures).
+//
+// Returns:
+//   - []byte: The indented JSON payload.
+//   - error: An error if the fixture does not exist.
+func Fixture(name string) ([]byte, error) {
+	data, err := fixtureFS.ReadFile("testdata/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("unknown fixture %q: %w", name, err)
+	}
+	return data, nil
+}
+
+// Fixtures lists the fixture names of the corpus.
+//
+// Returns:
+//   - []string: The sorted names, without extension.
+func Fixtures() []string {
+	entries, _ := fs.ReadDir(fixtureFS, "testdata")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(names)
+	return names
+}
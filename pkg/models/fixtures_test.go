@@ -0,0 +1,105 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// mustFixture returns a payload of the corpus.
+func mustFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := Fixture(name)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return data
+}
+
+// TestFixturesGolden tests that every payload of the corpus decodes, is valid
+// and is written back byte for byte by the current encoding.
+func TestFixturesGolden(t *testing.T) {
+	expected := []string{"cloudevent_order", "event_entry", "inventory_out_of_stock", "log_entry", "order_cancelled", "order_updated", "order_v1.0", "order_v1.1", "payment_failed"}
+	if names := Fixtures(); !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Expected fixtures %v, got %v", expected, names)
+	}
+
+	for _, name := range expected {
+		raw := mustFixture(t, name)
+		var decoded interface{}
+		var err error
+		switch name {
+		case "cloudevent_order":
+			var event CloudEvent
+			err = json.Unmarshal(raw, &event)
+			decoded = event
+		case "event_entry":
+			var entry EventEntry
+			err = json.Unmarshal(raw, &entry)
+			decoded = entry
+		case "log_entry":
+			var entry LogEntry
+			err = json.Unmarshal(raw, &entry)
+			decoded = entry
+		default:
+			decoded, err = DecodeEvent(raw)
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+
+		switch event := decoded.(type) {
+		case Order:
+			err = event.Validate()
+		case OrderDiff:
+			err = event.Validate()
+		case CloudEvent:
+			_, err = FromCloudEvent(event)
+		case OrderCancelled:
+			err = event.Validate()
+		case PaymentFailed:
+			err = event.Validate()
+		case InventoryOutOfStock:
+			err = event.Validate()
+		}
+		if err != nil {
+			t.Errorf("%s: expected a valid payload, got %v", name, err)
+		}
+
+		if name == "order_v1.0" {
+			continue // upgraded on decoding, see TestFixturesUpgrade
+		}
+		written, _ := json.MarshalIndent(decoded, "", "  ")
+		if !bytes.Equal(append(written, '\n'), raw) {
+			t.Errorf("%s: expected the payload to be written back unchanged, got\n%s", name, written)
+		}
+	}
+
+	if _, err := Fixture("order_v9"); err == nil {
+		t.Error("Expected an error for an unknown fixture")
+	}
+}
+
+// TestFixturesUpgrade tests that the v1.0 order upgrades to the v1.1 one.
+func TestFixturesUpgrade(t *testing.T) {
+	old, err := DecodeOrder(mustFixture(t, "order_v1.0"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	current, _ := DecodeOrder(mustFixture(t, "order_v1.1"))
+	if !reflect.DeepEqual(old, current) {
+		t.Errorf("Expected the upgraded v1.0 order to equal the v1.1 one, got %+v", old)
+	}
+}
+
+// TestFixturesSchemas tests the corpus against the generated JSON Schemas.
+func TestFixturesSchemas(t *testing.T) {
+	if err := ValidateJSON(mustFixture(t, "order_v1.1")); err != nil {
+		t.Errorf("order_v1.1: unexpected schema error: %v", err)
+	}
+	if err := EventEntrySchema().Validate(mustFixture(t, "event_entry")); err != nil {
+		t.Errorf("event_entry: unexpected schema error: %v", err)
+	}
+}
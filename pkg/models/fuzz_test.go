@@ -0,0 +1,79 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// addFixtureSeeds seeds a fuzz target with the corpus, indented and compacted.
+func addFixtureSeeds(f *testing.F) {
+	for _, name := range Fixtures() {
+		raw, err := Fixture(name)
+		if err != nil {
+			f.Fatalf("Unexpected error: %v", err)
+		}
+		f.Add(raw)
+		var line bytes.Buffer
+		if err := json.Compact(&line, raw); err == nil {
+			f.Add(line.Bytes())
+		}
+	}
+	f.Add([]byte(`{"metadata": {"version": "2.0"}}`))
+	f.Add([]byte(`{"items": [{"quantity": -1, "unit_price": 1e300}]}`))
+	f.Add([]byte(`{"order_id"`))
+}
+
+// FuzzDecodeOrder tests that the tracker's decoding path never panics and
+// that the strict decoding only adds schema mismatches to DecodeOrder.
+func FuzzDecodeOrder(f *testing.F) {
+	addFixtureSeeds(f)
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		order, err := DecodeOrder(raw)
+		strict, strictErr := DecodeOrderStrict(raw)
+		switch {
+		case err != nil && strictErr == nil:
+			t.Fatalf("Strict decoding accepted a payload rejected by DecodeOrder: %v", err)
+		case err == nil && strictErr == nil && !reflect.DeepEqual(order, strict):
+			t.Fatalf("Strict decoding gave %+v instead of %+v", strict, order)
+		case err == nil && strictErr != nil && !errors.Is(strictErr, ErrSchemaMismatch):
+			t.Fatalf("Expected ErrSchemaMismatch, got %v", strictErr)
+		}
+		if err != nil {
+			return
+		}
+
+		_ = order.ValidateAll()
+		if _, err := json.Marshal(order); err != nil {
+			t.Fatalf("Cannot encode a decoded order: %v", err)
+		}
+		_, _ = DecodeEvent(raw)
+		normalized := order
+		_ = normalized.Normalize()
+	})
+}
+
+// FuzzEventEntry tests that the tracker.events entries decode without panic
+// and that a decoded entry is written back to an equivalent one.
+func FuzzEventEntry(f *testing.F) {
+	addFixtureSeeds(f)
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		var entry EventEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return
+		}
+		written, err := json.Marshal(entry)
+		if err != nil {
+			t.Fatalf("Cannot encode a decoded entry: %v", err)
+		}
+		var again EventEntry
+		if err := json.Unmarshal(written, &again); err != nil {
+			t.Fatalf("Cannot decode a written entry %s: %v", written, err)
+		}
+		if again.Timestamp.String() != entry.Timestamp.String() || again.KafkaOffset != entry.KafkaOffset || again.RawMessage != entry.RawMessage {
+			t.Fatalf("Expected %+v, got %+v", entry, again)
+		}
+	})
+}
@@ -0,0 +1,282 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// schemaDraft is the JSON Schema dialect of the generated schemas.
+const schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema validation errors, wrapped by the SchemaError values returned by
+// ValidateJSON and JSONSchema.Validate.
+var (
+	ErrUnknownField = newCodedError("ERR_FIELD_UNKNOWN", "unknown field")
+	ErrWrongType    = newCodedError("ERR_FIELD_WRONG_TYPE", "wrong type")
+	ErrMissingField = newCodedError("ERR_FIELD_REQUIRED", "missing required field")
+)
+
+var (
+	moneyType      = reflect.TypeOf(Money{})
+	timestampType  = reflect.TypeOf(Timestamp{})
+	rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+)
+
+// JSONSchema is a JSON Schema document generated from a Go struct by SchemaOf.
+type JSONSchema map[string]interface{}
+
+// SchemaError reports a JSON value that does not match a schema.
+type SchemaError struct {
+	Pointer string // JSON pointer (RFC 6901) of the value, "" for the document.
+	Err     error  // ErrUnknownField, ErrWrongType or ErrMissingField.
+	Detail  string // Expected and actual types, for ErrWrongType.
+}
+
+// Error formats the error with its JSON pointer.
+//
+// Returns:
+//   - string: The error message (e.g., "/items/0/quantity: wrong type: expected integer, got string").
+func (e *SchemaError) Error() string {
+	msg := fmt.Sprintf("%s: %v", e.Pointer, e.Err)
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error.
+//
+// Returns:
+//   - error: ErrUnknownField, ErrWrongType or ErrMissingField.
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// SchemaOf generates the JSON Schema of a struct from its JSON tags. Fields
+// without omitempty are required, unknown fields are rejected, Money amounts
+// are numbers and json.RawMessage fields accept any value.
+//
+// Parameters:
+//   - v: A value of the struct type.
+//   - title: The schema title.
+//
+// Returns:
+//   - JSONSchema: The schema document.
+func SchemaOf(v interface{}, title string) JSONSchema {
+	s := typeSchema(reflect.TypeOf(v))
+	s["$schema"] = schemaDraft
+	s["title"] = title
+	return s
+}
+
+// OrderSchema returns the JSON Schema of an order, in the current schema version.
+//
+// Returns:
+//   - JSONSchema: The schema of Order.
+func OrderSchema() JSONSchema {
+	return SchemaOf(Order{}, "PubSub order "+string(CurrentSchemaVersion))
+}
+
+// EventEntrySchema returns the JSON Schema of an entry of `tracker.events`.
+//
+// Returns:
+//   - JSONSchema: The schema of EventEntry.
+func EventEntrySchema() JSONSchema {
+	return SchemaOf(EventEntry{}, "PubSub tracker event")
+}
+
+// ValidateJSON checks a JSON order against OrderSchema. Unlike DecodeOrder,
+// which ignores unknown fields and zeroes missing ones, it reports every
+// unknown field, wrong type and missing required field.
+//
+// Parameters:
+//   - raw: The JSON order.
+//
+// Returns:
+//   - error: nil if the order matches, a syntax error, or the SchemaError values joined by errors.Join.
+func ValidateJSON(raw []byte) error {
+	return OrderSchema().Validate(raw)
+}
+
+// Validate checks a JSON document against the schema.
+//
+// Parameters:
+//   - raw: The JSON document.
+//
+// Returns:
+//   - error: nil if the document matches, a syntax error, or the SchemaError values joined by errors.Join.
+func (s JSONSchema) Validate(raw []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return err
+	}
+	var errs []error
+	validateValue(s, doc, "", &errs)
+	return errors.Join(errs...)
+}
+
+// typeSchema returns the schema of a Go type.
+//
+// Parameters:
+//   - t: The type.
+//
+// Returns:
+//   - map[string]interface{}: The schema.
+func typeSchema(t reflect.Type) map[string]interface{} {
+	switch {
+	case t == moneyType:
+		return map[string]interface{}{"type": "number"}
+	case t == timestampType:
+		return map[string]interface{}{"type": "string"}
+	case t == rawMessageType:
+		return map[string]interface{}{}
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		props := make(map[string]interface{})
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			parts := strings.Split(t.Field(i).Tag.Get("json"), ",")
+			name := parts[0]
+			if name == "" || name == "-" {
+				continue
+			}
+			props[name] = typeSchema(t.Field(i).Type)
+			if !containsString(parts[1:], "omitempty") {
+				required = append(required, name)
+			}
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           props,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	}
+	return map[string]interface{}{}
+}
+
+// validateValue checks a decoded JSON value against a generated schema and
+// appends the mismatches to errs.
+//
+// Parameters:
+//   - s: The schema of the value.
+//   - v: The value, decoded with json.Decoder.UseNumber.
+//   - pointer: The JSON pointer of the value.
+//   - errs: The collected errors.
+func validateValue(s map[string]interface{}, v interface{}, pointer string, errs *[]error) {
+	want, _ := s["type"].(string)
+	if want == "" {
+		return
+	}
+	if got := jsonType(v); got != want && !(want == "number" && got == "integer") {
+		*errs = append(*errs, &SchemaError{Pointer: pointer, Err: ErrWrongType, Detail: fmt.Sprintf("expected %s, got %s", want, got)})
+		return
+	}
+	switch want {
+	case "object":
+		obj := v.(map[string]interface{})
+		props, _ := s["properties"].(map[string]interface{})
+		if props == nil {
+			return
+		}
+		required, _ := s["required"].([]string)
+		for _, name := range required {
+			if _, ok := obj[name]; !ok {
+				*errs = append(*errs, &SchemaError{Pointer: pointer + "/" + escapePointer(name), Err: ErrMissingField})
+			}
+		}
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			sub := pointer + "/" + escapePointer(name)
+			prop, ok := props[name].(map[string]interface{})
+			if !ok {
+				*errs = append(*errs, &SchemaError{Pointer: sub, Err: ErrUnknownField})
+				continue
+			}
+			validateValue(prop, obj[name], sub, errs)
+		}
+	case "array":
+		items, _ := s["items"].(map[string]interface{})
+		for i, item := range v.([]interface{}) {
+			validateValue(items, item, fmt.Sprintf("%s/%d", pointer, i), errs)
+		}
+	}
+}
+
+// jsonType returns the JSON Schema type of a decoded JSON value.
+//
+// Parameters:
+//   - v: The value, decoded with json.Decoder.UseNumber.
+//
+// Returns:
+//   - string: The type ("object", "array", "string", "boolean", "integer", "number" or "null").
+func jsonType(v interface{}) string {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	}
+	return "null"
+}
+
+// escapePointer escapes a key for use in a JSON pointer (RFC 6901).
+//
+// Parameters:
+//   - key: The object key.
+//
+// Returns:
+//   - string: The key with "~" and "/" escaped.
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+// containsString reports whether a list contains a string.
+//
+// Parameters:
+//   - list: The list.
+//   - s: The string.
+//
+// Returns:
+//   - bool: True if s is in list.
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
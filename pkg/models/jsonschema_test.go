@@ -0,0 +1,94 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// validOrderJSON returns a complete order in the current schema.
+func validOrderJSON(t *testing.T) []byte {
+	t.Helper()
+	order := Order{
+		OrderID:      "order-123",
+		Sequence:     1,
+		Status:       "pending",
+		CustomerInfo: CustomerInfo{CustomerID: "cust-001", Name: "John Doe"},
+		Items:        []OrderItem{{ItemID: "item-001", ItemName: "Espresso", Quantity: 2, UnitPrice: cents(350), TotalPrice: cents(700)}},
+		SubTotal:     cents(700),
+		Currency:     "EUR",
+		Metadata:     OrderMetadata{Version: string(CurrentSchemaVersion)},
+	}
+	data, err := json.Marshal(order)
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+	return data
+}
+
+// TestOrderSchema tests the generated schema of an order.
+func TestOrderSchema(t *testing.T) {
+	schema := OrderSchema()
+	if schema["$schema"] != schemaDraft || schema["additionalProperties"] != false {
+		t.Errorf("Expected a closed draft 2020-12 schema, got %v", schema)
+	}
+	props := schema["properties"].(map[string]interface{})
+	if got := props["total"].(map[string]interface{})["type"]; got != "number" {
+		t.Errorf("Expected Money to be a number, got %v", got)
+	}
+	items := props["items"].(map[string]interface{})["items"].(map[string]interface{})
+	if got := items["properties"].(map[string]interface{})["quantity"].(map[string]interface{})["type"]; got != "integer" {
+		t.Errorf("Expected quantity to be an integer, got %v", got)
+	}
+	required := strings.Join(schema["required"].([]string), ",")
+	if !strings.Contains(required, "order_id") || strings.Contains(required, "delivery_notes") {
+		t.Errorf("Expected omitempty fields only to be optional, got %s", required)
+	}
+
+	event := EventEntrySchema()
+	if got := event["properties"].(map[string]interface{})["order_full"]; len(got.(map[string]interface{})) != 0 {
+		t.Errorf("Expected order_full to accept any value, got %v", got)
+	}
+	if _, err := json.Marshal(event); err != nil {
+		t.Errorf("Expected the schema to be JSON-encodable, got %v", err)
+	}
+}
+
+// TestValidateJSON tests that every mismatch is reported with its JSON pointer.
+func TestValidateJSON(t *testing.T) {
+	if err := ValidateJSON(validOrderJSON(t)); err != nil {
+		t.Fatalf("Expected a valid order, got %v", err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(validOrderJSON(t), &doc); err != nil {
+		t.Fatal(err)
+	}
+	delete(doc, "order_id")
+	doc["coupon/code"] = "X"
+	doc["items"].([]interface{})[0].(map[string]interface{})["quantity"] = "two"
+	raw, _ := json.Marshal(doc)
+
+	err := ValidateJSON(raw)
+	if !errors.Is(err, ErrMissingField) || !errors.Is(err, ErrUnknownField) || !errors.Is(err, ErrWrongType) {
+		t.Fatalf("Expected the three kinds of errors, got %v", err)
+	}
+	for _, want := range []string{
+		"/order_id: missing required field",
+		"/coupon~1code: unknown field",
+		"/items/0/quantity: wrong type: expected integer, got string",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in %q", want, err)
+		}
+	}
+
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) || schemaErr.Pointer == "" {
+		t.Errorf("Expected SchemaError values, got %v", err)
+	}
+	if err := ValidateJSON([]byte(`{`)); err == nil || errors.As(err, &schemaErr) {
+		t.Errorf("Expected a syntax error, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// HeaderSentAt carries the send time of the message, in Unix nanoseconds read
+// from the SendClock of the producer, so that a consumer measures its delivery
+// latency.
+const HeaderSentAt = "sent-at"
+
+// SendClock stamps the send time of the messages. Its readings are the wall
+// clock time at creation plus the monotonic time elapsed since: they never go
+// backwards when the system clock is adjusted, while staying comparable with
+// the wall clock of the consumers.
+type SendClock struct {
+	base time.Time // Creation time, with its monotonic reading.
+}
+
+// NewSendClock creates a clock anchored on the current wall clock time.
+//
+// Returns:
+//   - *SendClock: The clock.
+func NewSendClock() *SendClock {
+	return &SendClock{base: time.Now()}
+}
+
+// Now returns the current send time.
+//
+// Returns:
+//   - int64: The time, in Unix nanoseconds.
+func (c *SendClock) Now() int64 {
+	return c.base.UnixNano() + int64(time.Since(c.base))
+}
+
+// Header returns the value of the HeaderSentAt header for the current time.
+//
+// Returns:
+//   - []byte: The decimal Unix nanoseconds.
+func (c *SendClock) Header() []byte {
+	return strconv.AppendInt(nil, c.Now(), 10)
+}
+
+// ParseSentAt reads the value of a HeaderSentAt header.
+//
+// Parameters:
+//   - value: The header value.
+//
+// Returns:
+//   - time.Time: The send time.
+//   - error: An error if the value is not a positive integer.
+func ParseSentAt(value string) (time.Time, error) {
+	nanos, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || nanos <= 0 {
+		return time.Time{}, fmt.Errorf("invalid %s header %q", HeaderSentAt, value)
+	}
+	return time.Unix(0, nanos), nil
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSendClock tests that the send times follow the wall clock and never go backwards.
+func TestSendClock(t *testing.T) {
+	clock := NewSendClock()
+	first := clock.Now()
+	if drift := time.Since(time.Unix(0, first)); drift < 0 || drift > time.Second {
+		t.Errorf("Expected a send time close to the wall clock, got a drift of %s", drift)
+	}
+	for i := 0; i < 1000; i++ {
+		if next := clock.Now(); next < first {
+			t.Fatalf("Send time went backwards: %d < %d", next, first)
+		}
+	}
+}
+
+// TestParseSentAt tests the parsing of valid and invalid sent-at headers.
+func TestParseSentAt(t *testing.T) {
+	clock := NewSendClock()
+	sentAt, err := ParseSentAt(string(clock.Header()))
+	if err != nil || time.Since(sentAt) > time.Second {
+		t.Errorf("Unexpected send time %s (%v)", sentAt, err)
+	}
+	for _, value := range []string{"", "abc", "0", "-5", "1.5"} {
+		if _, err := ParseSentAt(value); err == nil {
+			t.Errorf("Expected %q to be rejected", value)
+		}
+	}
+}
@@ -24,12 +24,16 @@ const (
 // the application state (startup, shutdown, errors, metrics). This format is optimized
 // for ingestion, analysis, and visualization by monitoring and alerting tools.
 type LogEntry struct {
-	Timestamp string                 `json:"timestamp"`          // Log timestamp in RFC3339 format.
-	Level     LogLevel               `json:"level"`              // Severity level (INFO, ERROR).
-	Message   string                 `json:"message"`            // Main log message.
-	Service   string                 `json:"service"`            // Name of the emitting service.
-	Error     string                 `json:"error,omitempty"`    // Error message, if any.
-	Metadata  map[string]interface{} `json:"metadata,omitempty"` // Additional contextual data.
+	Timestamp     Timestamp              `json:"timestamp"`                // Log timestamp in RFC3339 format.
+	Level         LogLevel               `json:"level"`                    // Severity level (INFO, ERROR).
+	Message       string                 `json:"message"`                  // Main log message.
+	Service       string                 `json:"service"`                  // Name of the emitting service.
+	TraceID       string                 `json:"trace_id,omitempty"`       // Trace of the processed message, if any.
+	SpanID        string                 `json:"span_id,omitempty"`        // Producer span of the processed message, if any.
+	CorrelationID string                 `json:"correlation_id,omitempty"` // Correlation ID of the processed order, if any.
+	Error         string                 `json:"error,omitempty"`          // Error message, if any.
+	ErrorCode     ErrorCode              `json:"error_code,omitempty"`     // Code of the error, if it is a coded error of this package.
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`       // Additional contextual data.
 }
 
 // EventEntry is the structure of an event written to `tracker.events`.
@@ -40,14 +44,18 @@ type LogEntry struct {
 // and contextual information like topic, partition, and offset.
 // This log is the source of truth for auditing, event replay, and debugging.
 type EventEntry struct {
-	Timestamp      string          `json:"timestamp"`            // Reception timestamp in RFC3339 format.
-	EventType      string          `json:"event_type"`           // Event type (e.g., "message.received").
-	KafkaTopic     string          `json:"kafka_topic"`          // Source Kafka topic.
-	KafkaPartition int32           `json:"kafka_partition"`      // Source Kafka partition.
-	KafkaOffset    int64           `json:"kafka_offset"`         // Message offset in the partition.
-	RawMessage     string          `json:"raw_message"`          // Raw message content.
-	MessageSize    int             `json:"message_size"`         // Message size in bytes.
-	Deserialized   bool            `json:"deserialized"`         // Indicates if deserialization was successful.
-	Error          string          `json:"error,omitempty"`      // Deserialization error, if any.
-	OrderFull      json.RawMessage `json:"order_full,omitempty"` // Full content of the deserialized order.
+	Timestamp      Timestamp       `json:"timestamp"`                // Reception timestamp in RFC3339 format.
+	EventType      string          `json:"event_type"`               // Event type (e.g., "message.received").
+	TraceID        string          `json:"trace_id,omitempty"`       // Trace of the message (traceparent header).
+	SpanID         string          `json:"span_id,omitempty"`        // Producer span of the message (traceparent header).
+	CorrelationID  string          `json:"correlation_id,omitempty"` // Correlation ID (correlation-id header or order metadata).
+	KafkaTopic     string          `json:"kafka_topic"`              // Source Kafka topic.
+	KafkaPartition int32           `json:"kafka_partition"`          // Source Kafka partition.
+	KafkaOffset    int64           `json:"kafka_offset"`             // Message offset in the partition.
+	RawMessage     string          `json:"raw_message"`              // Raw message content.
+	MessageSize    int             `json:"message_size"`             // Message size in bytes.
+	Deserialized   bool            `json:"deserialized"`             // Indicates if deserialization was successful.
+	Error          string          `json:"error,omitempty"`          // Deserialization error, if any.
+	ErrorCode      ErrorCode       `json:"error_code,omitempty"`     // Code of the deserialization error, if it is a coded error of this package.
+	OrderFull      json.RawMessage `json:"order_full,omitempty"`     // Full content of the deserialized order.
 }
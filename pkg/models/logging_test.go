@@ -3,7 +3,6 @@ package models
 import (
 	"encoding/json"
 	"testing"
-	"time"
 )
 
 func TestLogLevelConstants(t *testing.T) {
@@ -17,7 +16,7 @@ func TestLogLevelConstants(t *testing.T) {
 }
 
 func TestLogEntry(t *testing.T) {
-	now := time.Now().Format(time.RFC3339)
+	now := Now()
 
 	entry := LogEntry{
 		Timestamp: now,
@@ -48,7 +47,7 @@ func TestLogEntry(t *testing.T) {
 
 func TestLogEntryWithError(t *testing.T) {
 	entry := LogEntry{
-		Timestamp: time.Now().Format(time.RFC3339),
+		Timestamp: Now(),
 		Level:     LogLevelERROR,
 		Message:   "Error occurred",
 		Error:     "connection refused",
@@ -61,7 +60,7 @@ func TestLogEntryWithError(t *testing.T) {
 
 func TestLogEntryWithNilMetadata(t *testing.T) {
 	entry := LogEntry{
-		Timestamp: time.Now().Format(time.RFC3339),
+		Timestamp: Now(),
 		Level:     LogLevelERROR,
 		Message:   "Error without metadata",
 	}
@@ -72,7 +71,7 @@ func TestLogEntryWithNilMetadata(t *testing.T) {
 }
 
 func TestEventEntry(t *testing.T) {
-	now := time.Now().Format(time.RFC3339)
+	now := Now()
 
 	entry := EventEntry{
 		Timestamp:      now,
@@ -113,7 +112,7 @@ func TestEventEntry(t *testing.T) {
 
 func TestEventEntryDeserializationFailed(t *testing.T) {
 	entry := EventEntry{
-		Timestamp:    time.Now().Format(time.RFC3339),
+		Timestamp:    Now(),
 		EventType:    "deserialization_failed",
 		KafkaOffset:  999,
 		Deserialized: false,
@@ -132,7 +131,7 @@ func TestEventEntryWithOrderFull(t *testing.T) {
 	orderJSON := json.RawMessage(`{"order_id":"ORD-123","items":[]}`)
 
 	entry := EventEntry{
-		Timestamp:    time.Now().Format(time.RFC3339),
+		Timestamp:    Now(),
 		EventType:    "order_received",
 		Deserialized: true,
 		OrderFull:    orderJSON,
@@ -0,0 +1,272 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in minor currency units (cents) of a currency. Integer
+// arithmetic keeps sums exact, so amounts can be compared without an epsilon.
+//
+// The currency is carried by the amount, so that Add and Sub reject amounts of
+// different currencies. An amount without currency (the zero value, or an
+// amount decoded from JSON) is unbound: it takes the currency of the amount it
+// is added to. Order.Currency binds the amounts of a decoded order.
+//
+// Money marshals to a JSON number with two decimals (e.g., 2.50), so payloads
+// stay compatible with consumers expecting float amounts; the currency is
+// carried once per order by Order.Currency, as in the payloads.
+type Money struct {
+	cents    int64  // Amount in minor units.
+	currency string // ISO 4217 code in upper case ("" while unbound).
+}
+
+// ErrCurrencyMismatch is returned when amounts of different currencies are combined.
+var ErrCurrencyMismatch = newCodedError("ERR_CURRENCY_MISMATCH", "amounts must share the same currency")
+
+// maxJSONAmount is the largest amount decoded from JSON, in major units: above
+// 2^53 cents, a float64 no longer holds every cent.
+const maxJSONAmount = float64(1<<53) / 100
+
+// NewMoney converts a decimal amount to Money, rounding to the nearest cent.
+//
+// Parameters:
+//   - amount: The amount in major units (e.g., 2.5 for 2.50).
+//   - currency: The ISO 4217 code (e.g., "EUR"; "" for an unbound amount).
+//
+// Returns:
+//   - Money: The amount in cents.
+func NewMoney(amount float64, currency string) Money {
+	return FromCents(int64(math.Round(amount*100)), currency)
+}
+
+// FromCents creates an amount from minor units.
+//
+// Parameters:
+//   - cents: The amount in minor units (e.g., 250 for 2.50).
+//   - currency: The ISO 4217 code (e.g., "EUR"; "" for an unbound amount).
+//
+// Returns:
+//   - Money: The amount.
+func FromCents(cents int64, currency string) Money {
+	return Money{cents: cents, currency: strings.ToUpper(strings.TrimSpace(currency))}
+}
+
+// Cents returns the amount in minor units.
+//
+// Returns:
+//   - int64: The amount (e.g., 250 for 2.50).
+func (m Money) Cents() int64 {
+	return m.cents
+}
+
+// Currency returns the currency of the amount.
+//
+// Returns:
+//   - string: The ISO 4217 code, or "" for an unbound amount.
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// WithCurrency binds an unbound amount to a currency. A bound amount is
+// returned unchanged: converting between currencies is not supported.
+//
+// Parameters:
+//   - currency: The ISO 4217 code (e.g., "EUR").
+//
+// Returns:
+//   - Money: The bound amount.
+func (m Money) WithCurrency(currency string) Money {
+	if m.currency != "" {
+		return m
+	}
+	return FromCents(m.cents, currency)
+}
+
+// Sign returns the sign of the amount.
+//
+// Returns:
+//   - int: -1, 0 or +1.
+func (m Money) Sign() int {
+	switch {
+	case m.cents < 0:
+		return -1
+	case m.cents > 0:
+		return 1
+	}
+	return 0
+}
+
+// IsZero reports whether the amount is zero, whatever its currency.
+//
+// Returns:
+//   - bool: True for a zero amount.
+func (m Money) IsZero() bool {
+	return m.cents == 0
+}
+
+// compatible reports whether two amounts can be combined: they share their
+// currency, or one of them is unbound.
+//
+// Parameters:
+//   - other: The other amount.
+//
+// Returns:
+//   - bool: True if the currencies match.
+func (m Money) compatible(other Money) bool {
+	return m.currency == "" || other.currency == "" || m.currency == other.currency
+}
+
+// combine checks that two amounts can be combined and returns the currency
+// of the result.
+//
+// Parameters:
+//   - other: The other amount.
+//
+// Returns:
+//   - string: The currency of the bound amount, or "" if both are unbound.
+//   - error: An error wrapping ErrCurrencyMismatch.
+func (m Money) combine(other Money) (string, error) {
+	if !m.compatible(other) {
+		return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+	if m.currency != "" {
+		return m.currency, nil
+	}
+	return other.currency, nil
+}
+
+// Add returns the sum of two amounts.
+//
+// Parameters:
+//   - other: The amount to add.
+//
+// Returns:
+//   - Money: m + other, in the currency of the bound amount.
+//   - error: An error wrapping ErrCurrencyMismatch if the currencies differ.
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.combine(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{cents: m.cents + other.cents, currency: currency}, nil
+}
+
+// Sub returns the difference of two amounts.
+//
+// Parameters:
+//   - other: The amount to subtract.
+//
+// Returns:
+//   - Money: m - other, in the currency of the bound amount.
+//   - error: An error wrapping ErrCurrencyMismatch if the currencies differ.
+func (m Money) Sub(other Money) (Money, error) {
+	currency, err := m.combine(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{cents: m.cents - other.cents, currency: currency}, nil
+}
+
+// Sum returns the sum of amounts of one currency.
+//
+// Parameters:
+//   - amounts: The amounts to add.
+//
+// Returns:
+//   - Money: The sum (an unbound zero if amounts is empty).
+//   - error: An error wrapping ErrCurrencyMismatch if the currencies differ.
+func Sum(amounts ...Money) (Money, error) {
+	var sum Money
+	for _, amount := range amounts {
+		var err error
+		if sum, err = sum.Add(amount); err != nil {
+			return Money{}, err
+		}
+	}
+	return sum, nil
+}
+
+// Mul returns the amount multiplied by a quantity.
+//
+// Parameters:
+//   - qty: The quantity.
+//
+// Returns:
+//   - Money: m * qty, in the same currency.
+func (m Money) Mul(qty int) Money {
+	return Money{cents: m.cents * int64(qty), currency: m.currency}
+}
+
+// MulRate applies a rate to the amount (e.g., a tax rate), rounding to the
+// nearest cent.
+//
+// Parameters:
+//   - rate: The rate (e.g., 0.2 for 20%).
+//
+// Returns:
+//   - Money: m * rate, rounded, in the same currency.
+func (m Money) MulRate(rate float64) Money {
+	return Money{cents: int64(math.Round(float64(m.cents) * rate)), currency: m.currency}
+}
+
+// Float64 returns the amount in major units.
+//
+// Returns:
+//   - float64: The amount (e.g., 2.5 for 250 cents).
+func (m Money) Float64() float64 {
+	return float64(m.cents) / 100
+}
+
+// String formats the amount with two decimals, without currency.
+//
+// Returns:
+//   - string: The amount (e.g., "2.50", "-0.05").
+func (m Money) String() string {
+	sign := ""
+	cents := uint64(m.cents)
+	if m.cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// MarshalJSON encodes the amount as a JSON number with two decimals.
+//
+// Returns:
+//   - []byte: The JSON number (e.g., 2.50).
+//   - error: Always nil.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number, rounding it to the nearest cent. The
+// decoded amount is unbound: the payload carries its currency elsewhere.
+//
+// Parameters:
+//   - data: The JSON number (e.g., 2.5, 2.50 or 3).
+//
+// Returns:
+//   - error: An error if data is not a JSON number, or is too large to be held to the cent.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return fmt.Errorf("invalid amount %s: %w", data, err)
+	}
+	amount, err := strconv.ParseFloat(number.String(), 64)
+	if err != nil {
+		return fmt.Errorf("invalid amount %s: %w", data, err)
+	}
+	if math.Abs(amount) > maxJSONAmount {
+		return fmt.Errorf("invalid amount %s: out of range", data)
+	}
+	*m = NewMoney(amount, "")
+	return nil
+}
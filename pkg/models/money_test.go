@@ -0,0 +1,125 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+// cents returns an unbound amount, as decoded from JSON.
+func cents(c int64) Money {
+	return FromCents(c, "")
+}
+
+// TestMoneyArithmetic tests that Money sums stay exact where float64 drifts.
+func TestMoneyArithmetic(t *testing.T) {
+	var sum Money
+	for i := 0; i < 10; i++ {
+		var err error
+		if sum, err = sum.Add(NewMoney(0.10, "EUR")); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if sum != FromCents(100, "EUR") {
+		t.Errorf("Expected 10 x 0.10 to be 100 EUR cents, got %d %s", sum.Cents(), sum.Currency())
+	}
+
+	if got := NewMoney(3.20, "EUR").Mul(3); got != FromCents(960, "EUR") {
+		t.Errorf("Expected 3 x 3.20 to be 960 cents, got %d", got.Cents())
+	}
+	if got := NewMoney(9.60, "EUR").MulRate(0.2); got != FromCents(192, "EUR") {
+		t.Errorf("Expected 20%% of 9.60 to be 192 cents, got %d", got.Cents())
+	}
+	if got := cents(5).MulRate(0.5); got.Cents() != 3 {
+		t.Errorf("Expected 50%% of 5 cents to round to 3, got %d", got.Cents())
+	}
+	if got, err := NewMoney(2.50, "EUR").Sub(NewMoney(3, "EUR")); err != nil || got.Cents() != -50 {
+		t.Errorf("Expected 2.50 - 3.00 to be -50 cents, got %d (%v)", got.Cents(), err)
+	}
+	if got := NewMoney(2.50, "EUR").Float64(); got != 2.5 {
+		t.Errorf("Expected 2.5, got %v", got)
+	}
+}
+
+// TestMoneyCurrency tests that Add and Sub reject amounts of different
+// currencies, and that an unbound amount takes the currency of the other.
+func TestMoneyCurrency(t *testing.T) {
+	eur, usd := NewMoney(2.50, " eur "), NewMoney(1, "USD")
+	if eur.Currency() != "EUR" {
+		t.Errorf("Expected the currency code in upper case, got %q", eur.Currency())
+	}
+
+	if _, err := eur.Add(usd); !errors.Is(err, ErrCurrencyMismatch) || !IsValidationError(err) {
+		t.Errorf("Expected ErrCurrencyMismatch for EUR + USD, got %v", err)
+	}
+	if _, err := usd.Sub(eur); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch for USD - EUR, got %v", err)
+	}
+	if _, err := Sum(eur, eur, usd); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch from Sum, got %v", err)
+	}
+
+	if got, err := cents(50).Add(eur); err != nil || got != FromCents(300, "EUR") {
+		t.Errorf("Expected the unbound amount to take EUR, got %d %q (%v)", got.Cents(), got.Currency(), err)
+	}
+	if got, err := eur.Sub(cents(50)); err != nil || got != FromCents(200, "EUR") {
+		t.Errorf("Expected 2.00 EUR, got %d %q (%v)", got.Cents(), got.Currency(), err)
+	}
+	if got := cents(50).WithCurrency("usd"); got != FromCents(50, "USD") {
+		t.Errorf("Expected WithCurrency to bind an unbound amount, got %q", got.Currency())
+	}
+	if got := eur.WithCurrency("USD"); got != eur {
+		t.Errorf("Expected WithCurrency to keep a bound amount, got %q", got.Currency())
+	}
+}
+
+// TestMoneyString tests the two-decimal formatting.
+func TestMoneyString(t *testing.T) {
+	tests := map[int64]string{
+		0:      "0.00",
+		5:      "0.05",
+		250:    "2.50",
+		123456: "1234.56",
+		-5:     "-0.05",
+		-1090:  "-10.90",
+
+		math.MinInt64: "-92233720368547758.08",
+	}
+	for c, want := range tests {
+		if got := FromCents(c, "EUR").String(); got != want {
+			t.Errorf("FromCents(%d).String() = %q, want %q", c, got, want)
+		}
+	}
+}
+
+// TestMoneyJSON tests that Money reads and writes the float amounts of the
+// existing payloads.
+func TestMoneyJSON(t *testing.T) {
+	data, err := json.Marshal(OrderItem{ItemID: "item-001", ItemName: "Espresso", Quantity: 2, UnitPrice: cents(250), TotalPrice: cents(500)})
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+	want := `{"item_id":"item-001","item_name":"Espresso","quantity":2,"unit_price":2.50,"total_price":5.00}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+
+	var item OrderItem
+	if err := json.Unmarshal([]byte(`{"unit_price":3.2,"total_price":9.600000000000001}`), &item); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+	if item.UnitPrice != cents(320) || item.TotalPrice != cents(960) {
+		t.Errorf("Expected 320 and 960 unbound cents, got %+v and %+v", item.UnitPrice, item.TotalPrice)
+	}
+
+	var m Money
+	if err := json.Unmarshal([]byte(`"abc"`), &m); err == nil {
+		t.Error("Expected an error for a non-numeric amount")
+	}
+	for _, huge := range []string{`1e300`, `-1e17`} {
+		if err := json.Unmarshal([]byte(huge), &m); err == nil {
+			t.Errorf("Expected an error for the out-of-range amount %s", huge)
+		}
+	}
+}
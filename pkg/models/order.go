@@ -10,28 +10,27 @@ package models
 import (
 	"errors"
 	"fmt"
-	"math"
 	"regexp"
 	"strings"
 )
 
 // Validation errors
 var (
-	ErrEmptyOrderID        = errors.New("order_id is required")
-	ErrInvalidSequence     = errors.New("sequence must be positive")
-	ErrEmptyStatus         = errors.New("status is required")
-	ErrNoItems             = errors.New("order must contain at least one item")
-	ErrInvalidCustomerID   = errors.New("customer_id is required")
-	ErrInvalidCustomerName = errors.New("customer name is required")
-	ErrInvalidEmail        = errors.New("invalid email format")
-	ErrInvalidItemID       = errors.New("item_id is required")
-	ErrInvalidItemName     = errors.New("item_name is required")
-	ErrInvalidQuantity     = errors.New("quantity must be positive")
-	ErrInvalidUnitPrice    = errors.New("unit_price must be positive")
-	ErrInvalidTotalPrice   = errors.New("total_price does not match quantity * unit_price")
-	ErrInvalidSubtotal     = errors.New("subtotal does not match sum of items")
-	ErrInvalidTax          = errors.New("tax must be positive or zero")
-	ErrInvalidTotal        = errors.New("total is inconsistent with subtotal + tax + shipping fee")
+	ErrEmptyOrderID        = newCodedError("ERR_ORDER_ID_REQUIRED", "order_id is required")
+	ErrInvalidSequence     = newCodedError("ERR_SEQUENCE_NOT_POSITIVE", "sequence must be positive")
+	ErrEmptyStatus         = newCodedError("ERR_STATUS_REQUIRED", "status is required")
+	ErrNoItems             = newCodedError("ERR_ITEMS_REQUIRED", "order must contain at least one item")
+	ErrInvalidCustomerID   = newCodedError("ERR_CUSTOMER_ID_REQUIRED", "customer_id is required")
+	ErrInvalidCustomerName = newCodedError("ERR_CUSTOMER_NAME_REQUIRED", "customer name is required")
+	ErrInvalidEmail        = newCodedError("ERR_EMAIL_INVALID", "invalid email format")
+	ErrInvalidItemID       = newCodedError("ERR_ITEM_ID_REQUIRED", "item_id is required")
+	ErrInvalidItemName     = newCodedError("ERR_ITEM_NAME_REQUIRED", "item_name is required")
+	ErrInvalidQuantity     = newCodedError("ERR_QUANTITY_NOT_POSITIVE", "quantity must be positive")
+	ErrInvalidUnitPrice    = newCodedError("ERR_UNIT_PRICE_NOT_POSITIVE", "unit_price must be positive")
+	ErrInvalidTotalPrice   = newCodedError("ERR_TOTAL_PRICE_MISMATCH", "total_price does not match quantity * unit_price")
+	ErrInvalidSubtotal     = newCodedError("ERR_SUBTOTAL_MISMATCH", "subtotal does not match sum of items")
+	ErrInvalidTax          = newCodedError("ERR_TAX_NEGATIVE", "tax must be positive or zero")
+	ErrInvalidTotal        = newCodedError("ERR_TOTAL_MISMATCH", "total is inconsistent with subtotal + tax + shipping fee")
 )
 
 // emailRegex verifies the email format
@@ -40,84 +39,96 @@ var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]
 // CustomerInfo contains detailed information about the customer.
 // These data are embedded in every order message.
 type CustomerInfo struct {
-	CustomerID   string `json:"customer_id"`   // Unique identifier of the customer.
-	Name         string `json:"name"`          // Full name of the customer.
-	Email        string `json:"email"`         // Email address of the customer.
-	Phone        string `json:"phone"`         // Phone number of the customer.
-	Address      string `json:"address"`       // Physical address of the customer.
-	LoyaltyLevel string `json:"loyalty_level"` // Loyalty level (e.g., "silver", "gold").
+	CustomerID   string `json:"customer_id"`            // Unique identifier of the customer.
+	Name         string `json:"name" pii:"truncate"`    // Full name of the customer.
+	Email        string `json:"email" pii:"hash"`       // Email address of the customer.
+	Phone        string `json:"phone" pii:"truncate"`   // Phone number of the customer.
+	Address      string `json:"address" pii:"truncate"` // Physical address of the customer.
+	LoyaltyLevel string `json:"loyalty_level"`          // Loyalty level (e.g., "silver", "gold").
 }
 
 // Validate checks that the customer information is valid.
 //
 // Returns:
-//   - error: An error if a required field is missing or invalid.
+//   - error: A *FieldError for the first required field missing or invalid.
 func (c *CustomerInfo) Validate() error {
-	if strings.TrimSpace(c.CustomerID) == "" {
-		return ErrInvalidCustomerID
-	}
-	if strings.TrimSpace(c.Name) == "" {
-		return ErrInvalidCustomerName
-	}
-	if c.Email != "" && !emailRegex.MatchString(c.Email) {
-		return ErrInvalidEmail
-	}
-	return nil
+	r := &rules{}
+	c.validate(r, "")
+	return r.err()
+}
+
+// validate checks the customer information rules.
+//
+// Parameters:
+//   - r: The failed rules.
+//   - path: The field path of the customer information.
+func (c *CustomerInfo) validate(r *rules, path string) {
+	r.check(strings.TrimSpace(c.CustomerID) != "", fieldPath(path, "customer_id"), ErrInvalidCustomerID)
+	r.check(strings.TrimSpace(c.Name) != "", fieldPath(path, "name"), ErrInvalidCustomerName)
+	r.check(c.Email == "" || emailRegex.MatchString(c.Email), fieldPath(path, "email"), ErrInvalidEmail)
 }
 
 // InventoryStatus represents the inventory state for a specific item at the time of the order.
 // This information allows understanding the stock context without querying an inventory service.
 type InventoryStatus struct {
-	ItemID       string  `json:"item_id"`       // Identifier of the item in stock.
-	ItemName     string  `json:"item_name"`     // Name of the item.
-	AvailableQty int     `json:"available_qty"` // Quantity available before the order.
-	ReservedQty  int     `json:"reserved_qty"`  // Quantity reserved by this order.
-	UnitPrice    float64 `json:"unit_price"`    // Unit price.
-	InStock      bool    `json:"in_stock"`      // Availability indicator (true if stock > 0).
-	Warehouse    string  `json:"warehouse"`     // Origin warehouse.
+	ItemID       string `json:"item_id"`       // Identifier of the item in stock.
+	ItemName     string `json:"item_name"`     // Name of the item.
+	AvailableQty int    `json:"available_qty"` // Quantity available before the order.
+	ReservedQty  int    `json:"reserved_qty"`  // Quantity reserved by this order.
+	UnitPrice    Money  `json:"unit_price"`    // Unit price.
+	InStock      bool   `json:"in_stock"`      // Availability indicator (true if stock > 0).
+	Warehouse    string `json:"warehouse"`     // Origin warehouse.
 }
 
 // OrderItem represents an individual item within an order.
 type OrderItem struct {
-	ItemID     string  `json:"item_id"`     // Unique identifier of the item.
-	ItemName   string  `json:"item_name"`   // Name of the item.
-	Quantity   int     `json:"quantity"`    // Ordered quantity.
-	UnitPrice  float64 `json:"unit_price"`  // Unit price.
-	TotalPrice float64 `json:"total_price"` // Total price for this item (Quantity * Price).
+	ItemID     string `json:"item_id"`     // Unique identifier of the item.
+	ItemName   string `json:"item_name"`   // Name of the item.
+	Quantity   int    `json:"quantity"`    // Ordered quantity.
+	UnitPrice  Money  `json:"unit_price"`  // Unit price.
+	TotalPrice Money  `json:"total_price"` // Total price for this item (Quantity * Price).
 }
 
 // Validate checks that an order item is valid.
 //
 // Returns:
-//   - error: An error if the item data is invalid or inconsistent.
+//   - error: A *FieldError for the first invalid or inconsistent field.
 func (item *OrderItem) Validate() error {
-	if strings.TrimSpace(item.ItemID) == "" {
-		return ErrInvalidItemID
-	}
-	if strings.TrimSpace(item.ItemName) == "" {
-		return ErrInvalidItemName
-	}
-	if item.Quantity <= 0 {
-		return ErrInvalidQuantity
-	}
-	if item.UnitPrice <= 0 {
-		return ErrInvalidUnitPrice
+	r := &rules{}
+	item.validate(r, "", item.UnitPrice.Currency())
+	return r.err()
+}
+
+// validate checks the order item rules. The total price is checked only when
+// the quantity, the unit price and the currencies are valid.
+//
+// Parameters:
+//   - r: The failed rules.
+//   - path: The field path of the item.
+//   - currency: The currency of the order ("" accepts any).
+func (item *OrderItem) validate(r *rules, path, currency string) {
+	r.check(strings.TrimSpace(item.ItemID) != "", fieldPath(path, "item_id"), ErrInvalidItemID)
+	r.check(strings.TrimSpace(item.ItemName) != "", fieldPath(path, "item_name"), ErrInvalidItemName)
+	validQty := r.check(item.Quantity > 0, fieldPath(path, "quantity"), ErrInvalidQuantity)
+	validPrice := r.check(item.UnitPrice.Sign() > 0, fieldPath(path, "unit_price"), ErrInvalidUnitPrice)
+	validCurrency := checkCurrency(r, fieldPath(path, "unit_price"), currency, item.UnitPrice)
+	validCurrency = checkCurrency(r, fieldPath(path, "total_price"), currency, item.TotalPrice) && validCurrency
+	if !validQty || !validPrice || !validCurrency {
+		return
 	}
-	expectedTotal := float64(item.Quantity) * item.UnitPrice
-	if math.Abs(item.TotalPrice-expectedTotal) > 0.01 {
-		return fmt.Errorf("%w: expected %.2f, got %.2f", ErrInvalidTotalPrice, expectedTotal, item.TotalPrice)
+	if expectedTotal := item.UnitPrice.Mul(item.Quantity); item.TotalPrice.Cents() != expectedTotal.Cents() {
+		r.fail(fieldPath(path, "total_price"), fmt.Errorf("%w: expected %s, got %s", ErrInvalidTotalPrice, expectedTotal, item.TotalPrice))
 	}
-	return nil
 }
 
 // OrderMetadata contains technical and contextual metadata for the order event.
 // This information is essential for tracking, debugging, and analyzing the message flow.
 type OrderMetadata struct {
-	Timestamp     string `json:"timestamp"`      // Event creation timestamp (RFC3339).
-	Version       string `json:"version"`        // Data schema version.
-	EventType     string `json:"event_type"`     // Event type (e.g., "order.created").
-	Source        string `json:"source"`         // Event source (e.g., "producer-service").
-	CorrelationID string `json:"correlation_id"` // Correlation identifier for distributed tracing.
+	Timestamp     Timestamp `json:"timestamp"`      // Event creation timestamp (RFC3339).
+	Version       string    `json:"version"`        // Data schema version.
+	EventType     string    `json:"event_type"`     // Event type (e.g., "order.created").
+	Source        string    `json:"source"`         // Event source (e.g., "producer-service").
+	CorrelationID string    `json:"correlation_id"` // Correlation identifier for distributed tracing.
 }
 
 // Order is the main structure representing a complete customer order.
@@ -139,70 +150,216 @@ type Order struct {
 	Inventory InventoryStatus `json:"inventory"`
 
 	// Financial Details
-	SubTotal    float64 `json:"subtotal"`     // Sum of items.
-	Tax         float64 `json:"tax"`          // Tax amount.
-	ShippingFee float64 `json:"shipping_fee"` // Shipping fee.
-	Total       float64 `json:"total"`        // Total amount.
-	Currency    string  `json:"currency"`     // Currency (e.g., "EUR").
+	SubTotal    Money  `json:"subtotal"`     // Sum of items.
+	Tax         Money  `json:"tax"`          // Tax amount.
+	ShippingFee Money  `json:"shipping_fee"` // Shipping fee.
+	Total       Money  `json:"total"`        // Total amount.
+	Currency    string `json:"currency"`     // Currency of all the amounts (e.g., "EUR").
 
 	// Payment and Delivery
-	PaymentMethod string `json:"payment_method"`           // Payment method used.
-	DeliveryNotes string `json:"delivery_notes,omitempty"` // Optional delivery notes.
+	PaymentMethod string `json:"payment_method"`                          // Payment method used.
+	DeliveryNotes string `json:"delivery_notes,omitempty" pii:"truncate"` // Optional delivery notes (may contain the address).
 
 	// Event Metadata
 	Metadata OrderMetadata `json:"metadata"`
 }
 
 // Validate checks that an order is valid.
-// It validates all required fields and the consistency of amounts.
+// It validates all required fields and the consistency of amounts, and stops
+// at the first failed rule.
 //
 // Returns:
-//   - error: An error if the order is invalid.
+//   - error: A *FieldError for the first failed rule, or nil.
 func (o *Order) Validate() error {
+	r := &rules{}
+	o.validate(r)
+	return r.err()
+}
+
+// ValidateAll checks every rule of Validate, without stopping at the first
+// failure, to report all the problems of a payload at once.
+//
+// Returns:
+//   - error: The *FieldError of every failed rule, joined by errors.Join, or nil.
+func (o *Order) ValidateAll() error {
+	r := &rules{all: true}
+	o.validate(r)
+	return r.err()
+}
+
+// validate checks the order rules.
+//
+// Parameters:
+//   - r: The failed rules.
+func (o *Order) validate(r *rules) {
 	// Basic Validations
-	if strings.TrimSpace(o.OrderID) == "" {
-		return ErrEmptyOrderID
-	}
-	if o.Sequence <= 0 {
-		return ErrInvalidSequence
+	r.check(strings.TrimSpace(o.OrderID) != "", "order_id", ErrEmptyOrderID)
+	r.check(o.Sequence > 0, "sequence", ErrInvalidSequence)
+	r.check(strings.TrimSpace(o.Status) != "", "status", ErrEmptyStatus)
+
+	// Customer Validation
+	o.CustomerInfo.validate(r, "customer_info")
+
+	// Items Validation
+	r.check(len(o.Items) > 0, "items", ErrNoItems)
+	totals := make([]Money, len(o.Items))
+	for i := range o.Items {
+		if r.stop() {
+			return
+		}
+		o.Items[i].validate(r, fmt.Sprintf("items[%d]", i), o.Currency)
+		totals[i] = o.Items[i].TotalPrice
 	}
-	if strings.TrimSpace(o.Status) == "" {
-		return ErrEmptyStatus
+	if r.stop() {
+		return
 	}
 
-	// Customer Validation
-	if err := o.CustomerInfo.Validate(); err != nil {
-		return err
+	// Currency Validations
+	validCurrency := true
+	for _, amount := range []struct {
+		field string
+		m     Money
+	}{
+		{"inventory.unit_price", o.Inventory.UnitPrice}, {"subtotal", o.SubTotal},
+		{"tax", o.Tax}, {"shipping_fee", o.ShippingFee}, {"total", o.Total},
+	} {
+		validCurrency = checkCurrency(r, amount.field, o.Currency, amount.m) && validCurrency
+	}
+	if !validCurrency {
+		return
 	}
 
-	// Items Validation
-	if len(o.Items) == 0 {
-		return ErrNoItems
+	// Financial Validations
+	if calculatedSubtotal, err := Sum(totals...); err != nil {
+		r.fail("subtotal", err)
+	} else if o.SubTotal.Cents() != calculatedSubtotal.Cents() {
+		r.fail("subtotal", fmt.Errorf("%w: expected %s, got %s", ErrInvalidSubtotal, calculatedSubtotal, o.SubTotal))
+	}
+	r.check(o.Tax.Sign() >= 0, "tax", ErrInvalidTax)
+	if expectedTotal, err := Sum(o.SubTotal, o.Tax, o.ShippingFee); err != nil {
+		r.fail("total", err)
+	} else if o.Total.Cents() != expectedTotal.Cents() {
+		r.fail("total", fmt.Errorf("%w: expected %s, got %s", ErrInvalidTotal, expectedTotal, o.Total))
 	}
+}
+
+// validationErrors lists the errors returned by the Validate methods.
+var validationErrors = []error{
+	ErrEmptyOrderID, ErrInvalidSequence, ErrEmptyStatus, ErrNoItems,
+	ErrInvalidCustomerID, ErrInvalidCustomerName, ErrInvalidEmail,
+	ErrInvalidItemID, ErrInvalidItemName, ErrInvalidQuantity, ErrInvalidUnitPrice, ErrInvalidTotalPrice,
+	ErrInvalidSubtotal, ErrInvalidTax, ErrInvalidTotal, ErrUnsupportedSchemaVersion, ErrSchemaMismatch,
+	ErrUnknownEventType, ErrEmptyReason, ErrInvalidAmount, ErrInvalidRefund, ErrNotOutOfStock,
+	ErrNoChanges, ErrOrderMismatch, ErrDiffConflict, ErrInvalidCurrency, ErrCurrencyMismatch,
+	ErrMissingAttribute, ErrUnsupportedSpecVersion, ErrUnsupportedContentType,
+}
 
-	var calculatedSubtotal float64
-	for i, item := range o.Items {
-		if err := item.Validate(); err != nil {
-			return fmt.Errorf("item %d: %w", i+1, err)
+// IsValidationError reports whether an error is (or wraps) a validation error
+// of this package. Such errors are permanent: retrying cannot fix the data.
+//
+// Parameters:
+//   - err: The error to check.
+//
+// Returns:
+//   - bool: True if err wraps one of the Err* validation errors.
+func IsValidationError(err error) bool {
+	for _, target := range validationErrors {
+		if errors.Is(err, target) {
+			return true
 		}
-		calculatedSubtotal += item.TotalPrice
 	}
+	return false
+}
 
-	// Financial Validations
-	if math.Abs(o.SubTotal-calculatedSubtotal) > 0.01 {
-		return fmt.Errorf("%w: expected %.2f, got %.2f", ErrInvalidSubtotal, calculatedSubtotal, o.SubTotal)
+// FieldError is a failed validation rule, located by its field path.
+type FieldError struct {
+	Field string // Field path (e.g., "items[0].quantity").
+	Err   error  // The validation error, wrapping one of the Err* errors.
+}
+
+// Error formats the error with its field path.
+//
+// Returns:
+//   - string: The error message (e.g., "items[0].quantity: quantity must be positive").
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap returns the validation error.
+//
+// Returns:
+//   - error: The wrapped validation error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// rules collects the failed validation rules: only the first one, or all of
+// them when all is set.
+type rules struct {
+	all  bool
+	errs []error
+}
+
+// check records an error for the field if the rule does not hold.
+//
+// Parameters:
+//   - ok: The rule that must hold.
+//   - field: The field path.
+//   - err: The validation error.
+//
+// Returns:
+//   - bool: ok, so that dependent rules can be skipped.
+func (r *rules) check(ok bool, field string, err error) bool {
+	if !ok {
+		r.fail(field, err)
 	}
+	return ok
+}
 
-	if o.Tax < 0 {
-		return ErrInvalidTax
+// fail records an error for the field, unless a first error is already
+// recorded and all is not set.
+//
+// Parameters:
+//   - field: The field path.
+//   - err: The validation error.
+func (r *rules) fail(field string, err error) {
+	if r.all || len(r.errs) == 0 {
+		r.errs = append(r.errs, &FieldError{Field: field, Err: err})
 	}
+}
+
+// stop reports whether the remaining rules can be skipped.
+//
+// Returns:
+//   - bool: True if a first error is recorded and all is not set.
+func (r *rules) stop() bool {
+	return !r.all && len(r.errs) > 0
+}
 
-	expectedTotal := o.SubTotal + o.Tax + o.ShippingFee
-	if math.Abs(o.Total-expectedTotal) > 0.01 {
-		return fmt.Errorf("%w: expected %.2f, got %.2f", ErrInvalidTotal, expectedTotal, o.Total)
+// err returns the recorded errors.
+//
+// Returns:
+//   - error: The single error, all the errors joined by errors.Join, or nil.
+func (r *rules) err() error {
+	if len(r.errs) == 1 {
+		return r.errs[0]
 	}
+	return errors.Join(r.errs...)
+}
 
-	return nil
+// fieldPath qualifies a field name with the path of its parent.
+//
+// Parameters:
+//   - parent: The parent path ("" for a top-level field).
+//   - name: The JSON name of the field.
+//
+// Returns:
+//   - string: The field path (e.g., "customer_info.email").
+func fieldPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
 }
 
 // IsValid returns true if the order is valid.
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -17,8 +20,8 @@ func TestOrderItemValidate(t *testing.T) {
 				ItemID:     "item-001",
 				ItemName:   "Espresso",
 				Quantity:   2,
-				UnitPrice:  3.50,
-				TotalPrice: 7.00,
+				UnitPrice:  cents(350),
+				TotalPrice: cents(700),
 			},
 			wantErr: false,
 		},
@@ -28,8 +31,8 @@ func TestOrderItemValidate(t *testing.T) {
 				ItemID:     "",
 				ItemName:   "Espresso",
 				Quantity:   2,
-				UnitPrice:  3.50,
-				TotalPrice: 7.00,
+				UnitPrice:  cents(350),
+				TotalPrice: cents(700),
 			},
 			wantErr: true,
 		},
@@ -39,8 +42,8 @@ func TestOrderItemValidate(t *testing.T) {
 				ItemID:     "item-001",
 				ItemName:   "",
 				Quantity:   2,
-				UnitPrice:  3.50,
-				TotalPrice: 7.00,
+				UnitPrice:  cents(350),
+				TotalPrice: cents(700),
 			},
 			wantErr: true,
 		},
@@ -50,8 +53,8 @@ func TestOrderItemValidate(t *testing.T) {
 				ItemID:     "item-001",
 				ItemName:   "Espresso",
 				Quantity:   0,
-				UnitPrice:  3.50,
-				TotalPrice: 0.00,
+				UnitPrice:  cents(350),
+				TotalPrice: cents(0),
 			},
 			wantErr: true,
 		},
@@ -61,8 +64,8 @@ func TestOrderItemValidate(t *testing.T) {
 				ItemID:     "item-001",
 				ItemName:   "Espresso",
 				Quantity:   -1,
-				UnitPrice:  3.50,
-				TotalPrice: -3.50,
+				UnitPrice:  cents(350),
+				TotalPrice: cents(-350),
 			},
 			wantErr: true,
 		},
@@ -72,8 +75,8 @@ func TestOrderItemValidate(t *testing.T) {
 				ItemID:     "item-001",
 				ItemName:   "Espresso",
 				Quantity:   2,
-				UnitPrice:  -3.50,
-				TotalPrice: -7.00,
+				UnitPrice:  cents(-350),
+				TotalPrice: cents(-700),
 			},
 			wantErr: true,
 		},
@@ -83,8 +86,8 @@ func TestOrderItemValidate(t *testing.T) {
 				ItemID:     "item-001",
 				ItemName:   "Espresso",
 				Quantity:   2,
-				UnitPrice:  3.50,
-				TotalPrice: 10.00, // Should be 7.00
+				UnitPrice:  cents(350),
+				TotalPrice: cents(1000), // Should be 700
 			},
 			wantErr: true,
 		},
@@ -179,8 +182,8 @@ func TestOrderValidate(t *testing.T) {
 		ItemID:     "item-001",
 		ItemName:   "Espresso",
 		Quantity:   2,
-		UnitPrice:  3.50,
-		TotalPrice: 7.00,
+		UnitPrice:  cents(350),
+		TotalPrice: cents(700),
 	}
 
 	validCustomer := CustomerInfo{
@@ -201,10 +204,10 @@ func TestOrderValidate(t *testing.T) {
 				Sequence:     1,
 				Status:       "pending",
 				Items:        []OrderItem{validItem},
-				SubTotal:     7.00,
-				Tax:          1.40,
-				ShippingFee:  2.50,
-				Total:        10.90,
+				SubTotal:     cents(700),
+				Tax:          cents(140),
+				ShippingFee:  cents(250),
+				Total:        cents(1090),
 				CustomerInfo: validCustomer,
 			},
 			wantErr: false,
@@ -216,10 +219,10 @@ func TestOrderValidate(t *testing.T) {
 				Sequence:     1,
 				Status:       "pending",
 				Items:        []OrderItem{validItem},
-				SubTotal:     7.00,
-				Tax:          1.40,
-				ShippingFee:  2.50,
-				Total:        10.90,
+				SubTotal:     cents(700),
+				Tax:          cents(140),
+				ShippingFee:  cents(250),
+				Total:        cents(1090),
 				CustomerInfo: validCustomer,
 			},
 			wantErr: true,
@@ -231,10 +234,10 @@ func TestOrderValidate(t *testing.T) {
 				Sequence:     0,
 				Status:       "pending",
 				Items:        []OrderItem{validItem},
-				SubTotal:     7.00,
-				Tax:          1.40,
-				ShippingFee:  2.50,
-				Total:        10.90,
+				SubTotal:     cents(700),
+				Tax:          cents(140),
+				ShippingFee:  cents(250),
+				Total:        cents(1090),
 				CustomerInfo: validCustomer,
 			},
 			wantErr: true,
@@ -246,10 +249,10 @@ func TestOrderValidate(t *testing.T) {
 				Sequence:     1,
 				Status:       "",
 				Items:        []OrderItem{validItem},
-				SubTotal:     7.00,
-				Tax:          1.40,
-				ShippingFee:  2.50,
-				Total:        10.90,
+				SubTotal:     cents(700),
+				Tax:          cents(140),
+				ShippingFee:  cents(250),
+				Total:        cents(1090),
 				CustomerInfo: validCustomer,
 			},
 			wantErr: true,
@@ -261,10 +264,10 @@ func TestOrderValidate(t *testing.T) {
 				Sequence:     1,
 				Status:       "pending",
 				Items:        []OrderItem{},
-				SubTotal:     0,
-				Tax:          0,
-				ShippingFee:  0,
-				Total:        0,
+				SubTotal:     cents(0),
+				Tax:          cents(0),
+				ShippingFee:  cents(0),
+				Total:        cents(0),
 				CustomerInfo: validCustomer,
 			},
 			wantErr: true,
@@ -276,10 +279,10 @@ func TestOrderValidate(t *testing.T) {
 				Sequence:     1,
 				Status:       "pending",
 				Items:        []OrderItem{validItem},
-				SubTotal:     7.00,
-				Tax:          1.40,
-				ShippingFee:  2.50,
-				Total:        100.00, // Wrong total
+				SubTotal:     cents(700),
+				Tax:          cents(140),
+				ShippingFee:  cents(250),
+				Total:        cents(10000), // Wrong total
 				CustomerInfo: validCustomer,
 			},
 			wantErr: true,
@@ -291,10 +294,10 @@ func TestOrderValidate(t *testing.T) {
 				Sequence:    1,
 				Status:      "pending",
 				Items:       []OrderItem{validItem},
-				SubTotal:    7.00,
-				Tax:         1.40,
-				ShippingFee: 2.50,
-				Total:       10.90,
+				SubTotal:    cents(700),
+				Tax:         cents(140),
+				ShippingFee: cents(250),
+				Total:       cents(1090),
 				CustomerInfo: CustomerInfo{
 					CustomerID: "",
 					Name:       "John",
@@ -325,14 +328,14 @@ func TestOrderIsValid(t *testing.T) {
 				ItemID:     "item-001",
 				ItemName:   "Espresso",
 				Quantity:   2,
-				UnitPrice:  3.50,
-				TotalPrice: 7.00,
+				UnitPrice:  cents(350),
+				TotalPrice: cents(700),
 			},
 		},
-		SubTotal:    7.00,
-		Tax:         1.40,
-		ShippingFee: 2.50,
-		Total:       10.90,
+		SubTotal:    cents(700),
+		Tax:         cents(140),
+		ShippingFee: cents(250),
+		Total:       cents(1090),
 		CustomerInfo: CustomerInfo{
 			CustomerID: "cust-001",
 			Name:       "John Doe",
@@ -363,21 +366,21 @@ func TestMultipleItemsSubtotal(t *testing.T) {
 				ItemID:     "item-001",
 				ItemName:   "Espresso",
 				Quantity:   2,
-				UnitPrice:  3.50,
-				TotalPrice: 7.00,
+				UnitPrice:  cents(350),
+				TotalPrice: cents(700),
 			},
 			{
 				ItemID:     "item-002",
 				ItemName:   "Cappuccino",
 				Quantity:   1,
-				UnitPrice:  4.00,
-				TotalPrice: 4.00,
+				UnitPrice:  cents(400),
+				TotalPrice: cents(400),
 			},
 		},
-		SubTotal:    11.00, // 7.00 + 4.00
-		Tax:         2.20,
-		ShippingFee: 2.50,
-		Total:       15.70, // 11.00 + 2.20 + 2.50
+		SubTotal:    cents(1100), // 700 + 400
+		Tax:         cents(220),
+		ShippingFee: cents(250),
+		Total:       cents(1570), // 1100 + 220 + 250
 		CustomerInfo: CustomerInfo{
 			CustomerID: "cust-001",
 			Name:       "John Doe",
@@ -388,3 +391,66 @@ func TestMultipleItemsSubtotal(t *testing.T) {
 		t.Errorf("Expected valid order with multiple items, got error: %v", err)
 	}
 }
+
+// TestIsValidationError tests that validation errors are recognized, even wrapped.
+func TestIsValidationError(t *testing.T) {
+	order := Order{OrderID: "o-1", Sequence: 1, Status: "pending", CustomerInfo: CustomerInfo{CustomerID: "c", Name: "n"}}
+	if err := order.Validate(); !IsValidationError(err) {
+		t.Errorf("Expected a validation error, got %v", err)
+	}
+	if !IsValidationError(fmt.Errorf("item 1: %w", ErrInvalidQuantity)) {
+		t.Error("Expected a wrapped validation error to be recognized")
+	}
+	if IsValidationError(errors.New("broker down")) || IsValidationError(nil) {
+		t.Error("Expected other errors not to be validation errors")
+	}
+}
+
+// TestOrderValidateAll tests that ValidateAll reports every failed rule with
+// its field path, while Validate stops at the first one.
+func TestOrderValidateAll(t *testing.T) {
+	order := Order{
+		Sequence:     0,
+		Status:       "pending",
+		CustomerInfo: CustomerInfo{CustomerID: "cust-001", Name: "John Doe", Email: "not-an-email"},
+		Items: []OrderItem{
+			{ItemID: "item-001", ItemName: "Espresso", Quantity: 2, UnitPrice: cents(350), TotalPrice: cents(700)},
+			{ItemID: "item-002", ItemName: "Cappuccino", Quantity: 0, UnitPrice: cents(400), TotalPrice: cents(400)},
+		},
+		SubTotal: cents(1100),
+		Tax:      cents(-1),
+		Total:    cents(1099),
+	}
+
+	err := order.ValidateAll()
+	want := []string{
+		"order_id: " + ErrEmptyOrderID.Error(),
+		"sequence: " + ErrInvalidSequence.Error(),
+		"customer_info.email: " + ErrInvalidEmail.Error(),
+		"items[1].quantity: " + ErrInvalidQuantity.Error(),
+		"tax: " + ErrInvalidTax.Error(),
+	}
+	if err == nil || err.Error() != strings.Join(want, "\n") {
+		t.Fatalf("Expected %q, got %v", strings.Join(want, "\n"), err)
+	}
+	if !errors.Is(err, ErrInvalidQuantity) || !IsValidationError(err) {
+		t.Errorf("Expected the joined errors to wrap the validation errors, got %v", err)
+	}
+
+	var fieldErr *FieldError
+	first := order.Validate()
+	if !errors.As(first, &fieldErr) || fieldErr.Field != "order_id" || !errors.Is(first, ErrEmptyOrderID) {
+		t.Errorf("Expected Validate to return the first failed rule, got %v", first)
+	}
+
+	order.Items[1].Quantity = 1
+	order.Items[1].TotalPrice = cents(500)
+	order.Total = cents(2000)
+	err = order.ValidateAll()
+	if !errors.Is(err, ErrInvalidTotalPrice) || !strings.Contains(err.Error(), "items[1].total_price: ") {
+		t.Errorf("Expected the item total mismatch, got %v", err)
+	}
+	if !errors.Is(err, ErrInvalidSubtotal) || !errors.Is(err, ErrInvalidTotal) {
+		t.Errorf("Expected the subtotal and total mismatches, got %v", err)
+	}
+}
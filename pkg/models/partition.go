@@ -0,0 +1,122 @@
+package models
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// PartitionStrategy selects the order field used as the Kafka message key,
+// and therefore which orders are kept in order on the same partition.
+type PartitionStrategy string
+
+// Partition strategies.
+const (
+	// PartitionByCustomer keys the orders by customer ID: the orders of a
+	// customer are consumed in sequence.
+	PartitionByCustomer PartitionStrategy = "customer"
+	// PartitionByOrder keys the orders by order ID: the events of an order
+	// are consumed in sequence and the load spreads evenly.
+	PartitionByOrder PartitionStrategy = "order"
+	// PartitionByRegion keys the orders by the region of the customer
+	// address (see Order.Region).
+	PartitionByRegion PartitionStrategy = "region"
+
+	// DefaultPartitionStrategy is the strategy used by the producer.
+	DefaultPartitionStrategy = PartitionByCustomer
+)
+
+// ErrUnknownPartitionStrategy is returned for a strategy other than customer, order or region.
+var ErrUnknownPartitionStrategy = newCodedError("ERR_PARTITION_STRATEGY_UNKNOWN", "unknown partition strategy")
+
+// ParsePartitionStrategy parses a partition strategy, as written in the
+// configuration.
+//
+// Parameters:
+//   - s: The strategy ("customer", "order" or "region"; "" for the default one).
+//
+// Returns:
+//   - PartitionStrategy: The strategy.
+//   - error: An error wrapping ErrUnknownPartitionStrategy.
+func ParsePartitionStrategy(s string) (PartitionStrategy, error) {
+	switch strategy := PartitionStrategy(strings.ToLower(strings.TrimSpace(s))); strategy {
+	case "":
+		return DefaultPartitionStrategy, nil
+	case PartitionByCustomer, PartitionByOrder, PartitionByRegion:
+		return strategy, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownPartitionStrategy, s)
+}
+
+// PartitionKey returns the Kafka message key of the order for a strategy.
+// Keys only depend on the order data, so that the same order always gets the
+// same key. An order without region is keyed by customer.
+//
+// Parameters:
+//   - strategy: The partition strategy.
+//
+// Returns:
+//   - string: The message key.
+//   - error: An error wrapping ErrUnknownPartitionStrategy.
+func (o *Order) PartitionKey(strategy PartitionStrategy) (string, error) {
+	switch strategy {
+	case PartitionByCustomer:
+		return o.CustomerInfo.CustomerID, nil
+	case PartitionByOrder:
+		return o.OrderID, nil
+	case PartitionByRegion:
+		if region := o.Region(); region != "" {
+			return region, nil
+		}
+		return o.CustomerInfo.CustomerID, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownPartitionStrategy, strategy)
+}
+
+// Region returns the region of the customer address: its last comma-separated
+// part without postal code, in lower case ("75001 Paris" gives "paris",
+// "Boston, MA 02108" gives "ma").
+//
+// Returns:
+//   - string: The region, or "" if the address is empty.
+func (o *Order) Region() string {
+	address := o.CustomerInfo.Address
+	if i := strings.LastIndex(address, ","); i >= 0 {
+		address = address[i+1:]
+	}
+	words := strings.FieldsFunc(address, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsDigit(r)
+	})
+	return strings.ToLower(strings.Join(words, " "))
+}
+
+// HashKey hashes a message key with 32-bit FNV-1a. The hash does not depend
+// on the Kafka client, so that tools share one definition of the partition of
+// a key (see PartitionFor).
+//
+// Parameters:
+//   - key: The message key.
+//
+// Returns:
+//   - uint32: The hash.
+func HashKey(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return h.Sum32()
+}
+
+// PartitionFor returns the partition of a message key among n partitions.
+//
+// Parameters:
+//   - key: The message key.
+//   - partitions: The number of partitions of the topic.
+//
+// Returns:
+//   - int: The partition, from 0 to partitions-1 (0 if partitions <= 0).
+func PartitionFor(key string, partitions int) int {
+	if partitions <= 0 {
+		return 0
+	}
+	return int(HashKey(key) % uint32(partitions))
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestPartitionKey tests the key of each strategy and the region fallback.
+func TestPartitionKey(t *testing.T) {
+	order := Order{
+		OrderID:      "order-123",
+		CustomerInfo: CustomerInfo{CustomerID: "cust-001", Address: "12 rue de la Paix, 75001 Paris"},
+	}
+	tests := []struct {
+		strategy PartitionStrategy
+		want     string
+	}{
+		{PartitionByCustomer, "cust-001"},
+		{PartitionByOrder, "order-123"},
+		{PartitionByRegion, "paris"},
+	}
+	for _, tt := range tests {
+		if got, err := order.PartitionKey(tt.strategy); err != nil || got != tt.want {
+			t.Errorf("PartitionKey(%s) = %q, %v; expected %q", tt.strategy, got, err, tt.want)
+		}
+	}
+
+	order.CustomerInfo.Address = "1 Main Street, Boston, MA 02108"
+	if got, _ := order.PartitionKey(PartitionByRegion); got != "ma" {
+		t.Errorf("Expected region ma, got %q", got)
+	}
+	order.CustomerInfo.Address = ""
+	if got, _ := order.PartitionKey(PartitionByRegion); got != "cust-001" {
+		t.Errorf("Expected the customer key without address, got %q", got)
+	}
+	if _, err := order.PartitionKey("country"); !errors.Is(err, ErrUnknownPartitionStrategy) {
+		t.Errorf("Expected ErrUnknownPartitionStrategy, got %v", err)
+	}
+}
+
+// TestParsePartitionStrategy tests the strategies read from the configuration.
+func TestParsePartitionStrategy(t *testing.T) {
+	for input, want := range map[string]PartitionStrategy{"": DefaultPartitionStrategy, "Order": PartitionByOrder, " region ": PartitionByRegion} {
+		if got, err := ParsePartitionStrategy(input); err != nil || got != want {
+			t.Errorf("ParsePartitionStrategy(%q) = %q, %v; expected %q", input, got, err, want)
+		}
+	}
+	if _, err := ParsePartitionStrategy("country"); !errors.Is(err, ErrUnknownPartitionStrategy) {
+		t.Errorf("Expected ErrUnknownPartitionStrategy, got %v", err)
+	}
+}
+
+// TestPartitionFor tests that the partition of a key is stable and in range.
+func TestPartitionFor(t *testing.T) {
+	// Reference FNV-1a values: the hash must never change.
+	if HashKey("") != 0x811c9dc5 || HashKey("a") != 0xe40c292c {
+		t.Errorf("Unexpected hashes %#x and %#x", HashKey(""), HashKey("a"))
+	}
+	if PartitionFor("cust-001", 6) != PartitionFor("cust-001", 6) {
+		t.Error("Expected the same partition for the same key")
+	}
+	seen := make(map[int]bool)
+	for _, key := range []string{"cust-001", "cust-002", "cust-003", "cust-004", "cust-005", "cust-006", "cust-007", "cust-008"} {
+		p := PartitionFor(key, 3)
+		if p < 0 || p >= 3 {
+			t.Fatalf("Expected a partition in [0, 3), got %d", p)
+		}
+		seen[p] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("Expected the keys to spread over the partitions, got %v", seen)
+	}
+	if PartitionFor("cust-001", 0) != 0 {
+		t.Error("Expected partition 0 without partitions")
+	}
+}
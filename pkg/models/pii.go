@@ -0,0 +1,88 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"strings"
+)
+
+// PII masking modes, set by the pii struct tag of the fields holding personal
+// data.
+const (
+	// PIIHash replaces the value by a truncated SHA-256 hash of its lower-case
+	// form: equal values keep equal hashes, so that masked entries can still
+	// be joined.
+	PIIHash = "hash"
+	// PIITruncate keeps the first runes of the value only.
+	PIITruncate = "truncate"
+)
+
+// piiTruncateKeep is the number of runes kept by PIITruncate.
+const piiTruncateKeep = 4
+
+// Redact returns a copy of the customer information with its personal data
+// masked: hashed email, truncated name, phone and address.
+//
+// Returns:
+//   - CustomerInfo: The masked copy; the customer ID and loyalty level are kept.
+func (c CustomerInfo) Redact() CustomerInfo {
+	redactPII(reflect.ValueOf(&c).Elem())
+	return c
+}
+
+// MaskForLogging returns a copy of the order with the personal data of every
+// field tagged pii masked, for the audit logs.
+//
+// Returns:
+//   - Order: The masked copy; the order itself is unchanged.
+func (o Order) MaskForLogging() Order {
+	redactPII(reflect.ValueOf(&o).Elem())
+	return o
+}
+
+// redactPII masks the string fields tagged pii of a struct and of its nested
+// structs.
+//
+// Parameters:
+//   - v: The addressable struct value.
+func redactPII(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		switch field.Kind() {
+		case reflect.Struct:
+			redactPII(field)
+		case reflect.String:
+			field.SetString(MaskPII(field.String(), v.Type().Field(i).Tag.Get("pii")))
+		}
+	}
+}
+
+// MaskPII masks a value according to its masking mode.
+//
+// Parameters:
+//   - value: The personal data.
+//   - mode: PIIHash, PIITruncate, or "" for a value that is not personal data.
+//
+// Returns:
+//   - string: The masked value (e.g., "sha256:1f2e..." or "+33 ***"); empty values stay empty.
+func MaskPII(value, mode string) string {
+	if value == "" {
+		return value
+	}
+	switch mode {
+	case PIIHash:
+		sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(value))))
+		return "sha256:" + hex.EncodeToString(sum[:8])
+	case PIITruncate:
+		runes := []rune(value)
+		if len(runes) <= piiTruncateKeep {
+			return "***"
+		}
+		return string(runes[:piiTruncateKeep]) + "***"
+	}
+	return value
+}
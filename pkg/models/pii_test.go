@@ -0,0 +1,60 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestMaskPII tests the masking modes.
+func TestMaskPII(t *testing.T) {
+	tests := []struct {
+		value, mode, want string
+	}{
+		{"+33 6 12 34 56 78", PIITruncate, "+33 ***"},
+		{"Bob", PIITruncate, "***"},
+		{"", PIITruncate, ""},
+		{"", PIIHash, ""},
+		{"gold", "", "gold"},
+	}
+	for _, tt := range tests {
+		if got := MaskPII(tt.value, tt.mode); got != tt.want {
+			t.Errorf("MaskPII(%q, %q) = %q; expected %q", tt.value, tt.mode, got, tt.want)
+		}
+	}
+
+	hash := MaskPII("camille.martin@example.com", PIIHash)
+	if !strings.HasPrefix(hash, "sha256:") || len(hash) != len("sha256:")+16 || strings.Contains(hash, "camille") {
+		t.Errorf("Unexpected hash %q", hash)
+	}
+	if MaskPII(" Camille.Martin@Example.com", PIIHash) != hash {
+		t.Error("Expected the hash to ignore case and spaces")
+	}
+}
+
+// TestMaskForLogging tests that the tagged fields of an order are masked in a
+// copy only.
+func TestMaskForLogging(t *testing.T) {
+	order, err := DecodeOrder(mustFixture(t, "order_v1.1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	original := order.CustomerInfo
+
+	masked := order.MaskForLogging()
+	c := masked.CustomerInfo
+	if c.CustomerID != original.CustomerID || c.LoyaltyLevel != original.LoyaltyLevel {
+		t.Errorf("Expected the non-personal fields to be kept, got %+v", c)
+	}
+	if c.Name != "Cami***" || c.Phone != "+33 ***" || c.Address != "12 r***" || !strings.HasPrefix(c.Email, "sha256:") {
+		t.Errorf("Expected the personal fields to be masked, got %+v", c)
+	}
+	if masked.DeliveryNotes != "Livr***" || masked.OrderID != order.OrderID || masked.Total != order.Total {
+		t.Errorf("Unexpected masked order %+v", masked)
+	}
+	if order.CustomerInfo != original {
+		t.Error("Expected the order to be unchanged")
+	}
+	if original.Redact() != c {
+		t.Errorf("Expected Redact to mask like MaskForLogging, got %+v", original.Redact())
+	}
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Timestamp is a point in time written as an RFC3339 string in JSON, as in
+// the payloads and the log files. The zero Timestamp is written as "".
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp converts a time to a Timestamp in UTC.
+//
+// Parameters:
+//   - t: The time.
+//
+// Returns:
+//   - Timestamp: The timestamp.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t.UTC()}
+}
+
+// Now returns the current time as a Timestamp in UTC.
+//
+// Returns:
+//   - Timestamp: The current timestamp.
+func Now() Timestamp {
+	return NewTimestamp(time.Now())
+}
+
+// ParseTimestamp parses an RFC3339 timestamp, with or without fractional
+// seconds.
+//
+// Parameters:
+//   - s: The timestamp (e.g., "2024-03-01T12:00:00Z"); "" gives the zero Timestamp.
+//
+// Returns:
+//   - Timestamp: The timestamp.
+//   - error: An error if s is not an RFC3339 timestamp.
+func ParseTimestamp(s string) (Timestamp, error) {
+	if s == "" {
+		return Timestamp{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return Timestamp{}, fmt.Errorf("invalid timestamp %q: %w", s, err)
+	}
+	return Timestamp{Time: t}, nil
+}
+
+// String formats the timestamp in RFC3339.
+//
+// Returns:
+//   - string: The timestamp (e.g., "2024-03-01T12:00:00Z"), or "" if zero.
+func (t Timestamp) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// Clock formats the time of day in UTC, as shown in the monitor lists.
+//
+// Returns:
+//   - string: The time (e.g., "12:00:00"), or "" if zero.
+func (t Timestamp) Clock() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format("15:04:05")
+}
+
+// MarshalJSON encodes the timestamp as an RFC3339 string.
+//
+// Returns:
+//   - []byte: The JSON string ("" if zero).
+//   - error: Always nil.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON decodes an RFC3339 string.
+//
+// Parameters:
+//   - data: The JSON string (or null).
+//
+// Returns:
+//   - error: An error if data is not an RFC3339 string.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid timestamp %s: %w", data, err)
+	}
+	parsed, err := ParseTimestamp(s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
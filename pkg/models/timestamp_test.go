@@ -0,0 +1,60 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// mustTimestamp parses an RFC3339 timestamp for the test fixtures.
+func mustTimestamp(s string) Timestamp {
+	ts, _ := ParseTimestamp(s)
+	return ts
+}
+
+// TestTimestampJSON tests the RFC3339 encoding and the strict decoding.
+func TestTimestampJSON(t *testing.T) {
+	ts := NewTimestamp(time.Date(2024, 3, 1, 13, 0, 0, 0, time.FixedZone("CET", 3600)))
+	data, err := json.Marshal(LogEntry{Timestamp: ts, Message: "ok"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var entry LogEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if entry.Timestamp.String() != "2024-03-01T12:00:00Z" || !entry.Timestamp.Equal(ts.Time) {
+		t.Errorf("Expected a UTC round trip, got %s from %s", entry.Timestamp, data)
+	}
+
+	var zero Timestamp
+	if data, _ := json.Marshal(zero); string(data) != `""` {
+		t.Errorf(`Expected "" for the zero timestamp, got %s`, data)
+	}
+	for _, raw := range []string{`""`, `null`} {
+		var decoded Timestamp
+		if err := json.Unmarshal([]byte(raw), &decoded); err != nil || !decoded.IsZero() {
+			t.Errorf("Expected %s to decode to the zero timestamp, got %v (%v)", raw, decoded, err)
+		}
+	}
+	for _, raw := range []string{`"2024-03-01 12:00:00"`, `"invalide"`, `1709294400`} {
+		var decoded Timestamp
+		if err := json.Unmarshal([]byte(raw), &decoded); err == nil {
+			t.Errorf("Expected an error for %s", raw)
+		}
+	}
+}
+
+// TestTimestampClock tests the time of day shown in the monitor lists.
+func TestTimestampClock(t *testing.T) {
+	ts, err := ParseTimestamp("2024-03-01T13:04:05.123+01:00")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ts.Clock() != "12:04:05" {
+		t.Errorf("Expected 12:04:05, got %q", ts.Clock())
+	}
+	if (Timestamp{}).Clock() != "" || (Timestamp{}).String() != "" {
+		t.Error("Expected empty strings for the zero timestamp")
+	}
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Kafka headers carrying the trace context of a message.
+const (
+	// HeaderTraceParent carries the W3C Trace Context of the message
+	// (e.g., "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01").
+	HeaderTraceParent = "traceparent"
+	// HeaderCorrelationID carries the correlation ID of the order.
+	HeaderCorrelationID = "correlation-id"
+)
+
+// TraceContext identifies the distributed trace of a message, so that the
+// tracker logs can be joined with the traces of the other services.
+type TraceContext struct {
+	TraceID       string // Trace identifier (32 lowercase hex digits).
+	SpanID        string // Span identifier of the producer (16 lowercase hex digits).
+	CorrelationID string // Correlation identifier of the order.
+}
+
+// NewTraceContext starts a new trace with random identifiers.
+//
+// Parameters:
+//   - correlationID: The correlation ID of the order.
+//
+// Returns:
+//   - TraceContext: The trace context.
+func NewTraceContext(correlationID string) TraceContext {
+	return TraceContext{TraceID: randomHex(16), SpanID: randomHex(8), CorrelationID: correlationID}
+}
+
+// ParseTraceParent reads the trace and span IDs of a W3C traceparent header.
+//
+// Parameters:
+//   - header: The header value (version-traceid-spanid-flags).
+//
+// Returns:
+//   - TraceContext: The trace context, without correlation ID.
+//   - bool: False if the header is not a valid traceparent.
+func ParseTraceParent(header string) (TraceContext, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || !isHex(parts[0], 2) || parts[0] == "ff" || !isHex(parts[3], 2) ||
+		!isHex(parts[1], 32) || !isHex(parts[2], 16) ||
+		parts[1] == strings.Repeat("0", 32) || parts[2] == strings.Repeat("0", 16) {
+		return TraceContext{}, false
+	}
+	return TraceContext{TraceID: parts[1], SpanID: parts[2]}, true
+}
+
+// TraceParent formats the W3C traceparent header of the trace context,
+// sampled.
+//
+// Returns:
+//   - string: The header value, or "" if the trace or span ID is missing.
+func (tc TraceContext) TraceParent() string {
+	if tc.TraceID == "" || tc.SpanID == "" {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", tc.TraceID, tc.SpanID)
+}
+
+// randomHex returns n random bytes in hexadecimal.
+//
+// Parameters:
+//   - n: The number of bytes.
+//
+// Returns:
+//   - string: The 2*n hex digits.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// isHex reports whether s has the given length and only lowercase hex digits.
+//
+// Parameters:
+//   - s: The string.
+//   - length: The expected length.
+//
+// Returns:
+//   - bool: True if s is a lowercase hex string of that length.
+func isHex(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
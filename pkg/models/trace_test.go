@@ -0,0 +1,48 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseTraceParent tests the parsing of valid and invalid traceparent headers.
+func TestParseTraceParent(t *testing.T) {
+	tc, ok := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if !ok || tc.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || tc.SpanID != "00f067aa0ba902b7" {
+		t.Errorf("Unexpected trace context %+v, %v", tc, ok)
+	}
+
+	invalid := []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6-00f067aa0ba902b7-01",
+	}
+	for _, header := range invalid {
+		if _, ok := ParseTraceParent(header); ok {
+			t.Errorf("Expected %q to be rejected", header)
+		}
+	}
+}
+
+// TestTraceParentRoundTrip tests that a new trace context survives its header.
+func TestTraceParentRoundTrip(t *testing.T) {
+	tc := NewTraceContext("corr-1")
+	header := tc.TraceParent()
+	if !strings.HasSuffix(header, "-01") {
+		t.Errorf("Expected a sampled traceparent, got %q", header)
+	}
+	parsed, ok := ParseTraceParent(header)
+	if !ok || parsed.TraceID != tc.TraceID || parsed.SpanID != tc.SpanID {
+		t.Errorf("Expected %+v, got %+v (%v)", tc, parsed, ok)
+	}
+	if NewTraceContext("").TraceID == tc.TraceID {
+		t.Error("Expected a new trace ID for each trace")
+	}
+	if (TraceContext{CorrelationID: "corr-1"}).TraceParent() != "" {
+		t.Error("Expected no traceparent without trace ID")
+	}
+}
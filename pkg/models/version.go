@@ -0,0 +1,144 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SchemaVersion identifies the version of the order schema, carried by
+// Metadata.Version.
+type SchemaVersion string
+
+const (
+	// SchemaV1_0 is the first order schema. Items carry their unit price
+	// only: total_price and subtotal are absent.
+	SchemaV1_0 SchemaVersion = "1.0"
+	// SchemaV1_1 adds the derived amounts: the total price of each item and
+	// the order subtotal.
+	SchemaV1_1 SchemaVersion = "1.1"
+	// CurrentSchemaVersion is the version written by the producer and
+	// returned by DecodeOrder.
+	CurrentSchemaVersion = SchemaV1_1
+)
+
+// ErrUnsupportedSchemaVersion is returned for an order whose schema version
+// is unknown, e.g. written by a newer producer.
+var ErrUnsupportedSchemaVersion = newCodedError("ERR_SCHEMA_VERSION_UNSUPPORTED", "unsupported order schema version")
+
+// ErrSchemaMismatch is returned by DecodeOrderStrict for an order carrying a
+// field unknown to the schema, e.g. added or renamed by a producer.
+var ErrSchemaMismatch = newCodedError("ERR_SCHEMA_MISMATCH", "order does not match the schema")
+
+// upgrade converts an order of one schema version to the next one.
+type upgrade struct {
+	next SchemaVersion
+	fn   func(*Order)
+}
+
+// upgrades maps each older schema version to the upgrade to its successor.
+var upgrades = map[SchemaVersion]upgrade{
+	SchemaV1_0: {next: SchemaV1_1, fn: upgradeV1_0},
+}
+
+// UpgradeOrder converts an order to CurrentSchemaVersion by applying the
+// upgrades one version at a time. An order without version is a v1.0 order.
+//
+// Parameters:
+//   - o: The order to upgrade in place.
+//
+// Returns:
+//   - error: An error wrapping ErrUnsupportedSchemaVersion if the version is unknown.
+func UpgradeOrder(o *Order) error {
+	version := SchemaVersion(o.Metadata.Version)
+	if version == "" {
+		version = SchemaV1_0
+	}
+	for version != CurrentSchemaVersion {
+		up, ok := upgrades[version]
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrUnsupportedSchemaVersion, version)
+		}
+		up.fn(o)
+		version = up.next
+	}
+	o.Metadata.Version = string(version)
+	return nil
+}
+
+// DecodeOrder decodes an order of any supported schema version and upgrades
+// it to CurrentSchemaVersion.
+//
+// Parameters:
+//   - raw: The JSON order.
+//
+// Returns:
+//   - Order: The order, in the current schema.
+//   - error: A JSON decoding error, or an error wrapping ErrUnsupportedSchemaVersion.
+func DecodeOrder(raw []byte) (Order, error) {
+	return decodeOrder(raw, false)
+}
+
+// DecodeOrderStrict decodes an order like DecodeOrder, but rejects the
+// fields unknown to the schema instead of ignoring them.
+//
+// Parameters:
+//   - raw: The JSON order.
+//
+// Returns:
+//   - Order: The order, in the current schema.
+//   - error: A JSON decoding error, or an error wrapping ErrSchemaMismatch or ErrUnsupportedSchemaVersion.
+func DecodeOrderStrict(raw []byte) (Order, error) {
+	return decodeOrder(raw, true)
+}
+
+// decodeOrder decodes and upgrades an order.
+//
+// Parameters:
+//   - raw: The JSON order.
+//   - strict: Whether unknown fields are rejected.
+//
+// Returns:
+//   - Order: The order, in the current schema.
+//   - error: A JSON decoding error, or an error wrapping ErrSchemaMismatch or ErrUnsupportedSchemaVersion.
+func decodeOrder(raw []byte, strict bool) (Order, error) {
+	var order Order
+	if err := json.Unmarshal(raw, &order); err != nil {
+		return Order{}, err
+	}
+	if strict {
+		// The payload is valid JSON: the second pass only fails on unknown
+		// fields, for which encoding/json has no error type.
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&Order{}); err != nil {
+			return Order{}, fmt.Errorf("%w: %s", ErrSchemaMismatch, strings.TrimPrefix(err.Error(), "json: "))
+		}
+	}
+	order.bindCurrency()
+	if err := UpgradeOrder(&order); err != nil {
+		return Order{}, err
+	}
+	return order, nil
+}
+
+// upgradeV1_0 computes the amounts added by v1.1: the total price of each
+// item and the subtotal.
+//
+// Parameters:
+//   - o: The v1.0 order.
+func upgradeV1_0(o *Order) {
+	totals := make([]Money, len(o.Items))
+	for i := range o.Items {
+		item := &o.Items[i]
+		if item.TotalPrice.IsZero() {
+			item.TotalPrice = item.UnitPrice.Mul(item.Quantity)
+		}
+		totals[i] = item.TotalPrice
+	}
+	// On a currency mismatch, the subtotal is left to Validate.
+	if subtotal, err := Sum(totals...); err == nil && o.SubTotal.IsZero() {
+		o.SubTotal = subtotal
+	}
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestDecodeOrderV1_0 tests that a v1.0 order, without item totals nor
+// subtotal, is upgraded to the current schema.
+func TestDecodeOrderV1_0(t *testing.T) {
+	raw := []byte(`{
+		"order_id": "order-123", "sequence": 1, "status": "pending",
+		"customer_info": {"customer_id": "cust-001", "name": "John Doe"},
+		"items": [
+			{"item_id": "item-001", "item_name": "Espresso", "quantity": 2, "unit_price": 3.50},
+			{"item_id": "item-002", "item_name": "Cappuccino", "quantity": 1, "unit_price": 4.00}
+		],
+		"tax": 2.20, "shipping_fee": 2.50, "total": 15.70, "currency": "EUR",
+		"metadata": {"version": "1.0"}
+	}`)
+
+	order, err := DecodeOrder(raw)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if order.Metadata.Version != string(CurrentSchemaVersion) {
+		t.Errorf("Expected version %s, got %s", CurrentSchemaVersion, order.Metadata.Version)
+	}
+	if order.Items[0].TotalPrice.Cents() != 700 || order.Items[1].TotalPrice.Cents() != 400 {
+		t.Errorf("Expected item totals 700 and 400, got %s and %s", order.Items[0].TotalPrice, order.Items[1].TotalPrice)
+	}
+	if order.SubTotal.Cents() != 1100 {
+		t.Errorf("Expected subtotal 1100, got %s", order.SubTotal)
+	}
+	if err := order.Validate(); err != nil {
+		t.Errorf("Expected the upgraded order to be valid, got %v", err)
+	}
+}
+
+// TestDecodeOrderVersions tests the dispatch on Metadata.Version.
+func TestDecodeOrderVersions(t *testing.T) {
+	unversioned, err := DecodeOrder([]byte(`{"items": [{"quantity": 3, "unit_price": 1.10}]}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if unversioned.SubTotal.Cents() != 330 || unversioned.Metadata.Version != string(CurrentSchemaVersion) {
+		t.Errorf("Expected an unversioned order to be upgraded as v1.0, got %+v", unversioned)
+	}
+
+	current, err := DecodeOrder([]byte(`{"items": [{"quantity": 3, "unit_price": 1.10, "total_price": 3.00}], "metadata": {"version": "1.1"}}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if current.SubTotal.Cents() != 0 {
+		t.Errorf("Expected a current order not to be modified, got subtotal %s", current.SubTotal)
+	}
+
+	_, err = DecodeOrder([]byte(`{"metadata": {"version": "2.0"}}`))
+	if !errors.Is(err, ErrUnsupportedSchemaVersion) || !IsValidationError(err) {
+		t.Errorf("Expected ErrUnsupportedSchemaVersion, got %v", err)
+	}
+
+	if _, err := DecodeOrder([]byte(`{"sequence": "one"}`)); err == nil {
+		t.Error("Expected a JSON decoding error")
+	}
+}
+
+// TestDecodeOrderStrict tests that the strict decoding rejects unknown
+// fields, at any depth, that DecodeOrder ignores.
+func TestDecodeOrderStrict(t *testing.T) {
+	valid := []byte(`{"order_id": "order-123", "items": [{"quantity": 1, "unit_price": 1.10}], "metadata": {"version": "1.0"}}`)
+	order, err := DecodeOrderStrict(valid)
+	if err != nil || order.Items[0].TotalPrice.Cents() != 110 {
+		t.Errorf("Expected a known-field order to be decoded and upgraded, got %+v, %v", order, err)
+	}
+
+	for _, raw := range []string{
+		`{"order_id": "order-123", "priority": "high"}`,
+		`{"order_id": "order-123", "items": [{"qty": 1}]}`,
+		`{"order_id": "order-123", "customer_info": {"mail": "john@example.com"}}`,
+	} {
+		if _, err := DecodeOrder([]byte(raw)); err != nil {
+			t.Errorf("Expected DecodeOrder to ignore the unknown field of %s, got %v", raw, err)
+		}
+		_, err := DecodeOrderStrict([]byte(raw))
+		if !errors.Is(err, ErrSchemaMismatch) || !IsValidationError(err) {
+			t.Errorf("Expected ErrSchemaMismatch for %s, got %v", raw, err)
+		}
+	}
+
+	if _, err := DecodeOrderStrict([]byte(`{"order_id"`)); err == nil || errors.Is(err, ErrSchemaMismatch) {
+		t.Errorf("Expected a JSON syntax error, got %v", err)
+	}
+}
@@ -0,0 +1,264 @@
+// Package kafkadriver is the Apache Kafka driver of the transport package,
+// built on confluent-kafka-go (librdkafka). It is the default driver of the
+// producer and the tracker (kafka.broker host:9092,...).
+package kafkadriver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/transport"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// eventsBufferSize is the capacity of the delivery report channel of a Producer.
+const eventsBufferSize = 10000
+
+// Producer publishes messages to Kafka. The delivery reports of librdkafka
+// are forwarded to the reports channel given to each Publish call.
+type Producer struct {
+	producer *kafka.Producer
+	events   chan kafka.Event // Delivery reports of every published message.
+	done     chan struct{}    // Closed by Close, stops the forwarding goroutine.
+}
+
+// NewProducer creates a Kafka producer.
+//
+// Parameters:
+//   - brokers: The comma-separated bootstrap broker addresses.
+//   - properties: Additional librdkafka properties (may be nil).
+//
+// Returns:
+//   - *Producer: The producer.
+//   - error: An error if the configuration is invalid.
+func NewProducer(brokers string, properties map[string]string) (*Producer, error) {
+	configMap := kafka.ConfigMap{"bootstrap.servers": brokers}
+	for name, value := range properties {
+		configMap[name] = value
+	}
+	producer, err := kafka.NewProducer(&configMap)
+	if err != nil {
+		return nil, err
+	}
+	p := &Producer{
+		producer: producer,
+		events:   make(chan kafka.Event, eventsBufferSize),
+		done:     make(chan struct{}),
+	}
+	go p.forward()
+	return p, nil
+}
+
+// Publish queues a message in librdkafka.
+//
+// Parameters:
+//   - msg: The message; its topic is mandatory.
+//   - reports: The channel receiving the delivery report (nil: no report).
+//
+// Returns:
+//   - error: An error if the message cannot be queued (kafka.Error).
+func (p *Producer) Publish(msg *transport.Message, reports chan<- transport.Delivery) error {
+	if msg.Topic == "" {
+		return errors.New("kafka: message without topic")
+	}
+	km := ToKafka(msg)
+	if reports != nil {
+		km.Opaque = reports
+	}
+	return p.producer.Produce(km, p.events)
+}
+
+// forward sends the delivery reports of librdkafka to the channels of their
+// Publish calls, until Close is called.
+func (p *Producer) forward() {
+	for {
+		select {
+		case e := <-p.events:
+			m, ok := e.(*kafka.Message)
+			if !ok {
+				continue
+			}
+			if reports, ok := m.Opaque.(chan<- transport.Delivery); ok {
+				reports <- transport.Delivery{Message: FromKafka(m), Err: m.TopicPartition.Error}
+			}
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// Flush waits for the delivery of the queued messages.
+//
+// Parameters:
+//   - timeout: The maximum wait time.
+//
+// Returns:
+//   - int: The number of messages still undelivered.
+func (p *Producer) Flush(timeout time.Duration) int {
+	return p.producer.Flush(int(timeout / time.Millisecond))
+}
+
+// Close closes the producer; the messages not yet delivered are lost.
+func (p *Producer) Close() {
+	p.producer.Close()
+	close(p.done)
+}
+
+// Consumer reads the messages of its topics as a member of a Kafka consumer group.
+type Consumer struct {
+	consumer *kafka.Consumer
+}
+
+// NewConsumer creates a Kafka consumer.
+//
+// Parameters:
+//   - brokers: The comma-separated bootstrap broker addresses.
+//   - group: The consumer group ID.
+//   - properties: Additional librdkafka properties (may be nil).
+//
+// Returns:
+//   - *Consumer: The consumer.
+//   - error: An error if the configuration is invalid.
+func NewConsumer(brokers, group string, properties map[string]string) (*Consumer, error) {
+	configMap := kafka.ConfigMap{
+		"bootstrap.servers": brokers,
+		"group.id":          group,
+	}
+	for name, value := range properties {
+		configMap[name] = value
+	}
+	consumer, err := kafka.NewConsumer(&configMap)
+	if err != nil {
+		return nil, err
+	}
+	return &Consumer{consumer: consumer}, nil
+}
+
+// Subscribe subscribes to topics, replacing the previous subscription.
+//
+// Parameters:
+//   - topics: The topics.
+//
+// Returns:
+//   - error: An error if the subscription fails.
+func (c *Consumer) Subscribe(topics []string) error {
+	return c.consumer.SubscribeTopics(topics, nil)
+}
+
+// Receive returns the next message, waiting for one at most timeout.
+//
+// Parameters:
+//   - timeout: The maximum wait time.
+//
+// Returns:
+//   - *transport.Message: The message.
+//   - error: transport.ErrTimeout if no message arrived in time, an error
+//     wrapping transport.ErrUnavailable if no broker can be reached, or the
+//     kafka.Error.
+func (c *Consumer) Receive(timeout time.Duration) (*transport.Message, error) {
+	msg, err := c.consumer.ReadMessage(timeout)
+	if err != nil {
+		return nil, readError(err)
+	}
+	return FromKafka(msg), nil
+}
+
+// Commit stores the offset following a processed message, committed with the
+// next automatic commit. It is only needed with enable.auto.offset.store=false.
+//
+// Parameters:
+//   - msg: The processed message.
+//
+// Returns:
+//   - error: An error if the offset cannot be stored.
+func (c *Consumer) Commit(msg *transport.Message) error {
+	_, err := c.consumer.StoreMessage(ToKafka(msg))
+	return err
+}
+
+// Close leaves the consumer group and closes the consumer.
+//
+// Returns:
+//   - error: An error if closing fails.
+func (c *Consumer) Close() error {
+	return c.consumer.Close()
+}
+
+// readError maps a read error of librdkafka to the transport errors.
+//
+// Parameters:
+//   - err: The read error.
+//
+// Returns:
+//   - error: transport.ErrTimeout, an error wrapping transport.ErrUnavailable,
+//     or err itself.
+func readError(err error) error {
+	var kafkaErr kafka.Error
+	if !errors.As(err, &kafkaErr) {
+		return err
+	}
+	if kafkaErr.Code() == kafka.ErrTimedOut {
+		return transport.ErrTimeout
+	}
+	text := err.Error()
+	if kafkaErr.Code() == kafka.ErrAllBrokersDown ||
+		strings.Contains(text, "brokers are down") ||
+		strings.Contains(text, "Connection refused") {
+		return fmt.Errorf("%w: %w", transport.ErrUnavailable, err)
+	}
+	return err
+}
+
+// ToKafka converts a message to a Kafka message.
+//
+// Parameters:
+//   - msg: The message (transport.AnyPartition: partition chosen by librdkafka).
+//
+// Returns:
+//   - *kafka.Message: The Kafka message.
+func ToKafka(msg *transport.Message) *kafka.Message {
+	topic := msg.Topic
+	partition := msg.Partition
+	if partition == transport.AnyPartition {
+		partition = kafka.PartitionAny
+	}
+	km := &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: partition, Offset: kafka.Offset(msg.Offset)},
+		Key:            msg.Key,
+		Value:          msg.Value,
+		Timestamp:      msg.Timestamp,
+	}
+	for _, h := range msg.Headers {
+		km.Headers = append(km.Headers, kafka.Header{Key: h.Key, Value: h.Value})
+	}
+	return km
+}
+
+// FromKafka converts a Kafka message to a message.
+//
+// Parameters:
+//   - km: The Kafka message.
+//
+// Returns:
+//   - *transport.Message: The message.
+func FromKafka(km *kafka.Message) *transport.Message {
+	msg := &transport.Message{
+		Partition: km.TopicPartition.Partition,
+		Offset:    int64(km.TopicPartition.Offset),
+		Key:       km.Key,
+		Value:     km.Value,
+		Timestamp: km.Timestamp,
+	}
+	if km.TopicPartition.Topic != nil {
+		msg.Topic = *km.TopicPartition.Topic
+	}
+	if len(km.Headers) > 0 {
+		msg.Headers = make([]transport.Header, len(km.Headers))
+		for i, h := range km.Headers {
+			msg.Headers[i] = transport.Header{Key: h.Key, Value: h.Value}
+		}
+	}
+	return msg
+}
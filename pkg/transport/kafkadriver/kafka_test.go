@@ -0,0 +1,69 @@
+package kafkadriver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/transport"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+var (
+	_ transport.Publisher  = (*Producer)(nil)
+	_ transport.Subscriber = (*Consumer)(nil)
+	_ transport.Committer  = (*Consumer)(nil)
+)
+
+func TestMessageRoundTrip(t *testing.T) {
+	msg := &transport.Message{
+		Topic:     "orders",
+		Partition: 2,
+		Offset:    42,
+		Key:       []byte("customer-1"),
+		Value:     []byte(`{"order_id":"o-1"}`),
+		Headers:   []transport.Header{{Key: "traceparent", Value: []byte("00-abc-def-01")}},
+		Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	km := ToKafka(msg)
+	if *km.TopicPartition.Topic != "orders" || km.TopicPartition.Partition != 2 || km.TopicPartition.Offset != 42 {
+		t.Errorf("Unexpected topic partition %v", km.TopicPartition)
+	}
+	if got := FromKafka(km); !reflect.DeepEqual(got, msg) {
+		t.Errorf("Expected %+v, got %+v", msg, got)
+	}
+}
+
+func TestToKafkaAnyPartition(t *testing.T) {
+	km := ToKafka(&transport.Message{Topic: "orders", Partition: transport.AnyPartition})
+	if km.TopicPartition.Partition != kafka.PartitionAny {
+		t.Errorf("Expected kafka.PartitionAny, got %d", km.TopicPartition.Partition)
+	}
+	if km.Headers != nil {
+		t.Errorf("Expected no headers, got %v", km.Headers)
+	}
+}
+
+func TestReadError(t *testing.T) {
+	if err := readError(kafka.NewError(kafka.ErrTimedOut, "timed out", false)); err != transport.ErrTimeout {
+		t.Errorf("Expected transport.ErrTimeout, got %v", err)
+	}
+	for _, err := range []error{
+		kafka.NewError(kafka.ErrAllBrokersDown, "down", false),
+		kafka.NewError(kafka.ErrTransport, "1/1 brokers are down", false),
+		kafka.NewError(kafka.ErrTransport, "Connection refused", false),
+	} {
+		if got := readError(err); !errors.Is(got, transport.ErrUnavailable) {
+			t.Errorf("Expected %v to wrap transport.ErrUnavailable, got %v", err, got)
+		}
+	}
+	other := kafka.NewError(kafka.ErrUnknownTopic, "unknown topic", false)
+	if got := readError(other); got != other {
+		t.Errorf("Expected the Kafka error unchanged, got %v", got)
+	}
+	generic := errors.New("boom")
+	if got := readError(generic); got != generic {
+		t.Errorf("Expected the generic error unchanged, got %v", got)
+	}
+}
@@ -0,0 +1,333 @@
+// Package memory is the in-process driver of the transport package: a pub/sub
+// broker implementing transport.Publisher and transport.Subscriber, so that
+// the producer and the tracker can run without any external dependency
+// (kafka.broker memory://).
+//
+// Topics are created on first use and keep every message in memory.
+// Consumers of the same group share the partitions of their topics and the
+// group position, which advances as messages are read (there is no separate
+// commit). The broker only connects clients of the same process.
+package memory
+
+import (
+	"errors"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/transport"
+)
+
+// DefaultPartitions is the number of partitions of the topics created by the
+// default broker.
+const DefaultPartitions = 1
+
+// Broker is an in-process broker. It is safe for concurrent use.
+type Broker struct {
+	partitions int // Partitions of each new topic.
+
+	mu      sync.Mutex
+	topics  map[string][][]*transport.Message // Messages by topic and partition.
+	groups  map[string]*group                 // Consumer groups by ID.
+	next    map[string]int32                  // Next partition of the unkeyed messages, by topic.
+	changed chan struct{}                     // Closed (and replaced) on every new message or group change.
+}
+
+// group is a consumer group: its members, in join order, and its position in
+// every partition.
+type group struct {
+	members   []*Consumer
+	positions map[string][]int64 // Next offset by topic and partition.
+}
+
+// defaultBroker is the broker shared by the clients of the process.
+var defaultBroker = NewBroker(DefaultPartitions)
+
+// Default returns the broker shared by the clients of the process.
+//
+// Returns:
+//   - *Broker: The broker.
+func Default() *Broker {
+	return defaultBroker
+}
+
+// NewBroker creates an empty broker.
+//
+// Parameters:
+//   - partitions: The number of partitions of each topic (at least 1).
+//
+// Returns:
+//   - *Broker: The broker.
+func NewBroker(partitions int) *Broker {
+	if partitions < 1 {
+		partitions = 1
+	}
+	return &Broker{
+		partitions: partitions,
+		topics:     make(map[string][][]*transport.Message),
+		groups:     make(map[string]*group),
+		next:       make(map[string]int32),
+		changed:    make(chan struct{}),
+	}
+}
+
+// NewProducer creates a producer publishing to the broker.
+//
+// Returns:
+//   - *Producer: The producer.
+func (b *Broker) NewProducer() *Producer {
+	return &Producer{broker: b}
+}
+
+// NewConsumer creates a consumer, which joins its group when it subscribes.
+//
+// Parameters:
+//   - groupID: The consumer group.
+//
+// Returns:
+//   - *Consumer: The consumer.
+func (b *Broker) NewConsumer(groupID string) *Consumer {
+	return &Consumer{broker: b, groupID: groupID}
+}
+
+// Messages returns a copy of the messages of a topic partition.
+//
+// Parameters:
+//   - topic: The topic.
+//   - partition: The partition.
+//
+// Returns:
+//   - []*transport.Message: The messages, in offset order (nil if the partition does not exist).
+func (b *Broker) Messages(topic string, partition int32) []*transport.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	partitions := b.topics[topic]
+	if int(partition) >= len(partitions) || partition < 0 {
+		return nil
+	}
+	return append([]*transport.Message(nil), partitions[partition]...)
+}
+
+// topic returns the partitions of a topic, creating it if needed; the caller
+// holds the lock.
+//
+// Parameters:
+//   - name: The topic.
+//
+// Returns:
+//   - [][]*transport.Message: The partitions.
+func (b *Broker) topic(name string) [][]*transport.Message {
+	partitions, ok := b.topics[name]
+	if !ok {
+		partitions = make([][]*transport.Message, b.partitions)
+		b.topics[name] = partitions
+	}
+	return partitions
+}
+
+// publish appends a message to its partition; the caller holds the lock.
+//
+// Parameters:
+//   - msg: The message; its topic must be set.
+//
+// Returns:
+//   - *transport.Message: The stored copy, with its partition, offset and timestamp.
+func (b *Broker) publish(msg *transport.Message) *transport.Message {
+	partitions := b.topic(msg.Topic)
+	partition := msg.Partition
+	switch {
+	case partition >= 0 && int(partition) < len(partitions):
+	case len(msg.Key) > 0:
+		h := fnv.New32a()
+		h.Write(msg.Key)
+		partition = int32(h.Sum32() % uint32(len(partitions)))
+	default:
+		partition = b.next[msg.Topic]
+		b.next[msg.Topic] = (partition + 1) % int32(len(partitions))
+	}
+
+	stored := *msg
+	stored.Partition = partition
+	stored.Offset = int64(len(partitions[partition]))
+	if stored.Timestamp.IsZero() {
+		stored.Timestamp = time.Now()
+	}
+	partitions[partition] = append(partitions[partition], &stored)
+	b.notify()
+	return &stored
+}
+
+// notify wakes the consumers waiting for a message; the caller holds the lock.
+func (b *Broker) notify() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+// Producer publishes messages to a Broker. Messages are stored when Publish
+// returns: there is never anything to flush.
+type Producer struct {
+	broker *Broker
+}
+
+// Publish stores a message and sends its delivery report.
+//
+// Parameters:
+//   - msg: The message; AnyPartition selects the partition by key hash, else round-robin.
+//   - reports: The channel receiving the delivery report of the stored message (nil: no report).
+//
+// Returns:
+//   - error: An error if the message has no topic.
+func (p *Producer) Publish(msg *transport.Message, reports chan<- transport.Delivery) error {
+	if msg.Topic == "" {
+		return errors.New("memory: message without topic")
+	}
+	p.broker.mu.Lock()
+	stored := p.broker.publish(msg)
+	p.broker.mu.Unlock()
+	if reports != nil {
+		reports <- transport.Delivery{Message: stored}
+	}
+	return nil
+}
+
+// Flush returns immediately: messages are stored by Publish.
+//
+// Parameters:
+//   - timeout: The maximum wait time (unused).
+//
+// Returns:
+//   - int: 0.
+func (p *Producer) Flush(timeout time.Duration) int {
+	return 0
+}
+
+// Close releases nothing: the broker outlives its producers.
+func (p *Producer) Close() {}
+
+// Consumer reads the messages of its subscribed topics from a Broker, sharing
+// the partitions with the other members of its group.
+type Consumer struct {
+	broker  *Broker
+	groupID string
+	topics  []string
+	joined  bool
+}
+
+// Subscribe joins the consumer group and subscribes to topics, replacing the
+// previous subscription. Partitions are reassigned on every group change.
+//
+// Parameters:
+//   - topics: The topics.
+//
+// Returns:
+//   - error: Always nil.
+func (c *Consumer) Subscribe(topics []string) error {
+	b := c.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c.topics = append([]string(nil), topics...)
+	for _, name := range topics {
+		b.topic(name)
+	}
+	if !c.joined {
+		g := b.groups[c.groupID]
+		if g == nil {
+			g = &group{positions: make(map[string][]int64)}
+			b.groups[c.groupID] = g
+		}
+		g.members = append(g.members, c)
+		c.joined = true
+		b.notify()
+	}
+	return nil
+}
+
+// Receive returns the next message of the partitions assigned to the
+// consumer, waiting for one at most timeout.
+//
+// Parameters:
+//   - timeout: The maximum wait time.
+//
+// Returns:
+//   - *transport.Message: The message.
+//   - error: transport.ErrTimeout if no message arrived in time.
+func (c *Consumer) Receive(timeout time.Duration) (*transport.Message, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	b := c.broker
+	for {
+		b.mu.Lock()
+		msg := c.next()
+		changed := b.changed
+		b.mu.Unlock()
+		if msg != nil {
+			return msg, nil
+		}
+		select {
+		case <-changed:
+		case <-timer.C:
+			return nil, transport.ErrTimeout
+		}
+	}
+}
+
+// next returns and consumes the next message of the assigned partitions; the
+// caller holds the lock.
+//
+// Returns:
+//   - *transport.Message: The message (nil if none is available).
+func (c *Consumer) next() *transport.Message {
+	g := c.broker.groups[c.groupID]
+	if g == nil {
+		return nil
+	}
+	member, members := -1, len(g.members)
+	for i, m := range g.members {
+		if m == c {
+			member = i
+		}
+	}
+	if member < 0 {
+		return nil
+	}
+	for _, name := range c.topics {
+		partitions := c.broker.topics[name]
+		positions := g.positions[name]
+		if len(positions) < len(partitions) {
+			positions = append(positions, make([]int64, len(partitions)-len(positions))...)
+			g.positions[name] = positions
+		}
+		for p := member; p < len(partitions); p += members {
+			if positions[p] < int64(len(partitions[p])) {
+				msg := partitions[p][positions[p]]
+				positions[p]++
+				return msg
+			}
+		}
+	}
+	return nil
+}
+
+// Close leaves the consumer group; its partitions are reassigned to the
+// remaining members.
+//
+// Returns:
+//   - error: Always nil.
+func (c *Consumer) Close() error {
+	b := c.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !c.joined {
+		return nil
+	}
+	g := b.groups[c.groupID]
+	for i, m := range g.members {
+		if m == c {
+			g.members = append(g.members[:i], g.members[i+1:]...)
+			break
+		}
+	}
+	c.joined = false
+	b.notify()
+	return nil
+}
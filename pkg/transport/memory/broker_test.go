@@ -0,0 +1,142 @@
+package memory_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agbruneau/PubSub/pkg/transport"
+	"github.com/agbruneau/PubSub/pkg/transport/memory"
+)
+
+var (
+	_ transport.Publisher  = (*memory.Producer)(nil)
+	_ transport.Subscriber = (*memory.Consumer)(nil)
+)
+
+// message returns a message for a topic, with an optional key.
+func message(topic, key, value string) *transport.Message {
+	msg := &transport.Message{Topic: topic, Partition: transport.AnyPartition, Value: []byte(value)}
+	if key != "" {
+		msg.Key = []byte(key)
+	}
+	return msg
+}
+
+func TestPublishAndReceive(t *testing.T) {
+	b := memory.NewBroker(1)
+	p := b.NewProducer()
+	reports := make(chan transport.Delivery, 2)
+	for _, v := range []string{"a", "b"} {
+		if err := p.Publish(message("orders", "", v), reports); err != nil {
+			t.Fatalf("Unexpected publish error: %v", err)
+		}
+	}
+	for want := int64(0); want < 2; want++ {
+		d := <-reports
+		if d.Err != nil || d.Message.Topic != "orders" || d.Message.Partition != 0 || d.Message.Offset != want {
+			t.Errorf("Unexpected delivery report %+v", d)
+		}
+		if d.Message.Timestamp.IsZero() {
+			t.Error("Expected the stored message to be timestamped")
+		}
+	}
+
+	c := b.NewConsumer("tracker")
+	defer c.Close()
+	if err := c.Subscribe([]string{"orders"}); err != nil {
+		t.Fatalf("Unexpected subscribe error: %v", err)
+	}
+	for _, want := range []string{"a", "b"} {
+		m, err := c.Receive(time.Second)
+		if err != nil || string(m.Value) != want {
+			t.Fatalf("Expected message %q, got %v (%v)", want, m, err)
+		}
+	}
+	if _, err := c.Receive(10 * time.Millisecond); !errors.Is(err, transport.ErrTimeout) {
+		t.Errorf("Expected a timeout error, got %v", err)
+	}
+}
+
+func TestPublishWithoutTopic(t *testing.T) {
+	if err := memory.NewBroker(1).NewProducer().Publish(&transport.Message{}, nil); err == nil {
+		t.Error("Expected an error for a message without topic")
+	}
+}
+
+func TestKeyedMessagesKeepTheirPartition(t *testing.T) {
+	b := memory.NewBroker(4)
+	p := b.NewProducer()
+	reports := make(chan transport.Delivery, 3)
+	for i := 0; i < 3; i++ {
+		p.Publish(message("orders", "customer-1", "v"), reports)
+	}
+	first := (<-reports).Message.Partition
+	for i := 0; i < 2; i++ {
+		if got := (<-reports).Message.Partition; got != first {
+			t.Errorf("Expected partition %d for the same key, got %d", first, got)
+		}
+	}
+	if got := len(b.Messages("orders", first)); got != 3 {
+		t.Errorf("Expected 3 messages in partition %d, got %d", first, got)
+	}
+}
+
+func TestReceiveWaitsForPublish(t *testing.T) {
+	b := memory.NewBroker(1)
+	c := b.NewConsumer("tracker")
+	c.Subscribe([]string{"orders"})
+	time.AfterFunc(20*time.Millisecond, func() { b.NewProducer().Publish(message("orders", "", "late"), nil) })
+
+	m, err := c.Receive(time.Second)
+	if err != nil || string(m.Value) != "late" {
+		t.Errorf("Expected the late message, got %v (%v)", m, err)
+	}
+}
+
+func TestConsumerGroupSharesPartitions(t *testing.T) {
+	b := memory.NewBroker(2)
+	p := b.NewProducer()
+	for i := 0; i < 4; i++ {
+		p.Publish(message("orders", "", "v"), nil)
+	}
+
+	first, second := b.NewConsumer("tracker"), b.NewConsumer("tracker")
+	first.Subscribe([]string{"orders"})
+	second.Subscribe([]string{"orders"})
+	for _, c := range []*memory.Consumer{first, second} {
+		partition := int32(-1)
+		for i := 0; i < 2; i++ {
+			m, err := c.Receive(time.Second)
+			if err != nil {
+				t.Fatalf("Unexpected receive error: %v", err)
+			}
+			if partition >= 0 && m.Partition != partition {
+				t.Errorf("Expected a single partition per member, got %d and %d", partition, m.Partition)
+			}
+			partition = m.Partition
+		}
+	}
+
+	// The partitions of a departed member are reassigned, from the group position.
+	second.Close()
+	p.Publish(message("orders", "", "v"), nil)
+	p.Publish(message("orders", "", "v"), nil)
+	for i := 0; i < 2; i++ {
+		if _, err := first.Receive(time.Second); err != nil {
+			t.Fatalf("Expected the remaining member to read every partition: %v", err)
+		}
+	}
+	if _, err := first.Receive(10 * time.Millisecond); err == nil {
+		t.Error("Expected no message left for the group")
+	}
+
+	// Another group reads the topic from the beginning.
+	other := b.NewConsumer("audit")
+	other.Subscribe([]string{"orders"})
+	for i := 0; i < 6; i++ {
+		if _, err := other.Receive(time.Second); err != nil {
+			t.Fatalf("Expected 6 messages for another group, got %d: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Package transport defines the broker-agnostic messaging layer of the PubSub
+system: the Message exchanged by the services, and the Publisher and
+Subscriber interfaces implemented by each broker driver.
+
+The drivers live in the sub-packages: kafkadriver (Apache Kafka, through
+confluent-kafka-go), memory (in-process broker) and rabbitmq (AMQP 0-9-1).
+The producer and the tracker only depend on this package, so that their
+tests mock a Publisher or a Subscriber without any Kafka type.
+*/
+package transport
+
+import (
+	"errors"
+	"time"
+)
+
+// AnyPartition lets the driver choose the partition of a published message
+// (by key hash, else round-robin).
+const AnyPartition int32 = -1
+
+var (
+	// ErrTimeout is returned by Subscriber.Receive when no message arrived
+	// before the timeout. It is not a failure.
+	ErrTimeout = errors.New("transport: no message before the timeout")
+
+	// ErrUnavailable is wrapped by the errors of a broker that cannot be reached.
+	ErrUnavailable = errors.New("transport: broker unavailable")
+)
+
+// Header is a message header.
+type Header struct {
+	Key   string // Header name (e.g., traceparent).
+	Value []byte // Header value.
+}
+
+// Message is a message published to or received from a topic.
+type Message struct {
+	Topic     string    // Topic of the message.
+	Partition int32     // Partition (AnyPartition when publishing: chosen by the driver).
+	Offset    int64     // Position in the partition, set by the broker (0 when publishing).
+	Key       []byte    // Key selecting the partition (nil: none).
+	Value     []byte    // Payload.
+	Headers   []Header  // Headers, in order.
+	Timestamp time.Time // Creation time (zero when publishing: set by the driver or the broker).
+}
+
+// Header returns the value of the first header with the given name.
+//
+// Parameters:
+//   - key: The header name.
+//
+// Returns:
+//   - string: The header value.
+//   - bool: True if the header is present.
+func (m *Message) Header(key string) (string, bool) {
+	for _, h := range m.Headers {
+		if h.Key == key {
+			return string(h.Value), true
+		}
+	}
+	return "", false
+}
+
+// Delivery is the delivery report of a published message.
+type Delivery struct {
+	Message *Message // The message, with the partition and offset assigned by the broker.
+	Err     error    // The delivery error (nil: delivered).
+}
+
+// Publisher publishes messages to a broker.
+type Publisher interface {
+	// Publish sends a message asynchronously.
+	//
+	// Parameters:
+	//   - msg: The message; its topic is mandatory.
+	//   - reports: The channel receiving the delivery report (nil: no report).
+	//
+	// Returns:
+	//   - error: An error if the message cannot be queued.
+	Publish(msg *Message, reports chan<- Delivery) error
+
+	// Flush waits for the delivery of the queued messages.
+	//
+	// Parameters:
+	//   - timeout: The maximum wait time.
+	//
+	// Returns:
+	//   - int: The number of messages still undelivered.
+	Flush(timeout time.Duration) int
+
+	// Close releases the connection to the broker.
+	Close()
+}
+
+// Subscriber receives the messages of its topics as a member of a consumer group.
+type Subscriber interface {
+	// Subscribe subscribes to topics, replacing the previous subscription.
+	//
+	// Parameters:
+	//   - topics: The topics.
+	//
+	// Returns:
+	//   - error: An error if the subscription fails.
+	Subscribe(topics []string) error
+
+	// Receive returns the next message, waiting for one at most timeout.
+	//
+	// Parameters:
+	//   - timeout: The maximum wait time.
+	//
+	// Returns:
+	//   - *Message: The message.
+	//   - error: ErrTimeout if no message arrived in time, an error wrapping
+	//     ErrUnavailable if the broker cannot be reached, or another error.
+	Receive(timeout time.Duration) (*Message, error)
+
+	// Close leaves the consumer group and releases the connection.
+	//
+	// Returns:
+	//   - error: An error if closing fails.
+	Close() error
+}
+
+// Committer is implemented by the subscribers whose consumed positions are
+// stored explicitly rather than on reception, such as the Kafka consumer
+// created with enable.auto.offset.store=false.
+type Committer interface {
+	// Commit stores the position following a processed message; the group
+	// resumes from it after a restart or a rebalance.
+	//
+	// Parameters:
+	//   - msg: The processed message, as returned by Receive.
+	//
+	// Returns:
+	//   - error: An error if the position cannot be stored.
+	Commit(msg *Message) error
+}